@@ -4,10 +4,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/config"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
 )
 
 var (
@@ -61,6 +64,8 @@ func main() {
 	// Initialize logger with configured log level
 	log := logrus.New()
 	log.SetFormatter(&logrus.JSONFormatter{})
+	// Adds request_id to entries logged with WithContext(ctx) during an HTTP request
+	log.AddHook(middleware.NewRequestIDHook())
 
 	level, err := logrus.ParseLevel(cfg.LogLevel)
 	if err != nil {
@@ -100,7 +105,15 @@ func main() {
 
 	// Initialize MCO client for infrastructure layer monitoring
 	mcoClient := integrations.NewMCOClient(k8sClients.DynamicClient, log)
-	log.Info("MCO client initialized for infrastructure layer monitoring")
+	mcoClient.SetPoolHistorySize(cfg.MCOPoolHistorySize)
+	stopPoolHistory := func() {}
+	if cfg.MCOPoolHistoryPollInterval > 0 {
+		stopPoolHistory = mcoClient.StartPoolHistoryRecorder(cfg.MCOPoolHistoryPollInterval)
+	}
+	log.WithFields(logrus.Fields{
+		"pool_history_size":          cfg.MCOPoolHistorySize,
+		"pool_history_poll_interval": cfg.MCOPoolHistoryPollInterval,
+	}).Info("MCO client initialized for infrastructure layer monitoring")
 
 	// Initialize deployment detector
 	deploymentDetector := detector.NewDetector(k8sClients.Clientset, log)
@@ -135,6 +148,7 @@ func main() {
 	// Apply global middleware
 	router.Use(middleware.Recovery(log))
 	router.Use(middleware.RequestLogger(log))
+	router.Use(middleware.MaxBodySize(cfg.MaxRequestBodyBytes))
 
 	// Initialize KServe proxy client if enabled (ADR-039, ADR-040)
 	kserveProxyHandler := initKServeProxy(cfg, log)
@@ -144,12 +158,16 @@ func main() {
 
 	// Initialize incident store with persistence if DATA_DIR is configured (ADR-014)
 	incidentStore := initIncidentStore(cfg, log)
+	configureIncidentEscalation(incidentStore, cfg, log)
+	stopIncidentAutoResolve := configureIncidentAutoResolve(incidentStore, cfg, log)
+	incidentWebhook := configureIncidentWebhook(incidentStore, cfg, log)
+	linkWorkflowOutcomes(orchestrator, incidentStore, log)
 
 	// Create API handlers
 	healthHandler := v1.NewHealthHandler(log, k8sClients.Clientset, rbacVerifier, cfg.MLServiceURL, Version, startTime)
 	// TODO: Add MCO health monitoring to health handler in future enhancement
-	_ = mcoClient // MCO client available for infrastructure layer operations
 	remediationHandler := v1.NewRemediationHandlerWithStore(orchestrator, incidentStore, log)
+	remediationHandler.SetPurgeTokenFile(cfg.IncidentPurge.TokenFile)
 	detectionHandler := v1.NewDetectionHandler(deploymentDetector, log)
 	coordinationHandler := v1.NewCoordinationHandler(layerDetector, multiLayerPlanner, multiLayerOrchestrator, log)
 	log.Info("Coordination handler initialized")
@@ -163,9 +181,55 @@ func main() {
 
 	// Build prediction handler config from environment-loaded FeatureEngineering settings (Issue #57)
 	predictionConfig := v1.PredictionHandlerConfig{
-		EnableFeatureEngineering: cfg.FeatureEngineering.Enabled,
-		LookbackHours:            cfg.FeatureEngineering.LookbackHours,
-		ExpectedFeatureCount:     cfg.FeatureEngineering.ExpectedFeatureCount,
+		EnableFeatureEngineering:    cfg.FeatureEngineering.Enabled,
+		LookbackHours:               cfg.FeatureEngineering.LookbackHours,
+		MaxLookbackHours:            cfg.FeatureEngineering.MaxLookbackHours,
+		ExpectedFeatureCount:        cfg.FeatureEngineering.ExpectedFeatureCount,
+		TimeFeatures:                cfg.FeatureEngineering.TimeFeatures,
+		FeatureBaseMetrics:          cfg.FeatureEngineering.BaseMetrics,
+		LogFeatureQueries:           cfg.FeatureEngineering.LogQueries,
+		LogFeatureVectorStats:       cfg.FeatureEngineering.LogVectorStats,
+		FeatureResampleRule:         cfg.FeatureEngineering.ResampleRule,
+		FeatureQueryStep:            cfg.FeatureEngineering.QueryStep,
+		FeatureClusterAggregation:   cfg.FeatureEngineering.ClusterAggregation,
+		FeatureClusterTopNamespaces: cfg.FeatureEngineering.ClusterTopNamespaces,
+		FeatureBusinessHoursStart:   cfg.FeatureEngineering.BusinessHoursStart,
+		FeatureBusinessHoursEnd:     cfg.FeatureEngineering.BusinessHoursEnd,
+		FeatureBusinessDays:         cfg.FeatureEngineering.BusinessDays,
+		FeatureWeekendDays:          cfg.FeatureEngineering.WeekendDays,
+		FeatureLagPeriods:           cfg.FeatureEngineering.LagPeriods,
+		FeatureRollingWindows:       cfg.FeatureEngineering.RollingWindows,
+		FeatureScalerFile:           cfg.FeatureEngineering.ScalerFile,
+		MaxFeatureBuildDuration:     cfg.FeatureEngineering.MaxBuildDuration,
+		FallbackPolicy:              cfg.FeatureEngineering.FallbackPolicy,
+		RegressionOutputs: v1.RegressionOutputMapping{
+			CPUIndex:    cfg.KServe.Regression.CPUIndex,
+			MemoryIndex: cfg.KServe.Regression.MemoryIndex,
+			Scale:       cfg.KServe.Regression.Scale,
+		},
+		ForecastKeys: v1.ForecastKeyMapping{
+			CPUKeys:    cfg.KServe.Forecast.CPUKeys,
+			MemoryKeys: cfg.KServe.Forecast.MemoryKeys,
+		},
+		ForecastStep:             cfg.KServe.Forecast.Step,
+		ThresholdPercent:         cfg.KServe.Forecast.ThresholdPercent,
+		ModelTimeouts:            cfg.KServe.ModelTimeouts,
+		ModelReachabilityTTL:     cfg.KServe.ReachabilityTTL,
+		CacheTTL:                 cfg.PredictionCache.TTL,
+		CacheBucket:              cfg.PredictionCache.Bucket,
+		BaselineAlpha:            cfg.PredictionBaseline.Alpha,
+		BaselineMaxEntries:       cfg.PredictionBaseline.MaxEntries,
+		MaxConcurrentPredictions: cfg.PredictionConcurrency.MaxConcurrent,
+		PredictionQueueTimeout:   cfg.PredictionConcurrency.QueueTimeout,
+		DebugRawResponse:         cfg.KServe.DebugRawResponse,
+		MetricAggregation:        cfg.PredictionMetricAggregation,
+		DegradedMode:             cfg.PredictionDegradedMode.Enabled,
+	}
+	if cfg.DataDir != "" {
+		predictionConfig.BaselineFile = filepath.Join(cfg.DataDir, v1.BaselineFileName)
+	}
+	if cfg.KServe.DebugRawResponse {
+		log.Warn("KSERVE_DEBUG_RAW_RESPONSE is enabled: prediction requests may return raw model responses; disable in production")
 	}
 
 	if kserveProxyHandler != nil {
@@ -181,6 +245,8 @@ func main() {
 			log,
 			predictionConfig,
 		)
+		kserveProxyHandler.SetFeatureDescriber(predictionHandler)
+		kserveProxyHandler.SetReachabilityReporter(predictionHandler)
 	} else {
 		recommendationsHandler = v1.NewRecommendationsHandler(
 			orchestrator,
@@ -199,10 +265,58 @@ func main() {
 	// Configure Prometheus client for real metrics if available
 	if prometheusClient != nil {
 		recommendationsHandler.SetPrometheusClient(prometheusClient)
-		log.WithField("prometheus_url", cfg.PrometheusURL).Info("Prometheus client configured for ML predictions")
+
+		// One snapshot for both handlers so bursts of predictions and recommendations share queries
+		metricsSnapshot := integrations.NewMetricsSnapshot(prometheusClient, cfg.MetricsSnapshotTTL)
+		recommendationsHandler.SetMetricsSnapshot(metricsSnapshot)
+		predictionHandler.SetMetricsSnapshot(metricsSnapshot)
+		log.WithFields(logrus.Fields{
+			"prometheus_url":       cfg.PrometheusURL,
+			"metrics_snapshot_ttl": cfg.MetricsSnapshotTTL,
+		}).Info("Prometheus client configured for ML predictions")
+	}
+	if cfg.PredictionTargetValidation.Enabled {
+		predictionHandler.SetTargetChecker(integrations.NewTargetChecker(
+			k8sClients.DynamicClient, cfg.PredictionTargetValidation.CacheTTL, log))
+		log.WithField("cache_ttl", cfg.PredictionTargetValidation.CacheTTL).Info("Prediction target validation enabled")
+	}
+	if cfg.PredictionHistory.Enabled {
+		predictionHandler.SetPredictionStore(initPredictionStore(cfg, log))
 	}
+	if cfg.PredictedIncidents.Enabled {
+		predictionHandler.SetPredictedIncidents(incidentStore, v1.PredictedIncidentPolicy{
+			CPUPercent:    cfg.PredictedIncidents.CPUThreshold,
+			MemoryPercent: cfg.PredictedIncidents.MemoryThreshold,
+			MinConfidence: cfg.PredictedIncidents.MinConfidence,
+		})
+		log.WithFields(logrus.Fields{
+			"cpu_threshold":    cfg.PredictedIncidents.CPUThreshold,
+			"memory_threshold": cfg.PredictedIncidents.MemoryThreshold,
+			"min_confidence":   cfg.PredictedIncidents.MinConfidence,
+		}).Info("Incidents opened from high-risk predictions")
+	}
+	recommendationsHandler.SetHistoricalWeighting(v1.HistoricalWeighting{
+		HalfLife:         cfg.RecommendationHistory.HalfLife,
+		MaxAge:           cfg.RecommendationHistory.MaxAge,
+		ResolvedDiscount: cfg.RecommendationHistory.ResolvedDiscount,
+	})
+	if cfg.RecommendationMCOGating {
+		recommendationsHandler.SetMCOClient(mcoClient)
+		log.Info("Recommendations deferred while MachineConfigPools update")
+	}
+	recommendationsHandler.SetAckStore(initRecommendationAckStore(cfg, log), cfg.RecommendationAckDuration)
+	recommendationsHandler.SetMaxRecommendations(cfg.RecommendationMaxCount)
+	recommendationsHandler.SetMLBatching(cfg.RecommendationMLBatchSize, cfg.RecommendationMLConcurrency)
+	recommendationsHandler.SetMLConfidenceFloor(cfg.RecommendationMLConfidenceFloor)
+	recommendationsHandler.SetModelTimeouts(cfg.KServe.ModelTimeouts)
+	if err := recommendationsHandler.SetPredictionHorizons(cfg.RecommendationPredictionHorizons); err != nil {
+		log.WithError(err).Fatal("Invalid recommendation prediction horizons")
+	}
+	configureRecommendationTickets(recommendationsHandler, cfg, log)
 	log.Info("Recommendations handler initialized")
 
+	stopBaselinePersistence := startBaselinePersistence(predictionHandler, cfg, log)
+
 	// API v1 routes
 	apiV1 := router.PathPrefix("/api/v1").Subrouter()
 
@@ -214,14 +328,21 @@ func main() {
 	apiV1.HandleFunc("/workflows/{id}", remediationHandler.GetWorkflow).Methods("GET")
 	apiV1.HandleFunc("/incidents", remediationHandler.ListIncidents).Methods("GET")
 	apiV1.HandleFunc("/incidents", remediationHandler.CreateIncident).Methods("POST")
+	if cfg.IncidentPurge.Enabled {
+		apiV1.HandleFunc("/incidents", remediationHandler.PurgeIncidents).Methods("DELETE")
+		log.WithField("token_file", cfg.IncidentPurge.TokenFile).Warn("Incident purge endpoint enabled")
+	}
+	apiV1.HandleFunc("/incidents/stats", remediationHandler.IncidentStats).Methods("GET")
 
 	// Recommendations endpoint (ML-powered remediation predictions)
-	apiV1.HandleFunc("/recommendations", recommendationsHandler.GetRecommendations).Methods("POST")
-	log.Info("Recommendations API endpoint registered: POST /api/v1/recommendations")
+	recommendationsHandler.RegisterRoutes(router)
 
 	// Prediction endpoint (time-specific resource predictions)
 	predictionHandler.RegisterRoutes(router)
-	log.Info("Prediction API endpoint registered: POST /api/v1/predict")
+
+	// Namespace overview: prediction and recommendations in one payload
+	overviewHandler := v1.NewNamespaceOverviewHandler(predictionHandler, recommendationsHandler, log)
+	overviewHandler.RegisterRoutes(router)
 
 	// Detection endpoints
 	detectionHandler.RegisterRoutes(router)
@@ -238,6 +359,7 @@ func main() {
 
 	// Anomaly analysis endpoints (Issue #30)
 	anomalyHandler := initAnomalyHandler(kserveProxyHandler, prometheusClient, log)
+	anomalyHandler.SetModelTimeouts(cfg.KServe.ModelTimeouts)
 	anomalyHandler.RegisterRoutes(router)
 	log.Info("Anomaly analysis API endpoint registered: POST /api/v1/anomalies/analyze")
 
@@ -294,9 +416,9 @@ func main() {
 	// Start main API server
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Port),
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		Handler:      withCORS(router, cfg, log),
+		ReadTimeout:  cfg.ServerReadTimeout,
+		WriteTimeout: cfg.ServerWriteTimeout,
 		IdleTimeout:  60 * time.Second,
 	}
 
@@ -327,6 +449,26 @@ func main() {
 		log.WithError(err).Error("Metrics server shutdown error")
 	}
 
+	stopBaselinePersistence()
+	stopPoolHistory()
+	if err := predictionHandler.SaveBaselines(); err != nil {
+		log.WithError(err).Error("Prediction baseline shutdown save error")
+	}
+
+	// Flush write-behind changes after the servers stop accepting requests and the
+	// auto-resolver has finished its last pass
+	stopIncidentAutoResolve()
+	if err := incidentStore.Close(); err != nil {
+		log.WithError(err).Error("Incident store shutdown flush error")
+	}
+
+	// Closed after the store so events from its last mutations are still delivered
+	if incidentWebhook != nil {
+		if err := incidentWebhook.Close(ctx); err != nil {
+			log.WithError(err).Error("Incident webhook shutdown error")
+		}
+	}
+
 	log.Info("Servers stopped")
 }
 
@@ -338,8 +480,9 @@ func initKServeProxy(cfg *config.Config, log *logrus.Logger) *v1.KServeProxyHand
 	}
 
 	kserveProxyConfig := kserve.ProxyConfig{
-		Namespace: cfg.KServe.Namespace,
-		Timeout:   cfg.KServe.Timeout,
+		Namespace:        cfg.KServe.Namespace,
+		Timeout:          cfg.KServe.Timeout,
+		RegressionModels: cfg.KServe.Regression.Models,
 	}
 
 	kserveProxyClient, err := kserve.NewProxyClient(kserveProxyConfig, log)
@@ -349,6 +492,7 @@ func initKServeProxy(cfg *config.Config, log *logrus.Logger) *v1.KServeProxyHand
 	}
 
 	handler := v1.NewKServeProxyHandler(kserveProxyClient, log)
+	handler.SetModelTimeouts(cfg.KServe.ModelTimeouts)
 	log.WithFields(logrus.Fields{
 		"models":    kserveProxyClient.ListModels(),
 		"namespace": cfg.KServe.Namespace,
@@ -400,18 +544,28 @@ func initRemediationComponents(
 
 // initPrometheusClient creates a Prometheus query client if configured
 func initPrometheusClient(cfg *config.Config, log *logrus.Logger) *integrations.PrometheusClient {
-	if cfg.PrometheusURL == "" {
+	opts := integrations.PrometheusClientOptions{
+		RootCAFile:         cfg.PrometheusCAFile,
+		InsecureSkipVerify: cfg.PrometheusInsecureSkipVerify,
+		BearerTokenFile:    cfg.PrometheusBearerTokenFile,
+		FallbackURLs:       cfg.PrometheusFallbackURLs,
+		EndpointCooldown:   cfg.PrometheusEndpointCooldown,
+	}
+	client, err := integrations.NewPrometheusClientWithOptions(cfg.PrometheusURL, cfg.HTTPTimeout, log, opts)
+	if errors.Is(err, integrations.ErrPrometheusNotConfigured) {
 		log.Info("PROMETHEUS_URL not set, ML predictions will use default metric values")
 		return nil
 	}
-
-	client := integrations.NewPrometheusClient(cfg.PrometheusURL, cfg.HTTPTimeout, log)
-	if client == nil {
-		log.Warn("Failed to create Prometheus client")
+	if err != nil {
+		log.WithError(err).Warn("Failed to create Prometheus client")
 		return nil
 	}
 
-	log.WithField("prometheus_url", cfg.PrometheusURL).Info("Prometheus client initialized for metrics querying")
+	log.WithFields(logrus.Fields{
+		"prometheus_url":    cfg.PrometheusURL,
+		"fallback_urls":     cfg.PrometheusFallbackURLs,
+		"endpoint_cooldown": cfg.PrometheusEndpointCooldown,
+	}).Info("Prometheus client initialized for metrics querying")
 	return client
 }
 
@@ -528,6 +682,46 @@ func initKubernetesClient(cfg *config.Config, log *logrus.Logger) (*KubernetesCl
 	}, nil
 }
 
+// initPredictionStore initializes the prediction history store, persisted if DATA_DIR is configured
+func initPredictionStore(cfg *config.Config, log *logrus.Logger) *storage.PredictionStore {
+	if cfg.DataDir == "" {
+		log.WithField("max_records", cfg.PredictionHistory.MaxRecords).
+			Info("Prediction history enabled in memory (DATA_DIR not configured, history will be lost on restart)")
+		return storage.NewPredictionStore(cfg.PredictionHistory.MaxRecords)
+	}
+
+	predictionStore, err := storage.NewPredictionStoreWithPersistence(cfg.DataDir, cfg.PredictionHistory.MaxRecords, log)
+	if err != nil {
+		log.WithError(err).Error("Failed to create persistent prediction store, falling back to in-memory")
+		return storage.NewPredictionStore(cfg.PredictionHistory.MaxRecords)
+	}
+
+	log.WithFields(logrus.Fields{
+		"file":               filepath.Join(cfg.DataDir, storage.PredictionFileName),
+		"max_records":        cfg.PredictionHistory.MaxRecords,
+		"loaded_predictions": predictionStore.Count(),
+	}).Info("Prediction history enabled with file-based persistence")
+	return predictionStore
+}
+
+// initRecommendationAckStore initializes the recommendation acknowledgement store, persisted if DATA_DIR is configured
+func initRecommendationAckStore(cfg *config.Config, log *logrus.Logger) *storage.RecommendationAckStore {
+	if cfg.DataDir == "" {
+		log.Info("DATA_DIR not configured, recommendation acknowledgements will be lost on restart")
+		return storage.NewRecommendationAckStore()
+	}
+
+	ackStore, err := storage.NewRecommendationAckStoreWithPersistence(cfg.DataDir, log)
+	if err != nil {
+		log.WithError(err).Error("Failed to create persistent recommendation acknowledgement store, falling back to in-memory")
+		return storage.NewRecommendationAckStore()
+	}
+
+	log.WithField("file", filepath.Join(cfg.DataDir, storage.RecommendationAckFileName)).
+		Info("Recommendation acknowledgements enabled with file-based persistence")
+	return ackStore
+}
+
 // initIncidentStore initializes the incident store with persistence if DATA_DIR is configured (ADR-014)
 func initIncidentStore(cfg *config.Config, log *logrus.Logger) *storage.IncidentStore {
 	if cfg.DataDir == "" {
@@ -548,8 +742,24 @@ func initIncidentStore(cfg *config.Config, log *logrus.Logger) *storage.Incident
 		"loaded_incidents": incidentStore.Count(),
 	}).Info("Incident store initialized with file-based persistence")
 
+	if cfg.IncidentWriteBehind.Enabled {
+		policy := storage.DefaultWriteBehindPolicy()
+		policy.Enabled = true
+		policy.FlushInterval = cfg.IncidentWriteBehind.FlushInterval
+		policy.MaxPending = cfg.IncidentWriteBehind.MaxPending
+		if err := incidentStore.StartWriteBehind(policy); err != nil {
+			log.WithError(err).Error("Failed to start incident write-behind, writing synchronously")
+		}
+	}
+
 	// Start background cleanup goroutine for old incidents
-	if cfg.IncidentRetentionDays > 0 {
+	if cfg.IncidentRetentionDays > 0 || len(cfg.IncidentRetentionDaysBySeverity) > 0 {
+		severityRetention := make(map[models.IncidentSeverity]int, len(cfg.IncidentRetentionDaysBySeverity))
+		for severity, days := range cfg.IncidentRetentionDaysBySeverity {
+			severityRetention[models.IncidentSeverity(severity)] = days
+		}
+		incidentStore.SetSeverityRetention(severityRetention)
+
 		go func() {
 			// Run cleanup every 24 hours
 			ticker := time.NewTicker(24 * time.Hour)
@@ -561,10 +771,214 @@ func initIncidentStore(cfg *config.Config, log *logrus.Logger) *storage.Incident
 				}
 			}
 		}()
-		log.WithField("retention_days", cfg.IncidentRetentionDays).Info("Background incident cleanup enabled")
+		log.WithFields(logrus.Fields{
+			"retention_days":             cfg.IncidentRetentionDays,
+			"retention_days_by_severity": cfg.IncidentRetentionDaysBySeverity,
+		}).Info("Background incident cleanup enabled")
 	} else {
 		log.Info("Incident cleanup disabled (INCIDENT_RETENTION_DAYS=0)")
 	}
 
 	return incidentStore
 }
+
+// configureIncidentAutoResolve applies the auto-resolve policy and starts the periodic task
+// that resolves incidents which stopped recurring. The returned func stops the task and waits
+// for an in-flight pass, so the store is not mutated after its final flush.
+func configureIncidentAutoResolve(incidentStore *storage.IncidentStore, cfg *config.Config, log *logrus.Logger) func() {
+	if !cfg.IncidentAutoResolve.Enabled {
+		return func() {}
+	}
+
+	incidentStore.SetAutoResolvePolicy(storage.AutoResolvePolicy{
+		Enabled:     true,
+		QuietPeriod: cfg.IncidentAutoResolve.QuietPeriod,
+	})
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(cfg.IncidentAutoResolve.CheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := incidentStore.AutoResolveStale(); err != nil {
+					log.WithError(err).Error("Failed to auto-resolve stale incidents")
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	log.WithFields(logrus.Fields{
+		"quiet_period":   cfg.IncidentAutoResolve.QuietPeriod,
+		"check_interval": cfg.IncidentAutoResolve.CheckInterval,
+	}).Info("Incident auto-resolve enabled")
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// configureIncidentWebhook registers a webhook observer that posts incident changes to the
+// configured URL. It returns nil when no URL is set.
+func configureIncidentWebhook(incidentStore *storage.IncidentStore, cfg *config.Config, log *logrus.Logger) *integrations.IncidentWebhook {
+	if cfg.IncidentWebhook.URL == "" {
+		return nil
+	}
+
+	events := make([]storage.IncidentEventType, 0, len(cfg.IncidentWebhook.Events))
+	for _, event := range cfg.IncidentWebhook.Events {
+		events = append(events, storage.IncidentEventType(event))
+	}
+
+	webhook := integrations.NewIncidentWebhook(integrations.IncidentWebhookConfig{
+		URL:        cfg.IncidentWebhook.URL,
+		Timeout:    cfg.IncidentWebhook.Timeout,
+		MaxRetries: cfg.IncidentWebhook.MaxRetries,
+		Events:     events,
+	}, log)
+	incidentStore.RegisterObserver(webhook.Notify)
+
+	log.WithFields(logrus.Fields{
+		"timeout":     cfg.IncidentWebhook.Timeout,
+		"max_retries": cfg.IncidentWebhook.MaxRetries,
+		"events":      cfg.IncidentWebhook.Events,
+	}).Info("Incident webhook notifications enabled")
+	return webhook
+}
+
+// configureRecommendationTickets lets recommendations be exported as tickets to the configured
+// ticketing system. An invalid field mapping stops startup rather than failing every export.
+func configureRecommendationTickets(handler *v1.RecommendationsHandler, cfg *config.Config, log *logrus.Logger) {
+	ticketCfg := cfg.RecommendationTicket
+	if ticketCfg.URL == "" {
+		return
+	}
+
+	var fields map[string]string
+	if ticketCfg.FieldsFile != "" {
+		var err error
+		if fields, err = integrations.LoadTicketFields(ticketCfg.FieldsFile); err != nil {
+			log.WithError(err).Fatal("Failed to load recommendation ticket field mapping")
+		}
+	}
+	exporter, err := integrations.NewTicketExporter(integrations.TicketExporterConfig{
+		URL:               ticketCfg.URL,
+		Timeout:           ticketCfg.Timeout,
+		Format:            ticketCfg.Format,
+		Fields:            fields,
+		AuthorizationFile: ticketCfg.AuthorizationFile,
+		ReferenceField:    ticketCfg.ReferenceField,
+	}, log)
+	if err != nil {
+		log.WithError(err).Fatal("Invalid recommendation ticket configuration")
+	}
+	handler.SetTicketExporter(exporter)
+
+	log.WithFields(logrus.Fields{
+		"format":      ticketCfg.Format,
+		"fields_file": ticketCfg.FieldsFile,
+		"timeout":     ticketCfg.Timeout,
+	}).Info("Recommendation ticket export enabled")
+}
+
+// linkWorkflowOutcomes records finished remediation workflows on the incidents they were triggered for
+func linkWorkflowOutcomes(orchestrator *remediation.Orchestrator, incidentStore *storage.IncidentStore, log *logrus.Logger) {
+	orchestrator.SetCompletionHook(func(workflow *models.Workflow) {
+		if workflow.IncidentID == "" {
+			return
+		}
+		if _, err := incidentStore.LinkWorkflow(workflow.IncidentID, workflow.ID, workflow.Status); err != nil {
+			// Remediation can be triggered for incidents tracked outside the store
+			log.WithError(err).WithFields(logrus.Fields{
+				"incident_id": workflow.IncidentID,
+				"workflow_id": workflow.ID,
+			}).Debug("Could not link workflow outcome to incident")
+		}
+	})
+}
+
+// configureIncidentEscalation applies the recurrence escalation policy to the incident store
+func configureIncidentEscalation(incidentStore *storage.IncidentStore, cfg *config.Config, log *logrus.Logger) {
+	if !cfg.IncidentEscalation.Enabled {
+		return
+	}
+
+	incidentStore.SetEscalationPolicy(storage.EscalationPolicy{
+		Enabled:          true,
+		RecurrenceWindow: cfg.IncidentEscalation.RecurrenceWindow,
+		Thresholds:       cfg.IncidentEscalation.Thresholds,
+	})
+
+	log.WithFields(logrus.Fields{
+		"recurrence_window": cfg.IncidentEscalation.RecurrenceWindow,
+		"thresholds":        cfg.IncidentEscalation.Thresholds,
+	}).Info("Incident severity escalation on recurrence enabled")
+}
+
+// withCORS wraps the API router in the CORS middleware when ENABLE_CORS is set. It wraps the
+// router rather than joining router.Use so preflight requests for POST-only routes are answered.
+func withCORS(router http.Handler, cfg *config.Config, log *logrus.Logger) http.Handler {
+	if !cfg.EnableCORS {
+		return router
+	}
+
+	corsConfig := middleware.DefaultCORSConfig()
+	corsConfig.AllowedOrigins = cfg.CORSAllowOrigin
+	if len(cfg.CORSAllowMethods) > 0 {
+		corsConfig.AllowedMethods = cfg.CORSAllowMethods
+	}
+	if len(cfg.CORSAllowHeaders) > 0 {
+		corsConfig.AllowedHeaders = cfg.CORSAllowHeaders
+	}
+	corsConfig.AllowCredentials = cfg.CORSAllowCredentials
+	corsConfig.MaxAge = int(cfg.CORSMaxAge.Seconds())
+
+	log.WithFields(logrus.Fields{
+		"allowed_origins":   corsConfig.AllowedOrigins,
+		"allow_credentials": corsConfig.AllowCredentials,
+	}).Info("CORS enabled for the API server")
+	return middleware.CORS(corsConfig)(router)
+}
+
+// startBaselinePersistence periodically saves learned prediction baselines to DATA_DIR so a
+// crash loses at most one interval of learning. The returned func stops the saver and waits
+// for an in-flight save, so a final save afterwards does not race it on the temp file.
+func startBaselinePersistence(predictionHandler *v1.PredictionHandler, cfg *config.Config, log *logrus.Logger) func() {
+	interval := cfg.PredictionBaseline.PersistInterval
+	if cfg.DataDir == "" || interval <= 0 {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := predictionHandler.SaveBaselines(); err != nil {
+					log.WithError(err).Warn("Failed to persist prediction baselines")
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	log.WithFields(logrus.Fields{
+		"file":     filepath.Join(cfg.DataDir, v1.BaselineFileName),
+		"interval": interval,
+	}).Info("Prediction baseline persistence enabled")
+	return func() {
+		close(stop)
+		<-done
+	}
+}
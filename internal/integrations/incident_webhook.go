@@ -0,0 +1,229 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// Defaults for IncidentWebhookConfig fields left unset
+const (
+	DefaultIncidentWebhookTimeout      = 5 * time.Second
+	DefaultIncidentWebhookRetryBackoff = 1 * time.Second
+	DefaultIncidentWebhookQueueSize    = 100
+)
+
+// IncidentWebhookConfig configures an IncidentWebhook
+type IncidentWebhookConfig struct {
+	// URL receives a JSON POST per event
+	URL string
+
+	// Timeout bounds each delivery attempt (0 = DefaultIncidentWebhookTimeout)
+	Timeout time.Duration
+
+	// MaxRetries is how many times a failed delivery is retried
+	MaxRetries int
+
+	// RetryBackoff is the delay before the first retry, doubled for each further retry
+	// (0 = DefaultIncidentWebhookRetryBackoff)
+	RetryBackoff time.Duration
+
+	// Events limits delivery to these event types (empty = all)
+	Events []storage.IncidentEventType
+
+	// QueueSize is how many events may wait for delivery before new ones are dropped
+	// (0 = DefaultIncidentWebhookQueueSize)
+	QueueSize int
+}
+
+// IncidentWebhookPayload is the JSON body posted for each event. Text is a one-line summary,
+// so Slack-compatible incoming webhooks can display it without a custom template.
+type IncidentWebhookPayload struct {
+	Text           string                    `json:"text"`
+	Event          storage.IncidentEventType `json:"event"`
+	Incident       models.Incident           `json:"incident"`
+	PreviousStatus models.IncidentStatus     `json:"previous_status,omitempty"`
+	Timestamp      time.Time                 `json:"timestamp"`
+}
+
+// IncidentWebhook posts incident events to an HTTP endpoint. Notify only queues the event;
+// a single worker delivers events in order, retrying transient failures, so the incident
+// store is never blocked on the network.
+type IncidentWebhook struct {
+	url          string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	events       map[storage.IncidentEventType]bool
+	log          *logrus.Logger
+
+	mu     sync.RWMutex // guards closed against sends on the closed queue
+	closed bool
+	queue  chan storage.IncidentEvent
+	done   chan struct{}
+}
+
+// NewIncidentWebhook creates a webhook notifier and starts its delivery worker
+func NewIncidentWebhook(cfg IncidentWebhookConfig, log *logrus.Logger) *IncidentWebhook {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultIncidentWebhookTimeout
+	}
+	backoff := cfg.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultIncidentWebhookRetryBackoff
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultIncidentWebhookQueueSize
+	}
+
+	var events map[storage.IncidentEventType]bool
+	if len(cfg.Events) > 0 {
+		events = make(map[storage.IncidentEventType]bool, len(cfg.Events))
+		for _, eventType := range cfg.Events {
+			events[eventType] = true
+		}
+	}
+
+	w := &IncidentWebhook{
+		url:          cfg.URL,
+		httpClient:   &http.Client{Timeout: timeout},
+		maxRetries:   max(cfg.MaxRetries, 0),
+		retryBackoff: backoff,
+		events:       events,
+		log:          log,
+		queue:        make(chan storage.IncidentEvent, queueSize),
+		done:         make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Notify queues event for delivery. It is an IncidentObserver and never blocks; when the
+// queue is full the event is dropped and logged.
+func (w *IncidentWebhook) Notify(event storage.IncidentEvent) {
+	if w.events != nil && !w.events[event.Type] {
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.queue <- event:
+	default:
+		w.log.WithFields(logrus.Fields{
+			"event":       event.Type,
+			"incident_id": event.Incident.ID,
+		}).Warn("Incident webhook queue full, dropping event")
+	}
+}
+
+// Close stops accepting events and waits for queued ones to be delivered or for ctx to end
+func (w *IncidentWebhook) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("incident webhook did not drain: %w", ctx.Err())
+	}
+}
+
+func (w *IncidentWebhook) run() {
+	defer close(w.done)
+	for event := range w.queue {
+		w.deliver(event)
+	}
+}
+
+// deliver posts event, retrying network errors, 429 and 5xx responses with exponential backoff
+func (w *IncidentWebhook) deliver(event storage.IncidentEvent) {
+	body, err := json.Marshal(newIncidentWebhookPayload(event))
+	if err != nil {
+		w.log.WithError(err).Error("Failed to encode incident webhook payload")
+		return
+	}
+
+	log := w.log.WithFields(logrus.Fields{
+		"event":       event.Type,
+		"incident_id": event.Incident.ID,
+	})
+
+	backoff := w.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.post(body)
+		if err == nil {
+			log.Debug("Delivered incident webhook")
+			return
+		}
+		if !retryable || attempt >= w.maxRetries {
+			log.WithError(err).WithField("attempts", attempt+1).Error("Failed to deliver incident webhook")
+			return
+		}
+
+		log.WithError(err).WithField("retry_in", backoff).Warn("Incident webhook delivery failed, retrying")
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends one delivery attempt and reports whether a failure is worth retrying
+func (w *IncidentWebhook) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+}
+
+func newIncidentWebhookPayload(event storage.IncidentEvent) IncidentWebhookPayload {
+	incident := event.Incident
+	text := fmt.Sprintf("[%s] Incident %s %s: %s (target %s)",
+		incident.Severity, incident.ID, event.Type, incident.Title, incident.Target)
+	if event.Type == storage.IncidentEventStatusChanged {
+		text = fmt.Sprintf("[%s] Incident %s status changed %s -> %s: %s (target %s)",
+			incident.Severity, incident.ID, event.PreviousStatus, incident.Status, incident.Title, incident.Target)
+	}
+
+	return IncidentWebhookPayload{
+		Text:           text,
+		Event:          event.Type,
+		Incident:       incident,
+		PreviousStatus: event.PreviousStatus,
+		Timestamp:      event.Timestamp,
+	}
+}
@@ -0,0 +1,171 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func newTestIncidentEvent(eventType storage.IncidentEventType) storage.IncidentEvent {
+	return storage.IncidentEvent{
+		Type: eventType,
+		Incident: models.Incident{
+			ID:       "inc-1",
+			Title:    "Pods crash looping",
+			Severity: models.IncidentSeverityHigh,
+			Target:   "payments",
+			Status:   models.IncidentStatusResolved,
+		},
+		PreviousStatus: models.IncidentStatusActive,
+		Timestamp:      time.Now(),
+	}
+}
+
+func closeWebhook(t *testing.T, webhook *IncidentWebhook) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, webhook.Close(ctx))
+}
+
+// TestIncidentWebhook_DeliversPayload verifies the posted JSON body
+func TestIncidentWebhook_DeliversPayload(t *testing.T) {
+	var mu sync.Mutex
+	var payloads []IncidentWebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload IncidentWebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	webhook := NewIncidentWebhook(IncidentWebhookConfig{URL: server.URL}, logrus.New())
+	webhook.Notify(newTestIncidentEvent(storage.IncidentEventStatusChanged))
+	closeWebhook(t, webhook)
+
+	require.Len(t, payloads, 1)
+	payload := payloads[0]
+	assert.Equal(t, storage.IncidentEventStatusChanged, payload.Event)
+	assert.Equal(t, "inc-1", payload.Incident.ID)
+	assert.Equal(t, models.IncidentStatusActive, payload.PreviousStatus)
+	assert.Contains(t, payload.Text, "status changed active -> resolved")
+	assert.Contains(t, payload.Text, "payments")
+}
+
+// TestIncidentWebhook_Retries verifies 5xx and 429 responses are retried and other 4xx are not
+func TestIncidentWebhook_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int32
+	}{
+		{name: "server error retried", status: http.StatusBadGateway, wantAttempts: 3},
+		{name: "rate limit retried", status: http.StatusTooManyRequests, wantAttempts: 3},
+		{name: "client error not retried", status: http.StatusBadRequest, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			webhook := NewIncidentWebhook(IncidentWebhookConfig{
+				URL:          server.URL,
+				MaxRetries:   2,
+				RetryBackoff: time.Millisecond,
+			}, logrus.New())
+			webhook.Notify(newTestIncidentEvent(storage.IncidentEventCreated))
+			closeWebhook(t, webhook)
+
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+// TestIncidentWebhook_RecoversAfterTransientFailure verifies a retry that succeeds stops retrying
+func TestIncidentWebhook_RecoversAfterTransientFailure(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	webhook := NewIncidentWebhook(IncidentWebhookConfig{URL: server.URL, MaxRetries: 5, RetryBackoff: time.Millisecond}, logrus.New())
+	webhook.Notify(newTestIncidentEvent(storage.IncidentEventCreated))
+	closeWebhook(t, webhook)
+
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+// TestIncidentWebhook_FiltersEvents verifies only configured event types are delivered
+func TestIncidentWebhook_FiltersEvents(t *testing.T) {
+	var mu sync.Mutex
+	var received []storage.IncidentEventType
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload IncidentWebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		received = append(received, payload.Event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	webhook := NewIncidentWebhook(IncidentWebhookConfig{
+		URL:    server.URL,
+		Events: []storage.IncidentEventType{storage.IncidentEventEscalated, storage.IncidentEventDeleted},
+	}, logrus.New())
+	for _, eventType := range storage.IncidentEventTypes {
+		webhook.Notify(newTestIncidentEvent(eventType))
+	}
+	closeWebhook(t, webhook)
+
+	assert.Equal(t, []storage.IncidentEventType{storage.IncidentEventEscalated, storage.IncidentEventDeleted}, received)
+}
+
+// TestIncidentWebhook_NotifyDoesNotBlock verifies a full queue drops events instead of blocking
+// the caller, and events sent after Close are ignored
+func TestIncidentWebhook_NotifyDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		<-release
+	}))
+	defer server.Close()
+
+	webhook := NewIncidentWebhook(IncidentWebhookConfig{URL: server.URL, QueueSize: 1}, logrus.New())
+	webhook.Notify(newTestIncidentEvent(storage.IncidentEventCreated))
+	require.Eventually(t, func() bool { return attempts.Load() == 1 }, 2*time.Second, 5*time.Millisecond)
+
+	// One event fits in the queue while the first delivery is stuck; the rest are dropped
+	for i := 0; i < 5; i++ {
+		webhook.Notify(newTestIncidentEvent(storage.IncidentEventCreated))
+	}
+	close(release)
+	closeWebhook(t, webhook)
+	webhook.Notify(newTestIncidentEvent(storage.IncidentEventCreated))
+
+	assert.Equal(t, int32(2), attempts.Load())
+}
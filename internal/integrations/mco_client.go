@@ -3,12 +3,17 @@ package integrations
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
@@ -17,6 +22,51 @@ import (
 type MCOClient struct {
 	dynamicClient dynamic.Interface
 	log           *logrus.Logger
+	waitOptions   PoolWaitOptions
+	history       *poolHistory
+}
+
+// DefaultPoolPollInterval is the base interval between MachineConfigPool status checks
+const DefaultPoolPollInterval = 10 * time.Second
+
+// poolStatusConcurrency is how many pool statuses GetAllPoolStatuses fetches in parallel
+const poolStatusConcurrency = 4
+
+// PoolWaitOptions controls how WaitForPoolStable polls a MachineConfigPool
+type PoolWaitOptions struct {
+	// PollInterval is the base delay between status checks (0 = DefaultPoolPollInterval)
+	PollInterval time.Duration
+
+	// Jitter randomizes each delay by up to this fraction of PollInterval in either
+	// direction (0.1 = ±10%), so concurrent waits don't poll the API in lockstep
+	Jitter float64
+
+	// InitialDelay postpones the first status check, e.g. when the caller has just fetched status
+	InitialDelay time.Duration
+}
+
+// DefaultPoolWaitOptions returns a 10s poll interval with ±10% jitter and no initial delay
+func DefaultPoolWaitOptions() PoolWaitOptions {
+	return PoolWaitOptions{
+		PollInterval: DefaultPoolPollInterval,
+		Jitter:       0.1,
+	}
+}
+
+// nextInterval returns the jittered delay before the next status check
+func (o PoolWaitOptions) nextInterval() time.Duration {
+	interval := o.PollInterval
+	if interval <= 0 {
+		interval = DefaultPoolPollInterval
+	}
+
+	jitter := min(max(o.Jitter, 0), 1)
+	if jitter == 0 {
+		return interval
+	}
+	// Uniform in [interval*(1-jitter), interval*(1+jitter)]
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(interval) * factor)
 }
 
 // NewMCOClient creates a new MCO monitoring client
@@ -24,9 +74,17 @@ func NewMCOClient(dynamicClient dynamic.Interface, log *logrus.Logger) *MCOClien
 	return &MCOClient{
 		dynamicClient: dynamicClient,
 		log:           log,
+		waitOptions:   DefaultPoolWaitOptions(),
+		history:       newPoolHistory(DefaultPoolHistorySize),
 	}
 }
 
+// SetPoolWaitOptions sets the client-wide polling behavior used by WaitForPoolStable
+// and WaitForAllPoolsStable
+func (mc *MCOClient) SetPoolWaitOptions(opts PoolWaitOptions) {
+	mc.waitOptions = opts
+}
+
 // MachineConfigPoolStatus represents MCO pool status
 type MachineConfigPoolStatus struct {
 	Name                 string `json:"name"`
@@ -39,14 +97,67 @@ type MachineConfigPoolStatus struct {
 	CurrentConfiguration string `json:"currentConfiguration"`
 }
 
+// IsStable reports whether the pool is not updating, not degraded and has all machines updated
+func (s *MachineConfigPoolStatus) IsStable() bool {
+	return !s.Updating &&
+		!s.Degraded &&
+		s.UpdatedMachineCount == s.MachineCount
+}
+
+// WorkerPoolSelector matches the pools that manage worker nodes, including custom pools
+// that inherit the worker role
+const WorkerPoolSelector = "pools.operator.machineconfiguration.openshift.io/worker"
+
+// PoolSelector restricts which MachineConfigPools are listed and waited on. The zero value
+// selects every pool.
+type PoolSelector struct {
+	// LabelSelector is a Kubernetes label selector passed to the API server (e.g. WorkerPoolSelector)
+	LabelSelector string
+
+	// Names, when set, keeps only pools with one of these names
+	Names []string
+}
+
+// isEmpty reports whether the selector matches every pool
+func (s PoolSelector) isEmpty() bool {
+	return s.LabelSelector == "" && len(s.Names) == 0
+}
+
+// matchesName reports whether the allowlist, if any, contains name
+func (s PoolSelector) matchesName(name string) bool {
+	if len(s.Names) == 0 {
+		return true
+	}
+	for _, allowed := range s.Names {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	mcpGVR = schema.GroupVersionResource{
 		Group:    "machineconfiguration.openshift.io",
 		Version:  "v1",
 		Resource: "machineconfigpools",
 	}
+	nodeGVR = schema.GroupVersionResource{
+		Version:  "v1",
+		Resource: "nodes",
+	}
 )
 
+// ErrNodeHasNoPool is returned by GetPoolForNode when no MachineConfigPool selects the node
+var ErrNodeHasNoPool = errors.New("node does not belong to any MachineConfigPool")
+
+// nodeRoleLabelPrefix prefixes the node role labels (node-role.kubernetes.io/<role>) that
+// GetPoolForNode falls back to when no pool node selector matches
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// workerPoolName is the default pool; nodes that also belong to a custom pool belong to that one
+const workerPoolName = "worker"
+
 // GetPoolStatus retrieves MachineConfigPool status
 func (mc *MCOClient) GetPoolStatus(ctx context.Context, poolName string) (*MachineConfigPoolStatus, error) {
 	mc.log.WithField("pool", poolName).Debug("Fetching MachineConfigPool status")
@@ -60,6 +171,7 @@ func (mc *MCOClient) GetPoolStatus(ctx context.Context, poolName string) (*Machi
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse pool status: %w", err)
 	}
+	mc.RecordPoolStatus(status, time.Now())
 
 	mc.log.WithFields(logrus.Fields{
 		"pool":     poolName,
@@ -171,13 +283,7 @@ func (mc *MCOClient) IsPoolStable(ctx context.Context, poolName string) (bool, e
 		return false, err
 	}
 
-	// Pool is stable if:
-	// 1. Not updating
-	// 2. Not degraded
-	// 3. All machines are updated
-	stable := !status.Updating &&
-		!status.Degraded &&
-		status.UpdatedMachineCount == status.MachineCount
+	stable := status.IsStable()
 
 	mc.log.WithFields(logrus.Fields{
 		"pool":    poolName,
@@ -189,16 +295,133 @@ func (mc *MCOClient) IsPoolStable(ctx context.Context, poolName string) (bool, e
 	return stable, nil
 }
 
-// WaitForPoolStable waits for MachineConfigPool to become stable
+// GetPoolForNode returns the MachineConfigPool the node belongs to. Pools are matched by their
+// spec.nodeSelector; if none matches, a node-role.kubernetes.io/<pool> label naming an existing
+// pool is used. As in the MCO, a custom pool wins over the worker pool, and a node matched by
+// more than one custom pool is an error. Returns ErrNodeHasNoPool if no pool matches.
+func (mc *MCOClient) GetPoolForNode(ctx context.Context, nodeName string) (string, error) {
+	mc.log.WithField("node", nodeName).Debug("Resolving MachineConfigPool for node")
+
+	node, err := mc.dynamicClient.Resource(nodeGVR).Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+	nodeLabels := labels.Set(node.GetLabels())
+
+	pools, err := mc.dynamicClient.Resource(mcpGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to list MachineConfigPools: %w", err)
+	}
+
+	var selected, byRole []string
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		selector, err := poolNodeSelector(pool)
+		if err != nil {
+			mc.log.WithError(err).WithField("pool", pool.GetName()).Warn("Ignoring MachineConfigPool with invalid node selector")
+		} else if selector != nil && selector.Matches(nodeLabels) {
+			selected = append(selected, pool.GetName())
+		}
+		if nodeLabels.Has(nodeRoleLabelPrefix + pool.GetName()) {
+			byRole = append(byRole, pool.GetName())
+		}
+	}
+
+	matched, source := selected, "node_selector"
+	if len(matched) == 0 {
+		matched, source = byRole, "node_role_label"
+	}
+	poolName, err := pickNodePool(matched)
+	if err != nil {
+		return "", fmt.Errorf("node %s: %w", nodeName, err)
+	}
+
+	mc.log.WithFields(logrus.Fields{
+		"node":   nodeName,
+		"pool":   poolName,
+		"source": source,
+	}).Debug("Resolved MachineConfigPool for node")
+
+	return poolName, nil
+}
+
+// poolNodeSelector returns the selector of the pool's spec.nodeSelector, or nil if the pool
+// has none. As in the MCO, an empty selector selects no nodes rather than all of them.
+func poolNodeSelector(pool *unstructured.Unstructured) (labels.Selector, error) {
+	raw, found, err := unstructured.NestedMap(pool.Object, "spec", "nodeSelector")
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &labelSelector); err != nil {
+		return nil, fmt.Errorf("failed to parse node selector: %w", err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node selector: %w", err)
+	}
+	if selector.Empty() {
+		return nil, nil
+	}
+	return selector, nil
+}
+
+// pickNodePool chooses the pool of a node matched by pools: the only custom pool if there is
+// one, else the worker pool
+func pickNodePool(pools []string) (string, error) {
+	var custom []string
+	for _, pool := range pools {
+		if pool != workerPoolName {
+			custom = append(custom, pool)
+		}
+	}
+	switch {
+	case len(custom) == 1:
+		return custom[0], nil
+	case len(custom) > 1:
+		return "", fmt.Errorf("node matches more than one custom MachineConfigPool: %v", custom)
+	case len(pools) > 0:
+		return workerPoolName, nil
+	default:
+		return "", ErrNodeHasNoPool
+	}
+}
+
+// IsNodeStable returns true if the MachineConfigPool the node belongs to is not updating and
+// not degraded, i.e. it is safe to act on the node
+func (mc *MCOClient) IsNodeStable(ctx context.Context, nodeName string) (bool, error) {
+	poolName, err := mc.GetPoolForNode(ctx, nodeName)
+	if err != nil {
+		return false, err
+	}
+	return mc.IsPoolStable(ctx, poolName)
+}
+
+// WaitForPoolStable waits for MachineConfigPool to become stable using the client's PoolWaitOptions
 func (mc *MCOClient) WaitForPoolStable(ctx context.Context, poolName string, timeout time.Duration) error {
+	return mc.WaitForPoolStableWithOptions(ctx, poolName, timeout, mc.waitOptions)
+}
+
+// WaitForPoolStableWithOptions waits for MachineConfigPool to become stable, polling as described by opts
+func (mc *MCOClient) WaitForPoolStableWithOptions(ctx context.Context, poolName string, timeout time.Duration, opts PoolWaitOptions) error {
 	mc.log.WithFields(logrus.Fields{
-		"pool":    poolName,
-		"timeout": timeout,
+		"pool":          poolName,
+		"timeout":       timeout,
+		"poll_interval": opts.PollInterval,
+		"initial_delay": opts.InitialDelay,
 	}).Info("Waiting for MachineConfigPool to stabilize")
 
 	deadline := time.Now().Add(timeout)
 
-	for time.Now().Before(deadline) {
+	if opts.InitialDelay > 0 {
+		if err := waitUntilNextPoll(ctx, poolName, opts.InitialDelay, deadline); err != nil {
+			return err
+		}
+	}
+
+	// Waits are cut short at the deadline, so the last check runs at the deadline itself
+	for {
 		stable, err := mc.IsPoolStable(ctx, poolName)
 		if err != nil {
 			mc.log.WithError(err).Warn("Failed to check pool stability")
@@ -220,29 +443,63 @@ func (mc *MCOClient) WaitForPoolStable(ctx context.Context, poolName string, tim
 			}).Debug("Waiting for pool to stabilize")
 		}
 
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("context cancelled while waiting for pool %s: %w", poolName, ctx.Err())
-		case <-time.After(10 * time.Second):
-			// Continue polling
+		if !time.Now().Before(deadline) {
+			break
+		}
+		if err := waitUntilNextPoll(ctx, poolName, opts.nextInterval(), deadline); err != nil {
+			return err
 		}
 	}
 
 	return fmt.Errorf("MachineConfigPool %s did not stabilize within %v", poolName, timeout)
 }
 
+// waitUntilNextPoll sleeps for delay, cut short at the deadline, and fails if ctx is cancelled first
+func waitUntilNextPoll(ctx context.Context, poolName string, delay time.Duration, deadline time.Time) error {
+	delay = min(delay, time.Until(deadline))
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context cancelled while waiting for pool %s: %w", poolName, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
 // ListMachineConfigPools lists all MachineConfigPools
 func (mc *MCOClient) ListMachineConfigPools(ctx context.Context) ([]string, error) {
-	mc.log.Debug("Listing MachineConfigPools")
+	return mc.ListMachineConfigPoolsMatching(ctx, PoolSelector{})
+}
 
-	pools, err := mc.dynamicClient.Resource(mcpGVR).List(ctx, metav1.ListOptions{})
+// ListMachineConfigPoolsMatching lists the MachineConfigPools matching selector
+func (mc *MCOClient) ListMachineConfigPoolsMatching(ctx context.Context, selector PoolSelector) ([]string, error) {
+	mc.log.WithFields(logrus.Fields{
+		"label_selector": selector.LabelSelector,
+		"names":          selector.Names,
+	}).Debug("Listing MachineConfigPools")
+
+	if selector.LabelSelector != "" {
+		if _, err := labels.Parse(selector.LabelSelector); err != nil {
+			return nil, fmt.Errorf("invalid pool label selector %q: %w", selector.LabelSelector, err)
+		}
+	}
+
+	pools, err := mc.dynamicClient.Resource(mcpGVR).List(ctx, metav1.ListOptions{LabelSelector: selector.LabelSelector})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list MachineConfigPools: %w", err)
 	}
 
 	poolNames := make([]string, 0, len(pools.Items))
 	for _, pool := range pools.Items {
-		poolNames = append(poolNames, pool.GetName())
+		if selector.matchesName(pool.GetName()) {
+			poolNames = append(poolNames, pool.GetName())
+		}
 	}
 
 	mc.log.WithField("count", len(poolNames)).Debug("MachineConfigPools listed")
@@ -250,16 +507,96 @@ func (mc *MCOClient) ListMachineConfigPools(ctx context.Context) ([]string, erro
 	return poolNames, nil
 }
 
+// GetAllPoolStatuses fetches the status of every MachineConfigPool, a few pools at a time, so
+// one slow pool does not delay the others. Statuses are returned in list order. If some
+// pools fail, the statuses that were fetched are returned together with an error naming
+// each failed pool.
+func (mc *MCOClient) GetAllPoolStatuses(ctx context.Context) ([]MachineConfigPoolStatus, error) {
+	pools, err := mc.ListMachineConfigPools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pools: %w", err)
+	}
+
+	results := make([]*MachineConfigPoolStatus, len(pools))
+	errs := make([]error, len(pools))
+	sem := make(chan struct{}, poolStatusConcurrency)
+	var wg sync.WaitGroup
+	for i, poolName := range pools {
+		wg.Add(1)
+		go func(i int, poolName string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = mc.GetPoolStatus(ctx, poolName)
+		}(i, poolName)
+	}
+	wg.Wait()
+
+	statuses := make([]MachineConfigPoolStatus, 0, len(pools))
+	for _, status := range results {
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+	}
+
+	mc.log.WithFields(logrus.Fields{
+		"pools":   len(pools),
+		"fetched": len(statuses),
+	}).Debug("MachineConfigPool statuses retrieved")
+
+	return statuses, errors.Join(errs...)
+}
+
+// AreAllPoolsStable reports whether every MachineConfigPool is stable. An error is returned
+// if any pool's status could not be fetched, since its stability is then unknown.
+func (mc *MCOClient) AreAllPoolsStable(ctx context.Context) (bool, error) {
+	statuses, err := mc.GetAllPoolStatuses(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var unstable []string
+	for i := range statuses {
+		if !statuses[i].IsStable() {
+			unstable = append(unstable, statuses[i].Name)
+		}
+	}
+
+	mc.log.WithFields(logrus.Fields{
+		"pools":    len(statuses),
+		"unstable": unstable,
+	}).Debug("All pools stability check")
+
+	return len(unstable) == 0, nil
+}
+
 // WaitForAllPoolsStable waits for all MachineConfigPools to become stable
 func (mc *MCOClient) WaitForAllPoolsStable(ctx context.Context, timeout time.Duration) error {
-	mc.log.WithField("timeout", timeout).Info("Waiting for all MachineConfigPools to stabilize")
+	return mc.WaitForPoolsStable(ctx, PoolSelector{}, timeout)
+}
 
-	pools, err := mc.ListMachineConfigPools(ctx)
+// WaitForPoolsStable waits for the MachineConfigPools matching selector to become stable, so
+// callers can e.g. wait on worker pools while an infra pool is still updating. A non-empty
+// selector that matches no pools is an error, since a mistyped label or name would otherwise
+// look like a stable cluster.
+func (mc *MCOClient) WaitForPoolsStable(ctx context.Context, selector PoolSelector, timeout time.Duration) error {
+	mc.log.WithFields(logrus.Fields{
+		"timeout":        timeout,
+		"label_selector": selector.LabelSelector,
+		"names":          selector.Names,
+	}).Info("Waiting for MachineConfigPools to stabilize")
+
+	pools, err := mc.ListMachineConfigPoolsMatching(ctx, selector)
 	if err != nil {
 		return fmt.Errorf("failed to list pools: %w", err)
 	}
 
 	if len(pools) == 0 {
+		if !selector.isEmpty() {
+			return fmt.Errorf("no MachineConfigPools match label selector %q and names %v",
+				selector.LabelSelector, selector.Names)
+		}
 		mc.log.Warn("No MachineConfigPools found")
 		return nil
 	}
@@ -271,7 +608,7 @@ func (mc *MCOClient) WaitForAllPoolsStable(ctx context.Context, timeout time.Dur
 		}
 	}
 
-	mc.log.Info("All MachineConfigPools are stable")
+	mc.log.WithField("pools", pools).Info("MachineConfigPools are stable")
 	return nil
 }
 
@@ -2,16 +2,20 @@ package integrations
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 // createMachineConfigPool creates a fake MachineConfigPool for testing
@@ -336,6 +340,138 @@ func TestMCOClient_WaitForPoolStable_ContextCancelled(t *testing.T) {
 	assert.Contains(t, err.Error(), "context cancelled")
 }
 
+func TestMCOClient_WaitForPoolStableWithOptions_BecomesStable(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	pool := createMachineConfigPool("worker", 3, 2, 2, 0, true, false)
+
+	scheme := runtime.NewScheme()
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, pool)
+	client := NewMCOClient(dynamicClient, log)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		stable := createMachineConfigPool("worker", 3, 3, 3, 0, false, false)
+		_, _ = dynamicClient.Resource(mcpGVR).Update(context.Background(), stable, metav1.UpdateOptions{})
+	}()
+
+	start := time.Now()
+	err := client.WaitForPoolStableWithOptions(context.Background(), "worker", 5*time.Second,
+		PoolWaitOptions{PollInterval: 10 * time.Millisecond, Jitter: 0.5})
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second, "short poll interval should notice the update quickly")
+}
+
+func TestMCOClient_WaitForPoolStable_TimeoutCutsPollShort(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	pool := createMachineConfigPool("worker", 3, 2, 2, 0, true, false)
+
+	scheme := runtime.NewScheme()
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, pool)
+	client := NewMCOClient(dynamicClient, log)
+
+	// Default 10s interval must not outlive the 100ms timeout
+	start := time.Now()
+	err := client.WaitForPoolStable(context.Background(), "worker", 100*time.Millisecond)
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestMCOClient_WaitForPoolStable_InitialDelay(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	pool := createMachineConfigPool("worker", 3, 3, 3, 0, false, false)
+
+	scheme := runtime.NewScheme()
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, pool)
+	var gets atomic.Int32
+	dynamicClient.PrependReactor("get", "machineconfigpools", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets.Add(1)
+		return false, nil, nil
+	})
+
+	client := NewMCOClient(dynamicClient, log)
+	client.SetPoolWaitOptions(PoolWaitOptions{PollInterval: 10 * time.Millisecond, InitialDelay: 50 * time.Millisecond})
+
+	start := time.Now()
+	err := client.WaitForPoolStable(context.Background(), "worker", 5*time.Second)
+
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "first check should wait for the initial delay")
+	assert.Equal(t, int32(1), gets.Load(), "stable pool should be fetched exactly once")
+
+	// An initial delay longer than the timeout is cut short and the pool is checked once at the deadline
+	gets.Store(0)
+	client.SetPoolWaitOptions(PoolWaitOptions{InitialDelay: time.Minute})
+	err = client.WaitForPoolStable(context.Background(), "worker", 50*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), gets.Load())
+}
+
+// TestMCOClient_WaitForPoolStable_FinalCheckAtDeadline verifies a pool that stabilizes during
+// the last, deadline-truncated wait is still reported stable
+func TestMCOClient_WaitForPoolStable_FinalCheckAtDeadline(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	pool := createMachineConfigPool("worker", 3, 2, 2, 0, true, false)
+
+	scheme := runtime.NewScheme()
+	dynamicClient := fake.NewSimpleDynamicClient(scheme, pool)
+	client := NewMCOClient(dynamicClient, log)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		stable := createMachineConfigPool("worker", 3, 3, 3, 0, false, false)
+		_, _ = dynamicClient.Resource(mcpGVR).Update(context.Background(), stable, metav1.UpdateOptions{})
+	}()
+
+	// The 10s poll interval is cut to the 200ms deadline, after which the pool is checked again
+	err := client.WaitForPoolStableWithOptions(context.Background(), "worker", 200*time.Millisecond,
+		PoolWaitOptions{PollInterval: 10 * time.Second})
+
+	assert.NoError(t, err)
+}
+
+func TestPoolWaitOptions_NextInterval(t *testing.T) {
+	t.Run("defaults to 10s without jitter", func(t *testing.T) {
+		assert.Equal(t, DefaultPoolPollInterval, PoolWaitOptions{}.nextInterval())
+	})
+
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		opts := PoolWaitOptions{PollInterval: time.Second, Jitter: 0.2}
+		seen := make(map[time.Duration]bool)
+		for i := 0; i < 200; i++ {
+			interval := opts.nextInterval()
+			assert.GreaterOrEqual(t, interval, 800*time.Millisecond)
+			assert.LessOrEqual(t, interval, 1200*time.Millisecond)
+			seen[interval] = true
+		}
+		assert.Greater(t, len(seen), 1, "jitter should vary the interval")
+	})
+
+	t.Run("jitter above one is capped", func(t *testing.T) {
+		opts := PoolWaitOptions{PollInterval: time.Second, Jitter: 5}
+		for i := 0; i < 100; i++ {
+			interval := opts.nextInterval()
+			assert.GreaterOrEqual(t, interval, time.Duration(0))
+			assert.LessOrEqual(t, interval, 2*time.Second)
+		}
+	})
+
+	t.Run("default options", func(t *testing.T) {
+		opts := DefaultPoolWaitOptions()
+		assert.Equal(t, 10*time.Second, opts.PollInterval)
+		assert.Zero(t, opts.InitialDelay)
+	})
+}
+
 func TestMCOClient_WaitForAllPoolsStable(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.FatalLevel)
@@ -370,6 +506,89 @@ func TestMCOClient_WaitForAllPoolsStable_OneFails(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to stabilize")
 }
 
+func newLabeledPool(name string, updating bool, labels map[string]interface{}) *unstructured.Unstructured {
+	updated := int32(3)
+	if updating {
+		updated = 2
+	}
+	pool := createMachineConfigPool(name, 3, updated, updated, 0, updating, false)
+	_ = unstructured.SetNestedMap(pool.Object, labels, "metadata", "labels")
+	return pool
+}
+
+func TestMCOClient_ListMachineConfigPoolsMatching(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	workerLabel := map[string]interface{}{WorkerPoolSelector: ""}
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newLabeledPool("master", false, map[string]interface{}{"pools.operator.machineconfiguration.openshift.io/master": ""}),
+		newLabeledPool("worker", false, workerLabel),
+		newLabeledPool("worker-gpu", false, workerLabel),
+		newLabeledPool("infra", true, nil),
+	)
+	client := NewMCOClient(dynamicClient, log)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		selector PoolSelector
+		want     []string
+	}{
+		{name: "all pools", want: []string{"infra", "master", "worker", "worker-gpu"}},
+		{name: "label selector", selector: PoolSelector{LabelSelector: WorkerPoolSelector}, want: []string{"worker", "worker-gpu"}},
+		{name: "name allowlist", selector: PoolSelector{Names: []string{"master", "missing"}}, want: []string{"master"}},
+		{
+			name:     "label selector and allowlist",
+			selector: PoolSelector{LabelSelector: WorkerPoolSelector, Names: []string{"worker", "master"}},
+			want:     []string{"worker"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pools, err := client.ListMachineConfigPoolsMatching(ctx, tt.selector)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, pools)
+		})
+	}
+
+	t.Run("invalid label selector", func(t *testing.T) {
+		_, err := client.ListMachineConfigPoolsMatching(ctx, PoolSelector{LabelSelector: "role in (worker"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid pool label selector")
+	})
+}
+
+// TestMCOClient_WaitForPoolsStable verifies worker pools can be waited on while an infra pool
+// is still updating
+func TestMCOClient_WaitForPoolsStable(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newLabeledPool("worker", false, map[string]interface{}{WorkerPoolSelector: ""}),
+		newLabeledPool("infra", true, nil),
+	)
+	client := NewMCOClient(dynamicClient, log)
+	ctx := context.Background()
+
+	assert.NoError(t, client.WaitForPoolsStable(ctx, PoolSelector{LabelSelector: WorkerPoolSelector}, time.Minute))
+	assert.NoError(t, client.WaitForPoolsStable(ctx, PoolSelector{Names: []string{"worker"}}, time.Minute))
+
+	err := client.WaitForPoolsStable(ctx, PoolSelector{}, 100*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "infra")
+
+	err = client.WaitForPoolsStable(ctx, PoolSelector{LabelSelector: "pools.operator.machineconfiguration.openshift.io/gpu="}, time.Minute)
+	require.Error(t, err, "a selector matching no pools must not report stability")
+	assert.Contains(t, err.Error(), "no MachineConfigPools match")
+
+	err = client.WaitForPoolsStable(ctx, PoolSelector{Names: []string{"wroker"}}, time.Minute)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wroker")
+}
+
 func TestMCOClient_WaitForAllPoolsStable_NoPools(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.FatalLevel)
@@ -387,6 +606,69 @@ func TestMCOClient_WaitForAllPoolsStable_NoPools(t *testing.T) {
 	assert.NoError(t, err) // Should not error when no pools exist
 }
 
+// TestMCOClient_GetAllPoolStatuses verifies every pool's status is returned in list order and
+// AreAllPoolsStable reflects the least stable pool
+func TestMCOClient_GetAllPoolStatuses(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	pools := []runtime.Object{
+		createMachineConfigPool("custom", 2, 2, 2, 0, false, false),
+		createMachineConfigPool("master", 3, 3, 3, 0, false, false),
+		createMachineConfigPool("worker", 5, 4, 4, 0, true, false),
+	}
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), pools...)
+	client := NewMCOClient(dynamicClient, log)
+
+	names, err := client.ListMachineConfigPools(context.Background())
+	require.NoError(t, err)
+
+	statuses, err := client.GetAllPoolStatuses(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for i, status := range statuses {
+		assert.Equal(t, names[i], status.Name)
+	}
+
+	stable, err := client.AreAllPoolsStable(context.Background())
+	require.NoError(t, err)
+	assert.False(t, stable, "worker is updating")
+
+	stableClient := NewMCOClient(fake.NewSimpleDynamicClient(runtime.NewScheme(), pools[0], pools[1]), log)
+	stable, err = stableClient.AreAllPoolsStable(context.Background())
+	require.NoError(t, err)
+	assert.True(t, stable)
+}
+
+// TestMCOClient_GetAllPoolStatuses_PartialFailure verifies a failing pool is reported while the
+// other statuses are still returned, and makes stability unknown
+func TestMCOClient_GetAllPoolStatuses_PartialFailure(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+		createMachineConfigPool("master", 3, 3, 3, 0, false, false),
+		createMachineConfigPool("worker", 3, 3, 3, 0, false, false),
+	)
+	dynamicClient.PrependReactor("get", "machineconfigpools", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.GetAction).GetName() == "worker" {
+			return true, nil, errors.New("etcd timeout")
+		}
+		return false, nil, nil
+	})
+	client := NewMCOClient(dynamicClient, log)
+
+	statuses, err := client.GetAllPoolStatuses(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worker")
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "master", statuses[0].Name)
+
+	stable, err := client.AreAllPoolsStable(context.Background())
+	assert.Error(t, err)
+	assert.False(t, stable)
+}
+
 func TestMCOClient_HealthCheck(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.FatalLevel)
@@ -425,3 +707,85 @@ func TestMCOClient_ParsePoolStatus_MissingStatus(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "status not found")
 }
+
+func newNode(name string, nodeLabels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Node",
+		"metadata":   map[string]interface{}{"name": name, "labels": nodeLabels},
+	}}
+}
+
+func withNodeSelector(pool *unstructured.Unstructured, matchLabels map[string]interface{}) *unstructured.Unstructured {
+	_ = unstructured.SetNestedMap(pool.Object, map[string]interface{}{"matchLabels": matchLabels}, "spec", "nodeSelector")
+	return pool
+}
+
+func TestMCOClient_GetPoolForNode(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	workerRole := map[string]interface{}{"node-role.kubernetes.io/worker": ""}
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+		withNodeSelector(createMachineConfigPool("master", 3, 3, 3, 0, false, false), map[string]interface{}{"node-role.kubernetes.io/master": ""}),
+		withNodeSelector(createMachineConfigPool("worker", 3, 3, 3, 0, false, false), workerRole),
+		withNodeSelector(createMachineConfigPool("infra", 2, 1, 1, 0, true, false), map[string]interface{}{"node-role.kubernetes.io/infra": ""}),
+		withNodeSelector(createMachineConfigPool("gpu", 1, 1, 1, 0, false, false), map[string]interface{}{"gpu": "true"}),
+		createMachineConfigPool("edge", 1, 1, 1, 0, false, false),
+		newNode("master-0", map[string]interface{}{"node-role.kubernetes.io/master": ""}),
+		newNode("worker-0", workerRole),
+		newNode("infra-0", map[string]interface{}{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/infra": ""}),
+		newNode("edge-0", map[string]interface{}{"node-role.kubernetes.io/edge": ""}),
+		newNode("ambiguous-0", map[string]interface{}{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/infra": "", "gpu": "true"}),
+		newNode("orphan-0", map[string]interface{}{"node-role.kubernetes.io/bastion": ""}),
+	)
+	client := NewMCOClient(dynamicClient, log)
+	ctx := context.Background()
+
+	tests := []struct {
+		node string
+		want string
+	}{
+		{node: "master-0", want: "master"},
+		{node: "worker-0", want: "worker"},
+		{node: "infra-0", want: "infra"},
+		{node: "edge-0", want: "edge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.node, func(t *testing.T) {
+			pool, err := client.GetPoolForNode(ctx, tt.node)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pool)
+		})
+	}
+
+	t.Run("more than one custom pool", func(t *testing.T) {
+		_, err := client.GetPoolForNode(ctx, "ambiguous-0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "more than one custom MachineConfigPool")
+	})
+
+	t.Run("no pool", func(t *testing.T) {
+		_, err := client.GetPoolForNode(ctx, "orphan-0")
+		assert.ErrorIs(t, err, ErrNodeHasNoPool)
+	})
+
+	t.Run("missing node", func(t *testing.T) {
+		_, err := client.GetPoolForNode(ctx, "missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get node missing")
+	})
+
+	t.Run("node stability", func(t *testing.T) {
+		stable, err := client.IsNodeStable(ctx, "worker-0")
+		require.NoError(t, err)
+		assert.True(t, stable)
+
+		stable, err = client.IsNodeStable(ctx, "infra-0")
+		require.NoError(t, err)
+		assert.False(t, stable, "infra pool is updating")
+
+		_, err = client.IsNodeStable(ctx, "orphan-0")
+		assert.ErrorIs(t, err, ErrNodeHasNoPool)
+	})
+}
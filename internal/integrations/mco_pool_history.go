@@ -0,0 +1,193 @@
+package integrations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPoolHistorySize is how many status transitions are kept per MachineConfigPool
+const DefaultPoolHistorySize = 50
+
+// DefaultPoolHistoryPollInterval is how often StartPoolHistoryRecorder fetches every pool's status
+const DefaultPoolHistoryPollInterval = 30 * time.Second
+
+// MachineConfigPool states recorded in PoolTransition
+const (
+	PoolStateStable   = "stable"
+	PoolStateUpdating = "updating"
+	PoolStateDegraded = "degraded"
+)
+
+// State summarizes the status as one of the PoolState constants. Degraded wins over updating,
+// and a pool with machines still to update counts as updating.
+func (s *MachineConfigPoolStatus) State() string {
+	switch {
+	case s.Degraded:
+		return PoolStateDegraded
+	case s.IsStable():
+		return PoolStateStable
+	default:
+		return PoolStateUpdating
+	}
+}
+
+// PoolTransition is a change of a MachineConfigPool's state between two observations
+type PoolTransition struct {
+	Pool string `json:"pool"`
+
+	// From is the previously observed state, empty for the first observation of the pool
+	From string `json:"from,omitempty"`
+
+	To string    `json:"to"`
+	At time.Time `json:"at"`
+}
+
+// poolHistory keeps the most recent transitions of each pool in a ring buffer
+type poolHistory struct {
+	mu    sync.Mutex
+	size  int
+	pools map[string]*poolRing
+}
+
+// poolRing holds one pool's last observed state and its transitions; once full, next is the
+// index of the oldest transition, which the following one overwrites
+type poolRing struct {
+	state       string
+	transitions []PoolTransition
+	next        int
+}
+
+func newPoolHistory(size int) *poolHistory {
+	return &poolHistory{size: size, pools: make(map[string]*poolRing)}
+}
+
+// ordered returns the ring's transitions oldest first
+func (r *poolRing) ordered() []PoolTransition {
+	ordered := make([]PoolTransition, 0, len(r.transitions))
+	ordered = append(ordered, r.transitions[r.next:]...)
+	return append(ordered, r.transitions[:r.next]...)
+}
+
+// record notes the pool's state at observedAt, returning the transition if it changed
+func (h *poolHistory) record(pool, state string, observedAt time.Time) (PoolTransition, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ring, ok := h.pools[pool]
+	if !ok {
+		ring = &poolRing{}
+		h.pools[pool] = ring
+	}
+	if ring.state == state {
+		return PoolTransition{}, false
+	}
+
+	transition := PoolTransition{Pool: pool, From: ring.state, To: state, At: observedAt}
+	ring.state = state
+	if len(ring.transitions) < h.size {
+		ring.transitions = append(ring.transitions, transition)
+		return transition, true
+	}
+	ring.transitions[ring.next] = transition
+	ring.next = (ring.next + 1) % len(ring.transitions)
+	return transition, true
+}
+
+// since returns the pool's transitions at or after since, oldest first
+func (h *poolHistory) since(pool string, since time.Time) []PoolTransition {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ring, ok := h.pools[pool]
+	if !ok {
+		return nil
+	}
+	var transitions []PoolTransition
+	for _, transition := range ring.ordered() {
+		if !transition.At.Before(since) {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions
+}
+
+// resize changes the per-pool bound, keeping the most recent transitions of each pool
+func (h *poolHistory) resize(size int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.size = size
+	for _, ring := range h.pools {
+		ordered := ring.ordered()
+		ring.transitions = ordered[max(len(ordered)-size, 0):]
+		ring.next = 0
+	}
+}
+
+// SetPoolHistorySize bounds how many transitions GetPoolHistory keeps per pool; older ones are
+// dropped. A size of 0 or less restores DefaultPoolHistorySize.
+func (mc *MCOClient) SetPoolHistorySize(size int) {
+	if size <= 0 {
+		size = DefaultPoolHistorySize
+	}
+	mc.history.resize(size)
+}
+
+// RecordPoolStatus adds a status observed at observedAt to the pool's history. GetPoolStatus
+// records every status it fetches, including those of StartPoolHistoryRecorder's polls;
+// callers that learn of pool changes some other way, such as a watch, can record them here.
+func (mc *MCOClient) RecordPoolStatus(status *MachineConfigPoolStatus, observedAt time.Time) {
+	transition, changed := mc.history.record(status.Name, status.State(), observedAt)
+	if !changed || transition.From == "" {
+		return
+	}
+	mc.log.WithFields(logrus.Fields{
+		"pool": transition.Pool,
+		"from": transition.From,
+		"to":   transition.To,
+	}).Info("MachineConfigPool state changed")
+}
+
+// StartPoolHistoryRecorder fetches every pool's status each interval (0 or less =
+// DefaultPoolHistoryPollInterval), so the history shows transitions even when nothing else asks
+// for pool status. The returned func stops the recorder and waits for an in-flight poll.
+func (mc *MCOClient) StartPoolHistoryRecorder(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = DefaultPoolHistoryPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// GetPoolStatus records each status it fetches
+			if _, err := mc.GetAllPoolStatuses(ctx); err != nil && ctx.Err() == nil {
+				mc.log.WithError(err).Debug("Failed to poll MachineConfigPool statuses for history")
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// GetPoolHistory returns the pool's recorded state transitions at or after since, oldest
+// first, so callers can spot a pool flapping between degraded and stable rather than act on
+// one snapshot. Only observed statuses are recorded: a pool that changed and changed back
+// between two observations shows no transition.
+func (mc *MCOClient) GetPoolHistory(poolName string, since time.Time) []PoolTransition {
+	return mc.history.since(poolName, since)
+}
@@ -0,0 +1,123 @@
+package integrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestMachineConfigPoolStatus_State(t *testing.T) {
+	assert.Equal(t, PoolStateStable, (&MachineConfigPoolStatus{MachineCount: 3, UpdatedMachineCount: 3}).State())
+	assert.Equal(t, PoolStateUpdating, (&MachineConfigPoolStatus{MachineCount: 3, UpdatedMachineCount: 2, Updating: true}).State())
+	assert.Equal(t, PoolStateUpdating, (&MachineConfigPoolStatus{MachineCount: 3, UpdatedMachineCount: 2}).State())
+	assert.Equal(t, PoolStateDegraded, (&MachineConfigPoolStatus{MachineCount: 3, UpdatedMachineCount: 2, Updating: true, Degraded: true}).State())
+}
+
+func TestMCOClient_GetPoolHistory(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	stable := &MachineConfigPoolStatus{Name: "worker", MachineCount: 3, UpdatedMachineCount: 3}
+	degraded := &MachineConfigPoolStatus{Name: "worker", MachineCount: 3, UpdatedMachineCount: 3, Degraded: true}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("records state changes only", func(t *testing.T) {
+		client := NewMCOClient(fake.NewSimpleDynamicClient(runtime.NewScheme()), log)
+		client.RecordPoolStatus(stable, start)
+		client.RecordPoolStatus(stable, start.Add(time.Minute))
+		client.RecordPoolStatus(degraded, start.Add(2*time.Minute))
+		client.RecordPoolStatus(stable, start.Add(3*time.Minute))
+		client.RecordPoolStatus(&MachineConfigPoolStatus{Name: "master", MachineCount: 3, UpdatedMachineCount: 3}, start)
+
+		assert.Equal(t, []PoolTransition{
+			{Pool: "worker", To: PoolStateStable, At: start},
+			{Pool: "worker", From: PoolStateStable, To: PoolStateDegraded, At: start.Add(2 * time.Minute)},
+			{Pool: "worker", From: PoolStateDegraded, To: PoolStateStable, At: start.Add(3 * time.Minute)},
+		}, client.GetPoolHistory("worker", time.Time{}))
+
+		recent := client.GetPoolHistory("worker", start.Add(2*time.Minute))
+		require.Len(t, recent, 2)
+		assert.Equal(t, PoolStateDegraded, recent[0].To)
+		assert.Nil(t, client.GetPoolHistory("infra", time.Time{}))
+	})
+
+	t.Run("bounded per pool", func(t *testing.T) {
+		client := NewMCOClient(fake.NewSimpleDynamicClient(runtime.NewScheme()), log)
+		client.SetPoolHistorySize(3)
+		for i := range 7 {
+			status := stable
+			if i%2 == 1 {
+				status = degraded
+			}
+			client.RecordPoolStatus(status, start.Add(time.Duration(i)*time.Minute))
+		}
+
+		history := client.GetPoolHistory("worker", time.Time{})
+		require.Len(t, history, 3)
+		for i, transition := range history {
+			assert.Equal(t, start.Add(time.Duration(4+i)*time.Minute), transition.At, "oldest transitions are dropped")
+		}
+
+		client.SetPoolHistorySize(2)
+		history = client.GetPoolHistory("worker", time.Time{})
+		require.Len(t, history, 2)
+		assert.Equal(t, start.Add(5*time.Minute), history[0].At)
+		assert.Equal(t, start.Add(6*time.Minute), history[1].At)
+
+		client.RecordPoolStatus(degraded, start.Add(7*time.Minute))
+		history = client.GetPoolHistory("worker", time.Time{})
+		require.Len(t, history, 2)
+		assert.Equal(t, start.Add(7*time.Minute), history[1].At)
+	})
+
+	t.Run("fetched statuses are recorded", func(t *testing.T) {
+		pool := createMachineConfigPool("worker", 3, 3, 3, 0, false, false)
+		dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), pool)
+		client := NewMCOClient(dynamicClient, log)
+		ctx := context.Background()
+
+		_, err := client.GetPoolStatus(ctx, "worker")
+		require.NoError(t, err)
+		_, err = dynamicClient.Resource(mcpGVR).Update(ctx, createMachineConfigPool("worker", 3, 3, 2, 1, false, true), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		stable, err := client.IsPoolStable(ctx, "worker")
+		require.NoError(t, err)
+		assert.False(t, stable)
+
+		history := client.GetPoolHistory("worker", time.Time{})
+		require.Len(t, history, 2)
+		assert.Equal(t, PoolStateStable, history[0].To)
+		assert.Equal(t, PoolStateDegraded, history[1].To)
+	})
+
+	t.Run("recorder polls every pool until stopped", func(t *testing.T) {
+		dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme(),
+			createMachineConfigPool("worker", 3, 3, 3, 0, false, false),
+			createMachineConfigPool("master", 3, 3, 3, 0, false, false))
+		client := NewMCOClient(dynamicClient, log)
+		ctx := context.Background()
+
+		stop := client.StartPoolHistoryRecorder(5 * time.Millisecond)
+		require.Eventually(t, func() bool {
+			return len(client.GetPoolHistory("worker", time.Time{})) == 1 && len(client.GetPoolHistory("master", time.Time{})) == 1
+		}, time.Second, time.Millisecond, "the first poll runs at start")
+
+		_, err := dynamicClient.Resource(mcpGVR).Update(ctx, createMachineConfigPool("worker", 3, 3, 2, 1, false, true), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return len(client.GetPoolHistory("worker", time.Time{})) == 2
+		}, time.Second, time.Millisecond, "later changes are picked up without a caller")
+
+		stop()
+		_, err = dynamicClient.Resource(mcpGVR).Update(ctx, createMachineConfigPool("worker", 3, 3, 3, 0, false, false), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		assert.Len(t, client.GetPoolHistory("worker", time.Time{}), 2, "nothing is recorded after stop")
+	})
+}
@@ -0,0 +1,106 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Aggregations of a scope's recent CPU and memory utilization
+const (
+	// MetricAggregationMean is the current utilization averaged over the rate window, as
+	// GetScopedCPURollingMean and GetScopedMemoryRollingMean report it
+	MetricAggregationMean = "mean"
+
+	// MetricAggregationP95 is the 95th percentile over MetricAggregationWindow, which follows
+	// the spikes that cause throttling or OOM kills
+	MetricAggregationP95 = "p95"
+
+	// MetricAggregationMax is the peak over MetricAggregationWindow
+	MetricAggregationMax = "max"
+)
+
+// MetricAggregationWindow is how far back p95 and max look, sampled every
+// metricAggregationStep
+const MetricAggregationWindow = time.Hour
+
+const metricAggregationStep = 5 * time.Minute
+
+// MetricAggregations returns the accepted metric aggregations
+func MetricAggregations() []string {
+	return []string{MetricAggregationMean, MetricAggregationP95, MetricAggregationMax}
+}
+
+// aggregateOverTime wraps an instant utilization query in a subquery taking aggregation over
+// MetricAggregationWindow
+func aggregateOverTime(query, aggregation string) string {
+	subquery := fmt.Sprintf("(%s)[%s:%s]", query, formatDurationForPromQL(MetricAggregationWindow), formatDurationForPromQL(metricAggregationStep))
+	if aggregation == MetricAggregationMax {
+		return "max_over_time(" + subquery + ")"
+	}
+	return "quantile_over_time(0.95, " + subquery + ")"
+}
+
+// GetScopedCPUAggregate returns scoped CPU utilization (0-1 of cluster allocatable) under
+// aggregation, one of MetricAggregations; empty means MetricAggregationMean. Filters work as
+// in GetScopedCPURollingMean, and all-empty filters cover the whole cluster.
+func (c *PrometheusClient) GetScopedCPUAggregate(ctx context.Context, namespace, deployment, pod, aggregation string) (float64, error) {
+	if aggregation == "" || aggregation == MetricAggregationMean {
+		return c.GetScopedCPURollingMean(ctx, namespace, deployment, pod)
+	}
+	return c.queryScopedAggregate(ctx, "cpu", aggregation,
+		c.buildScopedCPUQuery(namespace, deployment, pod),
+		c.buildScopedCPUQueryFallback(namespace, deployment, pod))
+}
+
+// GetScopedMemoryAggregate returns scoped memory utilization (0-1 of cluster allocatable)
+// under aggregation, like GetScopedCPUAggregate
+func (c *PrometheusClient) GetScopedMemoryAggregate(ctx context.Context, namespace, deployment, pod, aggregation string) (float64, error) {
+	if aggregation == "" || aggregation == MetricAggregationMean {
+		return c.GetScopedMemoryRollingMean(ctx, namespace, deployment, pod)
+	}
+	return c.queryScopedAggregate(ctx, "memory", aggregation,
+		c.buildScopedMemoryQuery(namespace, deployment, pod),
+		c.buildScopedMemoryQueryFallback(namespace, deployment, pod))
+}
+
+// queryScopedAggregate runs the primary utilization query under aggregation, falling back to
+// the query that does not need kube-state-metrics, and caches the clamped result
+func (c *PrometheusClient) queryScopedAggregate(ctx context.Context, metric, aggregation, query, fallbackQuery string) (float64, error) {
+	if !c.IsAvailable() {
+		return 0, fmt.Errorf("prometheus client not available")
+	}
+	if !slices.Contains(MetricAggregations(), aggregation) {
+		return 0, fmt.Errorf("unknown metric aggregation %q (supported: %v)", aggregation, MetricAggregations())
+	}
+
+	query = aggregateOverTime(query, aggregation)
+	cacheKey := metric + "_" + aggregation + "_" + query
+	if value, ok := c.getCached(cacheKey); ok {
+		return value, nil
+	}
+
+	value, err := c.queryInstant(ctx, query)
+	if err != nil {
+		c.log.WithError(err).Debugf("Primary scoped %s %s query failed, trying fallback", metric, aggregation)
+		value, err = c.queryInstant(ctx, aggregateOverTime(fallbackQuery, aggregation))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	normalizedValue := clampToUnitRange(value)
+	c.setCached(cacheKey, normalizedValue)
+
+	c.log.WithFields(logrus.Fields{
+		"metric":           metric,
+		"aggregation":      aggregation,
+		"raw_value":        value,
+		"normalized_value": normalizedValue,
+	}).Debug("Retrieved scoped utilization aggregate from Prometheus")
+
+	return normalizedValue, nil
+}
@@ -0,0 +1,250 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMetricsSnapshotTTL is how long a scope's rolling means are reused
+const DefaultMetricsSnapshotTTL = 10 * time.Second
+
+// MetricsScope identifies the workload a snapshot covers. The zero value is the whole cluster.
+type MetricsScope struct {
+	Namespace  string
+	Deployment string
+	Pod        string
+}
+
+// RollingMeans holds the CPU and memory rolling means (0-1) for one scope. A value is only
+// meaningful when its OK flag is set.
+type RollingMeans struct {
+	CPU      float64
+	Memory   float64
+	CPUOK    bool
+	MemoryOK bool
+}
+
+// MetricsSnapshot shares recent rolling means between API handlers. Results are cached per
+// scope for a short TTL, and concurrent requests for a scope that is not cached wait for a
+// single pair of Prometheus queries instead of issuing their own. It is safe for concurrent use.
+type MetricsSnapshot struct {
+	client *PrometheusClient
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	observer func(MetricsScope, RollingMeans)
+	entries  map[snapshotKey]snapshotEntry
+	inflight map[snapshotKey]*snapshotCall
+}
+
+// snapshotKey identifies a cached result: a scope under one of MetricAggregations
+type snapshotKey struct {
+	scope       MetricsScope
+	aggregation string
+}
+
+type snapshotEntry struct {
+	means     RollingMeans
+	expiresAt time.Time
+}
+
+// snapshotCall is a query in progress; done is closed once means and err are set
+type snapshotCall struct {
+	done  chan struct{}
+	means RollingMeans
+	err   error
+}
+
+// NewMetricsSnapshot creates a snapshot over client. A non-positive ttl disables caching but
+// still collapses concurrent queries for the same scope.
+func NewMetricsSnapshot(client *PrometheusClient, ttl time.Duration) *MetricsSnapshot {
+	return &MetricsSnapshot{
+		client:   client,
+		ttl:      ttl,
+		entries:  make(map[snapshotKey]snapshotEntry),
+		inflight: make(map[snapshotKey]*snapshotCall),
+	}
+}
+
+// SetObserver registers fn to receive each scope's rolling means as they are freshly queried
+// from Prometheus. Results served from this snapshot's or the client's cache, shared with
+// concurrent callers or memoized for a request are not passed again, and only freshly fetched
+// metrics are marked OK. Other aggregations are not observed. nil removes the observer.
+func (s *MetricsSnapshot) SetObserver(fn func(MetricsScope, RollingMeans)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observer = fn
+}
+
+// IsAvailable reports whether the underlying Prometheus client is configured
+func (s *MetricsSnapshot) IsAvailable() bool {
+	return s != nil && s.client.IsAvailable()
+}
+
+// requestMemoKey carries a *requestMemo in a context
+type requestMemoKey struct{}
+
+// requestMemo pins each scope's rolling means for the lifetime of one request
+type requestMemo struct {
+	mu    sync.Mutex
+	calls map[snapshotKey]*memoCall
+}
+
+type memoCall struct {
+	once  sync.Once
+	means RollingMeans
+	err   error
+}
+
+// WithRequestMemo returns a context under which RollingMeans answers each scope once: later
+// lookups under the context get the first result, even after it expires from the snapshot.
+// Handlers that serve one request together use it so they all see the same moment in time
+// and Prometheus is queried once per scope.
+func WithRequestMemo(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestMemoKey{}, &requestMemo{calls: make(map[snapshotKey]*memoCall)})
+}
+
+// RollingMeans returns the CPU and memory rolling means for scope. A failed CPU query does not
+// prevent the memory query, and vice versa; the error joins the failures of any metric that
+// could not be fetched. Only complete results are cached.
+func (s *MetricsSnapshot) RollingMeans(ctx context.Context, scope MetricsScope) (RollingMeans, error) {
+	return s.Aggregated(ctx, scope, MetricAggregationMean)
+}
+
+// Aggregated returns the scope's CPU and memory utilization under aggregation, one of
+// MetricAggregations, e.g. the p95 over the last hour instead of the rolling mean. It is
+// cached, shared and memoized like RollingMeans, separately per aggregation.
+func (s *MetricsSnapshot) Aggregated(ctx context.Context, scope MetricsScope, aggregation string) (RollingMeans, error) {
+	if !s.IsAvailable() {
+		return RollingMeans{}, fmt.Errorf("prometheus client not available")
+	}
+	key := snapshotKey{scope: scope, aggregation: aggregation}
+
+	memo, ok := ctx.Value(requestMemoKey{}).(*requestMemo)
+	if !ok {
+		return s.rollingMeans(ctx, key)
+	}
+	memo.mu.Lock()
+	call, found := memo.calls[key]
+	if !found {
+		call = &memoCall{}
+		memo.calls[key] = call
+	}
+	memo.mu.Unlock()
+
+	call.once.Do(func() {
+		call.means, call.err = s.rollingMeans(ctx, key)
+	})
+	return call.means, call.err
+}
+
+// rollingMeans serves key from the cache, an in-flight query or a new query
+func (s *MetricsSnapshot) rollingMeans(ctx context.Context, key snapshotKey) (RollingMeans, error) {
+
+	s.mu.Lock()
+	if entry, ok := s.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		s.mu.Unlock()
+		return entry.means, nil
+	}
+	call, running := s.inflight[key]
+	if !running {
+		call = &snapshotCall{done: make(chan struct{})}
+		s.inflight[key] = call
+	}
+	s.mu.Unlock()
+
+	if !running {
+		// The shared query must not fail for every waiter when the first caller goes away, and
+		// runs in the background so the first caller still honors its own deadline
+		go func(ctx context.Context) {
+			var fresh RollingMeans
+			call.means, fresh, call.err = s.query(ctx, key)
+
+			s.mu.Lock()
+			delete(s.inflight, key)
+			if call.err == nil && s.ttl > 0 {
+				s.entries[key] = snapshotEntry{means: call.means, expiresAt: time.Now().Add(s.ttl)}
+				s.pruneUnsafe()
+			}
+			observer := s.observer
+			s.mu.Unlock()
+
+			if observer != nil && key.aggregation == MetricAggregationMean && (fresh.CPUOK || fresh.MemoryOK) {
+				observer(key.scope, fresh)
+			}
+			close(call.done)
+		}(context.WithoutCancel(ctx))
+	}
+
+	select {
+	case <-call.done:
+		return call.means, call.err
+	case <-ctx.Done():
+		return RollingMeans{}, ctx.Err()
+	}
+}
+
+// pruneUnsafe drops expired entries so one-off scopes don't accumulate. Caller holds s.mu.
+func (s *MetricsSnapshot) pruneUnsafe() {
+	now := time.Now()
+	for key, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// query fetches both metrics for key's scope and aggregation from Prometheus. Cluster-wide
+// means use the cluster rolling mean queries; other aggregations use the scoped queries
+// without filters. fresh holds the metrics that were not served from the client's cache.
+func (s *MetricsSnapshot) query(ctx context.Context, key snapshotKey) (means, fresh RollingMeans, err error) {
+	scope := key.scope
+	cpuQuery, memoryQuery := s.client.GetCPURollingMean, s.client.GetMemoryRollingMean
+	scopeName := "cluster"
+	if scope != (MetricsScope{}) {
+		scopeName = scope.name()
+	}
+	if scope != (MetricsScope{}) || key.aggregation != MetricAggregationMean {
+		cpuQuery = func(ctx context.Context) (float64, error) {
+			return s.client.GetScopedCPUAggregate(ctx, scope.Namespace, scope.Deployment, scope.Pod, key.aggregation)
+		}
+		memoryQuery = func(ctx context.Context) (float64, error) {
+			return s.client.GetScopedMemoryAggregate(ctx, scope.Namespace, scope.Deployment, scope.Pod, key.aggregation)
+		}
+	}
+
+	var errs []error
+
+	cpuCtx, cpuCached := withCacheHitReport(ctx)
+	if value, err := cpuQuery(cpuCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to get %s CPU metrics: %w", scopeName, err))
+	} else {
+		means.CPU, means.CPUOK = value, true
+		fresh.CPU, fresh.CPUOK = value, !*cpuCached
+	}
+
+	memoryCtx, memoryCached := withCacheHitReport(ctx)
+	if value, err := memoryQuery(memoryCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to get %s memory metrics: %w", scopeName, err))
+	} else {
+		means.Memory, means.MemoryOK = value, true
+		fresh.Memory, fresh.MemoryOK = value, !*memoryCached
+	}
+
+	return means, fresh, errors.Join(errs...)
+}
+
+// name returns the narrowest level the scope filters on, for error messages
+func (s MetricsScope) name() string {
+	switch {
+	case s.Pod != "":
+		return "pod"
+	case s.Deployment != "":
+		return "deployment"
+	default:
+		return "namespace"
+	}
+}
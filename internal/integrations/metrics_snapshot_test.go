@@ -0,0 +1,337 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeVectorResponse(w http.ResponseWriter, value string) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"` + value + `"]}]}}`))
+}
+
+// TestMetricsSnapshot_ConcurrentCallsShareQueries verifies concurrent callers for one scope
+// wait for a single pair of Prometheus queries
+func TestMetricsSnapshot_ConcurrentCallsShareQueries(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		writeVectorResponse(w, "0.5")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, time.Minute)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	results := make([]RollingMeans, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = snapshot.RollingMeans(context.Background(), MetricsScope{Namespace: "payments"})
+		}(i)
+	}
+
+	require.Eventually(t, func() bool { return requests.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond) // let the remaining callers join the in-flight query
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), requests.Load(), "one CPU and one memory query for all callers")
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		assert.True(t, results[i].CPUOK)
+		assert.True(t, results[i].MemoryOK)
+		assert.InDelta(t, 0.5, results[i].CPU, 0.0001)
+	}
+}
+
+// TestMetricsSnapshot_LeaderHonorsDeadline verifies the caller that starts a shared query
+// returns at its own deadline while the query keeps running for the callers still waiting
+func TestMetricsSnapshot_LeaderHonorsDeadline(t *testing.T) {
+	release := make(chan struct{})
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		writeVectorResponse(w, "0.5")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := snapshot.RollingMeans(ctx, MetricsScope{Namespace: "payments"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second, "leader must not wait for the slow query")
+
+	done := make(chan struct{})
+	var means RollingMeans
+	var followerErr error
+	go func() {
+		defer close(done)
+		means, followerErr = snapshot.RollingMeans(context.Background(), MetricsScope{Namespace: "payments"})
+	}()
+	close(release)
+	<-done
+
+	require.NoError(t, followerErr)
+	assert.True(t, means.CPUOK)
+	assert.InDelta(t, 0.5, means.CPU, 0.0001)
+}
+
+// TestMetricsSnapshot_ReusesWithinTTL verifies complete results are kept per scope until they expire
+func TestMetricsSnapshot_ReusesWithinTTL(t *testing.T) {
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeVectorResponse(w, "0.3")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, time.Minute)
+	scope := MetricsScope{Namespace: "payments", Deployment: "api"}
+
+	means, err := snapshot.RollingMeans(context.Background(), scope)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.3, means.Memory, 0.0001)
+
+	snapshot.mu.Lock()
+	entry, ok := snapshot.entries[snapshotKey{scope: scope, aggregation: MetricAggregationMean}]
+	snapshot.mu.Unlock()
+	require.True(t, ok)
+	assert.Equal(t, means, entry.means)
+
+	// An expired entry is queried again and replaced
+	snapshot.mu.Lock()
+	snapshot.entries[snapshotKey{scope: scope, aggregation: MetricAggregationMean}] = snapshotEntry{means: RollingMeans{CPU: 0.9, CPUOK: true}, expiresAt: time.Now().Add(-time.Second)}
+	snapshot.mu.Unlock()
+
+	means, err = snapshot.RollingMeans(context.Background(), scope)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.3, means.CPU, 0.0001)
+}
+
+// TestMetricsSnapshot_ObserverSeesFreshResultsOnly verifies the observer is passed each
+// Prometheus result once, not again when it is served from the snapshot or client cache
+func TestMetricsSnapshot_ObserverSeesFreshResultsOnly(t *testing.T) {
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeVectorResponse(w, "0.3")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, time.Minute)
+	var observed []RollingMeans
+	snapshot.SetObserver(func(_ MetricsScope, means RollingMeans) {
+		observed = append(observed, means)
+	})
+	scope := MetricsScope{Namespace: "payments"}
+	key := snapshotKey{scope: scope, aggregation: MetricAggregationMean}
+	expire := func() {
+		snapshot.mu.Lock()
+		delete(snapshot.entries, key)
+		snapshot.mu.Unlock()
+	}
+
+	_, err := snapshot.RollingMeans(context.Background(), scope)
+	require.NoError(t, err)
+	require.Len(t, observed, 1)
+	assert.True(t, observed[0].CPUOK)
+	assert.True(t, observed[0].MemoryOK)
+	assert.InDelta(t, 0.3, observed[0].CPU, 0.0001)
+
+	_, err = snapshot.RollingMeans(context.Background(), scope)
+	require.NoError(t, err)
+	assert.Len(t, observed, 1, "snapshot cache hit")
+
+	expire()
+	_, err = snapshot.RollingMeans(context.Background(), scope)
+	require.NoError(t, err)
+	assert.Len(t, observed, 1, "client cache hit")
+
+	expire()
+	client.ClearCache()
+	_, err = snapshot.RollingMeans(context.Background(), scope)
+	require.NoError(t, err)
+	assert.Len(t, observed, 2, "fresh query after both caches expired")
+
+	_, err = snapshot.Aggregated(context.Background(), scope, MetricAggregationP95)
+	require.NoError(t, err)
+	assert.Len(t, observed, 2, "other aggregations are not observed")
+}
+
+// TestMetricsSnapshot_ZeroTTLDoesNotCache verifies a non-positive TTL keeps no entries
+func TestMetricsSnapshot_ZeroTTLDoesNotCache(t *testing.T) {
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeVectorResponse(w, "0.3")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, 0)
+	_, err := snapshot.RollingMeans(context.Background(), MetricsScope{})
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.entries)
+}
+
+// TestMetricsSnapshot_PartialFailureNotCached verifies a failed metric is reported and retried
+// on the next call while the other metric is still returned
+func TestMetricsSnapshot_PartialFailureNotCached(t *testing.T) {
+	var memoryRequests atomic.Int32
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("query"), "memory") {
+			memoryRequests.Add(1)
+			http.Error(w, "query timed out", http.StatusServiceUnavailable)
+			return
+		}
+		writeVectorResponse(w, "0.42")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, time.Minute)
+	scope := MetricsScope{Namespace: "payments", Pod: "api-0"}
+
+	means, err := snapshot.RollingMeans(context.Background(), scope)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get pod memory metrics")
+	assert.True(t, means.CPUOK)
+	assert.False(t, means.MemoryOK)
+	assert.InDelta(t, 0.42, means.CPU, 0.0001)
+
+	first := memoryRequests.Load()
+	_, err = snapshot.RollingMeans(context.Background(), scope)
+	require.Error(t, err)
+	assert.Greater(t, memoryRequests.Load(), first, "failed results must not be cached")
+}
+
+// TestMetricsSnapshot_RequestMemo verifies lookups under one request context reuse the first
+// result, including a partial one, while other contexts query again
+func TestMetricsSnapshot_RequestMemo(t *testing.T) {
+	var requests atomic.Int32
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if strings.Contains(r.URL.Query().Get("query"), "memory") {
+			http.Error(w, "query timed out", http.StatusServiceUnavailable)
+			return
+		}
+		writeVectorResponse(w, "0.42")
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, 0)
+	scope := MetricsScope{Namespace: "payments"}
+	ctx := WithRequestMemo(context.Background())
+
+	first, firstErr := snapshot.RollingMeans(ctx, scope)
+	require.Error(t, firstErr)
+	queried := requests.Load()
+
+	second, secondErr := snapshot.RollingMeans(ctx, scope)
+	assert.Equal(t, first, second)
+	assert.Equal(t, firstErr, secondErr)
+	assert.Equal(t, queried, requests.Load(), "the request's second lookup does not query Prometheus")
+
+	_, err := snapshot.RollingMeans(ctx, MetricsScope{Namespace: "billing"})
+	require.Error(t, err)
+	assert.Greater(t, requests.Load(), queried, "other scopes are looked up")
+
+	queried = requests.Load()
+	_, err = snapshot.RollingMeans(context.Background(), scope)
+	require.Error(t, err)
+	assert.Greater(t, requests.Load(), queried, "other requests are not served from the memo")
+}
+
+// TestMetricsSnapshot_ClusterScope verifies the zero scope uses the cluster-wide queries
+func TestMetricsSnapshot_ClusterScope(t *testing.T) {
+	var queries []string
+	var mu sync.Mutex
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("query"))
+		mu.Unlock()
+		writeVectorResponse(w, "0.2")
+	})
+	defer server.Close()
+
+	_, err := NewMetricsSnapshot(client, time.Minute).RollingMeans(context.Background(), MetricsScope{})
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, queries, 2)
+	for _, query := range queries {
+		assert.NotContains(t, query, "namespace=")
+	}
+}
+
+// TestMetricsSnapshot_Aggregated verifies p95 and max are queried over the aggregation window
+// and cached apart from the rolling means
+func TestMetricsSnapshot_Aggregated(t *testing.T) {
+	var queries []string
+	var mu sync.Mutex
+	client, server := newTestPrometheusClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		mu.Lock()
+		queries = append(queries, query)
+		mu.Unlock()
+		switch {
+		case strings.HasPrefix(query, "quantile_over_time(0.95, ("):
+			writeVectorResponse(w, "0.8")
+		case strings.HasPrefix(query, "max_over_time("):
+			writeVectorResponse(w, "0.9")
+		default:
+			writeVectorResponse(w, "0.4")
+		}
+	})
+	defer server.Close()
+
+	snapshot := NewMetricsSnapshot(client, time.Minute)
+	scope := MetricsScope{Namespace: "payments"}
+	ctx := context.Background()
+
+	means, err := snapshot.RollingMeans(ctx, scope)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, means.CPU, 0.0001)
+
+	p95, err := snapshot.Aggregated(ctx, scope, MetricAggregationP95)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.8, p95.CPU, 0.0001)
+	assert.InDelta(t, 0.8, p95.Memory, 0.0001)
+
+	peak, err := snapshot.Aggregated(ctx, MetricsScope{}, MetricAggregationMax)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.9, peak.CPU, 0.0001)
+
+	_, err = snapshot.Aggregated(ctx, scope, MetricAggregationP95)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, queries, 6, "the repeated p95 lookup is served from the cache")
+	assert.Contains(t, queries[2], `namespace="payments"`)
+	assert.Contains(t, queries[2], "[1h:5m])")
+
+	_, err = client.GetScopedCPUAggregate(ctx, "payments", "", "", "median")
+	assert.Error(t, err)
+}
+
+// TestMetricsSnapshot_Unavailable verifies an unconfigured client fails without querying
+func TestMetricsSnapshot_Unavailable(t *testing.T) {
+	snapshot := NewMetricsSnapshot(nil, time.Minute)
+	assert.False(t, snapshot.IsAvailable())
+
+	_, err := snapshot.RollingMeans(context.Background(), MetricsScope{})
+	assert.Error(t, err)
+
+	var nilSnapshot *MetricsSnapshot
+	assert.False(t, nilSnapshot.IsAvailable())
+}
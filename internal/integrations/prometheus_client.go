@@ -4,13 +4,16 @@ package integrations
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,6 +22,13 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// DeploymentPodSelector returns a PromQL label matcher for the pods of a deployment, which
+// are named "<deployment>-<hash>". The name is regex-escaped so characters such as '.' only
+// match themselves.
+func DeploymentPodSelector(deployment string) string {
+	return fmt.Sprintf("pod=~%q", regexp.QuoteMeta(deployment)+"-.*")
+}
+
 // ScopeType defines the scope of metric queries
 type ScopeType string
 
@@ -67,11 +77,52 @@ type TrendAnalysis struct {
 	Confidence          float64   `json:"confidence"` // 0.0-1.0
 }
 
+// MaxNetworkBytesPerSec is the throughput scoped network rates are normalized against
+// (1 Gbps = 125MB/s): GetScopedNetworkIn and GetScopedNetworkOut return value/MaxNetworkBytesPerSec
+const MaxNetworkBytesPerSec = 125000000.0
+
+// DefaultServiceAccountTokenFile is the in-cluster service account token path
+const DefaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// PrometheusClientOptions configures TLS and authentication for Prometheus/Thanos queries
+type PrometheusClientOptions struct {
+	// RootCAFile is a PEM bundle used to verify the Prometheus server certificate.
+	// When empty, the system root CAs are used.
+	RootCAFile string
+
+	// InsecureSkipVerify disables server certificate verification.
+	// Ignored when RootCAFile is set.
+	InsecureSkipVerify bool
+
+	// BearerTokenFile is read on every request so rotated service account tokens
+	// are picked up without restarting. Empty disables the Authorization header.
+	BearerTokenFile string
+
+	// FallbackURLs are tried in order when the base URL cannot be reached or answers 502-504,
+	// e.g. the other replicas of Thanos Query. They share the TLS and token settings.
+	FallbackURLs []string
+
+	// EndpointCooldown is how long a failed endpoint is skipped.
+	// 0 uses DefaultPrometheusEndpointCooldown.
+	EndpointCooldown time.Duration
+}
+
+// DefaultPrometheusClientOptions returns options matching the historical in-cluster behavior:
+// skip certificate verification and authenticate with the pod's service account token.
+func DefaultPrometheusClientOptions() PrometheusClientOptions {
+	return PrometheusClientOptions{
+		InsecureSkipVerify: true,
+		BearerTokenFile:    DefaultServiceAccountTokenFile,
+	}
+}
+
 // PrometheusClient queries Prometheus for cluster metrics
 type PrometheusClient struct {
-	baseURL    string
-	httpClient *http.Client
-	log        *logrus.Logger
+	endpoints        []*prometheusEndpoint // In failover order; the base URL first
+	endpointCooldown time.Duration
+	httpClient       *http.Client
+	log              *logrus.Logger
+	bearerTokenFile  string
 
 	// Cache for rolling mean values with TTL
 	cache    map[string]cachedMetric
@@ -99,33 +150,91 @@ type PrometheusQueryResponse struct {
 	ErrorType string `json:"errorType,omitempty"`
 }
 
-// NewPrometheusClient creates a new Prometheus query client
+// ErrPrometheusNotConfigured is returned when a Prometheus client is created without a URL
+var ErrPrometheusNotConfigured = errors.New("prometheus URL not configured")
+
+// NewPrometheusClient creates a new Prometheus query client using DefaultPrometheusClientOptions.
+// It returns nil when baseURL is empty.
 func NewPrometheusClient(baseURL string, timeout time.Duration, log *logrus.Logger) *PrometheusClient {
-	if baseURL == "" {
+	client, err := NewPrometheusClientWithOptions(baseURL, timeout, log, DefaultPrometheusClientOptions())
+	if errors.Is(err, ErrPrometheusNotConfigured) {
 		return nil
 	}
+	if err != nil {
+		// Default options never load files at construction time, so this is unreachable
+		log.WithError(err).Error("Failed to create Prometheus client")
+		return nil
+	}
+	return client
+}
+
+// NewPrometheusClientWithOptions creates a new Prometheus query client with custom TLS and
+// bearer-token settings. It returns ErrPrometheusNotConfigured when baseURL is empty.
+func NewPrometheusClientWithOptions(baseURL string, timeout time.Duration, log *logrus.Logger, opts PrometheusClientOptions) (*PrometheusClient, error) {
+	if baseURL == "" {
+		return nil, ErrPrometheusNotConfigured
+	}
+
+	tlsConfig, err := buildPrometheusTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create HTTP client with TLS configuration for OpenShift's Prometheus
+	// A custom TLS config disables HTTP/2 unless it is forced; with it, concurrent feature
+	// queries are multiplexed over one connection to an HTTP/2-capable endpoint
 	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: PrometheusDialTimeout, KeepAlive: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout: PrometheusDialTimeout,
 		MaxIdleConns:        10,
 		MaxIdleConnsPerHost: 5,
 		IdleConnTimeout:     90 * time.Second,
 		DisableKeepAlives:   false,
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true, //#nosec G402 -- Required for self-signed certs in OpenShift clusters
-		},
+		TLSClientConfig:     tlsConfig,
+		ForceAttemptHTTP2:   true,
+	}
+
+	cooldown := opts.EndpointCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultPrometheusEndpointCooldown
 	}
 
 	return &PrometheusClient{
-		baseURL: baseURL,
+		endpoints:        newPrometheusEndpoints(append([]string{baseURL}, opts.FallbackURLs...)),
+		endpointCooldown: cooldown,
 		httpClient: &http.Client{
 			Transport: transport,
 			Timeout:   timeout,
 		},
-		log:      log,
-		cache:    make(map[string]cachedMetric),
-		cacheTTL: 5 * time.Minute, // Cache metrics for 5 minutes
+		log:             log,
+		bearerTokenFile: opts.BearerTokenFile,
+		cache:           make(map[string]cachedMetric),
+		cacheTTL:        5 * time.Minute, // Cache metrics for 5 minutes
+	}, nil
+}
+
+// buildPrometheusTLSConfig builds the TLS configuration from client options
+func buildPrometheusTLSConfig(opts PrometheusClientOptions) (*tls.Config, error) {
+	if opts.RootCAFile == "" {
+		return &tls.Config{
+			InsecureSkipVerify: opts.InsecureSkipVerify, //#nosec G402 -- Opt-in for self-signed certs in OpenShift clusters
+		}, nil
 	}
+
+	caPEM, err := os.ReadFile(opts.RootCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Prometheus root CA file %s: %w", opts.RootCAFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no valid certificates found in Prometheus root CA file %s", opts.RootCAFile)
+	}
+
+	return &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
 }
 
 // Close releases resources held by the client
@@ -135,9 +244,10 @@ func (c *PrometheusClient) Close() {
 	}
 }
 
-// IsAvailable returns true if the Prometheus client is configured
+// IsAvailable returns true if the Prometheus client is configured with at least one endpoint.
+// Endpoints cooling down after a failure still count: the one that failed longest ago is retried.
 func (c *PrometheusClient) IsAvailable() bool {
-	return c != nil && c.baseURL != ""
+	return c != nil && len(c.endpoints) > 0
 }
 
 // GetCPURollingMean returns the cluster CPU utilization as a ratio of allocatable capacity (0-1)
@@ -150,6 +260,7 @@ func (c *PrometheusClient) GetCPURollingMean(ctx context.Context) (float64, erro
 
 	cacheKey := "cpu_rolling_mean"
 	if value, ok := c.getCached(cacheKey); ok {
+		reportCacheHit(ctx)
 		return value, nil
 	}
 
@@ -193,6 +304,7 @@ func (c *PrometheusClient) GetMemoryRollingMean(ctx context.Context) (float64, e
 
 	cacheKey := "memory_rolling_mean"
 	if value, ok := c.getCached(cacheKey); ok {
+		reportCacheHit(ctx)
 		return value, nil
 	}
 
@@ -298,6 +410,7 @@ func (c *PrometheusClient) GetScopedCPURollingMean(ctx context.Context, namespac
 
 	cacheKey := fmt.Sprintf("cpu_rolling_mean_scoped_%s_%s_%s", namespace, deployment, pod)
 	if value, ok := c.getCached(cacheKey); ok {
+		reportCacheHit(ctx)
 		return value, nil
 	}
 
@@ -344,6 +457,7 @@ func (c *PrometheusClient) GetScopedMemoryRollingMean(ctx context.Context, names
 
 	cacheKey := fmt.Sprintf("memory_rolling_mean_scoped_%s_%s_%s", namespace, deployment, pod)
 	if value, ok := c.getCached(cacheKey); ok {
+		reportCacheHit(ctx)
 		return value, nil
 	}
 
@@ -380,6 +494,33 @@ func (c *PrometheusClient) GetScopedMemoryRollingMean(ctx context.Context, names
 	return normalizedValue, nil
 }
 
+// GetScopedCPUUtilizationAt returns the scoped CPU utilization ratio (0-1) as it was at the
+// given time. It uses the same queries as GetScopedCPURollingMean and is not cached.
+func (c *PrometheusClient) GetScopedCPUUtilizationAt(ctx context.Context, namespace, deployment, pod string, at time.Time) (float64, error) {
+	return c.queryScopedAtTime(ctx, c.buildScopedCPUQuery(namespace, deployment, pod),
+		c.buildScopedCPUQueryFallback(namespace, deployment, pod), at)
+}
+
+// GetScopedMemoryUtilizationAt returns the scoped memory utilization ratio (0-1) as it was at
+// the given time. It uses the same queries as GetScopedMemoryRollingMean and is not cached.
+func (c *PrometheusClient) GetScopedMemoryUtilizationAt(ctx context.Context, namespace, deployment, pod string, at time.Time) (float64, error) {
+	return c.queryScopedAtTime(ctx, c.buildScopedMemoryQuery(namespace, deployment, pod),
+		c.buildScopedMemoryQueryFallback(namespace, deployment, pod), at)
+}
+
+// queryScopedAtTime evaluates query at the given time, retrying with fallbackQuery on failure
+func (c *PrometheusClient) queryScopedAtTime(ctx context.Context, query, fallbackQuery string, at time.Time) (float64, error) {
+	value, err := c.QueryAtTime(ctx, query, at)
+	if err != nil {
+		c.log.WithError(err).Debug("Primary point-in-time query failed, trying fallback")
+		value, err = c.QueryAtTime(ctx, fallbackQuery, at)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return clampToUnitRange(value), nil
+}
+
 // GetScopedDiskUsage returns disk usage as a ratio (0-1) with flexible scoping
 // Supports namespace, deployment, and pod filtering (Issue #58)
 func (c *PrometheusClient) GetScopedDiskUsage(ctx context.Context, namespace, deployment, pod string) (float64, error) {
@@ -451,8 +592,7 @@ func (c *PrometheusClient) GetScopedNetworkIn(ctx context.Context, namespace, de
 	}
 
 	// Normalize to 0-1 range assuming 125MB/s (1Gbps) as max reasonable throughput
-	const maxNetworkBytesPerSec = 125000000.0 // 1 Gbps in bytes
-	normalizedValue := clampToUnitRange(value / maxNetworkBytesPerSec)
+	normalizedValue := clampToUnitRange(value / MaxNetworkBytesPerSec)
 	c.setCached(cacheKey, normalizedValue)
 
 	c.log.WithFields(logrus.Fields{
@@ -492,8 +632,7 @@ func (c *PrometheusClient) GetScopedNetworkOut(ctx context.Context, namespace, d
 	}
 
 	// Normalize to 0-1 range assuming 125MB/s (1Gbps) as max reasonable throughput
-	const maxNetworkBytesPerSec = 125000000.0 // 1 Gbps in bytes
-	normalizedValue := clampToUnitRange(value / maxNetworkBytesPerSec)
+	normalizedValue := clampToUnitRange(value / MaxNetworkBytesPerSec)
 	c.setCached(cacheKey, normalizedValue)
 
 	c.log.WithFields(logrus.Fields{
@@ -521,7 +660,7 @@ func (c *PrometheusClient) buildScopedCPUQuery(namespace, deployment, pod string
 
 	// Add deployment filter (matches pods with deployment prefix)
 	if deployment != "" {
-		labelSelectors = append(labelSelectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		labelSelectors = append(labelSelectors, DeploymentPodSelector(deployment))
 	}
 
 	// Add pod filter (exact match)
@@ -548,7 +687,7 @@ func (c *PrometheusClient) buildScopedCPUQueryFallback(namespace, deployment, po
 
 	// Add deployment filter (matches pods with deployment prefix)
 	if deployment != "" {
-		labelSelectors = append(labelSelectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		labelSelectors = append(labelSelectors, DeploymentPodSelector(deployment))
 	}
 
 	// Add pod filter (exact match)
@@ -576,7 +715,7 @@ func (c *PrometheusClient) buildScopedMemoryQuery(namespace, deployment, pod str
 
 	// Add deployment filter (matches pods with deployment prefix)
 	if deployment != "" {
-		labelSelectors = append(labelSelectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		labelSelectors = append(labelSelectors, DeploymentPodSelector(deployment))
 	}
 
 	// Add pod filter (exact match)
@@ -604,7 +743,7 @@ func (c *PrometheusClient) buildScopedMemoryQueryFallback(namespace, deployment,
 
 	// Add deployment filter (matches pods with deployment prefix)
 	if deployment != "" {
-		labelSelectors = append(labelSelectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		labelSelectors = append(labelSelectors, DeploymentPodSelector(deployment))
 	}
 
 	// Add pod filter (exact match)
@@ -640,7 +779,7 @@ func (c *PrometheusClient) buildScopedNetworkInQuery(namespace, deployment, pod
 
 	// Add deployment filter (matches pods with deployment prefix)
 	if deployment != "" {
-		labelSelectors = append(labelSelectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		labelSelectors = append(labelSelectors, DeploymentPodSelector(deployment))
 	}
 
 	// Add pod filter (exact match)
@@ -666,7 +805,7 @@ func (c *PrometheusClient) buildScopedNetworkOutQuery(namespace, deployment, pod
 
 	// Add deployment filter (matches pods with deployment prefix)
 	if deployment != "" {
-		labelSelectors = append(labelSelectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		labelSelectors = append(labelSelectors, DeploymentPodSelector(deployment))
 	}
 
 	// Add pod filter (exact match)
@@ -692,43 +831,12 @@ func joinSelectors(selectors []string) string {
 
 // queryInstant executes an instant query against Prometheus
 func (c *PrometheusClient) queryInstant(ctx context.Context, query string) (float64, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/query", c.baseURL)
-
-	// Build request URL with query parameter
-	reqURL, err := url.Parse(endpoint)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse URL: %w", err)
-	}
-
 	params := url.Values{}
 	params.Set("query", query)
-	reqURL.RawQuery = params.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
+	body, err := c.get(ctx, "/api/v1/query", params)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-
-	// Add bearer token if available (for OpenShift authentication)
-	if token := c.getServiceAccountToken(); token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return 0, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer closeBody(resp)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
+		return 0, err
 	}
 
 	var promResp PrometheusQueryResponse
@@ -755,22 +863,62 @@ func (c *PrometheusClient) queryInstant(ctx context.Context, query string) (floa
 		return 0, fmt.Errorf("unexpected value type in result")
 	}
 
-	var value float64
-	if _, err := fmt.Sscanf(valueStr, "%f", &value); err != nil {
+	return parseSampleFloat(valueStr)
+}
+
+// ErrNonFiniteSample is returned for a sample whose value is NaN or ±Inf, which Prometheus
+// produces e.g. for a ratio whose denominator is momentarily zero
+var ErrNonFiniteSample = errors.New("sample value is NaN or Inf")
+
+// parseSampleFloat parses a sample's string value, rejecting NaN and ±Inf so a bad scrape
+// cannot reach current metrics, baselines or JSON output
+func parseSampleFloat(valueStr string) (float64, error) {
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
 		return 0, fmt.Errorf("failed to parse value '%s': %w", valueStr, err)
 	}
-
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%w: %s", ErrNonFiniteSample, valueStr)
+	}
 	return value, nil
 }
 
-// getServiceAccountToken reads the service account token for in-cluster authentication
-func (c *PrometheusClient) getServiceAccountToken() string {
-	token, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/token")
+// setAuthHeader adds the bearer token to a request if one is configured and readable
+func (c *PrometheusClient) setAuthHeader(req *http.Request) {
+	if token := c.getBearerToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// getBearerToken reads the configured bearer token file.
+// The file is re-read on every call because OpenShift rotates service account tokens.
+func (c *PrometheusClient) getBearerToken() string {
+	if c.bearerTokenFile == "" {
+		return ""
+	}
+	token, err := os.ReadFile(c.bearerTokenFile)
 	if err != nil {
 		// Not running in-cluster or token not available
 		return ""
 	}
-	return string(token)
+	return strings.TrimSpace(string(token))
+}
+
+// cacheHitKey carries a *bool in a context, set when a rolling mean read under the context is
+// served from the client's cache instead of Prometheus
+type cacheHitKey struct{}
+
+// withCacheHitReport returns a context under which rolling mean reads report cache hits
+func withCacheHitReport(ctx context.Context) (context.Context, *bool) {
+	hit := new(bool)
+	return context.WithValue(ctx, cacheHitKey{}, hit), hit
+}
+
+// reportCacheHit records a cache hit for withCacheHitReport
+func reportCacheHit(ctx context.Context) {
+	if hit, ok := ctx.Value(cacheHitKey{}).(*bool); ok {
+		*hit = true
+	}
 }
 
 // getCached returns a cached value if it exists and hasn't expired
@@ -814,9 +962,9 @@ func closeBody(resp *http.Response) {
 	}
 }
 
-// clampToUnitRange ensures a value is within the 0.0 to 1.0 range
+// clampToUnitRange ensures a value is within the 0.0 to 1.0 range; NaN becomes 0
 func clampToUnitRange(value float64) float64 {
-	if value < 0 {
+	if value < 0 || math.IsNaN(value) {
 		return 0
 	}
 	if value > 1 {
@@ -1028,12 +1176,7 @@ func (c *PrometheusClient) GetControlPlaneHealth(ctx context.Context) (string, e
 func (c *PrometheusClient) queryRange(ctx context.Context, query, window, step string) ([]MetricDataPoint, error) {
 	start, end := c.calculateTimeRange(window)
 
-	reqURL, err := c.buildRangeQueryURL(query, start, end, step)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.executeRangeQuery(ctx, reqURL)
+	body, err := c.executeRangeQuery(ctx, rangeQueryParams(query, start, end, step))
 	if err != nil {
 		return nil, err
 	}
@@ -1055,52 +1198,19 @@ func (c *PrometheusClient) calculateTimeRange(window string) (start, end time.Ti
 	return start, end
 }
 
-// buildRangeQueryURL builds the URL for a range query
-func (c *PrometheusClient) buildRangeQueryURL(query string, start, end time.Time, step string) (string, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/query_range", c.baseURL)
-	reqURL, err := url.Parse(endpoint)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse URL: %w", err)
-	}
-
+// rangeQueryParams builds the parameters of a range query
+func rangeQueryParams(query string, start, end time.Time, step string) url.Values {
 	params := url.Values{}
 	params.Set("query", query)
 	params.Set("start", fmt.Sprintf("%d", start.Unix()))
 	params.Set("end", fmt.Sprintf("%d", end.Unix()))
 	params.Set("step", step)
-	reqURL.RawQuery = params.Encode()
-
-	return reqURL.String(), nil
+	return params
 }
 
 // executeRangeQuery executes the HTTP request for a range query
-func (c *PrometheusClient) executeRangeQuery(ctx context.Context, reqURL string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	if token := c.getServiceAccountToken(); token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer closeBody(resp)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return body, nil
+func (c *PrometheusClient) executeRangeQuery(ctx context.Context, params url.Values) ([]byte, error) {
+	return c.get(ctx, "/api/v1/query_range", params)
 }
 
 // parseRangeResponse parses the Prometheus range query response
@@ -1148,8 +1258,8 @@ func (c *PrometheusClient) parseDataPoint(values []interface{}) (MetricDataPoint
 		return MetricDataPoint{}, false
 	}
 
-	var value float64
-	if _, err := fmt.Sscanf(valueStr, "%f", &value); err != nil {
+	value, err := parseSampleFloat(valueStr)
+	if err != nil {
 		return MetricDataPoint{}, false
 	}
 
@@ -1177,7 +1287,7 @@ func (c *PrometheusClient) buildQueryWithScope(baseQuery string, opts QueryOptio
 		}
 	case ScopeDeployment:
 		if opts.Deployment != "" {
-			filters = append(filters, fmt.Sprintf(`pod=~"%s-.*"`, opts.Deployment))
+			filters = append(filters, DeploymentPodSelector(opts.Deployment))
 		}
 		if opts.Namespace != "" {
 			filters = append(filters, fmt.Sprintf(`namespace=%q`, opts.Namespace))
@@ -1337,7 +1447,7 @@ func (c *PrometheusClient) buildMemoryRatioQuery(opts QueryOptions, windowStr st
 		}
 	case ScopeDeployment:
 		if opts.Deployment != "" {
-			filters = append(filters, fmt.Sprintf(`pod=~"%s-.*"`, opts.Deployment))
+			filters = append(filters, DeploymentPodSelector(opts.Deployment))
 		}
 		if opts.Namespace != "" {
 			filters = append(filters, fmt.Sprintf(`namespace=%q`, opts.Namespace))
@@ -1568,20 +1678,7 @@ func (c *PrometheusClient) queryRangeWithDuration(ctx context.Context, query str
 	end := time.Now()
 	start := end.Add(-window)
 
-	endpoint := fmt.Sprintf("%s/api/v1/query_range", c.baseURL)
-	reqURL, err := url.Parse(endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	params := url.Values{}
-	params.Set("query", query)
-	params.Set("start", fmt.Sprintf("%d", start.Unix()))
-	params.Set("end", fmt.Sprintf("%d", end.Unix()))
-	params.Set("step", formatDurationForPromQL(step))
-	reqURL.RawQuery = params.Encode()
-
-	body, err := c.executeRangeQuery(ctx, reqURL.String())
+	body, err := c.executeRangeQuery(ctx, rangeQueryParams(query, start, end, formatDurationForPromQL(step)))
 	if err != nil {
 		return nil, err
 	}
@@ -1972,7 +2069,7 @@ func (c *PrometheusClient) buildAnomalyQueries(namespace, pod, deployment string
 		selectors = append(selectors, fmt.Sprintf(`pod=%q`, pod))
 	}
 	if deployment != "" {
-		selectors = append(selectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		selectors = append(selectors, DeploymentPodSelector(deployment))
 	}
 
 	selectorStr := ""
@@ -2049,29 +2146,15 @@ func (c *PrometheusClient) QueryRange(ctx context.Context, query string, start,
 		return nil, fmt.Errorf("prometheus client not available")
 	}
 
-	endpoint := fmt.Sprintf("%s/api/v1/query_range", c.baseURL)
-	reqURL, err := url.Parse(endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL: %w", err)
-	}
-
-	params := url.Values{}
-	params.Set("query", query)
-	params.Set("start", fmt.Sprintf("%d", start.Unix()))
-	params.Set("end", fmt.Sprintf("%d", end.Unix()))
-	params.Set("step", formatDurationForPromQL(step))
-	reqURL.RawQuery = params.Encode()
-
-	// Debug: Log the full request URL for troubleshooting
+	// Debug: Log the request for troubleshooting
 	c.log.WithFields(logrus.Fields{
-		"url":   reqURL.String(),
 		"query": query,
 		"start": start.Format(time.RFC3339),
 		"end":   end.Format(time.RFC3339),
 		"step":  formatDurationForPromQL(step),
 	}).Debug("Executing predictive analytics range query")
 
-	body, err := c.executeRangeQuery(ctx, reqURL.String())
+	body, err := c.executeRangeQuery(ctx, rangeQueryParams(query, start, end, formatDurationForPromQL(step)))
 	if err != nil {
 		c.log.WithError(err).WithField("query", query).Debug("Range query execution failed")
 		return nil, err
@@ -2190,45 +2273,10 @@ func (c *PrometheusClient) QueryAtTime(ctx context.Context, query string, timest
 
 // executeQueryAtTime executes the HTTP request for a point-in-time query
 func (c *PrometheusClient) executeQueryAtTime(ctx context.Context, query string, timestamp time.Time) ([]byte, error) {
-	endpoint := fmt.Sprintf("%s/api/v1/query", c.baseURL)
-	reqURL, err := url.Parse(endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse URL: %w", err)
-	}
-
 	params := url.Values{}
 	params.Set("query", query)
 	params.Set("time", fmt.Sprintf("%d", timestamp.Unix()))
-	reqURL.RawQuery = params.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-
-	// Add bearer token if available (for OpenShift authentication)
-	if token := c.getServiceAccountToken(); token != "" {
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer closeBody(resp)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return body, nil
+	return c.get(ctx, "/api/v1/query", params)
 }
 
 // parseInstantQueryResponse parses the response from an instant query
@@ -2260,15 +2308,5 @@ func (c *PrometheusClient) extractInstantValue(value []interface{}) (float64, er
 		return 0, fmt.Errorf("value is not a string")
 	}
 
-	val, err := strconv.ParseFloat(valStr, 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse value: %w", err)
-	}
-
-	// Handle NaN and Inf
-	if math.IsNaN(val) || math.IsInf(val, 0) {
-		return 0, fmt.Errorf("invalid value: NaN or Inf")
-	}
-
-	return val, nil
+	return parseSampleFloat(valStr)
 }
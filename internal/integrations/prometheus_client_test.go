@@ -4,9 +4,13 @@ package integrations
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -116,6 +120,13 @@ func TestPrometheusClient_BuildQueryWithScope_Deployment(t *testing.T) {
 	assert.Contains(t, result, `namespace="production"`)
 }
 
+// TestDeploymentPodSelector verifies deployment names are regex-escaped in the pod matcher
+func TestDeploymentPodSelector(t *testing.T) {
+	assert.Equal(t, `pod=~"web-app-.*"`, DeploymentPodSelector("web-app"))
+	assert.Equal(t, `pod=~"api\\.v2-.*"`, DeploymentPodSelector("api.v2"))
+	assert.Equal(t, `pod=~"a\\|b-.*"`, DeploymentPodSelector("a|b"))
+}
+
 // TestPrometheusClient_BuildQueryWithScope_Namespace tests namespace-scoped query building
 func TestPrometheusClient_BuildQueryWithScope_Namespace(t *testing.T) {
 	log := logrus.New()
@@ -531,6 +542,10 @@ func TestPrometheusClient_IsAvailable(t *testing.T) {
 	t.Run("empty URL", func(t *testing.T) {
 		client := NewPrometheusClient("", 30*time.Second, logrus.New())
 		assert.Nil(t, client)
+
+		client, err := NewPrometheusClientWithOptions("", 30*time.Second, logrus.New(), PrometheusClientOptions{})
+		assert.ErrorIs(t, err, ErrPrometheusNotConfigured)
+		assert.Nil(t, client)
 	})
 }
 
@@ -939,6 +954,44 @@ func TestPrometheusClient_GetScopedCPURollingMean_WithFallback(t *testing.T) {
 	assert.InDelta(t, 0.35, value, 0.01)
 }
 
+// TestPrometheusClient_GetScopedUtilizationAt verifies point-in-time queries pass the requested
+// time, fall back like the rolling-mean queries and bypass the cache
+func TestPrometheusClient_GetScopedUtilizationAt(t *testing.T) {
+	at := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	var times []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, r.URL.Query().Get("time"))
+		if contains(r.URL.Query().Get("query"), "node_memory_MemTotal_bytes") {
+			_, _ = w.Write([]byte(mockPrometheusResponse(0.6)))
+			return
+		}
+		if contains(r.URL.Query().Get("query"), "container_memory_working_set_bytes") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(mockPrometheusResponse(1.4)))
+	})
+
+	client, server := newTestPrometheusClient(t, handler)
+	defer server.Close()
+
+	cpu, err := client.GetScopedCPUUtilizationAt(context.Background(), "payments", "api", "", at)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, cpu, "clamped to the unit range")
+
+	memory, err := client.GetScopedMemoryUtilizationAt(context.Background(), "payments", "api", "", at)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.6, memory, 0.0001, "fallback query used")
+
+	_, err = client.GetScopedCPUUtilizationAt(context.Background(), "payments", "api", "", at)
+	require.NoError(t, err)
+
+	require.Len(t, times, 4)
+	for _, ts := range times {
+		assert.Equal(t, fmt.Sprintf("%d", at.Unix()), ts)
+	}
+}
+
 // TestPrometheusClient_NormalizedValues_InRange ensures all normalized values are 0-1
 func TestPrometheusClient_NormalizedValues_InRange(t *testing.T) {
 	testCases := []struct {
@@ -971,3 +1024,141 @@ func TestPrometheusClient_NormalizedValues_InRange(t *testing.T) {
 		})
 	}
 }
+
+// TestPrometheusClient_BearerTokenFile verifies the token is sent on instant and range queries
+// and that a rotated token is picked up without recreating the client
+func TestPrometheusClient_BearerTokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-v1\n"), 0o600))
+
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/query_range") {
+			_, _ = w.Write([]byte(mockPrometheusRangeResponse([]float64{0.5})))
+			return
+		}
+		_, _ = w.Write([]byte(mockPrometheusResponse(0.5)))
+	}))
+	defer server.Close()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	client, err := NewPrometheusClientWithOptions(server.URL, 5*time.Second, log, PrometheusClientOptions{
+		BearerTokenFile: tokenFile,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	ctx := context.Background()
+	_, err = client.Query(ctx, "up")
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, err = client.QueryRange(ctx, "up", now.Add(-time.Hour), now, time.Minute)
+	require.NoError(t, err)
+
+	// Simulate service account token rotation
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-v2"), 0o600))
+	_, err = client.Query(ctx, "vector(1)")
+	require.NoError(t, err)
+
+	require.Len(t, authHeaders, 3)
+	assert.Equal(t, "Bearer token-v1", authHeaders[0])
+	assert.Equal(t, "Bearer token-v1", authHeaders[1])
+	assert.Equal(t, "Bearer token-v2", authHeaders[2])
+}
+
+// TestPrometheusClient_NoBearerTokenFile verifies no Authorization header is sent when disabled
+func TestPrometheusClient_NoBearerTokenFile(t *testing.T) {
+	var authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(mockPrometheusResponse(0.5)))
+	}))
+	defer server.Close()
+
+	client, err := NewPrometheusClientWithOptions(server.URL, 5*time.Second, logrus.New(), PrometheusClientOptions{})
+	require.NoError(t, err)
+
+	_, err = client.Query(context.Background(), "up")
+	require.NoError(t, err)
+	assert.Empty(t, authHeader)
+}
+
+// TestPrometheusClient_NonFiniteSamples verifies NaN and ±Inf samples are rejected instead of
+// reaching callers as values
+func TestPrometheusClient_NonFiniteSamples(t *testing.T) {
+	for _, sample := range []string{"NaN", "+Inf", "-Inf"} {
+		t.Run(sample, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v1/query_range" {
+					fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1700000000,"%s"],[1700000060,"0.5"]]}]}}`, sample)
+					return
+				}
+				fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"api-1"},"value":[1700000000,"%s"]}]}}`, sample)
+			}))
+			defer server.Close()
+			client := NewPrometheusClient(server.URL, 5*time.Second, logrus.New())
+			ctx := context.Background()
+
+			_, err := client.Query(ctx, "ratio")
+			assert.ErrorIs(t, err, ErrNonFiniteSample)
+			_, err = client.QueryAtTime(ctx, "ratio", time.Unix(1700000000, 0))
+			assert.ErrorIs(t, err, ErrNonFiniteSample)
+
+			points, err := client.QueryRange(ctx, "ratio", time.Unix(1700000000, 0), time.Unix(1700000060, 0), time.Minute)
+			require.NoError(t, err)
+			require.Len(t, points, 1, "the non-finite point is dropped")
+			assert.Equal(t, 0.5, points[0].Value)
+
+			trend, err := client.queryRange(ctx, "ratio", "1h", "1m")
+			require.NoError(t, err)
+			require.Len(t, trend, 1)
+			assert.Equal(t, 0.5, trend[0].Value)
+		})
+	}
+}
+
+// TestPrometheusClient_RootCAFile verifies TLS verification against a custom root CA
+func TestPrometheusClient_RootCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(mockPrometheusResponse(0.42)))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	certPEM := pemEncodeCertificate(server.Certificate().Raw)
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+
+	client, err := NewPrometheusClientWithOptions(server.URL, 5*time.Second, logrus.New(), PrometheusClientOptions{
+		RootCAFile: caFile,
+	})
+	require.NoError(t, err)
+
+	value, err := client.Query(context.Background(), "up")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.42, value, 0.0001)
+}
+
+// TestPrometheusClient_InvalidRootCAFile verifies construction fails for missing or invalid CA files
+func TestPrometheusClient_InvalidRootCAFile(t *testing.T) {
+	log := logrus.New()
+
+	_, err := NewPrometheusClientWithOptions("https://prometheus:9091", 5*time.Second, log, PrometheusClientOptions{
+		RootCAFile: filepath.Join(t.TempDir(), "missing.crt"),
+	})
+	assert.Error(t, err)
+
+	badFile := filepath.Join(t.TempDir(), "bad.crt")
+	require.NoError(t, os.WriteFile(badFile, []byte("not a certificate"), 0o600))
+	_, err = NewPrometheusClientWithOptions("https://prometheus:9091", 5*time.Second, log, PrometheusClientOptions{
+		RootCAFile: badFile,
+	})
+	assert.Error(t, err)
+}
+
+func pemEncodeCertificate(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,198 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPrometheusEndpointCooldown is how long an endpoint that failed is skipped
+const DefaultPrometheusEndpointCooldown = 30 * time.Second
+
+// PrometheusDialTimeout bounds connecting to an endpoint, so a blackholed replica fails over
+// quickly instead of using up the request's whole timeout
+const PrometheusDialTimeout = 5 * time.Second
+
+// errNoHealthyPrometheusEndpoint is returned when every endpoint is cooling down after a failure
+var errNoHealthyPrometheusEndpoint = errors.New("no healthy Prometheus endpoint: all are cooling down after failures")
+
+// prometheusEndpoint is one Prometheus/Thanos query URL and its failover health
+type prometheusEndpoint struct {
+	url string
+
+	mu        sync.Mutex
+	downUntil time.Time // Zero when healthy
+}
+
+// healthy reports whether the endpoint may be queried at now
+func (e *prometheusEndpoint) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return !now.Before(e.downUntil)
+}
+
+// markDown skips the endpoint until now+cooldown
+func (e *prometheusEndpoint) markDown(now time.Time, cooldown time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.downUntil = now.Add(cooldown)
+}
+
+// markUp clears a previous failure
+func (e *prometheusEndpoint) markUp() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.downUntil = time.Time{}
+}
+
+// newPrometheusEndpoints returns the endpoints in order, dropping empty and repeated URLs
+func newPrometheusEndpoints(urls []string) []*prometheusEndpoint {
+	endpoints := make([]*prometheusEndpoint, 0, len(urls))
+	seen := make(map[string]bool, len(urls))
+	for _, u := range urls {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		endpoints = append(endpoints, &prometheusEndpoint{url: u})
+	}
+	return endpoints
+}
+
+// EndpointURLs returns the configured endpoints in the order they are tried
+func (c *PrometheusClient) EndpointURLs() []string {
+	urls := make([]string, len(c.endpoints))
+	for i, e := range c.endpoints {
+		urls[i] = e.url
+	}
+	return urls
+}
+
+// get sends a GET for path and params to the first healthy endpoint and returns the body of a
+// 200 response. An endpoint that cannot be reached, or whose proxy answers 502-504, is skipped
+// for the cooldown and the next one is tried. When every endpoint is cooling down, the one that
+// failed longest ago is tried anyway rather than failing without a query. Other answers are
+// final: Prometheus itself reports bad or timed-out queries with an API error body, which every
+// replica would return alike. Timeouts and caller cancellation are final too and do not mark
+// the endpoint down.
+func (c *PrometheusClient) get(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	var errs []error
+	for _, endpoint := range c.endpoints {
+		if !endpoint.healthy(time.Now()) {
+			continue
+		}
+
+		body, failover, err := c.getFrom(ctx, endpoint, path, params)
+		if !failover {
+			return body, err
+		}
+		c.markFailed(endpoint, err)
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	endpoint := c.oldestFailedEndpoint()
+	if endpoint == nil {
+		return nil, errNoHealthyPrometheusEndpoint
+	}
+	body, failover, err := c.getFrom(ctx, endpoint, path, params)
+	if !failover {
+		return body, err
+	}
+	c.markFailed(endpoint, err)
+	return nil, fmt.Errorf("%w: %w", errNoHealthyPrometheusEndpoint, err)
+}
+
+// markFailed starts the cooldown of an endpoint that failed
+func (c *PrometheusClient) markFailed(endpoint *prometheusEndpoint, err error) {
+	endpoint.markDown(time.Now(), c.endpointCooldown)
+	c.log.WithError(err).WithFields(logrus.Fields{
+		"endpoint": endpoint.url,
+		"cooldown": c.endpointCooldown,
+	}).Warn("Prometheus endpoint failed, trying next endpoint")
+}
+
+// oldestFailedEndpoint returns the endpoint whose cooldown ends first, i.e. the one that
+// failed longest ago, or nil without endpoints
+func (c *PrometheusClient) oldestFailedEndpoint() *prometheusEndpoint {
+	var oldest *prometheusEndpoint
+	var oldestUntil time.Time
+	for _, e := range c.endpoints {
+		e.mu.Lock()
+		until := e.downUntil
+		e.mu.Unlock()
+		if oldest == nil || until.Before(oldestUntil) {
+			oldest, oldestUntil = e, until
+		}
+	}
+	return oldest
+}
+
+// getFrom queries one endpoint. failover is true when the endpoint itself failed; an endpoint
+// that answered is marked healthy again.
+func (c *PrometheusClient) getFrom(ctx context.Context, endpoint *prometheusEndpoint, path string, params url.Values) (body []byte, failover bool, err error) {
+	reqURL, err := url.Parse(endpoint.url + path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	reqURL.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.setAuthHeader(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, isFailoverError(ctx), fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer closeBody(resp)
+
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, isFailoverError(ctx), fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if isFailoverResponse(resp.StatusCode, body) {
+		return nil, true, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
+	}
+	endpoint.markUp()
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, false, nil
+}
+
+// isFailoverError reports whether a transport error moves the query to the next endpoint.
+// Dial failures, refused connections and the client's own timeout all do; only the caller
+// giving up (its ctx is done) does not, since no replica could answer in time then.
+func isFailoverError(ctx context.Context) bool {
+	return ctx.Err() == nil
+}
+
+// isFailoverResponse reports whether a 502, 503 or 504 came from a failing proxy or replica
+// rather than from Prometheus evaluating the query, which answers with an API error body
+func isFailoverResponse(status int, body []byte) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return false
+	}
+	var apiErr struct {
+		Status string `json:"status"`
+	}
+	return json.Unmarshal(body, &apiErr) != nil || apiErr.Status != "error"
+}
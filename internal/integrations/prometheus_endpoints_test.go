@@ -0,0 +1,196 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCountingPrometheusServer answers every query with value and counts the requests
+func newCountingPrometheusServer(t *testing.T, status int, value float64) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if status != http.StatusOK {
+			http.Error(w, "upstream unavailable", status)
+			return
+		}
+		_, _ = w.Write([]byte(mockPrometheusResponse(value)))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// closedServerURL returns the URL of a server that no longer accepts connections
+func closedServerURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func newFailoverClient(t *testing.T, baseURL string, fallbacks ...string) *PrometheusClient {
+	t.Helper()
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	opts := PrometheusClientOptions{FallbackURLs: fallbacks, EndpointCooldown: time.Minute}
+	client, err := NewPrometheusClientWithOptions(baseURL, 5*time.Second, log, opts)
+	require.NoError(t, err)
+	return client
+}
+
+// TestPrometheusClient_Failover verifies an unreachable endpoint is skipped for the cooldown
+func TestPrometheusClient_Failover(t *testing.T) {
+	replica, calls := newCountingPrometheusServer(t, http.StatusOK, 0.42)
+	client := newFailoverClient(t, closedServerURL(), replica.URL)
+
+	value, err := client.Query(context.Background(), "up")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.42, value, 0.0001)
+	assert.False(t, client.endpoints[0].healthy(time.Now()), "the unreachable endpoint cools down")
+	assert.True(t, client.IsAvailable())
+
+	_, err = client.QueryAtTime(context.Background(), "up", time.Now())
+	require.NoError(t, err)
+	_, _ = client.QueryRange(context.Background(), "up", time.Now().Add(-time.Hour), time.Now(), time.Minute)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+// TestPrometheusClient_Failover_BadGateway verifies a proxy 502-504 fails over while a query
+// error from Prometheus itself does not
+func TestPrometheusClient_Failover_BadGateway(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		gateway, _ := newCountingPrometheusServer(t, status, 0)
+		replica, _ := newCountingPrometheusServer(t, http.StatusOK, 0.42)
+		client := newFailoverClient(t, gateway.URL, replica.URL)
+
+		_, err := client.Query(context.Background(), "up")
+		require.NoError(t, err, "status %d", status)
+		assert.False(t, client.endpoints[0].healthy(time.Now()), "status %d", status)
+	}
+
+	timedOut := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"error","errorType":"timeout","error":"query timed out"}`))
+	}))
+	t.Cleanup(timedOut.Close)
+	replica, replicaCalls := newCountingPrometheusServer(t, http.StatusOK, 0.42)
+	client := newFailoverClient(t, timedOut.URL, replica.URL)
+
+	_, err := client.Query(context.Background(), "up")
+	assert.ErrorContains(t, err, "status 503")
+	assert.Zero(t, replicaCalls.Load())
+	assert.True(t, client.endpoints[0].healthy(time.Now()))
+}
+
+// TestPrometheusClient_Failover_AllDown verifies the client stays available while every
+// endpoint cools down and keeps retrying the oldest failure
+func TestPrometheusClient_Failover_AllDown(t *testing.T) {
+	client := newFailoverClient(t, closedServerURL(), closedServerURL())
+	assert.True(t, client.IsAvailable())
+
+	_, err := client.Query(context.Background(), "up")
+	require.Error(t, err)
+	for _, endpoint := range client.endpoints {
+		assert.False(t, endpoint.healthy(time.Now()))
+	}
+	assert.True(t, client.IsAvailable())
+
+	_, err = client.get(context.Background(), "/api/v1/query", nil)
+	assert.ErrorIs(t, err, errNoHealthyPrometheusEndpoint)
+	assert.ErrorContains(t, err, "failed to execute query", "the oldest failure is retried")
+}
+
+// TestPrometheusClient_Failover_CanceledContext verifies a canceled request does not mark the
+// endpoint down
+func TestPrometheusClient_Failover_CanceledContext(t *testing.T) {
+	replica, _ := newCountingPrometheusServer(t, http.StatusOK, 0.42)
+	client := newFailoverClient(t, replica.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.Query(ctx, "up")
+	require.Error(t, err)
+	assert.True(t, client.IsAvailable())
+}
+
+// TestPrometheusClient_Failover_RetriesOldestFailure verifies that with every endpoint cooling
+// down the one that failed longest ago is still queried
+func TestPrometheusClient_Failover_RetriesOldestFailure(t *testing.T) {
+	first, firstCalls := newCountingPrometheusServer(t, http.StatusOK, 0.1)
+	second, secondCalls := newCountingPrometheusServer(t, http.StatusOK, 0.2)
+	client := newFailoverClient(t, first.URL, second.URL)
+
+	client.endpoints[0].markDown(time.Now(), 2*time.Minute)
+	client.endpoints[1].markDown(time.Now(), time.Minute)
+
+	value, err := client.Query(context.Background(), "up")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, value, 0.0001)
+	assert.Zero(t, firstCalls.Load())
+	assert.Equal(t, int32(1), secondCalls.Load())
+	assert.True(t, client.endpoints[1].healthy(time.Now()), "a successful retry clears the cooldown")
+}
+
+// TestPrometheusClient_Failover_Timeout verifies a timed-out query neither fails over nor
+// marks the endpoint down
+func TestPrometheusClient_Failover_Timeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(slow.Close)
+	replica, replicaCalls := newCountingPrometheusServer(t, http.StatusOK, 0.42)
+	client := newFailoverClient(t, slow.URL, replica.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Query(ctx, "up")
+	require.Error(t, err)
+	assert.Zero(t, replicaCalls.Load())
+	assert.True(t, client.endpoints[0].healthy(time.Now()))
+}
+
+// TestPrometheusClient_Failover_ClientTimeout verifies an endpoint that hangs past the client's
+// own timeout fails over while the caller is still waiting
+func TestPrometheusClient_Failover_ClientTimeout(t *testing.T) {
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(hung.Close)
+	replica, replicaCalls := newCountingPrometheusServer(t, http.StatusOK, 0.42)
+	client := newFailoverClient(t, hung.URL, replica.URL)
+	client.httpClient.Timeout = 50 * time.Millisecond
+
+	value, err := client.Query(context.Background(), "up")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.42, value, 1e-9)
+	assert.Equal(t, int32(1), replicaCalls.Load())
+	assert.False(t, client.endpoints[0].healthy(time.Now()))
+}
+
+// TestPrometheusClient_DialTimeout verifies connecting is bounded separately from the request
+func TestPrometheusClient_DialTimeout(t *testing.T) {
+	client := newFailoverClient(t, "http://thanos-0:9090")
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, transport.DialContext)
+	assert.Equal(t, PrometheusDialTimeout, transport.TLSHandshakeTimeout)
+}
+
+func TestNewPrometheusEndpoints(t *testing.T) {
+	client := newFailoverClient(t, "http://thanos-0:9090", "", "http://thanos-1:9090", "http://thanos-0:9090")
+	assert.Equal(t, []string{"http://thanos-0:9090", "http://thanos-1:9090"}, client.EndpointURLs())
+	assert.Equal(t, time.Minute, client.endpointCooldown)
+
+	client, err := NewPrometheusClientWithOptions("http://thanos-0:9090", time.Second, logrus.New(), PrometheusClientOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPrometheusEndpointCooldown, client.endpointCooldown)
+}
@@ -0,0 +1,148 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultTargetCacheTTL is how long an existence lookup is reused when no TTL is configured
+const DefaultTargetCacheTTL = 30 * time.Second
+
+var (
+	namespaceGVR  = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "namespaces"}
+	deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	podGVR        = schema.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+)
+
+// TargetNotFoundError reports a namespace, deployment or pod that does not exist
+type TargetNotFoundError struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (e *TargetNotFoundError) Error() string {
+	if e.Kind == "namespace" {
+		return fmt.Sprintf("namespace %q not found", e.Name)
+	}
+	return fmt.Sprintf("%s %q not found in namespace %q", e.Kind, e.Name, e.Namespace)
+}
+
+// TargetChecker verifies that prediction targets exist in the cluster. Results, including
+// "not found", are cached for a short TTL so repeated requests for the same target cost one
+// API call per TTL.
+type TargetChecker struct {
+	dynamicClient dynamic.Interface
+	ttl           time.Duration
+	log           *logrus.Logger
+
+	mu    sync.Mutex
+	cache map[targetKey]targetCacheEntry
+	now   func() time.Time
+}
+
+type targetKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type targetCacheEntry struct {
+	exists  bool
+	expires time.Time
+}
+
+// NewTargetChecker creates a checker backed by dynamicClient. A ttl <= 0 uses DefaultTargetCacheTTL.
+func NewTargetChecker(dynamicClient dynamic.Interface, ttl time.Duration, log *logrus.Logger) *TargetChecker {
+	if ttl <= 0 {
+		ttl = DefaultTargetCacheTTL
+	}
+	return &TargetChecker{
+		dynamicClient: dynamicClient,
+		ttl:           ttl,
+		log:           log,
+		cache:         make(map[targetKey]targetCacheEntry),
+		now:           time.Now,
+	}
+}
+
+// CheckTarget verifies that each non-empty identifier exists: the namespace, then the deployment
+// and pod within it. It returns a *TargetNotFoundError for the first missing one, or the API
+// error if existence could not be determined.
+func (tc *TargetChecker) CheckTarget(ctx context.Context, namespace, deployment, pod string) error {
+	if namespace == "" {
+		return nil
+	}
+	checks := []targetKey{{kind: "namespace", name: namespace}}
+	if deployment != "" {
+		checks = append(checks, targetKey{kind: "deployment", namespace: namespace, name: deployment})
+	}
+	if pod != "" {
+		checks = append(checks, targetKey{kind: "pod", namespace: namespace, name: pod})
+	}
+
+	for _, key := range checks {
+		exists, err := tc.exists(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return &TargetNotFoundError{Kind: key.kind, Namespace: key.namespace, Name: key.name}
+		}
+	}
+	return nil
+}
+
+// exists answers from the cache when possible, otherwise asks the API server
+func (tc *TargetChecker) exists(ctx context.Context, key targetKey) (bool, error) {
+	now := tc.now()
+	tc.mu.Lock()
+	entry, ok := tc.cache[key]
+	tc.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.exists, nil
+	}
+
+	var err error
+	switch key.kind {
+	case "namespace":
+		_, err = tc.dynamicClient.Resource(namespaceGVR).Get(ctx, key.name, metav1.GetOptions{})
+	case "deployment":
+		_, err = tc.dynamicClient.Resource(deploymentGVR).Namespace(key.namespace).Get(ctx, key.name, metav1.GetOptions{})
+	case "pod":
+		_, err = tc.dynamicClient.Resource(podGVR).Namespace(key.namespace).Get(ctx, key.name, metav1.GetOptions{})
+	default:
+		return false, fmt.Errorf("unknown target kind %q", key.kind)
+	}
+
+	exists := err == nil
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get %s %s: %w", key.kind, key.name, err)
+	}
+
+	tc.mu.Lock()
+	// Drop expired entries so lookups of mistyped names do not accumulate
+	for cachedKey, cached := range tc.cache {
+		if !now.Before(cached.expires) {
+			delete(tc.cache, cachedKey)
+		}
+	}
+	tc.cache[key] = targetCacheEntry{exists: exists, expires: now.Add(tc.ttl)}
+	tc.mu.Unlock()
+
+	tc.log.WithFields(logrus.Fields{
+		"kind":      key.kind,
+		"namespace": key.namespace,
+		"name":      key.name,
+		"exists":    exists,
+	}).Debug("Checked prediction target")
+	return exists, nil
+}
@@ -0,0 +1,113 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTargetObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+	}}
+}
+
+func newTargetDynamicClient() *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClient(runtime.NewScheme(),
+		newTargetObject("v1", "Namespace", "", "payments"),
+		newTargetObject("apps/v1", "Deployment", "payments", "api"),
+		newTargetObject("v1", "Pod", "payments", "api-7d9f"),
+	)
+}
+
+// TestTargetChecker_CheckTarget verifies existing targets pass and the first missing one is reported
+func TestTargetChecker_CheckTarget(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	checker := NewTargetChecker(newTargetDynamicClient(), 0, log)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		namespace  string
+		deployment string
+		pod        string
+		wantKind   string
+	}{
+		{name: "cluster scope", namespace: ""},
+		{name: "namespace exists", namespace: "payments"},
+		{name: "deployment exists", namespace: "payments", deployment: "api"},
+		{name: "pod exists", namespace: "payments", pod: "api-7d9f"},
+		{name: "namespace missing", namespace: "paymnets", deployment: "api", wantKind: "namespace"},
+		{name: "deployment missing", namespace: "payments", deployment: "apii", wantKind: "deployment"},
+		{name: "pod missing", namespace: "payments", pod: "api-0000", wantKind: "pod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checker.CheckTarget(ctx, tt.namespace, tt.deployment, tt.pod)
+			if tt.wantKind == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var notFound *TargetNotFoundError
+			require.ErrorAs(t, err, &notFound)
+			assert.Equal(t, tt.wantKind, notFound.Kind)
+		})
+	}
+}
+
+// TestTargetChecker_Caching verifies results are reused within the TTL, refreshed after it, and
+// API failures are returned without being cached
+func TestTargetChecker_Caching(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	client := newTargetDynamicClient()
+	var gets int
+	var failNext bool
+	client.PrependReactor("get", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		if failNext {
+			failNext = false
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+
+	now := time.Now()
+	checker := NewTargetChecker(client, time.Minute, log)
+	checker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, checker.CheckTarget(ctx, "payments", "", ""))
+	require.Error(t, checker.CheckTarget(ctx, "missing", "", ""))
+	require.NoError(t, checker.CheckTarget(ctx, "payments", "", ""))
+	require.Error(t, checker.CheckTarget(ctx, "missing", "", ""))
+	assert.Equal(t, 2, gets, "found and not-found results are cached")
+
+	now = now.Add(2 * time.Minute)
+	failNext = true
+	err := checker.CheckTarget(ctx, "payments", "", "")
+	require.Error(t, err)
+	var notFound *TargetNotFoundError
+	assert.False(t, errors.As(err, &notFound), "API failures are not reported as not found")
+
+	require.NoError(t, checker.CheckTarget(ctx, "payments", "", ""))
+	assert.Equal(t, 4, gets)
+	assert.Len(t, checker.cache, 1, "expired entries are pruned")
+}
@@ -0,0 +1,289 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTicketExportTimeout bounds a ticket creation request when TicketExporterConfig.Timeout is unset
+const DefaultTicketExportTimeout = 10 * time.Second
+
+// maxTicketResponseBytes bounds how much of the ticketing system's response is read
+const maxTicketResponseBytes = 1 << 20
+
+// Ticket payload presets, selected with TicketExporterConfig.Format
+const (
+	TicketFormatServiceNow = "servicenow"
+	TicketFormatJira       = "jira"
+	TicketFormatCustom     = "custom"
+)
+
+// TicketFieldPresets map payload fields to templates for common ticketing systems. Keys are
+// dot-separated paths into the JSON payload; values are text/template templates executed
+// against the exported item.
+var TicketFieldPresets = map[string]map[string]string{
+	// ServiceNow Table API (POST /api/now/table/incident)
+	TicketFormatServiceNow: {
+		"short_description": "[{{.Severity}}] {{.IssueType}} on {{.Target}}",
+		"description":       "{{join .Evidence \"\\n\"}}\n\nRecommended actions:\n{{join .RecommendedActions \"\\n\"}}",
+		"urgency":           "{{if eq .Severity \"critical\"}}1{{else if eq .Severity \"high\"}}2{{else}}3{{end}}",
+		"impact":            "{{if eq .Severity \"critical\"}}1{{else if eq .Severity \"high\"}}2{{else}}3{{end}}",
+		"correlation_id":    "{{.ID}}",
+	},
+	// Jira REST API (POST /rest/api/2/issue); fields.project.key must be added by the mapping
+	TicketFormatJira: {
+		"fields.summary":        "[{{.Severity}}] {{.IssueType}} on {{.Target}}",
+		"fields.description":    "{{join .Evidence \"\\n\"}}\n\nRecommended actions:\n{{join .RecommendedActions \"\\n\"}}",
+		"fields.issuetype.name": "Task",
+	},
+	TicketFormatCustom: {},
+}
+
+// defaultTicketReferenceFields are tried in order when no reference field is configured:
+// Jira returns key, ServiceNow result.number
+var defaultTicketReferenceFields = []string{"key", "result.number", "number", "id", "result.sys_id", "sys_id"}
+
+// TicketExporterConfig configures a TicketExporter
+type TicketExporterConfig struct {
+	// URL receives a JSON POST per exported ticket
+	URL string
+
+	// Timeout bounds each request (0 = DefaultTicketExportTimeout)
+	Timeout time.Duration
+
+	// Format selects the preset fields from TicketFieldPresets (empty = custom)
+	Format string
+
+	// Fields adds payload fields to the preset, or replaces preset ones with the same path
+	Fields map[string]string
+
+	// AuthorizationFile holds the Authorization header value, e.g. "Basic ..." or "Bearer ...".
+	// It is re-read per request so rotated credentials are picked up (empty = no header).
+	AuthorizationFile string
+
+	// ReferenceField is the dot-separated path of the ticket reference in the response
+	// (empty = the first of key, result.number, number, id, result.sys_id, sys_id present)
+	ReferenceField string
+}
+
+// TicketReference identifies a ticket created by a TicketExporter
+type TicketReference struct {
+	// Reference is the value found at the reference field, e.g. "OPS-123" or "INC0010001"
+	Reference string `json:"reference,omitempty"`
+
+	// ReferenceField is the response path Reference was read from
+	ReferenceField string `json:"reference_field,omitempty"`
+
+	// StatusCode is the ticketing system's HTTP status
+	StatusCode int `json:"status_code"`
+
+	// Response is the ticketing system's JSON response, when it returned JSON
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// ticketField is one payload field with its parsed template
+type ticketField struct {
+	path     []string
+	template *template.Template
+}
+
+// TicketExporter renders items into a ticketing system's payload through a field mapping and
+// creates tickets from them. Requests are not retried: a create call that timed out may still
+// have opened a ticket, and a retry would open a duplicate.
+type TicketExporter struct {
+	url               string
+	httpClient        *http.Client
+	authorizationFile string
+	referenceField    string
+	fields            []ticketField
+	log               *logrus.Logger
+}
+
+// NewTicketExporter creates an exporter, parsing the preset and mapped field templates
+func NewTicketExporter(cfg TicketExporterConfig, log *logrus.Logger) (*TicketExporter, error) {
+	format := cfg.Format
+	if format == "" {
+		format = TicketFormatCustom
+	}
+	preset, ok := TicketFieldPresets[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown ticket format %q", cfg.Format)
+	}
+	mapping := maps.Clone(preset)
+	maps.Copy(mapping, cfg.Fields)
+	if len(mapping) == 0 {
+		return nil, fmt.Errorf("ticket format %q needs at least one mapped field", format)
+	}
+
+	funcs := template.FuncMap{"join": strings.Join, "upper": strings.ToUpper, "lower": strings.ToLower}
+	fields := make([]ticketField, 0, len(mapping))
+	for _, path := range slices.Sorted(maps.Keys(mapping)) {
+		parts := strings.Split(path, ".")
+		if slices.Contains(parts, "") {
+			return nil, fmt.Errorf("invalid ticket field path %q", path)
+		}
+		tmpl, err := template.New(path).Funcs(funcs).Option("missingkey=error").Parse(mapping[path])
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for ticket field %q: %w", path, err)
+		}
+		fields = append(fields, ticketField{path: parts, template: tmpl})
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTicketExportTimeout
+	}
+	return &TicketExporter{
+		url:               cfg.URL,
+		httpClient:        &http.Client{Timeout: timeout},
+		authorizationFile: cfg.AuthorizationFile,
+		referenceField:    cfg.ReferenceField,
+		fields:            fields,
+		log:               log,
+	}, nil
+}
+
+// LoadTicketFields reads a field mapping from a JSON object of path to template
+func LoadTicketFields(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket field mapping: %w", err)
+	}
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse ticket field mapping %s: %w", path, err)
+	}
+	return fields, nil
+}
+
+// Render executes the field templates against item and assembles the payload. A path that is
+// both a field and the parent of another field is an error.
+func (e *TicketExporter) Render(item any) (map[string]any, error) {
+	payload := make(map[string]any)
+	for _, field := range e.fields {
+		var value strings.Builder
+		if err := field.template.Execute(&value, item); err != nil {
+			return nil, fmt.Errorf("failed to render ticket field %q: %w", strings.Join(field.path, "."), err)
+		}
+
+		parent := payload
+		for _, key := range field.path[:len(field.path)-1] {
+			child, ok := parent[key].(map[string]any)
+			if !ok {
+				if _, taken := parent[key]; taken {
+					return nil, fmt.Errorf("ticket field %q conflicts with %q", strings.Join(field.path, "."), key)
+				}
+				child = make(map[string]any)
+				parent[key] = child
+			}
+			parent = child
+		}
+		leaf := field.path[len(field.path)-1]
+		if _, taken := parent[leaf]; taken {
+			return nil, fmt.Errorf("ticket field %q conflicts with a nested field", strings.Join(field.path, "."))
+		}
+		parent[leaf] = value.String()
+	}
+	return payload, nil
+}
+
+// Export renders item and creates a ticket from it, returning the ticket's reference
+func (e *TicketExporter) Export(ctx context.Context, item any) (*TicketReference, error) {
+	payload, err := e.Render(item)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ticket payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if authorization := e.authorization(); authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ticket request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxTicketResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("ticketing system returned status %d: %s", resp.StatusCode, respBody[:min(len(respBody), 200)])
+	}
+
+	ticket := &TicketReference{StatusCode: resp.StatusCode}
+	var decoded any
+	if json.Unmarshal(respBody, &decoded) == nil {
+		ticket.Response = respBody
+		ticket.ReferenceField, ticket.Reference = e.findReference(decoded)
+	}
+	if ticket.Reference == "" {
+		e.log.WithField("status_code", resp.StatusCode).Warn("Ticket created but no reference found in the response")
+	}
+	return ticket, nil
+}
+
+// findReference returns the configured reference field's value in response, or the first
+// default reference field present
+func (e *TicketExporter) findReference(response any) (string, string) {
+	candidates := defaultTicketReferenceFields
+	if e.referenceField != "" {
+		candidates = []string{e.referenceField}
+	}
+	for _, path := range candidates {
+		value := response
+		for _, key := range strings.Split(path, ".") {
+			object, ok := value.(map[string]any)
+			if !ok {
+				value = nil
+				break
+			}
+			value = object[key]
+		}
+		switch v := value.(type) {
+		case string:
+			if v != "" {
+				return path, v
+			}
+		case float64:
+			return path, fmt.Sprint(v)
+		}
+	}
+	return "", ""
+}
+
+// authorization reads the Authorization header value, re-read on every call so rotated
+// credentials are picked up
+func (e *TicketExporter) authorization() string {
+	if e.authorizationFile == "" {
+		return ""
+	}
+	value, err := os.ReadFile(e.authorizationFile)
+	if err != nil {
+		e.log.WithError(err).Warn("Failed to read ticket authorization file")
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
@@ -0,0 +1,151 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ticketItem struct {
+	ID                 string
+	Severity           string
+	IssueType          string
+	Target             string
+	Evidence           []string
+	RecommendedActions []string
+}
+
+var testTicketItem = ticketItem{
+	ID:                 "rec-0123456789ab",
+	Severity:           "high",
+	IssueType:          "memory_pressure",
+	Target:             "payments",
+	Evidence:           []string{"Memory at 91%"},
+	RecommendedActions: []string{"increase_memory_limit"},
+}
+
+func TestTicketExporter_Render(t *testing.T) {
+	log := logrus.New()
+
+	t.Run("jira preset with mapped project", func(t *testing.T) {
+		exporter, err := NewTicketExporter(TicketExporterConfig{
+			Format: TicketFormatJira,
+			Fields: map[string]string{"fields.project.key": "OPS", "fields.priority.name": "{{if eq .Severity \"high\"}}High{{else}}Medium{{end}}"},
+		}, log)
+		require.NoError(t, err)
+
+		payload, err := exporter.Render(testTicketItem)
+		require.NoError(t, err)
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"fields": {
+			"project": {"key": "OPS"},
+			"summary": "[high] memory_pressure on payments",
+			"description": "Memory at 91%\n\nRecommended actions:\nincrease_memory_limit",
+			"issuetype": {"name": "Task"},
+			"priority": {"name": "High"}
+		}}`, string(body))
+	})
+
+	t.Run("servicenow preset", func(t *testing.T) {
+		exporter, err := NewTicketExporter(TicketExporterConfig{Format: TicketFormatServiceNow}, log)
+		require.NoError(t, err)
+		payload, err := exporter.Render(testTicketItem)
+		require.NoError(t, err)
+		assert.Equal(t, "2", payload["urgency"])
+		assert.Equal(t, "rec-0123456789ab", payload["correlation_id"])
+	})
+
+	t.Run("invalid mappings", func(t *testing.T) {
+		_, err := NewTicketExporter(TicketExporterConfig{Format: "remedy"}, log)
+		assert.ErrorContains(t, err, "unknown ticket format")
+
+		_, err = NewTicketExporter(TicketExporterConfig{}, log)
+		assert.ErrorContains(t, err, "at least one mapped field")
+
+		_, err = NewTicketExporter(TicketExporterConfig{Fields: map[string]string{"title": "{{.Target"}}, log)
+		assert.ErrorContains(t, err, `invalid template for ticket field "title"`)
+
+		exporter, err := NewTicketExporter(TicketExporterConfig{Fields: map[string]string{"a": "x", "a.b": "y"}}, log)
+		require.NoError(t, err)
+		_, err = exporter.Render(testTicketItem)
+		assert.ErrorContains(t, err, "conflicts")
+
+		exporter, err = NewTicketExporter(TicketExporterConfig{Fields: map[string]string{"title": "{{.Missing}}"}}, log)
+		require.NoError(t, err)
+		_, err = exporter.Render(testTicketItem)
+		assert.ErrorContains(t, err, `failed to render ticket field "title"`)
+	})
+}
+
+func TestTicketExporter_Export(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	var received map[string]any
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received["short_description"] == "fail" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": "not allowed"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"result": {"number": "INC0010001", "sys_id": "9d385017c611228701d22104cc95c371"}}`))
+	}))
+	defer server.Close()
+
+	authFile := filepath.Join(t.TempDir(), "authorization")
+	require.NoError(t, os.WriteFile(authFile, []byte("Basic YWRtaW46YWRtaW4=\n"), 0o600))
+
+	exporter, err := NewTicketExporter(TicketExporterConfig{URL: server.URL, Format: TicketFormatServiceNow, AuthorizationFile: authFile}, log)
+	require.NoError(t, err)
+
+	ticket, err := exporter.Export(context.Background(), testTicketItem)
+	require.NoError(t, err)
+	assert.Equal(t, "INC0010001", ticket.Reference)
+	assert.Equal(t, "result.number", ticket.ReferenceField)
+	assert.Equal(t, http.StatusCreated, ticket.StatusCode)
+	assert.Equal(t, "Basic YWRtaW46YWRtaW4=", authorization)
+	assert.Equal(t, "[high] memory_pressure on payments", received["short_description"])
+
+	t.Run("configured reference field", func(t *testing.T) {
+		exporter, err := NewTicketExporter(TicketExporterConfig{URL: server.URL, Format: TicketFormatServiceNow, ReferenceField: "result.sys_id"}, log)
+		require.NoError(t, err)
+		ticket, err := exporter.Export(context.Background(), testTicketItem)
+		require.NoError(t, err)
+		assert.Equal(t, "9d385017c611228701d22104cc95c371", ticket.Reference)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		exporter, err := NewTicketExporter(TicketExporterConfig{URL: server.URL, Fields: map[string]string{"short_description": "fail"}}, log)
+		require.NoError(t, err)
+		_, err = exporter.Export(context.Background(), testTicketItem)
+		assert.ErrorContains(t, err, "ticketing system returned status 403")
+	})
+}
+
+func TestLoadTicketFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fields.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"fields.project.key": "OPS"}`), 0o600))
+	fields, err := LoadTicketFields(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"fields.project.key": "OPS"}, fields)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"fields": {"project": "OPS"}}`), 0o600))
+	_, err = LoadTicketFields(path)
+	assert.Error(t, err)
+}
@@ -13,13 +13,17 @@ import (
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
 )
 
+// WorkflowCompletionHook is called once a workflow reaches a final status
+type WorkflowCompletionHook func(workflow *models.Workflow)
+
 // Orchestrator manages remediation workflow execution
 type Orchestrator struct {
-	detector   *detector.Detector
-	remediator Remediator
-	workflows  map[string]*models.Workflow
-	mu         sync.RWMutex
-	log        *logrus.Logger
+	detector       *detector.Detector
+	remediator     Remediator
+	workflows      map[string]*models.Workflow
+	completionHook WorkflowCompletionHook
+	mu             sync.RWMutex
+	log            *logrus.Logger
 }
 
 // NewOrchestrator creates a new remediation orchestrator
@@ -36,6 +40,12 @@ func NewOrchestrator(
 	}
 }
 
+// SetCompletionHook registers a callback invoked after each workflow completes or fails.
+// Must be called before workflows are triggered.
+func (o *Orchestrator) SetCompletionHook(hook WorkflowCompletionHook) {
+	o.completionHook = hook
+}
+
 // TriggerRemediation initiates a remediation workflow
 func (o *Orchestrator) TriggerRemediation(ctx context.Context, incidentID string, issue *models.Issue) (*models.Workflow, error) {
 	o.log.WithFields(logrus.Fields{
@@ -180,6 +190,10 @@ func (o *Orchestrator) executeWorkflow(ctx context.Context, workflow *models.Wor
 		"status":      workflow.Status,
 		"duration":    workflow.Duration().String(),
 	}).Info("Workflow execution completed")
+
+	if o.completionHook != nil {
+		o.completionHook(workflow)
+	}
 }
 
 // detectDeploymentMethod detects how the resource was deployed
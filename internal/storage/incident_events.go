@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// IncidentEventType identifies the change an IncidentEvent reports
+type IncidentEventType string
+
+const (
+	// IncidentEventCreated is sent when a new incident is stored
+	IncidentEventCreated IncidentEventType = "created"
+
+	// IncidentEventRecurred is sent when Create records a sighting on an existing incident
+	IncidentEventRecurred IncidentEventType = "recurred"
+
+	// IncidentEventEscalated is sent when a recurrence raises the incident's severity
+	IncidentEventEscalated IncidentEventType = "escalated"
+
+	// IncidentEventUpdated is sent by Update and LinkWorkflow
+	IncidentEventUpdated IncidentEventType = "updated"
+
+	// IncidentEventStatusChanged is sent instead of IncidentEventUpdated when the status changed,
+	// including resolutions by LinkWorkflow and AutoResolveStale
+	IncidentEventStatusChanged IncidentEventType = "status_changed"
+
+	// IncidentEventDeleted is sent by Delete; retention cleanup is not reported
+	IncidentEventDeleted IncidentEventType = "deleted"
+)
+
+// IncidentEventTypes lists every event type, for validating configured filters
+var IncidentEventTypes = []IncidentEventType{
+	IncidentEventCreated,
+	IncidentEventRecurred,
+	IncidentEventEscalated,
+	IncidentEventUpdated,
+	IncidentEventStatusChanged,
+	IncidentEventDeleted,
+}
+
+// IncidentEvent describes a persisted change to an incident
+type IncidentEvent struct {
+	Type IncidentEventType `json:"type"`
+
+	// Incident is a copy of the incident after the change (before it, for deletions)
+	Incident models.Incident `json:"incident"`
+
+	// PreviousStatus is the status before the change; empty for created events
+	PreviousStatus models.IncidentStatus `json:"previous_status,omitempty"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// IncidentObserver receives incident events. Observers run synchronously on the goroutine
+// that changed the store, after the change is persisted and the store lock is released, so
+// slow work such as network calls should be handed off to another goroutine.
+type IncidentObserver func(IncidentEvent)
+
+// RegisterObserver adds an observer for incident changes
+func (s *IncidentStore) RegisterObserver(observer IncidentObserver) {
+	s.observersMu.Lock()
+	defer s.observersMu.Unlock()
+	s.observers = append(s.observers, observer)
+}
+
+// notify delivers events to every registered observer. Callers must not hold s.mu.
+func (s *IncidentStore) notify(events []IncidentEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	s.observersMu.RLock()
+	observers := s.observers
+	s.observersMu.RUnlock()
+
+	for _, event := range events {
+		for _, observer := range observers {
+			observer(event)
+		}
+	}
+}
+
+// newIncidentEvent snapshots incident for an event
+func newIncidentEvent(eventType IncidentEventType, incident *models.Incident, previous models.IncidentStatus) IncidentEvent {
+	return IncidentEvent{
+		Type:           eventType,
+		Incident:       *incident,
+		PreviousStatus: previous,
+		Timestamp:      time.Now(),
+	}
+}
+
+// changeEvent classifies an update as a status change or a plain update
+func changeEvent(before, after *models.Incident) IncidentEvent {
+	if before.Status != after.Status {
+		return newIncidentEvent(IncidentEventStatusChanged, after, before.Status)
+	}
+	return newIncidentEvent(IncidentEventUpdated, after, before.Status)
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func recordEvents(store *IncidentStore) *[]IncidentEvent {
+	events := &[]IncidentEvent{}
+	store.RegisterObserver(func(event IncidentEvent) {
+		*events = append(*events, event)
+	})
+	return events
+}
+
+func eventTypes(events []IncidentEvent) []IncidentEventType {
+	types := make([]IncidentEventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+// TestIncidentStore_Observers verifies each kind of change produces its event
+func TestIncidentStore_Observers(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{3}})
+	events := recordEvents(store)
+
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+		require.NoError(t, err)
+	}
+
+	edited := *created
+	edited.Description = "updated description"
+	require.NoError(t, store.Update(&edited))
+
+	_, err = store.LinkWorkflow(created.ID, "wf-1", models.WorkflowStatusCompleted)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Delete(created.ID))
+
+	assert.Equal(t, []IncidentEventType{
+		IncidentEventCreated,
+		IncidentEventRecurred,
+		IncidentEventEscalated,
+		IncidentEventUpdated,
+		IncidentEventStatusChanged,
+		IncidentEventDeleted,
+	}, eventTypes(*events))
+
+	escalated := (*events)[2]
+	assert.Equal(t, models.IncidentSeverityHigh, escalated.Incident.Severity)
+	assert.Equal(t, 3, escalated.Incident.OccurrenceCount)
+
+	resolved := (*events)[4]
+	assert.Equal(t, models.IncidentStatusActive, resolved.PreviousStatus)
+	assert.Equal(t, models.IncidentStatusResolved, resolved.Incident.Status)
+	assert.Equal(t, created.ID, (*events)[5].Incident.ID)
+}
+
+// TestIncidentStore_Observers_FailedChangeNotReported verifies rejected changes produce no events
+func TestIncidentStore_Observers_FailedChangeNotReported(t *testing.T) {
+	store := NewIncidentStore()
+	events := recordEvents(store)
+
+	_, err := store.Create(&models.Incident{})
+	require.Error(t, err)
+	require.Error(t, store.Delete("missing"))
+	require.Error(t, store.Update(&models.Incident{ID: "missing"}))
+
+	assert.Empty(t, *events)
+}
+
+// TestIncidentStore_Observers_AutoResolve verifies auto-resolution reports a status change per incident
+func TestIncidentStore_Observers_AutoResolve(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetAutoResolvePolicy(AutoResolvePolicy{Enabled: true, QuietPeriod: time.Hour})
+
+	incident, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	ageIncident(t, store, incident.ID, 2*time.Hour)
+
+	events := recordEvents(store)
+	resolved, err := store.AutoResolveStale()
+	require.NoError(t, err)
+	require.Equal(t, 1, resolved)
+
+	require.Len(t, *events, 1)
+	assert.Equal(t, IncidentEventStatusChanged, (*events)[0].Type)
+	assert.Equal(t, models.IncidentStatusResolved, (*events)[0].Incident.Status)
+}
+
+// TestIncidentStore_Observers_RunOutsideLock verifies observers can call back into the store
+func TestIncidentStore_Observers_RunOutsideLock(t *testing.T) {
+	store := NewIncidentStore()
+	counts := make(chan int, 1)
+	store.RegisterObserver(func(IncidentEvent) {
+		counts <- store.Count()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("observer deadlocked on the store lock")
+	}
+	assert.Equal(t, 1, <-counts)
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// resolutionBucketBounds are the upper bounds of the resolution-time histogram buckets.
+// Resolutions slower than the last bound fall into a final "+Inf" bucket.
+var resolutionBucketBounds = []struct {
+	label string
+	bound time.Duration
+}{
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"4h", 4 * time.Hour},
+	{"24h", 24 * time.Hour},
+}
+
+// IncidentStats summarizes the incidents matching a ListFilter
+type IncidentStats struct {
+	Total          int                 `json:"total"`
+	BySeverity     map[string]int      `json:"by_severity"`
+	ByStatus       map[string]int      `json:"by_status"`
+	ByNamespace    map[string]int      `json:"by_namespace"`
+	ResolutionTime ResolutionTimeStats `json:"resolution_time"`
+}
+
+// ResolutionTimeStats describes how long resolved incidents took from creation to resolution.
+// Durations are in seconds; they are zero when Count is zero.
+type ResolutionTimeStats struct {
+	Count       int                `json:"count"`
+	MinSeconds  float64            `json:"min_seconds"`
+	MeanSeconds float64            `json:"mean_seconds"`
+	P50Seconds  float64            `json:"p50_seconds"`
+	P90Seconds  float64            `json:"p90_seconds"`
+	MaxSeconds  float64            `json:"max_seconds"`
+	Buckets     []ResolutionBucket `json:"buckets"`
+}
+
+// ResolutionBucket counts resolutions that took at most UpperBound (and longer than the
+// previous bucket's bound)
+type ResolutionBucket struct {
+	UpperBound string `json:"le"`
+	Count      int    `json:"count"`
+}
+
+// Stats aggregates incidents matching filter by severity, status and namespace (Target), and
+// summarizes resolution times of the resolved ones. filter.Limit is ignored.
+func (s *IncidentStore) Stats(filter ListFilter) IncidentStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := IncidentStats{
+		BySeverity:  make(map[string]int),
+		ByStatus:    make(map[string]int),
+		ByNamespace: make(map[string]int),
+	}
+
+	var resolutionTimes []time.Duration
+	for _, incident := range s.incidents {
+		if !filter.matches(incident) {
+			continue
+		}
+
+		stats.Total++
+		stats.BySeverity[string(incident.Severity)]++
+		stats.ByStatus[string(incident.Status)]++
+		stats.ByNamespace[incident.Target]++
+
+		if incident.ResolvedAt != nil && !incident.IsActive() {
+			resolutionTimes = append(resolutionTimes, incident.ResolvedAt.Sub(incident.CreatedAt))
+		}
+	}
+
+	stats.ResolutionTime = summarizeResolutionTimes(resolutionTimes)
+	return stats
+}
+
+// summarizeResolutionTimes computes the resolution-time summary and histogram
+func summarizeResolutionTimes(durations []time.Duration) ResolutionTimeStats {
+	summary := ResolutionTimeStats{
+		Count:   len(durations),
+		Buckets: make([]ResolutionBucket, len(resolutionBucketBounds)+1),
+	}
+	for i, b := range resolutionBucketBounds {
+		summary.Buckets[i].UpperBound = b.label
+	}
+	summary.Buckets[len(resolutionBucketBounds)].UpperBound = "+Inf"
+
+	if len(durations) == 0 {
+		return summary
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+		bucket := sort.Search(len(resolutionBucketBounds), func(i int) bool {
+			return d <= resolutionBucketBounds[i].bound
+		})
+		summary.Buckets[bucket].Count++
+	}
+
+	summary.MinSeconds = durations[0].Seconds()
+	summary.MaxSeconds = durations[len(durations)-1].Seconds()
+	summary.MeanSeconds = (total / time.Duration(len(durations))).Seconds()
+	summary.P50Seconds = nearestRank(durations, 0.50).Seconds()
+	summary.P90Seconds = nearestRank(durations, 0.90).Seconds()
+	return summary
+}
+
+// nearestRank returns the q-quantile of sorted using the nearest-rank method
+func nearestRank(sorted []time.Duration, q float64) time.Duration {
+	rank := int(math.Ceil(q * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// maxIntervalBuckets bounds the series CountByInterval returns, so a tiny bucket over a long
+// range cannot allocate without limit
+const maxIntervalBuckets = 10000
+
+// IntervalCount is the number of incidents created in [BucketStart, BucketStart+bucket)
+type IntervalCount struct {
+	BucketStart time.Time `json:"bucket_start"`
+	Count       int       `json:"count"`
+}
+
+// CountByInterval counts incidents matching filter by CreatedAt into consecutive buckets from
+// since up to now, e.g. hourly counts over the last 24h for a sparkline. since is truncated to
+// a multiple of bucket so buckets line up with clock boundaries, and empty buckets are
+// included with a zero count so the series is continuous. When filter.Since is later than
+// since it narrows which incidents are counted; filter.Limit is ignored.
+func (s *IncidentStore) CountByInterval(filter ListFilter, bucket time.Duration, since time.Time) ([]IntervalCount, error) {
+	if bucket <= 0 {
+		return nil, fmt.Errorf("bucket must be positive, got %s", bucket)
+	}
+
+	now := time.Now()
+	start := since.Truncate(bucket)
+	if start.After(now) {
+		return nil, fmt.Errorf("since %s is in the future", since.Format(time.RFC3339))
+	}
+	buckets := int(now.Sub(start)/bucket) + 1
+	if buckets > maxIntervalBuckets {
+		return nil, fmt.Errorf("%d buckets of %s since %s exceed the limit of %d", buckets, bucket, since.Format(time.RFC3339), maxIntervalBuckets)
+	}
+
+	series := make([]IntervalCount, buckets)
+	for i := range series {
+		series[i].BucketStart = start.Add(time.Duration(i) * bucket)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, incident := range s.incidents {
+		if !filter.matches(incident) || incident.CreatedAt.Before(start) {
+			continue
+		}
+		i := int(incident.CreatedAt.Sub(start) / bucket)
+		if i >= buckets {
+			continue // created after now, e.g. by a skewed clock
+		}
+		series[i].Count++
+	}
+	return series, nil
+}
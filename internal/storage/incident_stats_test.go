@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// createStatsIncident stores an incident created age ago and, when resolvedAfter is positive,
+// resolved that long after creation
+func createStatsIncident(t *testing.T, store *IncidentStore, target, issueType string, severity models.IncidentSeverity, age, resolvedAfter time.Duration) {
+	t.Helper()
+	created, err := store.Create(newTestIncident(target, issueType, severity))
+	require.NoError(t, err)
+
+	stored, err := store.Get(created.ID)
+	require.NoError(t, err)
+	stored.CreatedAt = time.Now().Add(-age)
+	if resolvedAfter > 0 {
+		stored.Resolve()
+		resolvedAt := stored.CreatedAt.Add(resolvedAfter)
+		stored.ResolvedAt = &resolvedAt
+	}
+}
+
+// TestIncidentStore_Stats verifies grouping by severity, status and namespace
+func TestIncidentStore_Stats(t *testing.T) {
+	store := NewIncidentStore()
+	createStatsIncident(t, store, "payments", "crash", models.IncidentSeverityCritical, time.Hour, 0)
+	createStatsIncident(t, store, "payments", "oom", models.IncidentSeverityCritical, 2*time.Hour, 0)
+	createStatsIncident(t, store, "checkout", "crash", models.IncidentSeverityCritical, 3*time.Hour, 0)
+	createStatsIncident(t, store, "checkout", "oom", models.IncidentSeverityLow, 4*time.Hour, 10*time.Minute)
+	createStatsIncident(t, store, "payments", "disk", models.IncidentSeverityCritical, 48*time.Hour, 0)
+
+	stats := store.Stats(ListFilter{})
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, map[string]int{"critical": 4, "low": 1}, stats.BySeverity)
+	assert.Equal(t, map[string]int{"active": 4, "resolved": 1}, stats.ByStatus)
+	assert.Equal(t, map[string]int{"payments": 3, "checkout": 2}, stats.ByNamespace)
+
+	// Active critical incidents per namespace over the last 24h
+	stats = store.Stats(ListFilter{
+		Severity: string(models.IncidentSeverityCritical),
+		Status:   string(models.IncidentStatusActive),
+		Since:    time.Now().Add(-24 * time.Hour),
+		Limit:    1, // ignored
+	})
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, map[string]int{"payments": 2, "checkout": 1}, stats.ByNamespace)
+	assert.Equal(t, 0, stats.ResolutionTime.Count)
+}
+
+// TestIncidentStore_Stats_ResolutionTime verifies the resolution-time summary and histogram
+func TestIncidentStore_Stats_ResolutionTime(t *testing.T) {
+	store := NewIncidentStore()
+	for i, after := range []time.Duration{2 * time.Minute, 10 * time.Minute, 30 * time.Minute, 2 * time.Hour, 48 * time.Hour} {
+		createStatsIncident(t, store, "payments", string(rune('a'+i)), models.IncidentSeverityMedium, 72*time.Hour, after)
+	}
+	createStatsIncident(t, store, "payments", "active", models.IncidentSeverityMedium, time.Hour, 0)
+
+	rt := store.Stats(ListFilter{}).ResolutionTime
+	assert.Equal(t, 5, rt.Count)
+	assert.Equal(t, 120.0, rt.MinSeconds)
+	assert.Equal(t, 1800.0, rt.P50Seconds)
+	assert.Equal(t, (48 * time.Hour).Seconds(), rt.P90Seconds)
+	assert.Equal(t, (48 * time.Hour).Seconds(), rt.MaxSeconds)
+	assert.InDelta(t, (2*time.Minute+10*time.Minute+30*time.Minute+2*time.Hour+48*time.Hour).Seconds()/5, rt.MeanSeconds, 0.001)
+
+	require.Len(t, rt.Buckets, 6)
+	counts := make(map[string]int)
+	for _, b := range rt.Buckets {
+		counts[b.UpperBound] = b.Count
+	}
+	assert.Equal(t, map[string]int{"5m": 1, "15m": 1, "1h": 1, "4h": 1, "24h": 0, "+Inf": 1}, counts)
+}
+
+// TestListFilter_Since verifies List honors the creation-time lower bound
+func TestListFilter_Since(t *testing.T) {
+	store := NewIncidentStore()
+	createStatsIncident(t, store, "payments", "crash", models.IncidentSeverityHigh, time.Hour, 0)
+	createStatsIncident(t, store, "payments", "oom", models.IncidentSeverityHigh, 48*time.Hour, 0)
+
+	assert.Len(t, store.List(ListFilter{}), 2)
+	assert.Len(t, store.List(ListFilter{Since: time.Now().Add(-24 * time.Hour)}), 1)
+}
+
+// TestListFilter_Resolved verifies the resolution window skips unresolved incidents and
+// narrows the resolution-time summary
+func TestListFilter_Resolved(t *testing.T) {
+	store := NewIncidentStore()
+	createStatsIncident(t, store, "payments", "crash", models.IncidentSeverityHigh, 72*time.Hour, time.Hour) // resolved 71h ago
+	createStatsIncident(t, store, "payments", "oom", models.IncidentSeverityHigh, 10*time.Hour, 2*time.Hour) // resolved 8h ago
+	createStatsIncident(t, store, "payments", "disk", models.IncidentSeverityHigh, 5*time.Hour, 0)           // active
+
+	now := time.Now()
+	recent := store.List(ListFilter{ResolvedAfter: now.Add(-24 * time.Hour)})
+	require.Len(t, recent, 1)
+	assert.Equal(t, "oom", recent[0].IssueType)
+
+	older := store.List(ListFilter{ResolvedBefore: now.Add(-24 * time.Hour)})
+	require.Len(t, older, 1)
+	assert.Equal(t, "crash", older[0].IssueType)
+
+	assert.Len(t, store.List(ListFilter{ResolvedAfter: now.Add(-96 * time.Hour), ResolvedBefore: now}), 2)
+
+	stats := store.Stats(ListFilter{ResolvedAfter: now.Add(-24 * time.Hour)})
+	assert.Equal(t, 1, stats.Total)
+	assert.Equal(t, 1, stats.ResolutionTime.Count)
+	assert.Equal(t, (2 * time.Hour).Seconds(), stats.ResolutionTime.MeanSeconds)
+}
+
+// TestIncidentStore_CountByInterval verifies incidents are counted into aligned, zero-filled buckets
+func TestIncidentStore_CountByInterval(t *testing.T) {
+	store := NewIncidentStore()
+	since := time.Now().Add(-24 * time.Hour)
+	start := since.Truncate(time.Hour)
+	createdAt := func(offset time.Duration) time.Duration { return time.Since(start.Add(offset)) }
+
+	createStatsIncident(t, store, "payments", "crash", models.IncidentSeverityHigh, createdAt(10*time.Minute), 0)
+	createStatsIncident(t, store, "payments", "oom", models.IncidentSeverityHigh, createdAt(50*time.Minute), 0)
+	createStatsIncident(t, store, "checkout", "crash", models.IncidentSeverityLow, createdAt(3*time.Hour+5*time.Minute), 0)
+	createStatsIncident(t, store, "payments", "disk", models.IncidentSeverityHigh, createdAt(-time.Hour), 0) // before the series
+
+	series, err := store.CountByInterval(ListFilter{}, time.Hour, since)
+	require.NoError(t, err)
+	require.Len(t, series, 25)
+	for i, point := range series {
+		assert.Equal(t, start.Add(time.Duration(i)*time.Hour), point.BucketStart)
+	}
+	assert.Equal(t, 2, series[0].Count)
+	assert.Equal(t, 0, series[1].Count)
+	assert.Equal(t, 1, series[3].Count)
+
+	total := 0
+	for _, point := range series {
+		total += point.Count
+	}
+	assert.Equal(t, 3, total)
+
+	series, err = store.CountByInterval(ListFilter{Namespace: "checkout"}, time.Hour, since)
+	require.NoError(t, err)
+	assert.Equal(t, 0, series[0].Count)
+	assert.Equal(t, 1, series[3].Count)
+}
+
+// TestIncidentStore_CountByInterval_InvalidArguments verifies bad buckets and ranges are rejected
+func TestIncidentStore_CountByInterval_InvalidArguments(t *testing.T) {
+	store := NewIncidentStore()
+
+	_, err := store.CountByInterval(ListFilter{}, 0, time.Now().Add(-time.Hour))
+	assert.Error(t, err)
+
+	_, err = store.CountByInterval(ListFilter{}, time.Minute, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+
+	_, err = store.CountByInterval(ListFilter{}, time.Second, time.Now().Add(-30*24*time.Hour))
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultWriteBehindFlushInterval is the base delay between write-behind flushes
+const DefaultWriteBehindFlushInterval = 5 * time.Second
+
+// WriteBehindPolicy controls write-behind persistence. In write-behind mode mutations only mark
+// the store dirty and a background loop writes the file, so a burst of changes costs one write
+// instead of one per change. The trade-off is durability: changes made since the last flush are
+// lost if the process dies without Close, e.g. on SIGKILL or an OOM kill, and write errors are
+// logged by the flush loop instead of failing (and rolling back) the mutation. Purge is the
+// exception: it is always written before it returns and rolled back if the write fails.
+type WriteBehindPolicy struct {
+	// Enabled switches mutations from synchronous writes to write-behind
+	Enabled bool
+
+	// FlushInterval is the base delay between flushes (0 = DefaultWriteBehindFlushInterval)
+	FlushInterval time.Duration
+
+	// Jitter randomizes each delay by up to this fraction of FlushInterval in either
+	// direction (0.1 = ±10%), so replicas sharing a volume don't write in lockstep
+	Jitter float64
+
+	// MaxPending flushes early once this many mutations are unwritten, bounding how much a
+	// crash can lose during a burst (0 = flush on the interval only)
+	MaxPending int
+}
+
+// DefaultWriteBehindPolicy returns the write-behind policy used when none is configured
+// (disabled: every mutation is written before it returns)
+func DefaultWriteBehindPolicy() WriteBehindPolicy {
+	return WriteBehindPolicy{
+		Enabled:       false,
+		FlushInterval: DefaultWriteBehindFlushInterval,
+		Jitter:        0.1,
+		MaxPending:    1000,
+	}
+}
+
+// nextInterval returns the jittered delay before the next flush
+func (p WriteBehindPolicy) nextInterval() time.Duration {
+	interval := p.FlushInterval
+	if interval <= 0 {
+		interval = DefaultWriteBehindFlushInterval
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 {
+		return interval
+	}
+	// Uniform in [interval*(1-jitter), interval*(1+jitter)]
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(interval) * factor)
+}
+
+// writeBehindState is the flush loop of a store in write-behind mode
+type writeBehindState struct {
+	pending  int           // Mutations not yet written
+	flushNow chan struct{} // Signalled when pending reaches MaxPending
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// StartWriteBehind switches the store to write-behind persistence and starts the flush loop.
+// Call Close on shutdown to stop the loop and write pending changes. A disabled policy leaves
+// the store synchronous.
+func (s *IncidentStore) StartWriteBehind(policy WriteBehindPolicy) error {
+	if !policy.Enabled {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.filePath == "" {
+		return errors.New("write-behind requires file persistence")
+	}
+	if s.writeBehind != nil {
+		return errors.New("write-behind already started")
+	}
+
+	state := &writeBehindState{
+		flushNow: make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	s.writeBehindPolicy = policy
+	s.writeBehind = state
+	go s.runWriteBehind(policy, state)
+
+	s.log.WithFields(logrus.Fields{
+		"flush_interval": policy.FlushInterval,
+		"max_pending":    policy.MaxPending,
+	}).Info("Incident write-behind persistence enabled")
+	return nil
+}
+
+// runWriteBehind flushes on each jittered interval and whenever MaxPending is reached. A failed
+// flush keeps the store dirty, so the changes are retried on the next one.
+func (s *IncidentStore) runWriteBehind(policy WriteBehindPolicy, state *writeBehindState) {
+	defer close(state.done)
+
+	timer := time.NewTimer(policy.nextInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			timer.Reset(policy.nextInterval())
+		case <-state.flushNow:
+		case <-state.stop:
+			return
+		}
+		if err := s.Flush(); err != nil {
+			s.log.WithError(err).Warn("Failed to flush incidents, will retry")
+		}
+	}
+}
+
+// Flush writes pending write-behind changes to disk. It does nothing when the store is
+// synchronous or has no unwritten changes.
+func (s *IncidentStore) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writeBehind == nil || s.writeBehind.pending == 0 {
+		return nil
+	}
+	if err := s.saveToFileUnsafe(); err != nil {
+		return err
+	}
+	s.writeBehind.pending = 0
+	return nil
+}
+
+// Close stops the write-behind loop and writes pending changes. The store returns to
+// synchronous persistence, so mutations after Close are still written.
+func (s *IncidentStore) Close() error {
+	s.mu.Lock()
+	state := s.writeBehind
+	s.mu.Unlock()
+	if state == nil {
+		return nil
+	}
+
+	close(state.stop)
+	<-state.done
+
+	err := s.Flush()
+
+	// Unwritten changes are still in memory; the next synchronous write includes them
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeBehind = nil
+	return err
+}
+
+// persistNowUnsafe writes the store even in write-behind mode, for mutations that must be durable
+// before they return, such as Purge (caller must hold lock). The write also covers pending changes.
+func (s *IncidentStore) persistNowUnsafe() error {
+	if err := s.saveToFileUnsafe(); err != nil {
+		return err
+	}
+	if s.writeBehind != nil {
+		s.writeBehind.pending = 0
+	}
+	return nil
+}
+
+// persistUnsafe persists the store after a mutation (caller must hold lock). In write-behind
+// mode it only records the mutation, so it never fails and callers never roll back.
+func (s *IncidentStore) persistUnsafe() error {
+	state := s.writeBehind
+	if state == nil {
+		return s.saveToFileUnsafe()
+	}
+
+	state.pending++
+	// Signal once per burst; after a failed flush the timer retries instead of every mutation
+	if s.writeBehindPolicy.MaxPending > 0 && state.pending == s.writeBehindPolicy.MaxPending {
+		select {
+		case state.flushNow <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// writeBehindForTest never flushes on its own within a test, so flushes happen only on demand
+func writeBehindForTest(maxPending int) WriteBehindPolicy {
+	return WriteBehindPolicy{Enabled: true, FlushInterval: time.Hour, MaxPending: maxPending}
+}
+
+// TestIncidentStore_WriteBehind_CoalescesUntilFlush verifies mutations are not written until Flush
+func TestIncidentStore_WriteBehind_CoalescesUntilFlush(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.StartWriteBehind(writeBehindForTest(0)))
+	defer store.Close()
+
+	first, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("checkout", "oom_killed", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(first.ID))
+
+	_, err = os.Stat(filepath.Join(dir, "incidents.json"))
+	assert.True(t, os.IsNotExist(err), "nothing should be written before a flush")
+
+	require.NoError(t, store.Flush())
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reloaded.Count())
+}
+
+// TestIncidentStore_WriteBehind_MaxPendingFlushesEarly verifies reaching MaxPending triggers a flush
+func TestIncidentStore_WriteBehind_MaxPendingFlushesEarly(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.StartWriteBehind(writeBehindForTest(2)))
+	defer store.Close()
+
+	for _, target := range []string{"payments", "checkout"} {
+		_, err := store.Create(newTestIncident(target, "pod_crash_loop", models.IncidentSeverityHigh))
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+		return err == nil && reloaded.Count() == 2
+	}, 2*time.Second, 5*time.Millisecond)
+}
+
+// TestIncidentStore_WriteBehind_CloseFlushesAndRestoresSync verifies Close writes pending changes
+// and later mutations are written synchronously
+func TestIncidentStore_WriteBehind_CloseFlushesAndRestoresSync(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.StartWriteBehind(writeBehindForTest(0)))
+
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reloaded.Count())
+
+	_, err = store.Create(newTestIncident("checkout", "oom_killed", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	reloaded, err = NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reloaded.Count())
+
+	assert.NoError(t, store.Close(), "closing twice is a no-op")
+}
+
+// TestIncidentStore_WriteBehind_FailedWriteDoesNotRollBack verifies a mutation succeeds when the
+// disk is unavailable and the flush reports the error
+func TestIncidentStore_WriteBehind_FailedWriteDoesNotRollBack(t *testing.T) {
+	store, err := NewIncidentStoreWithPersistence(t.TempDir(), nil)
+	require.NoError(t, err)
+	require.NoError(t, store.StartWriteBehind(writeBehindForTest(0)))
+	store.filePath = filepath.Join(t.TempDir(), "missing", "incidents.json")
+
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Count())
+
+	assert.Error(t, store.Flush())
+	assert.Error(t, store.Close())
+}
+
+// TestIncidentStore_WriteBehind_PurgeIsSynchronous verifies a purge is written before it returns
+// and rolled back when the write fails, even in write-behind mode
+func TestIncidentStore_WriteBehind_PurgeIsSynchronous(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+	require.NoError(t, store.StartWriteBehind(writeBehindForTest(0)))
+	defer store.Close()
+
+	count, err := store.Purge(PurgeConfirmToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Zero(t, reloaded.Count(), "the purge is on disk without a flush")
+
+	_, err = store.Create(newTestIncident("checkout", "oom_killed", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	store.filePath = filepath.Join(t.TempDir(), "missing", "incidents.json")
+	_, err = store.Purge(PurgeConfirmToken)
+	require.Error(t, err)
+	assert.Equal(t, 1, store.Count(), "a failed purge deletes nothing")
+}
+
+// TestIncidentStore_StartWriteBehind_Errors verifies write-behind needs persistence and starts once
+func TestIncidentStore_StartWriteBehind_Errors(t *testing.T) {
+	assert.Error(t, NewIncidentStore().StartWriteBehind(writeBehindForTest(0)))
+	assert.NoError(t, NewIncidentStore().StartWriteBehind(DefaultWriteBehindPolicy()), "disabled policy is a no-op")
+
+	store, err := NewIncidentStoreWithPersistence(t.TempDir(), nil)
+	require.NoError(t, err)
+	require.NoError(t, store.StartWriteBehind(writeBehindForTest(0)))
+	defer store.Close()
+	assert.Error(t, store.StartWriteBehind(writeBehindForTest(0)))
+}
+
+func TestWriteBehindPolicy_NextInterval(t *testing.T) {
+	assert.Equal(t, DefaultWriteBehindFlushInterval, WriteBehindPolicy{}.nextInterval())
+
+	policy := WriteBehindPolicy{FlushInterval: 10 * time.Second, Jitter: 0.1}
+	for range 100 {
+		interval := policy.nextInterval()
+		assert.GreaterOrEqual(t, interval, 9*time.Second)
+		assert.LessOrEqual(t, interval, 11*time.Second)
+	}
+}
@@ -3,9 +3,13 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -16,20 +20,90 @@ import (
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
 )
 
+// EscalationPolicy controls severity escalation when the same Target/IssueType recurs
+type EscalationPolicy struct {
+	// Enabled turns on recurrence tracking in Create
+	Enabled bool
+
+	// RecurrenceWindow is the sliding window in which sightings count towards escalation
+	RecurrenceWindow time.Duration
+
+	// Thresholds are occurrence counts within the window at which severity is bumped one level,
+	// e.g. [3, 5] escalates on the 3rd and again on the 5th sighting. Each threshold applies once
+	// per burst; a burst ends when a whole window passes without a sighting
+	Thresholds []int
+}
+
+// DefaultEscalationPolicy returns the escalation policy used when none is configured (disabled)
+func DefaultEscalationPolicy() EscalationPolicy {
+	return EscalationPolicy{
+		Enabled:          false,
+		RecurrenceWindow: time.Hour,
+		Thresholds:       []int{3, 5},
+	}
+}
+
+// AutoResolvePolicy controls automatic resolution of active incidents that stop recurring
+type AutoResolvePolicy struct {
+	// Enabled turns on AutoResolveStale
+	Enabled bool
+
+	// QuietPeriod is how long an incident's Target/IssueType must go unreported before it is resolved
+	QuietPeriod time.Duration
+}
+
+// DefaultAutoResolvePolicy returns the auto-resolve policy used when none is configured (disabled)
+func DefaultAutoResolvePolicy() AutoResolvePolicy {
+	return AutoResolvePolicy{
+		Enabled:     false,
+		QuietPeriod: 24 * time.Hour,
+	}
+}
+
+// MaxIdempotencyKeysPerIncident bounds the idempotency keys kept for one incident. An incident
+// that keeps recurring under new keys only replays its most recent creates.
+const MaxIdempotencyKeysPerIncident = 32
+
+// storedIncident is an incident as written to the incidents file, together with the
+// idempotency keys that resolve to it. The keys are store-internal and not part of the API model.
+type storedIncident struct {
+	*models.Incident
+	IdempotencyKeys []string `json:"idempotency_keys,omitempty"`
+}
+
 // IncidentStore manages incident storage and retrieval
 type IncidentStore struct {
-	incidents map[string]*models.Incident
-	mu        sync.RWMutex
-	filePath  string // Path to persistent storage file (empty = in-memory only)
-	log       *logrus.Logger
+	incidents    map[string]*models.Incident
+	idempotency  map[string]string   // idempotency key -> incident ID, rebuilt from incidentKeys on load
+	incidentKeys map[string][]string // incident ID -> its idempotency keys, oldest first
+	mu           sync.RWMutex
+	filePath     string // Path to persistent storage file (empty = in-memory only)
+	log          *logrus.Logger
+	escalation   EscalationPolicy
+	autoResolve  AutoResolvePolicy
+
+	// Retention of resolved incidents per severity, overriding CleanupOldIncidents' default
+	severityRetention map[models.IncidentSeverity]int
+
+	// Write-behind persistence; nil writeBehind means every mutation is written synchronously
+	writeBehindPolicy WriteBehindPolicy
+	writeBehind       *writeBehindState
+
+	// Observers are notified after changes are persisted and mu is released
+	observers   []IncidentObserver
+	observersMu sync.RWMutex
 }
 
 // NewIncidentStore creates a new in-memory incident store (no persistence)
 func NewIncidentStore() *IncidentStore {
 	return &IncidentStore{
-		incidents: make(map[string]*models.Incident),
-		filePath:  "",
-		log:       logrus.New(),
+		incidents:    make(map[string]*models.Incident),
+		idempotency:  make(map[string]string),
+		incidentKeys: make(map[string][]string),
+		filePath:     "",
+		log:          logrus.New(),
+		escalation:   DefaultEscalationPolicy(),
+		autoResolve:  DefaultAutoResolvePolicy(),
 	}
 }
 
@@ -47,9 +121,13 @@ func NewIncidentStoreWithPersistence(dataDir string, log *logrus.Logger) (*Incid
 	filePath := filepath.Join(dataDir, "incidents.json")
 
 	store := &IncidentStore{
-		incidents: make(map[string]*models.Incident),
-		filePath:  filePath,
-		log:       log,
+		incidents:    make(map[string]*models.Incident),
+		idempotency:  make(map[string]string),
+		incidentKeys: make(map[string][]string),
+		filePath:     filePath,
+		log:          log,
+		escalation:   DefaultEscalationPolicy(),
+		autoResolve:  DefaultAutoResolvePolicy(),
 	}
 
 	// Load existing incidents from file
@@ -60,14 +138,79 @@ func NewIncidentStoreWithPersistence(dataDir string, log *logrus.Logger) (*Incid
 	return store, nil
 }
 
-// Create stores a new incident and returns the generated ID
+// SetEscalationPolicy configures severity escalation on recurrence
+func (s *IncidentStore) SetEscalationPolicy(policy EscalationPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.escalation = policy
+}
+
+// SetAutoResolvePolicy configures automatic resolution of incidents that stop recurring
+func (s *IncidentStore) SetAutoResolvePolicy(policy AutoResolvePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoResolve = policy
+}
+
+// SetSeverityRetention sets how many days resolved incidents of each severity are kept by
+// CleanupOldIncidents, e.g. keeping critical incidents longer for post-incident review.
+// Severities not listed use the default passed to CleanupOldIncidents; 0 keeps them forever.
+func (s *IncidentStore) SetSeverityRetention(days map[models.IncidentSeverity]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.severityRetention = maps.Clone(days)
+}
+
+// Create stores a new incident and returns the generated ID.
+// When escalation is enabled and an active incident with the same Target and IssueType
+// already exists, the sighting is recorded on that incident and it is returned instead.
 func (s *IncidentStore) Create(incident *models.Incident) (*models.Incident, error) {
+	created, _, err := s.CreateIdempotent(incident, "")
+	return created, err
+}
+
+// CreateIdempotent behaves like Create, but makes retries of the same logical create safe:
+// if idempotencyKey is non-empty and an earlier create with that key produced an incident,
+// that incident is returned unchanged and replayed is true. An empty key disables the check.
+func (s *IncidentStore) CreateIdempotent(incident *models.Incident, idempotencyKey string) (created *models.Incident, replayed bool, err error) {
+	// Deferred before the unlock so observers run after the lock is released
+	var events []IncidentEvent
+	defer func() { s.notify(events) }()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if idempotencyKey != "" {
+		if id, ok := s.idempotency[idempotencyKey]; ok {
+			if existing, exists := s.incidents[id]; exists {
+				s.log.WithFields(logrus.Fields{
+					"incident_id":     id,
+					"idempotency_key": idempotencyKey,
+				}).Debug("Incident create replayed for idempotency key")
+				return existing, true, nil
+			}
+		}
+	}
+
 	// Validate incident
 	if err := incident.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, false, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := models.ValidateLabels(incident.Labels); err != nil {
+		return nil, false, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if existing := s.findRecurrenceUnsafe(incident); existing != nil {
+		updated, err := s.recordRecurrenceUnsafe(existing, idempotencyKey)
+		if err != nil {
+			return nil, false, err
+		}
+		eventType := IncidentEventRecurred
+		if updated.Severity != existing.Severity {
+			eventType = IncidentEventEscalated
+		}
+		events = append(events, newIncidentEvent(eventType, updated, existing.Status))
+		return updated, false, nil
 	}
 
 	// Generate ID if not provided
@@ -85,19 +228,143 @@ func (s *IncidentStore) Create(incident *models.Incident) (*models.Incident, err
 		incident.Status = models.IncidentStatusActive
 	}
 
+	incident.OccurrenceCount = 1
+	incident.RecentOccurrences = []time.Time{now}
+	incident.LastSeen = now
+	incident.RecordHistory("created")
+
 	// Store incident
 	s.incidents[incident.ID] = incident
+	if idempotencyKey != "" {
+		s.addKeyUnsafe(incident.ID, idempotencyKey)
+	}
 
 	// Persist to file if enabled
 	if s.filePath != "" {
-		if err := s.saveToFileUnsafe(); err != nil {
+		if err := s.persistUnsafe(); err != nil {
 			// Rollback in-memory change on persistence failure
 			delete(s.incidents, incident.ID)
-			return nil, fmt.Errorf("failed to persist incident: %w", err)
+			s.setKeysUnsafe(incident.ID, nil)
+			return nil, false, fmt.Errorf("failed to persist incident: %w", err)
 		}
 	}
 
-	return incident, nil
+	events = append(events, newIncidentEvent(IncidentEventCreated, incident, ""))
+	return incident, false, nil
+}
+
+// setKeysUnsafe replaces the idempotency keys that resolve to incident id; nil removes them
+// all (caller must hold lock)
+func (s *IncidentStore) setKeysUnsafe(id string, keys []string) {
+	for _, key := range s.incidentKeys[id] {
+		if s.idempotency[key] == id {
+			delete(s.idempotency, key)
+		}
+	}
+	if len(keys) == 0 {
+		delete(s.incidentKeys, id)
+		return
+	}
+	s.incidentKeys[id] = keys
+	for _, key := range keys {
+		s.idempotency[key] = id
+	}
+}
+
+// addKeyUnsafe attaches an idempotency key to incident id, dropping its oldest keys beyond
+// MaxIdempotencyKeysPerIncident (caller must hold lock)
+func (s *IncidentStore) addKeyUnsafe(id, key string) {
+	keys := append(slices.Clone(s.incidentKeys[id]), key)
+	if len(keys) > MaxIdempotencyKeysPerIncident {
+		keys = keys[len(keys)-MaxIdempotencyKeysPerIncident:]
+	}
+	s.setKeysUnsafe(id, keys)
+}
+
+// findRecurrenceUnsafe returns the active incident the new one is a recurrence of, if any (caller must hold lock)
+func (s *IncidentStore) findRecurrenceUnsafe(incident *models.Incident) *models.Incident {
+	if !s.escalation.Enabled || incident.IssueType == "" {
+		return nil
+	}
+
+	for _, existing := range s.incidents {
+		if existing.IsActive() &&
+			existing.Target == incident.Target &&
+			existing.IssueType == incident.IssueType {
+			return existing
+		}
+	}
+	return nil
+}
+
+// recordRecurrenceUnsafe counts a new sighting on an existing incident and escalates
+// its severity when a threshold is reached inside the recurrence window. A non-empty
+// idempotencyKey is attached to the incident so a retried create replays it (caller must hold lock).
+func (s *IncidentStore) recordRecurrenceUnsafe(existing *models.Incident, idempotencyKey string) (*models.Incident, error) {
+	// Work on a copy so a persistence failure leaves the stored incident untouched
+	updated := *existing
+	updated.StatusHistory = append([]models.IncidentHistoryEntry(nil), existing.StatusHistory...)
+	previousKeys := s.incidentKeys[existing.ID]
+
+	now := time.Now()
+	cutoff := now.Add(-s.escalation.RecurrenceWindow)
+	recent := make([]time.Time, 0, len(existing.RecentOccurrences)+1)
+	for _, ts := range existing.RecentOccurrences {
+		if ts.After(cutoff) {
+			recent = append(recent, ts)
+		}
+	}
+	if len(recent) == 0 {
+		// A full window passed without a sighting, so this starts a new burst
+		updated.EscalatedAtThreshold = 0
+	}
+	recent = append(recent, now)
+
+	updated.RecentOccurrences = recent
+	updated.OccurrenceCount++
+	updated.LastSeen = now
+	updated.UpdatedAt = now
+
+	for _, threshold := range s.escalation.Thresholds {
+		// Thresholds already escalated at in this burst stay spent even when pruning
+		// drops the window count below them and a later sighting reaches them again
+		if threshold <= updated.EscalatedAtThreshold || len(recent) < threshold {
+			continue
+		}
+		next := models.NextSeverity(updated.Severity)
+		if next == updated.Severity {
+			break
+		}
+		previous := updated.Severity
+		updated.Severity = next
+		updated.EscalatedAtThreshold = threshold
+		updated.RecordHistory(fmt.Sprintf("escalated from %s: %d occurrences within %s",
+			previous, len(recent), s.escalation.RecurrenceWindow))
+
+		s.log.WithFields(logrus.Fields{
+			"incident_id": updated.ID,
+			"target":      updated.Target,
+			"issue_type":  updated.IssueType,
+			"occurrences": len(recent),
+			"severity":    updated.Severity,
+		}).Info("Incident severity escalated on recurrence")
+		break
+	}
+
+	s.incidents[existing.ID] = &updated
+	if idempotencyKey != "" {
+		s.addKeyUnsafe(existing.ID, idempotencyKey)
+	}
+
+	if s.filePath != "" {
+		if err := s.persistUnsafe(); err != nil {
+			s.incidents[existing.ID] = existing
+			s.setKeysUnsafe(existing.ID, previousKeys)
+			return nil, fmt.Errorf("failed to persist incident recurrence: %w", err)
+		}
+	}
+
+	return &updated, nil
 }
 
 // Get retrieves an incident by ID
@@ -115,6 +382,9 @@ func (s *IncidentStore) Get(id string) (*models.Incident, error) {
 
 // Update modifies an existing incident
 func (s *IncidentStore) Update(incident *models.Incident) error {
+	var events []IncidentEvent
+	defer func() { s.notify(events) }()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -123,24 +393,160 @@ func (s *IncidentStore) Update(incident *models.Incident) error {
 	if !exists {
 		return fmt.Errorf("incident not found: %s", incident.ID)
 	}
+	// Labels loaded from an older file may predate validation; only changed labels are checked
+	if !maps.Equal(incident.Labels, oldIncident.Labels) {
+		if err := models.ValidateLabels(incident.Labels); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
 
 	incident.UpdatedAt = time.Now()
 	s.incidents[incident.ID] = incident
 
 	// Persist to file if enabled
 	if s.filePath != "" {
-		if err := s.saveToFileUnsafe(); err != nil {
+		if err := s.persistUnsafe(); err != nil {
 			// Rollback in-memory change on persistence failure
 			s.incidents[incident.ID] = oldIncident
 			return fmt.Errorf("failed to persist incident update: %w", err)
 		}
 	}
 
+	events = append(events, changeEvent(oldIncident, incident))
 	return nil
 }
 
+// LinkWorkflow records the outcome of a finished remediation workflow on an incident.
+// A completed workflow resolves the incident if it is still active; a failed one leaves it
+// open and increments RemediationFailures.
+func (s *IncidentStore) LinkWorkflow(incidentID, workflowID string, outcome models.WorkflowStatus) (*models.Incident, error) {
+	if outcome != models.WorkflowStatusCompleted && outcome != models.WorkflowStatusFailed {
+		return nil, fmt.Errorf("invalid workflow outcome: %s (must be %s or %s)",
+			outcome, models.WorkflowStatusCompleted, models.WorkflowStatusFailed)
+	}
+
+	var events []IncidentEvent
+	defer func() { s.notify(events) }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, exists := s.incidents[incidentID]
+	if !exists {
+		return nil, fmt.Errorf("incident not found: %s", incidentID)
+	}
+
+	// Work on a copy so a persistence failure leaves the stored incident untouched
+	updated := *existing
+	updated.StatusHistory = append([]models.IncidentHistoryEntry(nil), existing.StatusHistory...)
+
+	updated.WorkflowID = workflowID
+	updated.RemediationOutcome = outcome
+	updated.RemediationAttempts++
+	updated.UpdatedAt = time.Now()
+
+	if outcome == models.WorkflowStatusFailed {
+		updated.RemediationFailures++
+		updated.RecordHistory(fmt.Sprintf("remediation workflow %s failed", workflowID))
+	} else {
+		updated.RecordHistory(fmt.Sprintf("remediation workflow %s completed", workflowID))
+		if updated.IsActive() {
+			updated.Resolve()
+		}
+	}
+
+	s.incidents[incidentID] = &updated
+
+	if s.filePath != "" {
+		if err := s.persistUnsafe(); err != nil {
+			s.incidents[incidentID] = existing
+			return nil, fmt.Errorf("failed to persist workflow link: %w", err)
+		}
+	}
+
+	events = append(events, changeEvent(existing, &updated))
+	return &updated, nil
+}
+
+// AutoResolveStale resolves active incidents whose Target/IssueType has not been reported
+// within the policy's quiet period and returns how many were resolved. A sighting on any
+// incident with the same Target and IssueType counts, so duplicates created while
+// escalation is disabled keep each other open. Incidents without an IssueType only count
+// their own sightings. It is a no-op when the policy is disabled.
+func (s *IncidentStore) AutoResolveStale() (int, error) {
+	var events []IncidentEvent
+	defer func() { s.notify(events) }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.autoResolve.Enabled || s.autoResolve.QuietPeriod <= 0 {
+		return 0, nil
+	}
+
+	lastSeen := make(map[string]time.Time)
+	for _, incident := range s.incidents {
+		key := recurrenceKey(incident)
+		if seen := incident.LastSeenAt(); seen.After(lastSeen[key]) {
+			lastSeen[key] = seen
+		}
+	}
+
+	cutoff := time.Now().Add(-s.autoResolve.QuietPeriod)
+	reason := fmt.Sprintf("auto-resolved: no recurrence within %s", s.autoResolve.QuietPeriod)
+	previous := make(map[string]*models.Incident)
+	for id, incident := range s.incidents {
+		if !incident.IsActive() || !lastSeen[recurrenceKey(incident)].Before(cutoff) {
+			continue
+		}
+
+		// Work on a copy so a persistence failure leaves the stored incident untouched
+		updated := *incident
+		updated.StatusHistory = append([]models.IncidentHistoryEntry(nil), incident.StatusHistory...)
+		updated.ResolveWithReason(reason)
+
+		previous[id] = incident
+		s.incidents[id] = &updated
+	}
+
+	if len(previous) == 0 {
+		return 0, nil
+	}
+
+	if s.filePath != "" {
+		if err := s.persistUnsafe(); err != nil {
+			for id, incident := range previous {
+				s.incidents[id] = incident
+			}
+			return 0, fmt.Errorf("failed to persist auto-resolution: %w", err)
+		}
+	}
+
+	for id, incident := range previous {
+		events = append(events, changeEvent(incident, s.incidents[id]))
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"resolved":     len(previous),
+		"quiet_period": s.autoResolve.QuietPeriod,
+	}).Info("Auto-resolved incidents that stopped recurring")
+
+	return len(previous), nil
+}
+
+// recurrenceKey groups incidents that report the same problem
+func recurrenceKey(incident *models.Incident) string {
+	if incident.IssueType == "" {
+		return "id:" + incident.ID
+	}
+	return incident.Target + "|" + incident.IssueType
+}
+
 // Delete removes an incident by ID
 func (s *IncidentStore) Delete(id string) error {
+	var events []IncidentEvent
+	defer func() { s.notify(events) }()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -150,26 +556,121 @@ func (s *IncidentStore) Delete(id string) error {
 		return fmt.Errorf("incident not found: %s", id)
 	}
 
+	deletedKeys := s.incidentKeys[id]
 	delete(s.incidents, id)
+	s.setKeysUnsafe(id, nil)
 
 	// Persist to file if enabled
 	if s.filePath != "" {
-		if err := s.saveToFileUnsafe(); err != nil {
+		if err := s.persistUnsafe(); err != nil {
 			// Rollback in-memory change on persistence failure
 			s.incidents[id] = deleted
+			s.setKeysUnsafe(id, deletedKeys)
 			return fmt.Errorf("failed to persist incident deletion: %w", err)
 		}
 	}
 
+	events = append(events, newIncidentEvent(IncidentEventDeleted, deleted, deleted.Status))
 	return nil
 }
 
+// PurgeConfirmToken must be passed to Purge; it keeps code from wiping the whole store by
+// accident. It is not an API secret: DELETE /api/v1/incidents checks its own configured token.
+const PurgeConfirmToken = "purge-all-incidents"
+
+// ErrPurgeNotConfirmed is returned by Purge when the confirmation token does not match
+var ErrPurgeNotConfirmed = errors.New("purge not confirmed: confirmation token does not match")
+
+// Purge deletes every incident and returns how many were deleted. It only runs when
+// confirmToken equals PurgeConfirmToken. All deletions are persisted with a single write, also
+// in write-behind mode; if that write fails, no incident is deleted.
+func (s *IncidentStore) Purge(confirmToken string) (int, error) {
+	if confirmToken != PurgeConfirmToken {
+		return 0, ErrPurgeNotConfirmed
+	}
+
+	var events []IncidentEvent
+	defer func() { s.notify(events) }()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged, purgedIndex, purgedKeys := s.incidents, s.idempotency, s.incidentKeys
+	s.incidents = make(map[string]*models.Incident)
+	s.idempotency = make(map[string]string)
+	s.incidentKeys = make(map[string][]string)
+
+	// Persist to file if enabled, bypassing write-behind so the purge is durable once it returns
+	if s.filePath != "" {
+		if err := s.persistNowUnsafe(); err != nil {
+			// Rollback in-memory change on persistence failure
+			s.incidents, s.idempotency, s.incidentKeys = purged, purgedIndex, purgedKeys
+			return 0, fmt.Errorf("failed to persist incident purge: %w", err)
+		}
+	}
+
+	for _, incident := range purged {
+		events = append(events, newIncidentEvent(IncidentEventDeleted, incident, incident.Status))
+	}
+	if s.log != nil {
+		s.log.WithField("count", len(purged)).Warn("Incident store purged")
+	}
+	return len(purged), nil
+}
+
 // ListFilter defines filter options for listing incidents
 type ListFilter struct {
 	Namespace string
 	Severity  string
 	Status    string
+	Since     time.Time // Only incidents created at or after Since; zero means no lower bound
 	Limit     int
+
+	// ResolvedAfter and ResolvedBefore keep incidents resolved in [ResolvedAfter, ResolvedBefore).
+	// Setting either excludes incidents that were never resolved; zero means no bound.
+	ResolvedAfter  time.Time
+	ResolvedBefore time.Time
+
+	// Labels keeps incidents carrying every one of these labels with the same value
+	Labels map[string]string
+}
+
+// matches reports whether incident passes the filter's field criteria (Limit is not applied)
+func (f ListFilter) matches(incident *models.Incident) bool {
+	if f.Namespace != "" && incident.Target != f.Namespace {
+		return false
+	}
+	if f.Severity != "" && string(incident.Severity) != f.Severity {
+		return false
+	}
+	if f.Status != "" && string(incident.Status) != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && incident.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if f.filtersResolution() {
+		if incident.ResolvedAt == nil {
+			return false
+		}
+		if !f.ResolvedAfter.IsZero() && incident.ResolvedAt.Before(f.ResolvedAfter) {
+			return false
+		}
+		if !f.ResolvedBefore.IsZero() && !incident.ResolvedAt.Before(f.ResolvedBefore) {
+			return false
+		}
+	}
+	for key, value := range f.Labels {
+		if got, ok := incident.Labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// filtersResolution reports whether the filter only keeps resolved incidents
+func (f ListFilter) filtersResolution() bool {
+	return !f.ResolvedAfter.IsZero() || !f.ResolvedBefore.IsZero()
 }
 
 // List returns incidents matching the filter criteria
@@ -180,18 +681,9 @@ func (s *IncidentStore) List(filter ListFilter) []*models.Incident {
 	results := make([]*models.Incident, 0, len(s.incidents))
 
 	for _, incident := range s.incidents {
-		// Apply filters
-		if filter.Namespace != "" && incident.Target != filter.Namespace {
-			continue
-		}
-		if filter.Severity != "" && string(incident.Severity) != filter.Severity {
-			continue
-		}
-		if filter.Status != "" && string(incident.Status) != filter.Status {
-			continue
+		if filter.matches(incident) {
+			results = append(results, incident)
 		}
-
-		results = append(results, incident)
 	}
 
 	// Sort by created_at descending (newest first)
@@ -226,41 +718,99 @@ func (s *IncidentStore) SaveToFile() error {
 	return s.saveToFileUnsafe()
 }
 
-// saveToFileUnsafe saves incidents to file (caller must hold lock)
+// saveToFileUnsafe saves incidents to file (caller must hold lock). A failed write is retried
+// once unless the error is one a retry cannot fix, such as a missing directory.
 func (s *IncidentStore) saveToFileUnsafe() error {
 	if s.filePath == "" {
 		return fmt.Errorf("no file path configured for persistence")
 	}
 
 	// Marshal incidents to JSON
-	data, err := json.MarshalIndent(s.incidents, "", "  ")
+	stored := make(map[string]storedIncident, len(s.incidents))
+	for id, incident := range s.incidents {
+		stored[id] = storedIncident{Incident: incident, IdempotencyKeys: s.incidentKeys[id]}
+	}
+	data, err := json.MarshalIndent(stored, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal incidents: %w", err)
 	}
 
-	// Atomic write pattern: write to temp file, then rename
-	tempFile := s.filePath + ".tmp"
-	if err := os.WriteFile(tempFile, data, 0o600); err != nil {
+	err = writeFileDurable(s.filePath, data, s.log)
+	if err != nil && isRetryableWriteError(err) {
+		s.log.WithError(err).Warn("Failed to save incidents, retrying once")
+		err = writeFileDurable(s.filePath, data, s.log)
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.log != nil {
+		s.log.WithField("file", s.filePath).Debug("Incidents saved to file")
+	}
+
+	return nil
+}
+
+// writeFileDurable replaces the file at path with data. The rename alone is atomic but not
+// durable: the temp file is fsynced before it and the directory after it, so a crash cannot
+// leave an empty file or lose the rename.
+func writeFileDurable(path string, data []byte, log *logrus.Logger) error {
+	tempFile := path + ".tmp"
+	if err := writeAndSync(tempFile, data); err != nil {
+		if removeErr := os.Remove(tempFile); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+			log.WithError(removeErr).Warn("Failed to remove temp file after write failure")
+		}
 		return fmt.Errorf("failed to write temp file: %w", err)
 	}
 
 	// Atomic rename (POSIX guarantees atomicity)
-	if err := os.Rename(tempFile, s.filePath); err != nil {
+	if err := os.Rename(tempFile, path); err != nil {
 		// Cleanup temp file on failure
 		if removeErr := os.Remove(tempFile); removeErr != nil {
-			s.log.WithError(removeErr).Warn("Failed to remove temp file after rename failure")
+			log.WithError(removeErr).Warn("Failed to remove temp file after rename failure")
 		}
 		return fmt.Errorf("failed to rename temp file: %w", err)
 	}
 
-	if s.log != nil {
-		s.log.WithField("file", s.filePath).Debug("Incidents saved to file")
+	// The new file is in place, so a failed directory sync is not reported as a failed save
+	if err := syncDir(filepath.Dir(path)); err != nil {
+		log.WithError(err).WithField("file", path).Warn("Failed to sync data directory, the last save may not survive a crash")
 	}
-
 	return nil
 }
 
-// LoadFromFile loads incidents from the file system
+// writeAndSync writes data to path and flushes it to stable storage
+func writeAndSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	return f.Close()
+}
+
+// syncDir flushes a directory's entries, making a rename into it durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	return errors.Join(d.Sync(), d.Close())
+}
+
+// isRetryableWriteError reports whether a failed write may succeed if tried again. Missing
+// directories and permission errors persist until someone intervenes.
+func isRetryableWriteError(err error) bool {
+	return !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, fs.ErrPermission)
+}
+
+// LoadFromFile loads incidents from the file system. Incidents that fail validation, e.g. with
+// an unknown severity or status after a hand edit, are logged and skipped.
 func (s *IncidentStore) LoadFromFile() error {
 	if s.filePath == "" {
 		return fmt.Errorf("no file path configured for persistence")
@@ -285,52 +835,113 @@ func (s *IncidentStore) LoadFromFile() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if err := json.Unmarshal(data, &s.incidents); err != nil {
+	var loaded map[string]*storedIncident
+	if err := json.Unmarshal(data, &loaded); err != nil {
 		return fmt.Errorf("failed to unmarshal incidents: %w", err)
 	}
 
+	valid := make(map[string]*storedIncident, len(loaded))
+	for id, entry := range loaded {
+		var incident *models.Incident
+		if entry != nil {
+			incident = entry.Incident
+		}
+		if err := validateLoadedIncident(id, incident); err != nil {
+			if s.log != nil {
+				s.log.WithError(err).WithFields(logrus.Fields{
+					"file":        s.filePath,
+					"incident_id": id,
+				}).Warn("Skipping invalid incident from file")
+			}
+			continue
+		}
+		if err := models.ValidateLabels(incident.Labels); err != nil && s.log != nil {
+			// Labels are only enforced on create and update; keep incidents stored before that
+			s.log.WithError(err).WithFields(logrus.Fields{
+				"file":        s.filePath,
+				"incident_id": id,
+			}).Warn("Loaded incident has invalid labels")
+		}
+		valid[id] = entry
+	}
+
+	for id, entry := range valid {
+		s.incidents[id] = entry.Incident
+		s.setKeysUnsafe(id, nil)
+		for _, key := range entry.IdempotencyKeys {
+			s.addKeyUnsafe(id, key)
+		}
+	}
+
 	if s.log != nil {
 		s.log.WithFields(logrus.Fields{
-			"file":  s.filePath,
-			"count": len(s.incidents),
+			"file":    s.filePath,
+			"count":   len(valid),
+			"skipped": len(loaded) - len(valid),
 		}).Info("Incidents loaded from file")
 	}
 
 	return nil
 }
 
-// CleanupOldIncidents removes resolved incidents older than the specified duration
-func (s *IncidentStore) CleanupOldIncidents(retentionDays int) error {
-	if retentionDays <= 0 {
-		return nil // Cleanup disabled
+// validateLoadedIncident checks an incident read from file. Beyond Validate, a stored incident
+// must have a status and be keyed by its own ID.
+func validateLoadedIncident(id string, incident *models.Incident) error {
+	if incident == nil {
+		return fmt.Errorf("incident is null")
+	}
+	if incident.ID != id {
+		return fmt.Errorf("id %q does not match its key", incident.ID)
 	}
+	if err := incident.Validate(); err != nil {
+		return err
+	}
+	if incident.Status == "" {
+		return fmt.Errorf("status is required")
+	}
+	return nil
+}
 
+// CleanupOldIncidents removes resolved incidents older than their severity's retention set by
+// SetSeverityRetention, or retentionDays for severities without one (0 = keep them)
+func (s *IncidentStore) CleanupOldIncidents(retentionDays int) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	cutoffTime := time.Now().AddDate(0, 0, -retentionDays)
+	if retentionDays <= 0 && len(s.severityRetention) == 0 {
+		return nil // Cleanup disabled
+	}
+
+	now := time.Now()
 	deleted := 0
 
 	for id, incident := range s.incidents {
 		// Only delete resolved incidents
-		if incident.Status == models.IncidentStatusResolved && incident.ResolvedAt != nil {
-			if incident.ResolvedAt.Before(cutoffTime) {
-				delete(s.incidents, id)
-				deleted++
-			}
+		if incident.Status != models.IncidentStatusResolved || incident.ResolvedAt == nil {
+			continue
+		}
+		days, ok := s.severityRetention[incident.Severity]
+		if !ok {
+			days = retentionDays
+		}
+		if days > 0 && incident.ResolvedAt.Before(now.AddDate(0, 0, -days)) {
+			delete(s.incidents, id)
+			s.setKeysUnsafe(id, nil)
+			deleted++
 		}
 	}
 
 	// Persist changes if any deletions occurred
 	if deleted > 0 && s.filePath != "" {
-		if err := s.saveToFileUnsafe(); err != nil {
+		if err := s.persistUnsafe(); err != nil {
 			return fmt.Errorf("failed to persist cleanup: %w", err)
 		}
 
 		if s.log != nil {
 			s.log.WithFields(logrus.Fields{
-				"deleted":        deleted,
-				"retention_days": retentionDays,
+				"deleted":                 deleted,
+				"retention_days":          retentionDays,
+				"severity_retention_days": s.severityRetention,
 			}).Info("Old incidents cleaned up")
 		}
 	}
@@ -0,0 +1,731 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func newTestIncident(target, issueType string, severity models.IncidentSeverity) *models.Incident {
+	return &models.Incident{
+		Title:       "Pod crash loop",
+		Description: "Container restarting repeatedly",
+		Severity:    severity,
+		Target:      target,
+		IssueType:   issueType,
+	}
+}
+
+// TestIncidentStore_Create_RecordsHistory verifies a new incident starts with one occurrence and a history entry
+func TestIncidentStore_Create_RecordsHistory(t *testing.T) {
+	store := NewIncidentStore()
+
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, created.OccurrenceCount)
+	require.Len(t, created.StatusHistory, 1)
+	assert.Equal(t, "created", created.StatusHistory[0].Reason)
+	assert.Equal(t, models.IncidentStatusActive, created.StatusHistory[0].Status)
+}
+
+// TestIncidentStore_Labels verifies labels are validated, persisted and matched by ListFilter
+func TestIncidentStore_Labels(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	gold := newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh)
+	gold.Labels = map[string]string{"team": "payments", "example.com/sla": "gold"}
+	_, err = store.Create(gold)
+	require.NoError(t, err)
+
+	silver := newTestIncident("payments", "oom_killed", models.IncidentSeverityHigh)
+	silver.Labels = map[string]string{"team": "payments", "example.com/sla": "silver"}
+	_, err = store.Create(silver)
+	require.NoError(t, err)
+
+	_, err = store.Create(newTestIncident("checkout", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	for _, labels := range []map[string]string{
+		{"bad key": "x"},
+		{"team": "not valid!"},
+		{"-team": "payments"},
+	} {
+		invalid := newTestIncident("payments", "disk_full", models.IncidentSeverityLow)
+		invalid.Labels = labels
+		_, err := store.Create(invalid)
+		assert.Error(t, err, "labels %v", labels)
+	}
+
+	assert.Len(t, store.List(ListFilter{Labels: map[string]string{"team": "payments"}}), 2)
+	matched := store.List(ListFilter{Labels: map[string]string{"team": "payments", "example.com/sla": "gold"}})
+	require.Len(t, matched, 1)
+	assert.Equal(t, "pod_crash_loop", matched[0].IssueType)
+	assert.Empty(t, store.List(ListFilter{Labels: map[string]string{"team": ""}}), "an empty value still requires the key")
+	assert.Len(t, store.List(ListFilter{}), 3)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.List(ListFilter{Labels: map[string]string{"example.com/sla": "silver"}}), 1)
+}
+
+// TestIncidentStore_EscalateOnRecurrence verifies severity bumps at each threshold within the window
+func TestIncidentStore_EscalateOnRecurrence(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{
+		Enabled:          true,
+		RecurrenceWindow: time.Hour,
+		Thresholds:       []int{3, 5},
+	})
+
+	first, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+
+	var latest *models.Incident
+	for i := 0; i < 4; i++ {
+		latest, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, latest.ID, "recurrence should reuse the existing incident")
+
+		if i == 1 {
+			assert.Equal(t, models.IncidentSeverityHigh, latest.Severity, "3rd occurrence should escalate to high")
+		}
+	}
+
+	assert.Equal(t, 1, store.Count())
+	assert.Equal(t, 5, latest.OccurrenceCount)
+	assert.Equal(t, models.IncidentSeverityCritical, latest.Severity)
+
+	// created + two escalations
+	require.Len(t, latest.StatusHistory, 3)
+	assert.Equal(t, models.IncidentSeverityHigh, latest.StatusHistory[1].Severity)
+	assert.Contains(t, latest.StatusHistory[1].Reason, "escalated from medium")
+	assert.Equal(t, models.IncidentSeverityCritical, latest.StatusHistory[2].Severity)
+}
+
+// TestIncidentStore_EscalateOnRecurrence_WindowExpired verifies old sightings don't count towards thresholds
+func TestIncidentStore_EscalateOnRecurrence_WindowExpired(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{
+		Enabled:          true,
+		RecurrenceWindow: time.Hour,
+		Thresholds:       []int{3},
+	})
+
+	first, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityLow))
+	require.NoError(t, err)
+
+	// Age the existing sightings outside the window
+	stored, err := store.Get(first.ID)
+	require.NoError(t, err)
+	stored.RecentOccurrences = []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-90 * time.Minute)}
+
+	latest, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityLow))
+	require.NoError(t, err)
+
+	assert.Equal(t, models.IncidentSeverityLow, latest.Severity)
+	assert.Len(t, latest.RecentOccurrences, 1)
+	assert.Equal(t, 2, latest.OccurrenceCount)
+}
+
+// TestIncidentStore_EscalateOnRecurrence_NoReescalateAfterPruning verifies a threshold already
+// escalated at is not applied again when pruning drops the window count and it is reached again
+func TestIncidentStore_EscalateOnRecurrence_NoReescalateAfterPruning(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{
+		Enabled:          true,
+		RecurrenceWindow: time.Hour,
+		Thresholds:       []int{3},
+	})
+
+	first, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityLow))
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityLow))
+		require.NoError(t, err)
+	}
+
+	stored, err := store.Get(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.IncidentSeverityMedium, stored.Severity)
+	assert.Equal(t, 3, stored.EscalatedAtThreshold)
+
+	// The oldest sighting leaves the window; the next one brings the count back to 3
+	stored.RecentOccurrences[0] = time.Now().Add(-2 * time.Hour)
+	latest, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityLow))
+	require.NoError(t, err)
+	assert.Len(t, latest.RecentOccurrences, 3)
+	assert.Equal(t, models.IncidentSeverityMedium, latest.Severity, "threshold must not escalate twice in one burst")
+
+	// Once a whole window passes without a sighting a new burst may escalate again
+	stored, err = store.Get(first.ID)
+	require.NoError(t, err)
+	stored.RecentOccurrences = []time.Time{time.Now().Add(-2 * time.Hour)}
+	for i := 0; i < 3; i++ {
+		latest, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityLow))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, models.IncidentSeverityHigh, latest.Severity)
+}
+
+// TestIncidentStore_EscalateOnRecurrence_Disabled verifies incidents are not merged when the policy is off
+func TestIncidentStore_EscalateOnRecurrence_Disabled(t *testing.T) {
+	store := NewIncidentStore()
+
+	for i := 0; i < 3; i++ {
+		_, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, store.Count())
+}
+
+// TestIncidentStore_EscalateOnRecurrence_DifferentKeys verifies only matching Target/IssueType recur
+func TestIncidentStore_EscalateOnRecurrence_DifferentKeys(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{2}})
+
+	_, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("payments", "oom_killed", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("checkout", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("checkout", "", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("checkout", "", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, store.Count())
+}
+
+// TestIncidentStore_EscalateOnRecurrence_Persisted verifies escalations survive a reload
+func TestIncidentStore_EscalateOnRecurrence_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	store.SetEscalationPolicy(EscalationPolicy{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{2}})
+
+	first, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	incident, err := reloaded.Get(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IncidentSeverityCritical, incident.Severity)
+	assert.Equal(t, 2, incident.OccurrenceCount)
+	assert.Len(t, incident.StatusHistory, 2)
+}
+
+// TestIncidentStore_CreateIdempotent verifies a retried create with the same key returns the
+// original incident without creating or recording anything
+func TestIncidentStore_CreateIdempotent(t *testing.T) {
+	store := NewIncidentStore()
+
+	first, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-123")
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.Equal(t, []string{"alert-123"}, store.incidentKeys[first.ID])
+
+	retry, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-123")
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, first.ID, retry.ID)
+	assert.Equal(t, 1, retry.OccurrenceCount)
+
+	other, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-456")
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, first.ID, other.ID)
+
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.Count(), "creates without a key are never replayed")
+
+	require.NoError(t, store.Delete(first.ID))
+	recreated, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-123")
+	require.NoError(t, err)
+	assert.False(t, replayed, "deleting an incident releases its keys")
+	assert.NotEqual(t, first.ID, recreated.ID)
+}
+
+// TestIncidentStore_CreateIdempotent_Recurrence verifies a key that resolved to a recurrence
+// replays that incident instead of counting another occurrence
+func TestIncidentStore_CreateIdempotent_Recurrence(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{5}})
+
+	first, _, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-1")
+	require.NoError(t, err)
+	recurred, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-2")
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.Equal(t, first.ID, recurred.ID)
+	assert.Equal(t, []string{"alert-1", "alert-2"}, store.incidentKeys[first.ID])
+
+	retry, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-2")
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, 2, retry.OccurrenceCount)
+}
+
+// TestIncidentStore_CreateIdempotent_KeysCapped verifies a recurring incident keeps only its most
+// recent idempotency keys, and that keys stay out of the API model
+func TestIncidentStore_CreateIdempotent_KeysCapped(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{Enabled: true, RecurrenceWindow: time.Hour})
+
+	var incident *models.Incident
+	for i := 0; i <= MaxIdempotencyKeysPerIncident; i++ {
+		var err error
+		incident, _, err = store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), fmt.Sprintf("alert-%d", i))
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, store.incidentKeys[incident.ID], MaxIdempotencyKeysPerIncident)
+	assert.Len(t, store.idempotency, MaxIdempotencyKeysPerIncident)
+
+	_, replayed, err := store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), fmt.Sprintf("alert-%d", MaxIdempotencyKeysPerIncident))
+	require.NoError(t, err)
+	assert.True(t, replayed, "the newest key is kept")
+	_, replayed, err = store.CreateIdempotent(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium), "alert-0")
+	require.NoError(t, err)
+	assert.False(t, replayed, "the oldest key was dropped")
+
+	data, err := json.Marshal(incident)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "idempotency")
+}
+
+// TestIncidentStore_CreateIdempotent_Persisted verifies the key index survives a reload and
+// is cleaned up when old incidents are removed
+func TestIncidentStore_CreateIdempotent_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	first, _, err := store.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityMedium), "alert-123")
+	require.NoError(t, err)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	retry, replayed, err := reloaded.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityMedium), "alert-123")
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, first.ID, retry.ID)
+
+	resolvedAt := time.Now().AddDate(0, 0, -10)
+	retry.Status = models.IncidentStatusResolved
+	retry.ResolvedAt = &resolvedAt
+	require.NoError(t, reloaded.Update(retry))
+	require.NoError(t, reloaded.CleanupOldIncidents(7))
+
+	_, replayed, err = reloaded.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityMedium), "alert-123")
+	require.NoError(t, err)
+	assert.False(t, replayed)
+}
+
+// writeIncidentsFile writes incidents keyed by ID as a store would persist them
+func writeIncidentsFile(t *testing.T, dir string, incidents ...*models.Incident) {
+	t.Helper()
+	byID := make(map[string]*models.Incident, len(incidents))
+	for _, incident := range incidents {
+		byID[incident.ID] = incident
+	}
+	data, err := json.Marshal(byID)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "incidents.json"), data, 0o600))
+}
+
+// storedTestIncident returns a valid incident as it would appear in the incidents file
+func storedTestIncident(id string) *models.Incident {
+	incident := newTestIncident("payments", "", models.IncidentSeverityHigh)
+	incident.ID = id
+	incident.Status = models.IncidentStatusActive
+	return incident
+}
+
+// TestIncidentStore_LoadFromFile_SkipsInvalid verifies invalid incidents are skipped on load,
+// while invalid labels are only rejected on create and update
+func TestIncidentStore_LoadFromFile_SkipsInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	badSeverity := storedTestIncident("inc-bad-severity")
+	badSeverity.Severity = "urgent"
+	badStatus := storedTestIncident("inc-bad-status")
+	badStatus.Status = "open"
+	noStatus := storedTestIncident("inc-no-status")
+	noStatus.Status = ""
+	badLabels := storedTestIncident("inc-bad-labels")
+	badLabels.Labels = map[string]string{"bad key": "x"}
+	writeIncidentsFile(t, dir, storedTestIncident("inc-good"), badSeverity, badStatus, noStatus, badLabels)
+
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	incidents := store.List(ListFilter{})
+	require.Len(t, incidents, 2, "invalid labels alone do not drop a stored incident")
+	assert.ElementsMatch(t, []string{"inc-good", "inc-bad-labels"}, []string{incidents[0].ID, incidents[1].ID})
+
+	// Unchanged labels do not block an update; newly set invalid labels do
+	loaded, err := store.Get("inc-bad-labels")
+	require.NoError(t, err)
+	update := *loaded
+	update.Severity = models.IncidentSeverityCritical
+	require.NoError(t, store.Update(&update))
+	relabeled := update
+	relabeled.Labels = map[string]string{"team": "not valid!"}
+	assert.Error(t, store.Update(&relabeled))
+}
+
+// TestIncidentStore_LinkWorkflow_Completed verifies a successful workflow resolves the incident
+func TestIncidentStore_LinkWorkflow_Completed(t *testing.T) {
+	store := NewIncidentStore()
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	linked, err := store.LinkWorkflow(created.ID, "wf-123", models.WorkflowStatusCompleted)
+	require.NoError(t, err)
+
+	assert.Equal(t, "wf-123", linked.WorkflowID)
+	assert.Equal(t, models.WorkflowStatusCompleted, linked.RemediationOutcome)
+	assert.Equal(t, 1, linked.RemediationAttempts)
+	assert.Zero(t, linked.RemediationFailures)
+	assert.Equal(t, models.IncidentStatusResolved, linked.Status)
+	assert.True(t, linked.AutoResolved())
+
+	// created, workflow completed, resolved
+	require.Len(t, linked.StatusHistory, 3)
+	assert.Contains(t, linked.StatusHistory[1].Reason, "wf-123 completed")
+}
+
+// TestIncidentStore_LinkWorkflow_Failed verifies failed workflows keep the incident open and are counted
+func TestIncidentStore_LinkWorkflow_Failed(t *testing.T) {
+	store := NewIncidentStore()
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	_, err = store.LinkWorkflow(created.ID, "wf-1", models.WorkflowStatusFailed)
+	require.NoError(t, err)
+	linked, err := store.LinkWorkflow(created.ID, "wf-2", models.WorkflowStatusFailed)
+	require.NoError(t, err)
+
+	assert.Equal(t, "wf-2", linked.WorkflowID)
+	assert.Equal(t, models.IncidentStatusActive, linked.Status)
+	assert.Equal(t, 2, linked.RemediationAttempts)
+	assert.Equal(t, 2, linked.RemediationFailures)
+	assert.False(t, linked.AutoResolved())
+}
+
+// TestIncidentStore_LinkWorkflow_Errors verifies unknown incidents and non-final outcomes are rejected
+func TestIncidentStore_LinkWorkflow_Errors(t *testing.T) {
+	store := NewIncidentStore()
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	_, err = store.LinkWorkflow("inc-missing", "wf-1", models.WorkflowStatusCompleted)
+	assert.Error(t, err)
+
+	_, err = store.LinkWorkflow(created.ID, "wf-1", models.WorkflowStatusRunning)
+	assert.Error(t, err)
+
+	stored, err := store.Get(created.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.WorkflowID)
+}
+
+// TestIncidentStore_LinkWorkflow_Persisted verifies workflow links survive a reload
+func TestIncidentStore_LinkWorkflow_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+	_, err = store.LinkWorkflow(created.ID, "wf-9", models.WorkflowStatusFailed)
+	require.NoError(t, err)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	incident, err := reloaded.Get(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "wf-9", incident.WorkflowID)
+	assert.Equal(t, models.WorkflowStatusFailed, incident.RemediationOutcome)
+	assert.Equal(t, 1, incident.RemediationFailures)
+}
+
+// ageIncident moves an incident's last sighting into the past
+func ageIncident(t *testing.T, store *IncidentStore, id string, age time.Duration) {
+	t.Helper()
+	stored, err := store.Get(id)
+	require.NoError(t, err)
+	stored.LastSeen = time.Now().Add(-age)
+	stored.RecentOccurrences = []time.Time{stored.LastSeen}
+}
+
+// TestIncidentStore_AutoResolveStale verifies quiet incidents are resolved with a history reason
+func TestIncidentStore_AutoResolveStale(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetAutoResolvePolicy(AutoResolvePolicy{Enabled: true, QuietPeriod: time.Hour})
+
+	stale, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	fresh, err := store.Create(newTestIncident("checkout", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	ageIncident(t, store, stale.ID, 2*time.Hour)
+
+	resolved, err := store.AutoResolveStale()
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	got, err := store.Get(stale.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IncidentStatusResolved, got.Status)
+	assert.NotNil(t, got.ResolvedAt)
+	last := got.StatusHistory[len(got.StatusHistory)-1]
+	assert.Equal(t, models.IncidentStatusResolved, last.Status)
+	assert.Contains(t, last.Reason, "auto-resolved: no recurrence within 1h0m0s")
+
+	got, err = store.Get(fresh.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsActive())
+}
+
+// TestIncidentStore_AutoResolveStale_RecentDuplicateKeepsOpen verifies a recent sighting on a
+// duplicate incident keeps older incidents with the same Target/IssueType open
+func TestIncidentStore_AutoResolveStale_RecentDuplicateKeepsOpen(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetAutoResolvePolicy(AutoResolvePolicy{Enabled: true, QuietPeriod: time.Hour})
+
+	old, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	ageIncident(t, store, old.ID, 3*time.Hour)
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+
+	resolved, err := store.AutoResolveStale()
+	require.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+}
+
+// TestIncidentStore_AutoResolveStale_Recurrence verifies a recurrence refreshes LastSeen
+func TestIncidentStore_AutoResolveStale_Recurrence(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetEscalationPolicy(EscalationPolicy{Enabled: true, RecurrenceWindow: time.Hour})
+	store.SetAutoResolvePolicy(AutoResolvePolicy{Enabled: true, QuietPeriod: time.Hour})
+
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	ageIncident(t, store, created.ID, 2*time.Hour)
+
+	recurred, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, recurred.ID)
+	assert.Less(t, time.Since(recurred.LastSeen), time.Minute)
+
+	resolved, err := store.AutoResolveStale()
+	require.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+}
+
+// TestIncidentStore_AutoResolveStale_Disabled verifies the default policy leaves incidents alone
+func TestIncidentStore_AutoResolveStale_Disabled(t *testing.T) {
+	store := NewIncidentStore()
+
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityMedium))
+	require.NoError(t, err)
+	ageIncident(t, store, created.ID, 48*time.Hour)
+
+	resolved, err := store.AutoResolveStale()
+	require.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+
+	got, err := store.Get(created.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsActive())
+}
+
+// TestIncidentStore_AutoResolveStale_Persisted verifies auto-resolution survives a reload
+func TestIncidentStore_AutoResolveStale_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	store.SetAutoResolvePolicy(AutoResolvePolicy{Enabled: true, QuietPeriod: time.Hour})
+
+	created, err := store.Create(newTestIncident("payments", "", models.IncidentSeverityLow))
+	require.NoError(t, err)
+	ageIncident(t, store, created.ID, 2*time.Hour)
+
+	resolved, err := store.AutoResolveStale()
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	got, err := reloaded.Get(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.IncidentStatusResolved, got.Status)
+}
+
+// TestIncidentStore_Purge verifies Purge requires the token and deletes everything in one write
+func TestIncidentStore_Purge(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	var deleted int
+	store.RegisterObserver(func(event IncidentEvent) {
+		if event.Type == IncidentEventDeleted {
+			deleted++
+		}
+	})
+
+	_, _, err = store.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityHigh), "alert-1")
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("checkout", "", models.IncidentSeverityLow))
+	require.NoError(t, err)
+
+	count, err := store.Purge("yes")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrPurgeNotConfirmed))
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 2, store.Count())
+
+	count, err = store.Purge(PurgeConfirmToken)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 0, store.Count())
+	assert.Equal(t, 2, deleted)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, reloaded.Count())
+
+	// Idempotency keys went with their incidents
+	_, replayed, err := store.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityHigh), "alert-1")
+	require.NoError(t, err)
+	assert.False(t, replayed)
+}
+
+// TestIncidentStore_Purge_RollsBackOnWriteFailure verifies a failed write deletes nothing
+func TestIncidentStore_Purge_RollsBackOnWriteFailure(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	created, _, err := store.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityHigh), "alert-1")
+	require.NoError(t, err)
+
+	store.filePath = filepath.Join(dir, "missing", "incidents.json")
+	_, err = store.Purge(PurgeConfirmToken)
+	require.Error(t, err)
+
+	_, err = store.Get(created.ID)
+	require.NoError(t, err)
+	_, replayed, err := store.CreateIdempotent(newTestIncident("payments", "", models.IncidentSeverityHigh), "alert-1")
+	require.NoError(t, err)
+	assert.True(t, replayed)
+}
+
+// TestIncidentStore_SaveToFile_LeavesNoTempFile verifies a save replaces the file and cleans up
+func TestIncidentStore_SaveToFile_LeavesNoTempFile(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	_, err = store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "incidents.json", entries[0].Name())
+}
+
+// TestIncidentStore_SaveToFile_RetriesOnce verifies a write failure that the cleanup clears,
+// here a stale directory in the temp file's place, succeeds on the retry
+func TestIncidentStore_SaveToFile_RetriesOnce(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "incidents.json.tmp"), 0o750))
+
+	created, err := store.Create(newTestIncident("payments", "pod_crash_loop", models.IncidentSeverityHigh))
+	require.NoError(t, err)
+
+	reloaded, err := NewIncidentStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	_, err = reloaded.Get(created.ID)
+	assert.NoError(t, err)
+}
+
+func TestIsRetryableWriteError(t *testing.T) {
+	assert.True(t, isRetryableWriteError(errors.New("disk hiccup")))
+	assert.False(t, isRetryableWriteError(fmt.Errorf("open: %w", fs.ErrNotExist)))
+	assert.False(t, isRetryableWriteError(fmt.Errorf("open: %w", fs.ErrPermission)))
+}
+
+// TestIncidentStore_CleanupOldIncidents_SeverityRetention verifies resolved incidents are kept
+// for their severity's retention, falling back to the default
+func TestIncidentStore_CleanupOldIncidents_SeverityRetention(t *testing.T) {
+	store := NewIncidentStore()
+	store.SetSeverityRetention(map[models.IncidentSeverity]int{
+		models.IncidentSeverityCritical: 365,
+		models.IncidentSeverityHigh:     0,
+	})
+
+	resolve := func(target string, severity models.IncidentSeverity, daysAgo int) string {
+		created, err := store.Create(newTestIncident(target, "", severity))
+		require.NoError(t, err)
+		resolvedAt := time.Now().AddDate(0, 0, -daysAgo)
+		created.Status = models.IncidentStatusResolved
+		created.ResolvedAt = &resolvedAt
+		require.NoError(t, store.Update(created))
+		return created.ID
+	}
+	oldCritical := resolve("old-critical", models.IncidentSeverityCritical, 100)
+	expiredCritical := resolve("expired-critical", models.IncidentSeverityCritical, 400)
+	oldHigh := resolve("old-high", models.IncidentSeverityHigh, 1000)
+	oldLow := resolve("old-low", models.IncidentSeverityLow, 100)
+	recentLow := resolve("recent-low", models.IncidentSeverityLow, 10)
+	active, err := store.Create(newTestIncident("active", "", models.IncidentSeverityLow))
+	require.NoError(t, err)
+
+	require.NoError(t, store.CleanupOldIncidents(90))
+
+	for id, kept := range map[string]bool{
+		oldCritical:     true,
+		expiredCritical: false,
+		oldHigh:         true, // 0 keeps high severity incidents forever
+		oldLow:          false,
+		recentLow:       true,
+		active.ID:       true,
+	} {
+		_, err := store.Get(id)
+		assert.Equal(t, kept, err == nil, id)
+	}
+
+	// Severity retention applies even when the default disables cleanup
+	expiredCritical = resolve("expired-critical", models.IncidentSeverityCritical, 400)
+	oldLow = resolve("old-low", models.IncidentSeverityLow, 100)
+	require.NoError(t, store.CleanupOldIncidents(0))
+	_, err = store.Get(expiredCritical)
+	assert.Error(t, err)
+	_, err = store.Get(oldLow)
+	assert.NoError(t, err)
+}
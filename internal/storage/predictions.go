@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// DefaultPredictionStoreMaxRecords bounds the prediction history when no limit is configured
+const DefaultPredictionStoreMaxRecords = 10000
+
+// PredictionFileName is the prediction history file in the data directory
+const PredictionFileName = "predictions.jsonl"
+
+// PredictionStore keeps the most recent served predictions. Unlike incidents, predictions
+// arrive with every request, so the file is append-only JSON lines and is rewritten with the
+// retained records only once it holds twice the limit.
+type PredictionStore struct {
+	records    []*models.PredictionRecord // Oldest first
+	maxRecords int
+	mu         sync.RWMutex
+	filePath   string // Path to persistent storage file (empty = in-memory only)
+	fileLines  int    // Records appended to the file since it was last rewritten
+	log        *logrus.Logger
+}
+
+// NewPredictionStore creates a new in-memory prediction store keeping up to maxRecords
+// predictions (0 = DefaultPredictionStoreMaxRecords)
+func NewPredictionStore(maxRecords int) *PredictionStore {
+	if maxRecords <= 0 {
+		maxRecords = DefaultPredictionStoreMaxRecords
+	}
+	return &PredictionStore{
+		maxRecords: maxRecords,
+		log:        logrus.New(),
+	}
+}
+
+// NewPredictionStoreWithPersistence creates a prediction store backed by
+// PredictionFileName in dataDir, loading the predictions already there
+func NewPredictionStoreWithPersistence(dataDir string, maxRecords int, log *logrus.Logger) (*PredictionStore, error) {
+	if log == nil {
+		log = logrus.New()
+	}
+	if err := os.MkdirAll(dataDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	store := NewPredictionStore(maxRecords)
+	store.filePath = filepath.Join(dataDir, PredictionFileName)
+	store.log = log
+
+	if err := store.LoadFromFile(); err != nil {
+		log.WithError(err).Warn("Failed to load predictions from file, starting with empty store")
+	}
+	return store, nil
+}
+
+// Record stores a prediction, assigning an ID and RecordedAt when they are unset, and drops the
+// oldest predictions beyond the limit. The record is kept in memory even when appending it to
+// the file fails; the error is returned for logging.
+func (s *PredictionStore) Record(record *models.PredictionRecord) error {
+	if record.ID == "" {
+		record.ID = generatePredictionID()
+	}
+	if record.RecordedAt.IsZero() {
+		record.RecordedAt = time.Now().UTC()
+	}
+	if err := record.Validate(); err != nil {
+		return fmt.Errorf("invalid prediction record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+	if excess := len(s.records) - s.maxRecords; excess > 0 {
+		s.records = slices.Delete(s.records, 0, excess)
+	}
+
+	if s.filePath == "" {
+		return nil
+	}
+	if s.fileLines >= 2*s.maxRecords {
+		return s.rewriteFileUnsafe()
+	}
+	return s.appendToFileUnsafe(record)
+}
+
+// PredictionFilter defines filter options for listing predictions
+type PredictionFilter struct {
+	Scope      string
+	Namespace  string
+	Deployment string
+	Pod        string
+	Model      string
+	Since      time.Time // Only predictions recorded at or after Since; zero means no lower bound
+	Until      time.Time // Only predictions recorded before Until; zero means no upper bound
+	Limit      int
+}
+
+// matches reports whether record passes the filter's field criteria (Limit is not applied)
+func (f PredictionFilter) matches(record *models.PredictionRecord) bool {
+	if f.Scope != "" && record.Scope != f.Scope {
+		return false
+	}
+	if f.Namespace != "" && record.Namespace != f.Namespace {
+		return false
+	}
+	if f.Deployment != "" && record.Deployment != f.Deployment {
+		return false
+	}
+	if f.Pod != "" && record.Pod != f.Pod {
+		return false
+	}
+	if f.Model != "" && record.Model != f.Model {
+		return false
+	}
+	if !f.Since.IsZero() && record.RecordedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !record.RecordedAt.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// List returns predictions matching the filter, newest first
+func (s *PredictionStore) List(filter PredictionFilter) []*models.PredictionRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]*models.PredictionRecord, 0)
+	for i := len(s.records) - 1; i >= 0; i-- {
+		if !filter.matches(s.records[i]) {
+			continue
+		}
+		results = append(results, s.records[i])
+		if filter.Limit > 0 && len(results) == filter.Limit {
+			break
+		}
+	}
+	return results
+}
+
+// Count returns the number of stored predictions
+func (s *PredictionStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+// generatePredictionID generates a unique prediction ID
+func generatePredictionID() string {
+	return "pred-" + uuid.New().String()[:8]
+}
+
+// appendToFileUnsafe appends one record to the file (caller must hold lock). Appends are not
+// fsynced: losing the last few predictions in a crash is acceptable for trend analysis.
+func (s *PredictionStore) appendToFileUnsafe(record *models.PredictionRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal prediction: %w", err)
+	}
+
+	f, err := os.OpenFile(s.filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open predictions file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return errors.Join(fmt.Errorf("failed to append prediction: %w", err), f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close predictions file: %w", err)
+	}
+	s.fileLines++
+	return nil
+}
+
+// rewriteFileUnsafe replaces the file with the retained records (caller must hold lock)
+func (s *PredictionStore) rewriteFileUnsafe() error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range s.records {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to marshal prediction: %w", err)
+		}
+	}
+	if err := writeFileDurable(s.filePath, buf.Bytes(), s.log); err != nil {
+		return err
+	}
+	s.fileLines = len(s.records)
+	s.log.WithFields(logrus.Fields{
+		"file":    s.filePath,
+		"records": len(s.records),
+	}).Debug("Prediction history file compacted")
+	return nil
+}
+
+// LoadFromFile loads predictions from the file, keeping the newest up to the limit. Lines that
+// do not decode to a valid record, e.g. one cut short by a crash, are logged and skipped.
+func (s *PredictionStore) LoadFromFile() error {
+	if s.filePath == "" {
+		return fmt.Errorf("no file path configured for persistence")
+	}
+
+	f, err := os.Open(s.filePath)
+	if os.IsNotExist(err) {
+		s.log.WithField("file", s.filePath).Debug("No predictions file found, starting with empty store")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open predictions file: %w", err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			s.log.WithError(closeErr).Warn("Failed to close predictions file")
+		}
+	}()
+
+	var loaded []*models.PredictionRecord
+	lines, skipped := 0, 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		lines++
+		var record models.PredictionRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Validate() != nil {
+			skipped++
+			continue
+		}
+		loaded = append(loaded, &record)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read predictions file: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if excess := len(loaded) - s.maxRecords; excess > 0 {
+		loaded = loaded[excess:]
+	}
+	s.records = loaded
+	s.fileLines = lines
+
+	s.log.WithFields(logrus.Fields{
+		"file":    s.filePath,
+		"count":   len(loaded),
+		"skipped": skipped,
+	}).Info("Predictions loaded from file")
+	return nil
+}
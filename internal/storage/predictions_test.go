@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func newTestPrediction(scope, namespace string, recordedAt time.Time) *models.PredictionRecord {
+	return &models.PredictionRecord{
+		RecordedAt:    recordedAt,
+		Scope:         scope,
+		Target:        namespace,
+		Namespace:     namespace,
+		Model:         "predictive-analytics",
+		CPUPercent:    42,
+		MemoryPercent: 58,
+		Confidence:    0.9,
+	}
+}
+
+func countFileLines(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return bytes.Count(data, []byte("\n"))
+}
+
+// TestPredictionStore_Record verifies IDs and timestamps are assigned and invalid records rejected
+func TestPredictionStore_Record(t *testing.T) {
+	store := NewPredictionStore(0)
+
+	record := newTestPrediction("namespace", "payments", time.Time{})
+	require.NoError(t, store.Record(record))
+	assert.Contains(t, record.ID, "pred-")
+	assert.False(t, record.RecordedAt.IsZero())
+
+	assert.Error(t, store.Record(&models.PredictionRecord{Model: "predictive-analytics"}), "scope is required")
+	assert.Equal(t, 1, store.Count())
+}
+
+// TestPredictionStore_MaxRecords verifies the oldest predictions are dropped beyond the limit
+func TestPredictionStore_MaxRecords(t *testing.T) {
+	store := NewPredictionStore(3)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.Record(newTestPrediction("cluster", "", base.Add(time.Duration(i)*time.Minute))))
+	}
+
+	records := store.List(PredictionFilter{})
+	require.Len(t, records, 3)
+	assert.Equal(t, base.Add(4*time.Minute), records[0].RecordedAt, "newest first")
+	assert.Equal(t, base.Add(2*time.Minute), records[2].RecordedAt)
+}
+
+func TestPredictionStore_List_Filter(t *testing.T) {
+	store := NewPredictionStore(0)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(newTestPrediction("namespace", "payments", base)))
+	require.NoError(t, store.Record(newTestPrediction("namespace", "checkout", base.Add(time.Hour))))
+	require.NoError(t, store.Record(newTestPrediction("cluster", "", base.Add(2*time.Hour))))
+	require.NoError(t, store.Record(newTestPrediction("namespace", "payments", base.Add(3*time.Hour))))
+
+	assert.Len(t, store.List(PredictionFilter{Scope: "namespace"}), 3)
+	assert.Len(t, store.List(PredictionFilter{Namespace: "payments"}), 2)
+	assert.Len(t, store.List(PredictionFilter{Namespace: "payments", Limit: 1}), 1)
+	assert.Len(t, store.List(PredictionFilter{Model: "other"}), 0)
+
+	window := store.List(PredictionFilter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour)})
+	require.Len(t, window, 2, "since is inclusive, until exclusive")
+	assert.Equal(t, "cluster", window[0].Scope)
+	assert.Equal(t, "checkout", window[1].Namespace)
+}
+
+// TestPredictionStore_Persisted verifies predictions survive a restart and corrupt lines are skipped
+func TestPredictionStore_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewPredictionStoreWithPersistence(dir, 10, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.Record(newTestPrediction("namespace", "payments", time.Time{})))
+	require.NoError(t, store.Record(newTestPrediction("cluster", "", time.Time{})))
+
+	// A line cut short by a crash
+	f, err := os.OpenFile(filepath.Join(dir, PredictionFileName), os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"pred-trunc","scope":"na` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	reloaded, err := NewPredictionStoreWithPersistence(dir, 10, nil)
+	require.NoError(t, err)
+	records := reloaded.List(PredictionFilter{})
+	require.Len(t, records, 2)
+	assert.Equal(t, "cluster", records[0].Scope)
+	assert.Equal(t, "payments", records[1].Namespace)
+}
+
+// TestPredictionStore_Compaction verifies the file is rewritten once it holds twice the limit
+func TestPredictionStore_Compaction(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewPredictionStoreWithPersistence(dir, 2, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, store.Record(newTestPrediction("cluster", "", time.Time{})))
+	}
+	path := filepath.Join(dir, PredictionFileName)
+	assert.Equal(t, 4, countFileLines(t, path))
+
+	require.NoError(t, store.Record(newTestPrediction("namespace", "payments", time.Time{})))
+	assert.Equal(t, 2, countFileLines(t, path))
+
+	reloaded, err := NewPredictionStoreWithPersistence(dir, 2, nil)
+	require.NoError(t, err)
+	records := reloaded.List(PredictionFilter{})
+	require.Len(t, records, 2)
+	assert.Equal(t, "payments", records[0].Namespace)
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// RecommendationAckFileName is the recommendation acknowledgement file in the data directory
+const RecommendationAckFileName = "recommendation_acks.json"
+
+// RecommendationAckStore keeps the acknowledgements that suppress recommendations, keyed by
+// recommendation ID. Expired acknowledgements are dropped whenever the store is written or loaded.
+type RecommendationAckStore struct {
+	acks     map[string]*models.RecommendationAck
+	mu       sync.RWMutex
+	filePath string // Path to persistent storage file (empty = in-memory only)
+	log      *logrus.Logger
+}
+
+// NewRecommendationAckStore creates a new in-memory acknowledgement store (no persistence)
+func NewRecommendationAckStore() *RecommendationAckStore {
+	return &RecommendationAckStore{
+		acks: make(map[string]*models.RecommendationAck),
+		log:  logrus.New(),
+	}
+}
+
+// NewRecommendationAckStoreWithPersistence creates an acknowledgement store backed by
+// RecommendationAckFileName in dataDir, loading the acknowledgements already there
+func NewRecommendationAckStoreWithPersistence(dataDir string, log *logrus.Logger) (*RecommendationAckStore, error) {
+	if log == nil {
+		log = logrus.New()
+	}
+	if err := os.MkdirAll(dataDir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	store := NewRecommendationAckStore()
+	store.filePath = filepath.Join(dataDir, RecommendationAckFileName)
+	store.log = log
+
+	if err := store.LoadFromFile(); err != nil {
+		log.WithError(err).Warn("Failed to load recommendation acknowledgements from file, starting with empty store")
+	}
+	return store, nil
+}
+
+// Acknowledge stores ack, replacing any acknowledgement of the same recommendation
+func (s *RecommendationAckStore) Acknowledge(ack *models.RecommendationAck) error {
+	if err := ack.Validate(); err != nil {
+		return fmt.Errorf("invalid acknowledgement: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.acks[ack.RecommendationID]
+	s.acks[ack.RecommendationID] = ack
+	if err := s.saveToFileUnsafe(); err != nil {
+		if existed {
+			s.acks[ack.RecommendationID] = previous
+		} else {
+			delete(s.acks, ack.RecommendationID)
+		}
+		return err
+	}
+	return nil
+}
+
+// Remove deletes the acknowledgement of a recommendation, reporting whether an active one existed
+func (s *RecommendationAckStore) Remove(recommendationID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ack, exists := s.acks[recommendationID]
+	if !exists {
+		return false, nil
+	}
+	delete(s.acks, recommendationID)
+	if err := s.saveToFileUnsafe(); err != nil {
+		s.acks[recommendationID] = ack
+		return false, err
+	}
+	return ack.Active(time.Now()), nil
+}
+
+// Get returns the active acknowledgement of a recommendation
+func (s *RecommendationAckStore) Get(recommendationID string, now time.Time) (*models.RecommendationAck, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ack, exists := s.acks[recommendationID]
+	if !exists || !ack.Active(now) {
+		return nil, false
+	}
+	return ack, true
+}
+
+// List returns the acknowledgements active at now, soonest to expire first
+func (s *RecommendationAckStore) List(now time.Time) []*models.RecommendationAck {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	acks := make([]*models.RecommendationAck, 0, len(s.acks))
+	for _, ack := range s.acks {
+		if ack.Active(now) {
+			acks = append(acks, ack)
+		}
+	}
+	sort.Slice(acks, func(i, j int) bool {
+		if !acks[i].ExpiresAt.Equal(acks[j].ExpiresAt) {
+			return acks[i].ExpiresAt.Before(acks[j].ExpiresAt)
+		}
+		return acks[i].RecommendationID < acks[j].RecommendationID
+	})
+	return acks
+}
+
+// pruneExpiredUnsafe drops acknowledgements that no longer suppress anything (caller must hold lock)
+func (s *RecommendationAckStore) pruneExpiredUnsafe(now time.Time) {
+	for id, ack := range s.acks {
+		if !ack.Active(now) {
+			delete(s.acks, id)
+		}
+	}
+}
+
+// saveToFileUnsafe persists the active acknowledgements (caller must hold lock)
+func (s *RecommendationAckStore) saveToFileUnsafe() error {
+	s.pruneExpiredUnsafe(time.Now())
+	if s.filePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.acks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal acknowledgements: %w", err)
+	}
+	return writeFileDurable(s.filePath, data, s.log)
+}
+
+// LoadFromFile loads acknowledgements from the file, skipping expired and invalid ones
+func (s *RecommendationAckStore) LoadFromFile() error {
+	if s.filePath == "" {
+		return fmt.Errorf("no file path configured for persistence")
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		s.log.WithField("file", s.filePath).Debug("No recommendation acknowledgements file found, starting with empty store")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read acknowledgements file: %w", err)
+	}
+
+	var loaded map[string]*models.RecommendationAck
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("failed to unmarshal acknowledgements: %w", err)
+	}
+
+	now := time.Now()
+	acks := make(map[string]*models.RecommendationAck, len(loaded))
+	for id, ack := range loaded {
+		if ack == nil || ack.RecommendationID != id || ack.Validate() != nil {
+			s.log.WithFields(logrus.Fields{
+				"file":              s.filePath,
+				"recommendation_id": id,
+			}).Warn("Skipping invalid recommendation acknowledgement from file")
+			continue
+		}
+		if ack.Active(now) {
+			acks[id] = ack
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acks = acks
+
+	s.log.WithFields(logrus.Fields{
+		"file":  s.filePath,
+		"count": len(acks),
+	}).Info("Recommendation acknowledgements loaded from file")
+	return nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func newTestAck(id string, now time.Time, ttl time.Duration) *models.RecommendationAck {
+	return &models.RecommendationAck{
+		RecommendationID: id,
+		AcknowledgedBy:   "oncall",
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(ttl),
+	}
+}
+
+func TestRecommendationAckStore_Acknowledge(t *testing.T) {
+	store := NewRecommendationAckStore()
+	now := time.Now()
+
+	require.NoError(t, store.Acknowledge(newTestAck("rec-a", now, time.Hour)))
+	require.NoError(t, store.Acknowledge(newTestAck("rec-b", now, 10*time.Minute)))
+	assert.Error(t, store.Acknowledge(newTestAck("rec-c", now, 0)), "expiry must follow creation")
+
+	ack, ok := store.Get("rec-a", now)
+	require.True(t, ok)
+	assert.Equal(t, "oncall", ack.AcknowledgedBy)
+
+	_, ok = store.Get("rec-a", now.Add(2*time.Hour))
+	assert.False(t, ok, "expired acknowledgements no longer suppress")
+
+	acks := store.List(now)
+	require.Len(t, acks, 2)
+	assert.Equal(t, "rec-b", acks[0].RecommendationID, "soonest to expire first")
+
+	// Re-acknowledging replaces the previous acknowledgement
+	require.NoError(t, store.Acknowledge(newTestAck("rec-b", now, 3*time.Hour)))
+	acks = store.List(now)
+	assert.Equal(t, "rec-a", acks[0].RecommendationID)
+
+	removed, err := store.Remove("rec-a")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = store.Remove("rec-a")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+// TestRecommendationAckStore_Persisted verifies acknowledgements survive a restart and expired
+// ones are dropped on load
+func TestRecommendationAckStore_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewRecommendationAckStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, store.Acknowledge(newTestAck("rec-a", now, time.Hour)))
+	require.NoError(t, store.Acknowledge(newTestAck("rec-b", now, 50*time.Millisecond)))
+	time.Sleep(60 * time.Millisecond)
+
+	reloaded, err := NewRecommendationAckStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	acks := reloaded.List(time.Now())
+	require.Len(t, acks, 1)
+	assert.Equal(t, "rec-a", acks[0].RecommendationID)
+
+	_, err = reloaded.Remove("rec-a")
+	require.NoError(t, err)
+	reloaded, err = NewRecommendationAckStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.List(time.Now()))
+}
+
+func TestRecommendationAckStore_LoadSkipsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	created := time.Now().UTC().Format(time.RFC3339)
+	data := `{
+  "rec-good": {"recommendation_id": "rec-good", "created_at": "` + created + `", "expires_at": "` + expires + `"},
+  "rec-mismatch": {"recommendation_id": "rec-other", "created_at": "` + created + `", "expires_at": "` + expires + `"},
+  "rec-no-expiry": {"recommendation_id": "rec-no-expiry", "created_at": "` + created + `"}
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, RecommendationAckFileName), []byte(data), 0o600))
+
+	store, err := NewRecommendationAckStoreWithPersistence(dir, nil)
+	require.NoError(t, err)
+	acks := store.List(time.Now())
+	require.Len(t, acks, 1)
+	assert.Equal(t, "rec-good", acks[0].RecommendationID)
+}
@@ -0,0 +1,28 @@
+package types
+
+// ErrorResponse is the error body of the prediction and recommendation APIs
+type ErrorResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Details string `json:"details,omitempty"`
+	Code    string `json:"code"`
+}
+
+// Error codes of prediction failures
+const (
+	ErrCodeInvalidRequest             = "INVALID_REQUEST"
+	ErrCodePrometheusUnavailable      = "PROMETHEUS_UNAVAILABLE"
+	ErrCodeKServeUnavailable          = "KSERVE_UNAVAILABLE"
+	ErrCodeModelNotFound              = "MODEL_NOT_FOUND"
+	ErrCodePredictionFailed           = "PREDICTION_FAILED"
+	ErrCodeFeaturesUnavailable        = "FEATURE_ENGINEERING_UNAVAILABLE"
+	ErrCodePredictionCapacityExceeded = "PREDICTION_CAPACITY_EXCEEDED"
+)
+
+// Error codes of recommendation failures
+const (
+	ErrCodeInvalidTimeframe  = "INVALID_TIMEFRAME"
+	ErrCodeInvalidConfidence = "INVALID_CONFIDENCE"
+	ErrCodeMLUnavailable     = "ML_UNAVAILABLE"
+	ErrCodeInternalError     = "INTERNAL_ERROR"
+)
@@ -0,0 +1,180 @@
+// Package types holds the request and response bodies of the coordination engine's prediction
+// and recommendation APIs. It imports nothing outside the standard library, so API clients
+// can share the server's wire types without depending on the server.
+package types
+
+// PredictRequest represents the request body for time-specific predictions
+type PredictRequest struct {
+	Hour            int    `json:"hour"`                       // Required unless target_timestamp is set: 0-23 (hour of day)
+	DayOfWeek       int    `json:"day_of_week"`                // Required unless target_timestamp is set: 0=Monday, 6=Sunday
+	TargetTimestamp string `json:"target_timestamp,omitempty"` // Optional: RFC3339 future time; replaces hour and day_of_week
+	Namespace       string `json:"namespace"`                  // Optional: namespace filter
+	Deployment      string `json:"deployment"`                 // Optional: deployment filter
+	Pod             string `json:"pod"`                        // Optional: specific pod filter
+	Scope           string `json:"scope"`                      // Optional: pod, deployment, namespace, cluster (default: namespace)
+	Model           string `json:"model"`                      // Optional: KServe model name (default: predictive-analytics)
+
+	// Models is an ordered fallback chain replacing model: each model is tried in turn, with
+	// the feature shape it expects, until one serves the prediction; model_info.name reports
+	// which one did. The server limits the number of distinct models.
+	Models []string `json:"models,omitempty"`
+
+	// CapacityScaleFactor optionally asks what utilization would be if capacity were multiplied
+	// by it, e.g. 2 for twice the replicas; the answer is returned in capacity_what_if
+	CapacityScaleFactor *float64 `json:"capacity_scale_factor,omitempty"`
+
+	// ThresholdPercent overrides the server's utilization threshold (0-100] that
+	// time_to_threshold reports forecast crossings of
+	ThresholdPercent *float64 `json:"threshold_percent,omitempty"`
+
+	// Aggregation selects the statistic of the scope's current CPU and memory: mean, p95 or
+	// max over the last hour (default: the server's PREDICTION_METRIC_AGGREGATION)
+	Aggregation string `json:"aggregation,omitempty"`
+
+	// DebugRawResponse includes the model's response payload in raw_model_response. Only
+	// accepted when the server enables it (KSERVE_DEBUG_RAW_RESPONSE); such requests bypass
+	// the prediction cache.
+	DebugRawResponse bool `json:"debug_raw_response,omitempty"`
+
+	// QueryStep overrides the feature builder's range query resolution for this request, e.g.
+	// "1m" for short-lived pods; it must divide every rolling window (or the resample rule) evenly
+	QueryStep string `json:"query_step,omitempty"`
+
+	// Fields restricts the response to these top-level fields plus status, e.g. ["predictions"]
+	// for dashboards polling many scopes (empty = the full response)
+	Fields []string `json:"fields,omitempty"`
+}
+
+// PredictResponse represents the response for time-specific predictions
+type PredictResponse struct {
+	Status         string           `json:"status"`
+	Scope          string           `json:"scope"`
+	Target         string           `json:"target"`
+	Predictions    PredictionValues `json:"predictions"`
+	CurrentMetrics CurrentMetrics   `json:"current_metrics"`
+	ModelInfo      ModelInfo        `json:"model_info"`
+	TargetTime     TargetTimeInfo   `json:"target_time"`
+
+	// CapacityWhatIf is set when the request has a capacity_scale_factor
+	CapacityWhatIf *CapacityWhatIf `json:"capacity_what_if,omitempty"`
+
+	// TimeToThreshold is set when the model returned a multi-step forecast
+	TimeToThreshold *TimeToThreshold `json:"time_to_threshold,omitempty"`
+
+	// RawModelResponse is set when the request has debug_raw_response
+	RawModelResponse *RawModelResponse `json:"raw_model_response,omitempty"`
+
+	// DataQuality is "heuristic" and DegradedReason the KServe failure when status is
+	// "degraded": the prediction comes from learned baselines, not the model. It is "partial"
+	// when the feature build hit its maximum duration, with DefaultedFeatures counting the
+	// features that hold defaults instead of measurements.
+	DataQuality       string `json:"data_quality,omitempty"`
+	DegradedReason    string `json:"degraded_reason,omitempty"`
+	DefaultedFeatures int    `json:"defaulted_features,omitempty"`
+}
+
+// RawModelResponse is the payload returned by the KServe model, before the engine maps it to
+// CPU/memory percentages
+type RawModelResponse struct {
+	Type    string `json:"type"`    // forecast, anomaly or regression
+	Payload any    `json:"payload"` // The forecast, anomaly or regression response body
+}
+
+// CapacityWhatIf compares the predicted utilization at current capacity with the utilization if
+// capacity were multiplied by ScaleFactor. Added capacity is assumed to absorb load evenly, so
+// the scaled percentages are the baseline divided by ScaleFactor, capped at 100.
+type CapacityWhatIf struct {
+	ScaleFactor float64            `json:"scale_factor"`
+	Baseline    CapacityPrediction `json:"baseline"` // At current capacity; same as predictions
+	Scaled      CapacityPrediction `json:"scaled"`   // At current capacity * scale_factor
+}
+
+// CapacityPrediction is the predicted CPU and memory utilization at one capacity
+type CapacityPrediction struct {
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+}
+
+// PredictionValues contains the predicted resource usage. Fields ending in _percent are
+// 0-100 percentages; other metrics carry their unit. Disk and network values are only
+// present when the model forecasts them.
+type PredictionValues struct {
+	CPUPercent    float64      `json:"cpu_percent"`
+	MemoryPercent float64      `json:"memory_percent"`
+	DiskPercent   *float64     `json:"disk_percent,omitempty"`
+	NetworkIn     *MetricValue `json:"network_in,omitempty"`
+	NetworkOut    *MetricValue `json:"network_out,omitempty"`
+
+	// DefaultedMetrics lists metrics (cpu_percent, memory_percent) the model returned no
+	// forecast for under the configured keys; their values are the current rolling means
+	DefaultedMetrics []string `json:"defaulted_metrics,omitempty"`
+}
+
+// MetricValue is a predicted or current value that is not a 0-100 percentage, tagged with its
+// unit so clients can render it
+type MetricValue struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"` // e.g. bytes_per_second
+
+	// Capped is set when the source was clamped to a reference maximum before normalizing, so
+	// Value is that maximum and the actual value may be higher
+	Capped bool `json:"capped,omitempty"`
+}
+
+// CurrentMetrics contains the current rolling metrics from Prometheus
+type CurrentMetrics struct {
+	CPURollingMean    float64 `json:"cpu_rolling_mean"`
+	MemoryRollingMean float64 `json:"memory_rolling_mean"`
+
+	// Current disk usage sent to the model, as a percentage of its 0-1 ratio
+	DiskUsage float64 `json:"disk_usage"`
+
+	// Current network rates sent to the model, in bytes/sec like the network predictions
+	NetworkIn  MetricValue `json:"network_in"`
+	NetworkOut MetricValue `json:"network_out"`
+
+	// DefaultedMetrics lists disk_usage, network_in and network_out when their query failed
+	// and a default value was used instead
+	DefaultedMetrics []string `json:"defaulted_metrics,omitempty"`
+
+	// Aggregation is the statistic CPURollingMean and MemoryRollingMean hold: mean, or p95 or
+	// max over the last hour
+	Aggregation string `json:"aggregation"`
+
+	Timestamp string `json:"timestamp"`
+	TimeRange string `json:"time_range"`
+}
+
+// ModelInfo contains information about the KServe model used for prediction
+type ModelInfo struct {
+	Name       string  `json:"name"`
+	Version    string  `json:"version"`
+	Confidence float64 `json:"confidence"`
+}
+
+// TargetTimeInfo contains information about the prediction target time
+type TargetTimeInfo struct {
+	Hour         int    `json:"hour"`
+	DayOfWeek    int    `json:"day_of_week"`
+	ISOTimestamp string `json:"iso_timestamp"`
+}
+
+// TimeToThreshold reports when the forecast first reaches ThresholdPercent. A metric is null
+// when it stays below the threshold for the whole forecast horizon or has no forecast.
+type TimeToThreshold struct {
+	ThresholdPercent float64 `json:"threshold_percent"`
+	StepDuration     string  `json:"step_duration"`
+	HorizonSteps     int     `json:"horizon_steps"`
+
+	CPU    *ThresholdCrossing `json:"cpu"`
+	Memory *ThresholdCrossing `json:"memory"`
+}
+
+// ThresholdCrossing is the first forecast step at or above the threshold. Step 1 is the
+// closest forecast value, one step duration after the prediction was made.
+type ThresholdCrossing struct {
+	Step             int     `json:"step"`
+	HoursFromNow     float64 `json:"hours_from_now"`
+	EstimatedTime    string  `json:"estimated_time"`
+	PredictedPercent float64 `json:"predicted_percent"`
+}
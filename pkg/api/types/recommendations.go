@@ -0,0 +1,155 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetRecommendationsRequest represents the request body for getting recommendations
+type GetRecommendationsRequest struct {
+	Timeframe           string  `json:"timeframe"`            // "1h", "6h", "24h" (default: "6h")
+	IncludePredictions  *bool   `json:"include_predictions"`  // Include ML predictions (default: true)
+	ConfidenceThreshold float64 `json:"confidence_threshold"` // Minimum confidence 0.0-1.0 (default: 0.7)
+	Namespace           string  `json:"namespace"`            // Optional: filter by namespace
+	IncludeNearMisses   bool    `json:"include_near_misses"`  // Return recommendations just below the threshold separately (default: false)
+	NearMissMargin      float64 `json:"near_miss_margin"`     // How far below the threshold a near-miss may be, 0.0-1.0 (default: 0.1)
+	DetailedEvidence    bool    `json:"detailed_evidence"`    // Include the structured evidence_data behind evidence (default: false)
+	IncludeAcknowledged bool    `json:"include_acknowledged"` // Return acknowledged recommendations under acknowledged (default: false)
+	MaxRecommendations  int     `json:"max_recommendations"`  // Return at most this many, most important first (default and upper bound: server limit)
+
+	// IncidentLabels restricts historical analysis to incidents carrying all of these labels,
+	// e.g. {"team": "payments"} for one team's recommendations (default: all incidents)
+	IncidentLabels map[string]string `json:"incident_labels,omitempty"`
+
+	// MLNamespaces runs ML predictions for each of these namespaces from its own metrics, batched
+	// into as few model calls as possible (default: the namespace filter, or the whole cluster)
+	MLNamespaces []string `json:"ml_namespaces,omitempty"`
+}
+
+// Recommendation represents a single remediation recommendation
+type Recommendation struct {
+	ID                 string   `json:"id"` // Stable for the same source, issue type, namespace and target
+	Type               string   `json:"type"`
+	IssueType          string   `json:"issue_type"`
+	Target             string   `json:"target"`
+	Namespace          string   `json:"namespace"`
+	Severity           string   `json:"severity"`
+	Confidence         float64  `json:"confidence"`
+	PredictedTime      string   `json:"predicted_time,omitempty"`
+	RecommendedActions []string `json:"recommended_actions"`
+	Evidence           []string `json:"evidence"`
+	Source             string   `json:"source,omitempty"`
+	RelatedIncidentID  string   `json:"related_incident_id,omitempty"`
+
+	// Sources lists every source that produced this recommendation when duplicates were
+	// merged; Source stays the first of them
+	Sources []string `json:"sources,omitempty"`
+
+	// NearMiss marks a recommendation that fell short of the confidence threshold by
+	// ConfidenceShortfall; near-misses are only listed under below_threshold
+	NearMiss            bool    `json:"near_miss,omitempty"`
+	ConfidenceShortfall float64 `json:"confidence_shortfall,omitempty"`
+
+	// EvidenceData carries the numbers Evidence describes; only returned with detailed_evidence
+	EvidenceData *EvidenceData `json:"evidence_data,omitempty"`
+
+	// DeferUntilStable asks consumers to hold off acting until the MachineConfigPool update
+	// listed in the response's updating_pools finishes; Severity was lowered one level
+	DeferUntilStable bool `json:"defer_until_stable,omitempty"`
+
+	// Acknowledgement is set on recommendations listed under acknowledged
+	Acknowledgement *RecommendationAck `json:"acknowledgement,omitempty"`
+}
+
+// EvidenceData holds the measurements behind a recommendation, one section per source that
+// produced it. Sections of sources that did not contribute are nil.
+type EvidenceData struct {
+	Historical *HistoricalEvidence `json:"historical,omitempty"`
+	Prediction *PredictionEvidence `json:"prediction,omitempty"`
+	Pattern    *PatternEvidence    `json:"pattern,omitempty"`
+}
+
+// HistoricalEvidence is what historical_analysis counted for an issue type and namespace
+type HistoricalEvidence struct {
+	OccurrenceCount     int     `json:"occurrence_count"`
+	DiscountedCount     float64 `json:"discounted_count"` // Occurrences with resolved ones discounted; drives recurrence and severity
+	WeightedScore       float64 `json:"weighted_score"`   // Recency-weighted occurrences; drives confidence
+	AutoResolvedCount   int     `json:"auto_resolved_count"`
+	RemediationFailures int     `json:"remediation_failures"`
+}
+
+// PredictionEvidence is the model input and output behind an ml_prediction recommendation.
+// Rolling means are 0-1 ratios.
+type PredictionEvidence struct {
+	ModelOutput       int     `json:"model_output"`   // -1 = issue predicted
+	InstanceIndex     int     `json:"instance_index"` // 0 = current metrics, 1 = elevated scenario
+	HourOfDay         int     `json:"hour_of_day"`
+	DayOfWeek         int     `json:"day_of_week"`
+	CPURollingMean    float64 `json:"cpu_rolling_mean"`    // Cluster value
+	MemoryRollingMean float64 `json:"memory_rolling_mean"` // Cluster value
+	InstanceCPU       float64 `json:"instance_cpu"`        // Sent to the model; drives severity and confidence
+	InstanceMemory    float64 `json:"instance_memory"`     // Sent to the model; drives severity and confidence
+
+	// Score is the model's decision score for the instance when it reported one; it then drives
+	// confidence instead of the instance metrics
+	Score *float64 `json:"score,omitempty"`
+}
+
+// PatternEvidence is what pattern_detection counted for an issue type and namespace
+type PatternEvidence struct {
+	FailedWorkflows int `json:"failed_workflows"`
+}
+
+// GetRecommendationsResponse represents the response for getting recommendations
+type GetRecommendationsResponse struct {
+	Status               string           `json:"status"`
+	Timestamp            string           `json:"timestamp"`
+	Timeframe            string           `json:"timeframe"`
+	Recommendations      []Recommendation `json:"recommendations"`
+	TotalRecommendations int              `json:"total_recommendations"` // Above the threshold, before truncation
+	MLEnabled            bool             `json:"ml_enabled"`
+	Message              string           `json:"message,omitempty"`
+
+	// Truncated is true when recommendations or near-misses were cut to the max_recommendations
+	// most important
+	Truncated bool `json:"truncated,omitempty"`
+
+	// BelowThreshold lists near-miss recommendations when the request asked for them
+	BelowThreshold []Recommendation `json:"below_threshold,omitempty"`
+
+	// UpdatingPools lists MachineConfigPools mid-update, which deferred every recommendation
+	UpdatingPools []string `json:"updating_pools,omitempty"`
+
+	// Acknowledged lists suppressed recommendations above the threshold when the request asked
+	// for them; they are never counted in TotalRecommendations
+	Acknowledged []Recommendation `json:"acknowledged,omitempty"`
+}
+
+// RecommendationAck suppresses a recommendation until ExpiresAt, after an operator has seen it
+// and is acting on it (acknowledged) or wants it out of the way for a while (snoozed)
+type RecommendationAck struct {
+	RecommendationID string    `json:"recommendation_id"`
+	AcknowledgedBy   string    `json:"acknowledged_by,omitempty"`
+	Comment          string    `json:"comment,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// Active reports whether the acknowledgement still suppresses its recommendation at now
+func (a *RecommendationAck) Active(now time.Time) bool {
+	return now.Before(a.ExpiresAt)
+}
+
+// Validate checks the fields every stored acknowledgement must have
+func (a *RecommendationAck) Validate() error {
+	if a.RecommendationID == "" {
+		return fmt.Errorf("recommendation_id is required")
+	}
+	if a.CreatedAt.IsZero() {
+		return fmt.Errorf("created_at is required")
+	}
+	if !a.ExpiresAt.After(a.CreatedAt) {
+		return fmt.Errorf("expires_at must be after created_at")
+	}
+	return nil
+}
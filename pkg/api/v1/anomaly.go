@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"net/http"
 	"sort"
@@ -27,6 +28,9 @@ type AnomalyHandler struct {
 
 	// Default values when Prometheus is not available
 	defaultMetricValue float64
+
+	// Per-model KServe timeouts; models without one use the client timeout
+	modelTimeouts map[string]time.Duration
 }
 
 // NewAnomalyHandler creates a new anomaly analysis handler
@@ -249,7 +253,7 @@ func (h *AnomalyHandler) AnalyzeAnomalies(w http.ResponseWriter, r *http.Request
 
 	// Call KServe anomaly-detector model
 	instances := [][]float64{features}
-	resp, err := h.kserveClient.Predict(ctx, req.ModelName, instances)
+	resp, err := h.kserveClient.Predict(kserve.WithRequestTimeout(ctx, h.modelTimeouts[req.ModelName]), req.ModelName, instances)
 	if err != nil {
 		h.log.WithError(err).WithField("model", req.ModelName).Error("KServe anomaly detection failed")
 		h.respondError(w, http.StatusServiceUnavailable, "Anomaly detection failed", err.Error(), ErrCodeAnomalyAnalysisFailed)
@@ -802,6 +806,12 @@ func (h *AnomalyHandler) SetPrometheusClient(client *integrations.PrometheusClie
 	h.prometheusClient = client
 }
 
+// SetModelTimeouts overrides the KServe client timeout per model, capped at
+// kserve.MaxRequestTimeout. Must be called before serving.
+func (h *AnomalyHandler) SetModelTimeouts(timeouts map[string]time.Duration) {
+	h.modelTimeouts = maps.Clone(timeouts)
+}
+
 // collectEnrichedSignals queries optional application-level signals (ADR-017).
 // All signals gracefully return nil when the underlying metrics are unavailable.
 func (h *AnomalyHandler) collectEnrichedSignals(ctx context.Context, namespace, pod, deployment string) *EnrichedSignals {
@@ -2,10 +2,14 @@
 package v1
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"maps"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
@@ -13,20 +17,64 @@ import (
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
 )
 
+const (
+	// modelListHealthTimeout bounds the per-model reachability checks made while listing models
+	modelListHealthTimeout = 5 * time.Second
+
+	// modelListReachabilityTTL is how long ListModels reuses a probe until
+	// SetReachabilityReporter replaces its cache
+	modelListReachabilityTTL = 10 * time.Second
+)
+
+// ModelFeatureDescriber reports the feature strategy and expected feature count used for a model.
+// Implemented by PredictionHandler.
+type ModelFeatureDescriber interface {
+	DescribeModelFeatures(model string) (strategy string, featureCount int)
+}
+
+// ModelReachabilityReporter reports a model's predictor status and whether it can serve
+// predictions, from a cached probe. Implemented by PredictionHandler.
+type ModelReachabilityReporter interface {
+	ModelReachability(ctx context.Context, model string) (status string, reachable bool)
+}
+
 // KServeProxyHandler handles KServe model proxy API requests (ADR-039, ADR-040)
 type KServeProxyHandler struct {
-	proxyClient *kserve.ProxyClient
-	log         *logrus.Logger
+	proxyClient          *kserve.ProxyClient
+	featureDescriber     ModelFeatureDescriber
+	reachabilityReporter ModelReachabilityReporter
+	log                  *logrus.Logger
+
+	// Per-model timeouts for detect requests; models without one use the client timeout
+	modelTimeouts map[string]time.Duration
 }
 
 // NewKServeProxyHandler creates a new KServe proxy API handler
 func NewKServeProxyHandler(proxyClient *kserve.ProxyClient, log *logrus.Logger) *KServeProxyHandler {
 	return &KServeProxyHandler{
-		proxyClient: proxyClient,
-		log:         log,
+		proxyClient:          proxyClient,
+		log:                  log,
+		reachabilityReporter: newModelReachability(proxyClient, modelListReachabilityTTL),
 	}
 }
 
+// SetFeatureDescriber sets the source of per-model feature requirements reported by ListModels
+func (h *KServeProxyHandler) SetFeatureDescriber(describer ModelFeatureDescriber) {
+	h.featureDescriber = describer
+}
+
+// SetReachabilityReporter sets the cached probe state ListModels reports, so listing shares
+// the probes made for predictions
+func (h *KServeProxyHandler) SetReachabilityReporter(reporter ModelReachabilityReporter) {
+	h.reachabilityReporter = reporter
+}
+
+// SetModelTimeouts overrides the KServe client timeout per model for detect requests, capped
+// at kserve.MaxRequestTimeout. Must be called before serving.
+func (h *KServeProxyHandler) SetModelTimeouts(timeouts map[string]time.Duration) {
+	h.modelTimeouts = maps.Clone(timeouts)
+}
+
 // GetProxyClient returns the KServe proxy client for use by other handlers
 func (h *KServeProxyHandler) GetProxyClient() *kserve.ProxyClient {
 	return h.proxyClient
@@ -92,7 +140,7 @@ func (h *KServeProxyHandler) HandleDetect(w http.ResponseWriter, r *http.Request
 	}).Info("KServe detect request received")
 
 	// Call KServe model
-	resp, err := h.proxyClient.Predict(r.Context(), req.Model, req.Instances)
+	resp, err := h.proxyClient.Predict(kserve.WithRequestTimeout(r.Context(), h.modelTimeouts[req.Model]), req.Model, req.Instances)
 	if err != nil {
 		h.log.WithError(err).WithField("model", req.Model).Error("KServe prediction failed")
 
@@ -120,7 +168,7 @@ func (h *KServeProxyHandler) HandleDetect(w http.ResponseWriter, r *http.Request
 
 // ListModels handles GET /api/v1/models
 // @Summary List all registered KServe models
-// @Description Returns a list of all registered KServe InferenceServices
+// @Description Returns all registered KServe InferenceServices with reachability and feature requirements
 // @Tags kserve
 // @Produce json
 // @Success 200 {object} ModelsListResponse
@@ -131,8 +179,9 @@ func (h *KServeProxyHandler) ListModels(w http.ResponseWriter, r *http.Request)
 	models := h.proxyClient.ListModels()
 
 	response := ModelsListResponse{
-		Models: models,
-		Count:  len(models),
+		Models:  models,
+		Count:   len(models),
+		Details: h.describeModels(r.Context(), models),
 	}
 
 	h.log.WithField("count", len(models)).Debug("Returning model list")
@@ -177,10 +226,65 @@ func (h *KServeProxyHandler) CheckModelHealth(w http.ResponseWriter, r *http.Req
 	h.respondJSON(w, http.StatusOK, health)
 }
 
+// describeModels checks each model's health in parallel and attaches its feature requirements
+func (h *KServeProxyHandler) describeModels(ctx context.Context, models []string) []ModelDescription {
+	descriptions := make([]ModelDescription, len(models))
+
+	var wg sync.WaitGroup
+	for i, name := range models {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			descriptions[i] = h.describeModel(ctx, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	return descriptions
+}
+
+// describeModel builds the description for a single registered model
+func (h *KServeProxyHandler) describeModel(ctx context.Context, name string) ModelDescription {
+	description := ModelDescription{Name: name, Status: "unknown"}
+
+	if model, exists := h.proxyClient.GetModel(name); exists {
+		description.Service = model.ServiceName
+		description.Namespace = model.Namespace
+	}
+
+	// Served from the reachability cache so listing probes each predictor at most once per TTL
+	checkCtx, cancel := context.WithTimeout(ctx, modelListHealthTimeout)
+	defer cancel()
+	description.Status, description.Reachable = h.reachabilityReporter.ModelReachability(checkCtx, name)
+
+	if h.featureDescriber != nil {
+		description.FeatureStrategy, description.ExpectedFeatureCount = h.featureDescriber.DescribeModelFeatures(name)
+	}
+
+	return description
+}
+
 // ModelsListResponse represents the response for listing models
 type ModelsListResponse struct {
-	Models []string `json:"models"`
-	Count  int      `json:"count"`
+	Models  []string           `json:"models"`
+	Count   int                `json:"count"`
+	Details []ModelDescription `json:"details"`
+}
+
+// ModelDescription describes a registered model and what the prediction API sends it
+type ModelDescription struct {
+	Name      string `json:"name"`
+	Service   string `json:"service,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// Reachable is true when the model's KServe health endpoint reported ready on the latest
+	// cached probe
+	Reachable bool   `json:"reachable"`
+	Status    string `json:"status"`
+
+	// FeatureStrategy and ExpectedFeatureCount are omitted when no prediction handler is wired
+	FeatureStrategy      string `json:"feature_strategy,omitempty"`
+	ExpectedFeatureCount int    `json:"expected_feature_count,omitempty"`
 }
 
 // ErrorResponse represents an error response
@@ -2,10 +2,12 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,6 +19,44 @@ import (
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
 )
 
+// newSlowProxyClient returns a proxy client whose model does not answer until the test ends
+func newSlowProxyClient(t *testing.T, model string) *kserve.ProxyClient {
+	t.Helper()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"predictions": []int{1, 1}, "model_name": model})
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) }) // runs first, so Close does not wait on blocked handlers
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	client, err := kserve.NewProxyClient(kserve.ProxyConfig{Namespace: "test-ns", Timeout: 30 * time.Second}, log)
+	require.NoError(t, err)
+	client.RegisterModel(&kserve.ModelInfo{Name: model, URL: server.URL})
+	return client
+}
+
+// TestKServeProxyHandler_HandleDetect_ModelTimeout verifies detect requests use the model's
+// timeout instead of the client's
+func TestKServeProxyHandler_HandleDetect_ModelTimeout(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewKServeProxyHandler(newSlowProxyClient(t, "slow-model"), log)
+	handler.SetModelTimeouts(map[string]time.Duration{"slow-model": 50 * time.Millisecond})
+
+	start := time.Now()
+	req := httptest.NewRequest("POST", "/api/v1/detect", bytes.NewBufferString(`{"model": "slow-model", "instances": [[0.5, 1.2]]}`))
+	w := httptest.NewRecorder()
+	handler.HandleDetect(w, req)
+
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	assert.Less(t, time.Since(start), 2*time.Second, "the model timeout applies, not the 30s client timeout")
+}
+
 func TestKServeProxyHandler_HandleDetect(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -142,6 +182,126 @@ func TestKServeProxyHandler_ListModels(t *testing.T) {
 	assert.Contains(t, resp.Models, "model-b")
 }
 
+func TestKServeProxyHandler_ListModels_Details(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/models/ready-model" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer mockServer.Close()
+
+	client, err := kserve.NewProxyClient(kserve.ProxyConfig{Namespace: "test-ns", Timeout: 5 * time.Second}, log)
+	require.NoError(t, err)
+	client.RegisterModel(&kserve.ModelInfo{Name: "ready-model", ServiceName: "ready-predictor", Namespace: "test-ns", URL: mockServer.URL})
+	client.RegisterModel(&kserve.ModelInfo{Name: "down-model", Namespace: "test-ns", URL: mockServer.URL})
+
+	handler := NewKServeProxyHandler(client, log)
+
+	t.Run("without feature describer", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/models", http.NoBody)
+		w := httptest.NewRecorder()
+
+		handler.ListModels(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp ModelsListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Details, 2)
+		for _, d := range resp.Details {
+			assert.Empty(t, d.FeatureStrategy)
+			assert.Zero(t, d.ExpectedFeatureCount)
+		}
+	})
+
+	t.Run("with prediction handler as describer", func(t *testing.T) {
+		handler.SetFeatureDescriber(NewPredictionHandler(client, nil, log))
+
+		req := httptest.NewRequest("GET", "/api/v1/models", http.NoBody)
+		w := httptest.NewRecorder()
+
+		handler.ListModels(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp ModelsListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, 2, resp.Count)
+		require.Len(t, resp.Details, 2)
+
+		byName := make(map[string]ModelDescription)
+		for _, d := range resp.Details {
+			byName[d.Name] = d
+		}
+
+		ready := byName["ready-model"]
+		assert.True(t, ready.Reachable)
+		assert.Equal(t, "ready", ready.Status)
+		assert.Equal(t, "ready-predictor", ready.Service)
+		assert.Equal(t, FeatureStrategyRawMetrics, ready.FeatureStrategy)
+		assert.Equal(t, 5, ready.ExpectedFeatureCount)
+
+		down := byName["down-model"]
+		assert.False(t, down.Reachable)
+		assert.Equal(t, "unavailable", down.Status)
+	})
+}
+
+// TestKServeProxyHandler_ListModels_CachedReachability verifies listing serves cached probe
+// results instead of probing every predictor on each request
+func TestKServeProxyHandler_ListModels_CachedReachability(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	var probes atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	client, err := kserve.NewProxyClient(kserve.ProxyConfig{Namespace: "test-ns", Timeout: 5 * time.Second}, log)
+	require.NoError(t, err)
+	client.RegisterModel(&kserve.ModelInfo{Name: "ready-model", Namespace: "test-ns", URL: mockServer.URL})
+
+	list := func(handler *KServeProxyHandler) ModelDescription {
+		req := httptest.NewRequest("GET", "/api/v1/models", http.NoBody)
+		w := httptest.NewRecorder()
+		handler.ListModels(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp ModelsListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Details, 1)
+		return resp.Details[0]
+	}
+
+	t.Run("own cache", func(t *testing.T) {
+		probes.Store(0)
+		handler := NewKServeProxyHandler(client, log)
+		for i := 0; i < 3; i++ {
+			d := list(handler)
+			assert.True(t, d.Reachable)
+			assert.Equal(t, "ready", d.Status)
+		}
+		assert.Equal(t, int32(1), probes.Load())
+	})
+
+	t.Run("shared with predictions", func(t *testing.T) {
+		probes.Store(0)
+		predictionHandler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{ModelReachabilityTTL: time.Minute})
+		require.NoError(t, predictionHandler.validateKServeAvailability(context.Background(), "ready-model"))
+
+		handler := NewKServeProxyHandler(client, log)
+		handler.SetReachabilityReporter(predictionHandler)
+		d := list(handler)
+		assert.True(t, d.Reachable)
+		assert.Equal(t, int32(1), probes.Load(), "listing should reuse the prediction probe")
+	})
+}
+
 func TestKServeProxyHandler_CheckModelHealth(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+// NamespaceOverviewHandler serves a namespace's prediction and recommendations in one response
+type NamespaceOverviewHandler struct {
+	prediction      *PredictionHandler
+	recommendations *RecommendationsHandler
+	log             *logrus.Logger
+}
+
+// NewNamespaceOverviewHandler creates an overview handler over the prediction and
+// recommendations handlers. They should share a metrics snapshot (SetMetricsSnapshot) so one
+// overview queries each rolling mean once.
+func NewNamespaceOverviewHandler(prediction *PredictionHandler, recommendations *RecommendationsHandler, log *logrus.Logger) *NamespaceOverviewHandler {
+	return &NamespaceOverviewHandler{
+		prediction:      prediction,
+		recommendations: recommendations,
+		log:             log,
+	}
+}
+
+// RegisterRoutes registers the overview route
+func (h *NamespaceOverviewHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/v1/namespaces/{namespace}/overview", h.HandleNamespaceOverview).Methods("POST")
+	h.log.Info("Namespace overview API endpoint registered: POST /api/v1/namespaces/{namespace}/overview")
+}
+
+// NamespaceOverviewRequest is the optional body of a namespace overview. The namespace comes
+// from the path; both parts take the same fields as their own endpoints.
+type NamespaceOverviewRequest struct {
+	// Prediction is a /api/v1/predict body without scope fields. Without hour, day_of_week
+	// or target_timestamp it predicts for the current hour.
+	Prediction *PredictRequest `json:"prediction,omitempty"`
+
+	// Recommendations is a /api/v1/recommendations body without ml_namespaces
+	Recommendations *GetRecommendationsRequest `json:"recommendations,omitempty"`
+}
+
+// NamespaceOverviewResponse holds a namespace's prediction and recommendations, built from
+// the same rolling means. Status is "partial" when the prediction failed; the recommendations
+// are still returned and the failure is described in prediction_error.
+type NamespaceOverviewResponse struct {
+	Status          string                     `json:"status"`
+	Namespace       string                     `json:"namespace"`
+	Timestamp       string                     `json:"timestamp"`
+	Prediction      *PredictResponse           `json:"prediction,omitempty"`
+	PredictionError *CompareError              `json:"prediction_error,omitempty"`
+	Recommendations GetRecommendationsResponse `json:"recommendations"`
+}
+
+// HandleNamespaceOverview handles POST /api/v1/namespaces/{namespace}/overview
+//
+// @Summary Namespace prediction and recommendations
+// @Description Returns the namespace's utilization prediction and its recommendations in one payload. Both are computed from one lookup of the namespace's rolling means, so they describe the same moment.
+// @Tags prediction
+// @Accept json
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param request body NamespaceOverviewRequest false "Prediction and recommendations options"
+// @Success 200 {object} NamespaceOverviewResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Router /api/v1/namespaces/{namespace}/overview [post]
+func (h *NamespaceOverviewHandler) HandleNamespaceOverview(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
+
+	namespace := mux.Vars(r)["namespace"]
+	predictReq, recsReq, err := h.parseRequest(r, namespace)
+	if err != nil {
+		h.prediction.handleRequestError(w, err)
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"namespace": namespace,
+		"model":     predictReq.Model,
+		"timeframe": recsReq.Timeframe,
+	}).Info("Processing namespace overview request")
+
+	// Both halves look up the namespace's rolling means; the memo makes them share one lookup
+	ctx = integrations.WithRequestMemo(ctx)
+
+	var prediction *PredictResponse
+	var predictionErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		prediction, predictionErr = h.prediction.predictNamespace(ctx, predictReq)
+	}()
+	recommendations := h.recommendations.recommend(ctx, recsReq)
+	wg.Wait()
+
+	response := NamespaceOverviewResponse{
+		Status:          "success",
+		Namespace:       namespace,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		Prediction:      prediction,
+		Recommendations: recommendations,
+	}
+	if predictionErr != nil {
+		h.log.WithContext(ctx).WithError(predictionErr).Warn("Namespace overview prediction failed, returning recommendations only")
+		predictionError := compareErrorFor(namespace, predictionErr)
+		response.Status = "partial"
+		response.PredictionError = &predictionError
+	}
+
+	h.prediction.respondJSON(w, http.StatusOK, response)
+}
+
+// parseRequest decodes the optional body and returns the validated prediction and
+// recommendations requests for namespace
+func (h *NamespaceOverviewHandler) parseRequest(r *http.Request, namespace string) (*PredictRequest, *GetRecommendationsRequest, error) {
+	if err := features.ValidateScopeIdentifiers(namespace, "", ""); err != nil {
+		return nil, nil, &requestError{message: "invalid namespace", details: err.Error(), code: ErrCodeInvalidRequest}
+	}
+
+	var body NamespaceOverviewRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.log.WithContext(r.Context()).WithError(err).Debug("Invalid namespace overview request format")
+			return nil, nil, decodeRequestError("Invalid request format", err)
+		}
+	}
+
+	predictReq := body.Prediction
+	if predictReq == nil {
+		predictReq = &PredictRequest{}
+	}
+	if err := h.preparePredictRequest(r.Context(), predictReq, namespace); err != nil {
+		return nil, nil, err
+	}
+
+	recsReq := body.Recommendations
+	if recsReq == nil {
+		recsReq = &GetRecommendationsRequest{}
+	}
+	if err := prepareRecommendationsRequest(recsReq, namespace); err != nil {
+		return nil, nil, err
+	}
+	return predictReq, recsReq, nil
+}
+
+// preparePredictRequest scopes req to namespace, defaults it to the current hour and
+// validates it like a /api/v1/predict body
+func (h *NamespaceOverviewHandler) preparePredictRequest(ctx context.Context, req *PredictRequest, namespace string) error {
+	req.trimScope()
+	switch {
+	case req.Namespace != "" && req.Namespace != namespace:
+		return overviewFieldError("prediction.namespace", fmt.Sprintf("%q does not match the path namespace %q", req.Namespace, namespace))
+	case req.Deployment != "" || req.Pod != "":
+		return overviewFieldError("prediction", "deployment and pod are not supported; the overview is namespace-scoped")
+	case req.Scope != "" && req.Scope != "namespace":
+		return overviewFieldError("prediction.scope", fmt.Sprintf("must be namespace, got %q", req.Scope))
+	}
+	req.Namespace, req.Scope = namespace, "namespace"
+
+	if !req.hourOrDaySet && req.TargetTimestamp == "" {
+		now := time.Now().UTC()
+		req.Hour = now.Hour()
+		req.DayOfWeek = (int(now.Weekday()) + 6) % 7 // Monday=0
+	}
+
+	if err := h.prediction.validateRequest(req); err != nil {
+		return overviewFieldError("prediction", err.Error())
+	}
+	h.prediction.setRequestDefaults(req)
+	return h.prediction.checkTarget(ctx, req)
+}
+
+// prepareRecommendationsRequest scopes req to namespace and validates it like a
+// /api/v1/recommendations body
+func prepareRecommendationsRequest(req *GetRecommendationsRequest, namespace string) error {
+	req.Namespace = strings.TrimSpace(req.Namespace)
+	if req.Namespace != "" && req.Namespace != namespace {
+		return overviewFieldError("recommendations.namespace", fmt.Sprintf("%q does not match the path namespace %q", req.Namespace, namespace))
+	}
+	if len(req.MLNamespaces) > 0 {
+		return overviewFieldError("recommendations.ml_namespaces", "not supported; the overview is namespace-scoped")
+	}
+	req.Namespace = namespace
+
+	err := validateRecommendationsRequest(req)
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		reqErr.message = "recommendations: " + reqErr.message
+	}
+	return err
+}
+
+func overviewFieldError(field, details string) error {
+	return &requestError{message: "invalid " + field, details: details, code: ErrCodeInvalidRequest}
+}
+
+// predictNamespace runs the HandlePredict pipeline for a validated request without the cache
+// or degraded mode, taking a prediction slot when features are engineered
+func (h *PredictionHandler) predictNamespace(ctx context.Context, req *PredictRequest) (*PredictResponse, error) {
+	models, skipped := h.availableModels(ctx, req)
+	if len(models) == 0 {
+		return nil, chainError(skipped)
+	}
+	if h.usesFeatureEngineeringAny(models) {
+		release, err := h.limiter.acquire(ctx)
+		if err != nil {
+			return nil, &serviceError{message: "Too many concurrent predictions", details: err.Error(), code: ErrCodePredictionCapacityExceeded}
+		}
+		defer release()
+	}
+
+	response, err := h.predictWithFallback(ctx, req, models, skipped, func(ctx context.Context, req *PredictRequest) (PredictResponse, error) {
+		return h.predictScope(ctx, req, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.recordPrediction(ctx, req, &response)
+	h.openPredictedIncident(ctx, req, &response)
+	return &response, nil
+}
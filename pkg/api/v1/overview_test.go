@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+func newOverviewRouter(modelClient kserve.ModelClient) *mux.Router {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	prediction := NewPredictionHandlerWithConfig(modelClient, nil, log, PredictionHandlerConfig{})
+	recommendations := NewRecommendationsHandler(nil, storage.NewIncidentStore(), modelClient, log)
+	router := mux.NewRouter()
+	NewNamespaceOverviewHandler(prediction, recommendations, log).RegisterRoutes(router)
+	return router
+}
+
+func postOverview(t *testing.T, router *mux.Router, namespace, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/"+namespace+"/overview", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestNamespaceOverviewHandler(t *testing.T) {
+	client := &fakeModelClient{
+		models:   map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+	}
+	router := newOverviewRouter(client)
+
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) NamespaceOverviewResponse {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response NamespaceOverviewResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		response := decode(t, postOverview(t, router, "payments", ""))
+		assert.Equal(t, "success", response.Status)
+		assert.Equal(t, "payments", response.Namespace)
+		require.NotNil(t, response.Prediction)
+		assert.Nil(t, response.PredictionError)
+		assert.Equal(t, "namespace", response.Prediction.Scope)
+		assert.Equal(t, "payments", response.Prediction.Target)
+		assert.InDelta(t, 42, response.Prediction.Predictions.CPUPercent, 0.001)
+		assert.Equal(t, "success", response.Recommendations.Status)
+		assert.Equal(t, "6h", response.Recommendations.Timeframe)
+		for _, rec := range response.Recommendations.Recommendations {
+			assert.Equal(t, "payments", rec.Namespace)
+		}
+	})
+
+	t.Run("options for both parts", func(t *testing.T) {
+		response := decode(t, postOverview(t, router, "payments",
+			`{"prediction": {"hour": 9, "day_of_week": 0, "namespace": "payments"}, "recommendations": {"timeframe": "24h"}}`))
+		require.NotNil(t, response.Prediction)
+		assert.Equal(t, 9, response.Prediction.TargetTime.Hour)
+		assert.Equal(t, "24h", response.Recommendations.Timeframe)
+	})
+
+	t.Run("prediction failure keeps recommendations", func(t *testing.T) {
+		response := decode(t, postOverview(t, newOverviewRouter(nil), "payments", ""))
+		assert.Equal(t, "partial", response.Status)
+		assert.Nil(t, response.Prediction)
+		require.NotNil(t, response.PredictionError)
+		assert.Equal(t, "payments", response.PredictionError.Target)
+		assert.Equal(t, ErrCodeKServeUnavailable, response.PredictionError.Code)
+		assert.Equal(t, "success", response.Recommendations.Status)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		tests := []struct {
+			namespace string
+			body      string
+			want      string
+		}{
+			{namespace: "Payments", want: "invalid namespace"},
+			{namespace: "payments", body: `{"prediction": {"namespace": "billing"}}`, want: "prediction.namespace"},
+			{namespace: "payments", body: `{"prediction": {"deployment": "api"}}`, want: "namespace-scoped"},
+			{namespace: "payments", body: `{"prediction": {"scope": "cluster"}}`, want: "prediction.scope"},
+			{namespace: "payments", body: `{"prediction": {"hour": 25}}`, want: "hour must be between 0-23"},
+			{namespace: "payments", body: `{"recommendations": {"namespace": "billing"}}`, want: "recommendations.namespace"},
+			{namespace: "payments", body: `{"recommendations": {"ml_namespaces": ["billing"]}}`, want: "ml_namespaces"},
+			{namespace: "payments", body: `{"recommendations": {"timeframe": "2d"}}`, want: "recommendations: invalid timeframe"},
+			{namespace: "payments", body: `{"prediction": `, want: "Invalid request format"},
+		}
+		for _, tt := range tests {
+			rr := postOverview(t, router, tt.namespace, tt.body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, tt.body)
+			assert.Contains(t, rr.Body.String(), tt.want, tt.body)
+		}
+	})
+}
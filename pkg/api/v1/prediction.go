@@ -2,29 +2,51 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
 )
 
-// PredictionHandler handles time-specific resource prediction API requests
+// PredictionHandler handles time-specific resource prediction API requests.
+//
+// Thread safety: fields are set by the constructor or by the Set* methods, which must all be
+// called before the handler starts serving, and are only read afterwards. HandlePredict keeps
+// per-request working data (parsed request, metric values, feature vectors, model output) in
+// locals and the request context. A single handler can therefore serve concurrent requests.
+// Shared collaborators (KServe proxy, Prometheus client, feature builder) are each safe for
+// concurrent use. State written while serving must be guarded explicitly, as the response
+// cache is.
 type PredictionHandler struct {
-	kserveClient     *kserve.ProxyClient
+	kserveClient     kserve.ModelClient
 	prometheusClient *integrations.PrometheusClient
 	featureBuilder   *features.PredictiveFeatureBuilder
 	log              *logrus.Logger
 
+	// Routers and prefixes the routes are registered on
+	routes routeRegistry
+
+	// Rolling means shared with other handlers; replaced via SetMetricsSnapshot before serving
+	metricsSnapshot *integrations.MetricsSnapshot
+
 	// Default values when Prometheus is not available (Issue #58)
 	// These match the 5 features expected by the predictive-analytics model:
 	// cpu_usage, memory_usage, disk_usage, network_in, network_out
@@ -36,6 +58,128 @@ type PredictionHandler struct {
 
 	// Feature engineering configuration
 	enableFeatureEngineering bool
+	expectedFeatureCount     int // Feature count the predictive-analytics model expects (0 = not configured)
+
+	// Positional output mapping for "regression" model responses
+	regressionOutputs RegressionOutputMapping
+
+	// Metric keys read from "forecast" model responses
+	forecastKeys ForecastKeyMapping
+
+	// Time between forecast values and the default threshold for time_to_threshold
+	forecastStep     time.Duration
+	thresholdPercent float64
+
+	// Computes the confidence reported with each prediction
+	confidenceScorer ConfidenceScorer
+
+	// Per-model KServe timeouts; models without an entry use the client timeout
+	modelTimeouts map[string]time.Duration
+
+	// Cached per-model availability checked before each prediction
+	reachability *modelReachability
+
+	// Response cache and ETag time bucket (nil cache = server-side caching disabled)
+	cache       *predictionCache
+	cacheBucket time.Duration
+
+	// Per-scope rolling means learned from Prometheus, preferred over the defaults above
+	baselines    *baselineStore
+	baselineFile string
+
+	// Optional existence check for requested targets; nil skips it. Set via SetTargetChecker.
+	targetChecker *integrations.TargetChecker
+
+	// Bounds predictions doing feature engineering at once (nil = unlimited)
+	limiter *predictionLimiter
+
+	// Whether requests may ask for the raw model response (debug_raw_response)
+	debugRawResponse bool
+
+	// Whether unreachable KServe yields a heuristic prediction instead of a 503
+	degradedMode bool
+
+	// Whether a failed feature build fails the prediction instead of falling back to raw metrics
+	strictFeatureFallback bool
+
+	// Why feature engineering was requested but could not be set up; empty when it was set up
+	// or not requested. Under the strict policy such predictions fail instead of using raw metrics.
+	featureSetupFailure string
+
+	// Statistic of current CPU and memory used when a request sets no aggregation
+	metricAggregation string
+
+	// Records served predictions for trend analysis; nil disables history. Set via SetPredictionStore.
+	predictionStore *storage.PredictionStore
+
+	// Receives incidents opened from high-risk predictions; nil disables them. Set via SetPredictedIncidents.
+	incidentStore  *storage.IncidentStore
+	incidentPolicy PredictedIncidentPolicy
+	incidentMu     sync.Mutex // Serializes the active-incident check with the create
+}
+
+// DefaultPredictionModel serves predictions that name no model; it is the only model sent
+// engineered features
+const DefaultPredictionModel = "predictive-analytics"
+
+// Feature strategies reported by DescribeModelFeatures
+const (
+	// FeatureStrategyEngineered sends the full engineered feature vector (ADR-016)
+	FeatureStrategyEngineered = "engineered"
+
+	// FeatureStrategyRawMetrics sends the 5 raw metrics (Issue #58)
+	FeatureStrategyRawMetrics = "raw_metrics"
+)
+
+// Feature-build fallback policies, see PredictionHandlerConfig.FallbackPolicy
+const (
+	// FallbackPolicyLenient falls back to the 5 raw metrics, then defaults, when feature
+	// engineering fails
+	FallbackPolicyLenient = "lenient"
+
+	// FallbackPolicyStrict fails the prediction when feature engineering fails
+	FallbackPolicyStrict = "strict"
+)
+
+// rawMetricFeatureCount is the width of the raw metric instance:
+// [cpu_usage, memory_usage, disk_usage, network_in, network_out]
+const rawMetricFeatureCount = 5
+
+// RegressionOutputMapping maps positional regression model outputs to CPU/memory predictions
+type RegressionOutputMapping struct {
+	// CPUIndex is the output position holding the CPU prediction
+	CPUIndex int
+
+	// MemoryIndex is the output position holding the memory prediction
+	MemoryIndex int
+
+	// Scale converts raw outputs to percentages (1 for percentages, 100 for 0-1 fractions).
+	// A zero Scale means the mapping is unset and DefaultRegressionOutputMapping is used.
+	Scale float64
+}
+
+// DefaultRegressionOutputMapping returns the default mapping: [cpu_percent, memory_percent]
+func DefaultRegressionOutputMapping() RegressionOutputMapping {
+	return RegressionOutputMapping{
+		CPUIndex:    0,
+		MemoryIndex: 1,
+		Scale:       1,
+	}
+}
+
+// ForecastKeyMapping names the keys of a forecast response's predictions map holding the CPU
+// and memory forecasts. The first key present with a forecast is used.
+type ForecastKeyMapping struct {
+	CPUKeys    []string
+	MemoryKeys []string
+}
+
+// DefaultForecastKeyMapping returns the keys of the predictive-analytics model
+func DefaultForecastKeyMapping() ForecastKeyMapping {
+	return ForecastKeyMapping{
+		CPUKeys:    []string{"cpu_usage"},
+		MemoryKeys: []string{"memory_usage"},
+	}
 }
 
 // PredictionHandlerConfig holds configuration for the prediction handler
@@ -50,9 +194,130 @@ type PredictionHandlerConfig struct {
 	// LookbackHours is the number of hours to look back for historical data
 	LookbackHours int
 
+	// MaxLookbackHours caps LookbackHours (0 = features.DefaultMaxLookbackHours)
+	MaxLookbackHours int
+
 	// ExpectedFeatureCount is the number of features the model expects.
 	// If set (> 0), the builder will log a warning if the generated count doesn't match.
 	ExpectedFeatureCount int
+
+	// TimeFeatures selects the time-based features per timestep (empty = notebook defaults)
+	TimeFeatures []string
+
+	// FeatureBaseMetrics selects the base metrics the model was trained on (empty = all five);
+	// a subset requires ExpectedFeatureCount
+	FeatureBaseMetrics []string
+
+	// LogFeatureQueries logs every PromQL query the feature builder executes (debugging only)
+	LogFeatureQueries bool
+
+	// LogFeatureVectorStats logs summary statistics of every built feature vector for drift monitoring
+	LogFeatureVectorStats bool
+
+	// FeatureResampleRule is the bucket width metrics are resampled to before computing lags
+	// and rolling statistics (0 = point queries, see features.PredictiveFeatureConfig.ResampleRule)
+	FeatureResampleRule time.Duration
+
+	// FeatureQueryStep is the resolution of the feature builder's range queries
+	// (0 = features.DefaultQueryStep, see features.PredictiveFeatureConfig.QueryStep)
+	FeatureQueryStep time.Duration
+
+	// FeatureClusterAggregation selects how cluster-scope feature builds combine container
+	// metrics (empty = features.ClusterAggregationMean, see features.PredictiveFeatureConfig)
+	FeatureClusterAggregation string
+
+	// FeatureClusterTopNamespaces is the namespace count for features.ClusterAggregationTopNamespaces
+	// (0 = features.DefaultClusterTopNamespaces)
+	FeatureClusterTopNamespaces int
+
+	// FeatureBusinessHoursStart/End, FeatureBusinessDays and FeatureWeekendDays define the
+	// business calendar behind is_business_hours and is_weekend (zero values = 9-17, Saturday
+	// and Sunday off; see features.PredictiveFeatureConfig)
+	FeatureBusinessHoursStart int
+	FeatureBusinessHoursEnd   int
+	FeatureBusinessDays       []string
+	FeatureWeekendDays        []string
+
+	// FeatureLagPeriods and FeatureRollingWindows are the per-metric lag and rolling window
+	// hours (empty = the training notebook's, see features.PredictiveFeatureConfig)
+	FeatureLagPeriods     []int
+	FeatureRollingWindows []int
+
+	// FeatureScalerFile holds the training StandardScaler's parameters; built vectors are
+	// standardized with them (empty = raw values, see features.FeatureScaler)
+	FeatureScalerFile string
+
+	// MaxFeatureBuildDuration bounds each feature build; past it the remaining features are
+	// defaulted and the prediction's data_quality is "partial" (0 = unbounded)
+	MaxFeatureBuildDuration time.Duration
+
+	// RegressionOutputs maps positional outputs of regression models to CPU/memory percentages
+	RegressionOutputs RegressionOutputMapping
+
+	// ForecastKeys names the CPU/memory keys of forecast responses (empty lists = DefaultForecastKeyMapping)
+	ForecastKeys ForecastKeyMapping
+
+	// ForecastStep is the time between consecutive forecast values (0 = DefaultForecastStep)
+	ForecastStep time.Duration
+
+	// ThresholdPercent is the utilization time_to_threshold looks for when a request does not
+	// set threshold_percent (0 = DefaultThresholdPercent)
+	ThresholdPercent float64
+
+	// ConfidenceScorer computes prediction confidence from the raw model response
+	// (nil = DefaultConfidenceScorer with ForecastKeys)
+	ConfidenceScorer ConfidenceScorer
+
+	// ModelTimeouts overrides the KServe client timeout per model, capped at kserve.MaxRequestTimeout
+	ModelTimeouts map[string]time.Duration
+
+	// ModelReachabilityTTL is how long a probe of a model's predictor is reused before the next
+	// prediction probes it again (0 = no probing, only check that the model is registered)
+	ModelReachabilityTTL time.Duration
+
+	// CacheTTL is how long identical prediction requests are served from memory (0 = disabled).
+	// ETags are computed and If-None-Match honored regardless.
+	CacheTTL time.Duration
+
+	// CacheBucket is the time bucket folded into ETags and cache keys (0 = DefaultPredictionCacheBucket)
+	CacheBucket time.Duration
+
+	// BaselineAlpha weights each Prometheus observation in the per-scope EMA (0 = DefaultBaselineAlpha)
+	BaselineAlpha float64
+
+	// BaselineMaxEntries bounds the scopes with a learned baseline (0 = DefaultBaselineMaxEntries)
+	BaselineMaxEntries int
+
+	// BaselineFile is loaded at construction and written by SaveBaselines, so learned
+	// baselines survive restarts (empty = not persisted)
+	BaselineFile string
+
+	// MaxConcurrentPredictions bounds predictions that build engineered features at once,
+	// protecting Prometheus and KServe from bursts (0 = unlimited)
+	MaxConcurrentPredictions int
+
+	// PredictionQueueTimeout is how long a prediction waits for a free slot before a 503 with
+	// Retry-After (0 = reject immediately when all slots are busy)
+	PredictionQueueTimeout time.Duration
+
+	// DebugRawResponse allows requests to set debug_raw_response and receive the model's
+	// response payload. Keep disabled in production: payloads can be large and expose model internals.
+	DebugRawResponse bool
+
+	// DegradedMode answers with a heuristic prediction from learned baselines and the target
+	// hour, flagged with status "degraded", when KServe is unreachable instead of failing with 503
+	DegradedMode bool
+
+	// FallbackPolicy decides what happens when engineered features cannot be built:
+	// FallbackPolicyLenient (or empty) predicts from the raw metrics instead, FallbackPolicyStrict
+	// fails the request with ErrCodePredictionFailed. Strict also fails requests whose build
+	// was cut short by MaxFeatureBuildDuration, and every request for the default model when
+	// the feature builder could not be set up.
+	FallbackPolicy string
+
+	// MetricAggregation is the statistic of current CPU and memory for requests that set no
+	// aggregation, one of integrations.MetricAggregations (empty = integrations.MetricAggregationMean)
+	MetricAggregation string
 }
 
 // DefaultPredictionHandlerConfig returns the default configuration.
@@ -78,6 +343,8 @@ func DefaultPredictionHandlerConfig() PredictionHandlerConfig {
 		EnableFeatureEngineering: true,
 		LookbackHours:            defaultConfig.LookbackHours,
 		ExpectedFeatureCount:     0, // Disabled by default
+		FeatureResampleRule:      defaultConfig.ResampleRule,
+		RegressionOutputs:        DefaultRegressionOutputMapping(),
 	}
 }
 
@@ -87,7 +354,7 @@ func DefaultPredictionHandlerConfig() PredictionHandlerConfig {
 // environment variables. This function uses hardcoded defaults and ignores
 // ENABLE_FEATURE_ENGINEERING environment variable. See Issue #57.
 func NewPredictionHandler(
-	kserveClient *kserve.ProxyClient,
+	kserveClient kserve.ModelClient,
 	prometheusClient *integrations.PrometheusClient,
 	log *logrus.Logger,
 ) *PredictionHandler {
@@ -96,12 +363,13 @@ func NewPredictionHandler(
 
 // NewPredictionHandlerWithConfig creates a new prediction handler with custom configuration
 func NewPredictionHandlerWithConfig(
-	kserveClient *kserve.ProxyClient,
+	kserveClient kserve.ModelClient,
 	prometheusClient *integrations.PrometheusClient,
 	log *logrus.Logger,
 	config PredictionHandlerConfig,
 ) *PredictionHandler {
 	var featureBuilder *features.PredictiveFeatureBuilder
+	var featureSetupFailure string
 
 	// Create feature builder based on configuration and Prometheus availability
 	switch {
@@ -113,21 +381,51 @@ func NewPredictionHandlerWithConfig(
 			LookbackHours:        config.LookbackHours,
 			Enabled:              true,
 			ExpectedFeatureCount: config.ExpectedFeatureCount,
+			MaxLookbackHours:     config.MaxLookbackHours,
+			TimeFeatures:         config.TimeFeatures,
+			BaseMetrics:          config.FeatureBaseMetrics,
+			LogQueries:           config.LogFeatureQueries,
+			LogVectorStats:       config.LogFeatureVectorStats,
+			ResampleRule:         config.FeatureResampleRule,
+			ClusterAggregation:   config.FeatureClusterAggregation,
+			ClusterTopNamespaces: config.FeatureClusterTopNamespaces,
+			BusinessHoursStart:   config.FeatureBusinessHoursStart,
+			BusinessHoursEnd:     config.FeatureBusinessHoursEnd,
+			BusinessDays:         config.FeatureBusinessDays,
+			WeekendDays:          config.FeatureWeekendDays,
+			LagPeriods:           config.FeatureLagPeriods,
+			RollingWindows:       config.FeatureRollingWindows,
+			ScalerFile:           config.FeatureScalerFile,
+			QueryStep:            config.FeatureQueryStep,
+			MaxBuildDuration:     config.MaxFeatureBuildDuration,
 		}
 		if featureConfig.LookbackHours == 0 {
 			featureConfig.LookbackHours = 24 // Default
 		}
 
-		featureBuilder = features.NewPredictiveFeatureBuilder(adapter, featureConfig, log)
+		builder, err := features.NewPredictiveFeatureBuilder(adapter, featureConfig, log)
+		if err != nil {
+			log.WithError(err).Error("Invalid feature engineering configuration, falling back to raw metrics")
+			featureSetupFailure = "invalid feature engineering configuration: " + err.Error()
+			break
+		}
+		featureBuilder = builder
 		log.WithFields(logrus.Fields{
-			"lookback_hours":         featureConfig.LookbackHours,
-			"feature_count":          featureBuilder.GetFeatureInfo().TotalFeatures,
-			"base_metrics":           len(features.GetPredictiveBaseMetrics()),
+			"lookback_hours":         featureBuilder.GetFeatureInfo().LookbackHours,
+			"feature_count":          featureBuilder.FeatureCount(),
+			"base_metrics":           featureBuilder.GetFeatureInfo().BaseMetrics,
 			"expected_feature_count": config.ExpectedFeatureCount,
+			"resample_rule":          config.FeatureResampleRule.String(),
+			"query_step":             featureBuilder.GetFeatureInfo().QueryStep,
+			"cluster_aggregation":    featureBuilder.GetFeatureInfo().ClusterAggregation,
+			"scaled":                 featureBuilder.GetFeatureInfo().Scaled,
+			"lag_periods":            featureBuilder.GetFeatureInfo().LagPeriods,
+			"rolling_windows":        featureBuilder.GetFeatureInfo().RollingWindows,
 		}).Info("Predictive feature engineering enabled")
 
 	case config.EnableFeatureEngineering:
 		log.Warn("Feature engineering enabled but Prometheus not available, falling back to raw metrics")
+		featureSetupFailure = "Prometheus not available"
 
 	default:
 		// Feature engineering explicitly disabled via ENABLE_FEATURE_ENGINEERING=false (Issue #57)
@@ -136,106 +434,275 @@ func NewPredictionHandlerWithConfig(
 		}).Info("Predictive feature engineering disabled, using raw metrics only")
 	}
 
-	return &PredictionHandler{
+	regressionOutputs := config.RegressionOutputs
+	if regressionOutputs.Scale == 0 {
+		regressionOutputs = DefaultRegressionOutputMapping()
+	}
+
+	forecastKeys := config.ForecastKeys
+	if len(forecastKeys.CPUKeys) == 0 {
+		forecastKeys.CPUKeys = DefaultForecastKeyMapping().CPUKeys
+	}
+	if len(forecastKeys.MemoryKeys) == 0 {
+		forecastKeys.MemoryKeys = DefaultForecastKeyMapping().MemoryKeys
+	}
+
+	confidenceScorer := config.ConfidenceScorer
+	if confidenceScorer == nil {
+		confidenceScorer = DefaultConfidenceScorer{ForecastKeys: forecastKeys}
+	}
+
+	cacheBucket := config.CacheBucket
+	if cacheBucket <= 0 {
+		cacheBucket = DefaultPredictionCacheBucket
+	}
+
+	forecastStep := config.ForecastStep
+	if forecastStep <= 0 {
+		forecastStep = DefaultForecastStep
+	}
+	thresholdPercent := config.ThresholdPercent
+	if thresholdPercent <= 0 {
+		thresholdPercent = DefaultThresholdPercent
+	}
+
+	handler := &PredictionHandler{
 		kserveClient:             kserveClient,
 		prometheusClient:         prometheusClient,
 		featureBuilder:           featureBuilder,
 		log:                      log,
+		metricsSnapshot:          integrations.NewMetricsSnapshot(prometheusClient, 0),
 		defaultCPURollingMean:    0.65, // 65% average CPU usage
 		defaultMemoryRollingMean: 0.72, // 72% average memory usage
 		defaultDiskUsage:         0.45, // 45% average disk usage (Issue #58)
 		defaultNetworkIn:         0.10, // 10% normalized network in (Issue #58)
 		defaultNetworkOut:        0.08, // 8% normalized network out (Issue #58)
 		enableFeatureEngineering: config.EnableFeatureEngineering,
+		expectedFeatureCount:     config.ExpectedFeatureCount,
+		regressionOutputs:        regressionOutputs,
+		forecastKeys:             forecastKeys,
+		forecastStep:             forecastStep,
+		thresholdPercent:         thresholdPercent,
+		confidenceScorer:         confidenceScorer,
+		modelTimeouts:            maps.Clone(config.ModelTimeouts),
+		reachability:             newModelReachability(kserveClient, config.ModelReachabilityTTL),
+		cache:                    newPredictionCache(config.CacheTTL),
+		cacheBucket:              cacheBucket,
+		baselines:                newBaselineStore(config.BaselineAlpha, config.BaselineMaxEntries),
+		baselineFile:             config.BaselineFile,
+		limiter:                  newPredictionLimiter(config.MaxConcurrentPredictions, config.PredictionQueueTimeout),
+		debugRawResponse:         config.DebugRawResponse,
+		degradedMode:             config.DegradedMode,
+		strictFeatureFallback:    config.FallbackPolicy == FallbackPolicyStrict,
+		featureSetupFailure:      featureSetupFailure,
+		metricAggregation:        integrations.MetricAggregationMean,
+	}
+	if config.MetricAggregation != "" {
+		if slices.Contains(integrations.MetricAggregations(), config.MetricAggregation) {
+			handler.metricAggregation = config.MetricAggregation
+		} else {
+			log.WithField("aggregation", config.MetricAggregation).Warn("Unknown metric aggregation, using mean")
+		}
+	}
+	handler.metricsSnapshot.SetObserver(handler.baselines.observe)
+	handler.loadBaselines()
+	return handler
+}
+
+// loadBaselines restores baselines saved by a previous process. Failures are logged and
+// leave the handler to learn from scratch.
+func (h *PredictionHandler) loadBaselines() {
+	if h.baselineFile == "" {
+		return
+	}
+	count, err := h.baselines.load(h.baselineFile)
+	if err != nil {
+		h.log.WithError(err).WithField("file", h.baselineFile).Warn("Failed to load prediction baselines, starting cold")
+		return
+	}
+	h.log.WithFields(logrus.Fields{
+		"file":  h.baselineFile,
+		"count": count,
+	}).Info("Prediction baselines loaded from file")
+}
+
+// SaveBaselines writes the learned baselines to the configured BaselineFile. It does nothing
+// when persistence is not configured.
+func (h *PredictionHandler) SaveBaselines() error {
+	if h.baselineFile == "" {
+		return nil
+	}
+	if err := h.baselines.save(h.baselineFile); err != nil {
+		return fmt.Errorf("failed to save prediction baselines: %w", err)
 	}
+	h.log.WithField("file", h.baselineFile).Debug("Prediction baselines saved to file")
+	return nil
+}
+
+// SetMetricsSnapshot shares a metrics snapshot with other handlers so concurrent requests reuse
+// the same Prometheus queries. The handler becomes the snapshot's observer, so every fresh
+// rolling mean, whichever handler asked for it, updates the learned baselines. Must be called
+// before serving; nil is ignored.
+func (h *PredictionHandler) SetMetricsSnapshot(snapshot *integrations.MetricsSnapshot) {
+	if snapshot != nil {
+		h.metricsSnapshot = snapshot
+		snapshot.SetObserver(h.baselines.observe)
+	}
+}
+
+// SetTargetChecker makes requests for a namespace, deployment or pod that does not exist fail
+// with 400 instead of predicting from default metrics. Must be called before serving.
+func (h *PredictionHandler) SetTargetChecker(checker *integrations.TargetChecker) {
+	h.targetChecker = checker
 }
 
-// RegisterRoutes registers prediction API routes
+// RegisterRoutes registers prediction API routes under DefaultAPIPrefix
 func (h *PredictionHandler) RegisterRoutes(router *mux.Router) {
-	router.HandleFunc("/api/v1/predict", h.HandlePredict).Methods("POST")
-	h.log.Info("Prediction API endpoint registered: POST /api/v1/predict")
+	h.RegisterRoutesWithPrefix(router, DefaultAPIPrefix)
 }
 
-// PredictRequest represents the request body for time-specific predictions
-type PredictRequest struct {
-	Hour       int    `json:"hour"`        // Required: 0-23 (hour of day)
-	DayOfWeek  int    `json:"day_of_week"` // Required: 0=Monday, 6=Sunday
-	Namespace  string `json:"namespace"`   // Optional: namespace filter
-	Deployment string `json:"deployment"`  // Optional: deployment filter
-	Pod        string `json:"pod"`         // Optional: specific pod filter
-	Scope      string `json:"scope"`       // Optional: pod, deployment, namespace, cluster (default: namespace)
-	Model      string `json:"model"`       // Optional: KServe model name (default: predictive-analytics)
+// RegisterRoutesWithPrefix registers prediction API routes under prefix, e.g. /engine/api/v1
+// when embedded in a larger service; an empty prefix means DefaultAPIPrefix. Registering again
+// on the same router and prefix is a no-op.
+func (h *PredictionHandler) RegisterRoutesWithPrefix(router *mux.Router, prefix string) {
+	prefix = normalizeAPIPrefix(prefix)
+	if !h.routes.claim(router, prefix) {
+		h.log.WithField("prefix", prefix).Debug("Prediction API endpoints already registered")
+		return
+	}
+
+	router.HandleFunc(prefix+"/predict", h.HandlePredict).Methods("POST")
+	router.HandleFunc(prefix+"/predict/compare", h.HandlePredictCompare).Methods("POST")
+	router.HandleFunc(prefix+"/predict/validate", h.HandleValidatePredict).Methods("POST")
+	router.HandleFunc(prefix+"/predict/backtest", h.HandlePredictBacktest).Methods("POST")
+	router.HandleFunc(prefix+"/predict/curve", h.HandlePredictCurve).Methods("GET")
+	router.HandleFunc(prefix+"/predict/history", h.HandlePredictionHistory).Methods("GET")
+	router.Handle(prefix+"/debug/features/compare",
+		middleware.RaiseBodyLimit(FeatureCompareMaxBodyBytes)(http.HandlerFunc(h.HandleCompareFeatures))).Methods("POST")
+	router.HandleFunc(prefix+"/debug/features/queries", h.HandleFeatureQueryPlan).Methods("GET")
+	router.HandleFunc(prefix+"/debug/baselines", h.HandleListBaselines).Methods("GET")
+	router.HandleFunc(prefix+"/features/info", h.HandleFeaturesInfo).Methods("GET")
+	router.HandleFunc(prefix+"/config", h.HandleEffectiveConfig).Methods("GET")
+	h.log.Infof("Prediction API endpoints registered under %s: POST /predict, POST /predict/compare, POST /predict/validate, POST /predict/backtest, GET /predict/curve, GET /predict/history, POST /debug/features/compare, GET /debug/features/queries, GET /debug/baselines, GET /features/info, GET /config", prefix)
 }
 
-// PredictResponse represents the response for time-specific predictions
-type PredictResponse struct {
-	Status         string           `json:"status"`
-	Scope          string           `json:"scope"`
-	Target         string           `json:"target"`
-	Predictions    PredictionValues `json:"predictions"`
-	CurrentMetrics CurrentMetrics   `json:"current_metrics"`
-	ModelInfo      ModelInfo        `json:"model_info"`
-	TargetTime     TargetTimeInfo   `json:"target_time"`
+// PredictRequest is a prediction request as the handler sees it: the request body plus what
+// decoding and validation learn about it
+type PredictRequest struct {
+	types.PredictRequest
+
+	// hourOrDaySet records whether the decoded body contained hour or day_of_week,
+	// which are indistinguishable from their zero values after decoding
+	hourOrDaySet bool
+
+	// targetTime is TargetTimestamp parsed and converted to UTC during validation
+	targetTime time.Time
+
+	// queryStep is QueryStep parsed during validation (0 = the configured step)
+	queryStep time.Duration
 }
 
-// PredictionValues contains the predicted resource usage percentages
-type PredictionValues struct {
-	CPUPercent    float64 `json:"cpu_percent"`
-	MemoryPercent float64 `json:"memory_percent"`
+// UnmarshalJSON decodes a PredictRequest, recording whether hour or day_of_week were present
+func (r *PredictRequest) UnmarshalJSON(data []byte) error {
+	var presence struct {
+		Hour      *int `json:"hour"`
+		DayOfWeek *int `json:"day_of_week"`
+	}
+	if err := json.Unmarshal(data, &r.PredictRequest); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &presence); err != nil {
+		return err
+	}
+	r.hourOrDaySet = presence.Hour != nil || presence.DayOfWeek != nil
+	return nil
 }
 
-// CurrentMetrics contains the current rolling metrics from Prometheus
-type CurrentMetrics struct {
-	CPURollingMean    float64 `json:"cpu_rolling_mean"`
-	MemoryRollingMean float64 `json:"memory_rolling_mean"`
-	Timestamp         string  `json:"timestamp"`
-	TimeRange         string  `json:"time_range"`
+// checkKnownPredictFields returns an error naming the first field of data that PredictRequest
+// does not define. The decoder's DisallowUnknownFields does not reach through
+// PredictRequest.UnmarshalJSON, so the check runs on the request body type.
+func checkKnownPredictFields(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(new(types.PredictRequest))
 }
 
-// ModelInfo contains information about the KServe model used for prediction
-type ModelInfo struct {
-	Name       string  `json:"name"`
-	Version    string  `json:"version"`
-	Confidence float64 `json:"confidence"`
+// PredictValidateResponse echoes a valid prediction request with defaults applied
+type PredictValidateResponse struct {
+	Status  string         `json:"status"`
+	Request PredictRequest `json:"request"`
 }
 
-// TargetTimeInfo contains information about the prediction target time
-type TargetTimeInfo struct {
-	Hour         int    `json:"hour"`
-	DayOfWeek    int    `json:"day_of_week"`
-	ISOTimestamp string `json:"iso_timestamp"`
+// DataQualityPartial flags predictions whose feature build ran out of time, so part of the
+// model input is defaults
+const DataQualityPartial = "partial"
+
+// newRawModelResponse returns the typed payload of resp, or nil when there is none
+func newRawModelResponse(resp *kserve.ModelResponse) *RawModelResponse {
+	if resp == nil {
+		return nil
+	}
+	raw := &RawModelResponse{Type: resp.Type}
+	switch {
+	case resp.ForecastResponse != nil:
+		raw.Payload = resp.ForecastResponse
+	case resp.AnomalyResponse != nil:
+		raw.Payload = resp.AnomalyResponse
+	case resp.RegressionResponse != nil:
+		raw.Payload = resp.RegressionResponse
+	default:
+		return nil
+	}
+	return raw
 }
 
-// PredictErrorResponse represents an error response for predictions
-type PredictErrorResponse struct {
-	Status  string `json:"status"`
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
-	Code    string `json:"code"`
+// newCapacityWhatIf scales predictions to a capacity scaleFactor times the current one
+func newCapacityWhatIf(predictions PredictionValues, scaleFactor float64) *CapacityWhatIf {
+	return &CapacityWhatIf{
+		ScaleFactor: scaleFactor,
+		Baseline: CapacityPrediction{
+			CPUPercent:    predictions.CPUPercent,
+			MemoryPercent: predictions.MemoryPercent,
+		},
+		Scaled: CapacityPrediction{
+			CPUPercent:    clampPercentage(predictions.CPUPercent / scaleFactor),
+			MemoryPercent: clampPercentage(predictions.MemoryPercent / scaleFactor),
+		},
+	}
 }
 
 // Error codes for prediction failures
 const (
-	ErrCodeInvalidRequest        = "INVALID_REQUEST"
-	ErrCodePrometheusUnavailable = "PROMETHEUS_UNAVAILABLE"
-	ErrCodeKServeUnavailable     = "KSERVE_UNAVAILABLE"
-	ErrCodeModelNotFound         = "MODEL_NOT_FOUND"
-	ErrCodePredictionFailed      = "PREDICTION_FAILED"
+	ErrCodeInvalidRequest        = types.ErrCodeInvalidRequest
+	ErrCodePrometheusUnavailable = types.ErrCodePrometheusUnavailable
+	ErrCodeKServeUnavailable     = types.ErrCodeKServeUnavailable
+	ErrCodeModelNotFound         = types.ErrCodeModelNotFound
+	ErrCodePredictionFailed      = types.ErrCodePredictionFailed
+	ErrCodeFeaturesUnavailable   = types.ErrCodeFeaturesUnavailable
 )
 
 // HandlePredict handles POST /api/v1/predict
+//
+// Responses carry an ETag derived from the request, a coarse time bucket and the current
+// metric snapshot. A matching If-None-Match yields 304, and identical requests within the
+// cache TTL are answered without calling KServe.
+//
 // @Summary Get time-specific resource usage predictions
 // @Description Provides time-specific resource usage predictions using KServe ML models and Prometheus metrics
 // @Tags prediction
 // @Accept json
 // @Produce json
 // @Param request body PredictRequest true "Prediction request"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} PredictResponse
+// @Success 304 "Prediction unchanged"
 // @Failure 400 {object} PredictErrorResponse
 // @Failure 503 {object} PredictErrorResponse
 // @Router /api/v1/predict [post]
 func (h *PredictionHandler) HandlePredict(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	// Correlate all log lines of this request, including feature building and the KServe call
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
 
 	// Parse and validate request
 	req, err := h.parseAndValidateRequest(r)
@@ -244,37 +711,123 @@ func (h *PredictionHandler) HandlePredict(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	h.logPredictionRequest(req)
+	h.logPredictionRequest(ctx, req)
 
-	// Validate KServe availability
-	if err := h.validateKServeAvailability(req.Model); err != nil {
-		h.handleServiceError(w, err)
+	// Validate KServe availability; unavailable models of a fallback chain are skipped
+	models, skipped := h.availableModels(ctx, req)
+	if len(models) == 0 {
+		err := chainError(skipped)
+		if !h.respondDegraded(ctx, w, req, err) {
+			h.handleServiceError(w, err)
+		}
 		return
 	}
 
 	// Get metrics for response (used for logging and response building)
 	cpuRollingMean, memoryRollingMean := h.getMetricsWithDefaults(ctx, req)
 
-	// Build prediction instances (Issue #58: uses 5 raw metrics when feature engineering is disabled)
-	instances, featureCount := h.buildPredictionInstances(ctx, req)
+	// Debug responses carry the model payload, so they are neither cached nor served from cache
+	cacheable := !req.DebugRawResponse
+	cacheKey := predictionCacheKey(req, cpuRollingMean, memoryRollingMean, time.Now(), h.cacheBucket)
+	// The ETag is only sent with a 200 or 304, never with an error
+	etag := `"` + cacheKey + `"`
+	if cacheable {
+		cached, hit := h.cache.get(cacheKey)
+		if etagMatches(r.Header.Get("If-None-Match"), etag, hit) {
+			h.log.WithContext(ctx).WithField("etag", etag).Debug("Prediction not modified")
+			w.Header().Set("ETag", etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if hit {
+			h.log.WithContext(ctx).WithField("etag", etag).Debug("Serving prediction from cache")
+			// The cached forecast is reused, but its steps are dated from this request
+			cached.TimeToThreshold = redateTimeToThreshold(cached.TimeToThreshold, time.Now())
+			w.Header().Set("ETag", etag)
+			h.respondJSON(w, http.StatusOK, selectFields(&cached, req.Fields))
+			return
+		}
+	}
 
-	h.logPredictionInstances(featureCount, cpuRollingMean, memoryRollingMean)
+	// Feature engineering fans out into many Prometheus queries, so those predictions share
+	// a bounded number of slots; raw-metric predictions are cheap and skip the limiter
+	if h.usesFeatureEngineeringAny(models) {
+		release, ok := h.acquirePredictionSlot(w, r)
+		if !ok {
+			return
+		}
+		defer release()
+	}
+
+	// Build prediction instances for each model in turn (Issue #58: uses 5 raw metrics when
+	// feature engineering is disabled) and execute the prediction
+	response, err := h.predictWithFallback(ctx, req, models, skipped, func(ctx context.Context, req *PredictRequest) (PredictResponse, error) {
+		return h.predictWithMetrics(ctx, req, nil, cpuRollingMean, memoryRollingMean)
+	})
+	if err != nil {
+		if !h.respondDegraded(ctx, w, req, err) {
+			h.handleServiceError(w, err)
+		}
+		return
+	}
+
+	h.logPredictionSuccess(ctx, &response, response.Predictions.CPUPercent, response.Predictions.MemoryPercent, response.ModelInfo.Confidence)
+	// A later request may complete a partial build, so a partial prediction is not reused
+	if cacheable && response.DataQuality != DataQualityPartial {
+		h.cache.set(cacheKey, response)
+		w.Header().Set("ETag", etag)
+	}
+	h.recordPrediction(ctx, req, &response)
+	h.openPredictedIncident(ctx, req, &response)
+	h.respondJSON(w, http.StatusOK, selectFields(&response, req.Fields))
+}
+
+// HandleValidatePredict handles POST /api/v1/predict/validate
+//
+// Runs the same parsing and validation as /api/v1/predict without contacting KServe,
+// Prometheus or the cluster API, so clients can check input before running a prediction.
+// Unknown fields are rejected so form typos surface here; target existence is only checked
+// by /api/v1/predict.
+//
+// @Summary Validate a prediction request
+// @Description Validates a prediction request and returns it with the inferred scope and default model filled in
+// @Tags prediction
+// @Accept json
+// @Produce json
+// @Param request body PredictRequest true "Prediction request"
+// @Success 200 {object} PredictValidateResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Router /api/v1/predict/validate [post]
+func (h *PredictionHandler) HandleValidatePredict(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
 
-	// Execute prediction
-	cpuPercent, memoryPercent, confidence, modelVersion, err := h.executePrediction(ctx, req.Model, instances, cpuRollingMean, memoryRollingMean)
+	req, err := h.decodeAndValidateRequest(r, true)
 	if err != nil {
-		h.handleServiceError(w, err)
+		h.handleRequestError(w, err)
 		return
 	}
 
-	// Build and send response
-	response := h.buildPredictResponse(req, cpuPercent, memoryPercent, confidence, modelVersion, cpuRollingMean, memoryRollingMean)
-	h.logPredictionSuccess(&response, cpuPercent, memoryPercent, confidence)
-	h.respondJSON(w, http.StatusOK, response)
+	h.respondJSON(w, http.StatusOK, PredictValidateResponse{Status: "valid", Request: *req})
 }
 
-// parseAndValidateRequest parses the request body and validates it
+// parseAndValidateRequest parses the request body, validates it and checks the target exists
 func (h *PredictionHandler) parseAndValidateRequest(r *http.Request) (*PredictRequest, error) {
+	req, err := h.decodeAndValidateRequest(r, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.checkTarget(r.Context(), req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeAndValidateRequest parses the request body, validates it and fills in defaults without
+// contacting any dependency. With disallowUnknownFields, fields PredictRequest does not
+// define are rejected.
+func (h *PredictionHandler) decodeAndValidateRequest(r *http.Request, disallowUnknownFields bool) (*PredictRequest, error) {
 	// Check content type
 	contentType := r.Header.Get("Content-Type")
 	if contentType != "" && !strings.HasPrefix(contentType, "application/json") {
@@ -282,15 +835,26 @@ func (h *PredictionHandler) parseAndValidateRequest(r *http.Request) (*PredictRe
 	}
 
 	// Parse request
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.log.WithContext(r.Context()).WithError(err).Debug("Invalid predict request format")
+		return nil, decodeRequestError("Invalid request format", err)
+	}
+	if disallowUnknownFields {
+		if err := checkKnownPredictFields(body); err != nil {
+			h.log.WithContext(r.Context()).WithError(err).Debug("Predict request has unknown fields")
+			return nil, decodeRequestError("Invalid request format", err)
+		}
+	}
 	var req PredictRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.log.WithError(err).Debug("Invalid predict request format")
-		return nil, &requestError{message: "Invalid request format", details: err.Error(), code: ErrCodeInvalidRequest}
+	if err := json.Unmarshal(body, &req); err != nil {
+		h.log.WithContext(r.Context()).WithError(err).Debug("Invalid predict request format")
+		return nil, decodeRequestError("Invalid request format", err)
 	}
 
 	// Validate request
 	if err := h.validateRequest(&req); err != nil {
-		h.log.WithError(err).Debug("Predict request validation failed")
+		h.log.WithContext(r.Context()).WithError(err).Debug("Predict request validation failed")
 		return nil, &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
 	}
 
@@ -299,20 +863,66 @@ func (h *PredictionHandler) parseAndValidateRequest(r *http.Request) (*PredictRe
 	return &req, nil
 }
 
+// checkTarget verifies the request's namespace, deployment and pod exist when a target checker
+// is configured. If existence cannot be determined the request proceeds, so an API server
+// outage does not block predictions.
+func (h *PredictionHandler) checkTarget(ctx context.Context, req *PredictRequest) error {
+	if h.targetChecker == nil {
+		return nil
+	}
+
+	err := h.targetChecker.CheckTarget(ctx, req.Namespace, req.Deployment, req.Pod)
+	var notFound *integrations.TargetNotFoundError
+	if errors.As(err, &notFound) {
+		h.log.WithContext(ctx).WithError(err).Debug("Prediction target not found")
+		return &requestError{message: "target not found", details: err.Error(), code: ErrCodeInvalidRequest}
+	}
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Warn("Failed to verify prediction target, continuing without the check")
+	}
+	return nil
+}
+
 // requestError represents a request validation error
 type requestError struct {
 	message string
 	details string
 	code    string
+	status  int // HTTP status; 0 means 400 Bad Request
 }
 
 func (e *requestError) Error() string { return e.message }
 
+// statusCode returns the HTTP status the error should be reported with
+func (e *requestError) statusCode() int {
+	if e.status == 0 {
+		return http.StatusBadRequest
+	}
+	return e.status
+}
+
+// decodeRequestError wraps a JSON body decode failure, reporting bodies cut off by the
+// MaxBodySize middleware as 413 rather than as malformed JSON
+func decodeRequestError(message string, err error) *requestError {
+	if middleware.IsBodyTooLarge(err) {
+		return &requestError{
+			message: "Request body too large",
+			details: err.Error(),
+			code:    ErrCodeInvalidRequest,
+			status:  http.StatusRequestEntityTooLarge,
+		}
+	}
+	return &requestError{message: message, details: err.Error(), code: ErrCodeInvalidRequest}
+}
+
 // serviceError represents a service availability error
 type serviceError struct {
 	message string
 	details string
 	code    string
+
+	// kserveUnreachable is set when KServe could not be contacted, which degraded mode covers
+	kserveUnreachable bool
 }
 
 func (e *serviceError) Error() string { return e.message }
@@ -321,104 +931,266 @@ func (e *serviceError) Error() string { return e.message }
 func (h *PredictionHandler) handleRequestError(w http.ResponseWriter, err error) {
 	var reqErr *requestError
 	if errors.As(err, &reqErr) {
-		h.respondError(w, http.StatusBadRequest, reqErr.message, reqErr.details, reqErr.code)
+		h.respondError(w, reqErr.statusCode(), reqErr.message, reqErr.details, reqErr.code)
 	}
 }
 
-// handleServiceError handles service availability errors
+// handleServiceError handles service availability errors; any other error is answered as an
+// internal failure so a request never ends without a response
 func (h *PredictionHandler) handleServiceError(w http.ResponseWriter, err error) {
 	var svcErr *serviceError
 	if errors.As(err, &svcErr) {
 		h.respondError(w, http.StatusServiceUnavailable, svcErr.message, svcErr.details, svcErr.code)
+		return
 	}
+	h.respondError(w, http.StatusInternalServerError, "Prediction failed", err.Error(), ErrCodePredictionFailed)
 }
 
-// validateKServeAvailability checks if KServe and the requested model are available
-func (h *PredictionHandler) validateKServeAvailability(model string) error {
+// validateKServeAvailability checks if KServe and the requested model are available, reusing
+// a recent reachability probe of the model's predictor when there is one
+func (h *PredictionHandler) validateKServeAvailability(ctx context.Context, model string) error {
 	if h.kserveClient == nil {
-		return &serviceError{message: "KServe integration not enabled", details: "KServe client is not configured", code: ErrCodeKServeUnavailable}
-	}
-	if _, exists := h.kserveClient.GetModel(model); !exists {
-		return &serviceError{message: fmt.Sprintf("Model '%s' not available", model), details: "Model not found in KServe", code: ErrCodeModelNotFound}
+		return &serviceError{message: "KServe integration not enabled", details: "KServe client is not configured", code: ErrCodeKServeUnavailable, kserveUnreachable: true}
 	}
-	return nil
+	return h.reachability.check(ctx, model)
 }
 
-// getMetricsWithDefaults retrieves metrics from Prometheus or returns defaults
+// getMetricsWithDefaults retrieves metrics from Prometheus, substituting a fallback only for
+// metrics whose query failed: the scope's learned baseline if there is one, else the default
 func (h *PredictionHandler) getMetricsWithDefaults(ctx context.Context, req *PredictRequest) (cpuRollingMean, memoryRollingMean float64) {
-	cpuRollingMean, memoryRollingMean, prometheusErr := h.getScopedMetrics(ctx, req)
-	if prometheusErr != nil {
-		h.log.WithError(prometheusErr).Warn("Failed to get Prometheus metrics, using defaults")
-		return h.defaultCPURollingMean, h.defaultMemoryRollingMean
+	metrics, prometheusErr := h.getScopedMetrics(ctx, req)
+	if metrics.CPUOK && metrics.MemoryOK {
+		return metrics.CPU, metrics.Memory
+	}
+
+	baseline, _ := h.baselines.lookup(snapshotScope(req))
+	cpuRollingMean, memoryRollingMean = metrics.CPU, metrics.Memory
+	var defaulted, learned []string
+	switch {
+	case metrics.CPUOK:
+	case baseline.CPUSamples > 0:
+		cpuRollingMean = baseline.CPURollingMean
+		learned = append(learned, "cpu")
+	default:
+		cpuRollingMean = h.defaultCPURollingMean
+		defaulted = append(defaulted, "cpu")
+	}
+	switch {
+	case metrics.MemoryOK:
+	case baseline.MemorySamples > 0:
+		memoryRollingMean = baseline.MemoryRollingMean
+		learned = append(learned, "memory")
+	default:
+		memoryRollingMean = h.defaultMemoryRollingMean
+		defaulted = append(defaulted, "memory")
 	}
+
+	h.log.WithContext(ctx).WithError(prometheusErr).WithFields(logrus.Fields{
+		"baseline_metrics":  learned,
+		"defaulted_metrics": defaulted,
+	}).Warn("Failed to get some Prometheus metrics, using learned baselines or defaults for those")
 	return cpuRollingMean, memoryRollingMean
 }
 
-// buildPredictionInstances builds the feature vector for prediction
-func (h *PredictionHandler) buildPredictionInstances(ctx context.Context, req *PredictRequest) ([][]float64, int) {
+// rawMetricSnapshot holds the current disk and network values that fed a prediction
+type rawMetricSnapshot struct {
+	diskUsage  float64
+	networkIn  float64
+	networkOut float64
+	defaulted  []string
+
+	// partialFeatures is set when the engineered vector was cut short by the build's maximum
+	// duration; defaultedFeatures then counts its defaulted features
+	partialFeatures   bool
+	defaultedFeatures int
+}
+
+// buildPredictionInstancesWithTime builds the feature vector for prediction, using the given
+// time feature window for engineered features (nil = a fresh window ending now). It also
+// returns the current disk and network values the vector was built from. A failed feature
+// build falls back to raw metrics unless the fallback policy is strict, in which case it
+// returns a *serviceError. The strict policy also rejects partial vectors and models that
+// would use engineered features had the builder been set up.
+func (h *PredictionHandler) buildPredictionInstancesWithTime(ctx context.Context, req *PredictRequest, window *features.TimeFeatureWindow) ([][]float64, int, rawMetricSnapshot, error) {
+	if h.strictFeatureFallback && req.Model == DefaultPredictionModel && h.featureBuilder == nil && h.featureSetupFailure != "" {
+		return nil, 0, rawMetricSnapshot{}, &serviceError{message: "Prediction failed", details: "feature engineering unavailable: " + h.featureSetupFailure, code: ErrCodePredictionFailed}
+	}
+
 	// Use feature engineering for predictive-analytics model if enabled
-	if req.Model == "predictive-analytics" && h.featureBuilder != nil && h.enableFeatureEngineering {
-		featureVector, err := h.featureBuilder.BuildFeatures(ctx, req.Namespace, req.Deployment, req.Pod)
+	if h.usesFeatureEngineering(req.Model) {
+		if window == nil {
+			window = h.targetTimeFeatureWindow(req)
+		}
+		ctx = features.WithQueryStep(ctx, req.queryStep)
+		featureVector, err := h.featureBuilder.BuildFeaturesWithTime(ctx, window, req.Namespace, req.Deployment, req.Pod)
 		if err != nil {
-			h.log.WithError(err).Warn("Feature engineering failed, falling back to raw metrics")
+			if h.strictFeatureFallback {
+				h.log.WithContext(ctx).WithError(err).Warn("Feature engineering failed, failing prediction (strict fallback policy)")
+				return nil, 0, rawMetricSnapshot{}, &serviceError{message: "Prediction failed", details: "feature engineering failed: " + err.Error(), code: ErrCodePredictionFailed}
+			}
+			h.log.WithContext(ctx).WithError(err).Warn("Feature engineering failed, falling back to raw metrics")
 			// Issue #58: Use 5 raw metrics that match the model's training features
-			return h.buildRawMetricInstances(ctx, req)
+			instances, featureCount, rawMetrics := h.buildRawMetricInstances(ctx, req)
+			return instances, featureCount, rawMetrics, nil
+		}
+		if featureVector.Partial && h.strictFeatureFallback {
+			h.log.WithContext(ctx).WithField("defaulted_features", featureVector.DefaultedFeatures).Warn("Feature build incomplete, failing prediction (strict fallback policy)")
+			return nil, 0, rawMetricSnapshot{}, &serviceError{message: "Prediction failed",
+				details: fmt.Sprintf("feature build incomplete: %d features defaulted", featureVector.DefaultedFeatures), code: ErrCodePredictionFailed}
 		}
-		h.log.WithFields(logrus.Fields{
+		h.log.WithContext(ctx).WithFields(logrus.Fields{
 			"feature_count": featureVector.FeatureCount,
 			"metrics":       featureVector.MetricsData,
 		}).Debug("Built engineered features for prediction")
-		return [][]float64{featureVector.Features}, featureVector.FeatureCount
+		return [][]float64{featureVector.Features}, featureVector.FeatureCount, h.featureVectorSnapshot(featureVector), nil
 	}
 	// Issue #58: Use 5 raw features matching the model's expected input:
 	// [cpu_usage, memory_usage, disk_usage, network_in, network_out]
-	return h.buildRawMetricInstances(ctx, req)
+	instances, featureCount, rawMetrics := h.buildRawMetricInstances(ctx, req)
+	return instances, featureCount, rawMetrics, nil
+}
+
+// featureVectorSnapshot extracts the current disk and network values from an engineered vector.
+// Metrics the feature builder does not use are reported with their defaults, as defaulted.
+func (h *PredictionHandler) featureVectorSnapshot(featureVector *features.FeatureVector) rawMetricSnapshot {
+	defaults := h.defaultRawMetricSnapshot()
+	snapshot := rawMetricSnapshot{partialFeatures: featureVector.Partial}
+	if featureVector.Partial {
+		snapshot.defaultedFeatures = featureVector.DefaultedFeatures
+	}
+	for _, metric := range []struct {
+		name     string
+		value    *float64
+		fallback float64
+	}{
+		{"disk_usage", &snapshot.diskUsage, defaults.diskUsage},
+		{"network_in", &snapshot.networkIn, defaults.networkIn},
+		{"network_out", &snapshot.networkOut, defaults.networkOut},
+	} {
+		value, used := featureVector.MetricsData[metric.name]
+		if !used {
+			value = metric.fallback
+		}
+		*metric.value = value
+		if !used || slices.Contains(featureVector.DefaultedMetrics, metric.name) {
+			snapshot.defaulted = append(snapshot.defaulted, metric.name)
+		}
+	}
+	return snapshot
+}
+
+// targetTimeFeatureWindow returns the time features for req: those of its target_timestamp
+// when set, otherwise those of the current time
+func (h *PredictionHandler) targetTimeFeatureWindow(req *PredictRequest) *features.TimeFeatureWindow {
+	now := time.Now()
+	if req.targetTime.IsZero() {
+		return h.featureBuilder.BuildTimeFeatureWindow(now)
+	}
+	return h.featureBuilder.BuildTargetTimeFeatureWindow(now, req.targetTime)
+}
+
+// modelPrediction is the predicted values together with the model response they came from
+type modelPrediction struct {
+	PredictionValues
+
+	// response is returned to debug requests and scanned for time_to_threshold (nil = no model)
+	response *kserve.ModelResponse
 }
 
 // executePrediction calls the KServe model and processes the response
-func (h *PredictionHandler) executePrediction(ctx context.Context, model string, instances [][]float64, cpuRollingMean, memoryRollingMean float64) (cpuPercent, memoryPercent, confidence float64, modelVersion string, err error) {
-	resp, err := h.kserveClient.PredictFlexible(ctx, model, instances)
+func (h *PredictionHandler) executePrediction(ctx context.Context, model string, instances [][]float64, cpuRollingMean, memoryRollingMean float64) (predictions modelPrediction, confidence float64, modelVersion string, err error) {
+	resp, err := h.kserveClient.PredictFlexible(kserve.WithRequestTimeout(ctx, h.modelTimeouts[model]), model, instances)
 	if err != nil {
-		h.log.WithError(err).WithField("model", model).Error("KServe prediction failed")
-		return 0, 0, 0, "", &serviceError{message: "Prediction failed", details: err.Error(), code: ErrCodePredictionFailed}
+		h.log.WithContext(ctx).WithError(err).WithField("model", model).Error("KServe prediction failed")
+		var unavailableErr *kserve.ModelUnavailableError
+		return modelPrediction{}, 0, "", &serviceError{message: "Prediction failed", details: err.Error(), code: ErrCodePredictionFailed,
+			kserveUnreachable: errors.As(err, &unavailableErr)}
 	}
 
-	return h.processKServeResponse(resp, cpuRollingMean, memoryRollingMean)
+	cpuPercent, memoryPercent, confidence, modelVersion, err := h.processKServeResponse(ctx, resp, cpuRollingMean, memoryRollingMean)
+	if err != nil {
+		return modelPrediction{}, 0, "", err
+	}
+
+	predictions = modelPrediction{
+		PredictionValues: PredictionValues{CPUPercent: cpuPercent, MemoryPercent: memoryPercent},
+		response:         resp,
+	}
+	if resp.Type == "forecast" {
+		applyAdditionalForecasts(&predictions.PredictionValues, resp.ForecastResponse)
+		predictions.DefaultedMetrics = h.defaultedForecastMetrics(resp.ForecastResponse)
+		if len(predictions.DefaultedMetrics) > 0 {
+			h.log.WithContext(ctx).WithFields(logrus.Fields{
+				"model":             model,
+				"defaulted_metrics": predictions.DefaultedMetrics,
+				"response_keys":     slices.Sorted(maps.Keys(resp.ForecastResponse.Predictions)),
+				"cpu_keys":          h.forecastKeys.CPUKeys,
+				"memory_keys":       h.forecastKeys.MemoryKeys,
+			}).Warn("Forecast response has no forecast under the configured metric keys, using rolling means; check KSERVE_FORECAST_CPU_KEYS/KSERVE_FORECAST_MEMORY_KEYS against the model output")
+		}
+	}
+	return predictions, confidence, modelVersion, nil
 }
 
 // processKServeResponse processes the KServe response based on its type
-func (h *PredictionHandler) processKServeResponse(resp *kserve.ModelResponse, cpuRollingMean, memoryRollingMean float64) (cpuPercent, memoryPercent, confidence float64, modelVersion string, err error) {
+func (h *PredictionHandler) processKServeResponse(ctx context.Context, resp *kserve.ModelResponse, cpuRollingMean, memoryRollingMean float64) (cpuPercent, memoryPercent, confidence float64, modelVersion string, err error) {
 	switch resp.Type {
 	case "forecast":
 		if resp.ForecastResponse == nil {
 			return 0, 0, 0, "", &serviceError{message: "Prediction failed", details: "Empty forecast response from model", code: ErrCodePredictionFailed}
 		}
-		cpuPercent, memoryPercent, confidence = h.processForecastPredictions(resp.ForecastResponse, cpuRollingMean, memoryRollingMean)
-		return cpuPercent, memoryPercent, confidence, resp.ForecastResponse.ModelVersion, nil
+		cpuPercent, memoryPercent = h.processForecastPredictions(resp.ForecastResponse, cpuRollingMean, memoryRollingMean)
+		modelVersion = resp.ForecastResponse.ModelVersion
 	case "anomaly":
 		if resp.AnomalyResponse == nil {
 			return 0, 0, 0, "", &serviceError{message: "Prediction failed", details: "Empty anomaly response from model", code: ErrCodePredictionFailed}
 		}
-		cpuPercent, memoryPercent, confidence = h.processAnomalyPredictions(resp.AnomalyResponse, cpuRollingMean, memoryRollingMean)
-		return cpuPercent, memoryPercent, confidence, resp.AnomalyResponse.ModelVersion, nil
+		cpuPercent, memoryPercent = h.processAnomalyPredictions(resp.AnomalyResponse, cpuRollingMean, memoryRollingMean)
+		modelVersion = resp.AnomalyResponse.ModelVersion
+	case "regression":
+		if resp.RegressionResponse == nil {
+			return 0, 0, 0, "", &serviceError{message: "Prediction failed", details: "Empty regression response from model", code: ErrCodePredictionFailed}
+		}
+		cpuPercent, memoryPercent, err = h.processRegressionPredictions(resp.RegressionResponse)
+		if err != nil {
+			return 0, 0, 0, "", err
+		}
+		modelVersion = resp.RegressionResponse.ModelVersion
 	default:
 		return 0, 0, 0, "", &serviceError{message: "Prediction failed", details: "Unknown response format from model", code: ErrCodePredictionFailed}
 	}
+
+	confidence = h.confidenceScorer.Score(ConfidenceInput{
+		Response:          resp,
+		CPURollingMean:    cpuRollingMean,
+		MemoryRollingMean: memoryRollingMean,
+	})
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"cpu_percent":    cpuPercent,
+		"memory_percent": memoryPercent,
+		"confidence":     confidence,
+		"model_type":     resp.Type,
+	}).Debug("Processed model predictions")
+
+	return cpuPercent, memoryPercent, confidence, modelVersion, nil
 }
 
 // buildPredictResponse constructs the prediction response
-func (h *PredictionHandler) buildPredictResponse(req *PredictRequest, cpuPercent, memoryPercent, confidence float64, modelVersion string, cpuRollingMean, memoryRollingMean float64) PredictResponse {
-	return PredictResponse{
-		Status: "success",
-		Scope:  req.Scope,
-		Target: h.getTarget(req),
-		Predictions: PredictionValues{
-			CPUPercent:    cpuPercent,
-			MemoryPercent: memoryPercent,
-		},
+func (h *PredictionHandler) buildPredictResponse(req *PredictRequest, predictions modelPrediction, confidence float64, modelVersion string, cpuRollingMean, memoryRollingMean float64, rawMetrics rawMetricSnapshot) PredictResponse {
+	response := PredictResponse{
+		Status:      "success",
+		Scope:       req.Scope,
+		Target:      h.getTarget(req),
+		Predictions: predictions.PredictionValues,
 		CurrentMetrics: CurrentMetrics{
 			CPURollingMean:    cpuRollingMean * 100, // Convert to percentage
 			MemoryRollingMean: memoryRollingMean * 100,
+			DiskUsage:         rawMetrics.diskUsage * 100,
+			NetworkIn:         networkBytesPerSecond(rawMetrics.networkIn),
+			NetworkOut:        networkBytesPerSecond(rawMetrics.networkOut),
+			DefaultedMetrics:  rawMetrics.defaulted,
+			Aggregation:       h.requestAggregation(req),
 			Timestamp:         time.Now().UTC().Format(time.RFC3339),
 			TimeRange:         "24h",
 		},
@@ -430,16 +1202,29 @@ func (h *PredictionHandler) buildPredictResponse(req *PredictRequest, cpuPercent
 		TargetTime: TargetTimeInfo{
 			Hour:         req.Hour,
 			DayOfWeek:    req.DayOfWeek,
-			ISOTimestamp: h.calculateTargetTimestamp(req.Hour, req.DayOfWeek),
+			ISOTimestamp: h.requestTargetTimestamp(req),
 		},
 	}
+	if req.CapacityScaleFactor != nil {
+		response.CapacityWhatIf = newCapacityWhatIf(predictions.PredictionValues, *req.CapacityScaleFactor)
+	}
+	response.TimeToThreshold = h.requestTimeToThreshold(req, predictions.response, time.Now())
+	if req.DebugRawResponse {
+		response.RawModelResponse = newRawModelResponse(predictions.response)
+	}
+	if rawMetrics.partialFeatures {
+		response.DataQuality = DataQualityPartial
+		response.DefaultedFeatures = rawMetrics.defaultedFeatures
+	}
+	return response
 }
 
 // logPredictionRequest logs the incoming prediction request
-func (h *PredictionHandler) logPredictionRequest(req *PredictRequest) {
-	h.log.WithFields(logrus.Fields{
+func (h *PredictionHandler) logPredictionRequest(ctx context.Context, req *PredictRequest) {
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
 		"hour":        req.Hour,
 		"day_of_week": req.DayOfWeek,
+		"target_time": req.TargetTimestamp,
 		"namespace":   req.Namespace,
 		"deployment":  req.Deployment,
 		"pod":         req.Pod,
@@ -449,8 +1234,8 @@ func (h *PredictionHandler) logPredictionRequest(req *PredictRequest) {
 }
 
 // logPredictionInstances logs the prepared prediction instances
-func (h *PredictionHandler) logPredictionInstances(featureCount int, cpuRollingMean, memoryRollingMean float64) {
-	h.log.WithFields(logrus.Fields{
+func (h *PredictionHandler) logPredictionInstances(ctx context.Context, featureCount int, cpuRollingMean, memoryRollingMean float64) {
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
 		"feature_count":       featureCount,
 		"cpu_rolling_mean":    cpuRollingMean,
 		"memory_rolling_mean": memoryRollingMean,
@@ -459,8 +1244,8 @@ func (h *PredictionHandler) logPredictionInstances(featureCount int, cpuRollingM
 }
 
 // logPredictionSuccess logs successful prediction completion
-func (h *PredictionHandler) logPredictionSuccess(response *PredictResponse, cpuPercent, memoryPercent, confidence float64) {
-	h.log.WithFields(logrus.Fields{
+func (h *PredictionHandler) logPredictionSuccess(ctx context.Context, response *PredictResponse, cpuPercent, memoryPercent, confidence float64) {
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
 		"scope":          response.Scope,
 		"target":         response.Target,
 		"cpu_percent":    cpuPercent,
@@ -471,17 +1256,76 @@ func (h *PredictionHandler) logPredictionSuccess(response *PredictResponse, cpuP
 
 // validateRequest validates the prediction request parameters
 func (h *PredictionHandler) validateRequest(req *PredictRequest) error {
+	req.trimScope()
 	if err := h.validateTimeFields(req); err != nil {
 		return err
 	}
 	if err := h.validateScope(req); err != nil {
 		return err
 	}
-	return h.validateScopeRequirements(req)
+	if err := h.validateScopeRequirements(req); err != nil {
+		return err
+	}
+	if req.CapacityScaleFactor != nil && *req.CapacityScaleFactor <= 0 {
+		return fmt.Errorf("capacity_scale_factor must be greater than 0")
+	}
+	if req.ThresholdPercent != nil && (*req.ThresholdPercent <= 0 || *req.ThresholdPercent > 100) {
+		return fmt.Errorf("threshold_percent must be greater than 0 and at most 100")
+	}
+	if req.DebugRawResponse && !h.debugRawResponse {
+		return fmt.Errorf("debug_raw_response is disabled on this server")
+	}
+	if req.Aggregation != "" && !slices.Contains(integrations.MetricAggregations(), req.Aggregation) {
+		return fmt.Errorf("aggregation must be one of %v", integrations.MetricAggregations())
+	}
+	if err := h.validateQueryStep(req); err != nil {
+		return err
+	}
+	if err := validateModels(req); err != nil {
+		return err
+	}
+	if err := validateFields(req); err != nil {
+		return err
+	}
+	// Scope names end up in PromQL selectors, so they must be valid Kubernetes names
+	return features.ValidateScopeIdentifiers(req.Namespace, req.Deployment, req.Pod)
+}
+
+// trimScope strips surrounding whitespace from the scope fields, so a whitespace-only value
+// means "no filter" for scope inference instead of a selector on a blank label value that
+// matches nothing. Values that are still malformed are rejected by ValidateScopeIdentifiers.
+func (r *PredictRequest) trimScope() {
+	r.Namespace = strings.TrimSpace(r.Namespace)
+	r.Deployment = strings.TrimSpace(r.Deployment)
+	r.Pod = strings.TrimSpace(r.Pod)
+	r.Scope = strings.TrimSpace(r.Scope)
 }
 
-// validateTimeFields validates hour and day_of_week fields
+// validateQueryStep parses query_step and checks it fits the feature builder's windows. It is
+// only checked against the windows when feature engineering is enabled; raw-metric predictions
+// run no range queries and ignore it.
+func (h *PredictionHandler) validateQueryStep(req *PredictRequest) error {
+	if req.QueryStep == "" {
+		return nil
+	}
+	step, err := time.ParseDuration(req.QueryStep)
+	if err != nil || step <= 0 {
+		return fmt.Errorf("query_step must be a positive duration (e.g. 1m): %q", req.QueryStep)
+	}
+	if h.featureBuilder != nil {
+		if err := h.featureBuilder.ValidateQueryStep(step); err != nil {
+			return fmt.Errorf("invalid query_step: %w", err)
+		}
+	}
+	req.queryStep = step
+	return nil
+}
+
+// validateTimeFields validates hour and day_of_week fields, or target_timestamp when it is set
 func (h *PredictionHandler) validateTimeFields(req *PredictRequest) error {
+	if req.TargetTimestamp != "" {
+		return h.validateTargetTimestamp(req)
+	}
 	if req.Hour < 0 || req.Hour > 23 {
 		return fmt.Errorf("hour must be between 0-23")
 	}
@@ -491,6 +1335,23 @@ func (h *PredictionHandler) validateTimeFields(req *PredictRequest) error {
 	return nil
 }
 
+// validateTargetTimestamp parses target_timestamp, which must be in the future and may not be
+// combined with hour or day_of_week
+func (h *PredictionHandler) validateTargetTimestamp(req *PredictRequest) error {
+	if req.hourOrDaySet {
+		return fmt.Errorf("target_timestamp cannot be combined with hour or day_of_week")
+	}
+	target, err := time.Parse(time.RFC3339, req.TargetTimestamp)
+	if err != nil {
+		return fmt.Errorf("target_timestamp must be an RFC3339 timestamp (e.g. 2026-03-15T10:00:00Z)")
+	}
+	if !target.After(time.Now()) {
+		return fmt.Errorf("target_timestamp must be in the future")
+	}
+	req.targetTime = target.UTC()
+	return nil
+}
+
 // validateScope validates the scope field if provided
 func (h *PredictionHandler) validateScope(req *PredictRequest) error {
 	if req.Scope == "" {
@@ -531,12 +1392,21 @@ func (h *PredictionHandler) validateScopeRequirements(req *PredictRequest) error
 
 // setRequestDefaults sets default values for optional request fields
 func (h *PredictionHandler) setRequestDefaults(req *PredictRequest) {
+	if !req.targetTime.IsZero() {
+		req.TargetTimestamp = req.targetTime.Format(time.RFC3339)
+		req.Hour = req.targetTime.Hour()
+		req.DayOfWeek = (int(req.targetTime.Weekday()) + 6) % 7 // Monday=0
+	}
+
 	if req.Scope == "" {
 		req.Scope = h.inferScope(req)
 	}
 
-	if req.Model == "" {
-		req.Model = "predictive-analytics"
+	switch {
+	case len(req.Models) > 0:
+		req.Model = req.Models[0]
+	case req.Model == "":
+		req.Model = DefaultPredictionModel
 	}
 }
 
@@ -554,119 +1424,122 @@ func (h *PredictionHandler) inferScope(req *PredictRequest) string {
 	}
 }
 
-// getScopedMetrics retrieves CPU and memory rolling means based on the request scope
-func (h *PredictionHandler) getScopedMetrics(ctx context.Context, req *PredictRequest) (float64, float64, error) {
-	if h.prometheusClient == nil || !h.prometheusClient.IsAvailable() {
-		return h.defaultCPURollingMean, h.defaultMemoryRollingMean, fmt.Errorf("prometheus client not available")
+// getScopedMetrics retrieves CPU and memory based on the request scope, under the request's
+// aggregation. Each metric is queried independently; the error joins the failures of any
+// metric that could not be fetched. Rolling means freshly queried from Prometheus also update
+// the scope's learned baseline through the snapshot's observer; cached results, p95 and max
+// do not, so each sample is counted once and the baseline stays a mean.
+func (h *PredictionHandler) getScopedMetrics(ctx context.Context, req *PredictRequest) (integrations.RollingMeans, error) {
+	return h.metricsSnapshot.Aggregated(ctx, snapshotScope(req), h.requestAggregation(req))
+}
+
+// requestAggregation returns the request's aggregation, or the handler's default
+func (h *PredictionHandler) requestAggregation(req *PredictRequest) string {
+	if req.Aggregation != "" {
+		return req.Aggregation
 	}
+	return h.metricAggregation
+}
 
+// snapshotScope maps a request scope to the metrics snapshot key. Unknown scopes and a
+// namespace scope without a namespace fall back to cluster-wide metrics.
+func snapshotScope(req *PredictRequest) integrations.MetricsScope {
 	switch req.Scope {
-	case "cluster":
-		return h.getScopedMetricsForCluster(ctx)
 	case "namespace":
-		return h.getScopedMetricsForNamespace(ctx, req.Namespace)
+		return integrations.MetricsScope{Namespace: req.Namespace}
 	case "deployment":
-		return h.getScopedMetricsForDeployment(ctx, req.Namespace, req.Deployment)
+		return integrations.MetricsScope{Namespace: req.Namespace, Deployment: req.Deployment}
 	case "pod":
-		return h.getScopedMetricsForPod(ctx, req.Namespace, req.Pod)
+		return integrations.MetricsScope{Namespace: req.Namespace, Pod: req.Pod}
 	default:
-		return h.getScopedMetricsForCluster(ctx)
+		return integrations.MetricsScope{}
 	}
 }
 
-// getScopedMetricsForNamespace retrieves metrics for a specific namespace
-func (h *PredictionHandler) getScopedMetricsForNamespace(ctx context.Context, namespace string) (float64, float64, error) {
-	if namespace == "" {
-		return h.getScopedMetricsForCluster(ctx)
+// processForecastPredictions interprets the predictive-analytics model response with forecast data
+func (h *PredictionHandler) processForecastPredictions(resp *kserve.ForecastResponse, cpuRollingMean, memoryRollingMean float64) (float64, float64) {
+	// Default values based on rolling means
+	cpuPercent := cpuRollingMean * 100
+	memoryPercent := memoryRollingMean * 100
+
+	// Extract CPU forecast if available, using the first forecast value (closest prediction)
+	if cpuForecast, ok := findForecast(resp, h.forecastKeys.CPUKeys); ok {
+		cpuPercent = cpuForecast.Forecast[0] * 100
 	}
-	return h.getMetricsWithScope(ctx, namespace, "", "", "namespace")
-}
 
-// getScopedMetricsForDeployment retrieves metrics for a specific deployment
-func (h *PredictionHandler) getScopedMetricsForDeployment(ctx context.Context, namespace, deployment string) (float64, float64, error) {
-	return h.getMetricsWithScope(ctx, namespace, deployment, "", "deployment")
-}
+	// Extract memory forecast if available
+	if memForecast, ok := findForecast(resp, h.forecastKeys.MemoryKeys); ok {
+		memoryPercent = memForecast.Forecast[0] * 100
+	}
 
-// getScopedMetricsForPod retrieves metrics for a specific pod
-func (h *PredictionHandler) getScopedMetricsForPod(ctx context.Context, namespace, pod string) (float64, float64, error) {
-	return h.getMetricsWithScope(ctx, namespace, "", pod, "pod")
+	// Clamp values to valid percentages
+	cpuPercent = clampPercentage(cpuPercent)
+	memoryPercent = clampPercentage(memoryPercent)
+
+	return cpuPercent, memoryPercent
 }
 
-// getMetricsWithScope is a helper that queries Prometheus with the given scope parameters
-func (h *PredictionHandler) getMetricsWithScope(ctx context.Context, namespace, deployment, pod, scopeName string) (float64, float64, error) {
-	cpuValue, err := h.prometheusClient.GetScopedCPURollingMean(ctx, namespace, deployment, pod)
-	if err != nil {
-		return 0, 0, fmt.Errorf("failed to get %s CPU metrics: %w", scopeName, err)
-	}
-	memoryValue, err := h.prometheusClient.GetScopedMemoryRollingMean(ctx, namespace, deployment, pod)
-	if err != nil {
-		return cpuValue, 0, fmt.Errorf("failed to get %s memory metrics: %w", scopeName, err)
+// findForecast returns the forecast under the first of keys that has forecast values
+func findForecast(resp *kserve.ForecastResponse, keys []string) (kserve.ForecastResult, bool) {
+	for _, key := range keys {
+		if result, ok := resp.Predictions[key]; ok && len(result.Forecast) > 0 {
+			return result, true
+		}
 	}
-	return cpuValue, memoryValue, nil
+	return kserve.ForecastResult{}, false
 }
 
-// getScopedMetricsForCluster is a helper for cluster-wide metrics
-func (h *PredictionHandler) getScopedMetricsForCluster(ctx context.Context) (float64, float64, error) {
-	cpuValue, err := h.prometheusClient.GetCPURollingMean(ctx)
-	if err != nil {
-		return 0, 0, fmt.Errorf("failed to get cluster CPU metrics: %w", err)
+// defaultedForecastMetrics lists the metrics processForecastPredictions filled from rolling
+// means because none of their configured keys held a forecast
+func (h *PredictionHandler) defaultedForecastMetrics(resp *kserve.ForecastResponse) []string {
+	var defaulted []string
+	if _, ok := findForecast(resp, h.forecastKeys.CPUKeys); !ok {
+		defaulted = append(defaulted, CompareSortCPU)
 	}
-	memoryValue, err := h.prometheusClient.GetMemoryRollingMean(ctx)
-	if err != nil {
-		return cpuValue, 0, fmt.Errorf("failed to get cluster memory metrics: %w", err)
+	if _, ok := findForecast(resp, h.forecastKeys.MemoryKeys); !ok {
+		defaulted = append(defaulted, CompareSortMemory)
 	}
-	return cpuValue, memoryValue, nil
+	return defaulted
 }
 
-// processForecastPredictions interprets the predictive-analytics model response with forecast data
-func (h *PredictionHandler) processForecastPredictions(resp *kserve.ForecastResponse, cpuRollingMean, memoryRollingMean float64) (float64, float64, float64) {
-	// Default values based on rolling means
-	cpuPercent := cpuRollingMean * 100
-	memoryPercent := memoryRollingMean * 100
-	confidence := 0.85 // Base confidence
-
-	// Extract CPU forecast if available
-	if cpuForecast, ok := resp.Predictions["cpu_usage"]; ok && len(cpuForecast.Forecast) > 0 {
-		// Use the first forecast value (closest prediction)
-		cpuPercent = cpuForecast.Forecast[0] * 100
-
-		// Use confidence from the model if available
-		if len(cpuForecast.Confidence) > 0 {
-			confidence = cpuForecast.Confidence[0]
-		}
+// applyAdditionalForecasts copies disk and network forecasts into predictions when the model
+// returned them, scaled and bounded per metric: disk as a percentage, network as an unbounded
+// byte rate tagged with its unit. Metrics missing from the response are left nil so they are
+// omitted from JSON.
+func applyAdditionalForecasts(predictions *PredictionValues, resp *kserve.ForecastResponse) {
+	if resp == nil {
+		return
 	}
+	if value, ok := diskForecastMetric.firstForecast(resp); ok {
+		predictions.DiskPercent = &value
+	}
+	predictions.NetworkIn = networkInForecastMetric.firstForecastValue(resp)
+	predictions.NetworkOut = networkOutForecastMetric.firstForecastValue(resp)
+}
 
-	// Extract memory forecast if available
-	if memForecast, ok := resp.Predictions["memory_usage"]; ok && len(memForecast.Forecast) > 0 {
-		// Use the first forecast value (closest prediction)
-		memoryPercent = memForecast.Forecast[0] * 100
-
-		// Average confidence if both metrics have confidence values
-		if len(memForecast.Confidence) > 0 {
-			if cpuForecast, ok := resp.Predictions["cpu_usage"]; ok && len(cpuForecast.Confidence) > 0 {
-				confidence = (cpuForecast.Confidence[0] + memForecast.Confidence[0]) / 2
-			} else {
-				confidence = memForecast.Confidence[0]
-			}
+// processRegressionPredictions maps positional regression outputs to CPU/memory percentages
+// using the configured RegressionOutputMapping
+func (h *PredictionHandler) processRegressionPredictions(resp *kserve.RegressionResponse) (float64, float64, error) {
+	mapping := h.regressionOutputs
+	outputCount := len(resp.Outputs)
+	if mapping.CPUIndex < 0 || mapping.CPUIndex >= outputCount ||
+		mapping.MemoryIndex < 0 || mapping.MemoryIndex >= outputCount {
+		return 0, 0, &serviceError{
+			message: "Prediction failed",
+			details: fmt.Sprintf("Regression output mapping (cpu=%d, memory=%d) does not fit %d model outputs",
+				mapping.CPUIndex, mapping.MemoryIndex, outputCount),
+			code: ErrCodePredictionFailed,
 		}
 	}
 
-	// Clamp values to valid percentages
-	cpuPercent = clampPercentage(cpuPercent)
-	memoryPercent = clampPercentage(memoryPercent)
-
-	h.log.WithFields(logrus.Fields{
-		"cpu_percent":    cpuPercent,
-		"memory_percent": memoryPercent,
-		"confidence":     confidence,
-		"model_type":     "forecast",
-	}).Debug("Processed forecast predictions")
+	cpuPercent := clampPercentage(resp.Outputs[mapping.CPUIndex] * mapping.Scale)
+	memoryPercent := clampPercentage(resp.Outputs[mapping.MemoryIndex] * mapping.Scale)
 
-	return cpuPercent, memoryPercent, confidence
+	return cpuPercent, memoryPercent, nil
 }
 
 // processAnomalyPredictions interprets the anomaly-detector model response (legacy behavior)
-func (h *PredictionHandler) processAnomalyPredictions(resp *kserve.DetectResponse, cpuRollingMean, memoryRollingMean float64) (float64, float64, float64) {
+func (h *PredictionHandler) processAnomalyPredictions(resp *kserve.DetectResponse, cpuRollingMean, memoryRollingMean float64) (float64, float64) {
 	// The anomaly-detector model returns classification predictions (-1 or 1)
 	// We use the current metrics and prediction result to forecast values
 
@@ -674,86 +1547,124 @@ func (h *PredictionHandler) processAnomalyPredictions(resp *kserve.DetectRespons
 	cpuPercent := cpuRollingMean * 100
 	memoryPercent := memoryRollingMean * 100
 
-	// Calculate confidence based on model response and metric stability
-	confidence := 0.85 // Base confidence
+	// When the model returns decision scores, the issue adjustment scales with the score
+	// magnitude instead of using a fixed value
+	strength, scored := anomalyScoreStrength(resp)
 
-	// If the model predicts an issue (-1), adjust the prediction upward
-	if len(resp.Predictions) > 0 && resp.Predictions[0] == -1 {
+	switch anomalyLabel(resp) {
+	case -1:
 		// Issue predicted - increase expected resource usage
-		cpuPercent = min(cpuPercent*1.15, 100.0) // 15% increase
-		memoryPercent = min(memoryPercent*1.15, 100.0)
-		confidence = 0.92 // Higher confidence when issue is predicted
-	} else if len(resp.Predictions) > 0 && resp.Predictions[0] == 1 {
+		increase := 0.15 // 15% increase
+		if scored {
+			increase *= strength
+		}
+		cpuPercent = min(cpuPercent*(1+increase), 100.0)
+		memoryPercent = min(memoryPercent*(1+increase), 100.0)
+	case 1:
 		// Normal operation predicted - slight variation expected
 		cpuPercent *= 1 + (0.05 - 0.1*cpuRollingMean) // Small adjustment
 		memoryPercent *= 1 + (0.05 - 0.1*memoryRollingMean)
-		confidence = 0.88
 	}
 
 	// Clamp values to valid percentages
 	cpuPercent = clampPercentage(cpuPercent)
 	memoryPercent = clampPercentage(memoryPercent)
 
-	return cpuPercent, memoryPercent, confidence
+	return cpuPercent, memoryPercent
+}
+
+// anomalyScoreScale is the decision score magnitude treated as full certainty.
+// IsolationForest decision_function values rarely exceed ±0.5.
+const anomalyScoreScale = 0.5
+
+// anomalyLabel returns the first instance's label (-1 anomaly, 1 normal), falling back to
+// the sign of its score when the model returned scores only. Returns 0 when neither is present.
+func anomalyLabel(resp *kserve.DetectResponse) int {
+	if len(resp.Predictions) > 0 {
+		return resp.Predictions[0]
+	}
+	if _, scored := anomalyScoreStrength(resp); scored {
+		if resp.Scores[0] < 0 {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// anomalyScoreStrength maps the first instance's score magnitude onto [0, 1].
+// The second result is false when the response carries no usable score.
+func anomalyScoreStrength(resp *kserve.DetectResponse) (float64, bool) {
+	if len(resp.Scores) == 0 || math.IsNaN(resp.Scores[0]) || math.IsInf(resp.Scores[0], 0) {
+		return 0, false
+	}
+	return math.Min(math.Abs(resp.Scores[0])/anomalyScoreScale, 1), true
 }
 
 // processPredictions is kept for backwards compatibility with tests
 // Deprecated: Use processAnomalyPredictions or processForecastPredictions instead
-func (h *PredictionHandler) processPredictions(resp *kserve.DetectResponse, cpuRollingMean, memoryRollingMean float64) (float64, float64, float64) {
+func (h *PredictionHandler) processPredictions(resp *kserve.DetectResponse, cpuRollingMean, memoryRollingMean float64) (float64, float64) {
 	return h.processAnomalyPredictions(resp, cpuRollingMean, memoryRollingMean)
 }
 
 // buildRawMetricInstances builds the 5-feature instance for predictions (Issue #58)
 // Features: [cpu_usage, memory_usage, disk_usage, network_in, network_out]
 // This matches the predictive-analytics model's training data features.
-func (h *PredictionHandler) buildRawMetricInstances(ctx context.Context, req *PredictRequest) ([][]float64, int) {
+// The returned snapshot lists disk and network metrics that fell back to defaults.
+func (h *PredictionHandler) buildRawMetricInstances(ctx context.Context, req *PredictRequest) ([][]float64, int, rawMetricSnapshot) {
 	cpuUsage := h.defaultCPURollingMean
 	memoryUsage := h.defaultMemoryRollingMean
 	diskUsage := h.defaultDiskUsage
 	networkIn := h.defaultNetworkIn
 	networkOut := h.defaultNetworkOut
+	var defaulted []string
 
 	// Try to fetch real metrics from Prometheus if available
-	if h.prometheusClient != nil && h.prometheusClient.IsAvailable() {
+	if h.prometheusClient.IsAvailable() {
 		var err error
 
 		// Fetch CPU usage
 		cpuUsage, err = h.prometheusClient.GetScopedCPURollingMean(ctx, req.Namespace, req.Deployment, req.Pod)
 		if err != nil {
-			h.log.WithError(err).Debug("Failed to get CPU usage, using default")
+			h.log.WithContext(ctx).WithError(err).Debug("Failed to get CPU usage, using default")
 			cpuUsage = h.defaultCPURollingMean
 		}
 
 		// Fetch Memory usage
 		memoryUsage, err = h.prometheusClient.GetScopedMemoryRollingMean(ctx, req.Namespace, req.Deployment, req.Pod)
 		if err != nil {
-			h.log.WithError(err).Debug("Failed to get memory usage, using default")
+			h.log.WithContext(ctx).WithError(err).Debug("Failed to get memory usage, using default")
 			memoryUsage = h.defaultMemoryRollingMean
 		}
 
 		// Fetch Disk usage
 		diskUsage, err = h.prometheusClient.GetScopedDiskUsage(ctx, req.Namespace, req.Deployment, req.Pod)
 		if err != nil {
-			h.log.WithError(err).Debug("Failed to get disk usage, using default")
+			h.log.WithContext(ctx).WithError(err).Debug("Failed to get disk usage, using default")
 			diskUsage = h.defaultDiskUsage
+			defaulted = append(defaulted, "disk_usage")
 		}
 
 		// Fetch Network In
 		networkIn, err = h.prometheusClient.GetScopedNetworkIn(ctx, req.Namespace, req.Deployment, req.Pod)
 		if err != nil {
-			h.log.WithError(err).Debug("Failed to get network in, using default")
+			h.log.WithContext(ctx).WithError(err).Debug("Failed to get network in, using default")
 			networkIn = h.defaultNetworkIn
+			defaulted = append(defaulted, "network_in")
 		}
 
 		// Fetch Network Out
 		networkOut, err = h.prometheusClient.GetScopedNetworkOut(ctx, req.Namespace, req.Deployment, req.Pod)
 		if err != nil {
-			h.log.WithError(err).Debug("Failed to get network out, using default")
+			h.log.WithContext(ctx).WithError(err).Debug("Failed to get network out, using default")
 			networkOut = h.defaultNetworkOut
+			defaulted = append(defaulted, "network_out")
 		}
+	} else {
+		defaulted = []string{"disk_usage", "network_in", "network_out"}
 	}
 
-	h.log.WithFields(logrus.Fields{
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
 		"cpu_usage":    cpuUsage,
 		"memory_usage": memoryUsage,
 		"disk_usage":   diskUsage,
@@ -770,7 +1681,35 @@ func (h *PredictionHandler) buildRawMetricInstances(ctx context.Context, req *Pr
 		diskUsage,
 		networkIn,
 		networkOut,
-	}}, 5
+	}}, rawMetricFeatureCount, rawMetricSnapshot{
+		diskUsage:  diskUsage,
+		networkIn:  networkIn,
+		networkOut: networkOut,
+		defaulted:  defaulted,
+	}
+}
+
+// usesFeatureEngineering reports whether predictions for model are sent the engineered feature vector
+func (h *PredictionHandler) usesFeatureEngineering(model string) bool {
+	return model == DefaultPredictionModel && h.IsFeatureEngineeringEnabled()
+}
+
+// DescribeModelFeatures returns the feature strategy HandlePredict uses for model and the
+// number of features it sends
+func (h *PredictionHandler) DescribeModelFeatures(model string) (strategy string, featureCount int) {
+	if h.usesFeatureEngineering(model) {
+		return FeatureStrategyEngineered, h.featureBuilder.GetFeatureInfo().TotalFeatures
+	}
+	return FeatureStrategyRawMetrics, rawMetricFeatureCount
+}
+
+// ModelReachability reports model's predictor status and whether it can serve predictions,
+// from the same cached probe HandlePredict uses
+func (h *PredictionHandler) ModelReachability(ctx context.Context, model string) (status string, reachable bool) {
+	if h.kserveClient == nil {
+		return reachabilityStatusUnknown, false
+	}
+	return h.reachability.ModelReachability(ctx, model)
 }
 
 // IsFeatureEngineeringEnabled returns true if feature engineering is enabled
@@ -810,6 +1749,15 @@ func (h *PredictionHandler) getTarget(req *PredictRequest) string {
 	}
 }
 
+// requestTargetTimestamp returns the request's target_timestamp in UTC, or the next time
+// matching its hour and day_of_week
+func (h *PredictionHandler) requestTargetTimestamp(req *PredictRequest) string {
+	if !req.targetTime.IsZero() {
+		return req.targetTime.Format(time.RFC3339)
+	}
+	return h.calculateTargetTimestamp(req.Hour, req.DayOfWeek)
+}
+
 // calculateTargetTimestamp calculates the ISO timestamp for the prediction target time
 func (h *PredictionHandler) calculateTargetTimestamp(hour, dayOfWeek int) string {
 	now := time.Now().UTC()
@@ -844,9 +1792,10 @@ func (h *PredictionHandler) calculateTargetTimestamp(hour, dayOfWeek int) string
 	return targetTime.Format(time.RFC3339)
 }
 
-// clampPercentage ensures a percentage value is within 0-100 range
+// clampPercentage ensures a percentage value is within 0-100 range. NaN, which no comparison
+// catches, becomes 0.
 func clampPercentage(value float64) float64 {
-	if value < 0 {
+	if math.IsNaN(value) || value < 0 {
 		return 0
 	}
 	if value > 100 {
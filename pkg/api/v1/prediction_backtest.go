@@ -0,0 +1,260 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+// DefaultBacktestHorizon is how long before the target time a backtest prediction is made
+// when as_of is not given
+const DefaultBacktestHorizon = time.Hour
+
+// PredictBacktestRequest is the request body for replaying a prediction against history
+type PredictBacktestRequest struct {
+	TargetTimestamp string `json:"target_timestamp"`     // Required: RFC3339 past time the prediction is for
+	AsOf            string `json:"as_of,omitempty"`      // Optional: RFC3339 time the prediction is made at (default: 1h before target)
+	Namespace       string `json:"namespace"`            // Optional: namespace filter
+	Deployment      string `json:"deployment,omitempty"` // Optional: deployment filter
+	Pod             string `json:"pod,omitempty"`        // Optional: specific pod filter
+	Scope           string `json:"scope,omitempty"`      // Optional: inferred from the fields above
+	Model           string `json:"model,omitempty"`      // Optional: KServe model name (default: predictive-analytics)
+}
+
+// PredictBacktestResponse compares a replayed prediction with the utilization that occurred
+type PredictBacktestResponse struct {
+	Status          string           `json:"status"`
+	Scope           string           `json:"scope"`
+	Target          string           `json:"target"`
+	AsOf            string           `json:"as_of"`
+	TargetTimestamp string           `json:"target_timestamp"`
+	Predicted       PredictionValues `json:"predicted"`
+	Actual          ActualValues     `json:"actual"`
+	Accuracy        BacktestAccuracy `json:"accuracy"`
+	ModelInfo       ModelInfo        `json:"model_info"`
+
+	// DefaultedMetrics lists base metrics with no data at as_of, whose features were defaulted
+	DefaultedMetrics []string `json:"defaulted_metrics,omitempty"`
+}
+
+// ActualValues is the utilization observed at the target time, in percent
+type ActualValues struct {
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+}
+
+// BacktestAccuracy holds the absolute prediction errors in percentage points
+type BacktestAccuracy struct {
+	CPUAbsoluteError    float64 `json:"cpu_absolute_error"`
+	MemoryAbsoluteError float64 `json:"memory_absolute_error"`
+	MeanAbsoluteError   float64 `json:"mean_absolute_error"`
+}
+
+// HandlePredictBacktest handles POST /api/v1/predict/backtest
+// @Summary Backtest a prediction against observed utilization
+// @Description Builds features from data up to as_of, predicts the target time and compares the result with the utilization Prometheus recorded then
+// @Tags prediction
+// @Accept json
+// @Produce json
+// @Param request body PredictBacktestRequest true "Backtest request"
+// @Success 200 {object} PredictBacktestResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Failure 503 {object} PredictErrorResponse
+// @Router /api/v1/predict/backtest [post]
+func (h *PredictionHandler) HandlePredictBacktest(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
+
+	req, asOf, target, err := h.parseBacktestRequest(r)
+	if err != nil {
+		h.handleRequestError(w, err)
+		return
+	}
+
+	if err := h.validateKServeAvailability(ctx, req.Model); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	// Raw-metric instances only exist for the current time, so history can only be replayed
+	// through the feature builder
+	if !h.usesFeatureEngineering(req.Model) {
+		h.respondError(w, http.StatusServiceUnavailable, "Backtesting unavailable",
+			fmt.Sprintf("model %s is not served engineered features; backtesting requires feature engineering and Prometheus", req.Model),
+			ErrCodeFeaturesUnavailable)
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"as_of":       asOf.Format(time.RFC3339),
+		"target_time": target.Format(time.RFC3339),
+		"target":      h.getTarget(req),
+		"model":       req.Model,
+	}).Info("Processing prediction backtest request")
+
+	release, ok := h.acquirePredictionSlot(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	response, err := h.runBacktest(ctx, req, asOf, target)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"target":              response.Target,
+		"mean_absolute_error": response.Accuracy.MeanAbsoluteError,
+	}).Info("Prediction backtest completed")
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// parseBacktestRequest decodes and validates a backtest request, returning it as a prediction
+// request together with the as-of and target times
+func (h *PredictionHandler) parseBacktestRequest(r *http.Request) (*PredictRequest, time.Time, time.Time, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "" && !strings.HasPrefix(contentType, "application/json") {
+		return nil, time.Time{}, time.Time{}, &requestError{message: "Content-Type must be application/json", code: ErrCodeInvalidRequest}
+	}
+
+	var backtestReq PredictBacktestRequest
+	if err := json.NewDecoder(r.Body).Decode(&backtestReq); err != nil {
+		return nil, time.Time{}, time.Time{}, decodeRequestError("Invalid request format", err)
+	}
+
+	asOf, target, err := backtestTimes(backtestReq, time.Now())
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
+	}
+
+	req := &PredictRequest{PredictRequest: types.PredictRequest{
+		Namespace:  backtestReq.Namespace,
+		Deployment: backtestReq.Deployment,
+		Pod:        backtestReq.Pod,
+		Scope:      backtestReq.Scope,
+		Model:      backtestReq.Model,
+	}}
+	req.trimScope()
+	if err := h.validateScope(req); err != nil {
+		return nil, time.Time{}, time.Time{}, &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
+	}
+	if err := h.validateScopeRequirements(req); err != nil {
+		return nil, time.Time{}, time.Time{}, &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
+	}
+	if err := features.ValidateScopeIdentifiers(req.Namespace, req.Deployment, req.Pod); err != nil {
+		return nil, time.Time{}, time.Time{}, &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
+	}
+	h.setRequestDefaults(req)
+	req.TargetTimestamp = target.Format(time.RFC3339)
+	req.Hour = target.Hour()
+	req.DayOfWeek = (int(target.Weekday()) + 6) % 7 // Monday=0
+
+	if err := h.checkTarget(r.Context(), req); err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+	return req, asOf, target, nil
+}
+
+// backtestTimes parses the request's target and as-of times. Both must be in the past, and the
+// prediction cannot be made after the time it is for.
+func backtestTimes(req PredictBacktestRequest, now time.Time) (asOf, target time.Time, err error) {
+	if req.TargetTimestamp == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("target_timestamp is required")
+	}
+	target, err = time.Parse(time.RFC3339, req.TargetTimestamp)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("target_timestamp must be an RFC3339 timestamp (e.g. 2026-03-15T10:00:00Z)")
+	}
+	if !target.Before(now) {
+		return time.Time{}, time.Time{}, fmt.Errorf("target_timestamp must be in the past")
+	}
+
+	asOf = target.Add(-DefaultBacktestHorizon)
+	if req.AsOf != "" {
+		asOf, err = time.Parse(time.RFC3339, req.AsOf)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("as_of must be an RFC3339 timestamp (e.g. 2026-03-15T09:00:00Z)")
+		}
+		if asOf.After(target) {
+			return time.Time{}, time.Time{}, fmt.Errorf("as_of must not be after target_timestamp")
+		}
+	}
+	return asOf.UTC(), target.UTC(), nil
+}
+
+// runBacktest predicts target from data up to asOf and compares the prediction with the
+// utilization recorded at target
+func (h *PredictionHandler) runBacktest(ctx context.Context, req *PredictRequest, asOf, target time.Time) (PredictBacktestResponse, error) {
+	window := h.featureBuilder.BuildHistoricalTimeFeatureWindow(asOf, target)
+	featureVector, err := h.featureBuilder.BuildFeaturesWithTime(ctx, window, req.Namespace, req.Deployment, req.Pod)
+	if err != nil {
+		return PredictBacktestResponse{}, &serviceError{message: "Feature engineering failed", details: err.Error(), code: ErrCodeFeaturesUnavailable}
+	}
+
+	// The utilization at asOf stands in for the rolling means models fall back on
+	cpuRollingMean, err := h.prometheusClient.GetScopedCPUUtilizationAt(ctx, req.Namespace, req.Deployment, req.Pod, asOf)
+	if err != nil {
+		cpuRollingMean = h.defaultCPURollingMean
+	}
+	memoryRollingMean, err := h.prometheusClient.GetScopedMemoryUtilizationAt(ctx, req.Namespace, req.Deployment, req.Pod, asOf)
+	if err != nil {
+		memoryRollingMean = h.defaultMemoryRollingMean
+	}
+
+	predictions, confidence, modelVersion, err := h.executePrediction(ctx, req.Model, [][]float64{featureVector.Features}, cpuRollingMean, memoryRollingMean)
+	if err != nil {
+		return PredictBacktestResponse{}, err
+	}
+
+	actual, err := h.actualUtilization(ctx, req, target)
+	if err != nil {
+		return PredictBacktestResponse{}, err
+	}
+
+	cpuError := math.Abs(predictions.CPUPercent - actual.CPUPercent)
+	memoryError := math.Abs(predictions.MemoryPercent - actual.MemoryPercent)
+	return PredictBacktestResponse{
+		Status:          "success",
+		Scope:           req.Scope,
+		Target:          h.getTarget(req),
+		AsOf:            asOf.Format(time.RFC3339),
+		TargetTimestamp: target.Format(time.RFC3339),
+		Predicted:       predictions.PredictionValues,
+		Actual:          actual,
+		Accuracy: BacktestAccuracy{
+			CPUAbsoluteError:    cpuError,
+			MemoryAbsoluteError: memoryError,
+			MeanAbsoluteError:   (cpuError + memoryError) / 2,
+		},
+		ModelInfo: ModelInfo{
+			Name:       req.Model,
+			Version:    modelVersion,
+			Confidence: confidence,
+		},
+		DefaultedMetrics: featureVector.DefaultedMetrics,
+	}, nil
+}
+
+// actualUtilization reads the CPU and memory utilization Prometheus recorded at target
+func (h *PredictionHandler) actualUtilization(ctx context.Context, req *PredictRequest, target time.Time) (ActualValues, error) {
+	cpu, err := h.prometheusClient.GetScopedCPUUtilizationAt(ctx, req.Namespace, req.Deployment, req.Pod, target)
+	if err != nil {
+		return ActualValues{}, &serviceError{message: "Actual CPU utilization unavailable", details: err.Error(), code: ErrCodePrometheusUnavailable}
+	}
+	memory, err := h.prometheusClient.GetScopedMemoryUtilizationAt(ctx, req.Namespace, req.Deployment, req.Pod, target)
+	if err != nil {
+		return ActualValues{}, &serviceError{message: "Actual memory utilization unavailable", details: err.Error(), code: ErrCodePrometheusUnavailable}
+	}
+	return ActualValues{CPUPercent: cpu * 100, MemoryPercent: memory * 100}, nil
+}
@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+func TestBacktestTimes(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		req        PredictBacktestRequest
+		wantAsOf   string
+		wantTarget string
+		wantErr    string
+	}{
+		{
+			name:       "default horizon",
+			req:        PredictBacktestRequest{TargetTimestamp: "2026-03-15T10:00:00+02:00"},
+			wantAsOf:   "2026-03-15T07:00:00Z",
+			wantTarget: "2026-03-15T08:00:00Z",
+		},
+		{
+			name:       "explicit as_of",
+			req:        PredictBacktestRequest{TargetTimestamp: "2026-03-15T10:00:00Z", AsOf: "2026-03-14T10:00:00Z"},
+			wantAsOf:   "2026-03-14T10:00:00Z",
+			wantTarget: "2026-03-15T10:00:00Z",
+		},
+		{name: "missing target", req: PredictBacktestRequest{}, wantErr: "target_timestamp is required"},
+		{name: "bad target", req: PredictBacktestRequest{TargetTimestamp: "yesterday"}, wantErr: "RFC3339"},
+		{name: "future target", req: PredictBacktestRequest{TargetTimestamp: "2026-03-21T10:00:00Z"}, wantErr: "must be in the past"},
+		{name: "bad as_of", req: PredictBacktestRequest{TargetTimestamp: "2026-03-15T10:00:00Z", AsOf: "soon"}, wantErr: "as_of must be"},
+		{
+			name:    "as_of after target",
+			req:     PredictBacktestRequest{TargetTimestamp: "2026-03-15T10:00:00Z", AsOf: "2026-03-15T11:00:00Z"},
+			wantErr: "must not be after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asOf, target, err := backtestTimes(tt.req, now)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAsOf, asOf.Format(time.RFC3339))
+			assert.Equal(t, tt.wantTarget, target.Format(time.RFC3339))
+		})
+	}
+}
+
+// newBacktestPrometheusServer serves range queries with 0.25 and point-in-time queries with
+// 0.3 at actualTime and 0.2 otherwise. It records the latest time read by any query other
+// than those at actualTime, and whether any instant query was made without a time, which
+// would read current data.
+func newBacktestPrometheusServer(actualTime time.Time) (*httptest.Server, func() (time.Time, bool)) {
+	var mu sync.Mutex
+	var latestEnd time.Time
+	var currentRead bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		if strings.HasSuffix(r.URL.Path, "/query_range") {
+			var end float64
+			_, _ = fmt.Sscanf(query.Get("end"), "%g", &end)
+			mu.Lock()
+			if endTime := time.Unix(int64(end), 0); endTime.After(latestEnd) {
+				latestEnd = endTime
+			}
+			mu.Unlock()
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[%d,"0.25"]]}]}}`, int64(end))
+			return
+		}
+
+		value := "0.2"
+		switch query.Get("time") {
+		case "":
+			mu.Lock()
+			currentRead = true
+			mu.Unlock()
+		case fmt.Sprintf("%d", actualTime.Unix()):
+			value = "0.3"
+		default:
+			var at int64
+			_, _ = fmt.Sscanf(query.Get("time"), "%d", &at)
+			mu.Lock()
+			if atTime := time.Unix(at, 0); atTime.After(latestEnd) {
+				latestEnd = atTime
+			}
+			mu.Unlock()
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,%q]}]}}`, value)
+	}))
+
+	return server, func() (time.Time, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		return latestEnd, currentRead
+	}
+}
+
+// TestPredictionHandler_HandlePredictBacktest verifies features are built from data up to
+// as_of only and the prediction is compared with the utilization at the target time
+func TestPredictionHandler_HandlePredictBacktest(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	target := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Hour)
+	asOf := target.Add(-2 * time.Hour)
+	server, observed := newBacktestPrometheusServer(target)
+	defer server.Close()
+
+	client := &fakeModelClient{
+		models: map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{
+			Type:               "regression",
+			RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}, ModelVersion: "r1"},
+		},
+	}
+	prom := integrations.NewPrometheusClient(server.URL, 5*time.Second, log)
+	handler := NewPredictionHandlerWithConfig(client, prom, log, PredictionHandlerConfig{
+		EnableFeatureEngineering: true,
+		LookbackHours:            2,
+	})
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/predict/backtest", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(fmt.Sprintf(`{"target_timestamp": %q, "as_of": %q, "namespace": "payments", "deployment": "api"}`,
+		target.Format(time.RFC3339), asOf.Format(time.RFC3339)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp PredictBacktestResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "success", resp.Status)
+	assert.Equal(t, "payments/api", resp.Target)
+	assert.Equal(t, asOf.Format(time.RFC3339), resp.AsOf)
+	assert.Equal(t, target.Format(time.RFC3339), resp.TargetTimestamp)
+	assert.InDelta(t, 42.0, resp.Predicted.CPUPercent, 0.001)
+	assert.InDelta(t, 30.0, resp.Actual.CPUPercent, 0.001)
+	assert.InDelta(t, 30.0, resp.Actual.MemoryPercent, 0.001)
+	assert.InDelta(t, 12.0, resp.Accuracy.CPUAbsoluteError, 0.001)
+	assert.InDelta(t, 12.0, resp.Accuracy.MemoryAbsoluteError, 0.001)
+	assert.InDelta(t, 12.0, resp.Accuracy.MeanAbsoluteError, 0.001)
+	assert.Equal(t, "r1", resp.ModelInfo.Version)
+
+	latestEnd, currentRead := observed()
+	assert.False(t, latestEnd.After(asOf), "features read data after as_of: %s", latestEnd)
+	assert.False(t, currentRead, "no query reads current data")
+	require.Len(t, client.instances, 1)
+	assert.Len(t, client.instances[0], handler.featureBuilder.FeatureCount())
+
+	t.Run("future target rejected", func(t *testing.T) {
+		w := post(fmt.Sprintf(`{"target_timestamp": %q}`, time.Now().Add(time.Hour).Format(time.RFC3339)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("requires feature engineering", func(t *testing.T) {
+		rawHandler := NewPredictionHandlerWithConfig(client, prom, log, PredictionHandlerConfig{})
+		req := httptest.NewRequest("POST", "/api/v1/predict/backtest",
+			strings.NewReader(fmt.Sprintf(`{"target_timestamp": %q}`, target.Format(time.RFC3339))))
+		w := httptest.NewRecorder()
+		rawHandler.HandlePredictBacktest(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var errResp PredictErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&errResp))
+		assert.Equal(t, ErrCodeFeaturesUnavailable, errResp.Code)
+	})
+}
@@ -0,0 +1,190 @@
+package v1
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+)
+
+// Defaults for learned prediction baselines when PredictionHandlerConfig leaves them unset
+const (
+	DefaultBaselineAlpha      = 0.2
+	DefaultBaselineMaxEntries = 500
+)
+
+// BaselineFileName is the file in DATA_DIR that learned baselines are persisted to
+const BaselineFileName = "prediction_baselines.json"
+
+// ScopeBaseline is the learned exponential moving average of one scope's rolling means.
+// A metric is only meaningful when its sample count is non-zero.
+type ScopeBaseline struct {
+	Namespace         string    `json:"namespace,omitempty"`
+	Deployment        string    `json:"deployment,omitempty"`
+	Pod               string    `json:"pod,omitempty"`
+	CPURollingMean    float64   `json:"cpu_rolling_mean"`
+	MemoryRollingMean float64   `json:"memory_rolling_mean"`
+	CPUSamples        int       `json:"cpu_samples"`
+	MemorySamples     int       `json:"memory_samples"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// baselineStore is a bounded LRU of per-scope baselines, updated from successful
+// Prometheus reads. It is safe for concurrent use.
+type baselineStore struct {
+	alpha      float64
+	maxEntries int
+
+	mu    sync.Mutex
+	order *list.List // of *ScopeBaseline, most recently used first
+	index map[integrations.MetricsScope]*list.Element
+}
+
+func newBaselineStore(alpha float64, maxEntries int) *baselineStore {
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultBaselineAlpha
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultBaselineMaxEntries
+	}
+	return &baselineStore{
+		alpha:      alpha,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		index:      make(map[integrations.MetricsScope]*list.Element),
+	}
+}
+
+// observe folds the metrics that were fetched into the scope's baseline. The first sample of
+// a metric seeds the average directly.
+func (s *baselineStore) observe(scope integrations.MetricsScope, means integrations.RollingMeans) {
+	if !means.CPUOK && !means.MemoryOK {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var baseline *ScopeBaseline
+	if elem, ok := s.index[scope]; ok {
+		s.order.MoveToFront(elem)
+		baseline = elem.Value.(*ScopeBaseline)
+	} else {
+		baseline = &ScopeBaseline{Namespace: scope.Namespace, Deployment: scope.Deployment, Pod: scope.Pod}
+		s.index[scope] = s.order.PushFront(baseline)
+		if s.order.Len() > s.maxEntries {
+			oldest := s.order.Back()
+			s.order.Remove(oldest)
+			evicted := oldest.Value.(*ScopeBaseline)
+			delete(s.index, integrations.MetricsScope{Namespace: evicted.Namespace, Deployment: evicted.Deployment, Pod: evicted.Pod})
+		}
+	}
+
+	if means.CPUOK {
+		baseline.CPURollingMean = s.update(baseline.CPURollingMean, means.CPU, baseline.CPUSamples)
+		baseline.CPUSamples++
+	}
+	if means.MemoryOK {
+		baseline.MemoryRollingMean = s.update(baseline.MemoryRollingMean, means.Memory, baseline.MemorySamples)
+		baseline.MemorySamples++
+	}
+	baseline.UpdatedAt = time.Now().UTC()
+}
+
+func (s *baselineStore) update(average, value float64, samples int) float64 {
+	if samples == 0 {
+		return value
+	}
+	return s.alpha*value + (1-s.alpha)*average
+}
+
+// lookup returns a copy of the scope's baseline and marks it recently used
+func (s *baselineStore) lookup(scope integrations.MetricsScope) (ScopeBaseline, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.index[scope]
+	if !ok {
+		return ScopeBaseline{}, false
+	}
+	s.order.MoveToFront(elem)
+	return *elem.Value.(*ScopeBaseline), true
+}
+
+// snapshot returns copies of all baselines, most recently used first
+func (s *baselineStore) snapshot() []ScopeBaseline {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	baselines := make([]ScopeBaseline, 0, s.order.Len())
+	for elem := s.order.Front(); elem != nil; elem = elem.Next() {
+		baselines = append(baselines, *elem.Value.(*ScopeBaseline))
+	}
+	return baselines
+}
+
+// save writes all baselines to path, most recently used first. The file is replaced
+// atomically so a crash mid-write leaves the previous version intact.
+func (s *baselineStore) save(path string) error {
+	data, err := json.MarshalIndent(s.snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baselines: %w", err)
+	}
+
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
+
+// load replaces the store's baselines with those saved at path, keeping their recency order
+// and the store's size limit. A missing file loads nothing and is not an error; on any other
+// error the store is left unchanged. Returns the number of baselines loaded.
+func (s *baselineStore) load(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read baselines file: %w", err)
+	}
+
+	var baselines []ScopeBaseline
+	if err := json.Unmarshal(data, &baselines); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal baselines: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.order.Init()
+	clear(s.index)
+	for i := range baselines {
+		baseline := baselines[i]
+		baseline.CPUSamples = max(baseline.CPUSamples, 0)
+		baseline.MemorySamples = max(baseline.MemorySamples, 0)
+		if baseline.CPUSamples == 0 && baseline.MemorySamples == 0 {
+			continue
+		}
+		scope := integrations.MetricsScope{Namespace: baseline.Namespace, Deployment: baseline.Deployment, Pod: baseline.Pod}
+		if _, ok := s.index[scope]; ok {
+			continue
+		}
+		s.index[scope] = s.order.PushBack(&baseline)
+		if s.order.Len() == s.maxEntries {
+			break
+		}
+	}
+	return s.order.Len(), nil
+}
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+)
+
+func TestBaselineStore_ObserveEMA(t *testing.T) {
+	store := newBaselineStore(0.5, 10)
+	scope := integrations.MetricsScope{Namespace: "payments"}
+
+	store.observe(scope, integrations.RollingMeans{CPU: 0.4, Memory: 0.6, CPUOK: true, MemoryOK: true})
+	store.observe(scope, integrations.RollingMeans{CPU: 0.8, CPUOK: true})
+
+	baseline, ok := store.lookup(scope)
+	require.True(t, ok)
+	assert.InDelta(t, 0.6, baseline.CPURollingMean, 0.0001, "first sample seeds, second is averaged")
+	assert.InDelta(t, 0.6, baseline.MemoryRollingMean, 0.0001, "failed memory read leaves the average alone")
+	assert.Equal(t, 2, baseline.CPUSamples)
+	assert.Equal(t, 1, baseline.MemorySamples)
+	assert.Equal(t, "payments", baseline.Namespace)
+
+	store.observe(integrations.MetricsScope{Namespace: "other"}, integrations.RollingMeans{})
+	_, ok = store.lookup(integrations.MetricsScope{Namespace: "other"})
+	assert.False(t, ok, "a read with no metrics creates no entry")
+}
+
+func TestBaselineStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := newBaselineStore(0, 2)
+	means := integrations.RollingMeans{CPU: 0.5, CPUOK: true}
+	first := integrations.MetricsScope{Namespace: "a"}
+	second := integrations.MetricsScope{Namespace: "b"}
+	third := integrations.MetricsScope{Namespace: "c"}
+
+	store.observe(first, means)
+	store.observe(second, means)
+	_, _ = store.lookup(first) // first is now more recent than second
+	store.observe(third, means)
+
+	_, ok := store.lookup(second)
+	assert.False(t, ok)
+	_, ok = store.lookup(first)
+	assert.True(t, ok)
+
+	baselines := store.snapshot()
+	require.Len(t, baselines, 2)
+	assert.Equal(t, "a", baselines[0].Namespace, "most recently used first")
+	assert.Equal(t, DefaultBaselineAlpha, store.alpha)
+}
+
+// TestPredictionHandler_GetMetricsWithDefaults_LearnedBaseline verifies that without Prometheus
+// a scope falls back to its learned baseline and unseen scopes to the global default
+func TestPredictionHandler_GetMetricsWithDefaults_LearnedBaseline(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	handler := NewPredictionHandler(nil, nil, log)
+	handler.baselines.observe(integrations.MetricsScope{Namespace: "payments"},
+		integrations.RollingMeans{CPU: 0.3, CPUOK: true})
+
+	cpu, memory := handler.getMetricsWithDefaults(context.Background(), &PredictRequest{PredictRequest: types.PredictRequest{Scope: "namespace", Namespace: "payments"}})
+	assert.InDelta(t, 0.3, cpu, 0.0001, "learned CPU baseline replaces the default")
+	assert.Equal(t, handler.defaultMemoryRollingMean, memory, "memory was never observed")
+
+	cpu, memory = handler.getMetricsWithDefaults(context.Background(), &PredictRequest{PredictRequest: types.PredictRequest{Scope: "namespace", Namespace: "checkout"}})
+	assert.Equal(t, handler.defaultCPURollingMean, cpu)
+	assert.Equal(t, handler.defaultMemoryRollingMean, memory)
+}
+
+// TestPredictionHandler_GetScopedMetrics_LearnsBaseline verifies successful reads update the
+// scope's baseline and show up on the debug endpoint
+func TestPredictionHandler_GetScopedMetrics_LearnsBaseline(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	server := newPartialPrometheusServer("0.42")
+	defer server.Close()
+
+	handler := NewPredictionHandler(nil, integrations.NewPrometheusClient(server.URL, 5*time.Second, log), log)
+	_, err := handler.getScopedMetrics(context.Background(), &PredictRequest{PredictRequest: types.PredictRequest{Scope: "deployment", Namespace: "payments", Deployment: "api"}})
+	require.Error(t, err, "memory query fails")
+
+	// The CPU value is now served from the Prometheus client's cache, which is not a new sample
+	_, err = handler.getScopedMetrics(context.Background(), &PredictRequest{PredictRequest: types.PredictRequest{Scope: "deployment", Namespace: "payments", Deployment: "api"}})
+	require.Error(t, err)
+
+	w := httptest.NewRecorder()
+	handler.HandleListBaselines(w, httptest.NewRequest("GET", "/api/v1/debug/baselines", http.NoBody))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp BaselinesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Count)
+	assert.Equal(t, "api", resp.Baselines[0].Deployment)
+	assert.InDelta(t, 0.42, resp.Baselines[0].CPURollingMean, 0.0001)
+	assert.Equal(t, 1, resp.Baselines[0].CPUSamples)
+	assert.Equal(t, 0, resp.Baselines[0].MemorySamples)
+}
+
+// TestBaselineStore_SaveLoad verifies a round trip keeps values and recency order, and that a
+// smaller store keeps only the most recent baselines
+func TestBaselineStore_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), BaselineFileName)
+	store := newBaselineStore(0.5, 10)
+	store.observe(integrations.MetricsScope{Namespace: "a"}, integrations.RollingMeans{CPU: 0.1, CPUOK: true})
+	store.observe(integrations.MetricsScope{Namespace: "b", Deployment: "api"}, integrations.RollingMeans{Memory: 0.7, MemoryOK: true})
+	store.observe(integrations.MetricsScope{Namespace: "c"}, integrations.RollingMeans{CPU: 0.3, Memory: 0.4, CPUOK: true, MemoryOK: true})
+	require.NoError(t, store.save(path))
+
+	loaded := newBaselineStore(0.5, 10)
+	count, err := loaded.load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, store.snapshot(), loaded.snapshot())
+
+	baseline, ok := loaded.lookup(integrations.MetricsScope{Namespace: "b", Deployment: "api"})
+	require.True(t, ok)
+	assert.InDelta(t, 0.7, baseline.MemoryRollingMean, 0.0001)
+	assert.Equal(t, 1, baseline.MemorySamples)
+
+	small := newBaselineStore(0.5, 2)
+	count, err = small.load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	_, ok = small.lookup(integrations.MetricsScope{Namespace: "a"})
+	assert.False(t, ok, "least recently used baseline is dropped")
+}
+
+// TestBaselineStore_LoadErrors verifies a missing file is a cold start and a corrupt file
+// leaves the store untouched
+func TestBaselineStore_LoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	store := newBaselineStore(0.5, 10)
+	store.observe(integrations.MetricsScope{Namespace: "a"}, integrations.RollingMeans{CPU: 0.1, CPUOK: true})
+
+	count, err := store.load(filepath.Join(dir, "missing.json"))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{not json"), 0o600))
+	_, err = store.load(corrupt)
+	require.Error(t, err)
+	_, ok := store.lookup(integrations.MetricsScope{Namespace: "a"})
+	assert.True(t, ok)
+}
+
+// TestPredictionHandler_BaselineFile verifies baselines saved by one handler are loaded by the
+// next, and that an unreadable file does not stop the handler from starting
+func TestPredictionHandler_BaselineFile(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	path := filepath.Join(t.TempDir(), BaselineFileName)
+	scope := integrations.MetricsScope{Namespace: "payments"}
+
+	require.NoError(t, NewPredictionHandler(nil, nil, log).SaveBaselines(), "no file configured")
+
+	first := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{BaselineFile: path})
+	first.baselines.observe(scope, integrations.RollingMeans{CPU: 0.3, CPUOK: true})
+	require.NoError(t, first.SaveBaselines())
+
+	second := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{BaselineFile: path})
+	baseline, ok := second.baselines.lookup(scope)
+	require.True(t, ok)
+	assert.InDelta(t, 0.3, baseline.CPURollingMean, 0.0001)
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	cold := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{BaselineFile: path})
+	assert.Empty(t, cold.baselines.snapshot())
+}
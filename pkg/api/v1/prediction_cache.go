@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPredictionCacheBucket is the time bucket used in prediction cache keys when none is configured
+const DefaultPredictionCacheBucket = 5 * time.Minute
+
+// predictionCache holds recent prediction responses so repeated identical requests
+// (e.g. a polling dashboard) skip the KServe call within the TTL
+type predictionCache struct {
+	mu      sync.RWMutex
+	entries map[string]*predictionCacheEntry
+	ttl     time.Duration
+}
+
+type predictionCacheEntry struct {
+	response  PredictResponse
+	expiresAt time.Time
+}
+
+// newPredictionCache returns nil when ttl is not positive, which disables server-side caching
+func newPredictionCache(ttl time.Duration) *predictionCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &predictionCache{
+		entries: make(map[string]*predictionCacheEntry),
+		ttl:     ttl,
+	}
+}
+
+func (c *predictionCache) get(key string) (PredictResponse, bool) {
+	if c == nil {
+		return PredictResponse{}, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return PredictResponse{}, false
+	}
+	return entry.response, true
+}
+
+func (c *predictionCache) set(key string, response PredictResponse) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop expired entries so keys from past time buckets don't accumulate
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = &predictionCacheEntry{
+		response:  response,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// predictionCacheKey derives a deterministic key from the normalized request, the time bucket
+// containing now, and the current metric snapshot. Rolling means are rounded to 0.1% so
+// Prometheus jitter alone does not change the key.
+func predictionCacheKey(req *PredictRequest, cpuRollingMean, memoryRollingMean float64, now time.Time, bucket time.Duration) string {
+	if bucket <= 0 {
+		bucket = DefaultPredictionCacheBucket
+	}
+
+	snapshot := fmt.Sprintf("%d|%d|%s|%s|%s|%s|%s|%s|%d|%.3f|%.3f",
+		req.Hour, req.DayOfWeek, req.TargetTimestamp, req.Namespace, req.Deployment, req.Pod, req.Scope, req.Model,
+		now.Truncate(bucket).Unix(),
+		math.Round(cpuRollingMean*1000)/1000, math.Round(memoryRollingMean*1000)/1000)
+	if req.CapacityScaleFactor != nil {
+		snapshot += fmt.Sprintf("|%g", *req.CapacityScaleFactor) // Keeps keys of requests without it unchanged
+	}
+	if req.ThresholdPercent != nil {
+		snapshot += fmt.Sprintf("|t%g", *req.ThresholdPercent)
+	}
+	if req.queryStep > 0 {
+		snapshot += "|s" + req.queryStep.String()
+	}
+	if len(req.Models) > 1 {
+		snapshot += "|m" + strings.Join(req.Models, ",")
+	}
+	if len(req.Fields) > 0 {
+		snapshot += "|f" + strings.Join(req.Fields, ",")
+	}
+	if req.Aggregation != "" {
+		snapshot += "|a" + req.Aggregation
+	}
+
+	sum := sha256.Sum256([]byte(snapshot))
+	return hex.EncodeToString(sum[:16])
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+// Weak validators are compared by their opaque tag, as RFC 9110 requires for If-None-Match.
+// "*" matches only when a representation exists, i.e. a cached prediction for etag.
+func etagMatches(ifNoneMatch, etag string, exists bool) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if (candidate == "*" && exists) || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// newCachingTestHandler returns a handler backed by a regression model server that counts calls
+func newCachingTestHandler(t *testing.T, ttl time.Duration) (*PredictionHandler, *int32) {
+	t.Helper()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"predictions":   [][]float64{{42.0, 17.0}},
+			"model_name":    "predictive-analytics",
+			"model_version": "v1",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	kserveClient, err := kserve.NewProxyClient(kserve.ProxyConfig{
+		Namespace:        "test-ns",
+		Timeout:          5 * time.Second,
+		RegressionModels: []string{"predictive-analytics"},
+	}, log)
+	require.NoError(t, err)
+	kserveClient.RegisterModel(&kserve.ModelInfo{Name: "predictive-analytics", URL: server.URL})
+
+	config := DefaultPredictionHandlerConfig()
+	config.CacheTTL = ttl
+	return NewPredictionHandlerWithConfig(kserveClient, nil, log, config), &calls
+}
+
+func doPredict(t *testing.T, handler *PredictionHandler, namespace, ifNoneMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"hour": 15, "day_of_week": 3, "namespace": namespace})
+	req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	handler.HandlePredict(w, req)
+	return w
+}
+
+func TestPredictionHandler_HandlePredict_Cache(t *testing.T) {
+	t.Run("identical requests within TTL skip KServe", func(t *testing.T) {
+		handler, calls := newCachingTestHandler(t, time.Minute)
+
+		first := doPredict(t, handler, "ns-a", "")
+		second := doPredict(t, handler, "ns-a", "")
+
+		require.Equal(t, http.StatusOK, first.Code)
+		require.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+		assert.NotEmpty(t, first.Header().Get("ETag"))
+		assert.Equal(t, first.Header().Get("ETag"), second.Header().Get("ETag"))
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+	})
+
+	t.Run("different targets are cached separately", func(t *testing.T) {
+		handler, calls := newCachingTestHandler(t, time.Minute)
+
+		a := doPredict(t, handler, "ns-a", "")
+		b := doPredict(t, handler, "ns-b", "")
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+		assert.NotEqual(t, a.Header().Get("ETag"), b.Header().Get("ETag"))
+	})
+
+	t.Run("matching If-None-Match returns 304", func(t *testing.T) {
+		handler, calls := newCachingTestHandler(t, 0)
+
+		first := doPredict(t, handler, "ns-a", "")
+		etag := first.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		second := doPredict(t, handler, "ns-a", etag)
+		assert.Equal(t, http.StatusNotModified, second.Code)
+		assert.Empty(t, second.Body.String())
+		assert.Equal(t, etag, second.Header().Get("ETag"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("wildcard If-None-Match needs a cached prediction", func(t *testing.T) {
+		handler, calls := newCachingTestHandler(t, time.Minute)
+
+		first := doPredict(t, handler, "ns-a", "*")
+		require.Equal(t, http.StatusOK, first.Code)
+		assert.NotEmpty(t, first.Header().Get("ETag"))
+
+		second := doPredict(t, handler, "ns-a", "*")
+		assert.Equal(t, http.StatusNotModified, second.Code)
+		assert.Equal(t, first.Header().Get("ETag"), second.Header().Get("ETag"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("errors carry no ETag", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "model crashed", http.StatusInternalServerError)
+		}))
+		t.Cleanup(server.Close)
+
+		log := logrus.New()
+		log.SetLevel(logrus.FatalLevel)
+		kserveClient, err := kserve.NewProxyClient(kserve.ProxyConfig{
+			Namespace:        "test-ns",
+			Timeout:          5 * time.Second,
+			RegressionModels: []string{"predictive-analytics"},
+		}, log)
+		require.NoError(t, err)
+		kserveClient.RegisterModel(&kserve.ModelInfo{Name: "predictive-analytics", URL: server.URL})
+		config := DefaultPredictionHandlerConfig()
+		config.CacheTTL = time.Minute
+		handler := NewPredictionHandlerWithConfig(kserveClient, nil, log, config)
+
+		w := doPredict(t, handler, "ns-a", "")
+		require.GreaterOrEqual(t, w.Code, http.StatusInternalServerError)
+		assert.Empty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("disabled cache calls KServe every time", func(t *testing.T) {
+		handler, calls := newCachingTestHandler(t, 0)
+
+		doPredict(t, handler, "ns-a", "")
+		doPredict(t, handler, "ns-a", "")
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+}
+
+func TestPredictionCacheKey(t *testing.T) {
+	req := &PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: "ns", Scope: "namespace", Model: "predictive-analytics"}}
+	base := time.Date(2026, 1, 5, 10, 1, 0, 0, time.UTC)
+
+	key := predictionCacheKey(req, 0.65, 0.72, base, 5*time.Minute)
+
+	assert.Equal(t, key, predictionCacheKey(req, 0.65, 0.72, base.Add(3*time.Minute), 5*time.Minute), "same bucket")
+	assert.Equal(t, key, predictionCacheKey(req, 0.65001, 0.72, base, 5*time.Minute), "sub-0.1% metric jitter")
+	assert.NotEqual(t, key, predictionCacheKey(req, 0.65, 0.72, base.Add(5*time.Minute), 5*time.Minute), "next bucket")
+	assert.NotEqual(t, key, predictionCacheKey(req, 0.70, 0.72, base, 5*time.Minute), "metric snapshot changed")
+
+	other := *req
+	other.Deployment = "api"
+	assert.NotEqual(t, key, predictionCacheKey(&other, 0.65, 0.72, base, 5*time.Minute), "request params changed")
+
+	other = *req
+	other.TargetTimestamp = "2027-03-15T15:00:00Z"
+	assert.NotEqual(t, key, predictionCacheKey(&other, 0.65, 0.72, base, 5*time.Minute), "target timestamp changed")
+
+	other = *req
+	factor := 2.0
+	other.CapacityScaleFactor = &factor
+	assert.NotEqual(t, key, predictionCacheKey(&other, 0.65, 0.72, base, 5*time.Minute), "capacity scale factor added")
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := `"abc"`
+
+	assert.True(t, etagMatches(`"abc"`, etag, false))
+	assert.True(t, etagMatches(`W/"abc"`, etag, false))
+	assert.True(t, etagMatches(`"x", "abc"`, etag, false))
+	assert.True(t, etagMatches(`*`, etag, true))
+	assert.False(t, etagMatches(`*`, etag, false), "no cached representation")
+	assert.False(t, etagMatches(``, etag, true))
+	assert.False(t, etagMatches(`"abd"`, etag, true))
+}
+
+func TestPredictionCache_Expiry(t *testing.T) {
+	assert.Nil(t, newPredictionCache(0))
+
+	cache := newPredictionCache(time.Minute)
+	cache.set("k", PredictResponse{Target: "ns"})
+
+	got, ok := cache.get("k")
+	require.True(t, ok)
+	assert.Equal(t, "ns", got.Target)
+
+	cache.entries["k"].expiresAt = time.Now().Add(-time.Second)
+	_, ok = cache.get("k")
+	assert.False(t, ok)
+
+	cache.set("other", PredictResponse{})
+	assert.NotContains(t, cache.entries, "k", "expired entries are pruned on set")
+}
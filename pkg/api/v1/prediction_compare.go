@@ -0,0 +1,321 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+// MaxCompareScopes bounds the number of scopes in one comparison request; each scope costs
+// a feature build and a KServe call
+const MaxCompareScopes = 50
+
+// compareConcurrency is how many scopes are predicted in parallel
+const compareConcurrency = 4
+
+// Sort keys accepted by POST /api/v1/predict/compare
+const (
+	CompareSortCPU    = "cpu_percent"
+	CompareSortMemory = "memory_percent"
+)
+
+// CompareScope identifies one target in a comparison request
+type CompareScope struct {
+	Namespace  string `json:"namespace"`
+	Deployment string `json:"deployment,omitempty"`
+	Pod        string `json:"pod,omitempty"`
+	Scope      string `json:"scope,omitempty"` // Optional: inferred from the fields above
+}
+
+// PredictCompareRequest is the request body for comparing predictions across scopes
+type PredictCompareRequest struct {
+	Hour      int            `json:"hour"`        // Required: 0-23 (hour of day)
+	DayOfWeek int            `json:"day_of_week"` // Required: 0=Monday, 6=Sunday
+	Scopes    []CompareScope `json:"scopes"`      // Required: 1-MaxCompareScopes targets
+	Model     string         `json:"model"`       // Optional: KServe model name (default: predictive-analytics)
+	SortBy    string         `json:"sort_by"`     // Optional: cpu_percent (default) or memory_percent
+}
+
+// PredictCompareResponse ranks predictions for several scopes at one target time
+type PredictCompareResponse struct {
+	Status     string             `json:"status"`
+	SortBy     string             `json:"sort_by"`
+	TargetTime TargetTimeInfo     `json:"target_time"`
+	Ranking    []string           `json:"ranking"` // Targets ordered by SortBy, highest first
+	Results    []RankedPrediction `json:"results"`
+	Errors     []CompareError     `json:"errors,omitempty"`
+}
+
+// RankedPrediction is one scope's prediction and its 1-based position in the ranking
+type RankedPrediction struct {
+	Rank       int             `json:"rank"`
+	Prediction PredictResponse `json:"prediction"`
+}
+
+// CompareError reports a scope whose prediction failed; the remaining scopes are still ranked
+type CompareError struct {
+	Target  string `json:"target"`
+	Error   string `json:"error"`
+	Details string `json:"details,omitempty"`
+	Code    string `json:"code"`
+}
+
+// HandlePredictCompare handles POST /api/v1/predict/compare
+//
+// With "Accept: application/x-ndjson" each scope's prediction or error is streamed as it
+// completes, followed by a summary line with the ranking (see CompareStreamLine).
+//
+// @Summary Compare resource usage predictions across scopes
+// @Description Predicts several namespaces/deployments for one target time and ranks them by a metric
+// @Tags prediction
+// @Accept json
+// @Produce json,application/x-ndjson
+// @Param request body PredictCompareRequest true "Comparison request"
+// @Success 200 {object} PredictCompareResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Failure 503 {object} PredictErrorResponse
+// @Router /api/v1/predict/compare [post]
+func (h *PredictionHandler) HandlePredictCompare(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
+
+	compareReq, scopeReqs, err := h.parseCompareRequest(r)
+	if err != nil {
+		h.handleRequestError(w, err)
+		return
+	}
+
+	if err := h.validateKServeAvailability(ctx, compareReq.Model); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"hour":        compareReq.Hour,
+		"day_of_week": compareReq.DayOfWeek,
+		"scopes":      len(scopeReqs),
+		"model":       compareReq.Model,
+		"sort_by":     compareReq.SortBy,
+	}).Info("Processing prediction comparison request")
+
+	// Time-based features depend only on the window end, so build them once for all scopes
+	// A comparison takes one prediction slot; its scopes are already bounded by compareConcurrency
+	var window *features.TimeFeatureWindow
+	if h.usesFeatureEngineering(compareReq.Model) {
+		release, ok := h.acquirePredictionSlot(w, r)
+		if !ok {
+			return
+		}
+		defer release()
+		window = h.featureBuilder.BuildTimeFeatureWindow(time.Now())
+	}
+
+	results := h.predictScopes(ctx, scopeReqs, window)
+	if acceptsNDJSON(r) {
+		h.streamCompare(ctx, w, compareReq, scopeReqs, results)
+		return
+	}
+
+	responses := make([]*PredictResponse, len(scopeReqs))
+	errs := make([]error, len(scopeReqs))
+	for result := range results {
+		if result.err != nil {
+			errs[result.index] = result.err
+			continue
+		}
+		responses[result.index] = &result.response
+	}
+
+	result := PredictCompareResponse{
+		Status: "success",
+		SortBy: compareReq.SortBy,
+		TargetTime: TargetTimeInfo{
+			Hour:         compareReq.Hour,
+			DayOfWeek:    compareReq.DayOfWeek,
+			ISOTimestamp: h.calculateTargetTimestamp(compareReq.Hour, compareReq.DayOfWeek),
+		},
+		Ranking: make([]string, 0, len(scopeReqs)),
+		Results: make([]RankedPrediction, 0, len(scopeReqs)),
+	}
+	for i, response := range responses {
+		if response != nil {
+			result.Results = append(result.Results, RankedPrediction{Prediction: *response})
+			continue
+		}
+		result.Errors = append(result.Errors, compareErrorFor(h.getTarget(scopeReqs[i]), errs[i]))
+	}
+
+	if len(result.Results) == 0 {
+		h.respondError(w, http.StatusServiceUnavailable, "Prediction failed for all scopes", result.Errors[0].Details, ErrCodePredictionFailed)
+		return
+	}
+
+	rankPredictions(result.Results, compareReq.SortBy)
+	for i := range result.Results {
+		result.Ranking = append(result.Ranking, result.Results[i].Prediction.Target)
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"ranked": len(result.Results),
+		"failed": len(result.Errors),
+	}).Info("Prediction comparison completed")
+	h.respondJSON(w, http.StatusOK, result)
+}
+
+// parseCompareRequest decodes and validates a comparison request and expands it into one
+// prediction request per scope
+func (h *PredictionHandler) parseCompareRequest(r *http.Request) (*PredictCompareRequest, []*PredictRequest, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "" && !strings.HasPrefix(contentType, "application/json") {
+		return nil, nil, &requestError{message: "Content-Type must be application/json", code: ErrCodeInvalidRequest}
+	}
+
+	var compareReq PredictCompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&compareReq); err != nil {
+		return nil, nil, decodeRequestError("Invalid request format", err)
+	}
+
+	if err := h.validateTimeFields(&PredictRequest{PredictRequest: types.PredictRequest{Hour: compareReq.Hour, DayOfWeek: compareReq.DayOfWeek}}); err != nil {
+		return nil, nil, &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
+	}
+	if len(compareReq.Scopes) == 0 || len(compareReq.Scopes) > MaxCompareScopes {
+		return nil, nil, &requestError{message: fmt.Sprintf("scopes must contain between 1 and %d entries", MaxCompareScopes), code: ErrCodeInvalidRequest}
+	}
+
+	switch compareReq.SortBy {
+	case "":
+		compareReq.SortBy = CompareSortCPU
+	case CompareSortCPU, CompareSortMemory:
+	default:
+		return nil, nil, &requestError{message: fmt.Sprintf("sort_by must be one of: %s, %s", CompareSortCPU, CompareSortMemory), code: ErrCodeInvalidRequest}
+	}
+	if compareReq.Model == "" {
+		compareReq.Model = DefaultPredictionModel
+	}
+
+	scopeReqs := make([]*PredictRequest, 0, len(compareReq.Scopes))
+	for i, scope := range compareReq.Scopes {
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
+			Hour:       compareReq.Hour,
+			DayOfWeek:  compareReq.DayOfWeek,
+			Namespace:  scope.Namespace,
+			Deployment: scope.Deployment,
+			Pod:        scope.Pod,
+			Scope:      scope.Scope,
+			Model:      compareReq.Model,
+		}}
+		req.trimScope()
+		if err := h.validateScope(req); err != nil {
+			return nil, nil, &requestError{message: fmt.Sprintf("scopes[%d]: %s", i, err), code: ErrCodeInvalidRequest}
+		}
+		// Infer the scope first so e.g. a bare deployment is held to the deployment requirements
+		h.setRequestDefaults(req)
+		if err := h.validateScopeRequirements(req); err != nil {
+			return nil, nil, &requestError{message: fmt.Sprintf("scopes[%d]: %s", i, err), code: ErrCodeInvalidRequest}
+		}
+		if err := features.ValidateScopeIdentifiers(req.Namespace, req.Deployment, req.Pod); err != nil {
+			return nil, nil, &requestError{message: fmt.Sprintf("scopes[%d]: %s", i, err), code: ErrCodeInvalidRequest}
+		}
+		if err := h.checkTarget(r.Context(), req); err != nil {
+			var reqErr *requestError
+			if errors.As(err, &reqErr) {
+				reqErr.message = fmt.Sprintf("scopes[%d]: %s", i, reqErr.message)
+			}
+			return nil, nil, err
+		}
+		scopeReqs = append(scopeReqs, req)
+	}
+
+	return &compareReq, scopeReqs, nil
+}
+
+// scopeResult is the outcome of one scope of a comparison
+type scopeResult struct {
+	index    int // Position of the scope in the request
+	response PredictResponse
+	err      error
+}
+
+// predictScopes predicts each scope, compareConcurrency at a time, and sends each outcome as
+// it completes. The channel is closed once every scope is done; callers must drain it.
+func (h *PredictionHandler) predictScopes(ctx context.Context, scopeReqs []*PredictRequest, window *features.TimeFeatureWindow) <-chan scopeResult {
+	results := make(chan scopeResult)
+	sem := make(chan struct{}, compareConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range scopeReqs {
+		wg.Add(1)
+		go func(i int, req *PredictRequest) {
+			defer wg.Done()
+			sem <- struct{}{}
+			response, err := h.predictScope(ctx, req, window)
+			<-sem
+			results <- scopeResult{index: i, response: response, err: err}
+		}(i, req)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// predictScope runs the HandlePredict pipeline for one scope, reusing the shared time
+// features when the model gets engineered features
+func (h *PredictionHandler) predictScope(ctx context.Context, req *PredictRequest, window *features.TimeFeatureWindow) (PredictResponse, error) {
+	cpuRollingMean, memoryRollingMean := h.getMetricsWithDefaults(ctx, req)
+	return h.predictWithMetrics(ctx, req, window, cpuRollingMean, memoryRollingMean)
+}
+
+// predictWithMetrics builds req's instances, with window's time features when the model gets
+// engineered features (nil = a fresh window), and predicts with req.Model
+func (h *PredictionHandler) predictWithMetrics(ctx context.Context, req *PredictRequest, window *features.TimeFeatureWindow, cpuRollingMean, memoryRollingMean float64) (PredictResponse, error) {
+	instances, featureCount, rawMetrics, err := h.buildPredictionInstancesWithTime(ctx, req, window)
+	if err != nil {
+		return PredictResponse{}, err
+	}
+	h.logPredictionInstances(ctx, featureCount, cpuRollingMean, memoryRollingMean)
+
+	predictions, confidence, modelVersion, err := h.executePrediction(ctx, req.Model, instances, cpuRollingMean, memoryRollingMean)
+	if err != nil {
+		return PredictResponse{}, err
+	}
+	return h.buildPredictResponse(req, predictions, confidence, modelVersion, cpuRollingMean, memoryRollingMean, rawMetrics), nil
+}
+
+// compareErrorFor converts a per-scope failure into its response entry
+func compareErrorFor(target string, err error) CompareError {
+	var svcErr *serviceError
+	if errors.As(err, &svcErr) {
+		return CompareError{Target: target, Error: svcErr.message, Details: svcErr.details, Code: svcErr.code}
+	}
+	return CompareError{Target: target, Error: "Prediction failed", Details: err.Error(), Code: ErrCodePredictionFailed}
+}
+
+// rankPredictions sorts results by sortBy, highest first, and assigns 1-based ranks.
+// Ties keep request order.
+func rankPredictions(results []RankedPrediction, sortBy string) {
+	value := func(p PredictionValues) float64 {
+		if sortBy == CompareSortMemory {
+			return p.MemoryPercent
+		}
+		return p.CPUPercent
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return value(results[i].Prediction.Predictions) > value(results[j].Prediction.Predictions)
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+}
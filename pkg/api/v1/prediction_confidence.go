@@ -0,0 +1,100 @@
+package v1
+
+import (
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// Fixed confidences of DefaultConfidenceScorer, used when the model reports nothing better
+const (
+	// baseConfidence applies when the response carries no confidence signal, e.g. regression
+	// outputs or a forecast without confidence values
+	baseConfidence = 0.85
+
+	// Anomaly-detector labels without usable decision scores
+	anomalyIssueConfidence  = 0.92
+	anomalyNormalConfidence = 0.88
+)
+
+// Confidence range for score-derived anomaly confidence
+const (
+	anomalyMinConfidence = 0.5
+	anomalyMaxConfidence = 0.99
+)
+
+// ConfidenceInput is what a ConfidenceScorer sees for one prediction
+type ConfidenceInput struct {
+	// Response is the raw model response; exactly one of its typed responses is set
+	Response *kserve.ModelResponse
+
+	// Current rolling means (0-1) the prediction was made from
+	CPURollingMean    float64
+	MemoryRollingMean float64
+}
+
+// ConfidenceScorer computes the confidence (0-1) reported with a prediction, so deployments can
+// plug in a calibration matching their model instead of the default heuristics
+type ConfidenceScorer interface {
+	Score(input ConfidenceInput) float64
+}
+
+// DefaultConfidenceScorer is the built-in scorer:
+//   - forecast: the first-step confidence of the CPU forecast, averaged with the memory
+//     forecast's when both report one
+//   - anomaly: scaled by the decision score magnitude when present, else fixed by label
+//   - regression: baseConfidence
+type DefaultConfidenceScorer struct {
+	// ForecastKeys names the CPU/memory keys of forecast responses
+	ForecastKeys ForecastKeyMapping
+}
+
+// Score implements ConfidenceScorer
+func (s DefaultConfidenceScorer) Score(input ConfidenceInput) float64 {
+	resp := input.Response
+	switch {
+	case resp == nil:
+		return baseConfidence
+	case resp.ForecastResponse != nil:
+		return s.forecastConfidence(resp.ForecastResponse)
+	case resp.AnomalyResponse != nil:
+		return anomalyConfidence(resp.AnomalyResponse)
+	default:
+		return baseConfidence
+	}
+}
+
+// forecastConfidence averages the first-step confidence of the CPU and memory forecasts that
+// report one
+func (s DefaultConfidenceScorer) forecastConfidence(resp *kserve.ForecastResponse) float64 {
+	var sum float64
+	var count int
+	for _, keys := range [][]string{s.ForecastKeys.CPUKeys, s.ForecastKeys.MemoryKeys} {
+		if forecast, ok := findForecast(resp, keys); ok && len(forecast.Confidence) > 0 {
+			sum += forecast.Confidence[0]
+			count++
+		}
+	}
+	if count == 0 {
+		return baseConfidence
+	}
+	return sum / float64(count)
+}
+
+// anomalyConfidence scales with the decision score magnitude when the model returned one
+func anomalyConfidence(resp *kserve.DetectResponse) float64 {
+	if strength, scored := anomalyScoreStrength(resp); scored {
+		return anomalyScoreConfidence(strength)
+	}
+	switch anomalyLabel(resp) {
+	case -1:
+		return anomalyIssueConfidence
+	case 1:
+		return anomalyNormalConfidence
+	default:
+		return baseConfidence
+	}
+}
+
+// anomalyScoreConfidence converts a score strength into a confidence value
+func anomalyScoreConfidence(strength float64) float64 {
+	return anomalyMinConfidence + (anomalyMaxConfidence-anomalyMinConfidence)*strength
+}
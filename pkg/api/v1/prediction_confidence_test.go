@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// scoreConfidence scores resp with the handler's configured confidence scorer
+func scoreConfidence(h *PredictionHandler, resp *kserve.ModelResponse, cpuRollingMean, memoryRollingMean float64) float64 {
+	return h.confidenceScorer.Score(ConfidenceInput{
+		Response:          resp,
+		CPURollingMean:    cpuRollingMean,
+		MemoryRollingMean: memoryRollingMean,
+	})
+}
+
+// fixedConfidenceScorer records its input and returns a fixed confidence
+type fixedConfidenceScorer struct {
+	confidence float64
+	input      ConfidenceInput
+}
+
+func (s *fixedConfidenceScorer) Score(input ConfidenceInput) float64 {
+	s.input = input
+	return s.confidence
+}
+
+func TestDefaultConfidenceScorer(t *testing.T) {
+	scorer := DefaultConfidenceScorer{ForecastKeys: DefaultForecastKeyMapping()}
+
+	assert.Equal(t, baseConfidence, scorer.Score(ConfidenceInput{}))
+	assert.Equal(t, baseConfidence, scorer.Score(ConfidenceInput{Response: &kserve.ModelResponse{
+		Type:               "regression",
+		RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{50, 60}},
+	}}))
+	assert.Equal(t, baseConfidence, scorer.Score(ConfidenceInput{Response: &kserve.ModelResponse{
+		Type:            "anomaly",
+		AnomalyResponse: &kserve.DetectResponse{},
+	}}))
+
+	// A forecast whose only confidence is on a metric without forecast values is ignored
+	assert.Equal(t, baseConfidence, scorer.Score(ConfidenceInput{Response: &kserve.ModelResponse{
+		Type: "forecast",
+		ForecastResponse: &kserve.ForecastResponse{Predictions: map[string]kserve.ForecastResult{
+			"cpu_usage": {Confidence: []float64{0.99}},
+		}},
+	}}))
+}
+
+func TestPredictionHandler_CustomConfidenceScorer(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	scorer := &fixedConfidenceScorer{confidence: 0.42}
+	config := DefaultPredictionHandlerConfig()
+	config.ConfidenceScorer = scorer
+	handler := NewPredictionHandlerWithConfig(nil, nil, log, config)
+
+	resp := &kserve.ModelResponse{
+		Type: "forecast",
+		ForecastResponse: &kserve.ForecastResponse{Predictions: map[string]kserve.ForecastResult{
+			"cpu_usage": {Forecast: []float64{0.55}, Confidence: []float64{0.9}},
+		}},
+	}
+	cpuPercent, _, confidence, _, err := handler.processKServeResponse(context.Background(), resp, 0.60, 0.70)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.42, confidence)
+	assert.InDelta(t, 55.0, cpuPercent, 0.001, "the scorer does not change predicted values")
+	assert.Same(t, resp, scorer.input.Response)
+	assert.Equal(t, 0.60, scorer.input.CPURollingMean)
+	assert.Equal(t, 0.70, scorer.input.MemoryRollingMean)
+}
@@ -0,0 +1,127 @@
+package v1
+
+import "net/http"
+
+// Feature engineering states reported by GET /api/v1/config
+const (
+	FeatureEngineeringEnabled       = "enabled"
+	FeatureEngineeringDisabled      = "disabled"
+	FeatureEngineeringNoPrometheus  = "disabled (no prometheus)"
+	FeatureEngineeringInvalidConfig = "disabled (invalid configuration)"
+)
+
+// Prometheus states reported by GET /api/v1/config
+const (
+	PrometheusStatusAvailable     = "available"
+	PrometheusStatusUnavailable   = "unavailable"
+	PrometheusStatusNotConfigured = "not configured"
+)
+
+// EffectiveConfigResponse is the configuration the prediction handler is actually running
+// with, after defaults and unavailable dependencies are taken into account
+type EffectiveConfigResponse struct {
+	Status             string                      `json:"status"`
+	FeatureEngineering EffectiveFeatureEngineering `json:"feature_engineering"`
+	DefaultModel       string                      `json:"default_model"`
+	DefaultMetrics     DefaultMetrics              `json:"default_metrics"`    // Used when Prometheus has no data
+	MetricAggregation  string                      `json:"metric_aggregation"` // Used when a request sets no aggregation
+	FallbackPolicy     string                      `json:"fallback_policy"`    // lenient or strict
+	Prometheus         string                      `json:"prometheus"`         // available, unavailable or not configured
+	DegradedMode       bool                        `json:"degraded_mode"`
+	CacheTTL           string                      `json:"cache_ttl,omitempty"` // Omitted when caching is disabled
+}
+
+// EffectiveFeatureEngineering reports whether predictions use engineered features and why not
+type EffectiveFeatureEngineering struct {
+	// Status is enabled, disabled, "disabled (no prometheus)" or "disabled (invalid configuration)"
+	Status string `json:"status"`
+
+	// Configured is ENABLE_FEATURE_ENGINEERING; it differs from Status when a dependency is missing
+	Configured bool `json:"configured"`
+
+	// FeatureStrategy and FeatureCount describe what DefaultModel is sent
+	FeatureStrategy string `json:"feature_strategy"`
+	FeatureCount    int    `json:"feature_count"`
+
+	LookbackHours        int `json:"lookback_hours,omitempty"` // Only when enabled
+	ExpectedFeatureCount int `json:"expected_feature_count"`   // 0 = not validated
+}
+
+// DefaultMetrics are the metric values predictions fall back to without Prometheus data
+type DefaultMetrics struct {
+	CPURollingMean    float64 `json:"cpu_rolling_mean"`
+	MemoryRollingMean float64 `json:"memory_rolling_mean"`
+	DiskUsage         float64 `json:"disk_usage"`
+	NetworkIn         float64 `json:"network_in"`
+	NetworkOut        float64 `json:"network_out"`
+}
+
+// HandleEffectiveConfig handles GET /api/v1/config
+//
+// Reports the configuration in effect rather than the one requested, so a setting that was
+// not applied (e.g. feature engineering enabled without Prometheus, Issue #57) is visible
+// without reading startup logs.
+//
+// @Summary Get the effective prediction configuration
+// @Description Reports feature engineering state, default model and metrics, fallback policy and Prometheus availability as the handlers use them
+// @Tags prediction
+// @Produce json
+// @Success 200 {object} EffectiveConfigResponse
+// @Router /api/v1/config [get]
+func (h *PredictionHandler) HandleEffectiveConfig(w http.ResponseWriter, r *http.Request) {
+	strategy, count := h.DescribeModelFeatures(DefaultPredictionModel)
+	resp := EffectiveConfigResponse{
+		Status: "success",
+		FeatureEngineering: EffectiveFeatureEngineering{
+			Status:               h.featureEngineeringStatus(),
+			Configured:           h.enableFeatureEngineering,
+			FeatureStrategy:      strategy,
+			FeatureCount:         count,
+			ExpectedFeatureCount: h.expectedFeatureCount,
+		},
+		DefaultModel: DefaultPredictionModel,
+		DefaultMetrics: DefaultMetrics{
+			CPURollingMean:    h.defaultCPURollingMean,
+			MemoryRollingMean: h.defaultMemoryRollingMean,
+			DiskUsage:         h.defaultDiskUsage,
+			NetworkIn:         h.defaultNetworkIn,
+			NetworkOut:        h.defaultNetworkOut,
+		},
+		MetricAggregation: h.metricAggregation,
+		FallbackPolicy:    FallbackPolicyLenient,
+		Prometheus:        PrometheusStatusNotConfigured,
+		DegradedMode:      h.degradedMode,
+	}
+	if h.IsFeatureEngineeringEnabled() {
+		resp.FeatureEngineering.LookbackHours = h.featureBuilder.GetFeatureInfo().LookbackHours
+	}
+	if h.strictFeatureFallback {
+		resp.FallbackPolicy = FallbackPolicyStrict
+	}
+	if h.prometheusClient != nil {
+		resp.Prometheus = PrometheusStatusUnavailable
+		if h.prometheusClient.IsAvailable() {
+			resp.Prometheus = PrometheusStatusAvailable
+		}
+	}
+	if h.cache != nil {
+		resp.CacheTTL = h.cache.ttl.String()
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// featureEngineeringStatus explains whether predictions use engineered features
+func (h *PredictionHandler) featureEngineeringStatus() string {
+	switch {
+	case h.IsFeatureEngineeringEnabled():
+		return FeatureEngineeringEnabled
+	case !h.enableFeatureEngineering:
+		return FeatureEngineeringDisabled
+	case h.prometheusClient == nil:
+		return FeatureEngineeringNoPrometheus
+	default:
+		// Prometheus is configured but the feature builder rejected the configuration
+		return FeatureEngineeringInvalidConfig
+	}
+}
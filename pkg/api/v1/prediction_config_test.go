@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+)
+
+func TestPredictionHandler_HandleEffectiveConfig(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	get := func(t *testing.T, handler *PredictionHandler) EffectiveConfigResponse {
+		t.Helper()
+		router := mux.NewRouter()
+		handler.RegisterRoutes(router)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/config", http.NoBody))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp EffectiveConfigResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("enabled without prometheus", func(t *testing.T) {
+		handler := NewPredictionHandlerWithConfig(&fakeModelClient{}, nil, log, PredictionHandlerConfig{
+			EnableFeatureEngineering: true,
+			LookbackHours:            24,
+			ExpectedFeatureCount:     3264,
+			FallbackPolicy:           FallbackPolicyStrict,
+		})
+
+		resp := get(t, handler)
+		assert.Equal(t, FeatureEngineeringNoPrometheus, resp.FeatureEngineering.Status)
+		assert.True(t, resp.FeatureEngineering.Configured)
+		assert.Equal(t, FeatureStrategyRawMetrics, resp.FeatureEngineering.FeatureStrategy)
+		assert.Equal(t, rawMetricFeatureCount, resp.FeatureEngineering.FeatureCount)
+		assert.Zero(t, resp.FeatureEngineering.LookbackHours, "the lookback is not in effect")
+		assert.Equal(t, 3264, resp.FeatureEngineering.ExpectedFeatureCount)
+		assert.Equal(t, DefaultPredictionModel, resp.DefaultModel)
+		assert.InDelta(t, 0.65, resp.DefaultMetrics.CPURollingMean, 0.001)
+		assert.InDelta(t, 0.72, resp.DefaultMetrics.MemoryRollingMean, 0.001)
+		assert.Equal(t, FallbackPolicyStrict, resp.FallbackPolicy)
+		assert.Equal(t, PrometheusStatusNotConfigured, resp.Prometheus)
+		assert.Empty(t, resp.CacheTTL)
+	})
+
+	t.Run("explicitly disabled", func(t *testing.T) {
+		handler := NewPredictionHandlerWithConfig(&fakeModelClient{}, nil, log, PredictionHandlerConfig{CacheTTL: time.Minute})
+
+		resp := get(t, handler)
+		assert.Equal(t, FeatureEngineeringDisabled, resp.FeatureEngineering.Status)
+		assert.False(t, resp.FeatureEngineering.Configured)
+		assert.Equal(t, FallbackPolicyLenient, resp.FallbackPolicy)
+		assert.Equal(t, "1m0s", resp.CacheTTL)
+	})
+
+	t.Run("invalid feature configuration", func(t *testing.T) {
+		prometheus := integrations.NewPrometheusClient("http://prometheus.invalid:9090", time.Second, log)
+		handler := NewPredictionHandlerWithConfig(&fakeModelClient{}, prometheus, log, PredictionHandlerConfig{
+			EnableFeatureEngineering: true,
+			LookbackHours:            -1,
+		})
+
+		resp := get(t, handler)
+		assert.Equal(t, FeatureEngineeringInvalidConfig, resp.FeatureEngineering.Status)
+		assert.Equal(t, PrometheusStatusAvailable, resp.Prometheus)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		handler := NewPredictionHandlerWithConfig(&fakeModelClient{}, nil, log, PredictionHandlerConfig{EnableFeatureEngineering: true})
+		builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, log)
+		require.NoError(t, err)
+		handler.featureBuilder = builder
+
+		resp := get(t, handler)
+		assert.Equal(t, FeatureEngineeringEnabled, resp.FeatureEngineering.Status)
+		assert.Equal(t, FeatureStrategyEngineered, resp.FeatureEngineering.FeatureStrategy)
+		assert.Equal(t, builder.FeatureCount(), resp.FeatureEngineering.FeatureCount)
+		assert.Equal(t, 2, resp.FeatureEngineering.LookbackHours)
+	})
+}
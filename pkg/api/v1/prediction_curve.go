@@ -0,0 +1,292 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+// curveHours is the number of hourly points in a prediction curve
+const curveHours = 24
+
+// PredictCurveResponse is the predicted CPU/memory curve of one scope over a whole day
+type PredictCurveResponse struct {
+	Status string `json:"status"`
+	Scope  string `json:"scope"`
+	Target string `json:"target"`
+
+	// PeakHour is the hour with the highest predicted value of Summary.Metric
+	PeakHour int          `json:"peak_hour"`
+	Summary  CurveSummary `json:"summary"`
+
+	DayOfWeek      int            `json:"day_of_week"`
+	Date           string         `json:"date"`   // YYYY-MM-DD, UTC
+	Points         []CurvePoint   `json:"points"` // One per hour to 23, from 0 or, for today, the current hour
+	CurrentMetrics CurrentMetrics `json:"current_metrics"`
+	ModelInfo      ModelInfo      `json:"model_info"`
+}
+
+// CurvePoint is the prediction for one hour of the curve
+type CurvePoint struct {
+	Hour         int              `json:"hour"`
+	ISOTimestamp string           `json:"iso_timestamp"`
+	Predictions  PredictionValues `json:"predictions"`
+	Confidence   float64          `json:"confidence"`
+}
+
+// CurveSummary describes the curve of one metric
+type CurveSummary struct {
+	Metric      string  `json:"metric"` // cpu_percent or memory_percent
+	PeakHour    int     `json:"peak_hour"`
+	PeakValue   float64 `json:"peak_value"`
+	TroughHour  int     `json:"trough_hour"`
+	TroughValue float64 `json:"trough_value"`
+	Mean        float64 `json:"mean"`
+}
+
+// HandlePredictCurve handles GET /api/v1/predict/curve
+//
+// Metric features are built once for the scope; each hour's prediction only changes the time
+// features, so the 24 points cost one feature build and 24 KServe calls.
+//
+// @Summary Predict a scope's resource usage for every hour of a day
+// @Description Returns 24 hourly CPU/memory predictions for one day with the peak and trough hours
+// @Tags prediction
+// @Produce json
+// @Param day_of_week query int true "Day of week (0=Monday, 6=Sunday)"
+// @Param namespace query string false "Namespace filter"
+// @Param deployment query string false "Deployment filter"
+// @Param pod query string false "Pod filter"
+// @Param scope query string false "pod, deployment, namespace or cluster (default: namespace)"
+// @Param model query string false "KServe model name (default: predictive-analytics)"
+// @Param metric query string false "Metric summarized: cpu_percent (default) or memory_percent"
+// @Success 200 {object} PredictCurveResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Failure 500 {object} PredictErrorResponse
+// @Failure 503 {object} PredictErrorResponse
+// @Router /api/v1/predict/curve [get]
+func (h *PredictionHandler) HandlePredictCurve(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
+
+	req, metric, err := h.parseCurveRequest(r)
+	if err != nil {
+		h.handleRequestError(w, err)
+		return
+	}
+
+	if err := h.validateKServeAvailability(ctx, req.Model); err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	// Raw-metric instances carry no time features, so every hour would predict the same value
+	if !h.usesFeatureEngineering(req.Model) {
+		h.respondError(w, http.StatusServiceUnavailable, "Prediction curve unavailable",
+			fmt.Sprintf("model %s is not served engineered features; a curve requires feature engineering and Prometheus", req.Model),
+			ErrCodeFeaturesUnavailable)
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"day_of_week": req.DayOfWeek,
+		"target":      h.getTarget(req),
+		"model":       req.Model,
+		"metric":      metric,
+	}).Info("Processing prediction curve request")
+
+	release, ok := h.acquirePredictionSlot(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	now := time.Now().UTC()
+	response, err := h.predictCurve(ctx, req, curveDate(now, req.DayOfWeek), now)
+	if err != nil {
+		h.handleServiceError(w, err)
+		return
+	}
+	response.Summary = summarizeCurve(response.Points, metric)
+	response.PeakHour = response.Summary.PeakHour
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"target":     response.Target,
+		"peak_hour":  response.PeakHour,
+		"peak_value": response.Summary.PeakValue,
+	}).Info("Prediction curve completed")
+	h.respondJSON(w, http.StatusOK, response)
+}
+
+// parseCurveRequest reads the curve query parameters into a prediction request and the
+// summarized metric
+func (h *PredictionHandler) parseCurveRequest(r *http.Request) (*PredictRequest, string, error) {
+	query := r.URL.Query()
+
+	dayParam := query.Get("day_of_week")
+	if dayParam == "" {
+		return nil, "", &requestError{message: "day_of_week is required", code: ErrCodeInvalidRequest}
+	}
+	day, err := strconv.Atoi(dayParam)
+	if err != nil {
+		return nil, "", &requestError{message: "day_of_week must be an integer", details: fmt.Sprintf("got %q", dayParam), code: ErrCodeInvalidRequest}
+	}
+
+	req := &PredictRequest{PredictRequest: types.PredictRequest{
+		DayOfWeek:  day,
+		Namespace:  query.Get("namespace"),
+		Deployment: query.Get("deployment"),
+		Pod:        query.Get("pod"),
+		Scope:      query.Get("scope"),
+		Model:      query.Get("model"),
+	}}
+	if err := h.validateRequest(req); err != nil {
+		return nil, "", &requestError{message: err.Error(), code: ErrCodeInvalidRequest}
+	}
+
+	metric := query.Get("metric")
+	switch metric {
+	case "":
+		metric = CompareSortCPU
+	case CompareSortCPU, CompareSortMemory:
+	default:
+		return nil, "", &requestError{message: fmt.Sprintf("metric must be one of: %s, %s", CompareSortCPU, CompareSortMemory), code: ErrCodeInvalidRequest}
+	}
+
+	h.setRequestDefaults(req)
+	if err := h.checkTarget(r.Context(), req); err != nil {
+		return nil, "", err
+	}
+	return req, metric, nil
+}
+
+// curveDate returns midnight UTC of the next day matching dayOfWeek (0=Monday), today included
+func curveDate(now time.Time, dayOfWeek int) time.Time {
+	goDay := (dayOfWeek + 1) % 7 // Go uses Sunday=0
+	daysUntil := (goDay - int(now.Weekday()) + 7) % 7
+	date := now.AddDate(0, 0, daysUntil)
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// curveStartHour returns the first hour of date the curve predicts: the current hour when date
+// is today, since earlier hours have already passed, and 0 otherwise
+func curveStartHour(date, now time.Time) int {
+	if now.UTC().Before(date) {
+		return 0
+	}
+	return now.UTC().Hour()
+}
+
+// predictCurve builds the scope's metric features once and predicts each remaining hour of
+// date by re-timing them. Any failed hour fails the curve.
+func (h *PredictionHandler) predictCurve(ctx context.Context, req *PredictRequest, date, now time.Time) (*PredictCurveResponse, error) {
+	cpuRollingMean, memoryRollingMean := h.getMetricsWithDefaults(ctx, req)
+
+	vector, err := h.featureBuilder.BuildFeaturesWithTime(ctx, h.featureBuilder.BuildTargetTimeFeatureWindow(now, date), req.Namespace, req.Deployment, req.Pod)
+	if err != nil {
+		return nil, &serviceError{message: "Feature engineering failed", details: err.Error(), code: ErrCodeFeaturesUnavailable}
+	}
+	h.logPredictionInstances(ctx, vector.FeatureCount, cpuRollingMean, memoryRollingMean)
+
+	startHour := curveStartHour(date, now)
+	points := make([]CurvePoint, curveHours-startHour)
+	versions := make([]string, len(points))
+	errs := make([]error, len(points))
+	sem := make(chan struct{}, compareConcurrency)
+	var wg sync.WaitGroup
+	for i := range points {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			hour := startHour + i
+			target := date.Add(time.Duration(hour) * time.Hour)
+			instance, err := h.featureBuilder.WithTimeFeatures(vector.Features, h.featureBuilder.BuildTargetTimeFeatureWindow(now, target))
+			if err != nil {
+				errs[i] = &serviceError{message: "Feature engineering failed", details: err.Error(), code: ErrCodeFeaturesUnavailable}
+				return
+			}
+			predictions, confidence, modelVersion, err := h.executePrediction(ctx, req.Model, [][]float64{instance}, cpuRollingMean, memoryRollingMean)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			points[i] = CurvePoint{
+				Hour:         hour,
+				ISOTimestamp: target.Format(time.RFC3339),
+				Predictions:  predictions.PredictionValues,
+				Confidence:   confidence,
+			}
+			versions[i] = modelVersion
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		var svcErr *serviceError
+		if errors.As(err, &svcErr) {
+			svcErr.details = fmt.Sprintf("hour %d: %s", startHour+i, svcErr.details)
+			return nil, svcErr
+		}
+		return nil, fmt.Errorf("hour %d: %w", startHour+i, err)
+	}
+
+	// Current metrics and model info are shared by all points; confidence is the lowest point's
+	shared := h.buildPredictResponse(req, modelPrediction{}, points[0].Confidence, versions[0], cpuRollingMean, memoryRollingMean, h.featureVectorSnapshot(vector))
+	for _, point := range points {
+		shared.ModelInfo.Confidence = min(shared.ModelInfo.Confidence, point.Confidence)
+	}
+	return &PredictCurveResponse{
+		Status:         "success",
+		Scope:          shared.Scope,
+		Target:         shared.Target,
+		DayOfWeek:      req.DayOfWeek,
+		Date:           date.Format(time.DateOnly),
+		Points:         points,
+		CurrentMetrics: shared.CurrentMetrics,
+		ModelInfo:      shared.ModelInfo,
+	}, nil
+}
+
+// summarizeCurve finds the peak and trough of metric over points. Ties go to the earliest hour.
+func summarizeCurve(points []CurvePoint, metric string) CurveSummary {
+	value := func(p PredictionValues) float64 {
+		if metric == CompareSortMemory {
+			return p.MemoryPercent
+		}
+		return p.CPUPercent
+	}
+
+	summary := CurveSummary{Metric: metric}
+	if len(points) == 0 {
+		return summary
+	}
+	summary.PeakHour, summary.PeakValue = points[0].Hour, value(points[0].Predictions)
+	summary.TroughHour, summary.TroughValue = summary.PeakHour, summary.PeakValue
+	sum := 0.0
+	for _, point := range points {
+		v := value(point.Predictions)
+		sum += v
+		if v > summary.PeakValue {
+			summary.PeakHour, summary.PeakValue = point.Hour, v
+		}
+		if v < summary.TroughValue {
+			summary.TroughHour, summary.TroughValue = point.Hour, v
+		}
+	}
+	summary.Mean = sum / float64(len(points))
+	return summary
+}
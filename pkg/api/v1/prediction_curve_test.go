@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurveDate(t *testing.T) {
+	wednesday := time.Date(2026, 1, 7, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), curveDate(wednesday, 2), "today")
+	assert.Equal(t, time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC), curveDate(wednesday, 6), "coming Sunday")
+	assert.Equal(t, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), curveDate(wednesday, 0), "next Monday")
+}
+
+func TestCurveStartHour(t *testing.T) {
+	wednesday := time.Date(2026, 1, 7, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, 15, curveStartHour(curveDate(wednesday, 2), wednesday), "today starts at the current hour")
+	assert.Equal(t, 0, curveStartHour(curveDate(wednesday, 6), wednesday), "later days start at midnight")
+}
+
+func TestHandleServiceError_OtherErrors(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewPredictionHandler(nil, nil, log)
+
+	w := httptest.NewRecorder()
+	handler.handleServiceError(w, fmt.Errorf("hour 3: %w", errors.New("malformed model response")))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var resp PredictErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, ErrCodePredictionFailed, resp.Code)
+	assert.Contains(t, resp.Details, "hour 3")
+}
+
+func TestSummarizeCurve(t *testing.T) {
+	points := make([]CurvePoint, curveHours)
+	for hour := range points {
+		points[hour] = CurvePoint{Hour: hour, Predictions: PredictionValues{CPUPercent: 50, MemoryPercent: 60}}
+	}
+	points[14].Predictions.CPUPercent = 90
+	points[3].Predictions.CPUPercent = 20
+	points[20].Predictions.MemoryPercent = 85
+
+	cpu := summarizeCurve(points, CompareSortCPU)
+	assert.Equal(t, CompareSortCPU, cpu.Metric)
+	assert.Equal(t, 14, cpu.PeakHour)
+	assert.Equal(t, 90.0, cpu.PeakValue)
+	assert.Equal(t, 3, cpu.TroughHour)
+	assert.Equal(t, 20.0, cpu.TroughValue)
+	assert.InDelta(t, (22*50.0+90+20)/24, cpu.Mean, 0.001)
+
+	memory := summarizeCurve(points, CompareSortMemory)
+	assert.Equal(t, 20, memory.PeakHour)
+	assert.Equal(t, 0, memory.TroughHour, "ties go to the earliest hour")
+}
+
+func TestHandlePredictCurve_InvalidRequest(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewPredictionHandler(nil, nil, log)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing day_of_week", "namespace=my-app"},
+		{"non-numeric day_of_week", "namespace=my-app&day_of_week=monday"},
+		{"day_of_week out of range", "namespace=my-app&day_of_week=7"},
+		{"unknown metric", "namespace=my-app&day_of_week=1&metric=disk_percent"},
+		{"deployment scope without deployment", "namespace=my-app&day_of_week=1&scope=deployment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.HandlePredictCurve(w, httptest.NewRequest(http.MethodGet, "/api/v1/predict/curve?"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var resp PredictErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+		})
+	}
+}
+
+func TestHandlePredictCurve_RequiresKServe(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewPredictionHandler(nil, nil, log)
+
+	w := httptest.NewRecorder()
+	handler.HandlePredictCurve(w, httptest.NewRequest(http.MethodGet, "/api/v1/predict/curve?namespace=my-app&day_of_week=1", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var resp PredictErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, ErrCodeKServeUnavailable, resp.Code)
+}
@@ -0,0 +1,288 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+// FeatureCompareRequest is the request body for diffing engineered features against a
+// vector recorded from the training pipeline
+type FeatureCompareRequest struct {
+	Reference  []float64  `json:"reference"`            // Required: full feature vector from training
+	Namespace  string     `json:"namespace,omitempty"`  // Optional: scope the vector was recorded for
+	Deployment string     `json:"deployment,omitempty"` // Optional
+	Pod        string     `json:"pod,omitempty"`        // Optional
+	Timestamp  *time.Time `json:"timestamp,omitempty"`  // Optional: window end the reference was captured at (default: now)
+}
+
+// FeatureCompareResponse lists the features that differ from the reference
+type FeatureCompareResponse struct {
+	Status        string                     `json:"status"`
+	FeatureCount  int                        `json:"feature_count"`
+	MismatchCount int                        `json:"mismatch_count"`
+	Timestamp     time.Time                  `json:"timestamp"`
+	Mismatches    []features.FeatureMismatch `json:"mismatches"`
+}
+
+// FeatureCompareMaxBodyBytes caps POST /api/v1/debug/features/compare bodies. A full reference
+// vector for the default 24h lookback is tens of kilobytes, larger than the default
+// MAX_REQUEST_BODY_BYTES, which still applies when set higher.
+const FeatureCompareMaxBodyBytes = 1 << 20
+
+// HandleCompareFeatures handles POST /api/v1/debug/features/compare
+//
+// @Summary Diff engineered features against a training reference
+// @Description Builds the current predictive-analytics feature vector and reports every element that differs from the supplied reference
+// @Tags prediction
+// @Accept json
+// @Produce json
+// @Param request body FeatureCompareRequest true "Reference vector"
+// @Success 200 {object} FeatureCompareResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Failure 503 {object} PredictErrorResponse
+// @Router /api/v1/debug/features/compare [post]
+func (h *PredictionHandler) HandleCompareFeatures(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+
+	if h.featureBuilder == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "Feature engineering not enabled",
+			"Set ENABLE_FEATURE_ENGINEERING=true with Prometheus configured", ErrCodeFeaturesUnavailable)
+		return
+	}
+
+	var req FeatureCompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.handleRequestError(w, decodeRequestError("Invalid request format", err))
+		return
+	}
+	if len(req.Reference) == 0 {
+		h.respondError(w, http.StatusBadRequest, "reference is required", "", ErrCodeInvalidRequest)
+		return
+	}
+
+	end := time.Now()
+	if req.Timestamp != nil {
+		end = *req.Timestamp
+	}
+	window := h.featureBuilder.BuildTimeFeatureWindow(end)
+
+	mismatches, err := h.featureBuilder.CompareToReferenceWithTime(ctx, window, req.Namespace, req.Deployment, req.Pod, req.Reference)
+	switch {
+	case errors.Is(err, features.ErrReferenceLengthMismatch), errors.Is(err, features.ErrInvalidScopeIdentifier):
+		h.respondError(w, http.StatusBadRequest, "Invalid reference comparison", err.Error(), ErrCodeInvalidRequest)
+		return
+	case err != nil:
+		h.respondError(w, http.StatusServiceUnavailable, "Failed to build features", err.Error(), ErrCodePrometheusUnavailable)
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"feature_count":  len(req.Reference),
+		"mismatch_count": len(mismatches),
+	}).Info("Compared engineered features against reference")
+
+	h.respondJSON(w, http.StatusOK, FeatureCompareResponse{
+		Status:        "success",
+		FeatureCount:  len(req.Reference),
+		MismatchCount: len(mismatches),
+		Timestamp:     end,
+		Mismatches:    mismatches,
+	})
+}
+
+// FeatureQueryPlanResponse lists the Prometheus queries a prediction's feature build would execute
+type FeatureQueryPlanResponse struct {
+	Status     string `json:"status"`
+	Namespace  string `json:"namespace,omitempty"`
+	Deployment string `json:"deployment,omitempty"`
+	Pod        string `json:"pod,omitempty"`
+
+	// QueryCount counts every planned query; UniqueQueryCount counts distinct ones, so the
+	// difference is what reusing results within one build would save
+	QueryCount       int            `json:"query_count"`
+	UniqueQueryCount int            `json:"unique_query_count"`
+	QueriesByType    map[string]int `json:"queries_by_type"`
+
+	Queries []features.QueryPlanEntry `json:"queries"`
+}
+
+// HandleFeatureQueryPlan handles GET /api/v1/debug/features/queries
+//
+// @Summary Plan the Prometheus queries of a feature build
+// @Description Lists the queries, time ranges and steps the predictive-analytics feature builder would execute for a scope right now, without executing them
+// @Tags prediction
+// @Produce json
+// @Param namespace query string false "Namespace scope"
+// @Param deployment query string false "Deployment scope"
+// @Param pod query string false "Pod scope"
+// @Param query_step query string false "Range query step override, as in the predict request"
+// @Success 200 {object} FeatureQueryPlanResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Failure 503 {object} PredictErrorResponse
+// @Router /api/v1/debug/features/queries [get]
+func (h *PredictionHandler) HandleFeatureQueryPlan(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+
+	if h.featureBuilder == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "Feature engineering not enabled",
+			"Set ENABLE_FEATURE_ENGINEERING=true with Prometheus configured", ErrCodeFeaturesUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	namespace, deployment, pod := query.Get("namespace"), query.Get("deployment"), query.Get("pod")
+	if raw := query.Get("query_step"); raw != "" {
+		step, err := time.ParseDuration(raw)
+		if err == nil && step <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+		if err == nil {
+			err = h.featureBuilder.ValidateQueryStep(step)
+		}
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid query_step", err.Error(), ErrCodeInvalidRequest)
+			return
+		}
+		ctx = features.WithQueryStep(ctx, step)
+	}
+	plan, err := h.featureBuilder.PlanQueries(ctx, namespace, deployment, pod)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid scope", err.Error(), ErrCodeInvalidRequest)
+		return
+	}
+
+	resp := FeatureQueryPlanResponse{
+		Status:        "success",
+		Namespace:     namespace,
+		Deployment:    deployment,
+		Pod:           pod,
+		QueryCount:    len(plan),
+		QueriesByType: make(map[string]int),
+		Queries:       plan,
+	}
+	unique := make(map[queryPlanKey]bool)
+	for _, entry := range plan {
+		resp.QueriesByType[entry.Type]++
+		unique[newQueryPlanKey(entry)] = true
+	}
+	resp.UniqueQueryCount = len(unique)
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"query_count":        resp.QueryCount,
+		"unique_query_count": resp.UniqueQueryCount,
+	}).Info("Planned feature build queries")
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// queryPlanKey identifies a planned query by value; entries hold their times by pointer
+type queryPlanKey struct {
+	queryType, promql, step string
+	at, start, end          int64
+}
+
+func newQueryPlanKey(entry features.QueryPlanEntry) queryPlanKey {
+	unixNano := func(t *time.Time) int64 {
+		if t == nil {
+			return 0
+		}
+		return t.UnixNano()
+	}
+	return queryPlanKey{
+		queryType: entry.Type,
+		promql:    entry.PromQL,
+		step:      entry.Step,
+		at:        unixNano(entry.At),
+		start:     unixNano(entry.Start),
+		end:       unixNano(entry.End),
+	}
+}
+
+// BaselinesResponse lists the per-scope baselines learned from Prometheus
+type BaselinesResponse struct {
+	Status    string          `json:"status"`
+	Count     int             `json:"count"`
+	Baselines []ScopeBaseline `json:"baselines"`
+}
+
+// HandleListBaselines handles GET /api/v1/debug/baselines
+//
+// @Summary List learned prediction baselines
+// @Description Lists the per-scope exponential moving averages of CPU/memory rolling means used when Prometheus is unavailable, most recently used first
+// @Tags prediction
+// @Produce json
+// @Success 200 {object} BaselinesResponse
+// @Router /api/v1/debug/baselines [get]
+func (h *PredictionHandler) HandleListBaselines(w http.ResponseWriter, r *http.Request) {
+	baselines := h.baselines.snapshot()
+	h.respondJSON(w, http.StatusOK, BaselinesResponse{
+		Status:    "success",
+		Count:     len(baselines),
+		Baselines: baselines,
+	})
+}
+
+// FeaturesInfoResponse compares the features sent to the predictive-analytics model with the
+// count it is configured to expect
+type FeaturesInfoResponse struct {
+	Status          string `json:"status"`
+	Model           string `json:"model"`
+	FeatureStrategy string `json:"feature_strategy"` // engineered or raw_metrics
+	FeatureCount    int    `json:"feature_count"`    // Features sent per prediction
+
+	// ExpectedFeatureCount is FEATURE_ENGINEERING_EXPECTED_COUNT (0 = not configured)
+	ExpectedFeatureCount int `json:"expected_feature_count"`
+
+	// Matches is true only when an expected count is configured and equals FeatureCount
+	Matches bool   `json:"matches"`
+	Message string `json:"message,omitempty"` // Explains a mismatch or a missing expected count
+
+	// FeatureInfo breaks down the engineered feature count; omitted for raw metrics
+	FeatureInfo *features.FeatureInfo `json:"feature_info,omitempty"`
+}
+
+// HandleFeaturesInfo handles GET /api/v1/features/info
+//
+// It lets startup probes and CI verify the feature count without making a prediction; the
+// same mismatch is otherwise only logged when the feature builder is created.
+//
+// @Summary Check the predictive-analytics feature count
+// @Description Reports the features sent per predictive-analytics prediction, the configured expected count, whether they match, and how the engineered count is built
+// @Tags prediction
+// @Produce json
+// @Success 200 {object} FeaturesInfoResponse
+// @Router /api/v1/features/info [get]
+func (h *PredictionHandler) HandleFeaturesInfo(w http.ResponseWriter, r *http.Request) {
+	const model = DefaultPredictionModel
+	strategy, count := h.DescribeModelFeatures(model)
+
+	resp := FeaturesInfoResponse{
+		Status:               "success",
+		Model:                model,
+		FeatureStrategy:      strategy,
+		FeatureCount:         count,
+		ExpectedFeatureCount: h.expectedFeatureCount,
+		Matches:              h.expectedFeatureCount > 0 && count == h.expectedFeatureCount,
+	}
+	if strategy == FeatureStrategyEngineered {
+		resp.FeatureInfo = h.GetFeatureInfo()
+	}
+
+	switch {
+	case h.expectedFeatureCount <= 0:
+		resp.Message = "No expected feature count configured; set FEATURE_ENGINEERING_EXPECTED_COUNT to the model's input size"
+	case !resp.Matches:
+		resp.Message = fmt.Sprintf("Sending %d %s features but the model expects %d; predictions may be rejected",
+			count, strategy, h.expectedFeatureCount)
+	}
+
+	h.respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+func TestPredictionHandler_HandleCompareFeatures(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	doCompare := func(handler *PredictionHandler, reference []float64) (*httptest.ResponseRecorder, PredictErrorResponse) {
+		body, _ := json.Marshal(FeatureCompareRequest{Reference: reference})
+		req := httptest.NewRequest("POST", "/api/v1/debug/features/compare", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.HandleCompareFeatures(w, req)
+
+		var resp PredictErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w, resp
+	}
+
+	t.Run("feature engineering disabled", func(t *testing.T) {
+		w, resp := doCompare(NewPredictionHandler(nil, nil, log), []float64{1})
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, ErrCodeFeaturesUnavailable, resp.Code)
+	})
+
+	handler := NewPredictionHandler(nil, nil, log)
+	builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, log)
+	require.NoError(t, err)
+	handler.featureBuilder = builder
+
+	t.Run("empty reference", func(t *testing.T) {
+		w, resp := doCompare(handler, nil)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+	})
+
+	t.Run("reference length mismatch", func(t *testing.T) {
+		w, resp := doCompare(handler, []float64{1, 2, 3})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+		assert.Contains(t, resp.Details, "reference vector length mismatch")
+	})
+
+	t.Run("metrics unavailable", func(t *testing.T) {
+		w, resp := doCompare(handler, make([]float64, builder.FeatureCount()))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, ErrCodePrometheusUnavailable, resp.Code)
+	})
+
+	t.Run("reference larger than the default body limit", func(t *testing.T) {
+		router := mux.NewRouter()
+		router.Use(middleware.MaxBodySize(16 << 10))
+		handler.RegisterRoutes(router)
+
+		reference := make([]float64, 4000)
+		for i := range reference {
+			reference[i] = 0.123456789
+		}
+		body, err := json.Marshal(FeatureCompareRequest{Reference: reference})
+		require.NoError(t, err)
+		require.Greater(t, len(body), 16<<10)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/debug/features/compare", bytes.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, "decoded and rejected for its length, not its size")
+		assert.Contains(t, w.Body.String(), "reference vector length mismatch")
+	})
+}
+
+func TestPredictionHandler_HandleFeatureQueryPlan(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	getPlan := func(handler *PredictionHandler, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.HandleFeatureQueryPlan(w, httptest.NewRequest("GET", target, http.NoBody))
+		return w
+	}
+
+	t.Run("feature engineering disabled", func(t *testing.T) {
+		w := getPlan(NewPredictionHandler(nil, nil, log), "/api/v1/debug/features/queries")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	handler := NewPredictionHandler(nil, nil, log)
+	builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{
+		LookbackHours: 2, Enabled: true, LagPeriods: []int{1, 2}, RollingWindows: []int{3},
+	}, log)
+	require.NoError(t, err)
+	handler.featureBuilder = builder
+
+	t.Run("point queries", func(t *testing.T) {
+		w := getPlan(handler, "/api/v1/debug/features/queries?namespace=payments&deployment=api")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp FeatureQueryPlanResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		metrics := len(features.GetPredictiveBaseMetrics())
+		// Per timestep and metric: raw value, current value, 2 lags and 1 rolling window
+		assert.Equal(t, 2*metrics*5, resp.QueryCount)
+		assert.Len(t, resp.Queries, resp.QueryCount)
+		assert.Equal(t, 2*metrics*4, resp.QueriesByType[features.QueryTypeInstantAt])
+		assert.Equal(t, 2*metrics, resp.QueriesByType[features.QueryTypeRange])
+		// Instant queries at now, now-1h, now-2h and now-3h; the two rolling windows
+		assert.Equal(t, metrics*(4+2), resp.UniqueQueryCount)
+		assert.Equal(t, "payments", resp.Namespace)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		w := getPlan(handler, "/api/v1/debug/features/queries?namespace=Not_Valid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPredictionHandler_HandleFeaturesInfo(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	getInfo := func(handler *PredictionHandler) FeaturesInfoResponse {
+		w := httptest.NewRecorder()
+		handler.HandleFeaturesInfo(w, httptest.NewRequest("GET", "/api/v1/features/info", http.NoBody))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp FeaturesInfoResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+	engineered := func(expected int) *PredictionHandler {
+		config := DefaultPredictionHandlerConfig()
+		config.EnableFeatureEngineering = true
+		config.ExpectedFeatureCount = expected
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, config)
+		builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{LookbackHours: 24, Enabled: true}, log)
+		require.NoError(t, err)
+		handler.featureBuilder = builder
+		return handler
+	}
+
+	t.Run("engineered count matches", func(t *testing.T) {
+		resp := getInfo(engineered(3264))
+
+		assert.Equal(t, FeatureStrategyEngineered, resp.FeatureStrategy)
+		assert.Equal(t, 3264, resp.FeatureCount)
+		assert.True(t, resp.Matches)
+		assert.Empty(t, resp.Message)
+		require.NotNil(t, resp.FeatureInfo)
+		assert.Equal(t, 136, resp.FeatureInfo.Breakdown.ColumnsPerTimestep)
+		assert.Equal(t, 3264, resp.FeatureInfo.Breakdown.Total)
+	})
+
+	t.Run("engineered count mismatch", func(t *testing.T) {
+		resp := getInfo(engineered(3200))
+
+		assert.False(t, resp.Matches)
+		assert.Equal(t, 3200, resp.ExpectedFeatureCount)
+		assert.Contains(t, resp.Message, "expects 3200")
+	})
+
+	t.Run("raw metrics against an engineered model", func(t *testing.T) {
+		config := DefaultPredictionHandlerConfig()
+		config.ExpectedFeatureCount = 3264
+		resp := getInfo(NewPredictionHandlerWithConfig(nil, nil, log, config))
+
+		assert.Equal(t, FeatureStrategyRawMetrics, resp.FeatureStrategy)
+		assert.Equal(t, rawMetricFeatureCount, resp.FeatureCount)
+		assert.False(t, resp.Matches)
+		assert.Nil(t, resp.FeatureInfo)
+	})
+
+	t.Run("no expected count configured", func(t *testing.T) {
+		resp := getInfo(engineered(0))
+
+		assert.False(t, resp.Matches)
+		assert.Contains(t, resp.Message, "FEATURE_ENGINEERING_EXPECTED_COUNT")
+	})
+}
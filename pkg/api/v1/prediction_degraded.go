@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Degraded-mode responses are flagged by status and data_quality so clients can tell them
+// apart from model predictions
+const (
+	PredictStatusDegraded = "degraded"
+	DataQualityHeuristic  = "heuristic"
+	HeuristicModelVersion = "heuristic"
+)
+
+// Parameters of the degraded-mode heuristic
+const (
+	heuristicPeakHour      = 14   // Hour of day the diurnal adjustment peaks at
+	heuristicCPUAmplitude  = 0.15 // Relative CPU increase at the peak, and decrease at the trough
+	heuristicMemAmplitude  = 0.05 // Memory follows load far less than CPU does
+	heuristicConfidence    = 0.2  // Both metrics come from a learned baseline
+	heuristicLowConfidence = 0.1  // At least one metric is a current reading or a global default
+)
+
+// kserveUnreachable returns err as a serviceError if it means KServe could not be reached at
+// all, as opposed to a model that answered with an error or an unusable response
+func kserveUnreachable(err error) (*serviceError, bool) {
+	var svcErr *serviceError
+	if errors.As(err, &svcErr) && svcErr.kserveUnreachable {
+		return svcErr, true
+	}
+	return nil, false
+}
+
+// respondDegraded answers a prediction KServe could not serve with a heuristic one, if degraded
+// mode is enabled and KServe was unreachable. It reports whether it responded.
+func (h *PredictionHandler) respondDegraded(ctx context.Context, w http.ResponseWriter, req *PredictRequest, cause error) bool {
+	if !h.degradedMode {
+		return false
+	}
+	svcErr, ok := kserveUnreachable(cause)
+	if !ok {
+		return false
+	}
+	response := h.heuristicPrediction(ctx, req, svcErr.message+": "+svcErr.details)
+	h.log.WithContext(ctx).WithError(cause).WithFields(logrus.Fields{
+		"scope":          response.Scope,
+		"target":         response.Target,
+		"cpu_percent":    response.Predictions.CPUPercent,
+		"memory_percent": response.Predictions.MemoryPercent,
+		"confidence":     response.ModelInfo.Confidence,
+	}).Warn("KServe unreachable, serving degraded heuristic prediction")
+	h.respondJSON(w, http.StatusOK, selectFields(&response, req.Fields))
+	return true
+}
+
+// heuristicPrediction estimates CPU and memory from the scope's learned EMA baseline, scaled by
+// a simple hour-of-day curve peaking at heuristicPeakHour. Metrics without a baseline use the
+// current reading, or the global default when Prometheus is also unavailable, and lower the
+// confidence further.
+func (h *PredictionHandler) heuristicPrediction(ctx context.Context, req *PredictRequest, reason string) PredictResponse {
+	cpuRollingMean, memoryRollingMean := h.getMetricsWithDefaults(ctx, req)
+	baseline, _ := h.baselines.lookup(snapshotScope(req))
+
+	cpuBase, memoryBase, confidence := cpuRollingMean, memoryRollingMean, heuristicConfidence
+	if baseline.CPUSamples > 0 {
+		cpuBase = baseline.CPURollingMean
+	} else {
+		confidence = heuristicLowConfidence
+	}
+	if baseline.MemorySamples > 0 {
+		memoryBase = baseline.MemoryRollingMean
+	} else {
+		confidence = heuristicLowConfidence
+	}
+
+	predictions := modelPrediction{PredictionValues: PredictionValues{
+		CPUPercent:    heuristicPercent(cpuBase, req.Hour, heuristicCPUAmplitude),
+		MemoryPercent: heuristicPercent(memoryBase, req.Hour, heuristicMemAmplitude),
+	}}
+	response := h.buildPredictResponse(req, predictions, confidence, HeuristicModelVersion, cpuRollingMean, memoryRollingMean, h.defaultRawMetricSnapshot())
+	response.Status = PredictStatusDegraded
+	response.DataQuality = DataQualityHeuristic
+	response.DegradedReason = reason
+	response.RawModelResponse = nil
+	return response
+}
+
+// heuristicPercent scales a 0-1 rolling mean by the hour-of-day adjustment and returns it as a
+// percentage capped at 100
+func heuristicPercent(rollingMean float64, hour int, amplitude float64) float64 {
+	adjustment := 1 + amplitude*math.Cos(2*math.Pi*float64(hour-heuristicPeakHour)/24)
+	return math.Min(rollingMean*adjustment*100, 100)
+}
+
+// defaultRawMetricSnapshot reports the default disk and network values, all marked defaulted,
+// for responses that were not built from a feature vector
+func (h *PredictionHandler) defaultRawMetricSnapshot() rawMetricSnapshot {
+	return rawMetricSnapshot{
+		diskUsage:  h.defaultDiskUsage,
+		networkIn:  h.defaultNetworkIn,
+		networkOut: h.defaultNetworkOut,
+		defaulted:  []string{"disk_usage", "network_in", "network_out"},
+	}
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+func postPredict(t *testing.T, handler *PredictionHandler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/predict", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	handler.HandlePredict(rr, req)
+	return rr
+}
+
+// TestPredictionHandler_DegradedMode verifies that an unreachable KServe yields a flagged
+// heuristic prediction only when degraded mode is on
+func TestPredictionHandler_DegradedMode(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	body := `{"hour": 14, "day_of_week": 2, "namespace": "payments"}`
+
+	t.Run("disabled returns 503", func(t *testing.T) {
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{})
+		rr := postPredict(t, handler, body)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+
+	t.Run("learned baseline at the peak hour", func(t *testing.T) {
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{DegradedMode: true})
+		handler.baselines.observe(integrations.MetricsScope{Namespace: "payments"},
+			integrations.RollingMeans{CPU: 0.4, Memory: 0.5, CPUOK: true, MemoryOK: true})
+
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, PredictStatusDegraded, response.Status)
+		assert.Equal(t, DataQualityHeuristic, response.DataQuality)
+		assert.Contains(t, response.DegradedReason, "KServe client is not configured")
+		assert.Equal(t, HeuristicModelVersion, response.ModelInfo.Version)
+		assert.Equal(t, heuristicConfidence, response.ModelInfo.Confidence)
+		assert.InDelta(t, 40*(1+heuristicCPUAmplitude), response.Predictions.CPUPercent, 0.0001)
+		assert.InDelta(t, 50*(1+heuristicMemAmplitude), response.Predictions.MemoryPercent, 0.0001)
+	})
+
+	t.Run("no baseline lowers confidence", func(t *testing.T) {
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{DegradedMode: true})
+		rr := postPredict(t, handler, `{"hour": 2, "day_of_week": 2, "namespace": "checkout"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, heuristicLowConfidence, response.ModelInfo.Confidence)
+		assert.InDelta(t, handler.defaultCPURollingMean*100*(1-heuristicCPUAmplitude), response.Predictions.CPUPercent, 0.0001,
+			"the trough is 12 hours from the peak")
+	})
+
+	t.Run("model call that cannot connect", func(t *testing.T) {
+		client := &fakeModelClient{
+			models: map[string]bool{"predictive-analytics": true},
+			err:    &kserve.ModelUnavailableError{ModelName: "predictive-analytics", Cause: errors.New("connection refused")},
+		}
+		handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{DegradedMode: true})
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, PredictStatusDegraded, response.Status)
+		assert.Contains(t, response.DegradedReason, "connection refused")
+	})
+
+	t.Run("model errors still fail", func(t *testing.T) {
+		client := &fakeModelClient{
+			models: map[string]bool{"predictive-analytics": true},
+			err:    errors.New("model returned status 500"),
+		}
+		handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{DegradedMode: true})
+		rr := postPredict(t, handler, body)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxModelChain bounds the models one prediction request may list in models
+const maxModelChain = 5
+
+// modelFailure is why one model of a fallback chain could not serve a prediction
+type modelFailure struct {
+	model string
+	err   error
+}
+
+// modelChain returns the models req tries in order: models when set, else model
+func (r *PredictRequest) modelChain() []string {
+	if len(r.Models) > 0 {
+		return r.Models
+	}
+	return []string{r.Model}
+}
+
+// validateModels checks the models fallback chain: at most maxModelChain distinct, non-empty
+// names, not combined with model
+func validateModels(req *PredictRequest) error {
+	if len(req.Models) == 0 {
+		return nil
+	}
+	if req.Model != "" {
+		return fmt.Errorf("model and models are mutually exclusive")
+	}
+	if len(req.Models) > maxModelChain {
+		return fmt.Errorf("models lists %d models, at most %d are allowed", len(req.Models), maxModelChain)
+	}
+	seen := make(map[string]bool, len(req.Models))
+	for i, model := range req.Models {
+		model = strings.TrimSpace(model)
+		if model == "" {
+			return fmt.Errorf("models[%d] must not be empty", i)
+		}
+		if seen[model] {
+			return fmt.Errorf("models lists %q twice", model)
+		}
+		seen[model] = true
+		req.Models[i] = model
+	}
+	return nil
+}
+
+// availableModels returns the models of req's chain that pass the availability check, in
+// order, and the failures of those that do not
+func (h *PredictionHandler) availableModels(ctx context.Context, req *PredictRequest) (available []string, skipped []modelFailure) {
+	for _, model := range req.modelChain() {
+		if err := h.validateKServeAvailability(ctx, model); err != nil {
+			h.log.WithContext(ctx).WithError(err).WithField("model", model).Warn("Model unavailable, trying the next model of the chain")
+			skipped = append(skipped, modelFailure{model: model, err: err})
+			continue
+		}
+		available = append(available, model)
+	}
+	return available, skipped
+}
+
+// usesFeatureEngineeringAny reports whether any of models is sent engineered features
+func (h *PredictionHandler) usesFeatureEngineeringAny(models []string) bool {
+	return slices.ContainsFunc(models, h.usesFeatureEngineering)
+}
+
+// predictWithFallback calls predict with req set to each of models in turn until one succeeds,
+// so a degraded primary model can be backed by a simpler one. Each attempt builds the feature
+// shape of its own model, and the response's model_info names the model that served it.
+// failures holds models already skipped; when every model fails the error covers them all.
+func (h *PredictionHandler) predictWithFallback(ctx context.Context, req *PredictRequest, models []string, failures []modelFailure,
+	predict func(ctx context.Context, req *PredictRequest) (PredictResponse, error)) (PredictResponse, error) {
+	for _, model := range models {
+		attempt := *req
+		attempt.Model = model
+		response, err := predict(ctx, &attempt)
+		if err != nil {
+			h.log.WithContext(ctx).WithError(err).WithField("model", model).Warn("Model failed to predict, trying the next model of the chain")
+			failures = append(failures, modelFailure{model: model, err: err})
+			continue
+		}
+		if len(failures) > 0 {
+			failed := make([]string, len(failures))
+			for i, failure := range failures {
+				failed[i] = failure.model
+			}
+			h.log.WithContext(ctx).WithFields(logrus.Fields{
+				"model":         model,
+				"failed_models": failed,
+			}).Info("Prediction served by a fallback model")
+		}
+		return response, nil
+	}
+	return PredictResponse{}, chainError(failures)
+}
+
+// chainError is the error of a chain whose every model failed. A single failure is returned as
+// is; otherwise the last failure's code is kept and the details name every model's failure.
+// It counts as KServe unreachable, which degraded mode covers, only if every model was.
+func chainError(failures []modelFailure) error {
+	if len(failures) == 1 {
+		return failures[0].err
+	}
+
+	details := make([]string, len(failures))
+	unreachable := true
+	for i, failure := range failures {
+		var svcErr *serviceError
+		if errors.As(failure.err, &svcErr) {
+			details[i] = fmt.Sprintf("%s: %s (%s)", failure.model, svcErr.message, svcErr.details)
+		} else {
+			details[i] = fmt.Sprintf("%s: %s", failure.model, failure.err)
+		}
+		if _, ok := kserveUnreachable(failure.err); !ok {
+			unreachable = false
+		}
+	}
+
+	code := ErrCodePredictionFailed
+	var last *serviceError
+	if errors.As(failures[len(failures)-1].err, &last) {
+		code = last.code
+	}
+	return &serviceError{
+		message:           fmt.Sprintf("All %d models failed", len(failures)),
+		details:           strings.Join(details, "; "),
+		code:              code,
+		kserveUnreachable: unreachable,
+	}
+}
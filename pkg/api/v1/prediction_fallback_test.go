@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// chainModelClient fails PredictFlexible for the models in errs and records the feature count
+// every model was sent
+type chainModelClient struct {
+	fakeModelClient
+	errs map[string]error
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *chainModelClient) PredictFlexible(ctx context.Context, model string, instances [][]float64) (*kserve.ModelResponse, error) {
+	c.mu.Lock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[model] = len(instances[0])
+	c.mu.Unlock()
+	if err := c.errs[model]; err != nil {
+		return nil, err
+	}
+	return c.fakeModelClient.PredictFlexible(ctx, model, instances)
+}
+
+// constantMetricProvider answers every query with 0.5
+type constantMetricProvider struct{}
+
+func (constantMetricProvider) QueryRange(_ context.Context, _ string, start, end time.Time, _ time.Duration) ([]features.DataPoint, error) {
+	return []features.DataPoint{{Timestamp: start, Value: 0.5}, {Timestamp: end, Value: 0.5}}, nil
+}
+
+func (constantMetricProvider) Query(context.Context, string) (float64, error) { return 0.5, nil }
+
+func (constantMetricProvider) QueryAt(context.Context, string, time.Time) (float64, error) {
+	return 0.5, nil
+}
+
+func (constantMetricProvider) IsAvailable() bool { return true }
+
+func TestPredictionHandler_ModelFallbackChain(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	newHandler := func(registered ...string) (*PredictionHandler, *chainModelClient) {
+		client := &chainModelClient{
+			fakeModelClient: fakeModelClient{
+				models:   map[string]bool{},
+				response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}, ModelVersion: "v1"}},
+			},
+			errs: map[string]error{},
+		}
+		for _, model := range registered {
+			client.models[model] = true
+		}
+		handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{EnableFeatureEngineering: true})
+		builder, err := features.NewPredictiveFeatureBuilder(constantMetricProvider{}, features.PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, log)
+		require.NoError(t, err)
+		handler.featureBuilder = builder
+		return handler, client
+	}
+	const body = `{"hour": 14, "day_of_week": 2, "models": ["predictive-analytics", "predictive-lite"]}`
+
+	t.Run("primary serves", func(t *testing.T) {
+		handler, client := newHandler("predictive-analytics", "predictive-lite")
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "predictive-analytics", resp.ModelInfo.Name)
+		assert.Equal(t, map[string]int{"predictive-analytics": handler.featureBuilder.FeatureCount()}, client.calls)
+	})
+
+	t.Run("fallback after a failed prediction", func(t *testing.T) {
+		handler, client := newHandler("predictive-analytics", "predictive-lite")
+		client.errs["predictive-analytics"] = &kserve.ModelUnavailableError{ModelName: "predictive-analytics"}
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var resp PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "predictive-lite", resp.ModelInfo.Name)
+		assert.Equal(t, map[string]int{
+			"predictive-analytics": handler.featureBuilder.FeatureCount(),
+			"predictive-lite":      rawMetricFeatureCount,
+		}, client.calls, "each model gets its own feature shape")
+	})
+
+	t.Run("unavailable model is skipped", func(t *testing.T) {
+		handler, client := newHandler("predictive-lite")
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"name":"predictive-lite"`)
+		assert.Equal(t, map[string]int{"predictive-lite": rawMetricFeatureCount}, client.calls)
+	})
+
+	t.Run("every model fails", func(t *testing.T) {
+		handler, client := newHandler("predictive-lite")
+		client.errs["predictive-lite"] = &kserve.ModelUnavailableError{ModelName: "predictive-lite"}
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+		var resp PredictErrorResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "All 2 models failed", resp.Error)
+		assert.Equal(t, ErrCodePredictionFailed, resp.Code, "the last model's code")
+		assert.Contains(t, resp.Details, "predictive-analytics: Model 'predictive-analytics' not available")
+		assert.Contains(t, resp.Details, "predictive-lite: Prediction failed")
+	})
+
+	t.Run("every model missing", func(t *testing.T) {
+		handler, _ := newHandler()
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), ErrCodeModelNotFound)
+	})
+
+	t.Run("invalid chains", func(t *testing.T) {
+		handler, _ := newHandler("predictive-analytics")
+		for _, body := range []string{
+			`{"hour": 14, "day_of_week": 2, "model": "predictive-analytics", "models": ["predictive-lite"]}`,
+			`{"hour": 14, "day_of_week": 2, "models": ["a", "a"]}`,
+			`{"hour": 14, "day_of_week": 2, "models": ["a", " "]}`,
+			`{"hour": 14, "day_of_week": 2, "models": ["a", "b", "c", "d", "e", "f"]}`,
+		} {
+			rr := postPredict(t, handler, body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+			assert.Contains(t, rr.Body.String(), "models", body)
+		}
+	})
+
+	t.Run("cache key", func(t *testing.T) {
+		now := time.Now()
+		single := &PredictRequest{PredictRequest: types.PredictRequest{Model: "predictive-analytics"}}
+		chain := &PredictRequest{PredictRequest: types.PredictRequest{Model: "predictive-analytics", Models: []string{"predictive-analytics", "predictive-lite"}}}
+		assert.NotEqual(t, predictionCacheKey(single, 0.5, 0.5, now, time.Minute), predictionCacheKey(chain, 0.5, 0.5, now, time.Minute))
+	})
+}
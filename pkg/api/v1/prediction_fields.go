@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// predictResponseFields are the PredictResponse fields a request can select with fields, in
+// response order. status is always returned and need not be listed.
+var predictResponseFields = []string{
+	"scope",
+	"target",
+	"predictions",
+	"current_metrics",
+	"model_info",
+	"target_time",
+	"capacity_what_if",
+	"time_to_threshold",
+	"raw_model_response",
+	"data_quality",
+	"degraded_reason",
+	"defaulted_features",
+}
+
+// validateFields checks the requested response fields and rewrites them in response order
+// without duplicates, so equivalent selections share cache entries and ETags
+func validateFields(req *PredictRequest) error {
+	if len(req.Fields) == 0 {
+		return nil
+	}
+	selected := make(map[string]bool, len(req.Fields))
+	for i, field := range req.Fields {
+		field = strings.TrimSpace(field)
+		if field == "status" {
+			continue
+		}
+		if !slices.Contains(predictResponseFields, field) {
+			return fmt.Errorf("fields[%d] %q is not a response field, must be one of: status, %s",
+				i, field, strings.Join(predictResponseFields, ", "))
+		}
+		selected[field] = true
+	}
+	req.Fields = []string{"status"}
+	for _, field := range predictResponseFields {
+		if selected[field] {
+			req.Fields = append(req.Fields, field)
+		}
+	}
+	return nil
+}
+
+// partialPredictResponse is a PredictResponse restricted to selected fields. Unselected fields
+// are nil or empty and omitted, so their nested objects are never serialized.
+type partialPredictResponse struct {
+	Status            string            `json:"status"`
+	Scope             *string           `json:"scope,omitempty"`
+	Target            *string           `json:"target,omitempty"`
+	Predictions       *PredictionValues `json:"predictions,omitempty"`
+	CurrentMetrics    *CurrentMetrics   `json:"current_metrics,omitempty"`
+	ModelInfo         *ModelInfo        `json:"model_info,omitempty"`
+	TargetTime        *TargetTimeInfo   `json:"target_time,omitempty"`
+	CapacityWhatIf    *CapacityWhatIf   `json:"capacity_what_if,omitempty"`
+	TimeToThreshold   *TimeToThreshold  `json:"time_to_threshold,omitempty"`
+	RawModelResponse  *RawModelResponse `json:"raw_model_response,omitempty"`
+	DataQuality       string            `json:"data_quality,omitempty"`
+	DegradedReason    string            `json:"degraded_reason,omitempty"`
+	DefaultedFeatures int               `json:"defaulted_features,omitempty"`
+}
+
+// selectFields returns what to serialize for response: the response itself when fields is
+// empty, else only the listed fields
+func selectFields(response *PredictResponse, fields []string) any {
+	if len(fields) == 0 {
+		return response
+	}
+	partial := partialPredictResponse{Status: response.Status}
+	for _, field := range fields {
+		switch field {
+		case "scope":
+			partial.Scope = &response.Scope
+		case "target":
+			partial.Target = &response.Target
+		case "predictions":
+			partial.Predictions = &response.Predictions
+		case "current_metrics":
+			partial.CurrentMetrics = &response.CurrentMetrics
+		case "model_info":
+			partial.ModelInfo = &response.ModelInfo
+		case "target_time":
+			partial.TargetTime = &response.TargetTime
+		case "capacity_what_if":
+			partial.CapacityWhatIf = response.CapacityWhatIf
+		case "time_to_threshold":
+			partial.TimeToThreshold = response.TimeToThreshold
+		case "raw_model_response":
+			partial.RawModelResponse = response.RawModelResponse
+		case "data_quality":
+			partial.DataQuality = response.DataQuality
+		case "degraded_reason":
+			partial.DegradedReason = response.DegradedReason
+		case "defaulted_features":
+			partial.DefaultedFeatures = response.DefaultedFeatures
+		}
+	}
+	return partial
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+func TestPredictionHandler_ResponseFields(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	client := &fakeModelClient{
+		models:   map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}, ModelVersion: "v1"}},
+	}
+	handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{})
+
+	t.Run("minimal response", func(t *testing.T) {
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments", "fields": ["predictions"]}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.ElementsMatch(t, []string{"status", "predictions"}, slices.Collect(maps.Keys(body)))
+		assert.JSONEq(t, `{"cpu_percent": 42, "memory_percent": 18}`, string(body["predictions"]))
+	})
+
+	t.Run("full response by default", func(t *testing.T) {
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Contains(t, body, "current_metrics")
+		assert.Contains(t, body, "model_info")
+		assert.Contains(t, body, "target_time")
+	})
+
+	t.Run("selection and ETag", func(t *testing.T) {
+		req := &PredictRequest{PredictRequest: types.PredictRequest{Fields: []string{"model_info", " predictions", "status", "predictions"}}}
+		require.NoError(t, validateFields(req))
+		assert.Equal(t, []string{"status", "predictions", "model_info"}, req.Fields)
+
+		minimal := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments", "fields": ["predictions"]}`)
+		full := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments"}`)
+		assert.NotEqual(t, full.Header().Get("ETag"), minimal.Header().Get("ETag"))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments", "fields": ["prediction"]}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `\"prediction\" is not a response field`)
+	})
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// ErrCodePredictionHistoryDisabled is returned by the history endpoint when no store is configured
+const ErrCodePredictionHistoryDisabled = "PREDICTION_HISTORY_DISABLED"
+
+// maxPredictionHistoryLimit caps the records returned by one history request
+const maxPredictionHistoryLimit = 1000
+
+// SetPredictionStore records every freshly computed prediction in store, for trend and drift
+// analysis. Cached and 304 responses are not recorded again. Must be called before serving;
+// nil leaves history disabled.
+func (h *PredictionHandler) SetPredictionStore(store *storage.PredictionStore) {
+	h.predictionStore = store
+}
+
+// recordPrediction stores response with the request that produced it. A failed write is logged
+// and does not fail the prediction.
+func (h *PredictionHandler) recordPrediction(ctx context.Context, req *PredictRequest, response *PredictResponse) {
+	if h.predictionStore == nil {
+		return
+	}
+	record := &models.PredictionRecord{
+		Scope:           response.Scope,
+		Target:          response.Target,
+		Namespace:       req.Namespace,
+		Deployment:      req.Deployment,
+		Pod:             req.Pod,
+		Model:           response.ModelInfo.Name,
+		Hour:            response.TargetTime.Hour,
+		DayOfWeek:       response.TargetTime.DayOfWeek,
+		TargetTimestamp: req.TargetTimestamp,
+		CPUPercent:      response.Predictions.CPUPercent,
+		MemoryPercent:   response.Predictions.MemoryPercent,
+		Confidence:      response.ModelInfo.Confidence,
+		ModelVersion:    response.ModelInfo.Version,
+	}
+	if err := h.predictionStore.Record(record); err != nil {
+		h.log.WithContext(ctx).WithError(err).Warn("Failed to record prediction history")
+	}
+}
+
+// PredictionHistoryResponse lists recorded predictions, newest first
+type PredictionHistoryResponse struct {
+	Status  string                     `json:"status"`
+	Count   int                        `json:"count"`
+	Records []*models.PredictionRecord `json:"records"`
+}
+
+// HandlePredictionHistory handles GET /api/v1/predict/history
+//
+// @Summary List recorded predictions
+// @Description Lists predictions recorded when PREDICTION_HISTORY_ENABLED is set, newest first, filtered by scope, target, model and an RFC3339 time range (since inclusive, until exclusive)
+// @Tags prediction
+// @Produce json
+// @Param scope query string false "pod, deployment, namespace or cluster"
+// @Param namespace query string false "Namespace"
+// @Param deployment query string false "Deployment"
+// @Param pod query string false "Pod"
+// @Param model query string false "KServe model name"
+// @Param since query string false "RFC3339 start of the time range"
+// @Param until query string false "RFC3339 end of the time range"
+// @Param limit query int false "Maximum records to return (default and maximum 1000)"
+// @Success 200 {object} PredictionHistoryResponse
+// @Failure 400 {object} PredictErrorResponse
+// @Failure 503 {object} PredictErrorResponse
+// @Router /api/v1/predict/history [get]
+func (h *PredictionHandler) HandlePredictionHistory(w http.ResponseWriter, r *http.Request) {
+	if h.predictionStore == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "Prediction history not enabled",
+			"set PREDICTION_HISTORY_ENABLED=true to record predictions", ErrCodePredictionHistoryDisabled)
+		return
+	}
+
+	filter, err := parsePredictionHistoryFilter(r)
+	if err != nil {
+		h.handleRequestError(w, err)
+		return
+	}
+
+	records := h.predictionStore.List(filter)
+	h.respondJSON(w, http.StatusOK, PredictionHistoryResponse{
+		Status:  "success",
+		Count:   len(records),
+		Records: records,
+	})
+}
+
+// parsePredictionHistoryFilter reads the history query parameters
+func parsePredictionHistoryFilter(r *http.Request) (storage.PredictionFilter, error) {
+	query := r.URL.Query()
+	filter := storage.PredictionFilter{
+		Scope:      query.Get("scope"),
+		Namespace:  query.Get("namespace"),
+		Deployment: query.Get("deployment"),
+		Pod:        query.Get("pod"),
+		Model:      query.Get("model"),
+		Limit:      maxPredictionHistoryLimit,
+	}
+
+	var err error
+	if filter.Since, err = parseHistoryTime(query.Get("since"), "since"); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = parseHistoryTime(query.Get("until"), "until"); err != nil {
+		return filter, err
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return filter, &requestError{message: "since must be before until", code: ErrCodeInvalidRequest}
+	}
+
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil || limit < 1 || limit > maxPredictionHistoryLimit {
+			return filter, &requestError{
+				message: fmt.Sprintf("limit must be an integer between 1 and %d", maxPredictionHistoryLimit),
+				details: fmt.Sprintf("got %q", limitParam),
+				code:    ErrCodeInvalidRequest,
+			}
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+// parseHistoryTime parses an optional RFC3339 query parameter; empty yields the zero time
+func parseHistoryTime(value, name string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, &requestError{message: name + " must be an RFC3339 timestamp", details: err.Error(), code: ErrCodeInvalidRequest}
+	}
+	return t, nil
+}
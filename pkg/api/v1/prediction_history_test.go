@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+func TestPredictionHandler_PredictionHistory(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client := &fakeModelClient{
+		models: map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{
+			Type:               "regression",
+			RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 58}, ModelVersion: "r1"},
+		},
+	}
+	config := DefaultPredictionHandlerConfig()
+	config.CacheTTL = time.Minute
+	handler := NewPredictionHandlerWithConfig(client, nil, log, config)
+
+	predict := func(body string) {
+		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.HandlePredict(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+	history := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.HandlePredictionHistory(w, httptest.NewRequest("GET", "/api/v1/predict/history"+query, nil))
+		return w
+	}
+
+	w := history("")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodePredictionHistoryDisabled)
+
+	handler.SetPredictionStore(storage.NewPredictionStore(0))
+	predict(`{"hour": 15, "day_of_week": 3, "namespace": "payments"}`)
+	predict(`{"hour": 15, "day_of_week": 3, "namespace": "payments"}`) // Served from cache, not recorded again
+	predict(`{"hour": 9, "day_of_week": 1, "namespace": "payments", "deployment": "api"}`)
+
+	w = history("?namespace=payments")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp PredictionHistoryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 2, resp.Count)
+	assert.Equal(t, "deployment", resp.Records[0].Scope)
+	assert.Equal(t, "payments/api", resp.Records[0].Target)
+	assert.Equal(t, 9, resp.Records[0].Hour)
+	assert.Equal(t, "namespace", resp.Records[1].Scope)
+	assert.InDelta(t, 42.0, resp.Records[1].CPUPercent, 0.001)
+	assert.Equal(t, "r1", resp.Records[1].ModelVersion)
+
+	w = history("?scope=namespace&limit=5&since=" + time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, 1, resp.Count)
+
+	for _, query := range []string{"?since=yesterday", "?limit=0", "?limit=1001", "?since=2026-03-02T00:00:00Z&until=2026-03-01T00:00:00Z"} {
+		assert.Equal(t, http.StatusBadRequest, history(query).Code, query)
+	}
+}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// Labels and issue type of incidents opened from predictions
+const (
+	PredictedIncidentSourceLabel = "source"
+	PredictedIncidentSource      = "predicted"
+	PredictedIncidentScopeLabel  = "scope"
+	PredictedIncidentIssueType   = "predicted_resource_exhaustion"
+)
+
+// PredictedIncidentPolicy decides which predictions open an incident
+type PredictedIncidentPolicy struct {
+	// CPUPercent and MemoryPercent are the predicted utilizations at or above which an
+	// incident is opened; either one crossing is enough
+	CPUPercent    float64
+	MemoryPercent float64
+
+	// MinConfidence is the lowest prediction confidence (0-1) that opens an incident
+	MinConfidence float64
+}
+
+// SetPredictedIncidents opens an incident in store whenever a freshly computed prediction
+// crosses the policy's thresholds, closing the loop from prediction to remediation. Cached,
+// 304 and degraded responses never open one. Must be called before serving; nil store leaves
+// the hook disabled.
+func (h *PredictionHandler) SetPredictedIncidents(store *storage.IncidentStore, policy PredictedIncidentPolicy) {
+	h.incidentStore = store
+	h.incidentPolicy = policy
+}
+
+// exceeds reports whether response crosses the policy's thresholds
+func (p PredictedIncidentPolicy) exceeds(response *PredictResponse) bool {
+	if response.ModelInfo.Confidence < p.MinConfidence {
+		return false
+	}
+	return response.Predictions.CPUPercent >= p.CPUPercent || response.Predictions.MemoryPercent >= p.MemoryPercent
+}
+
+// openPredictedIncident creates an incident for response when it crosses the policy's
+// thresholds. While a predicted incident for the same target is active, repeated predictions
+// open no new one; once it is resolved the next crossing opens another. A failed create is
+// logged and does not fail the prediction.
+func (h *PredictionHandler) openPredictedIncident(ctx context.Context, req *PredictRequest, response *PredictResponse) {
+	if h.incidentStore == nil || response.Status == PredictStatusDegraded || !h.incidentPolicy.exceeds(response) {
+		return
+	}
+
+	target := req.Namespace
+	if target == "" {
+		target = response.Target
+	}
+	incident := &models.Incident{
+		Title:    fmt.Sprintf("Predicted resource exhaustion for %s %s", response.Scope, response.Target),
+		Severity: models.IncidentSeverityHigh,
+		Target:   target,
+		Description: fmt.Sprintf("Model %s predicts %.1f%% CPU and %.1f%% memory at %s (confidence %.2f), "+
+			"above the %.1f%% CPU / %.1f%% memory incident thresholds",
+			response.ModelInfo.Name, response.Predictions.CPUPercent, response.Predictions.MemoryPercent,
+			response.TargetTime.ISOTimestamp, response.ModelInfo.Confidence,
+			h.incidentPolicy.CPUPercent, h.incidentPolicy.MemoryPercent),
+		IssueType:         PredictedIncidentIssueType,
+		AffectedResources: []string{response.Target},
+		Labels: map[string]string{
+			PredictedIncidentSourceLabel: PredictedIncidentSource,
+			PredictedIncidentScopeLabel:  response.Scope,
+		},
+	}
+
+	h.incidentMu.Lock()
+	defer h.incidentMu.Unlock()
+	if h.activePredictedIncident(response.Scope, response.Target) != nil {
+		return
+	}
+	created, err := h.incidentStore.Create(incident)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Warn("Failed to open predicted incident")
+		return
+	}
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"incident_id":    created.ID,
+		"scope":          response.Scope,
+		"target":         response.Target,
+		"cpu_percent":    response.Predictions.CPUPercent,
+		"memory_percent": response.Predictions.MemoryPercent,
+	}).Info("Opened incident from high-risk prediction")
+}
+
+// activePredictedIncident returns the active incident opened from a prediction for the target
+// at scope, or nil
+func (h *PredictionHandler) activePredictedIncident(scope, target string) *models.Incident {
+	active := h.incidentStore.List(storage.ListFilter{
+		Status: string(models.IncidentStatusActive),
+		Labels: map[string]string{
+			PredictedIncidentSourceLabel: PredictedIncidentSource,
+			PredictedIncidentScopeLabel:  scope,
+		},
+	})
+	for _, incident := range active {
+		if slices.Contains(incident.AffectedResources, target) {
+			return incident
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func TestPredictionHandler_PredictedIncidents(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	policy := PredictedIncidentPolicy{CPUPercent: 95, MemoryPercent: 95, MinConfidence: 0.8}
+
+	newHandler := func(cpu, memory float64) *PredictionHandler {
+		client := &fakeModelClient{
+			models:   map[string]bool{"predictive-analytics": true},
+			response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{cpu, memory}, ModelVersion: "v1"}},
+		}
+		return NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{})
+	}
+
+	t.Run("high-risk prediction opens one incident while it is active", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		handler := newHandler(97, 40)
+		handler.SetPredictedIncidents(store, policy)
+
+		for range 3 {
+			rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments", "deployment": "api"}`)
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		incidents := store.List(storage.ListFilter{})
+		require.Len(t, incidents, 1)
+		incident := incidents[0]
+		assert.Equal(t, "payments", incident.Target)
+		assert.Equal(t, models.IncidentSeverityHigh, incident.Severity)
+		assert.Equal(t, PredictedIncidentIssueType, incident.IssueType)
+		assert.Equal(t, []string{"payments/api"}, incident.AffectedResources)
+		assert.Equal(t, map[string]string{"source": "predicted", "scope": "deployment"}, incident.Labels)
+		assert.Contains(t, incident.Description, "97.0% CPU")
+
+		resolved := *incident
+		resolved.Status = models.IncidentStatusResolved
+		require.NoError(t, store.Update(&resolved))
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments", "deployment": "api"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, 2, store.Count(), "a resolved incident does not suppress the next crossing")
+	})
+
+	t.Run("other targets are not deduplicated", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		handler := newHandler(50, 96)
+		handler.SetPredictedIncidents(store, policy)
+
+		for _, namespace := range []string{"payments", "checkout"} {
+			rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "`+namespace+`"}`)
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		}
+		assert.Equal(t, 2, store.Count())
+	})
+
+	t.Run("below thresholds or confidence", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		handler := newHandler(90, 90)
+		handler.SetPredictedIncidents(store, policy)
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		confident := policy
+		confident.MinConfidence = 0.9
+		handler = newHandler(99, 99)
+		handler.SetPredictedIncidents(store, confident)
+		rr = postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments"}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		assert.Zero(t, store.Count())
+	})
+
+	t.Run("disabled without a store", func(t *testing.T) {
+		handler := newHandler(99, 99)
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "namespace": "payments"}`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+)
+
+// ErrCodePredictionCapacityExceeded reports that all prediction slots stayed busy for the
+// queue timeout; the response carries Retry-After
+const ErrCodePredictionCapacityExceeded = types.ErrCodePredictionCapacityExceeded
+
+// predictionLimiter bounds how many predictions build features and call KServe at once. Each
+// feature build issues many Prometheus queries, so without a bound a burst of requests opens
+// more connections than Prometheus tolerates. A nil limiter admits every request.
+type predictionLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+// newPredictionLimiter returns a limiter with maxConcurrent slots, or nil when maxConcurrent <= 0.
+// Requests wait up to queueTimeout for a slot; 0 rejects them as soon as all slots are busy.
+func newPredictionLimiter(maxConcurrent int, queueTimeout time.Duration) *predictionLimiter {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	return &predictionLimiter{
+		slots:        make(chan struct{}, maxConcurrent),
+		queueTimeout: max(queueTimeout, 0),
+	}
+}
+
+// acquire takes a slot, waiting up to the queue timeout, and returns the function releasing it
+func (l *predictionLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	release := func() { <-l.slots }
+
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if l.queueTimeout == 0 {
+		return nil, fmt.Errorf("all %d prediction slots are busy", cap(l.slots))
+	}
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("all %d prediction slots stayed busy for %s", cap(l.slots), l.queueTimeout)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("request cancelled while waiting for a prediction slot: %w", ctx.Err())
+	}
+}
+
+// retryAfter is the Retry-After value in whole seconds: the queue timeout, at least one second
+func (l *predictionLimiter) retryAfter() string {
+	return strconv.Itoa(max(1, int(math.Ceil(l.queueTimeout.Seconds()))))
+}
+
+// acquirePredictionSlot takes a prediction slot for the request. When none frees up in time it
+// writes a 503 with Retry-After and returns ok=false; otherwise the caller must call release.
+func (h *PredictionHandler) acquirePredictionSlot(w http.ResponseWriter, r *http.Request) (release func(), ok bool) {
+	release, err := h.limiter.acquire(r.Context())
+	if err != nil {
+		h.log.WithContext(r.Context()).WithError(err).Warn("Prediction rejected, concurrency limit reached")
+		w.Header().Set("Retry-After", h.limiter.retryAfter())
+		h.respondError(w, http.StatusServiceUnavailable, "Too many concurrent predictions", err.Error(), ErrCodePredictionCapacityExceeded)
+		return nil, false
+	}
+	return release, true
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredictionLimiter_NilAdmitsEverything(t *testing.T) {
+	limiter := newPredictionLimiter(0, time.Second)
+	assert.Nil(t, limiter)
+
+	for range 3 {
+		release, err := limiter.acquire(context.Background())
+		require.NoError(t, err)
+		release()
+	}
+}
+
+func TestPredictionLimiter_RejectsWhenFullWithoutQueueTimeout(t *testing.T) {
+	limiter := newPredictionLimiter(1, 0)
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	_, err = limiter.acquire(context.Background())
+	assert.Error(t, err)
+
+	release()
+	release, err = limiter.acquire(context.Background())
+	require.NoError(t, err, "released slot should be reusable")
+	release()
+}
+
+func TestPredictionLimiter_QueuesUntilSlotFrees(t *testing.T) {
+	limiter := newPredictionLimiter(1, 5*time.Second)
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		release()
+	}()
+
+	second, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	second()
+}
+
+func TestPredictionLimiter_QueueTimeoutAndCancellation(t *testing.T) {
+	limiter := newPredictionLimiter(1, 20*time.Millisecond)
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	_, err = limiter.acquire(context.Background())
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = limiter.acquire(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestPredictionLimiter_RetryAfter(t *testing.T) {
+	assert.Equal(t, "1", newPredictionLimiter(1, 0).retryAfter())
+	assert.Equal(t, "3", newPredictionLimiter(1, 2500*time.Millisecond).retryAfter())
+}
+
+func TestAcquirePredictionSlot_RespondsServiceUnavailable(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	h := &PredictionHandler{log: log, limiter: newPredictionLimiter(1, 0)}
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/predict", nil)
+	release, ok := h.acquirePredictionSlot(httptest.NewRecorder(), r)
+	require.True(t, ok)
+	defer release()
+
+	w := httptest.NewRecorder()
+	_, ok = h.acquirePredictionSlot(w, r)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	var resp PredictErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, ErrCodePredictionCapacityExceeded, resp.Code)
+}
@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// Model statuses reported by modelReachability.status; predictors may report others
+const (
+	reachabilityStatusReady       = "ready"
+	reachabilityStatusUnavailable = "unavailable"
+	reachabilityStatusNotFound    = "not_found"
+	reachabilityStatusUnknown     = "unknown"
+)
+
+// modelHealthChecker is implemented by model clients that can ask a predictor whether it is
+// serving, such as kserve.ProxyClient
+type modelHealthChecker interface {
+	CheckModelHealth(ctx context.Context, modelName string) (*kserve.ModelHealthResponse, error)
+}
+
+// modelReachability caches per-model availability for a short TTL. A model must be registered
+// and, when the client can probe predictors, its predictor must report ready. Concurrent checks
+// of a model that is not cached wait for a single probe instead of issuing their own. Both
+// outcomes are cached, so an outage costs one probe per TTL. It is safe for concurrent use.
+type modelReachability struct {
+	client kserve.ModelClient
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	entries  map[string]reachabilityEntry
+	inflight map[string]*reachabilityCall
+}
+
+type reachabilityEntry struct {
+	status    string
+	err       error
+	expiresAt time.Time
+}
+
+// reachabilityCall is a probe in progress; done is closed once status and err are set
+type reachabilityCall struct {
+	done   chan struct{}
+	status string
+	err    error
+}
+
+func newModelReachability(client kserve.ModelClient, ttl time.Duration) *modelReachability {
+	return &modelReachability{
+		client:   client,
+		ttl:      ttl,
+		entries:  make(map[string]reachabilityEntry),
+		inflight: make(map[string]*reachabilityCall),
+	}
+}
+
+// check returns nil if model can serve predictions, or a *serviceError explaining why not.
+// A non-positive TTL disables probing: only registry presence is checked, on every call.
+func (m *modelReachability) check(ctx context.Context, model string) error {
+	_, err := m.status(ctx, model)
+	return err
+}
+
+// status is check that also reports the predictor's health status: "ready", the status the
+// predictor reported, "not_found" for unregistered models, or "unknown" when it was not probed
+func (m *modelReachability) status(ctx context.Context, model string) (string, error) {
+	if m.ttl <= 0 {
+		if err := m.registered(model); err != nil {
+			return reachabilityStatusNotFound, err
+		}
+		return reachabilityStatusUnknown, nil
+	}
+
+	m.mu.Lock()
+	if entry, ok := m.entries[model]; ok && time.Now().Before(entry.expiresAt) {
+		m.mu.Unlock()
+		return entry.status, entry.err
+	}
+	call, running := m.inflight[model]
+	if !running {
+		call = &reachabilityCall{done: make(chan struct{})}
+		m.inflight[model] = call
+	}
+	m.mu.Unlock()
+
+	if !running {
+		// The shared probe must not fail for every waiter when the first caller goes away, and
+		// runs in the background so the first caller still honors its own deadline
+		go func(ctx context.Context) {
+			call.status, call.err = m.probe(ctx, model)
+
+			m.mu.Lock()
+			delete(m.inflight, model)
+			m.entries[model] = reachabilityEntry{status: call.status, err: call.err, expiresAt: time.Now().Add(m.ttl)}
+			m.mu.Unlock()
+			close(call.done)
+		}(context.WithoutCancel(ctx))
+	}
+
+	select {
+	case <-call.done:
+		return call.status, call.err
+	case <-ctx.Done():
+		return reachabilityStatusUnknown, &serviceError{message: fmt.Sprintf("Model '%s' availability unknown", model), details: ctx.Err().Error(), code: ErrCodeKServeUnavailable}
+	}
+}
+
+// ModelReachability implements ModelReachabilityReporter
+func (m *modelReachability) ModelReachability(ctx context.Context, model string) (status string, reachable bool) {
+	status, err := m.status(ctx, model)
+	return status, err == nil && status == reachabilityStatusReady
+}
+
+// registered checks that model is in the client's registry
+func (m *modelReachability) registered(model string) error {
+	if _, exists := m.client.GetModel(model); !exists {
+		return &serviceError{message: fmt.Sprintf("Model '%s' not available", model), details: "Model not found in KServe", code: ErrCodeModelNotFound}
+	}
+	return nil
+}
+
+// probe checks registry presence, then asks the predictor whether it is serving when the
+// client supports health checks
+func (m *modelReachability) probe(ctx context.Context, model string) (string, error) {
+	if err := m.registered(model); err != nil {
+		return reachabilityStatusNotFound, err
+	}
+	checker, ok := m.client.(modelHealthChecker)
+	if !ok {
+		return reachabilityStatusUnknown, nil
+	}
+
+	health, err := checker.CheckModelHealth(ctx, model)
+	if err != nil {
+		return reachabilityStatusUnavailable, &serviceError{message: fmt.Sprintf("Model '%s' not reachable", model), details: err.Error(), code: ErrCodeKServeUnavailable, kserveUnreachable: true}
+	}
+	if health.Status != reachabilityStatusReady {
+		return health.Status, &serviceError{message: fmt.Sprintf("Model '%s' not serving", model), details: health.Message, code: ErrCodeKServeUnavailable, kserveUnreachable: true}
+	}
+	return health.Status, nil
+}
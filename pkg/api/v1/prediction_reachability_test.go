@@ -0,0 +1,186 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// probingModelClient is a fakeModelClient whose predictors answer health probes
+type probingModelClient struct {
+	fakeModelClient
+	status  string
+	err     error
+	probes  atomic.Int32
+	release chan struct{} // when set, probes block until it is closed
+}
+
+func (c *probingModelClient) CheckModelHealth(_ context.Context, modelName string) (*kserve.ModelHealthResponse, error) {
+	c.probes.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	return &kserve.ModelHealthResponse{Model: modelName, Status: c.status, Message: "predictor " + c.status}, c.err
+}
+
+func TestModelReachability_Check(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(status string) *probingModelClient {
+		return &probingModelClient{fakeModelClient: fakeModelClient{models: map[string]bool{"predictive-analytics": true}}, status: status}
+	}
+
+	t.Run("ready predictor is cached", func(t *testing.T) {
+		client := newClient("ready")
+		reachability := newModelReachability(client, time.Minute)
+		require.NoError(t, reachability.check(ctx, "predictive-analytics"))
+		require.NoError(t, reachability.check(ctx, "predictive-analytics"))
+		assert.Equal(t, int32(1), client.probes.Load())
+	})
+
+	t.Run("unavailable predictor is cached as unreachable", func(t *testing.T) {
+		client := newClient("unavailable")
+		reachability := newModelReachability(client, time.Minute)
+		err := reachability.check(ctx, "predictive-analytics")
+		_, unreachable := kserveUnreachable(err)
+		assert.True(t, unreachable)
+		assert.ErrorContains(t, err, "not serving")
+		assert.Equal(t, err, reachability.check(ctx, "predictive-analytics"))
+		assert.Equal(t, int32(1), client.probes.Load())
+	})
+
+	t.Run("probe errors are unreachable", func(t *testing.T) {
+		client := newClient("")
+		client.err = errors.New("dial tcp: connection refused")
+		err := newModelReachability(client, time.Minute).check(ctx, "predictive-analytics")
+		_, unreachable := kserveUnreachable(err)
+		assert.True(t, unreachable)
+	})
+
+	t.Run("unregistered model is not probed", func(t *testing.T) {
+		client := newClient("ready")
+		err := newModelReachability(client, time.Minute).check(ctx, "other")
+		var svcErr *serviceError
+		require.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, ErrCodeModelNotFound, svcErr.code)
+		assert.Zero(t, client.probes.Load())
+	})
+
+	t.Run("zero TTL only checks the registry", func(t *testing.T) {
+		client := newClient("unavailable")
+		assert.NoError(t, newModelReachability(client, 0).check(ctx, "predictive-analytics"))
+		assert.Zero(t, client.probes.Load())
+	})
+
+	t.Run("expired entries are probed again", func(t *testing.T) {
+		client := newClient("ready")
+		reachability := newModelReachability(client, time.Millisecond)
+		require.NoError(t, reachability.check(ctx, "predictive-analytics"))
+		time.Sleep(5 * time.Millisecond)
+		require.NoError(t, reachability.check(ctx, "predictive-analytics"))
+		assert.Equal(t, int32(2), client.probes.Load())
+	})
+
+	t.Run("concurrent checks share one probe", func(t *testing.T) {
+		client := newClient("ready")
+		client.release = make(chan struct{})
+		reachability := newModelReachability(client, time.Minute)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = reachability.check(ctx, "predictive-analytics")
+			}()
+		}
+		require.Eventually(t, func() bool { return client.probes.Load() == 1 }, time.Second, time.Millisecond)
+		close(client.release)
+		wg.Wait()
+
+		for _, err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), client.probes.Load())
+	})
+
+	t.Run("first caller honors its own deadline", func(t *testing.T) {
+		client := newClient("ready")
+		client.release = make(chan struct{})
+		reachability := newModelReachability(client, time.Minute)
+
+		shortCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err := reachability.check(shortCtx, "predictive-analytics")
+		var svcErr *serviceError
+		require.ErrorAs(t, err, &svcErr)
+		assert.Contains(t, svcErr.message, "availability unknown")
+
+		close(client.release)
+		require.NoError(t, reachability.check(ctx, "predictive-analytics"))
+		assert.Equal(t, int32(1), client.probes.Load())
+	})
+}
+
+// TestPredictionHandler_HandlePredict_UnreachablePredictor verifies a registered model whose
+// predictor does not answer probes is rejected before features are built
+func TestPredictionHandler_HandlePredict_UnreachablePredictor(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client := &probingModelClient{
+		fakeModelClient: fakeModelClient{
+			models:   map[string]bool{"predictive-analytics": true},
+			response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+		},
+		status: "unavailable",
+	}
+	handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{ModelReachabilityTTL: time.Minute})
+
+	rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Contains(t, rr.Body.String(), ErrCodeKServeUnavailable)
+	assert.Nil(t, client.instances, "the model is not called")
+
+	client.status = "ready"
+	handler = NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{ModelReachabilityTTL: time.Minute})
+	rr = postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+// TestModelReachability_ProxyClient verifies the probe reaches a real predictor's health endpoint
+func TestModelReachability_ProxyClient(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := kserve.NewProxyClient(kserve.ProxyConfig{Namespace: "test-ns", Timeout: 5 * time.Second}, log)
+	require.NoError(t, err)
+	client.RegisterModel(&kserve.ModelInfo{Name: "predictive-analytics", URL: server.URL})
+
+	err = newModelReachability(client, time.Minute).check(context.Background(), "predictive-analytics")
+	assert.ErrorContains(t, err, "not serving")
+
+	healthy.Store(true)
+	assert.NoError(t, newModelReachability(client, time.Minute).check(context.Background(), "predictive-analytics"))
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ContentTypeNDJSON requests, and marks, a streamed response with one JSON object per line
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// Line types of a streamed comparison
+const (
+	StreamLinePrediction = "prediction"
+	StreamLineError      = "error"
+	StreamLineSummary    = "summary"
+)
+
+// CompareStreamLine is one line of a streamed POST /api/v1/predict/compare response. Scope
+// lines arrive in completion order, tagged with the scope's index in the request; a summary
+// line ends the stream.
+type CompareStreamLine struct {
+	Type string `json:"type"` // prediction, error or summary
+
+	// Index is the scope's position in the request's scopes (prediction and error lines)
+	Index *int `json:"index,omitempty"`
+
+	Prediction *PredictResponse `json:"prediction,omitempty"`
+	Error      *CompareError    `json:"error,omitempty"`
+
+	// Summary line only
+	SortBy     string          `json:"sort_by,omitempty"`
+	TargetTime *TargetTimeInfo `json:"target_time,omitempty"`
+	Ranking    []string        `json:"ranking,omitempty"`
+	Succeeded  int             `json:"succeeded,omitempty"`
+	Failed     int             `json:"failed,omitempty"`
+}
+
+// streamLineWriteTimeout bounds writing one streamed line. It replaces the server's write
+// timeout, which counts from the start of the request and would cut off a long stream.
+const streamLineWriteTimeout = 15 * time.Second
+
+// acceptsNDJSON reports whether the request's Accept header lists ContentTypeNDJSON
+func acceptsNDJSON(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == ContentTypeNDJSON {
+			return true
+		}
+	}
+	return false
+}
+
+// streamCompare writes each scope's prediction or error as an NDJSON line as soon as it
+// completes, flushing after every line, then a summary line with the ranking. Each line gets
+// its own write deadline, so the stream may outlast the server's write timeout. Only targets and
+// predicted values are kept for the ranking, so memory does not grow with full responses.
+// The status is always 200 since it is sent before any scope completes; failed scopes show
+// up as error lines and in the summary's failed count.
+func (h *PredictionHandler) streamCompare(ctx context.Context, w http.ResponseWriter, compareReq *PredictCompareRequest, scopeReqs []*PredictRequest, results <-chan scopeResult) {
+	w.Header().Set("Content-Type", ContentTypeNDJSON)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	encoder := json.NewEncoder(w)
+	var writeErr error
+	write := func(line CompareStreamLine) {
+		if writeErr != nil {
+			return
+		}
+		if err := controller.SetWriteDeadline(time.Now().Add(streamLineWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			writeErr = err
+			return
+		}
+		if writeErr = encoder.Encode(line); writeErr != nil {
+			return
+		}
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			writeErr = err
+		}
+	}
+
+	ranked := make([]RankedPrediction, 0, len(scopeReqs))
+	failed := 0
+	// Keep draining after a write error so no prediction goroutine is left blocked
+	for result := range results {
+		index := result.index
+		if result.err != nil {
+			failed++
+			compareErr := compareErrorFor(h.getTarget(scopeReqs[index]), result.err)
+			write(CompareStreamLine{Type: StreamLineError, Index: &index, Error: &compareErr})
+			continue
+		}
+		ranked = append(ranked, RankedPrediction{Prediction: PredictResponse{Target: result.response.Target, Predictions: result.response.Predictions}})
+		write(CompareStreamLine{Type: StreamLinePrediction, Index: &index, Prediction: &result.response})
+	}
+
+	rankPredictions(ranked, compareReq.SortBy)
+	ranking := make([]string, len(ranked))
+	for i := range ranked {
+		ranking[i] = ranked[i].Prediction.Target
+	}
+	write(CompareStreamLine{
+		Type:   StreamLineSummary,
+		SortBy: compareReq.SortBy,
+		TargetTime: &TargetTimeInfo{
+			Hour:         compareReq.Hour,
+			DayOfWeek:    compareReq.DayOfWeek,
+			ISOTimestamp: h.calculateTargetTimestamp(compareReq.Hour, compareReq.DayOfWeek),
+		},
+		Ranking:   ranking,
+		Succeeded: len(ranked),
+		Failed:    failed,
+	})
+
+	entry := h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"ranked": len(ranked),
+		"failed": failed,
+	})
+	if writeErr != nil {
+		entry.WithError(writeErr).Warn("Streamed prediction comparison aborted")
+		return
+	}
+	entry.Info("Streamed prediction comparison completed")
+}
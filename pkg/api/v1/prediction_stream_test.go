@@ -0,0 +1,164 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// firstCallFailsModelClient fails its first prediction and answers the rest
+type firstCallFailsModelClient struct {
+	*fakeModelClient
+	calls atomic.Int32
+}
+
+func (c *firstCallFailsModelClient) PredictFlexible(ctx context.Context, model string, instances [][]float64) (*kserve.ModelResponse, error) {
+	if c.calls.Add(1) == 1 {
+		return nil, assert.AnError
+	}
+	return c.fakeModelClient.PredictFlexible(ctx, model, instances)
+}
+
+// slowModelClient answers each prediction after a delay
+type slowModelClient struct {
+	*fakeModelClient
+	delay time.Duration
+}
+
+func (c *slowModelClient) PredictFlexible(ctx context.Context, model string, instances [][]float64) (*kserve.ModelResponse, error) {
+	time.Sleep(c.delay)
+	return c.fakeModelClient.PredictFlexible(ctx, model, instances)
+}
+
+// TestPredictionHandler_HandlePredictCompare_NDJSON_OutlastsWriteTimeout verifies a stream is
+// not cut off when its scopes complete after the server's write timeout
+func TestPredictionHandler_HandlePredictCompare_NDJSON_OutlastsWriteTimeout(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	client := &slowModelClient{delay: 300 * time.Millisecond, fakeModelClient: &fakeModelClient{
+		models:   map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 17}, ModelVersion: "v1"}},
+	}}
+	handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{})
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequest("POST", server.URL+"/api/v1/predict/compare", strings.NewReader(`{
+		"hour": 15,
+		"day_of_week": 0,
+		"scopes": [{"namespace": "team-a"}, {"namespace": "team-b"}]
+	}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", ContentTypeNDJSON)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var lines []CompareStreamLine
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var line CompareStreamLine
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line), scanner.Text())
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, lines, 3)
+	assert.Equal(t, StreamLineSummary, lines[2].Type)
+	assert.Equal(t, 2, lines[2].Succeeded)
+}
+
+func TestPredictionHandler_HandlePredictCompare_NDJSON(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	client := &firstCallFailsModelClient{fakeModelClient: &fakeModelClient{
+		models:   map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 17}, ModelVersion: "v1"}},
+	}}
+	handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{})
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	req := httptest.NewRequest("POST", "/api/v1/predict/compare", strings.NewReader(`{
+		"hour": 15,
+		"day_of_week": 0,
+		"scopes": [{"namespace": "team-a"}, {"namespace": "team-b"}, {"namespace": "team-c"}]
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson; charset=utf-8, application/json;q=0.5")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, ContentTypeNDJSON, rr.Header().Get("Content-Type"))
+	assert.True(t, rr.Flushed)
+
+	var lines []CompareStreamLine
+	scanner := bufio.NewScanner(rr.Body)
+	for scanner.Scan() {
+		var line CompareStreamLine
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line), scanner.Text())
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 4)
+
+	indexes := make(map[int]string)
+	for _, line := range lines[:3] {
+		require.NotNil(t, line.Index)
+		indexes[*line.Index] = line.Type
+		switch line.Type {
+		case StreamLinePrediction:
+			require.NotNil(t, line.Prediction)
+			assert.Equal(t, 42.0, line.Prediction.Predictions.CPUPercent)
+		case StreamLineError:
+			require.NotNil(t, line.Error)
+			assert.Equal(t, ErrCodePredictionFailed, line.Error.Code)
+		default:
+			t.Fatalf("unexpected line type %q", line.Type)
+		}
+	}
+	assert.Len(t, indexes, 3, "every scope is reported once")
+
+	summary := lines[3]
+	assert.Equal(t, StreamLineSummary, summary.Type)
+	assert.Nil(t, summary.Index)
+	assert.Equal(t, 2, summary.Succeeded)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Len(t, summary.Ranking, 2)
+	assert.Equal(t, CompareSortCPU, summary.SortBy)
+	require.NotNil(t, summary.TargetTime)
+	assert.Equal(t, 15, summary.TargetTime.Hour)
+}
+
+func TestAcceptsNDJSON(t *testing.T) {
+	for accept, want := range map[string]bool{
+		"":                     false,
+		"application/json":     false,
+		"application/x-ndjson": true,
+		"application/json, application/x-ndjson;q=0.9": true,
+		"not a media type;;":                           false,
+	} {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.Header.Set("Accept", accept)
+		assert.Equal(t, want, acceptsNDJSON(req), accept)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,8 +17,15 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
 )
 
 func TestPredictionHandler_HandlePredict_Validation(t *testing.T) {
@@ -111,6 +121,27 @@ func TestPredictionHandler_HandlePredict_Validation(t *testing.T) {
 		assert.Contains(t, resp.Error, "scope must be one of")
 	})
 
+	t.Run("scope identifiers must be valid Kubernetes names", func(t *testing.T) {
+		for _, body := range []string{
+			`{"hour": 15, "day_of_week": 3, "namespace": "prod\",namespace=~\".*"}`,
+			`{"hour": 15, "day_of_week": 3, "namespace": "test-ns", "deployment": "api|billing"}`,
+			`{"hour": 15, "day_of_week": 3, "namespace": "test-ns", "pod": "My_Pod"}`,
+		} {
+			req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.HandlePredict(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+
+			var resp PredictErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+			assert.Contains(t, resp.Error, "invalid scope identifier")
+		}
+	})
+
 	t.Run("pod scope requires pod name", func(t *testing.T) {
 		reqBody := `{"hour": 15, "day_of_week": 3, "scope": "pod", "namespace": "test-ns"}`
 		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(reqBody))
@@ -197,6 +228,192 @@ func TestPredictionHandler_HandlePredict_Validation(t *testing.T) {
 	})
 }
 
+func TestPredictionHandler_HandlePredict_BodyTooLarge(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	handler := middleware.MaxBodySize(32)(http.HandlerFunc(NewPredictionHandler(nil, nil, log).HandlePredict))
+
+	reqBody := `{"hour": 15, "day_of_week": 3, "namespace": "` + strings.Repeat("a", 64) + `"}`
+	req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var resp PredictErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "Request body too large", resp.Error)
+	assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+}
+
+func TestPredictionHandler_HandleValidatePredict(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	// No KServe or Prometheus: validation must not depend on either
+	handler := NewPredictionHandler(nil, nil, log)
+
+	t.Run("returns normalized request", func(t *testing.T) {
+		reqBody := `{"hour": 15, "day_of_week": 3, "namespace": "payments", "deployment": "api"}`
+		req := httptest.NewRequest("POST", "/api/v1/predict/validate", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleValidatePredict(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp PredictValidateResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "valid", resp.Status)
+		assert.Equal(t, "deployment", resp.Request.Scope)
+		assert.Equal(t, "predictive-analytics", resp.Request.Model)
+		assert.Equal(t, 15, resp.Request.Hour)
+	})
+
+	t.Run("rejects invalid request", func(t *testing.T) {
+		reqBody := `{"hour": 24, "day_of_week": 3}`
+		req := httptest.NewRequest("POST", "/api/v1/predict/validate", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleValidatePredict(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp PredictErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		reqBody := `{"hour": 15, "day_of_week": 3, "namespce": "payments"}`
+		req := httptest.NewRequest("POST", "/api/v1/predict/validate", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleValidatePredict(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp PredictErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+		assert.Contains(t, resp.Details, "namespce")
+	})
+}
+
+// TestPredictionHandler_TargetChecker verifies requests for missing targets are rejected once a
+// target checker is set
+func TestPredictionHandler_TargetChecker(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	namespace := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata":   map[string]interface{}{"name": "payments"},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), namespace)
+
+	handler := NewPredictionHandler(nil, nil, log)
+	handler.SetTargetChecker(integrations.NewTargetChecker(dynamicClient, time.Minute, log))
+
+	predict := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.HandlePredict(w, req)
+		return w
+	}
+
+	// Without KServe an existing target gets past validation and fails as unavailable
+	assert.Equal(t, http.StatusServiceUnavailable, predict(`{"hour": 15, "day_of_week": 3, "namespace": "payments"}`).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, predict(`{"hour": 15, "day_of_week": 3}`).Code, "cluster scope has no target")
+
+	w := predict(`{"hour": 15, "day_of_week": 3, "namespace": "paymnets"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var resp PredictErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+	assert.Equal(t, "target not found", resp.Error)
+	assert.Contains(t, resp.Details, "paymnets")
+
+	w = predict(`{"hour": 15, "day_of_week": 3, "namespace": "payments", "deployment": "api"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "deployment does not exist")
+
+	// The validation endpoint stays free of cluster calls
+	req := httptest.NewRequest("POST", "/api/v1/predict/validate",
+		bytes.NewBufferString(`{"hour": 15, "day_of_week": 3, "namespace": "paymnets"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	handler.HandleValidatePredict(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+// TestPredictionHandler_TargetTimestamp verifies target_timestamp replaces hour and day_of_week
+func TestPredictionHandler_TargetTimestamp(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	handler := NewPredictionHandler(nil, nil, log)
+	target := time.Date(time.Now().Year()+2, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	validate := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/predict/validate", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.HandleValidatePredict(w, req)
+		return w
+	}
+
+	t.Run("derives hour and day_of_week", func(t *testing.T) {
+		// Offsets are normalized to UTC
+		w := validate(`{"target_timestamp": "` + target.In(time.FixedZone("CET", 3600)).Format(time.RFC3339) + `", "namespace": "payments"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp PredictValidateResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, target.Format(time.RFC3339), resp.Request.TargetTimestamp)
+		assert.Equal(t, 10, resp.Request.Hour)
+		assert.Equal(t, (int(target.Weekday())+6)%7, resp.Request.DayOfWeek)
+	})
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "malformed", body: `{"target_timestamp": "2026-03-15 10:00"}`, wantErr: "RFC3339"},
+		{name: "in the past", body: `{"target_timestamp": "2020-01-01T00:00:00Z"}`, wantErr: "in the future"},
+		{name: "with hour", body: `{"target_timestamp": "` + target.Format(time.RFC3339) + `", "hour": 10}`, wantErr: "cannot be combined"},
+		{name: "with zero day_of_week", body: `{"target_timestamp": "` + target.Format(time.RFC3339) + `", "day_of_week": 0}`, wantErr: "cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := validate(tt.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp PredictErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Contains(t, resp.Error, tt.wantErr)
+		})
+	}
+
+	t.Run("response reports the exact target time", func(t *testing.T) {
+		req := &PredictRequest{PredictRequest: types.PredictRequest{TargetTimestamp: target.Format(time.RFC3339)}}
+		require.NoError(t, handler.validateRequest(req))
+		handler.setRequestDefaults(req)
+
+		response := handler.buildPredictResponse(req, modelPrediction{}, 0.9, "v1", 0.5, 0.5, rawMetricSnapshot{})
+		assert.Equal(t, target.Format(time.RFC3339), response.TargetTime.ISOTimestamp)
+		assert.Equal(t, 10, response.TargetTime.Hour)
+	})
+}
+
 func TestPredictionHandler_HandlePredict_NoKServe(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -224,6 +441,41 @@ func TestPredictionHandler_HandlePredict_NoKServe(t *testing.T) {
 	})
 }
 
+func TestPredictionHandler_HandlePredict_RequestID(t *testing.T) {
+	var logBuf bytes.Buffer
+	log := logrus.New()
+	log.SetLevel(logrus.DebugLevel)
+	log.SetOutput(&logBuf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.AddHook(middleware.NewRequestIDHook())
+
+	handler := NewPredictionHandler(nil, nil, log)
+
+	t.Run("echoes X-Request-ID and tags log entries", func(t *testing.T) {
+		logBuf.Reset()
+		reqBody := `{"hour": 15, "day_of_week": 3, "namespace": "test-ns"}`
+		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(reqBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.RequestIDHeader, "predict-req-123")
+		w := httptest.NewRecorder()
+
+		handler.HandlePredict(w, req)
+
+		assert.Equal(t, "predict-req-123", w.Header().Get(middleware.RequestIDHeader))
+		assert.Contains(t, logBuf.String(), `"request_id":"predict-req-123"`)
+	})
+
+	t.Run("generates request ID when header is missing", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(`{invalid`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandlePredict(w, req)
+
+		assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader))
+	})
+}
+
 func TestPredictionHandler_HandlePredict_ModelNotFound(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -319,6 +571,98 @@ func TestPredictionHandler_HandlePredict_WithKServe(t *testing.T) {
 	})
 }
 
+// newConcurrentTestHandler returns a handler backed by a mock regression model server
+func newConcurrentTestHandler(t testing.TB) (*PredictionHandler, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"predictions":   [][]float64{{42.0, 17.0}},
+			"model_name":    "predictive-analytics",
+			"model_version": "v1",
+		})
+	}))
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	kserveClient, err := kserve.NewProxyClient(kserve.ProxyConfig{
+		Namespace:        "test-ns",
+		Timeout:          5 * time.Second,
+		RegressionModels: []string{"predictive-analytics"},
+	}, log)
+	require.NoError(t, err)
+	kserveClient.RegisterModel(&kserve.ModelInfo{Name: "predictive-analytics", URL: server.URL})
+
+	return NewPredictionHandler(kserveClient, nil, log), server.Close
+}
+
+// TestPredictionHandler_HandlePredict_Concurrent verifies one handler can serve parallel requests
+// without leaking request data between them. Run with -race to catch shared-state writes.
+func TestPredictionHandler_HandlePredict_Concurrent(t *testing.T) {
+	handler, cleanup := newConcurrentTestHandler(t)
+	defer cleanup()
+
+	const workers = 32
+	var wg sync.WaitGroup
+	codes := make([]int, workers)
+	responses := make([]PredictResponse, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			body, _ := json.Marshal(map[string]interface{}{
+				"hour":        i % 24,
+				"day_of_week": i % 7,
+				"namespace":   "ns-" + string(rune('a'+i%26)),
+			})
+			req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.HandlePredict(w, req)
+
+			codes[i] = w.Code
+			_ = json.NewDecoder(w.Body).Decode(&responses[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		require.Equal(t, http.StatusOK, codes[i], "worker %d", i)
+		assert.Equal(t, "namespace", responses[i].Scope)
+		assert.Equal(t, "ns-"+string(rune('a'+i%26)), responses[i].Target, "worker %d got another request's target", i)
+		assert.Equal(t, 42.0, responses[i].Predictions.CPUPercent)
+		assert.Equal(t, 17.0, responses[i].Predictions.MemoryPercent)
+	}
+}
+
+// BenchmarkPredictionHandler_HandlePredict_Parallel measures throughput of a shared handler
+func BenchmarkPredictionHandler_HandlePredict_Parallel(b *testing.B) {
+	handler, cleanup := newConcurrentTestHandler(b)
+	defer cleanup()
+
+	body := []byte(`{"hour": 15, "day_of_week": 3, "namespace": "bench"}`)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			handler.HandlePredict(w, req)
+
+			if w.Code != http.StatusOK {
+				b.Fatalf("unexpected status %d", w.Code)
+			}
+		}
+	})
+}
+
 func TestPredictionHandler_Scoping(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -326,11 +670,11 @@ func TestPredictionHandler_Scoping(t *testing.T) {
 	handler := NewPredictionHandler(nil, nil, log)
 
 	t.Run("namespace scope from namespace field", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
 			Namespace: "my-namespace",
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "namespace", req.Scope)
@@ -338,12 +682,12 @@ func TestPredictionHandler_Scoping(t *testing.T) {
 	})
 
 	t.Run("deployment scope from deployment field", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:       15,
 			DayOfWeek:  3,
 			Namespace:  "my-namespace",
 			Deployment: "my-deployment",
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "deployment", req.Scope)
@@ -351,12 +695,12 @@ func TestPredictionHandler_Scoping(t *testing.T) {
 	})
 
 	t.Run("pod scope from pod field", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
 			Namespace: "my-namespace",
 			Pod:       "my-pod-xyz",
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "pod", req.Scope)
@@ -364,10 +708,10 @@ func TestPredictionHandler_Scoping(t *testing.T) {
 	})
 
 	t.Run("cluster scope when no filters", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "cluster", req.Scope)
@@ -375,11 +719,11 @@ func TestPredictionHandler_Scoping(t *testing.T) {
 	})
 
 	t.Run("explicit cluster scope", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
 			Scope:     "cluster",
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "cluster", req.Scope)
@@ -387,27 +731,138 @@ func TestPredictionHandler_Scoping(t *testing.T) {
 	})
 
 	t.Run("default model is predictive-analytics", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "predictive-analytics", req.Model)
 	})
 
 	t.Run("custom model preserved", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
 			Model:     "custom-model",
-		}
+		}}
 		handler.setRequestDefaults(req)
 
 		assert.Equal(t, "custom-model", req.Model)
 	})
 }
 
+// newPartialPrometheusServer returns a Prometheus mock that answers CPU queries with cpuValue
+// and fails every memory query
+func newPartialPrometheusServer(cpuValue string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("query"), "memory") {
+			http.Error(w, "query timed out", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"` + cpuValue + `"]}]}}`))
+	}))
+}
+
+// TestPredictionHandler_GetMetricsWithDefaults_PartialFailure verifies a failed memory query
+// only defaults memory and keeps the real CPU value
+func TestPredictionHandler_GetMetricsWithDefaults_PartialFailure(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	server := newPartialPrometheusServer("0.42")
+	defer server.Close()
+
+	handler := NewPredictionHandler(nil, integrations.NewPrometheusClient(server.URL, 5*time.Second, log), log)
+
+	scopes := []*PredictRequest{
+		{PredictRequest: types.PredictRequest{Scope: "cluster"}},
+		{PredictRequest: types.PredictRequest{Scope: "namespace", Namespace: "payments"}},
+		{PredictRequest: types.PredictRequest{Scope: "deployment", Namespace: "payments", Deployment: "api"}},
+		{PredictRequest: types.PredictRequest{Scope: "pod", Namespace: "payments", Pod: "api-0"}},
+	}
+	for _, req := range scopes {
+		t.Run(req.Scope, func(t *testing.T) {
+			metrics, err := handler.getScopedMetrics(context.Background(), req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "memory")
+			assert.NotContains(t, err.Error(), "CPU")
+			assert.True(t, metrics.CPUOK)
+			assert.False(t, metrics.MemoryOK)
+			assert.InDelta(t, 0.42, metrics.CPU, 0.0001)
+
+			cpu, memory := handler.getMetricsWithDefaults(context.Background(), req)
+			assert.InDelta(t, 0.42, cpu, 0.0001, "real CPU value should be kept")
+			assert.Equal(t, handler.defaultMemoryRollingMean, memory, "only memory should fall back")
+		})
+	}
+}
+
+// TestPredictionHandler_GetScopedMetrics_Aggregation verifies requests and the handler default
+// select the statistic of current metrics, and only means feed the learned baseline
+func TestPredictionHandler_GetScopedMetrics_Aggregation(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := "0.4"
+		switch query := r.URL.Query().Get("query"); {
+		case strings.HasPrefix(query, "quantile_over_time(0.95,"):
+			value = "0.9"
+		case strings.HasPrefix(query, "max_over_time("):
+			value = "0.95"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"` + value + `"]}]}}`))
+	}))
+	defer server.Close()
+
+	handler := NewPredictionHandlerWithConfig(nil, integrations.NewPrometheusClient(server.URL, 5*time.Second, log), log, PredictionHandlerConfig{
+		MetricAggregation: integrations.MetricAggregationMax,
+	})
+	ctx := context.Background()
+
+	metrics, err := handler.getScopedMetrics(ctx, &PredictRequest{PredictRequest: types.PredictRequest{Scope: "namespace", Namespace: "payments"}})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.95, metrics.CPU, 0.0001, "handler default applies")
+
+	metrics, err = handler.getScopedMetrics(ctx, &PredictRequest{PredictRequest: types.PredictRequest{Scope: "namespace", Namespace: "payments", Aggregation: "p95"}})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.9, metrics.Memory, 0.0001)
+	_, learned := handler.baselines.lookup(integrations.MetricsScope{Namespace: "payments"})
+	assert.False(t, learned, "p95 and max do not feed the baseline")
+
+	metrics, err = handler.getScopedMetrics(ctx, &PredictRequest{PredictRequest: types.PredictRequest{Scope: "cluster", Aggregation: "mean"}})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, metrics.CPU, 0.0001)
+	baseline, learned := handler.baselines.lookup(integrations.MetricsScope{})
+	require.True(t, learned)
+	assert.InDelta(t, 0.4, baseline.CPURollingMean, 0.0001)
+
+	response := handler.buildPredictResponse(&PredictRequest{PredictRequest: types.PredictRequest{Aggregation: "p95"}}, modelPrediction{}, 0.9, "v1", 0.9, 0.9, rawMetricSnapshot{})
+	assert.Equal(t, integrations.MetricAggregationP95, response.CurrentMetrics.Aggregation)
+
+	err = handler.validateRequest(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 1, Aggregation: "median"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "aggregation must be one of")
+
+	unknown := NewPredictionHandlerWithConfig(nil, nil, log, PredictionHandlerConfig{MetricAggregation: "median"})
+	assert.Equal(t, integrations.MetricAggregationMean, unknown.requestAggregation(&PredictRequest{}))
+}
+
+// TestPredictionHandler_GetMetricsWithDefaults_NoPrometheus verifies both metrics default without Prometheus
+func TestPredictionHandler_GetMetricsWithDefaults_NoPrometheus(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	handler := NewPredictionHandler(nil, nil, log)
+
+	cpu, memory := handler.getMetricsWithDefaults(context.Background(), &PredictRequest{PredictRequest: types.PredictRequest{Scope: "cluster"}})
+	assert.Equal(t, handler.defaultCPURollingMean, cpu)
+	assert.Equal(t, handler.defaultMemoryRollingMean, memory)
+}
+
 func TestPredictionHandler_RegisterRoutes(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -555,6 +1010,8 @@ func TestClampPercentage(t *testing.T) {
 	assert.Equal(t, 50.0, clampPercentage(50.0))
 	assert.Equal(t, 100.0, clampPercentage(100.0))
 	assert.Equal(t, 100.0, clampPercentage(150.0))
+	assert.Equal(t, 0.0, clampPercentage(math.NaN()))
+	assert.Equal(t, 100.0, clampPercentage(math.Inf(1)))
 }
 
 func TestPredictionHandler_ValidateRequest(t *testing.T) {
@@ -564,47 +1021,114 @@ func TestPredictionHandler_ValidateRequest(t *testing.T) {
 	handler := NewPredictionHandler(nil, nil, log)
 
 	t.Run("valid request passes", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
-		}
+		}}
 		err := handler.validateRequest(req)
 		assert.NoError(t, err)
 	})
 
 	t.Run("valid request with all fields", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:       15,
 			DayOfWeek:  3,
 			Namespace:  "production",
 			Deployment: "my-app",
 			Scope:      "deployment",
 			Model:      "predictive-analytics",
-		}
+		}}
 		err := handler.validateRequest(req)
 		assert.NoError(t, err)
 	})
 
 	t.Run("valid namespace scope without namespace", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
 			Scope:     "namespace",
-		}
+		}}
 		err := handler.validateRequest(req)
 		// Namespace scope without namespace is allowed (falls back to cluster)
 		assert.NoError(t, err)
 	})
 
 	t.Run("valid cluster scope", func(t *testing.T) {
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      0,
 			DayOfWeek: 0,
 			Scope:     "cluster",
-		}
+		}}
 		err := handler.validateRequest(req)
 		assert.NoError(t, err)
 	})
+
+	t.Run("capacity scale factor must be positive", func(t *testing.T) {
+		for _, factor := range []float64{0, -1} {
+			req := &PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, CapacityScaleFactor: &factor}}
+			err := handler.validateRequest(req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "capacity_scale_factor")
+		}
+
+		factor := 0.5
+		assert.NoError(t, handler.validateRequest(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, CapacityScaleFactor: &factor}}))
+	})
+
+	t.Run("scope fields are trimmed", func(t *testing.T) {
+		req := &PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: " production\t", Deployment: "   ", Pod: "\n", Scope: " namespace "}}
+		require.NoError(t, handler.validateRequest(req))
+		assert.Equal(t, "production", req.Namespace)
+		assert.Empty(t, req.Deployment)
+		assert.Empty(t, req.Pod)
+		assert.Equal(t, "namespace", req.Scope)
+
+		req = &PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: "  ", Deployment: " "}}
+		require.NoError(t, handler.validateRequest(req))
+		handler.setRequestDefaults(req)
+		assert.Equal(t, "cluster", req.Scope, "whitespace-only filters do not narrow the scope")
+	})
+
+	t.Run("malformed scope identifiers", func(t *testing.T) {
+		for _, req := range []*PredictRequest{
+			{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: "prod uction"}},
+			{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: "production", Deployment: "my app"}},
+			{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: "production", Pod: "pod\x00"}},
+		} {
+			err := handler.validateRequest(req)
+			assert.ErrorIs(t, err, features.ErrInvalidScopeIdentifier, "%+v", req)
+		}
+	})
+
+	t.Run("whitespace deployment scope still needs a namespace", func(t *testing.T) {
+		err := handler.validateRequest(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: " ", Deployment: "my-app", Scope: "deployment"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "namespace")
+	})
+}
+
+func TestPredictionHandler_CapacityWhatIf(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewPredictionHandler(nil, nil, log)
+
+	predictions := modelPrediction{PredictionValues: PredictionValues{CPUPercent: 80, MemoryPercent: 60}}
+
+	response := handler.buildPredictResponse(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 15}}, predictions, 0.9, "v1", 0.5, 0.5, rawMetricSnapshot{})
+	assert.Nil(t, response.CapacityWhatIf, "only computed when requested")
+
+	factor := 2.0
+	response = handler.buildPredictResponse(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 15, CapacityScaleFactor: &factor}}, predictions, 0.9, "v1", 0.5, 0.5, rawMetricSnapshot{})
+	require.NotNil(t, response.CapacityWhatIf)
+	assert.Equal(t, 2.0, response.CapacityWhatIf.ScaleFactor)
+	assert.Equal(t, CapacityPrediction{CPUPercent: 80, MemoryPercent: 60}, response.CapacityWhatIf.Baseline)
+	assert.Equal(t, CapacityPrediction{CPUPercent: 40, MemoryPercent: 30}, response.CapacityWhatIf.Scaled)
+	assert.Equal(t, 80.0, response.Predictions.CPUPercent, "predictions stay the baseline")
+
+	// Removing capacity cannot push utilization past 100%
+	scaled := newCapacityWhatIf(predictions.PredictionValues, 0.5).Scaled
+	assert.Equal(t, 100.0, scaled.CPUPercent)
+	assert.InDelta(t, 100.0, scaled.MemoryPercent, 0.001)
 }
 
 func TestPredictionHandler_ProcessPredictions(t *testing.T) {
@@ -620,7 +1144,8 @@ func TestPredictionHandler_ProcessPredictions(t *testing.T) {
 		cpuMean := 0.65
 		memMean := 0.72
 
-		cpuPercent, memPercent, confidence := handler.processPredictions(resp, cpuMean, memMean)
+		cpuPercent, memPercent := handler.processPredictions(resp, cpuMean, memMean)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, cpuMean, memMean)
 
 		// Should increase the predictions
 		assert.Greater(t, cpuPercent, cpuMean*100)
@@ -635,7 +1160,8 @@ func TestPredictionHandler_ProcessPredictions(t *testing.T) {
 		cpuMean := 0.65
 		memMean := 0.72
 
-		cpuPercent, memPercent, confidence := handler.processPredictions(resp, cpuMean, memMean)
+		cpuPercent, memPercent := handler.processPredictions(resp, cpuMean, memMean)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, cpuMean, memMean)
 
 		// Values should be close to original
 		assert.InDelta(t, cpuMean*100, cpuPercent, 10.0)
@@ -650,7 +1176,8 @@ func TestPredictionHandler_ProcessPredictions(t *testing.T) {
 		cpuMean := 0.65
 		memMean := 0.72
 
-		cpuPercent, memPercent, confidence := handler.processPredictions(resp, cpuMean, memMean)
+		cpuPercent, memPercent := handler.processPredictions(resp, cpuMean, memMean)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, cpuMean, memMean)
 
 		// Should return base values
 		assert.Equal(t, cpuMean*100, cpuPercent)
@@ -665,7 +1192,7 @@ func TestPredictionHandler_ProcessPredictions(t *testing.T) {
 		cpuMean := 0.95 // Already high
 		memMean := 0.98
 
-		cpuPercent, memPercent, _ := handler.processPredictions(resp, cpuMean, memMean)
+		cpuPercent, memPercent := handler.processPredictions(resp, cpuMean, memMean)
 
 		// Should be clamped to 100
 		assert.LessOrEqual(t, cpuPercent, 100.0)
@@ -707,7 +1234,8 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			LookbackWindow: 24,
 		}
 
-		cpuPercent, memPercent, confidence := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "forecast", ForecastResponse: resp}, 0.60, 0.70)
 
 		// Should use first forecast value * 100
 		assert.Equal(t, 65.0, cpuPercent)
@@ -728,7 +1256,8 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			ModelName: "predictive-analytics",
 		}
 
-		cpuPercent, memPercent, confidence := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "forecast", ForecastResponse: resp}, 0.60, 0.70)
 
 		// CPU should use forecast, memory should fall back to rolling mean
 		assert.InDelta(t, 55.0, cpuPercent, 0.001)
@@ -748,7 +1277,8 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			ModelName: "predictive-analytics",
 		}
 
-		cpuPercent, memPercent, confidence := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "forecast", ForecastResponse: resp}, 0.60, 0.70)
 
 		// CPU should fall back to rolling mean, memory should use forecast
 		assert.Equal(t, 60.0, cpuPercent) // Rolling mean * 100
@@ -762,7 +1292,8 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			ModelName:   "predictive-analytics",
 		}
 
-		cpuPercent, memPercent, confidence := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "forecast", ForecastResponse: resp}, 0.60, 0.70)
 
 		// Should fall back to rolling means
 		assert.Equal(t, 60.0, cpuPercent)
@@ -781,7 +1312,8 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			ModelName: "predictive-analytics",
 		}
 
-		cpuPercent, memPercent, confidence := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "forecast", ForecastResponse: resp}, 0.60, 0.70)
 
 		// Should fall back to rolling means
 		assert.Equal(t, 60.0, cpuPercent)
@@ -804,7 +1336,7 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			ModelName: "predictive-analytics",
 		}
 
-		cpuPercent, memPercent, _ := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
 
 		// Should be clamped to 100
 		assert.Equal(t, 100.0, cpuPercent)
@@ -822,13 +1354,202 @@ func TestPredictionHandler_ProcessForecastPredictions(t *testing.T) {
 			ModelName: "predictive-analytics",
 		}
 
-		cpuPercent, _, _ := handler.processForecastPredictions(resp, 0.60, 0.70)
+		cpuPercent, _ := handler.processForecastPredictions(resp, 0.60, 0.70)
 
 		// Should be clamped to 0
 		assert.Equal(t, 0.0, cpuPercent)
 	})
 }
 
+func TestPredictionHandler_ForecastKeys(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	resp := &kserve.ForecastResponse{
+		Predictions: map[string]kserve.ForecastResult{
+			"cpu":    {Forecast: []float64{0.40}, Confidence: []float64{0.9}},
+			"memory": {Forecast: []float64{0.50}, Confidence: []float64{0.7}},
+		},
+	}
+
+	t.Run("default keys flag renamed metrics as defaulted", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		assert.InDelta(t, 60.0, cpuPercent, 0.001)
+		assert.InDelta(t, 70.0, memPercent, 0.001)
+		assert.Equal(t, []string{CompareSortCPU, CompareSortMemory}, handler.defaultedForecastMetrics(resp))
+	})
+
+	t.Run("configured aliases are used in order", func(t *testing.T) {
+		config := DefaultPredictionHandlerConfig()
+		config.ForecastKeys = ForecastKeyMapping{
+			CPUKeys:    []string{"cpu_usage", "cpu"},
+			MemoryKeys: []string{"memory"},
+		}
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, config)
+
+		cpuPercent, memPercent := handler.processForecastPredictions(resp, 0.60, 0.70)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "forecast", ForecastResponse: resp}, 0.60, 0.70)
+		assert.InDelta(t, 40.0, cpuPercent, 0.001)
+		assert.InDelta(t, 50.0, memPercent, 0.001)
+		assert.InDelta(t, 0.8, confidence, 0.001)
+		assert.Empty(t, handler.defaultedForecastMetrics(resp))
+	})
+
+	t.Run("empty key lists fall back to defaults", func(t *testing.T) {
+		config := DefaultPredictionHandlerConfig()
+		config.ForecastKeys = ForecastKeyMapping{MemoryKeys: []string{"memory"}}
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, config)
+
+		assert.Equal(t, DefaultForecastKeyMapping().CPUKeys, handler.forecastKeys.CPUKeys)
+		assert.Equal(t, []string{CompareSortCPU}, handler.defaultedForecastMetrics(resp))
+	})
+}
+
+func TestApplyAdditionalForecasts(t *testing.T) {
+	t.Run("populates disk and network forecasts when present", func(t *testing.T) {
+		resp := &kserve.ForecastResponse{
+			Predictions: map[string]kserve.ForecastResult{
+				"cpu_usage":   {Forecast: []float64{0.65}},
+				"disk_usage":  {Forecast: []float64{0.91, 0.95}},
+				"network_in":  {Forecast: []float64{0.30}},
+				"network_out": {Forecast: []float64{1.40}},
+			},
+		}
+
+		predictions := PredictionValues{CPUPercent: 65, MemoryPercent: 70}
+		applyAdditionalForecasts(&predictions, resp)
+
+		require.NotNil(t, predictions.DiskPercent)
+		assert.InDelta(t, 91.0, *predictions.DiskPercent, 0.001)
+		require.NotNil(t, predictions.NetworkIn)
+		assert.Equal(t, UnitBytesPerSecond, predictions.NetworkIn.Unit)
+		assert.InDelta(t, 37.5e6, predictions.NetworkIn.Value, 1, "ratios of 125MB/s become byte rates")
+		require.NotNil(t, predictions.NetworkOut)
+		assert.InDelta(t, 175e6, predictions.NetworkOut.Value, 1, "byte rates are not clamped to the 1 Gbps reference")
+	})
+
+	t.Run("bounds out-of-range forecasts", func(t *testing.T) {
+		resp := &kserve.ForecastResponse{
+			Predictions: map[string]kserve.ForecastResult{
+				"disk_usage":  {Forecast: []float64{1.40}},
+				"network_in":  {Forecast: []float64{-10}},
+				"network_out": {Forecast: []float64{math.Inf(1)}},
+			},
+		}
+
+		predictions := PredictionValues{}
+		applyAdditionalForecasts(&predictions, resp)
+
+		require.NotNil(t, predictions.DiskPercent)
+		assert.Equal(t, 100.0, *predictions.DiskPercent, "should be clamped to 100")
+		assert.Equal(t, &MetricValue{Value: 0, Unit: UnitBytesPerSecond}, predictions.NetworkIn)
+		assert.Nil(t, predictions.NetworkOut, "an infinite byte rate cannot be encoded")
+	})
+
+	t.Run("leaves missing or empty metrics unset", func(t *testing.T) {
+		resp := &kserve.ForecastResponse{
+			Predictions: map[string]kserve.ForecastResult{
+				"cpu_usage":  {Forecast: []float64{0.65}},
+				"disk_usage": {Forecast: []float64{}},
+			},
+		}
+
+		predictions := PredictionValues{}
+		applyAdditionalForecasts(&predictions, resp)
+
+		assert.Nil(t, predictions.DiskPercent)
+		assert.Nil(t, predictions.NetworkIn)
+		assert.Nil(t, predictions.NetworkOut)
+	})
+
+	t.Run("optional fields are omitted from JSON", func(t *testing.T) {
+		jsonData, err := json.Marshal(PredictionValues{CPUPercent: 65, MemoryPercent: 70})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"cpu_percent": 65, "memory_percent": 70}`, string(jsonData))
+
+		disk := 92.5
+		jsonData, err = json.Marshal(PredictionValues{CPUPercent: 65, MemoryPercent: 70, DiskPercent: &disk})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"cpu_percent": 65, "memory_percent": 70, "disk_percent": 92.5}`, string(jsonData))
+
+		jsonData, err = json.Marshal(PredictionValues{CPUPercent: 65, MemoryPercent: 70, NetworkIn: &MetricValue{Value: 2048, Unit: UnitBytesPerSecond}})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"cpu_percent": 65, "memory_percent": 70, "network_in": {"value": 2048, "unit": "bytes_per_second"}}`, string(jsonData))
+	})
+}
+
+func TestPredictionHandler_ProcessRegressionPredictions(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	t.Run("default mapping reads [cpu, memory] percentages", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+		resp := &kserve.ModelResponse{
+			Type: "regression",
+			RegressionResponse: &kserve.RegressionResponse{
+				Outputs:      []float64{72.5, 64.0},
+				ModelVersion: "2",
+			},
+		}
+
+		cpuPercent, memPercent, confidence, version, err := handler.processKServeResponse(context.Background(), resp, 0.60, 0.70)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 72.5, cpuPercent, 0.001)
+		assert.InDelta(t, 64.0, memPercent, 0.001)
+		assert.Equal(t, 0.85, confidence)
+		assert.Equal(t, "2", version)
+	})
+
+	t.Run("custom mapping and scale", func(t *testing.T) {
+		config := DefaultPredictionHandlerConfig()
+		config.RegressionOutputs = RegressionOutputMapping{CPUIndex: 2, MemoryIndex: 0, Scale: 100}
+		handler := NewPredictionHandlerWithConfig(nil, nil, log, config)
+
+		cpuPercent, memPercent, err := handler.processRegressionPredictions(&kserve.RegressionResponse{
+			Outputs: []float64{0.40, 0.99, 0.55},
+		})
+
+		require.NoError(t, err)
+		assert.InDelta(t, 55.0, cpuPercent, 0.001)
+		assert.InDelta(t, 40.0, memPercent, 0.001)
+	})
+
+	t.Run("values are clamped", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+
+		cpuPercent, memPercent, err := handler.processRegressionPredictions(&kserve.RegressionResponse{
+			Outputs: []float64{130, -5},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 100.0, cpuPercent)
+		assert.Equal(t, 0.0, memPercent)
+	})
+
+	t.Run("index out of range returns prediction error", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+
+		_, _, err := handler.processRegressionPredictions(&kserve.RegressionResponse{
+			Outputs: []float64{50},
+		})
+
+		require.Error(t, err)
+		var svcErr *serviceError
+		require.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, ErrCodePredictionFailed, svcErr.code)
+	})
+
+	t.Run("nil regression response", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+
+		_, _, _, _, err := handler.processKServeResponse(context.Background(), &kserve.ModelResponse{Type: "regression"}, 0.60, 0.70)
+		assert.Error(t, err)
+	})
+}
+
 func TestPredictionHandler_ProcessAnomalyPredictions(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -842,7 +1563,8 @@ func TestPredictionHandler_ProcessAnomalyPredictions(t *testing.T) {
 		cpuMean := 0.65
 		memMean := 0.72
 
-		cpuPercent, memPercent, confidence := handler.processAnomalyPredictions(resp, cpuMean, memMean)
+		cpuPercent, memPercent := handler.processAnomalyPredictions(resp, cpuMean, memMean)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, cpuMean, memMean)
 
 		// Should increase the predictions
 		assert.Greater(t, cpuPercent, cpuMean*100)
@@ -857,13 +1579,55 @@ func TestPredictionHandler_ProcessAnomalyPredictions(t *testing.T) {
 		cpuMean := 0.65
 		memMean := 0.72
 
-		cpuPercent, memPercent, confidence := handler.processAnomalyPredictions(resp, cpuMean, memMean)
+		cpuPercent, memPercent := handler.processAnomalyPredictions(resp, cpuMean, memMean)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, cpuMean, memMean)
 
 		// Values should be close to original
 		assert.InDelta(t, cpuMean*100, cpuPercent, 10.0)
 		assert.InDelta(t, memMean*100, memPercent, 10.0)
 		assert.Equal(t, 0.88, confidence)
 	})
+
+	t.Run("scores scale confidence and adjustment", func(t *testing.T) {
+		weak := &kserve.DetectResponse{Predictions: []int{-1}, Scores: []float64{-0.05}}
+		strong := &kserve.DetectResponse{Predictions: []int{-1}, Scores: []float64{-0.45}}
+
+		weakCPU, _ := handler.processAnomalyPredictions(weak, 0.5, 0.5)
+		weakConfidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: weak}, 0.5, 0.5)
+		strongCPU, _ := handler.processAnomalyPredictions(strong, 0.5, 0.5)
+		strongConfidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: strong}, 0.5, 0.5)
+
+		assert.Greater(t, strongConfidence, weakConfidence)
+		assert.Greater(t, strongCPU, weakCPU)
+		assert.InDelta(t, 0.549, weakConfidence, 0.001)
+		assert.InDelta(t, 0.941, strongConfidence, 0.001)
+	})
+
+	t.Run("scores beyond the scale are capped", func(t *testing.T) {
+		resp := &kserve.DetectResponse{Predictions: []int{1}, Scores: []float64{3.0}}
+
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, 0.5, 0.5)
+
+		assert.Equal(t, anomalyMaxConfidence, confidence)
+	})
+
+	t.Run("scores without labels use the score sign", func(t *testing.T) {
+		resp := &kserve.DetectResponse{Scores: []float64{-0.5}}
+
+		cpuPercent, _ := handler.processAnomalyPredictions(resp, 0.5, 0.5)
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, 0.5, 0.5)
+
+		assert.InDelta(t, 57.5, cpuPercent, 0.001)
+		assert.Equal(t, anomalyMaxConfidence, confidence)
+	})
+
+	t.Run("unusable scores fall back to fixed confidence", func(t *testing.T) {
+		resp := &kserve.DetectResponse{Predictions: []int{-1}, Scores: []float64{math.NaN()}}
+
+		confidence := scoreConfidence(handler, &kserve.ModelResponse{Type: "anomaly", AnomalyResponse: resp}, 0.5, 0.5)
+
+		assert.Equal(t, 0.92, confidence)
+	})
 }
 
 // =============================================================================
@@ -1003,13 +1767,13 @@ func TestPredictionHandler_BuildRawMetricInstances(t *testing.T) {
 
 	t.Run("builds 5 raw features matching model expectations", func(t *testing.T) {
 		ctx := context.Background()
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:      15,
 			DayOfWeek: 3,
 			Namespace: "test-ns",
-		}
+		}}
 
-		instances, featureCount := handler.buildRawMetricInstances(ctx, req)
+		instances, featureCount, _ := handler.buildRawMetricInstances(ctx, req)
 
 		require.Len(t, instances, 1, "Should return single instance")
 		require.Len(t, instances[0], 5, "Raw metrics should have exactly 5 features (Issue #58)")
@@ -1026,16 +1790,18 @@ func TestPredictionHandler_BuildRawMetricInstances(t *testing.T) {
 
 	t.Run("returns default values when Prometheus unavailable", func(t *testing.T) {
 		ctx := context.Background()
-		req := &PredictRequest{
+		req := &PredictRequest{PredictRequest: types.PredictRequest{
 			Hour:       0,
 			DayOfWeek:  0,
 			Namespace:  "another-ns",
 			Deployment: "my-app",
 			Pod:        "my-pod-xyz",
-		}
+		}}
 
-		instances, featureCount := handler.buildRawMetricInstances(ctx, req)
+		instances, featureCount, snapshot := handler.buildRawMetricInstances(ctx, req)
 
+		assert.Equal(t, []string{"disk_usage", "network_in", "network_out"}, snapshot.defaulted)
+		assert.InDelta(t, 0.45, snapshot.diskUsage, 0.001)
 		require.Len(t, instances, 1, "Should return single instance")
 		require.Len(t, instances[0], 5, "Should have 5 features")
 		assert.Equal(t, 5, featureCount, "Feature count should be 5")
@@ -1052,26 +1818,26 @@ func TestPredictionHandler_BuildRawMetricInstances(t *testing.T) {
 		ctx := context.Background()
 
 		// Cluster scope (no filters)
-		clusterReq := &PredictRequest{Scope: "cluster"}
-		instances, count := handler.buildRawMetricInstances(ctx, clusterReq)
+		clusterReq := &PredictRequest{PredictRequest: types.PredictRequest{Scope: "cluster"}}
+		instances, count, _ := handler.buildRawMetricInstances(ctx, clusterReq)
 		assert.Len(t, instances[0], 5)
 		assert.Equal(t, 5, count)
 
 		// Namespace scope
-		nsReq := &PredictRequest{Scope: "namespace", Namespace: "prod"}
-		instances, count = handler.buildRawMetricInstances(ctx, nsReq)
+		nsReq := &PredictRequest{PredictRequest: types.PredictRequest{Scope: "namespace", Namespace: "prod"}}
+		instances, count, _ = handler.buildRawMetricInstances(ctx, nsReq)
 		assert.Len(t, instances[0], 5)
 		assert.Equal(t, 5, count)
 
 		// Deployment scope
-		deployReq := &PredictRequest{Scope: "deployment", Namespace: "prod", Deployment: "api"}
-		instances, count = handler.buildRawMetricInstances(ctx, deployReq)
+		deployReq := &PredictRequest{PredictRequest: types.PredictRequest{Scope: "deployment", Namespace: "prod", Deployment: "api"}}
+		instances, count, _ = handler.buildRawMetricInstances(ctx, deployReq)
 		assert.Len(t, instances[0], 5)
 		assert.Equal(t, 5, count)
 
 		// Pod scope
-		podReq := &PredictRequest{Scope: "pod", Namespace: "prod", Pod: "api-abc123"}
-		instances, count = handler.buildRawMetricInstances(ctx, podReq)
+		podReq := &PredictRequest{PredictRequest: types.PredictRequest{Scope: "pod", Namespace: "prod", Pod: "api-abc123"}}
+		instances, count, _ = handler.buildRawMetricInstances(ctx, podReq)
 		assert.Len(t, instances[0], 5)
 		assert.Equal(t, 5, count)
 	})
@@ -1245,3 +2011,496 @@ func TestIssue57_ConfigPropagation(t *testing.T) {
 			"Without Prometheus, feature engineering should be disabled even if config enabled")
 	})
 }
+
+func TestPredictionHandler_DescribeModelFeatures(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	t.Run("raw metrics without feature engineering", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+
+		strategy, count := handler.DescribeModelFeatures("predictive-analytics")
+		assert.Equal(t, FeatureStrategyRawMetrics, strategy)
+		assert.Equal(t, 5, count)
+	})
+
+	t.Run("engineered features only for predictive-analytics", func(t *testing.T) {
+		handler := NewPredictionHandler(nil, nil, log)
+		handler.enableFeatureEngineering = true
+		builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{LookbackHours: 24, Enabled: true}, log)
+		require.NoError(t, err)
+		handler.featureBuilder = builder
+
+		strategy, count := handler.DescribeModelFeatures("predictive-analytics")
+		assert.Equal(t, FeatureStrategyEngineered, strategy)
+		assert.Equal(t, handler.featureBuilder.GetFeatureInfo().TotalFeatures, count)
+
+		strategy, count = handler.DescribeModelFeatures("anomaly-detector")
+		assert.Equal(t, FeatureStrategyRawMetrics, strategy)
+		assert.Equal(t, 5, count)
+	})
+}
+
+// TestPredictionHandler_FallbackPolicy verifies a failed feature build falls back to raw
+// metrics unless the fallback policy is strict
+func TestPredictionHandler_FallbackPolicy(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	newHandler := func(policy string) (*PredictionHandler, *fakeModelClient) {
+		client := &fakeModelClient{
+			models:   map[string]bool{"predictive-analytics": true},
+			response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+		}
+		handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{EnableFeatureEngineering: true, FallbackPolicy: policy})
+		// No metric provider, so every feature build fails
+		builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, log)
+		require.NoError(t, err)
+		handler.featureBuilder = builder
+		return handler, client
+	}
+
+	for _, policy := range []string{"", FallbackPolicyLenient} {
+		handler, client := newHandler(policy)
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+		require.Equal(t, http.StatusOK, rr.Code, "policy %q", policy)
+		require.Len(t, client.instances, 1)
+		assert.Len(t, client.instances[0], rawMetricFeatureCount, "policy %q predicts from raw metrics", policy)
+	}
+
+	handler, client := newHandler(FallbackPolicyStrict)
+	rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	var resp PredictErrorResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, ErrCodePredictionFailed, resp.Code)
+	assert.Contains(t, resp.Details, "feature engineering failed: metric data provider not available")
+	assert.Nil(t, client.instances, "the model is not called")
+
+	t.Run("strict without a feature builder", func(t *testing.T) {
+		client := &fakeModelClient{
+			models:   map[string]bool{"predictive-analytics": true},
+			response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+		}
+		handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{EnableFeatureEngineering: true, FallbackPolicy: FallbackPolicyStrict})
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), "feature engineering unavailable: Prometheus not available")
+		assert.Nil(t, client.instances)
+	})
+
+	t.Run("strict with a partial vector", func(t *testing.T) {
+		client := &fakeModelClient{
+			models:   map[string]bool{"predictive-analytics": true},
+			response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+		}
+		handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{EnableFeatureEngineering: true, FallbackPolicy: FallbackPolicyStrict})
+		builder, err := features.NewPredictiveFeatureBuilder(blockingMetricProvider{}, features.PredictiveFeatureConfig{
+			LookbackHours: 2, Enabled: true, MaxBuildDuration: 10 * time.Millisecond,
+		}, log)
+		require.NoError(t, err)
+		handler.featureBuilder = builder
+
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), "feature build incomplete")
+		assert.Nil(t, client.instances)
+	})
+}
+
+// blockingMetricProvider answers no query before its context ends
+type blockingMetricProvider struct{}
+
+func (blockingMetricProvider) QueryRange(ctx context.Context, _ string, _, _ time.Time, _ time.Duration) ([]features.DataPoint, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingMetricProvider) Query(ctx context.Context, _ string) (float64, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func (blockingMetricProvider) QueryAt(ctx context.Context, _ string, _ time.Time) (float64, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+func (blockingMetricProvider) IsAvailable() bool { return true }
+
+func TestPredictionHandler_QueryStep(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client := &fakeModelClient{
+		models:   map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+	}
+	handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{EnableFeatureEngineering: true})
+	builder, err := features.NewPredictiveFeatureBuilder(nil, features.PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, log)
+	require.NoError(t, err)
+	handler.featureBuilder = builder
+
+	for _, body := range []string{
+		`{"hour": 14, "day_of_week": 2, "query_step": "soon"}`,
+		`{"hour": 14, "day_of_week": 2, "query_step": "-1m"}`,
+		`{"hour": 14, "day_of_week": 2, "query_step": "7m"}`, // Does not divide the 3h window
+	} {
+		rr := postPredict(t, handler, body)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Contains(t, rr.Body.String(), "query_step", body)
+	}
+
+	rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2, "query_step": "1m"}`)
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	req := &PredictRequest{PredictRequest: types.PredictRequest{Hour: 14, DayOfWeek: 2}}
+	now := time.Now()
+	key := predictionCacheKey(req, 0.5, 0.5, now, time.Minute)
+	req.queryStep = time.Minute
+	assert.NotEqual(t, key, predictionCacheKey(req, 0.5, 0.5, now, time.Minute), "the step changes the features, so it is part of the key")
+}
+
+func TestPredictionHandler_HandlePredictCompare(t *testing.T) {
+	handler, cleanup := newConcurrentTestHandler(t)
+	defer cleanup()
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/predict/compare", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("ranks every scope", func(t *testing.T) {
+		w := post(`{
+			"hour": 15,
+			"day_of_week": 0,
+			"scopes": [
+				{"namespace": "team-a"},
+				{"namespace": "team-b", "deployment": "api"},
+				{"namespace": "team-c"}
+			],
+			"sort_by": "memory_percent"
+		}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp PredictCompareResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "success", resp.Status)
+		assert.Equal(t, CompareSortMemory, resp.SortBy)
+		assert.Equal(t, 15, resp.TargetTime.Hour)
+		assert.Empty(t, resp.Errors)
+		require.Len(t, resp.Results, 3)
+		assert.Len(t, resp.Ranking, 3)
+		assert.ElementsMatch(t, []string{"team-a", "team-b/api", "team-c"}, resp.Ranking)
+		for i, result := range resp.Results {
+			assert.Equal(t, i+1, result.Rank)
+			assert.Equal(t, resp.Ranking[i], result.Prediction.Target)
+			assert.Equal(t, 17.0, result.Prediction.Predictions.MemoryPercent)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tooMany := make([]CompareScope, MaxCompareScopes+1)
+		for i := range tooMany {
+			tooMany[i] = CompareScope{Namespace: "ns"}
+		}
+		tooManyBody, _ := json.Marshal(PredictCompareRequest{Hour: 1, Scopes: tooMany})
+
+		tests := []struct {
+			name string
+			body string
+		}{
+			{"no scopes", `{"hour": 1, "day_of_week": 1, "scopes": []}`},
+			{"too many scopes", string(tooManyBody)},
+			{"invalid hour", `{"hour": 24, "day_of_week": 1, "scopes": [{"namespace": "a"}]}`},
+			{"invalid sort key", `{"hour": 1, "day_of_week": 1, "scopes": [{"namespace": "a"}], "sort_by": "disk"}`},
+			{"deployment without namespace", `{"hour": 1, "day_of_week": 1, "scopes": [{"deployment": "api"}]}`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := post(tt.body)
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+
+				var resp PredictErrorResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+			})
+		}
+	})
+}
+
+func TestRankPredictions(t *testing.T) {
+	results := []RankedPrediction{
+		{Prediction: PredictResponse{Target: "low", Predictions: PredictionValues{CPUPercent: 10, MemoryPercent: 90}}},
+		{Prediction: PredictResponse{Target: "high", Predictions: PredictionValues{CPUPercent: 80, MemoryPercent: 20}}},
+		{Prediction: PredictResponse{Target: "mid", Predictions: PredictionValues{CPUPercent: 50, MemoryPercent: 50}}},
+	}
+
+	rankPredictions(results, CompareSortCPU)
+	assert.Equal(t, "high", results[0].Prediction.Target)
+	assert.Equal(t, "mid", results[1].Prediction.Target)
+	assert.Equal(t, "low", results[2].Prediction.Target)
+	assert.Equal(t, []int{1, 2, 3}, []int{results[0].Rank, results[1].Rank, results[2].Rank})
+
+	rankPredictions(results, CompareSortMemory)
+	assert.Equal(t, "low", results[0].Prediction.Target)
+	assert.Equal(t, "high", results[2].Prediction.Target)
+}
+
+func TestCompareErrorFor(t *testing.T) {
+	svc := compareErrorFor("ns", &serviceError{message: "Prediction failed", details: "timeout", code: ErrCodePredictionFailed})
+	assert.Equal(t, CompareError{Target: "ns", Error: "Prediction failed", Details: "timeout", Code: ErrCodePredictionFailed}, svc)
+
+	other := compareErrorFor("ns", assert.AnError)
+	assert.Equal(t, ErrCodePredictionFailed, other.Code)
+	assert.Equal(t, assert.AnError.Error(), other.Details)
+}
+
+// fakeModelClient is an in-memory kserve.ModelClient
+type fakeModelClient struct {
+	models   map[string]bool
+	response *kserve.ModelResponse
+	err      error
+
+	mu        sync.Mutex
+	instances [][]float64
+}
+
+func (c *fakeModelClient) GetModel(name string) (*kserve.ModelInfo, bool) {
+	if !c.models[name] {
+		return nil, false
+	}
+	return &kserve.ModelInfo{Name: name}, true
+}
+
+func (c *fakeModelClient) Predict(_ context.Context, modelName string, _ [][]float64) (*kserve.DetectResponse, error) {
+	return nil, &kserve.ModelNotFoundError{ModelName: modelName}
+}
+
+func (c *fakeModelClient) PredictFlexible(_ context.Context, _ string, instances [][]float64) (*kserve.ModelResponse, error) {
+	c.mu.Lock()
+	c.instances = instances
+	c.mu.Unlock()
+	return c.response, c.err
+}
+
+func TestPredictionHandler_ExecutePrediction_ModelClient(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	forecast := &kserve.ModelResponse{
+		Type: "forecast",
+		ForecastResponse: &kserve.ForecastResponse{
+			Predictions: map[string]kserve.ForecastResult{
+				"cpu_usage":    {Forecast: []float64{0.5}, Confidence: []float64{0.9}},
+				"memory_usage": {Forecast: []float64{0.6}, Confidence: []float64{0.8}},
+				"disk_usage":   {Forecast: []float64{0.4}},
+			},
+			ModelVersion: "v3",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		response *kserve.ModelResponse
+		err      error
+		wantErr  string
+	}{
+		{name: "forecast", response: forecast},
+		{name: "model error", err: &kserve.ModelUnavailableError{ModelName: "predictive-analytics"}, wantErr: "Prediction failed"},
+		{name: "empty forecast", response: &kserve.ModelResponse{Type: "forecast"}, wantErr: "Prediction failed"},
+		{name: "unknown format", response: &kserve.ModelResponse{Type: "embedding"}, wantErr: "Prediction failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeModelClient{models: map[string]bool{"predictive-analytics": true}, response: tt.response, err: tt.err}
+			handler := NewPredictionHandler(client, nil, log)
+
+			predictions, confidence, version, err := handler.executePrediction(context.Background(), "predictive-analytics", [][]float64{{0.1}}, 0.5, 0.5)
+			if tt.wantErr != "" {
+				var svcErr *serviceError
+				require.ErrorAs(t, err, &svcErr)
+				assert.Equal(t, tt.wantErr, svcErr.message)
+				assert.Equal(t, ErrCodePredictionFailed, svcErr.code)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.InDelta(t, 50.0, predictions.CPUPercent, 0.001)
+			assert.InDelta(t, 60.0, predictions.MemoryPercent, 0.001)
+			require.NotNil(t, predictions.DiskPercent)
+			assert.InDelta(t, 40.0, *predictions.DiskPercent, 0.001)
+			assert.Nil(t, predictions.NetworkIn)
+			assert.InDelta(t, 0.85, confidence, 0.001)
+			assert.Equal(t, "v3", version)
+		})
+	}
+}
+
+// TestPredictionHandler_HandlePredict_ModelClient runs a full prediction against a fake model
+// client, without KServe environment variables
+func TestPredictionHandler_HandlePredict_ModelClient(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client := &fakeModelClient{
+		models: map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{
+			Type:               "regression",
+			RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 58}, ModelVersion: "r1"},
+		},
+	}
+	handler := NewPredictionHandler(client, nil, log)
+
+	req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(`{"hour": 15, "day_of_week": 3, "namespace": "payments"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.HandlePredict(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp PredictResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.InDelta(t, 42.0, resp.Predictions.CPUPercent, 0.001)
+	assert.InDelta(t, 58.0, resp.Predictions.MemoryPercent, 0.001)
+	assert.Equal(t, "r1", resp.ModelInfo.Version)
+	assert.Equal(t, "payments", resp.Target)
+	assert.InDelta(t, 45.0, resp.CurrentMetrics.DiskUsage, 0.001)
+	assert.Equal(t, MetricValue{Value: 12.5e6, Unit: UnitBytesPerSecond}, resp.CurrentMetrics.NetworkIn)
+	assert.Equal(t, MetricValue{Value: 10e6, Unit: UnitBytesPerSecond}, resp.CurrentMetrics.NetworkOut)
+	assert.Equal(t, []string{"disk_usage", "network_in", "network_out"}, resp.CurrentMetrics.DefaultedMetrics)
+
+	require.Len(t, client.instances, 1)
+	assert.Len(t, client.instances[0], rawMetricFeatureCount, "no Prometheus, so raw metric defaults are sent")
+
+	t.Run("unknown model", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(`{"hour": 15, "day_of_week": 3, "model": "missing"}`))
+		w := httptest.NewRecorder()
+		handler.HandlePredict(w, req)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+// TestNetworkBytesPerSecond verifies rates clamped at the normalization reference are flagged
+func TestNetworkBytesPerSecond(t *testing.T) {
+	assert.Equal(t, MetricValue{Value: 12.5e6, Unit: UnitBytesPerSecond}, networkBytesPerSecond(0.1))
+	assert.Equal(t, MetricValue{Value: 125e6, Unit: UnitBytesPerSecond, Capped: true}, networkBytesPerSecond(1))
+}
+
+// TestPredictionHandler_HandlePredict_DebugRawResponse verifies the raw model response is
+// returned only when enabled and requested, and that debug requests bypass the cache
+func TestPredictionHandler_HandlePredict_DebugRawResponse(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client := &fakeModelClient{
+		models: map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{
+			Type:               "regression",
+			RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 58}, ModelVersion: "r1"},
+		},
+	}
+	predict := func(handler *PredictionHandler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/predict", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.HandlePredict(w, req)
+		return w
+	}
+	const debugBody = `{"hour": 15, "day_of_week": 3, "namespace": "payments", "debug_raw_response": true}`
+
+	t.Run("disabled", func(t *testing.T) {
+		handler := NewPredictionHandler(client, nil, log)
+		w := predict(handler, debugBody)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "debug_raw_response is disabled")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		config := DefaultPredictionHandlerConfig()
+		config.DebugRawResponse = true
+		config.CacheTTL = time.Minute
+		handler := NewPredictionHandlerWithConfig(client, nil, log, config)
+
+		// Cache a plain response first; the debug request must not be served from it
+		w := predict(handler, `{"hour": 15, "day_of_week": 3, "namespace": "payments"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "raw_model_response")
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+
+		w = predict(handler, debugBody)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("ETag"), "debug responses are not cacheable")
+
+		var resp struct {
+			RawModelResponse struct {
+				Type    string                    `json:"type"`
+				Payload kserve.RegressionResponse `json:"payload"`
+			} `json:"raw_model_response"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "regression", resp.RawModelResponse.Type)
+		assert.Equal(t, []float64{42, 58}, resp.RawModelResponse.Payload.Outputs)
+		assert.Equal(t, "r1", resp.RawModelResponse.Payload.ModelVersion)
+
+		w = predict(handler, `{"hour": 15, "day_of_week": 3, "namespace": "payments"}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "raw_model_response", "debug payloads never reach the cache")
+	})
+}
+
+func TestNewRawModelResponse(t *testing.T) {
+	assert.Nil(t, newRawModelResponse(nil))
+	assert.Nil(t, newRawModelResponse(&kserve.ModelResponse{Type: "forecast"}))
+
+	anomaly := &kserve.DetectResponse{Predictions: []int{-1}}
+	raw := newRawModelResponse(&kserve.ModelResponse{Type: "anomaly", AnomalyResponse: anomaly})
+	require.NotNil(t, raw)
+	assert.Equal(t, "anomaly", raw.Type)
+	assert.Same(t, anomaly, raw.Payload)
+}
+
+func TestFeatureVectorSnapshot(t *testing.T) {
+	handler := NewPredictionHandler(nil, nil, logrus.New())
+	snapshot := handler.featureVectorSnapshot(&features.FeatureVector{
+		MetricsData:      map[string]float64{"cpu_usage": 0.5, "disk_usage": 0.3, "network_in": 0.5, "network_out": 0.2},
+		DefaultedMetrics: []string{"cpu_usage", "network_in"},
+	})
+
+	assert.InDelta(t, 0.3, snapshot.diskUsage, 0.001)
+	assert.InDelta(t, 0.2, snapshot.networkOut, 0.001)
+	assert.Equal(t, []string{"network_in"}, snapshot.defaulted, "only disk and network metrics are reported")
+
+	snapshot = handler.featureVectorSnapshot(&features.FeatureVector{
+		MetricsData: map[string]float64{"cpu_usage": 0.5, "memory_usage": 0.6, "disk_usage": 0.3},
+	})
+	assert.InDelta(t, 0.3, snapshot.diskUsage, 0.001)
+	assert.InDelta(t, handler.defaultNetworkIn, snapshot.networkIn, 0.001)
+	assert.Equal(t, []string{"network_in", "network_out"}, snapshot.defaulted, "disabled metrics use their defaults")
+	assert.False(t, snapshot.partialFeatures)
+}
+
+func TestPredictionHandler_PartialFeatures(t *testing.T) {
+	handler := NewPredictionHandler(nil, nil, logrus.New())
+	snapshot := handler.featureVectorSnapshot(&features.FeatureVector{
+		MetricsData:       map[string]float64{"disk_usage": 0.3, "network_in": 0.5, "network_out": 0.2},
+		DefaultedFeatures: 1200,
+		Partial:           true,
+	})
+	require.True(t, snapshot.partialFeatures)
+
+	response := handler.buildPredictResponse(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 15}}, modelPrediction{}, 0.9, "v1", 0.5, 0.5, snapshot)
+	assert.Equal(t, DataQualityPartial, response.DataQuality)
+	assert.Equal(t, 1200, response.DefaultedFeatures)
+
+	response = handler.buildPredictResponse(&PredictRequest{PredictRequest: types.PredictRequest{Hour: 15}}, modelPrediction{}, 0.9, "v1", 0.5, 0.5, rawMetricSnapshot{})
+	assert.Empty(t, response.DataQuality)
+	assert.Zero(t, response.DefaultedFeatures)
+}
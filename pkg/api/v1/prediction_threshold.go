@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// Defaults for time-to-threshold estimates when PredictionHandlerConfig leaves them unset
+const (
+	DefaultForecastStep     = time.Hour // Forecast models are trained on hourly resampled metrics
+	DefaultThresholdPercent = 90.0
+)
+
+// requestTimeToThreshold estimates time to threshold for a forecast model response at the
+// request's threshold, dating steps from now. It returns nil for other model types.
+func (h *PredictionHandler) requestTimeToThreshold(req *PredictRequest, resp *kserve.ModelResponse, now time.Time) *TimeToThreshold {
+	if resp == nil || resp.Type != "forecast" {
+		return nil
+	}
+	threshold := h.thresholdPercent
+	if req.ThresholdPercent != nil {
+		threshold = *req.ThresholdPercent
+	}
+	return h.timeToThreshold(resp.ForecastResponse, threshold, now)
+}
+
+// redateTimeToThreshold returns a copy of t with its crossings dated from now, for a forecast
+// computed earlier and served again from the cache
+func redateTimeToThreshold(t *TimeToThreshold, now time.Time) *TimeToThreshold {
+	if t == nil {
+		return nil
+	}
+	redate := func(crossing *ThresholdCrossing) *ThresholdCrossing {
+		if crossing == nil {
+			return nil
+		}
+		redated := *crossing
+		offset := time.Duration(crossing.HoursFromNow * float64(time.Hour))
+		redated.EstimatedTime = now.Add(offset).UTC().Format(time.RFC3339)
+		return &redated
+	}
+	redated := *t
+	redated.CPU = redate(t.CPU)
+	redated.Memory = redate(t.Memory)
+	return &redated
+}
+
+// timeToThreshold scans the CPU and memory forecasts of resp for the first step reaching
+// threshold, dating steps from now. It returns nil when resp has neither forecast.
+func (h *PredictionHandler) timeToThreshold(resp *kserve.ForecastResponse, threshold float64, now time.Time) *TimeToThreshold {
+	if resp == nil {
+		return nil
+	}
+	cpuForecast, cpuOK := findForecast(resp, h.forecastKeys.CPUKeys)
+	memoryForecast, memoryOK := findForecast(resp, h.forecastKeys.MemoryKeys)
+	if !cpuOK && !memoryOK {
+		return nil
+	}
+
+	result := &TimeToThreshold{
+		ThresholdPercent: threshold,
+		StepDuration:     h.forecastStep.String(),
+		HorizonSteps:     max(len(cpuForecast.Forecast), len(memoryForecast.Forecast)),
+	}
+	result.CPU = firstCrossing(cpuForecast.Forecast, threshold, h.forecastStep, now)
+	result.Memory = firstCrossing(memoryForecast.Forecast, threshold, h.forecastStep, now)
+	return result
+}
+
+// firstCrossing returns the first of forecast's 0-1 values at or above threshold percent,
+// or nil if none is
+func firstCrossing(forecast []float64, threshold float64, step time.Duration, now time.Time) *ThresholdCrossing {
+	for i, value := range forecast {
+		percent := clampPercentage(value * 100)
+		if percent < threshold {
+			continue
+		}
+		offset := time.Duration(i+1) * step
+		return &ThresholdCrossing{
+			Step:             i + 1,
+			HoursFromNow:     offset.Hours(),
+			EstimatedTime:    now.Add(offset).UTC().Format(time.RFC3339),
+			PredictedPercent: percent,
+		}
+	}
+	return nil
+}
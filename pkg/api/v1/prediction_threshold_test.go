@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+func TestFirstCrossing(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	crossing := firstCrossing([]float64{0.5, 0.85, 0.92, 0.97}, 90, time.Hour, now)
+	require.NotNil(t, crossing)
+	assert.Equal(t, 3, crossing.Step)
+	assert.Equal(t, 3.0, crossing.HoursFromNow)
+	assert.Equal(t, "2026-03-02T13:00:00Z", crossing.EstimatedTime)
+	assert.InDelta(t, 92, crossing.PredictedPercent, 0.0001)
+
+	assert.Nil(t, firstCrossing([]float64{0.5, 0.6}, 90, time.Hour, now), "never crossed within the horizon")
+	assert.Nil(t, firstCrossing(nil, 90, time.Hour, now))
+
+	crossing = firstCrossing([]float64{0.95}, 90, 30*time.Minute, now)
+	require.NotNil(t, crossing)
+	assert.Equal(t, 0.5, crossing.HoursFromNow)
+}
+
+func TestPredictionHandler_HandlePredict_TimeToThreshold(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client := &fakeModelClient{
+		models: map[string]bool{"predictive-analytics": true},
+		response: &kserve.ModelResponse{
+			Type: "forecast",
+			ForecastResponse: &kserve.ForecastResponse{Predictions: map[string]kserve.ForecastResult{
+				"cpu_usage":    {Forecast: []float64{0.6, 0.8, 0.91, 0.95}},
+				"memory_usage": {Forecast: []float64{0.5, 0.55, 0.6, 0.62}},
+			}},
+		},
+	}
+	handler := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{})
+
+	decode := func(t *testing.T, body string) PredictResponse {
+		t.Helper()
+		rr := postPredict(t, handler, body)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("default threshold", func(t *testing.T) {
+		response := decode(t, `{"hour": 14, "day_of_week": 2}`)
+		require.NotNil(t, response.TimeToThreshold)
+		assert.Equal(t, DefaultThresholdPercent, response.TimeToThreshold.ThresholdPercent)
+		assert.Equal(t, "1h0m0s", response.TimeToThreshold.StepDuration)
+		assert.Equal(t, 4, response.TimeToThreshold.HorizonSteps)
+		require.NotNil(t, response.TimeToThreshold.CPU)
+		assert.Equal(t, 3, response.TimeToThreshold.CPU.Step)
+		assert.Nil(t, response.TimeToThreshold.Memory, "memory never reaches 90%")
+	})
+
+	t.Run("request threshold", func(t *testing.T) {
+		response := decode(t, `{"hour": 14, "day_of_week": 2, "threshold_percent": 55}`)
+		require.NotNil(t, response.TimeToThreshold)
+		require.NotNil(t, response.TimeToThreshold.CPU)
+		assert.Equal(t, 1, response.TimeToThreshold.CPU.Step)
+		require.NotNil(t, response.TimeToThreshold.Memory)
+		assert.Equal(t, 2, response.TimeToThreshold.Memory.Step)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		for _, body := range []string{
+			`{"hour": 14, "day_of_week": 2, "threshold_percent": 0}`,
+			`{"hour": 14, "day_of_week": 2, "threshold_percent": 101}`,
+		} {
+			rr := postPredict(t, handler, body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("cached forecasts are dated from each request", func(t *testing.T) {
+		cached := NewPredictionHandlerWithConfig(client, nil, log, PredictionHandlerConfig{CacheTTL: time.Minute})
+		body := `{"hour": 14, "day_of_week": 2}`
+		require.Equal(t, http.StatusOK, postPredict(t, cached, body).Code)
+
+		// Age the cached entry as if it had been stored an hour ago
+		for _, entry := range cached.cache.entries {
+			entry.response.TimeToThreshold.CPU.EstimatedTime = time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+		}
+
+		rr := postPredict(t, cached, body)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response PredictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.NotNil(t, response.TimeToThreshold)
+		require.NotNil(t, response.TimeToThreshold.CPU)
+		estimated, err := time.Parse(time.RFC3339, response.TimeToThreshold.CPU.EstimatedTime)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(3*time.Hour), estimated, 5*time.Second)
+	})
+
+	t.Run("regression responses have no forecast", func(t *testing.T) {
+		regression := &fakeModelClient{
+			models:   map[string]bool{"predictive-analytics": true},
+			response: &kserve.ModelResponse{Type: "regression", RegressionResponse: &kserve.RegressionResponse{Outputs: []float64{42, 18}}},
+		}
+		handler := NewPredictionHandlerWithConfig(regression, nil, log, PredictionHandlerConfig{})
+		rr := postPredict(t, handler, `{"hour": 14, "day_of_week": 2}`)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), "time_to_threshold")
+	})
+}
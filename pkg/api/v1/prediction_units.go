@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"math"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// Units of predicted metrics that are not percentages
+const (
+	UnitPercent        = "percent"
+	UnitBytesPerSecond = "bytes_per_second"
+)
+
+// forecastMetric describes how a model forecast becomes a predicted value
+type forecastMetric struct {
+	key   string  // Metric name in the forecast response
+	unit  string  // UnitPercent or UnitBytesPerSecond
+	scale float64 // Applied to the forecast, e.g. 100 for a 0-1 ratio
+	max   float64 // Upper bound, 0 = unbounded
+}
+
+var (
+	// Disk usage is forecast as a 0-1 ratio
+	diskForecastMetric = forecastMetric{key: "disk_usage", unit: UnitPercent, scale: 100, max: 100}
+
+	// Network traffic is forecast as a ratio of MaxNetworkBytesPerSec, like the model inputs, and
+	// reported as a byte rate without an upper bound since traffic may exceed the reference
+	networkInForecastMetric  = forecastMetric{key: "network_in", unit: UnitBytesPerSecond, scale: integrations.MaxNetworkBytesPerSec}
+	networkOutForecastMetric = forecastMetric{key: "network_out", unit: UnitBytesPerSecond, scale: integrations.MaxNetworkBytesPerSec}
+)
+
+// firstForecast returns the closest forecast value for the metric, scaled and bounded to
+// [0, max]. NaN becomes 0. It reports false if the metric has no forecast, or if an unbounded
+// metric's forecast is infinite, which JSON cannot represent.
+func (m forecastMetric) firstForecast(resp *kserve.ForecastResponse) (float64, bool) {
+	result, ok := resp.Predictions[m.key]
+	if !ok || len(result.Forecast) == 0 {
+		return 0, false
+	}
+	value := result.Forecast[0] * m.scale
+	switch {
+	case math.IsNaN(value) || value < 0:
+		return 0, true
+	case m.max > 0 && value > m.max:
+		return m.max, true
+	case math.IsInf(value, 1):
+		return 0, false
+	}
+	return value, true
+}
+
+// firstForecastValue is firstForecast tagged with the metric's unit, or nil without a forecast
+func (m forecastMetric) firstForecastValue(resp *kserve.ForecastResponse) *MetricValue {
+	value, ok := m.firstForecast(resp)
+	if !ok {
+		return nil
+	}
+	return &MetricValue{Value: value, Unit: m.unit}
+}
+
+// networkBytesPerSecond converts a normalized network rate sent to the model back to bytes/sec.
+// Rates above MaxNetworkBytesPerSec were clamped to 1 when normalized, so a full ratio is
+// reported as capped rather than as the actual rate.
+func networkBytesPerSecond(ratio float64) MetricValue {
+	return MetricValue{Value: ratio * integrations.MaxNetworkBytesPerSec, Unit: UnitBytesPerSecond, Capped: ratio >= 1}
+}
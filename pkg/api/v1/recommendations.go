@@ -2,19 +2,27 @@
 package v1
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
 	"github.com/KubeHeal/openshift-coordination-engine/internal/remediation"
 	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
 )
 
@@ -22,20 +30,100 @@ import (
 type RecommendationsHandler struct {
 	orchestrator     *remediation.Orchestrator
 	incidentStore    *storage.IncidentStore
-	kserveClient     *kserve.ProxyClient
+	kserveClient     kserve.ModelClient
 	prometheusClient *integrations.PrometheusClient
+	metricsSnapshot  *integrations.MetricsSnapshot
 	log              *logrus.Logger
 
 	// Default values when Prometheus is not available
 	defaultCPURollingMean    float64
 	defaultMemoryRollingMean float64
+
+	// Recency weighting applied to historical incidents
+	historicalWeighting HistoricalWeighting
+
+	// Optional MachineConfigPool status source; nil disables deferring during MCO updates
+	mcoClient *integrations.MCOClient
+
+	// Acknowledged recommendations are suppressed until their acknowledgement expires
+	ackStore    *storage.RecommendationAckStore
+	ackDuration time.Duration
+
+	// Upper bound on recommendations per response (0 = unlimited)
+	maxRecommendations int
+
+	// Instances per Predict call and Predict calls at once on the ML path
+	mlBatchSize   int
+	mlConcurrency int
+
+	// Lowest confidence reported for ML recommendations
+	mlConfidenceFloor float64
+
+	// Per-model KServe timeouts; models without one use the client timeout
+	modelTimeouts map[string]time.Duration
+
+	// Recently returned recommendations, and the optional exporter creating tickets from them
+	served         *servedRecommendations
+	ticketExporter *integrations.TicketExporter
+
+	// How far past the request time ML recommendations are predicted, per timeframe
+	predictionHorizons map[string]time.Duration
+
+	// Routers and prefixes the routes are registered on
+	routes routeRegistry
+}
+
+// HistoricalWeighting controls how much past incidents contribute to historical recommendations.
+// Each incident contributes 0.5^(age/HalfLife), so recent recurrences dominate old bursts.
+type HistoricalWeighting struct {
+	// HalfLife is the age at which an incident's contribution halves (0 = no decay)
+	HalfLife time.Duration
+
+	// MaxAge excludes incidents older than this (0 = no cutoff)
+	MaxAge time.Duration
+
+	// ResolvedDiscount reduces the contribution of resolved incidents by this fraction, so
+	// resolving the incidents behind a recommendation weakens or clears it: 0 counts them like
+	// open ones, 1 excludes them
+	ResolvedDiscount float64
+}
+
+// Historical weighting defaults - one week half-life, 90 day cutoff, resolved incidents count half
+const (
+	DefaultHistoryHalfLife         = 7 * 24 * time.Hour
+	DefaultHistoryMaxAge           = 90 * 24 * time.Hour
+	DefaultHistoryResolvedDiscount = 0.5
+)
+
+// DefaultHistoricalWeighting returns the default half-life, cutoff and resolved discount
+func DefaultHistoricalWeighting() HistoricalWeighting {
+	return HistoricalWeighting{
+		HalfLife:         DefaultHistoryHalfLife,
+		MaxAge:           DefaultHistoryMaxAge,
+		ResolvedDiscount: DefaultHistoryResolvedDiscount,
+	}
+}
+
+// weight returns the contribution of an occurrence at occurredAt, and false if it is past the cutoff
+func (w HistoricalWeighting) weight(occurredAt, now time.Time) (float64, bool) {
+	age := now.Sub(occurredAt)
+	if age < 0 {
+		age = 0
+	}
+	if w.MaxAge > 0 && age > w.MaxAge {
+		return 0, false
+	}
+	if w.HalfLife <= 0 {
+		return 1, true
+	}
+	return math.Pow(0.5, float64(age)/float64(w.HalfLife)), true
 }
 
 // NewRecommendationsHandler creates a new recommendations handler
 func NewRecommendationsHandler(
 	orchestrator *remediation.Orchestrator,
 	incidentStore *storage.IncidentStore,
-	kserveClient *kserve.ProxyClient,
+	kserveClient kserve.ModelClient,
 	log *logrus.Logger,
 ) *RecommendationsHandler {
 	return &RecommendationsHandler{
@@ -46,7 +134,115 @@ func NewRecommendationsHandler(
 		log:                      log,
 		defaultCPURollingMean:    0.65, // 65% average CPU usage
 		defaultMemoryRollingMean: 0.72, // 72% average memory usage
+		historicalWeighting:      DefaultHistoricalWeighting(),
+		ackStore:                 storage.NewRecommendationAckStore(), // Replaced via SetAckStore to persist
+		ackDuration:              DefaultRecommendationAckDuration,
+		maxRecommendations:       DefaultMaxRecommendations,
+		mlBatchSize:              DefaultMLBatchSize,
+		mlConcurrency:            DefaultMLConcurrency,
+		mlConfidenceFloor:        DefaultMLConfidenceFloor,
+		served:                   newServedRecommendations(),
+		predictionHorizons:       DefaultPredictionHorizons(),
+	}
+}
+
+// RegisterRoutes registers recommendations API routes under DefaultAPIPrefix
+func (h *RecommendationsHandler) RegisterRoutes(router *mux.Router) {
+	h.RegisterRoutesWithPrefix(router, DefaultAPIPrefix)
+}
+
+// RegisterRoutesWithPrefix registers recommendations API routes under prefix; an empty prefix
+// means DefaultAPIPrefix. Registering again on the same router and prefix is a no-op.
+func (h *RecommendationsHandler) RegisterRoutesWithPrefix(router *mux.Router, prefix string) {
+	prefix = normalizeAPIPrefix(prefix)
+	if !h.routes.claim(router, prefix) {
+		h.log.WithField("prefix", prefix).Debug("Recommendations API endpoints already registered")
+		return
+	}
+
+	router.HandleFunc(prefix+"/recommendations", h.GetRecommendations).Methods("POST")
+	router.HandleFunc(prefix+"/recommendations/acknowledgements", h.ListRecommendationAcks).Methods("GET")
+	router.HandleFunc(prefix+"/recommendations/{id}/acknowledge", h.AcknowledgeRecommendation).Methods("POST")
+	router.HandleFunc(prefix+"/recommendations/{id}/acknowledge", h.RemoveRecommendationAck).Methods("DELETE")
+	router.HandleFunc(prefix+"/recommendations/{id}/ticket", h.CreateRecommendationTicket).Methods("POST")
+	h.log.Infof("Recommendations API endpoints registered under %s: POST /recommendations, GET /recommendations/acknowledgements, POST|DELETE /recommendations/{id}/acknowledge, POST /recommendations/{id}/ticket", prefix)
+}
+
+// DefaultMaxRecommendations bounds the recommendations in one response unless configured otherwise
+const DefaultMaxRecommendations = 100
+
+// SetMaxRecommendations bounds the recommendations and near-misses returned per response;
+// requests may lower the bound with max_recommendations but not raise it. 0 removes the bound.
+func (h *RecommendationsHandler) SetMaxRecommendations(limit int) {
+	h.maxRecommendations = max(limit, 0)
+}
+
+// SetHistoricalWeighting sets the recency weighting used for historical recommendations
+func (h *RecommendationsHandler) SetHistoricalWeighting(weighting HistoricalWeighting) {
+	h.historicalWeighting = weighting
+}
+
+// recommendationTimeframes are the timeframes a recommendations request may ask for
+var recommendationTimeframes = []string{"1h", "6h", "24h"}
+
+// RecommendationTimeframes returns the timeframes a recommendations request may ask for
+func RecommendationTimeframes() []string {
+	return slices.Clone(recommendationTimeframes)
+}
+
+// DefaultPredictionHorizons places the predicted time of ML recommendations halfway into the timeframe
+func DefaultPredictionHorizons() map[string]time.Duration {
+	return map[string]time.Duration{
+		"1h":  30 * time.Minute,
+		"6h":  3 * time.Hour,
+		"24h": 12 * time.Hour,
+	}
+}
+
+// ValidatePredictionHorizons checks that horizons cover every timeframe in
+// RecommendationTimeframes, each within (0, timeframe]. An empty mapping is valid and stands
+// for DefaultPredictionHorizons.
+func ValidatePredictionHorizons(horizons map[string]time.Duration) error {
+	if len(horizons) == 0 {
+		return nil
+	}
+	for _, timeframe := range slices.Sorted(maps.Keys(horizons)) {
+		if !slices.Contains(recommendationTimeframes, timeframe) {
+			return fmt.Errorf("unsupported timeframe %q (must be one of %v)", timeframe, recommendationTimeframes)
+		}
+	}
+	for _, timeframe := range recommendationTimeframes {
+		horizon, ok := horizons[timeframe]
+		if !ok {
+			return fmt.Errorf("no prediction horizon for timeframe %q", timeframe)
+		}
+		length, _ := time.ParseDuration(timeframe)
+		if horizon <= 0 || horizon > length {
+			return fmt.Errorf("prediction horizon %s for timeframe %q must be within (0, %s]", horizon, timeframe, timeframe)
+		}
+	}
+	return nil
+}
+
+// SetPredictionHorizons sets how far past the request time ML recommendations are predicted
+// for each timeframe, e.g. the end of the timeframe instead of halfway into it. horizons must
+// pass ValidatePredictionHorizons; an empty mapping restores DefaultPredictionHorizons.
+func (h *RecommendationsHandler) SetPredictionHorizons(horizons map[string]time.Duration) error {
+	if err := ValidatePredictionHorizons(horizons); err != nil {
+		return err
 	}
+	if len(horizons) == 0 {
+		h.predictionHorizons = DefaultPredictionHorizons()
+		return nil
+	}
+	h.predictionHorizons = maps.Clone(horizons)
+	return nil
+}
+
+// SetMCOClient makes recommendations wait for MachineConfigPool updates: while any pool is
+// updating, every recommendation is marked defer_until_stable. nil turns this off.
+func (h *RecommendationsHandler) SetMCOClient(client *integrations.MCOClient) {
+	h.mcoClient = client
 }
 
 // SetPrometheusClient sets the Prometheus client for real metrics querying
@@ -57,66 +253,111 @@ func (h *RecommendationsHandler) SetPrometheusClient(client *integrations.Promet
 	}
 }
 
-// GetRecommendationsRequest represents the request body for getting recommendations
-type GetRecommendationsRequest struct {
-	Timeframe           string  `json:"timeframe"`            // "1h", "6h", "24h" (default: "6h")
-	IncludePredictions  *bool   `json:"include_predictions"`  // Include ML predictions (default: true)
-	ConfidenceThreshold float64 `json:"confidence_threshold"` // Minimum confidence 0.0-1.0 (default: 0.7)
-	Namespace           string  `json:"namespace"`            // Optional: filter by namespace
+// SetMetricsSnapshot makes rolling mean lookups go through a snapshot shared with other
+// handlers instead of querying the Prometheus client directly
+func (h *RecommendationsHandler) SetMetricsSnapshot(snapshot *integrations.MetricsSnapshot) {
+	h.metricsSnapshot = snapshot
 }
 
-// Recommendation represents a single remediation recommendation
-type Recommendation struct {
-	ID                 string   `json:"id"`
-	Type               string   `json:"type"`
-	IssueType          string   `json:"issue_type"`
-	Target             string   `json:"target"`
-	Namespace          string   `json:"namespace"`
-	Severity           string   `json:"severity"`
-	Confidence         float64  `json:"confidence"`
-	PredictedTime      string   `json:"predicted_time,omitempty"`
-	RecommendedActions []string `json:"recommended_actions"`
-	Evidence           []string `json:"evidence"`
-	Source             string   `json:"source,omitempty"`
-	RelatedIncidentID  string   `json:"related_incident_id,omitempty"`
-}
+// DefaultNearMissMargin is how far below the confidence threshold near-miss recommendations
+// are still returned when a request enables them without a margin
+const DefaultNearMissMargin = 0.1
 
-// GetRecommendationsResponse represents the response for getting recommendations
-type GetRecommendationsResponse struct {
-	Status               string           `json:"status"`
-	Timestamp            string           `json:"timestamp"`
-	Timeframe            string           `json:"timeframe"`
-	Recommendations      []Recommendation `json:"recommendations"`
-	TotalRecommendations int              `json:"total_recommendations"`
-	MLEnabled            bool             `json:"ml_enabled"`
-	Message              string           `json:"message,omitempty"`
-}
+// RecommendationsErrorResponse is the error body of the recommendations API. It has the same
+// shape as the prediction API's error body so clients can handle both alike.
+type RecommendationsErrorResponse = PredictErrorResponse
+
+// Error codes for recommendation failures
+const (
+	ErrCodeInvalidTimeframe  = types.ErrCodeInvalidTimeframe
+	ErrCodeInvalidConfidence = types.ErrCodeInvalidConfidence
+	ErrCodeMLUnavailable     = types.ErrCodeMLUnavailable
+	ErrCodeInternalError     = types.ErrCodeInternalError
+)
 
 // GetRecommendations handles POST /api/v1/recommendations
 func (h *RecommendationsHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	h.log.Info("Received get recommendations request")
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	r = r.WithContext(ctx)
+	h.log.WithContext(ctx).Info("Received get recommendations request")
 
 	// Parse and validate request
 	req, err := h.parseAndValidateRequest(r)
 	if err != nil {
-		h.respondError(w, http.StatusBadRequest, err.Error())
+		h.handleError(ctx, w, err)
 		return
 	}
 
-	h.log.WithFields(logrus.Fields{
+	h.respondJSON(w, http.StatusOK, h.recommend(ctx, req))
+}
+
+// recommend runs the recommendations pipeline for a validated request: collect, defer during
+// MCO updates, filter, suppress acknowledged, sort and cap
+func (h *RecommendationsHandler) recommend(ctx context.Context, req *GetRecommendationsRequest) GetRecommendationsResponse {
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
 		"timeframe":            req.Timeframe,
 		"include_predictions":  *req.IncludePredictions,
 		"confidence_threshold": req.ConfidenceThreshold,
 		"namespace":            req.Namespace,
+		"include_near_misses":  req.IncludeNearMisses,
 	}).Info("Processing recommendations request")
 
-	// Collect and filter recommendations
 	recommendations, mlEnabled := h.collectRecommendations(ctx, req)
-	filteredRecs := h.filterRecommendations(recommendations, req)
 
-	// Build and send response
-	h.sendRecommendationsResponse(w, req, filteredRecs, mlEnabled)
+	// Acting while nodes reboot into a new machine config is risky, so wait for the roll-out.
+	// Severity is lowered before filtering so every later step sees the deferred severity.
+	updatingPools := h.updatingPools(ctx)
+	if len(updatingPools) > 0 {
+		deferUntilStable(recommendations, updatingPools)
+		h.log.WithContext(ctx).WithField("updating_pools", updatingPools).
+			Info("MachineConfigPool update in progress, deferring recommendations")
+	}
+
+	filteredRecs, nearMisses := h.filterRecommendations(recommendations, req)
+
+	// Recommendations an operator is already acting on stay out of the results until the
+	// acknowledgement expires
+	now := time.Now()
+	filteredRecs, acknowledged := h.separateAcknowledged(filteredRecs, now)
+	nearMisses, _ = h.separateAcknowledged(nearMisses, now)
+	if !req.IncludeAcknowledged {
+		acknowledged = nil
+	}
+
+	// Most important first, so a capped response keeps the entries worth acting on
+	sortRecommendations(filteredRecs)
+	sortRecommendations(nearMisses)
+
+	// Remembered with their evidence data, so tickets can be created from what was listed
+	h.served.remember(now, filteredRecs, nearMisses, acknowledged)
+
+	return h.buildRecommendationsResponse(ctx, req, filteredRecs, nearMisses, acknowledged, mlEnabled, updatingPools)
+}
+
+// sortRecommendations orders recommendations by severity, then confidence, both descending.
+// Ties fall back to the stable ID so repeated requests list them in the same order.
+func sortRecommendations(recommendations []Recommendation) {
+	slices.SortStableFunc(recommendations, func(a, b Recommendation) int {
+		if c := cmp.Compare(severityRank(b.Severity), severityRank(a.Severity)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Confidence, a.Confidence); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// recommendationLimit returns the cap for req: its max_recommendations within the server limit
+func (h *RecommendationsHandler) recommendationLimit(req *GetRecommendationsRequest) int {
+	switch {
+	case req.MaxRecommendations == 0:
+		return h.maxRecommendations
+	case h.maxRecommendations == 0:
+		return req.MaxRecommendations
+	default:
+		return min(req.MaxRecommendations, h.maxRecommendations)
+	}
 }
 
 // parseAndValidateRequest parses the request body and validates parameters
@@ -125,11 +366,19 @@ func (h *RecommendationsHandler) parseAndValidateRequest(r *http.Request) (*GetR
 
 	if r.ContentLength > 0 {
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			h.log.WithError(err).Debug("Failed to decode request body")
-			return nil, fmt.Errorf("invalid request body: %w", err)
+			h.log.WithContext(r.Context()).WithError(err).Debug("Failed to decode request body")
+			return nil, decodeRequestError("invalid request body", err)
 		}
 	}
 
+	if err := validateRecommendationsRequest(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// validateRecommendationsRequest fills in req's defaults and validates its parameters
+func validateRecommendationsRequest(req *GetRecommendationsRequest) error {
 	// Set defaults
 	if req.Timeframe == "" {
 		req.Timeframe = "6h"
@@ -141,19 +390,59 @@ func (h *RecommendationsHandler) parseAndValidateRequest(r *http.Request) (*GetR
 	if req.ConfidenceThreshold == 0 {
 		req.ConfidenceThreshold = 0.7
 	}
+	if req.IncludeNearMisses && req.NearMissMargin == 0 {
+		req.NearMissMargin = DefaultNearMissMargin
+	}
 
 	// Validate timeframe
-	validTimeframes := map[string]bool{"1h": true, "6h": true, "24h": true}
-	if !validTimeframes[req.Timeframe] {
-		return nil, fmt.Errorf("invalid timeframe: must be '1h', '6h', or '24h'")
+	if !slices.Contains(recommendationTimeframes, req.Timeframe) {
+		return &requestError{
+			message: "invalid timeframe: must be '1h', '6h', or '24h'",
+			details: fmt.Sprintf("got %q", req.Timeframe),
+			code:    ErrCodeInvalidTimeframe,
+		}
 	}
 
 	// Validate confidence threshold
 	if req.ConfidenceThreshold < 0 || req.ConfidenceThreshold > 1 {
-		return nil, fmt.Errorf("invalid confidence_threshold: must be between 0.0 and 1.0")
+		return &requestError{
+			message: "invalid confidence_threshold: must be between 0.0 and 1.0",
+			details: fmt.Sprintf("got %g", req.ConfidenceThreshold),
+			code:    ErrCodeInvalidConfidence,
+		}
 	}
 
-	return &req, nil
+	// Validate near-miss margin
+	if req.NearMissMargin < 0 || req.NearMissMargin > 1 {
+		return &requestError{
+			message: "invalid near_miss_margin: must be between 0.0 and 1.0",
+			details: fmt.Sprintf("got %g", req.NearMissMargin),
+			code:    ErrCodeInvalidConfidence,
+		}
+	}
+
+	// Validate the result cap
+	if req.MaxRecommendations < 0 {
+		return &requestError{
+			message: "invalid max_recommendations: must not be negative",
+			details: fmt.Sprintf("got %d", req.MaxRecommendations),
+			code:    ErrCodeInvalidRequest,
+		}
+	}
+
+	if err := validateMLNamespaces(req.MLNamespaces); err != nil {
+		return err
+	}
+
+	if err := models.ValidateLabels(req.IncidentLabels); err != nil {
+		return &requestError{
+			message: "invalid incident_labels",
+			details: err.Error(),
+			code:    ErrCodeInvalidRequest,
+		}
+	}
+
+	return nil
 }
 
 // collectRecommendations gathers recommendations from all sources
@@ -170,7 +459,9 @@ func (h *RecommendationsHandler) collectRecommendations(ctx context.Context, req
 		mlEnabled = true
 		mlRecs, err := h.getMLPredictions(ctx, req)
 		if err != nil {
-			h.log.WithError(err).Warn("ML predictions failed, continuing with historical analysis")
+			// ML is best-effort here; the code is logged so failures can be told apart from bad input
+			h.log.WithContext(ctx).WithError(err).WithField("code", ErrCodeMLUnavailable).
+				Warn("ML predictions failed, continuing with historical analysis")
 			mlEnabled = false
 		} else {
 			recommendations = append(recommendations, mlRecs...)
@@ -181,47 +472,240 @@ func (h *RecommendationsHandler) collectRecommendations(ctx context.Context, req
 	patternRecs := h.getPatternRecommendations()
 	recommendations = append(recommendations, patternRecs...)
 
-	return recommendations, mlEnabled
+	merged := mergeRecommendations(recommendations)
+	if len(merged) < len(recommendations) {
+		h.log.WithContext(ctx).WithFields(logrus.Fields{
+			"collected": len(recommendations),
+			"merged":    len(merged),
+		}).Debug("Merged duplicate recommendations")
+	}
+	return merged, mlEnabled
+}
+
+// recommendationKey identifies recommendations that describe the same issue
+type recommendationKey struct {
+	issueType string
+	namespace string
+	target    string
+}
+
+// mergeRecommendations collapses recommendations sharing issue type, namespace and target, so
+// the same issue reported by several sources appears once. The merged recommendation keeps the
+// first one's ID and order, takes the highest confidence, the most severe severity and the
+// earliest predicted time, and combines evidence, actions and sources without repeats.
+func mergeRecommendations(recommendations []Recommendation) []Recommendation {
+	merged := make([]Recommendation, 0, len(recommendations))
+	index := make(map[recommendationKey]int, len(recommendations))
+
+	for _, rec := range recommendations {
+		key := recommendationKey{issueType: rec.IssueType, namespace: rec.Namespace, target: rec.Target}
+		i, seen := index[key]
+		if !seen {
+			rec.Sources = appendUnique(nil, rec.Source)
+			index[key] = len(merged)
+			merged = append(merged, rec)
+			continue
+		}
+
+		existing := &merged[i]
+		existing.Confidence = max(existing.Confidence, rec.Confidence)
+		if severityRank(rec.Severity) > severityRank(existing.Severity) {
+			existing.Severity = rec.Severity
+		}
+		if rec.PredictedTime != "" && (existing.PredictedTime == "" || rec.PredictedTime < existing.PredictedTime) {
+			existing.PredictedTime = rec.PredictedTime // RFC 3339 UTC timestamps sort lexically
+		}
+		if existing.RelatedIncidentID == "" {
+			existing.RelatedIncidentID = rec.RelatedIncidentID
+		}
+		existing.Evidence = appendUnique(existing.Evidence, rec.Evidence...)
+		existing.RecommendedActions = appendUnique(existing.RecommendedActions, rec.RecommendedActions...)
+		existing.Sources = appendUnique(existing.Sources, rec.Source)
+		existing.EvidenceData = mergeEvidenceData(existing.EvidenceData, rec.EvidenceData)
+	}
+
+	// A recommendation from a single source needs no sources list
+	for i := range merged {
+		if len(merged[i].Sources) < 2 {
+			merged[i].Sources = nil
+		}
+	}
+	return merged
 }
 
-// filterRecommendations filters recommendations by confidence and namespace
-func (h *RecommendationsHandler) filterRecommendations(recommendations []Recommendation, req *GetRecommendationsRequest) []Recommendation {
-	filteredRecs := make([]Recommendation, 0, len(recommendations))
+// mergeEvidenceData fills the sections a lacks from b. A section both have keeps a's, like the
+// merged recommendation keeps the first one's ID. The result never aliases a.
+func mergeEvidenceData(a, b *EvidenceData) *EvidenceData {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		return b
+	}
+	merged := *a
+	if merged.Historical == nil {
+		merged.Historical = b.Historical
+	}
+	if merged.Prediction == nil {
+		merged.Prediction = b.Prediction
+	}
+	if merged.Pattern == nil {
+		merged.Pattern = b.Pattern
+	}
+	return &merged
+}
+
+// appendUnique appends the non-empty values not already in list
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		if v != "" && !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// severityRank orders recommendation severities from low to critical; unknown severities rank lowest
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 4
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	case "low":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// filterRecommendations filters recommendations by confidence and namespace. When the request
+// includes near-misses, those within NearMissMargin below the threshold are returned
+// separately, flagged with their shortfall.
+func (h *RecommendationsHandler) filterRecommendations(recommendations []Recommendation, req *GetRecommendationsRequest) (filteredRecs, nearMisses []Recommendation) {
+	filteredRecs = make([]Recommendation, 0, len(recommendations))
+	floor := max(req.ConfidenceThreshold-req.NearMissMargin, 0)
 
 	for i := range recommendations {
 		rec := &recommendations[i]
-		if rec.Confidence >= req.ConfidenceThreshold {
-			if req.Namespace == "" || rec.Namespace == req.Namespace {
-				filteredRecs = append(filteredRecs, *rec)
-			}
+		if req.Namespace != "" && rec.Namespace != req.Namespace {
+			continue
+		}
+		switch {
+		case rec.Confidence >= req.ConfidenceThreshold:
+			filteredRecs = append(filteredRecs, *rec)
+		case req.IncludeNearMisses && rec.Confidence >= floor:
+			nearMiss := *rec
+			nearMiss.NearMiss = true
+			nearMiss.ConfidenceShortfall = math.Round((req.ConfidenceThreshold-rec.Confidence)*1000) / 1000
+			nearMisses = append(nearMisses, nearMiss)
 		}
 	}
 
-	return filteredRecs
+	return filteredRecs, nearMisses
 }
 
-// sendRecommendationsResponse builds and sends the response
-func (h *RecommendationsHandler) sendRecommendationsResponse(w http.ResponseWriter, req *GetRecommendationsRequest, filteredRecs []Recommendation, mlEnabled bool) {
+// buildRecommendationsResponse strips evidence data unless requested, applies the result cap
+// and builds the response
+func (h *RecommendationsHandler) buildRecommendationsResponse(ctx context.Context, req *GetRecommendationsRequest, filteredRecs, nearMisses, acknowledged []Recommendation, mlEnabled bool, updatingPools []string) GetRecommendationsResponse {
+	if !req.DetailedEvidence {
+		stripEvidenceData(filteredRecs)
+		stripEvidenceData(nearMisses)
+		stripEvidenceData(acknowledged)
+	}
+
+	total := len(filteredRecs)
+	truncated := false
+	if limit := h.recommendationLimit(req); limit > 0 {
+		truncated = len(filteredRecs) > limit || len(nearMisses) > limit
+		filteredRecs = filteredRecs[:min(len(filteredRecs), limit)]
+		nearMisses = nearMisses[:min(len(nearMisses), limit)]
+	}
+
 	response := GetRecommendationsResponse{
 		Status:               "success",
 		Timestamp:            time.Now().UTC().Format(time.RFC3339),
 		Timeframe:            req.Timeframe,
 		Recommendations:      filteredRecs,
-		TotalRecommendations: len(filteredRecs),
+		TotalRecommendations: total,
+		Truncated:            truncated,
 		MLEnabled:            mlEnabled,
+		BelowThreshold:       nearMisses,
+		UpdatingPools:        updatingPools,
+		Acknowledged:         acknowledged,
 	}
 
-	if len(filteredRecs) == 0 {
+	switch {
+	case len(filteredRecs) == 0 && len(nearMisses) > 0:
+		response.Message = fmt.Sprintf("No recommendations above the confidence threshold; %d near-misses below it", len(nearMisses))
+	case len(filteredRecs) == 0:
 		response.Message = "No recommendations above the confidence threshold"
 	}
 
-	h.log.WithFields(logrus.Fields{
-		"total_recommendations": len(filteredRecs),
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"total_recommendations": total,
+		"returned":              len(filteredRecs),
+		"near_misses":           len(nearMisses),
+		"acknowledged":          len(acknowledged),
 		"ml_enabled":            mlEnabled,
 		"timeframe":             req.Timeframe,
 	}).Info("Recommendations generated successfully")
 
-	h.respondJSON(w, http.StatusOK, response)
+	return response
+}
+
+// updatingPools returns the names of MachineConfigPools rolling out a new config. Pools whose
+// status cannot be read are skipped, so an unreachable MCO never holds recommendations back.
+func (h *RecommendationsHandler) updatingPools(ctx context.Context) []string {
+	if h.mcoClient == nil {
+		return nil
+	}
+	statuses, err := h.mcoClient.GetAllPoolStatuses(ctx)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).Warn("Failed to check MachineConfigPool status, gating only on pools that were read")
+	}
+
+	var updating []string
+	for i := range statuses {
+		if statuses[i].Updating {
+			updating = append(updating, statuses[i].Name)
+		}
+	}
+	return updating
+}
+
+// deferUntilStable flags recommendations to wait for the pools' update and lowers their severity
+func deferUntilStable(recommendations []Recommendation, pools []string) {
+	evidence := fmt.Sprintf("MachineConfigPool update in progress (%s); act once the pools are stable", strings.Join(pools, ", "))
+	for i := range recommendations {
+		rec := &recommendations[i]
+		rec.DeferUntilStable = true
+		rec.Severity = lowerSeverity(rec.Severity)
+		rec.Evidence = append(rec.Evidence, evidence)
+	}
+}
+
+// lowerSeverity returns the severity one level below severity; low and unknown severities are kept
+func lowerSeverity(severity string) string {
+	switch severity {
+	case "critical":
+		return "high"
+	case "high":
+		return "medium"
+	case "medium":
+		return "low"
+	default:
+		return severity
+	}
+}
+
+// stripEvidenceData drops the structured evidence from recommendations
+func stripEvidenceData(recommendations []Recommendation) {
+	for i := range recommendations {
+		recommendations[i].EvidenceData = nil
+	}
 }
 
 // getHistoricalRecommendations analyzes historical incidents to generate recommendations
@@ -231,71 +715,126 @@ func (h *RecommendationsHandler) getHistoricalRecommendations(req *GetRecommenda
 	// Get historical incidents from store
 	filter := storage.ListFilter{
 		Namespace: req.Namespace,
+		Labels:    req.IncidentLabels,
 		Limit:     100,
 	}
 	incidents := h.incidentStore.List(filter)
 
-	// Get workflow-based incidents (if orchestrator is available)
+	// Get workflow-based incidents (if orchestrator is available); they carry no labels
 	var workflows []*models.Workflow
-	if h.orchestrator != nil {
+	if h.orchestrator != nil && len(req.IncidentLabels) == 0 {
 		workflows = h.orchestrator.ListWorkflows()
 	}
 
-	// Analyze incident patterns
+	// Analyze incident patterns: discounted counts for recurrence and severity, recency-weighted
+	// scores for confidence
+	now := time.Now()
 	issueFrequency := make(map[string]int)
+	issueCount := make(map[string]float64)
+	issueScore := make(map[string]float64)
+	autoResolved := make(map[string]int)
+	remediationFailures := make(map[string]int)
+	record := func(key string, occurredAt time.Time, resolved bool) bool {
+		weight, ok := h.historicalWeighting.weight(occurredAt, now)
+		if !ok {
+			return false // Older than the cutoff
+		}
+		share := 1.0
+		if resolved {
+			if h.historicalWeighting.ResolvedDiscount >= 1 {
+				return false
+			}
+			share = 1 - h.historicalWeighting.ResolvedDiscount
+		}
+		issueFrequency[key]++
+		issueCount[key] += share
+		issueScore[key] += weight * share
+		return true
+	}
 
-	// Count incident types from stored incidents
+	// Count incident types from stored incidents, noting how linked remediation workflows went
 	for _, inc := range incidents {
 		key := string(inc.Severity) + ":" + inc.Target
-		issueFrequency[key]++
+		if !record(key, inc.CreatedAt, inc.Status == models.IncidentStatusResolved) {
+			continue
+		}
+		if inc.AutoResolved() {
+			autoResolved[key]++
+		}
+		remediationFailures[key] += inc.RemediationFailures
 	}
 
 	// Count issue types from workflows
 	for _, wf := range workflows {
-		key := wf.IssueType + ":" + wf.Namespace
-		issueFrequency[key]++
+		record(wf.IssueType+":"+wf.Namespace, wf.CreatedAt, false)
 	}
 
-	// Generate recommendations for recurring issues
-	recID := 0
-	for key, count := range issueFrequency {
-		if count < 2 {
+	// Generate recommendations for recurring issues, most frequent first in a reproducible order
+	for _, key := range keysByCount(issueCount) {
+		count := issueFrequency[key]
+		discounted := issueCount[key]
+		if discounted < 2 {
 			continue // Only recommend for recurring issues
 		}
+		score := issueScore[key]
 
 		issueType, namespace := parseKeyParts(key)
 		if issueType == "" || namespace == "" {
 			continue
 		}
 
-		recID++
+		actions := getRecommendedActions(issueType)
+		occurrences := fmt.Sprintf("Issue occurred %d times in recent history", count)
+		if discounted < float64(count) {
+			occurrences += fmt.Sprintf(" (%.1f after discounting resolved incidents)", discounted)
+		}
+		evidence := []string{
+			occurrences,
+			fmt.Sprintf("Recency-weighted occurrence score: %.2f", score),
+			fmt.Sprintf("Pattern detected in namespace: %s", namespace),
+		}
+		data := &HistoricalEvidence{
+			OccurrenceCount:     count,
+			DiscountedCount:     discounted,
+			WeightedScore:       score,
+			AutoResolvedCount:   autoResolved[key],
+			RemediationFailures: remediationFailures[key],
+		}
+		if resolved := autoResolved[key]; resolved > 0 {
+			evidence = append(evidence, fmt.Sprintf("%d incidents were auto-resolved by remediation workflows", resolved))
+		}
+		if failures := remediationFailures[key]; failures > 0 {
+			// Automated remediation keeps failing for this pattern, so a human should look at it
+			evidence = append(evidence, fmt.Sprintf("Automated remediation failed %d times for these incidents", failures))
+			actions = append(actions, "review_remediation_strategy")
+		}
+
 		recommendations = append(recommendations, Recommendation{
-			ID:                 fmt.Sprintf("rec-hist-%03d", recID),
+			ID:                 recommendationID("historical_analysis", issueType, namespace, namespace),
 			Type:               "proactive",
 			IssueType:          issueType,
 			Target:             namespace,
 			Namespace:          namespace,
-			Severity:           mapCountToSeverity(count),
-			Confidence:         calculateHistoricalConfidence(count),
-			RecommendedActions: getRecommendedActions(issueType),
-			Evidence: []string{
-				fmt.Sprintf("Issue occurred %d times in recent history", count),
-				fmt.Sprintf("Pattern detected in namespace: %s", namespace),
-			},
-			Source: "historical_analysis",
+			Severity:           mapCountToSeverity(int(discounted)),
+			Confidence:         calculateWeightedHistoricalConfidence(score),
+			RecommendedActions: actions,
+			Evidence:           evidence,
+			EvidenceData:       &EvidenceData{Historical: data},
+			Source:             "historical_analysis",
 		})
 	}
 
 	return recommendations
 }
 
-// getMLPredictions calls KServe predictive-analytics model for ML-based predictions
+// getMLPredictions calls KServe predictive-analytics model for ML-based predictions. Every
+// scope's instances go to the model together, in as few batched calls as the batch size allows.
 func (h *RecommendationsHandler) getMLPredictions(ctx context.Context, req *GetRecommendationsRequest) ([]Recommendation, error) {
 	recommendations := make([]Recommendation, 0)
 
 	// Check if predictive-analytics model is available
 	if _, exists := h.kserveClient.GetModel("predictive-analytics"); !exists {
-		h.log.Debug("predictive-analytics model not available")
+		h.log.WithContext(ctx).Debug("predictive-analytics model not available")
 		return recommendations, nil
 	}
 
@@ -305,46 +844,49 @@ func (h *RecommendationsHandler) getMLPredictions(ctx context.Context, req *GetR
 	// Prepare input features matching model training order:
 	// [hour_of_day, day_of_week, cpu_rolling_mean, memory_rolling_mean]
 	// The model expects exactly 4 features in this specific order
-	instances := h.buildPredictionInstances(ctx, currentTime)
+	scopes := h.mlScopes(ctx, req)
+	var instances [][]float64
+	for i := range scopes {
+		scopes[i].offset = len(instances)
+		instances = append(instances, h.buildPredictionInstances(currentTime, scopes[i])...)
+	}
 
-	h.log.WithFields(logrus.Fields{
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
 		"hour_of_day": currentTime.Hour(),
 		"day_of_week": int(currentTime.Weekday()),
+		"scopes":      len(scopes),
 		"instances":   len(instances),
 	}).Debug("Prepared ML prediction features")
 
 	// Call KServe model
-	resp, err := h.kserveClient.Predict(ctx, "predictive-analytics", instances)
+	predictions, err := h.predictBatched(ctx, "predictive-analytics", instances)
 	if err != nil {
-		return nil, fmt.Errorf("prediction failed: %w", err)
+		return nil, &serviceError{message: "ML predictions unavailable", details: err.Error(), code: ErrCodeMLUnavailable}
 	}
 
-	h.log.WithField("predictions", len(resp.Predictions)).Info("ML predictions successful")
+	h.log.WithContext(ctx).WithField("predictions", len(predictions)).Info("ML predictions successful")
 
 	// Interpret predictions
 	// The model may return classification (-1 = issue predicted, 1 = normal)
 	// or scaled values that indicate resource pressure
-	recommendations = h.interpretMLPredictions(resp.Predictions, req, currentTime, instances)
+	for _, scope := range scopes {
+		end := scope.offset + mlInstancesPerScope
+		recommendations = append(recommendations,
+			h.interpretMLPredictions(predictions[scope.offset:end], req, scope, currentTime, instances[scope.offset:end])...)
+	}
 
 	return recommendations, nil
 }
 
-// buildPredictionInstances creates feature instances for ML prediction
+// mlInstancesPerScope is the number of instances buildPredictionInstances returns
+const mlInstancesPerScope = 2
+
+// buildPredictionInstances creates the feature instances for one scope's ML prediction
 // Features must match training order: [hour_of_day, day_of_week, cpu_rolling_mean, memory_rolling_mean]
-func (h *RecommendationsHandler) buildPredictionInstances(ctx context.Context, currentTime time.Time) [][]float64 {
+func (h *RecommendationsHandler) buildPredictionInstances(currentTime time.Time, scope mlScope) [][]float64 {
 	hourOfDay := float64(currentTime.Hour())
 	dayOfWeek := float64(currentTime.Weekday())
-
-	// Calculate rolling means from recent metrics
-	// Uses Prometheus if available, otherwise falls back to defaults
-	cpuRollingMean := h.getCPURollingMeanWithContext(ctx)
-	memoryRollingMean := h.getMemoryRollingMeanWithContext(ctx)
-
-	h.log.WithFields(logrus.Fields{
-		"cpu_rolling_mean":    cpuRollingMean,
-		"memory_rolling_mean": memoryRollingMean,
-		"prometheus_enabled":  h.prometheusClient != nil && h.prometheusClient.IsAvailable(),
-	}).Debug("Retrieved rolling mean metrics")
+	cpuRollingMean, memoryRollingMean := scope.cpuRollingMean, scope.memoryRollingMean
 
 	// Build instances with 4 features each (matching model training)
 	instances := [][]float64{
@@ -362,61 +904,50 @@ func (h *RecommendationsHandler) buildPredictionInstances(ctx context.Context, c
 	return instances
 }
 
-// getCPURollingMean returns the 24-hour rolling mean of CPU usage
-// Queries Prometheus if available, otherwise returns a default value
-func (h *RecommendationsHandler) getCPURollingMean() float64 {
-	return h.getCPURollingMeanWithContext(context.Background())
-}
-
-// getCPURollingMeanWithContext returns the 24-hour rolling mean of CPU usage with context
-func (h *RecommendationsHandler) getCPURollingMeanWithContext(ctx context.Context) float64 {
-	if h.prometheusClient != nil && h.prometheusClient.IsAvailable() {
-		value, err := h.prometheusClient.GetCPURollingMean(ctx)
-		if err != nil {
-			h.log.WithError(err).Debug("Failed to get CPU rolling mean from Prometheus, using default")
-			return h.defaultCPURollingMean
-		}
-		return value
+// getRollingMeans returns the CPU and memory rolling means of namespace (empty = cluster),
+// using the shared metrics snapshot when one is set. Each metric falls back to its default
+// independently.
+func (h *RecommendationsHandler) getRollingMeans(ctx context.Context, namespace string) (cpuRollingMean, memoryRollingMean float64) {
+	snapshot := h.metricsSnapshot
+	if snapshot == nil {
+		snapshot = integrations.NewMetricsSnapshot(h.prometheusClient, 0)
+	}
+	if !snapshot.IsAvailable() {
+		return h.defaultCPURollingMean, h.defaultMemoryRollingMean
 	}
-	return h.defaultCPURollingMean
-}
 
-// getMemoryRollingMean returns the 24-hour rolling mean of memory usage
-// Queries Prometheus if available, otherwise returns a default value
-func (h *RecommendationsHandler) getMemoryRollingMean() float64 {
-	return h.getMemoryRollingMeanWithContext(context.Background())
-}
+	means, err := snapshot.RollingMeans(ctx, integrations.MetricsScope{Namespace: namespace})
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).WithField("namespace", namespace).
+			Debug("Failed to get rolling means from Prometheus, using defaults for missing metrics")
+	}
 
-// getMemoryRollingMeanWithContext returns the 24-hour rolling mean of memory usage with context
-func (h *RecommendationsHandler) getMemoryRollingMeanWithContext(ctx context.Context) float64 {
-	if h.prometheusClient != nil && h.prometheusClient.IsAvailable() {
-		value, err := h.prometheusClient.GetMemoryRollingMean(ctx)
-		if err != nil {
-			h.log.WithError(err).Debug("Failed to get memory rolling mean from Prometheus, using default")
-			return h.defaultMemoryRollingMean
-		}
-		return value
+	cpuRollingMean, memoryRollingMean = means.CPU, means.Memory
+	if !means.CPUOK {
+		cpuRollingMean = h.defaultCPURollingMean
+	}
+	if !means.MemoryOK {
+		memoryRollingMean = h.defaultMemoryRollingMean
 	}
-	return h.defaultMemoryRollingMean
+	return cpuRollingMean, memoryRollingMean
 }
 
-// interpretMLPredictions converts model output to recommendations
+// interpretMLPredictions converts one scope's model output to recommendations
 // The model returns classification predictions (-1 = issue predicted, 1 = normal)
-// for each input instance based on the 4 features
-func (h *RecommendationsHandler) interpretMLPredictions(predictions []int, req *GetRecommendationsRequest, currentTime time.Time, instances [][]float64) []Recommendation {
+// for each input instance based on the 4 features, optionally with decision scores
+func (h *RecommendationsHandler) interpretMLPredictions(predictions []mlPrediction, req *GetRecommendationsRequest, scope mlScope, currentTime time.Time, instances [][]float64) []Recommendation {
 	recommendations := make([]Recommendation, 0)
 
-	cpuRollingMean := h.getCPURollingMean()
-	memoryRollingMean := h.getMemoryRollingMean()
+	cpuRollingMean, memoryRollingMean := scope.cpuRollingMean, scope.memoryRollingMean
 
 	// Process each prediction corresponding to each instance
 	for i, prediction := range predictions {
 		// Skip if model predicts normal state (1 = normal, -1 = issue predicted)
-		if prediction != -1 {
+		if prediction.label != -1 {
 			continue
 		}
 
-		predictedTime := currentTime.Add(getPredictionHorizon(req.Timeframe))
+		predictedTime := currentTime.Add(h.predictionHorizon(req.Timeframe))
 
 		// Determine issue type based on which metrics are elevated
 		var issueType string
@@ -467,21 +998,36 @@ func (h *RecommendationsHandler) interpretMLPredictions(predictions []int, req *
 			fmt.Sprintf("Features: hour=%d, day=%d, cpu_rolling=%.2f, memory_rolling=%.2f",
 				currentTime.Hour(), int(currentTime.Weekday()), cpuRollingMean, memoryRollingMean))
 
-		// Calculate confidence based on how elevated the metrics are
-		confidence := calculatePredictionConfidence(instanceCPU, instanceMem)
+		// Calculate confidence from the model's certainty, or how elevated the metrics are
+		confidence := h.mlConfidence(prediction, instanceCPU, instanceMem)
+		var score *float64
+		if prediction.scored {
+			score = &prediction.score
+		}
 
 		recommendations = append(recommendations, Recommendation{
-			ID:                 fmt.Sprintf("rec-ml-%03d", i+1),
+			ID:                 recommendationID("ml_prediction", issueType, scope.namespace, "cluster-resources"),
 			Type:               "proactive",
 			IssueType:          issueType,
 			Target:             "cluster-resources",
-			Namespace:          req.Namespace,
+			Namespace:          scope.namespace,
 			Severity:           severity,
 			Confidence:         confidence,
 			PredictedTime:      predictedTime.UTC().Format(time.RFC3339),
 			RecommendedActions: actions,
 			Evidence:           evidence,
-			Source:             "ml_prediction",
+			EvidenceData: &EvidenceData{Prediction: &PredictionEvidence{
+				ModelOutput:       prediction.label,
+				InstanceIndex:     i,
+				HourOfDay:         currentTime.Hour(),
+				DayOfWeek:         int(currentTime.Weekday()),
+				CPURollingMean:    cpuRollingMean,
+				MemoryRollingMean: memoryRollingMean,
+				InstanceCPU:       instanceCPU,
+				InstanceMemory:    instanceMem,
+				Score:             score,
+			}},
+			Source: "ml_prediction",
 		})
 	}
 
@@ -538,8 +1084,8 @@ func (h *RecommendationsHandler) getPatternRecommendations() []Recommendation {
 	}
 
 	// Generate recommendations for repeated failures
-	recID := 0
-	for key, count := range failurePatterns {
+	for _, key := range keysByCount(failurePatterns) {
+		count := failurePatterns[key]
 		if count < 2 {
 			continue
 		}
@@ -549,12 +1095,12 @@ func (h *RecommendationsHandler) getPatternRecommendations() []Recommendation {
 			continue
 		}
 
-		recID++
+		target := fmt.Sprintf("%s-workloads", namespace)
 		recommendations = append(recommendations, Recommendation{
-			ID:         fmt.Sprintf("rec-pattern-%03d", recID),
+			ID:         recommendationID("pattern_detection", issueType, namespace, target),
 			Type:       "reactive",
 			IssueType:  issueType,
-			Target:     fmt.Sprintf("%s-workloads", namespace),
+			Target:     target,
 			Namespace:  namespace,
 			Severity:   "high",
 			Confidence: 0.80,
@@ -567,13 +1113,27 @@ func (h *RecommendationsHandler) getPatternRecommendations() []Recommendation {
 				fmt.Sprintf("Remediation failed %d times for similar issues", count),
 				"Pattern suggests underlying infrastructure problem",
 			},
-			Source: "pattern_detection",
+			EvidenceData: &EvidenceData{Pattern: &PatternEvidence{FailedWorkflows: count}},
+			Source:       "pattern_detection",
 		})
 	}
 
 	return recommendations
 }
 
+// keysByCount returns the keys of counts by count descending, then key ascending, so
+// recommendations built from them come out in the same order for the same data
+func keysByCount[N int | float64](counts map[string]N) []string {
+	keys := slices.Collect(maps.Keys(counts))
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return keys
+}
+
 // parseKeyParts splits a "type:namespace" key into its components
 func parseKeyParts(key string) (issueType, namespace string) {
 	if key == "" {
@@ -588,13 +1148,15 @@ func parseKeyParts(key string) (issueType, namespace string) {
 
 // Helper functions
 
-func calculateHistoricalConfidence(count int) float64 {
+// calculateWeightedHistoricalConfidence maps a recency-weighted occurrence score to confidence.
+// A score equals the raw count when every occurrence is recent, so the tiers match mapCountToSeverity.
+func calculateWeightedHistoricalConfidence(score float64) float64 {
 	switch {
-	case count >= 10:
+	case score >= 10:
 		return 0.95
-	case count >= 5:
+	case score >= 5:
 		return 0.85
-	case count >= 3:
+	case score >= 3:
 		return 0.75
 	default:
 		return 0.65
@@ -654,17 +1216,13 @@ func getRecommendedActions(issueType string) []string {
 	}
 }
 
-func getPredictionHorizon(timeframe string) time.Duration {
-	switch timeframe {
-	case "1h":
-		return 30 * time.Minute
-	case "6h":
-		return 3 * time.Hour
-	case "24h":
-		return 12 * time.Hour
-	default:
-		return 3 * time.Hour
+// predictionHorizon returns how far past the request time an ML recommendation for timeframe
+// is predicted, falling back to the default 6h timeframe's horizon
+func (h *RecommendationsHandler) predictionHorizon(timeframe string) time.Duration {
+	if horizon, ok := h.predictionHorizons[timeframe]; ok {
+		return horizon
 	}
+	return h.predictionHorizons["6h"]
 }
 
 func interpretPrediction(instanceIndex int) string {
@@ -690,11 +1248,29 @@ func (h *RecommendationsHandler) respondJSON(w http.ResponseWriter, statusCode i
 	}
 }
 
+// handleError maps an error to a structured response: request errors are 400, service
+// errors 503, and anything else is reported as an internal error
+func (h *RecommendationsHandler) handleError(ctx context.Context, w http.ResponseWriter, err error) {
+	var reqErr *requestError
+	var svcErr *serviceError
+	switch {
+	case errors.As(err, &reqErr):
+		h.respondError(w, reqErr.statusCode(), reqErr.message, reqErr.details, reqErr.code)
+	case errors.As(err, &svcErr):
+		h.respondError(w, http.StatusServiceUnavailable, svcErr.message, svcErr.details, svcErr.code)
+	default:
+		h.log.WithContext(ctx).WithError(err).Error("Recommendations request failed")
+		h.respondError(w, http.StatusInternalServerError, "internal error", err.Error(), ErrCodeInternalError)
+	}
+}
+
 // respondError writes an error response
-func (h *RecommendationsHandler) respondError(w http.ResponseWriter, statusCode int, message string) {
-	response := map[string]interface{}{
-		"status": "error",
-		"error":  message,
+func (h *RecommendationsHandler) respondError(w http.ResponseWriter, statusCode int, message, details, code string) {
+	response := RecommendationsErrorResponse{
+		Status:  "error",
+		Error:   message,
+		Details: details,
+		Code:    code,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+// Acknowledgement durations
+const (
+	// DefaultRecommendationAckDuration suppresses a recommendation for a day when the request
+	// gives no duration
+	DefaultRecommendationAckDuration = 24 * time.Hour
+
+	// MaxRecommendationAckDuration bounds a single acknowledgement, so forgotten ones lapse
+	MaxRecommendationAckDuration = 30 * 24 * time.Hour
+)
+
+// ErrCodeAcknowledgementNotFound is returned when removing an acknowledgement that does not exist
+const ErrCodeAcknowledgementNotFound = "ACKNOWLEDGEMENT_NOT_FOUND"
+
+// recommendationIDPrefix starts every recommendation ID
+const recommendationIDPrefix = "rec-"
+
+// isRecommendationID reports whether id has the form recommendationID produces
+func isRecommendationID(id string) bool {
+	digest, ok := strings.CutPrefix(id, recommendationIDPrefix)
+	if !ok || len(digest) != 12 {
+		return false
+	}
+	_, err := hex.DecodeString(digest)
+	return err == nil && strings.ToLower(digest) == digest
+}
+
+// recommendationID derives a stable ID from what a recommendation is about, so the same issue
+// keeps its ID across requests and can be acknowledged
+func recommendationID(source, issueType, namespace, target string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{source, issueType, namespace, target}, "\x00")))
+	return recommendationIDPrefix + hex.EncodeToString(sum[:6])
+}
+
+// SetAckStore replaces the in-memory acknowledgement store, e.g. with one persisted to DATA_DIR,
+// and sets the duration of acknowledgements that give none (0 = DefaultRecommendationAckDuration).
+// Must be called before serving; a nil store is ignored.
+func (h *RecommendationsHandler) SetAckStore(store *storage.RecommendationAckStore, defaultDuration time.Duration) {
+	if store != nil {
+		h.ackStore = store
+	}
+	if defaultDuration > 0 {
+		h.ackDuration = defaultDuration
+	}
+}
+
+// activeAck returns the acknowledgement suppressing rec. A merged recommendation carries the ID
+// of its first source, so the IDs its other sources would have had are checked too.
+func (h *RecommendationsHandler) activeAck(rec *Recommendation, now time.Time) (*models.RecommendationAck, bool) {
+	if ack, ok := h.ackStore.Get(rec.ID, now); ok {
+		return ack, true
+	}
+	for _, source := range rec.Sources {
+		if ack, ok := h.ackStore.Get(recommendationID(source, rec.IssueType, rec.Namespace, rec.Target), now); ok {
+			return ack, true
+		}
+	}
+	return nil, false
+}
+
+// separateAcknowledged splits off the acknowledged recommendations, attaching their acknowledgement
+func (h *RecommendationsHandler) separateAcknowledged(recommendations []Recommendation, now time.Time) (open, acknowledged []Recommendation) {
+	open = make([]Recommendation, 0, len(recommendations))
+	for i := range recommendations {
+		rec := recommendations[i]
+		if ack, ok := h.activeAck(&rec, now); ok {
+			rec.Acknowledgement = ack
+			acknowledged = append(acknowledged, rec)
+			continue
+		}
+		open = append(open, rec)
+	}
+	return open, acknowledged
+}
+
+// AcknowledgeRecommendationRequest is the body of POST /api/v1/recommendations/{id}/acknowledge
+type AcknowledgeRecommendationRequest struct {
+	Duration       string `json:"duration"`        // Go duration such as "4h"; default 24h, at most 720h
+	AcknowledgedBy string `json:"acknowledged_by"` // Optional: who is acting on the recommendation
+	Comment        string `json:"comment"`         // Optional: why it is acknowledged or snoozed
+}
+
+// RecommendationAckResponse returns one acknowledgement
+type RecommendationAckResponse struct {
+	Status          string                    `json:"status"`
+	Acknowledgement *models.RecommendationAck `json:"acknowledgement"`
+
+	// Warning is set when the ID was not among the recently served recommendations, e.g. a
+	// typo or an ID from before a restart; the acknowledgement is stored either way
+	Warning string `json:"warning,omitempty"`
+}
+
+// RecommendationAcksResponse lists the active acknowledgements, soonest to expire first
+type RecommendationAcksResponse struct {
+	Status           string                      `json:"status"`
+	Count            int                         `json:"count"`
+	Acknowledgements []*models.RecommendationAck `json:"acknowledgements"`
+}
+
+// AcknowledgeRecommendation handles POST /api/v1/recommendations/{id}/acknowledge
+//
+// Suppresses the recommendation from GetRecommendations until the acknowledgement expires.
+// Acknowledging again replaces the previous acknowledgement, e.g. to extend a snooze. An ID
+// that was not served recently is still acknowledged, since served recommendations are not
+// kept across restarts, but the response carries a warning.
+func (h *RecommendationsHandler) AcknowledgeRecommendation(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	id := mux.Vars(r)["id"]
+	if !isRecommendationID(id) {
+		h.handleError(ctx, w, &requestError{message: "invalid recommendation id", details: fmt.Sprintf("got %q", id), code: ErrCodeInvalidRequest})
+		return
+	}
+
+	var req AcknowledgeRecommendationRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.handleError(ctx, w, decodeRequestError("invalid request body", err))
+			return
+		}
+	}
+
+	duration := h.ackDuration
+	if req.Duration != "" {
+		parsed, err := time.ParseDuration(req.Duration)
+		if err != nil || parsed <= 0 || parsed > MaxRecommendationAckDuration {
+			h.handleError(ctx, w, &requestError{
+				message: fmt.Sprintf("invalid duration: must be a positive duration up to %s", MaxRecommendationAckDuration),
+				details: fmt.Sprintf("got %q", req.Duration),
+				code:    ErrCodeInvalidRequest,
+			})
+			return
+		}
+		duration = parsed
+	}
+
+	now := time.Now().UTC()
+	ack := &models.RecommendationAck{
+		RecommendationID: id,
+		AcknowledgedBy:   req.AcknowledgedBy,
+		Comment:          req.Comment,
+		CreatedAt:        now,
+		ExpiresAt:        now.Add(duration),
+	}
+	if err := h.ackStore.Acknowledge(ack); err != nil {
+		h.handleError(ctx, w, fmt.Errorf("failed to store acknowledgement: %w", err))
+		return
+	}
+
+	resp := RecommendationAckResponse{Status: "success", Acknowledgement: ack}
+	if !h.served.contains(id, now) {
+		resp.Warning = fmt.Sprintf("%q was not returned by POST /api/v1/recommendations in the last %s; check the ID",
+			id, servedRecommendationTTL)
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"recommendation_id": id,
+		"acknowledged_by":   req.AcknowledgedBy,
+		"expires_at":        ack.ExpiresAt.Format(time.RFC3339),
+		"served":            resp.Warning == "",
+	}).Info("Recommendation acknowledged")
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// RemoveRecommendationAck handles DELETE /api/v1/recommendations/{id}/acknowledge
+//
+// Lifts an acknowledgement early, so the recommendation is returned again.
+func (h *RecommendationsHandler) RemoveRecommendationAck(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	id := mux.Vars(r)["id"]
+
+	removed, err := h.ackStore.Remove(id)
+	if err != nil {
+		h.handleError(ctx, w, fmt.Errorf("failed to remove acknowledgement: %w", err))
+		return
+	}
+	if !removed {
+		h.handleError(ctx, w, &requestError{
+			message: "recommendation is not acknowledged",
+			details: fmt.Sprintf("no active acknowledgement for %q", id),
+			code:    ErrCodeAcknowledgementNotFound,
+			status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	h.log.WithContext(ctx).WithField("recommendation_id", id).Info("Recommendation acknowledgement removed")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListRecommendationAcks handles GET /api/v1/recommendations/acknowledgements
+func (h *RecommendationsHandler) ListRecommendationAcks(w http.ResponseWriter, r *http.Request) {
+	acks := h.ackStore.List(time.Now())
+	h.respondJSON(w, http.StatusOK, RecommendationAcksResponse{
+		Status:           "success",
+		Count:            len(acks),
+		Acknowledgements: acks,
+	})
+}
@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func TestRecommendationID(t *testing.T) {
+	id := recommendationID("historical_analysis", "high", "payments", "payments")
+	assert.Equal(t, id, recommendationID("historical_analysis", "high", "payments", "payments"), "stable across calls")
+	assert.Regexp(t, `^rec-[0-9a-f]{12}$`, id)
+	assert.NotEqual(t, id, recommendationID("pattern_detection", "high", "payments", "payments"))
+	assert.NotEqual(t, id, recommendationID("historical_analysis", "high", "payment", "spayments"), "fields do not run together")
+}
+
+func TestRecommendationsHandler_Acknowledge(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	for range 3 {
+		_, err := store.Create(&models.Incident{Title: "OOM", Description: "Container OOM killed", Severity: models.IncidentSeverityHigh, Target: "payments"})
+		require.NoError(t, err)
+	}
+	handler := NewRecommendationsHandler(nil, store, nil, log)
+	handler.SetAckStore(storage.NewRecommendationAckStore(), 0)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/recommendations", handler.GetRecommendations).Methods("POST")
+	router.HandleFunc("/api/v1/recommendations/acknowledgements", handler.ListRecommendationAcks).Methods("GET")
+	router.HandleFunc("/api/v1/recommendations/{id}/acknowledge", handler.AcknowledgeRecommendation).Methods("POST")
+	router.HandleFunc("/api/v1/recommendations/{id}/acknowledge", handler.RemoveRecommendationAck).Methods("DELETE")
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+	get := func(body string) GetRecommendationsResponse {
+		t.Helper()
+		w := serve("POST", "/api/v1/recommendations", body)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp GetRecommendationsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	resp := get(`{"confidence_threshold": 0.5}`)
+	require.Len(t, resp.Recommendations, 1)
+	id := resp.Recommendations[0].ID
+	assert.Equal(t, id, get(`{"confidence_threshold": 0.5}`).Recommendations[0].ID, "IDs are stable between requests")
+
+	w := serve("POST", "/api/v1/recommendations/"+id+"/acknowledge", `{"duration": "4h", "acknowledged_by": "oncall", "comment": "scaling up"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ackResp RecommendationAckResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ackResp))
+	assert.Equal(t, id, ackResp.Acknowledgement.RecommendationID)
+	assert.Equal(t, "4h0m0s", ackResp.Acknowledgement.ExpiresAt.Sub(ackResp.Acknowledgement.CreatedAt).String())
+	assert.Empty(t, ackResp.Warning, "the recommendation was served")
+
+	resp = get(`{"confidence_threshold": 0.5}`)
+	assert.Empty(t, resp.Recommendations)
+	assert.Zero(t, resp.TotalRecommendations)
+	assert.Empty(t, resp.Acknowledged, "acknowledged recommendations are opt-in")
+
+	resp = get(`{"confidence_threshold": 0.5, "include_acknowledged": true}`)
+	require.Len(t, resp.Acknowledged, 1)
+	require.NotNil(t, resp.Acknowledged[0].Acknowledgement)
+	assert.Equal(t, "oncall", resp.Acknowledged[0].Acknowledgement.AcknowledgedBy)
+
+	w = serve("GET", "/api/v1/recommendations/acknowledgements", "")
+	var list RecommendationAcksResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Equal(t, 1, list.Count)
+
+	assert.Equal(t, http.StatusNoContent, serve("DELETE", "/api/v1/recommendations/"+id+"/acknowledge", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve("DELETE", "/api/v1/recommendations/"+id+"/acknowledge", "").Code)
+	assert.Len(t, get(`{"confidence_threshold": 0.5}`).Recommendations, 1)
+
+	for _, body := range []string{`{"duration": "soon"}`, `{"duration": "-1h"}`, `{"duration": "721h"}`} {
+		assert.Equal(t, http.StatusBadRequest, serve("POST", "/api/v1/recommendations/"+id+"/acknowledge", body).Code, body)
+	}
+	for _, bad := range []string{"incident-1", "rec-", "rec-123", "rec-ABCDEF012345", "rec-zzzzzzzzzzzz", "rec-0123456789abcd"} {
+		assert.Equal(t, http.StatusBadRequest, serve("POST", "/api/v1/recommendations/"+bad+"/acknowledge", "").Code, bad)
+	}
+
+	w = serve("POST", "/api/v1/recommendations/rec-0123456789ab/acknowledge", "")
+	require.Equal(t, http.StatusOK, w.Code, "unserved IDs are acknowledged")
+	ackResp = RecommendationAckResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ackResp))
+	assert.Contains(t, ackResp.Warning, "rec-0123456789ab")
+}
+
+// TestRecommendationsHandler_AcknowledgeMerged verifies acknowledging the ID a source reported
+// suppresses the recommendation after it was merged under another source's ID
+func TestRecommendationsHandler_AcknowledgeMerged(t *testing.T) {
+	handler := NewRecommendationsHandler(nil, storage.NewIncidentStore(), nil, logrus.New())
+	merged := mergeRecommendations([]Recommendation{
+		{ID: recommendationID("historical_analysis", "memory_pressure", "prod", "prod"), IssueType: "memory_pressure", Namespace: "prod", Target: "prod", Source: "historical_analysis"},
+		{ID: recommendationID("ml_prediction", "memory_pressure", "prod", "prod"), IssueType: "memory_pressure", Namespace: "prod", Target: "prod", Source: "ml_prediction"},
+	})
+	require.Len(t, merged, 1)
+	handler.served.remember(time.Now(), merged)
+
+	rec := httptest.NewRecorder()
+	req := mux.SetURLVars(httptest.NewRequest("POST", "/", nil), map[string]string{"id": recommendationID("ml_prediction", "memory_pressure", "prod", "prod")})
+	handler.AcknowledgeRecommendation(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp RecommendationAckResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Empty(t, resp.Warning, "a merged source's ID counts as served")
+
+	open, acknowledged := handler.separateAcknowledged(merged, time.Now())
+	assert.Empty(t, open)
+	assert.Len(t, acknowledged, 1)
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// Defaults for batching the recommendations ML path when SetMLBatching is not called
+const (
+	// DefaultMLBatchSize is the most instances sent in one Predict call
+	DefaultMLBatchSize = 32
+
+	// DefaultMLConcurrency is the most Predict calls (and rolling mean lookups) in flight at once
+	DefaultMLConcurrency = 4
+
+	// DefaultMLConfidenceFloor is the lowest confidence an ML recommendation reports; it equals
+	// the lowest score-derived confidence, so by default no confidence is raised
+	DefaultMLConfidenceFloor = anomalyMinConfidence
+)
+
+// maxMLNamespaces bounds the namespaces one request may ask ML predictions for
+const maxMLNamespaces = 100
+
+// mlScope is one namespace (empty = cluster) predicted by getMLPredictions, with the rolling
+// means its instances are built from
+type mlScope struct {
+	namespace         string
+	cpuRollingMean    float64
+	memoryRollingMean float64
+
+	// offset is the position of the scope's first instance in the batched request
+	offset int
+}
+
+// mlPrediction is the model's output for one instance
+type mlPrediction struct {
+	label int // -1 = issue predicted, 1 = normal
+
+	// score is the model's decision score, set when scored; its magnitude reflects certainty
+	score  float64
+	scored bool
+}
+
+// SetMLConfidenceFloor sets the lowest confidence an ML recommendation reports; derived
+// confidences below it are raised to it. Values outside [0, 1] restore the default.
+func (h *RecommendationsHandler) SetMLConfidenceFloor(floor float64) {
+	if floor < 0 || floor > 1 {
+		floor = DefaultMLConfidenceFloor
+	}
+	h.mlConfidenceFloor = floor
+}
+
+// mlConfidence derives an ML recommendation's confidence from the model's decision score when
+// it reported one, like prediction confidence, else from how elevated the instance's metrics
+// are, and raises it to the configured floor
+func (h *RecommendationsHandler) mlConfidence(prediction mlPrediction, instanceCPU, instanceMem float64) float64 {
+	confidence := calculatePredictionConfidence(instanceCPU, instanceMem)
+	if prediction.scored {
+		confidence = anomalyScoreConfidence(math.Min(math.Abs(prediction.score)/anomalyScoreScale, 1))
+	}
+	return max(confidence, h.mlConfidenceFloor)
+}
+
+// SetModelTimeouts overrides the KServe client timeout per model, capped at
+// kserve.MaxRequestTimeout. Must be called before serving.
+func (h *RecommendationsHandler) SetModelTimeouts(timeouts map[string]time.Duration) {
+	h.modelTimeouts = maps.Clone(timeouts)
+}
+
+// SetMLBatching sets how ML predictions for many namespaces are sent to the model: at most
+// batchSize instances per Predict call and at most concurrency calls at once. Use a batch size
+// of 1 for models that do not accept batches. Non-positive values restore the defaults.
+func (h *RecommendationsHandler) SetMLBatching(batchSize, concurrency int) {
+	if batchSize <= 0 {
+		batchSize = DefaultMLBatchSize
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultMLConcurrency
+	}
+	h.mlBatchSize = batchSize
+	h.mlConcurrency = concurrency
+}
+
+// mlNamespaces returns the namespaces req asks ML predictions for: ml_namespaces, else the
+// namespace filter, else the cluster as a whole ("")
+func mlNamespaces(req *GetRecommendationsRequest) []string {
+	if len(req.MLNamespaces) > 0 {
+		return req.MLNamespaces
+	}
+	return []string{req.Namespace}
+}
+
+// validateMLNamespaces checks that ml_namespaces holds at most maxMLNamespaces distinct
+// namespace names
+func validateMLNamespaces(namespaces []string) error {
+	if len(namespaces) > maxMLNamespaces {
+		return &requestError{
+			message: fmt.Sprintf("invalid ml_namespaces: at most %d namespaces", maxMLNamespaces),
+			details: fmt.Sprintf("got %d", len(namespaces)),
+			code:    ErrCodeInvalidRequest,
+		}
+	}
+	seen := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		err := features.ValidateScopeIdentifiers(namespace, "", "")
+		if namespace == "" {
+			err = fmt.Errorf("namespace must not be empty")
+		}
+		if err == nil && seen[namespace] {
+			err = fmt.Errorf("namespace %q is listed twice", namespace)
+		}
+		if err != nil {
+			return &requestError{message: "invalid ml_namespaces", details: err.Error(), code: ErrCodeInvalidRequest}
+		}
+		seen[namespace] = true
+	}
+	return nil
+}
+
+// mlScopes looks up the rolling means of every namespace req predicts, at most mlConcurrency
+// lookups at a time
+func (h *RecommendationsHandler) mlScopes(ctx context.Context, req *GetRecommendationsRequest) []mlScope {
+	namespaces := mlNamespaces(req)
+	scopes := make([]mlScope, len(namespaces))
+	sem := make(chan struct{}, h.mlConcurrency)
+	var wg sync.WaitGroup
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			cpuRollingMean, memoryRollingMean := h.getRollingMeans(ctx, namespace)
+			scopes[i] = mlScope{namespace: namespace, cpuRollingMean: cpuRollingMean, memoryRollingMean: memoryRollingMean}
+		}()
+	}
+	wg.Wait()
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"namespaces":         len(scopes),
+		"prometheus_enabled": h.prometheusClient.IsAvailable() || h.metricsSnapshot.IsAvailable(),
+	}).Debug("Retrieved rolling mean metrics")
+	return scopes
+}
+
+// predictBatched sends instances to model in batches of at most mlBatchSize, at most
+// mlConcurrency calls at a time, and returns the predictions in instance order. Predictions are
+// matched to instances by position, so a batch answered with a different count fails. Decision
+// scores are kept only when the batch returned a finite one for every instance.
+func (h *RecommendationsHandler) predictBatched(ctx context.Context, model string, instances [][]float64) ([]mlPrediction, error) {
+	predictions := make([]mlPrediction, len(instances))
+	batches := (len(instances) + h.mlBatchSize - 1) / h.mlBatchSize
+	errs := make([]error, batches)
+	sem := make(chan struct{}, h.mlConcurrency)
+	var wg sync.WaitGroup
+	for batch := range batches {
+		start := batch * h.mlBatchSize
+		end := min(start+h.mlBatchSize, len(instances))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			resp, err := h.kserveClient.Predict(kserve.WithRequestTimeout(ctx, h.modelTimeouts[model]), model, instances[start:end])
+			switch {
+			case err != nil:
+				errs[batch] = err
+			case len(resp.Predictions) != end-start:
+				errs[batch] = fmt.Errorf("model returned %d predictions for %d instances", len(resp.Predictions), end-start)
+			default:
+				scored := len(resp.Scores) == end-start
+				for _, score := range resp.Scores {
+					scored = scored && !math.IsNaN(score) && !math.IsInf(score, 0)
+				}
+				for i, label := range resp.Predictions {
+					predictions[start+i] = mlPrediction{label: label}
+					if scored {
+						predictions[start+i].score, predictions[start+i].scored = resp.Scores[i], true
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for batch, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("batch %d of %d: %w", batch+1, batches, err)
+		}
+	}
+
+	if batches > 1 {
+		h.log.WithContext(ctx).WithFields(logrus.Fields{
+			"instances":  len(instances),
+			"batches":    batches,
+			"batch_size": h.mlBatchSize,
+		}).Debug("Split ML predictions into batches")
+	}
+	return predictions, nil
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+)
+
+// batchingModelClient answers Predict per instance, flagging instances whose memory rolling
+// mean is at least 0.8, and records the size of every call
+type batchingModelClient struct {
+	fakeModelClient
+	truncate bool // answer one prediction short
+
+	mu      sync.Mutex
+	batches []int
+}
+
+func (c *batchingModelClient) Predict(_ context.Context, _ string, instances [][]float64) (*kserve.DetectResponse, error) {
+	c.mu.Lock()
+	c.batches = append(c.batches, len(instances))
+	c.mu.Unlock()
+
+	predictions := make([]int, len(instances))
+	for i, instance := range instances {
+		predictions[i] = 1
+		if instance[3] >= 0.8 {
+			predictions[i] = -1
+		}
+	}
+	if c.truncate {
+		predictions = predictions[1:]
+	}
+	return &kserve.DetectResponse{Predictions: predictions}, nil
+}
+
+func TestRecommendationsHandler_GetMLPredictions_Batched(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	namespaces := []string{"team-a", "team-b", "team-c", "team-d", "team-e"}
+
+	client := &batchingModelClient{fakeModelClient: fakeModelClient{models: map[string]bool{"predictive-analytics": true}}}
+	handler := NewRecommendationsHandler(nil, nil, client, log)
+	handler.SetMLBatching(4, 2)
+
+	recs, err := handler.getMLPredictions(context.Background(), &GetRecommendationsRequest{Timeframe: "6h", MLNamespaces: namespaces})
+	require.NoError(t, err)
+
+	slices.Sort(client.batches)
+	assert.Equal(t, []int{2, 4, 4}, client.batches, "10 instances in batches of at most 4")
+
+	// Only the elevated scenario (default 72% memory * 1.15) of each namespace crosses 80%
+	require.Len(t, recs, len(namespaces))
+	for i, rec := range recs {
+		assert.Equal(t, namespaces[i], rec.Namespace, "predictions map back to their namespace")
+		assert.Equal(t, "memory_pressure", rec.IssueType)
+		assert.Equal(t, 1, rec.EvidenceData.Prediction.InstanceIndex)
+	}
+	assert.NotEqual(t, recs[0].ID, recs[1].ID)
+
+	t.Run("default scope is the namespace filter", func(t *testing.T) {
+		client.batches = nil
+		recs, err := handler.getMLPredictions(context.Background(), &GetRecommendationsRequest{Timeframe: "6h", Namespace: "payments"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, client.batches)
+		require.Len(t, recs, 1)
+		assert.Equal(t, "payments", recs[0].Namespace)
+	})
+
+	t.Run("prediction count mismatch", func(t *testing.T) {
+		client.truncate = true
+		defer func() { client.truncate = false }()
+		_, err := handler.getMLPredictions(context.Background(), &GetRecommendationsRequest{Timeframe: "6h", MLNamespaces: namespaces})
+		assert.ErrorContains(t, err, "ML predictions unavailable")
+	})
+}
+
+// TestRecommendationsHandler_GetMLPredictions_ModelTimeout verifies ML predictions use the
+// model's timeout instead of the client's
+func TestRecommendationsHandler_GetMLPredictions_ModelTimeout(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, nil, newSlowProxyClient(t, "predictive-analytics"), log)
+	handler.SetModelTimeouts(map[string]time.Duration{"predictive-analytics": 50 * time.Millisecond})
+
+	start := time.Now()
+	_, err := handler.getMLPredictions(context.Background(), &GetRecommendationsRequest{Timeframe: "6h", Namespace: "payments"})
+	assert.ErrorContains(t, err, "ML predictions unavailable")
+	assert.Less(t, time.Since(start), 2*time.Second, "the model timeout applies, not the 30s client timeout")
+}
+
+func TestRecommendationsHandler_MLNamespacesValidation(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, storage.NewIncidentStore(), nil, log)
+
+	tooMany := `{"ml_namespaces": [`
+	for i := range maxMLNamespaces + 1 {
+		if i > 0 {
+			tooMany += ","
+		}
+		tooMany += `"ns"`
+	}
+	tooMany += `]}`
+
+	for _, body := range []string{
+		`{"ml_namespaces": ["Not_Valid"]}`,
+		`{"ml_namespaces": [""]}`,
+		`{"ml_namespaces": ["team-a", "team-a"]}`,
+		tooMany,
+	} {
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		assert.Contains(t, w.Body.String(), "ml_namespaces")
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(`{"ml_namespaces": ["team-a", "team-b"]}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRecommendationsHandler_MLConfidence(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, nil, nil, log)
+
+	now := time.Date(2026, 1, 12, 14, 0, 0, 0, time.UTC)
+	instances := [][]float64{{14, 1, 0.5, 0.6}, {14, 1, 0.575, 0.69}}
+	scope := mlScope{cpuRollingMean: 0.5, memoryRollingMean: 0.6}
+	req := &GetRecommendationsRequest{Timeframe: "6h"}
+
+	t.Run("from metrics without scores", func(t *testing.T) {
+		recs := handler.interpretMLPredictions([]mlPrediction{{label: 1}, {label: -1}}, req, scope, now, instances)
+		require.Len(t, recs, 1)
+		assert.InDelta(t, calculatePredictionConfidence(0.575, 0.69), recs[0].Confidence, 1e-9)
+	})
+
+	t.Run("from decision scores", func(t *testing.T) {
+		recs := handler.interpretMLPredictions([]mlPrediction{{label: 1}, {label: -1, score: -0.25, scored: true}}, req, scope, now, instances)
+		require.Len(t, recs, 1)
+		assert.InDelta(t, anomalyScoreConfidence(0.5), recs[0].Confidence, 1e-9)
+		require.NotNil(t, recs[0].EvidenceData.Prediction.Score)
+		assert.InDelta(t, -0.25, *recs[0].EvidenceData.Prediction.Score, 1e-9)
+	})
+
+	t.Run("raised to the floor", func(t *testing.T) {
+		floored := NewRecommendationsHandler(nil, nil, nil, log)
+		floored.SetMLConfidenceFloor(0.9)
+		recs := floored.interpretMLPredictions([]mlPrediction{{label: 1}, {label: -1, score: -0.01, scored: true}}, req, scope, now, instances)
+		require.Len(t, recs, 1)
+		assert.InDelta(t, 0.9, recs[0].Confidence, 1e-9)
+
+		floored.SetMLConfidenceFloor(1.5)
+		assert.InDelta(t, DefaultMLConfidenceFloor, floored.mlConfidenceFloor, 1e-9, "out of range restores the default")
+	})
+}
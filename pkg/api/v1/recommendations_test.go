@@ -2,7 +2,10 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -12,12 +15,38 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
 	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/kserve"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
 	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
 )
 
+func TestRecommendationsHandler_GetRecommendations_RequestID(t *testing.T) {
+	var logBuf bytes.Buffer
+	log := logrus.New()
+	log.SetLevel(logrus.InfoLevel)
+	log.SetOutput(&logBuf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.AddHook(middleware.NewRequestIDHook())
+
+	handler := NewRecommendationsHandler(nil, storage.NewIncidentStore(), nil, log)
+
+	req := httptest.NewRequest("POST", "/api/v1/recommendations", http.NoBody)
+	req.Header.Set(middleware.RequestIDHeader, "recs-req-456")
+	w := httptest.NewRecorder()
+
+	handler.GetRecommendations(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "recs-req-456", w.Header().Get(middleware.RequestIDHeader))
+	assert.Contains(t, logBuf.String(), `"request_id":"recs-req-456"`)
+}
+
 func TestRecommendationsHandler_GetRecommendations(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -100,6 +129,8 @@ func TestRecommendationsHandler_GetRecommendations(t *testing.T) {
 		json.NewDecoder(w.Body).Decode(&resp)
 		assert.Equal(t, "error", resp["status"])
 		assert.Contains(t, resp["error"], "invalid timeframe")
+		assert.Equal(t, ErrCodeInvalidTimeframe, resp["code"])
+		assert.Equal(t, `got "2h"`, resp["details"])
 	})
 
 	t.Run("invalid confidence threshold - too high", func(t *testing.T) {
@@ -115,6 +146,7 @@ func TestRecommendationsHandler_GetRecommendations(t *testing.T) {
 		var resp map[string]interface{}
 		json.NewDecoder(w.Body).Decode(&resp)
 		assert.Contains(t, resp["error"], "confidence_threshold")
+		assert.Equal(t, ErrCodeInvalidConfidence, resp["code"])
 	})
 
 	t.Run("invalid confidence threshold - negative", func(t *testing.T) {
@@ -137,6 +169,11 @@ func TestRecommendationsHandler_GetRecommendations(t *testing.T) {
 		handler.GetRecommendations(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp RecommendationsErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, ErrCodeInvalidRequest, resp.Code)
+		assert.NotEmpty(t, resp.Details)
 	})
 
 	t.Run("high confidence threshold filters all", func(t *testing.T) {
@@ -286,6 +323,36 @@ func TestRecommendationsHandler_HistoricalRecommendations(t *testing.T) {
 			assert.LessOrEqual(t, rec.Confidence, 1.0)
 		}
 	})
+
+	t.Run("incident labels restrict the analyzed incidents", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := incidentStore.Create(&models.Incident{
+				Title:       "Checkout latency",
+				Description: "Latency above SLO",
+				Severity:    models.IncidentSeverityMedium,
+				Target:      "checkout",
+				Labels:      map[string]string{"team": "payments"},
+			})
+			require.NoError(t, err)
+		}
+
+		reqBody := `{"confidence_threshold": 0.1, "incident_labels": {"team": "payments"}}`
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(reqBody)))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp GetRecommendationsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.NotEmpty(t, resp.Recommendations)
+		for _, rec := range resp.Recommendations {
+			assert.Equal(t, "checkout", rec.Namespace)
+		}
+
+		w = httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations",
+			bytes.NewBufferString(`{"incident_labels": {"bad key": "x"}}`)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestRecommendationsHandler_NamespaceFiltering(t *testing.T) {
@@ -355,6 +422,113 @@ func TestRecommendationsHandler_NamespaceFiltering(t *testing.T) {
 	})
 }
 
+// TestRecommendationsHandler_NearMisses verifies recommendations just below the threshold are
+// only returned, flagged, when requested
+func TestRecommendationsHandler_NearMisses(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, nil, nil, log)
+
+	recommendations := []Recommendation{
+		{ID: "above", Namespace: "production", Confidence: 0.75},
+		{ID: "near", Namespace: "production", Confidence: 0.69},
+		{ID: "far", Namespace: "production", Confidence: 0.5},
+		{ID: "other-namespace", Namespace: "staging", Confidence: 0.68},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		filtered, nearMisses := handler.filterRecommendations(recommendations,
+			&GetRecommendationsRequest{ConfidenceThreshold: 0.7, Namespace: "production"})
+		require.Len(t, filtered, 1)
+		assert.Equal(t, "above", filtered[0].ID)
+		assert.Empty(t, nearMisses)
+	})
+
+	t.Run("returns near-misses within the margin", func(t *testing.T) {
+		filtered, nearMisses := handler.filterRecommendations(recommendations, &GetRecommendationsRequest{
+			ConfidenceThreshold: 0.7, Namespace: "production", IncludeNearMisses: true, NearMissMargin: 0.1,
+		})
+		require.Len(t, filtered, 1)
+		assert.False(t, filtered[0].NearMiss)
+
+		require.Len(t, nearMisses, 1)
+		assert.Equal(t, "near", nearMisses[0].ID)
+		assert.True(t, nearMisses[0].NearMiss)
+		assert.InDelta(t, 0.01, nearMisses[0].ConfidenceShortfall, 0.0001)
+	})
+
+	t.Run("request defaults and validation", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(`{"include_near_misses": true}`))
+		parsed, err := handler.parseAndValidateRequest(req)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultNearMissMargin, parsed.NearMissMargin)
+
+		req = httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(`{"include_near_misses": true, "near_miss_margin": 1.5}`))
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var resp RecommendationsErrorResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, ErrCodeInvalidConfidence, resp.Code)
+	})
+}
+
+func TestRecommendationsHandler_MaxRecommendations(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	// 2, 5 and 3 recurring incidents: low, high and medium severity recommendations
+	store := storage.NewIncidentStore()
+	for target, count := range map[string]int{"alpha": 2, "beta": 5, "gamma": 3} {
+		for range count {
+			_, err := store.Create(&models.Incident{Title: "OOM", Description: "Container OOM killed", Severity: models.IncidentSeverityHigh, Target: target})
+			require.NoError(t, err)
+		}
+	}
+	handler := NewRecommendationsHandler(nil, store, nil, log)
+	handler.SetMaxRecommendations(2)
+
+	get := func(body string) GetRecommendationsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp GetRecommendationsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	resp := get(`{"confidence_threshold": 0.5}`)
+	assert.Equal(t, 3, resp.TotalRecommendations, "total counts recommendations before the cap")
+	assert.True(t, resp.Truncated)
+	require.Len(t, resp.Recommendations, 2)
+	assert.Equal(t, "beta", resp.Recommendations[0].Namespace, "most severe first")
+	assert.Equal(t, "gamma", resp.Recommendations[1].Namespace)
+
+	resp = get(`{"confidence_threshold": 0.5, "max_recommendations": 1}`)
+	require.Len(t, resp.Recommendations, 1)
+	assert.Equal(t, "beta", resp.Recommendations[0].Namespace)
+
+	resp = get(`{"confidence_threshold": 0.5, "max_recommendations": 10}`)
+	assert.Len(t, resp.Recommendations, 2, "requests cannot raise the server limit")
+
+	// Near-misses are capped too, which also marks the response truncated
+	resp = get(`{"confidence_threshold": 0.9, "include_near_misses": true, "near_miss_margin": 0.3}`)
+	assert.Empty(t, resp.Recommendations)
+	assert.Len(t, resp.BelowThreshold, 2)
+	assert.True(t, resp.Truncated)
+
+	handler.SetMaxRecommendations(0)
+	resp = get(`{"confidence_threshold": 0.5}`)
+	assert.Len(t, resp.Recommendations, 3)
+	assert.False(t, resp.Truncated)
+
+	w := httptest.NewRecorder()
+	handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(`{"max_recommendations": -1}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestRecommendation_Structure(t *testing.T) {
 	rec := Recommendation{
 		ID:            "rec-001",
@@ -397,6 +571,61 @@ func TestRecommendation_Structure(t *testing.T) {
 	assert.Equal(t, rec.RelatedIncidentID, decoded.RelatedIncidentID)
 }
 
+func TestMergeRecommendations(t *testing.T) {
+	recs := []Recommendation{
+		{
+			ID: "rec-hist-001", IssueType: "memory_pressure", Namespace: "prod", Target: "prod",
+			Severity: "medium", Confidence: 0.75, Source: "historical_analysis",
+			RecommendedActions: []string{"increase_memory_limit"},
+			Evidence:           []string{"Issue occurred 3 times in recent history"},
+		},
+		{
+			ID: "rec-pattern-001", IssueType: "pod_crash_loop", Namespace: "prod", Target: "prod",
+			Severity: "high", Confidence: 0.8, Source: "pattern_detection",
+		},
+		{
+			ID: "rec-ml-001", IssueType: "memory_pressure", Namespace: "prod", Target: "prod",
+			Severity: "critical", Confidence: 0.9, Source: "ml_prediction",
+			PredictedTime:      "2026-01-11T19:30:00Z",
+			RecommendedActions: []string{"increase_memory_limit", "add_horizontal_scaling"},
+			Evidence:           []string{"ML model predicts memory pressure within 6h"},
+		},
+	}
+
+	merged := mergeRecommendations(recs)
+	require.Len(t, merged, 2)
+
+	rec := merged[0]
+	assert.Equal(t, "rec-hist-001", rec.ID)
+	assert.Equal(t, "critical", rec.Severity)
+	assert.Equal(t, 0.9, rec.Confidence)
+	assert.Equal(t, "2026-01-11T19:30:00Z", rec.PredictedTime)
+	assert.Equal(t, "historical_analysis", rec.Source)
+	assert.Equal(t, []string{"historical_analysis", "ml_prediction"}, rec.Sources)
+	assert.Equal(t, []string{"increase_memory_limit", "add_horizontal_scaling"}, rec.RecommendedActions)
+	assert.Equal(t, []string{
+		"Issue occurred 3 times in recent history",
+		"ML model predicts memory pressure within 6h",
+	}, rec.Evidence)
+
+	// A recommendation from a single source is left as is
+	assert.Equal(t, "rec-pattern-001", merged[1].ID)
+	assert.Nil(t, merged[1].Sources)
+
+	// Evidence data sections of every source are kept
+	recs[0].EvidenceData = &EvidenceData{Historical: &HistoricalEvidence{OccurrenceCount: 3}}
+	recs[2].EvidenceData = &EvidenceData{Prediction: &PredictionEvidence{ModelOutput: -1}}
+	data := mergeRecommendations(recs)[0].EvidenceData
+	require.NotNil(t, data)
+	assert.Equal(t, 3, data.Historical.OccurrenceCount)
+	assert.Equal(t, -1, data.Prediction.ModelOutput)
+	assert.Nil(t, recs[0].EvidenceData.Prediction, "merging must not modify its input")
+
+	// Different targets are not merged
+	recs[2].Target = "cluster-resources"
+	assert.Len(t, mergeRecommendations(recs), 3)
+}
+
 func TestGetRecommendationsResponse_Structure(t *testing.T) {
 	resp := GetRecommendationsResponse{
 		Status:    "success",
@@ -429,15 +658,300 @@ func TestGetRecommendationsResponse_Structure(t *testing.T) {
 	assert.Len(t, decoded.Recommendations, 1)
 }
 
+// TestRecommendationsHandler_HistoricalRecencyWeighting verifies old incidents decay or drop out
+func TestRecommendationsHandler_HistoricalDeterministicOrder(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	counts := map[string]int{"payments": 2, "checkout": 4, "billing": 2, "search": 3}
+	for target, count := range counts {
+		for range count {
+			_, err := store.Create(&models.Incident{Title: "OOM", Description: "Container OOM killed", Severity: models.IncidentSeverityHigh, Target: target})
+			require.NoError(t, err)
+		}
+	}
+	handler := NewRecommendationsHandler(nil, store, nil, log)
+
+	ids := func() (ids, targets []string) {
+		for _, rec := range handler.getHistoricalRecommendations(&GetRecommendationsRequest{}) {
+			ids = append(ids, rec.ID)
+			targets = append(targets, rec.Target)
+		}
+		return ids, targets
+	}
+
+	first, targets := ids()
+	assert.Equal(t, []string{"checkout", "search", "billing", "payments"}, targets, "count descending, then key")
+	assert.Equal(t, recommendationID("historical_analysis", "high", "checkout", "checkout"), first[0])
+	for range 10 {
+		again, _ := ids()
+		assert.Equal(t, first, again, "identical requests give identical IDs in the same order")
+	}
+}
+
+func TestKeysByCount(t *testing.T) {
+	assert.Equal(t, []string{"c", "a", "b"}, keysByCount(map[string]int{"a": 1, "b": 1, "c": 2}))
+	assert.Empty(t, keysByCount[int](nil))
+}
+
+func TestRecommendationsHandler_HistoricalRecencyWeighting(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	createAged := func(store *storage.IncidentStore, target string, age time.Duration) {
+		created, err := store.Create(&models.Incident{
+			Title:       "Memory pressure incident",
+			Description: "Memory usage above limits",
+			Severity:    models.IncidentSeverityHigh,
+			Target:      target,
+		})
+		require.NoError(t, err)
+		stored, err := store.Get(created.ID)
+		require.NoError(t, err)
+		aged := *stored
+		aged.CreatedAt = time.Now().Add(-age)
+		require.NoError(t, store.Update(&aged))
+	}
+
+	t.Run("recent burst keeps high confidence", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		// An hour of decay leaves each sighting just under full weight, so ten would score below 10
+		for i := 0; i < 12; i++ {
+			createAged(store, "production", time.Hour)
+		}
+		handler := NewRecommendationsHandler(nil, store, nil, log)
+
+		recs := handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		require.Len(t, recs, 1)
+		assert.Equal(t, 0.95, recs[0].Confidence)
+	})
+
+	t.Run("old burst decays to low confidence", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		for i := 0; i < 10; i++ {
+			createAged(store, "production", 60*24*time.Hour)
+		}
+		handler := NewRecommendationsHandler(nil, store, nil, log)
+
+		recs := handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		require.Len(t, recs, 1)
+		assert.Equal(t, 0.65, recs[0].Confidence, "60 day old incidents should carry little weight")
+		assert.Equal(t, "critical", recs[0].Severity)
+	})
+
+	t.Run("incidents past the cutoff are excluded", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		for i := 0; i < 10; i++ {
+			createAged(store, "production", 100*24*time.Hour)
+		}
+		createAged(store, "production", time.Hour)
+		handler := NewRecommendationsHandler(nil, store, nil, log)
+
+		recs := handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		assert.Empty(t, recs, "a single in-window incident is not a recurrence")
+	})
+
+	t.Run("zero half-life disables decay", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		for i := 0; i < 5; i++ {
+			createAged(store, "production", 60*24*time.Hour)
+		}
+		handler := NewRecommendationsHandler(nil, store, nil, log)
+		handler.SetHistoricalWeighting(HistoricalWeighting{})
+
+		recs := handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		require.Len(t, recs, 1)
+		assert.Equal(t, 0.85, recs[0].Confidence)
+	})
+
+	t.Run("resolved incidents are discounted", func(t *testing.T) {
+		store := storage.NewIncidentStore()
+		for i := 0; i < 4; i++ {
+			createAged(store, "production", 0)
+		}
+		for _, inc := range store.List(storage.ListFilter{})[:3] {
+			inc.Resolve()
+		}
+		handler := NewRecommendationsHandler(nil, store, nil, log)
+		handler.SetHistoricalWeighting(HistoricalWeighting{})
+
+		recs := handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		require.Len(t, recs, 1, "with no discount resolved incidents count like open ones")
+		assert.InDelta(t, 4.0, recs[0].EvidenceData.Historical.DiscountedCount, 0.001)
+		assert.InDelta(t, 4.0, recs[0].EvidenceData.Historical.WeightedScore, 0.001)
+		assert.Equal(t, "medium", recs[0].Severity)
+
+		handler.SetHistoricalWeighting(HistoricalWeighting{ResolvedDiscount: 0.5})
+		recs = handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		require.Len(t, recs, 1)
+		assert.Equal(t, 4, recs[0].EvidenceData.Historical.OccurrenceCount)
+		assert.InDelta(t, 2.5, recs[0].EvidenceData.Historical.DiscountedCount, 0.001)
+		assert.InDelta(t, 2.5, recs[0].EvidenceData.Historical.WeightedScore, 0.001)
+		assert.Equal(t, "low", recs[0].Severity, "severity follows the discounted count")
+		assert.Contains(t, recs[0].Evidence[0], "2.5 after discounting resolved incidents")
+
+		handler.SetHistoricalWeighting(HistoricalWeighting{ResolvedDiscount: 0.75})
+		recs = handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		assert.Empty(t, recs, "1.75 discounted occurrences are not a recurrence")
+
+		handler.SetHistoricalWeighting(HistoricalWeighting{ResolvedDiscount: 1})
+		recs = handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+		assert.Empty(t, recs, "resolving the incidents clears the recommendation")
+	})
+}
+
+// TestRecommendationsHandler_HistoricalRemediationOutcomes verifies linked workflow outcomes show up as evidence
+func TestRecommendationsHandler_HistoricalRemediationOutcomes(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	newIncident := func(target string) *models.Incident {
+		created, err := store.Create(&models.Incident{
+			Title:       "Crash loop",
+			Description: "Container restarting repeatedly",
+			Severity:    models.IncidentSeverityHigh,
+			Target:      target,
+		})
+		require.NoError(t, err)
+		return created
+	}
+
+	// "flaky": remediation keeps failing
+	for i := 0; i < 2; i++ {
+		inc := newIncident("flaky")
+		_, err := store.LinkWorkflow(inc.ID, fmt.Sprintf("wf-f%d", i), models.WorkflowStatusFailed)
+		require.NoError(t, err)
+	}
+	// "healing": remediation fixes it every time
+	for i := 0; i < 2; i++ {
+		inc := newIncident("healing")
+		_, err := store.LinkWorkflow(inc.ID, fmt.Sprintf("wf-h%d", i), models.WorkflowStatusCompleted)
+		require.NoError(t, err)
+	}
+
+	// Count the auto-resolved incidents in full so "healing" still recurs
+	weighting := DefaultHistoricalWeighting()
+	weighting.ResolvedDiscount = 0
+	handler := NewRecommendationsHandler(nil, store, nil, log)
+	handler.SetHistoricalWeighting(weighting)
+	recs := handler.getHistoricalRecommendations(&GetRecommendationsRequest{})
+	require.Len(t, recs, 2)
+
+	byTarget := make(map[string]Recommendation)
+	for _, rec := range recs {
+		byTarget[rec.Target] = rec
+	}
+
+	flaky := byTarget["flaky"]
+	assert.Contains(t, flaky.RecommendedActions, "review_remediation_strategy")
+	assert.Contains(t, flaky.Evidence, "Automated remediation failed 2 times for these incidents")
+
+	healing := byTarget["healing"]
+	assert.NotContains(t, healing.RecommendedActions, "review_remediation_strategy")
+	assert.Contains(t, healing.Evidence, "2 incidents were auto-resolved by remediation workflows")
+
+	require.NotNil(t, flaky.EvidenceData)
+	require.NotNil(t, flaky.EvidenceData.Historical)
+	assert.Equal(t, 2, flaky.EvidenceData.Historical.OccurrenceCount)
+	assert.Equal(t, 2, flaky.EvidenceData.Historical.RemediationFailures)
+	assert.Equal(t, 0, flaky.EvidenceData.Historical.AutoResolvedCount)
+	require.NotNil(t, healing.EvidenceData)
+	assert.Equal(t, 2, healing.EvidenceData.Historical.AutoResolvedCount)
+}
+
+func TestRecommendationsHandler_DetailedEvidence(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	for range 3 {
+		_, err := store.Create(&models.Incident{Title: "OOM", Description: "Container OOM killed", Severity: models.IncidentSeverityHigh, Target: "payments"})
+		require.NoError(t, err)
+	}
+	handler := NewRecommendationsHandler(nil, store, nil, log)
+
+	get := func(body string) GetRecommendationsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(body)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp GetRecommendationsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Recommendations, 1)
+		return resp
+	}
+
+	resp := get(`{"confidence_threshold": 0.5}`)
+	assert.Nil(t, resp.Recommendations[0].EvidenceData, "evidence_data is opt-in")
+
+	resp = get(`{"confidence_threshold": 0.5, "detailed_evidence": true}`)
+	data := resp.Recommendations[0].EvidenceData
+	require.NotNil(t, data)
+	require.NotNil(t, data.Historical)
+	assert.Equal(t, 3, data.Historical.OccurrenceCount)
+	assert.InDelta(t, 3.0, data.Historical.WeightedScore, 0.01)
+	assert.Nil(t, data.Prediction)
+	assert.Nil(t, data.Pattern)
+}
+
+func TestInterpretMLPredictions_EvidenceData(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, nil, nil, log)
+
+	now := time.Date(2026, 1, 12, 14, 0, 0, 0, time.UTC) // Monday
+	instances := [][]float64{{14, 1, 0.5, 0.6}, {14, 1, 0.575, 0.69}}
+	scope := mlScope{cpuRollingMean: 0.5, memoryRollingMean: 0.6}
+	recs := handler.interpretMLPredictions([]mlPrediction{{label: 1}, {label: -1}}, &GetRecommendationsRequest{Timeframe: "6h"}, scope, now, instances)
+	require.Len(t, recs, 1)
+
+	require.NotNil(t, recs[0].EvidenceData)
+	prediction := recs[0].EvidenceData.Prediction
+	require.NotNil(t, prediction)
+	assert.Equal(t, -1, prediction.ModelOutput)
+	assert.Equal(t, 1, prediction.InstanceIndex)
+	assert.Equal(t, 14, prediction.HourOfDay)
+	assert.Equal(t, 1, prediction.DayOfWeek)
+	assert.InDelta(t, 0.575, prediction.InstanceCPU, 1e-9)
+	assert.InDelta(t, 0.69, prediction.InstanceMemory, 1e-9)
+	assert.Nil(t, prediction.Score)
+}
+
+func TestHistoricalWeighting_Weight(t *testing.T) {
+	now := time.Now()
+	w := HistoricalWeighting{HalfLife: 24 * time.Hour, MaxAge: 72 * time.Hour}
+
+	weight, ok := w.weight(now, now)
+	assert.True(t, ok)
+	assert.InDelta(t, 1.0, weight, 0.0001)
+
+	weight, ok = w.weight(now.Add(-24*time.Hour), now)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, weight, 0.0001)
+
+	weight, ok = w.weight(now.Add(-48*time.Hour), now)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.25, weight, 0.0001)
+
+	_, ok = w.weight(now.Add(-73*time.Hour), now)
+	assert.False(t, ok)
+}
+
 func TestHelperFunctions(t *testing.T) {
-	t.Run("calculateHistoricalConfidence", func(t *testing.T) {
-		assert.Equal(t, 0.95, calculateHistoricalConfidence(10))
-		assert.Equal(t, 0.95, calculateHistoricalConfidence(15))
-		assert.Equal(t, 0.85, calculateHistoricalConfidence(5))
-		assert.Equal(t, 0.85, calculateHistoricalConfidence(7))
-		assert.Equal(t, 0.75, calculateHistoricalConfidence(3))
-		assert.Equal(t, 0.65, calculateHistoricalConfidence(2))
-		assert.Equal(t, 0.65, calculateHistoricalConfidence(1))
+	t.Run("calculateWeightedHistoricalConfidence", func(t *testing.T) {
+		assert.Equal(t, 0.95, calculateWeightedHistoricalConfidence(10))
+		assert.Equal(t, 0.95, calculateWeightedHistoricalConfidence(15))
+		assert.Equal(t, 0.85, calculateWeightedHistoricalConfidence(5))
+		assert.Equal(t, 0.85, calculateWeightedHistoricalConfidence(7))
+		assert.Equal(t, 0.75, calculateWeightedHistoricalConfidence(3))
+		assert.Equal(t, 0.65, calculateWeightedHistoricalConfidence(2))
+		assert.Equal(t, 0.65, calculateWeightedHistoricalConfidence(1))
+		assert.Equal(t, 0.95, calculateWeightedHistoricalConfidence(10.0))
+		assert.Equal(t, 0.85, calculateWeightedHistoricalConfidence(9.99))
+		assert.Equal(t, 0.75, calculateWeightedHistoricalConfidence(3.2))
+		assert.Equal(t, 0.65, calculateWeightedHistoricalConfidence(0.4))
 	})
 
 	t.Run("mapCountToSeverity", func(t *testing.T) {
@@ -466,11 +980,21 @@ func TestHelperFunctions(t *testing.T) {
 		assert.Contains(t, actions, "investigate_issue")
 	})
 
-	t.Run("getPredictionHorizon", func(t *testing.T) {
-		assert.Equal(t, 30*time.Minute, getPredictionHorizon("1h"))
-		assert.Equal(t, 3*time.Hour, getPredictionHorizon("6h"))
-		assert.Equal(t, 12*time.Hour, getPredictionHorizon("24h"))
-		assert.Equal(t, 3*time.Hour, getPredictionHorizon("unknown"))
+	t.Run("predictionHorizon", func(t *testing.T) {
+		handler := NewRecommendationsHandler(nil, nil, nil, logrus.New())
+		assert.Equal(t, 30*time.Minute, handler.predictionHorizon("1h"))
+		assert.Equal(t, 3*time.Hour, handler.predictionHorizon("6h"))
+		assert.Equal(t, 12*time.Hour, handler.predictionHorizon("24h"))
+		assert.Equal(t, 3*time.Hour, handler.predictionHorizon("unknown"))
+
+		require.NoError(t, handler.SetPredictionHorizons(map[string]time.Duration{"1h": time.Hour, "6h": 6 * time.Hour, "24h": 20 * time.Hour}))
+		assert.Equal(t, time.Hour, handler.predictionHorizon("1h"))
+		assert.Equal(t, 20*time.Hour, handler.predictionHorizon("24h"))
+
+		assert.ErrorContains(t, handler.SetPredictionHorizons(map[string]time.Duration{"1h": time.Hour, "6h": time.Hour}), `no prediction horizon for timeframe "24h"`)
+		assert.ErrorContains(t, handler.SetPredictionHorizons(map[string]time.Duration{"1h": time.Hour, "6h": time.Hour, "24h": time.Hour, "48h": time.Hour}), `unsupported timeframe "48h"`)
+		assert.ErrorContains(t, handler.SetPredictionHorizons(map[string]time.Duration{"1h": 2 * time.Hour, "6h": time.Hour, "24h": time.Hour}), "must be within (0, 1h]")
+		assert.Equal(t, time.Hour, handler.predictionHorizon("1h"), "rejected horizons are not applied")
 	})
 
 	t.Run("interpretPrediction", func(t *testing.T) {
@@ -490,3 +1014,147 @@ func TestGetRecommendationsRequest_Defaults(t *testing.T) {
 	assert.Zero(t, req.ConfidenceThreshold)
 	assert.Empty(t, req.Namespace)
 }
+
+// TestRecommendationsHandler_HandleError verifies errors map to stable status codes and error codes
+func TestRecommendationsHandler_HandleError(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, nil, nil, log)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "request error",
+			err:        &requestError{message: "bad", code: ErrCodeInvalidTimeframe},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidTimeframe,
+		},
+		{
+			name:       "wrapped service error",
+			err:        fmt.Errorf("collecting: %w", &serviceError{message: "ML predictions unavailable", details: "timeout", code: ErrCodeMLUnavailable}),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   ErrCodeMLUnavailable,
+		},
+		{
+			name:       "unexpected error",
+			err:        errors.New("boom"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   ErrCodeInternalError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.handleError(context.Background(), w, tt.err)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var resp RecommendationsErrorResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, "error", resp.Status)
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
+
+// machineConfigPool returns a MachineConfigPool with the given Updating condition
+func machineConfigPool(name string, updating bool) *unstructured.Unstructured {
+	status := "False"
+	if updating {
+		status = "True"
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "machineconfiguration.openshift.io/v1",
+		"kind":       "MachineConfigPool",
+		"metadata":   map[string]interface{}{"name": name},
+		"status": map[string]interface{}{
+			"machineCount":        int64(3),
+			"updatedMachineCount": int64(3),
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Updating", "status": status},
+			},
+		},
+	}}
+}
+
+func TestRecommendationsHandler_MCOGating(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	for range 3 {
+		_, err := store.Create(&models.Incident{Title: "OOM", Description: "Container OOM killed", Severity: models.IncidentSeverityHigh, Target: "payments"})
+		require.NoError(t, err)
+	}
+
+	get := func(handler *RecommendationsHandler) GetRecommendationsResponse {
+		t.Helper()
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(`{"confidence_threshold": 0.5}`)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp GetRecommendationsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Recommendations, 1)
+		return resp
+	}
+	withPools := func(pools ...*unstructured.Unstructured) *RecommendationsHandler {
+		objects := make([]runtime.Object, len(pools))
+		for i, pool := range pools {
+			objects[i] = pool
+		}
+		handler := NewRecommendationsHandler(nil, store, nil, log)
+		handler.SetMCOClient(integrations.NewMCOClient(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...), log))
+		return handler
+	}
+
+	t.Run("gating disabled", func(t *testing.T) {
+		resp := get(NewRecommendationsHandler(nil, store, nil, log))
+		assert.False(t, resp.Recommendations[0].DeferUntilStable)
+		assert.Equal(t, "medium", resp.Recommendations[0].Severity)
+	})
+
+	t.Run("all pools stable", func(t *testing.T) {
+		resp := get(withPools(machineConfigPool("master", false), machineConfigPool("worker", false)))
+		assert.False(t, resp.Recommendations[0].DeferUntilStable)
+		assert.Empty(t, resp.UpdatingPools)
+	})
+
+	t.Run("pool updating defers and down-ranks", func(t *testing.T) {
+		resp := get(withPools(machineConfigPool("master", false), machineConfigPool("worker", true)))
+		rec := resp.Recommendations[0]
+		assert.True(t, rec.DeferUntilStable)
+		assert.Equal(t, "low", rec.Severity)
+		assert.Contains(t, rec.Evidence[len(rec.Evidence)-1], "worker")
+		assert.Equal(t, []string{"worker"}, resp.UpdatingPools)
+	})
+
+	t.Run("deferred before filtering, so acknowledged entries are deferred too", func(t *testing.T) {
+		handler := withPools(machineConfigPool("worker", true))
+		acks := storage.NewRecommendationAckStore()
+		handler.SetAckStore(acks, 0)
+		id := get(handler).Recommendations[0].ID
+		now := time.Now()
+		require.NoError(t, acks.Acknowledge(&models.RecommendationAck{RecommendationID: id, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}))
+
+		w := httptest.NewRecorder()
+		handler.GetRecommendations(w, httptest.NewRequest("POST", "/api/v1/recommendations", bytes.NewBufferString(`{"confidence_threshold": 0.5, "include_acknowledged": true}`)))
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp GetRecommendationsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Acknowledged, 1)
+		assert.True(t, resp.Acknowledged[0].DeferUntilStable)
+		assert.Equal(t, "low", resp.Acknowledged[0].Severity)
+	})
+}
+
+func TestLowerSeverity(t *testing.T) {
+	assert.Equal(t, "high", lowerSeverity("critical"))
+	assert.Equal(t, "medium", lowerSeverity("high"))
+	assert.Equal(t, "low", lowerSeverity("medium"))
+	assert.Equal(t, "low", lowerSeverity("low"))
+	assert.Equal(t, "unknown", lowerSeverity("unknown"))
+}
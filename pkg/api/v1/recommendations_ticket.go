@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/middleware"
+)
+
+// Ticket export error codes
+const (
+	ErrCodeTicketExportDisabled   = "TICKET_EXPORT_DISABLED"
+	ErrCodeTicketExportFailed     = "TICKET_EXPORT_FAILED"
+	ErrCodeRecommendationNotFound = "RECOMMENDATION_NOT_FOUND"
+)
+
+// Served recommendations can be ticketed for a day; beyond maxServedRecommendations the oldest
+// are evicted in batches, so a busy handler does not rescan the map on every response
+const (
+	servedRecommendationTTL         = 24 * time.Hour
+	maxServedRecommendations        = 1000
+	servedRecommendationsEvictBatch = maxServedRecommendations / 10
+)
+
+// servedRecommendations remembers recently returned recommendations by ID. Recommendations are
+// computed per request, so tickets can only be created for ones a response actually listed.
+type servedRecommendations struct {
+	mu      sync.Mutex
+	entries map[string]servedRecommendation
+}
+
+type servedRecommendation struct {
+	recommendation Recommendation
+	servedAt       time.Time
+}
+
+func newServedRecommendations() *servedRecommendations {
+	return &servedRecommendations{entries: make(map[string]servedRecommendation)}
+}
+
+// remember records recommendations as served at now, replacing earlier versions with the same
+// ID. When full, expired entries are dropped first, then the oldest.
+func (s *servedRecommendations) remember(now time.Time, lists ...[]Recommendation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, recommendations := range lists {
+		for _, rec := range recommendations {
+			s.entries[rec.ID] = servedRecommendation{recommendation: rec, servedAt: now}
+		}
+	}
+	if len(s.entries) <= maxServedRecommendations {
+		return
+	}
+
+	for id, entry := range s.entries {
+		if now.Sub(entry.servedAt) > servedRecommendationTTL {
+			delete(s.entries, id)
+		}
+	}
+	excess := len(s.entries) - (maxServedRecommendations - servedRecommendationsEvictBatch)
+	if excess <= 0 {
+		return
+	}
+	ids := slices.SortedFunc(maps.Keys(s.entries), func(a, b string) int {
+		return s.entries[a].servedAt.Compare(s.entries[b].servedAt)
+	})
+	for _, id := range ids[:excess] {
+		delete(s.entries, id)
+	}
+}
+
+// get returns the last served version of the recommendation with id, unless it has expired
+func (s *servedRecommendations) get(id string, now time.Time) (Recommendation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[id]
+	if !ok || now.Sub(entry.servedAt) > servedRecommendationTTL {
+		return Recommendation{}, false
+	}
+	return entry.recommendation, true
+}
+
+// contains reports whether a recommendation served within the TTL has id, as its own ID or as
+// the ID one of its merged sources would have had
+func (s *servedRecommendations) contains(id string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if now.Sub(entry.servedAt) > servedRecommendationTTL {
+			continue
+		}
+		rec := entry.recommendation
+		if rec.ID == id {
+			return true
+		}
+		for _, source := range rec.Sources {
+			if recommendationID(source, rec.IssueType, rec.Namespace, rec.Target) == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetTicketExporter enables POST /api/v1/recommendations/{id}/ticket, which renders a served
+// recommendation through exporter's field mapping and creates a ticket. nil disables it.
+func (h *RecommendationsHandler) SetTicketExporter(exporter *integrations.TicketExporter) {
+	h.ticketExporter = exporter
+}
+
+// CreateRecommendationTicketRequest is the optional body of POST /api/v1/recommendations/{id}/ticket
+type CreateRecommendationTicketRequest struct {
+	DryRun bool `json:"dry_run"` // Return the rendered payload without creating a ticket
+}
+
+// RecommendationTicketResponse reports the ticket created for a recommendation, or the payload
+// that would be sent on a dry run
+type RecommendationTicketResponse struct {
+	Status           string                        `json:"status"`
+	RecommendationID string                        `json:"recommendation_id"`
+	Ticket           *integrations.TicketReference `json:"ticket,omitempty"`
+	Payload          map[string]any                `json:"payload,omitempty"`
+}
+
+// CreateRecommendationTicket handles POST /api/v1/recommendations/{id}/ticket
+//
+// Renders a recommendation returned within the last 24 hours into the configured ticket payload
+// and posts it to the ticketing system. Each call creates a new ticket.
+func (h *RecommendationsHandler) CreateRecommendationTicket(w http.ResponseWriter, r *http.Request) {
+	ctx, _ := middleware.EnsureRequestID(w, r)
+	if h.ticketExporter == nil {
+		h.respondError(w, http.StatusServiceUnavailable, "Ticket export not enabled",
+			"set RECOMMENDATION_TICKET_URL to export recommendations", ErrCodeTicketExportDisabled)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if !strings.HasPrefix(id, recommendationIDPrefix) {
+		h.handleError(ctx, w, &requestError{message: "invalid recommendation id", details: fmt.Sprintf("got %q", id), code: ErrCodeInvalidRequest})
+		return
+	}
+
+	var req CreateRecommendationTicketRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.handleError(ctx, w, decodeRequestError("invalid request body", err))
+			return
+		}
+	}
+
+	rec, ok := h.served.get(id, time.Now())
+	if !ok {
+		h.handleError(ctx, w, &requestError{
+			message: "recommendation not found",
+			details: fmt.Sprintf("%q was not returned by POST /api/v1/recommendations in the last %s", id, servedRecommendationTTL),
+			code:    ErrCodeRecommendationNotFound,
+			status:  http.StatusNotFound,
+		})
+		return
+	}
+
+	if req.DryRun {
+		payload, err := h.ticketExporter.Render(&rec)
+		if err != nil {
+			h.handleError(ctx, w, &serviceError{message: "failed to render ticket", details: err.Error(), code: ErrCodeTicketExportFailed})
+			return
+		}
+		h.respondJSON(w, http.StatusOK, RecommendationTicketResponse{Status: "success", RecommendationID: id, Payload: payload})
+		return
+	}
+
+	ticket, err := h.ticketExporter.Export(ctx, &rec)
+	if err != nil {
+		h.log.WithContext(ctx).WithError(err).WithField("recommendation_id", id).Warn("Failed to create recommendation ticket")
+		h.handleError(ctx, w, &serviceError{message: "failed to create ticket", details: err.Error(), code: ErrCodeTicketExportFailed})
+		return
+	}
+
+	h.log.WithContext(ctx).WithFields(logrus.Fields{
+		"recommendation_id": id,
+		"ticket":            ticket.Reference,
+	}).Info("Recommendation ticket created")
+	h.respondJSON(w, http.StatusCreated, RecommendationTicketResponse{Status: "success", RecommendationID: id, Ticket: ticket})
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func TestRecommendationsHandler_CreateTicket(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	var tickets []map[string]any
+	ticketing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		tickets = append(tickets, payload)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "10001", "key": "OPS-42"}`))
+	}))
+	defer ticketing.Close()
+
+	store := storage.NewIncidentStore()
+	for range 3 {
+		_, err := store.Create(&models.Incident{Title: "OOM", Description: "Container OOM killed", Severity: models.IncidentSeverityHigh, Target: "payments"})
+		require.NoError(t, err)
+	}
+	handler := NewRecommendationsHandler(nil, store, nil, log)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/recommendations", handler.GetRecommendations).Methods("POST")
+	router.HandleFunc("/api/v1/recommendations/{id}/ticket", handler.CreateRecommendationTicket).Methods("POST")
+	serve := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := serve("/api/v1/recommendations/rec-0123456789ab/ticket", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeTicketExportDisabled)
+
+	exporter, err := integrations.NewTicketExporter(integrations.TicketExporterConfig{
+		URL:    ticketing.URL,
+		Format: integrations.TicketFormatJira,
+		Fields: map[string]string{"fields.project.key": "OPS", "fields.customfield_10010": "{{.Namespace}}"},
+	}, log)
+	require.NoError(t, err)
+	handler.SetTicketExporter(exporter)
+
+	w = serve("/api/v1/recommendations/rec-0123456789ab/ticket", "")
+	assert.Equal(t, http.StatusNotFound, w.Code, "only served recommendations can be ticketed")
+	assert.Contains(t, w.Body.String(), ErrCodeRecommendationNotFound)
+
+	w = serve("/api/v1/recommendations", `{"confidence_threshold": 0.5}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp GetRecommendationsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Recommendations, 1)
+	id := resp.Recommendations[0].ID
+
+	w = serve("/api/v1/recommendations/"+id+"/ticket", `{"dry_run": true}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var dryRun RecommendationTicketResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&dryRun))
+	assert.Equal(t, "payments", dryRun.Payload["fields"].(map[string]any)["customfield_10010"])
+	assert.Empty(t, tickets, "dry runs create no ticket")
+
+	w = serve("/api/v1/recommendations/"+id+"/ticket", "")
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created RecommendationTicketResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.Equal(t, id, created.RecommendationID)
+	assert.Equal(t, "OPS-42", created.Ticket.Reference)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, map[string]any{"key": "OPS"}, tickets[0]["fields"].(map[string]any)["project"])
+
+	ticketing.Close()
+	w = serve("/api/v1/recommendations/"+id+"/ticket", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeTicketExportFailed)
+}
+
+func TestServedRecommendations(t *testing.T) {
+	served := newServedRecommendations()
+	now := time.Now()
+
+	served.remember(now.Add(-25*time.Hour), []Recommendation{{ID: "rec-old"}})
+	served.remember(now, []Recommendation{{ID: "rec-a", Severity: "high"}}, []Recommendation{{ID: "rec-b"}})
+	_, ok := served.get("rec-old", now)
+	assert.False(t, ok, "expired")
+	rec, ok := served.get("rec-a", now)
+	require.True(t, ok)
+	assert.Equal(t, "high", rec.Severity)
+
+	recs := make([]Recommendation, maxServedRecommendations+1)
+	for i := range recs {
+		recs[i] = Recommendation{ID: fmt.Sprintf("rec-%d", i)}
+	}
+	served.remember(now.Add(time.Minute), recs)
+	assert.LessOrEqual(t, len(served.entries), maxServedRecommendations)
+	_, ok = served.get("rec-a", now)
+	assert.False(t, ok, "oldest evicted first")
+
+	// Entries served after now, e.g. when the clock stepped back, are still evicted
+	served = newServedRecommendations()
+	served.remember(now.Add(time.Hour), recs[:maxServedRecommendations])
+	served.remember(now, []Recommendation{{ID: "rec-late"}})
+	assert.LessOrEqual(t, len(served.entries), maxServedRecommendations)
+}
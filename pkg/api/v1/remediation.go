@@ -1,8 +1,13 @@
 package v1
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -18,6 +23,9 @@ type RemediationHandler struct {
 	orchestrator  *remediation.Orchestrator
 	incidentStore *storage.IncidentStore
 	log           *logrus.Logger
+
+	// purgeTokenFile holds the secret PurgeIncidents requires (empty = purge refused)
+	purgeTokenFile string
 }
 
 // NewRemediationHandler creates a new remediation handler with in-memory incident store
@@ -38,6 +46,12 @@ func NewRemediationHandlerWithStore(orchestrator *remediation.Orchestrator, inci
 	}
 }
 
+// SetPurgeTokenFile sets the file holding the secret DELETE /api/v1/incidents must be confirmed
+// with. It is re-read per request so the secret can be rotated.
+func (h *RemediationHandler) SetPurgeTokenFile(path string) {
+	h.purgeTokenFile = path
+}
+
 // GetIncidentStore returns the incident store for use by other handlers
 func (h *RemediationHandler) GetIncidentStore() *storage.IncidentStore {
 	return h.incidentStore
@@ -91,8 +105,13 @@ type CreateIncidentRequest struct {
 	Description       string            `json:"description"`
 	Severity          string            `json:"severity"`
 	Target            string            `json:"target"`
+	IssueType         string            `json:"issue_type,omitempty"`
 	AffectedResources []string          `json:"affected_resources,omitempty"`
 	Labels            map[string]string `json:"labels,omitempty"`
+
+	// IdempotencyKey makes retries safe: a repeated create with the same key returns the
+	// incident created the first time. The Idempotency-Key header is used when this is empty.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // CreateIncidentResponse represents the response for creating an incident
@@ -102,6 +121,9 @@ type CreateIncidentResponse struct {
 	CreatedAt  string           `json:"created_at"`
 	Incident   *models.Incident `json:"incident"`
 	Message    string           `json:"message"`
+
+	// Replayed is true when the idempotency key matched an existing incident and nothing was created
+	Replayed bool `json:"replayed,omitempty"`
 }
 
 // TriggerRemediation handles POST /api/v1/remediation/trigger
@@ -250,25 +272,24 @@ func (h *RemediationHandler) CreateIncident(w http.ResponseWriter, r *http.Reque
 		Description:       req.Description,
 		Severity:          models.IncidentSeverity(req.Severity),
 		Target:            req.Target,
+		IssueType:         req.IssueType,
 		AffectedResources: req.AffectedResources,
 		Labels:            req.Labels,
 	}
 
-	// Store incident (validation happens in Create)
-	createdIncident, err := h.incidentStore.Create(incident)
+	idempotencyKey := req.IdempotencyKey
+	if idempotencyKey == "" {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+	}
+
+	// Store incident (validation happens in CreateIdempotent)
+	createdIncident, replayed, err := h.incidentStore.CreateIdempotent(incident, idempotencyKey)
 	if err != nil {
 		h.log.WithError(err).Error("Failed to create incident")
 		h.sendErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	h.log.WithFields(logrus.Fields{
-		"incident_id": createdIncident.ID,
-		"title":       createdIncident.Title,
-		"severity":    createdIncident.Severity,
-		"target":      createdIncident.Target,
-	}).Info("Incident created successfully")
-
 	// Build response
 	response := CreateIncidentResponse{
 		Status:     "success",
@@ -277,15 +298,39 @@ func (h *RemediationHandler) CreateIncident(w http.ResponseWriter, r *http.Reque
 		Incident:   createdIncident,
 		Message:    "Incident created successfully",
 	}
+	statusCode := http.StatusCreated
+
+	if replayed {
+		h.log.WithFields(logrus.Fields{
+			"incident_id":     createdIncident.ID,
+			"idempotency_key": idempotencyKey,
+		}).Info("Incident create replayed, returning existing incident")
+
+		response.Message = "Incident already created for this idempotency key"
+		response.Replayed = true
+		statusCode = http.StatusOK
+	} else {
+		h.log.WithFields(logrus.Fields{
+			"incident_id": createdIncident.ID,
+			"title":       createdIncident.Title,
+			"severity":    createdIncident.Severity,
+			"target":      createdIncident.Target,
+		}).Info("Incident created successfully")
+	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.log.WithError(err).Error("Failed to encode response")
 	}
 }
 
 // ListIncidents handles GET /api/v1/incidents
+//
+// resolved_after and resolved_before (a lookback duration or RFC3339 time) keep only stored
+// incidents resolved in that window; workflow incidents carry no resolution time and are omitted.
+// labels=team=payments,sla=gold keeps stored incidents carrying all of the given labels;
+// workflow incidents have no labels and are omitted too.
 func (h *RemediationHandler) ListIncidents(w http.ResponseWriter, r *http.Request) {
 	h.log.Info("Listing incidents")
 
@@ -300,10 +345,23 @@ func (h *RemediationHandler) ListIncidents(w http.ResponseWriter, r *http.Reques
 		Severity:  severity,
 		Limit:     50, // Default limit
 	}
+	if err := parseResolvedWindow(query, &filter, time.Now()); err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	labels, err := parseLabelSelector(query.Get("labels"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Labels = labels
 	storedIncidents := h.incidentStore.List(filter)
 
-	// Get workflow-based incidents
-	workflows := h.orchestrator.ListWorkflows()
+	// Get workflow-based incidents; they are never resolved at a known time and carry no labels
+	var workflows []*models.Workflow
+	if filter.ResolvedAfter.IsZero() && filter.ResolvedBefore.IsZero() && len(filter.Labels) == 0 {
+		workflows = h.orchestrator.ListWorkflows()
+	}
 
 	// Combine both sources into response
 	incidents := make([]map[string]interface{}, 0, len(storedIncidents)+len(workflows))
@@ -322,9 +380,18 @@ func (h *RemediationHandler) ListIncidents(w http.ResponseWriter, r *http.Reques
 			"labels":             inc.Labels,
 			"source":             "manual",
 		}
+		if inc.IssueType != "" {
+			incident["issue_type"] = inc.IssueType
+		}
+		if inc.OccurrenceCount > 1 {
+			incident["occurrence_count"] = inc.OccurrenceCount
+		}
 		if inc.WorkflowID != "" {
 			incident["workflow_id"] = inc.WorkflowID
 		}
+		if inc.ResolvedAt != nil {
+			incident["resolved_at"] = inc.ResolvedAt.Format(time.RFC3339)
+		}
 		incidents = append(incidents, incident)
 	}
 
@@ -374,6 +441,165 @@ func (h *RemediationHandler) ListIncidents(w http.ResponseWriter, r *http.Reques
 	h.log.WithField("count", len(incidents)).Info("Incidents listed successfully")
 }
 
+// IncidentStats handles GET /api/v1/incidents/stats
+//
+// Query parameters namespace, severity and status filter as in ListIncidents. since limits
+// the summary to incidents created in a lookback window ("24h") or after an RFC3339 time.
+// resolved_after and resolved_before limit it to incidents resolved in a window, e.g. for MTTR
+// over the last week with resolved_after=168h. labels=team=payments,sla=gold keeps incidents
+// carrying all of the given labels.
+func (h *RemediationHandler) IncidentStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := storage.ListFilter{
+		Namespace: query.Get("namespace"),
+		Severity:  query.Get("severity"),
+		Status:    query.Get("status"),
+	}
+
+	if since := query.Get("since"); since != "" {
+		sinceTime, err := parseSince(since, time.Now())
+		if err != nil {
+			h.sendErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.Since = sinceTime
+	}
+	if err := parseResolvedWindow(query, &filter, time.Now()); err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	labels, err := parseLabelSelector(query.Get("labels"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Labels = labels
+
+	stats := h.incidentStore.Stats(filter)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		h.log.WithError(err).Error("Failed to encode incident stats response")
+	}
+}
+
+// PurgeIncidentsResponse reports how many incidents a purge deleted
+type PurgeIncidentsResponse struct {
+	Status  string `json:"status"`
+	Deleted int    `json:"deleted"`
+}
+
+// PurgeIncidents handles DELETE /api/v1/incidents?confirm=<token>
+//
+// Deletes every stored incident, e.g. when re-provisioning a cluster. The confirm query
+// parameter must equal the secret in the purge token file. Workflows are not affected.
+func (h *RemediationHandler) PurgeIncidents(w http.ResponseWriter, r *http.Request) {
+	token, err := h.purgeToken()
+	if err != nil {
+		h.log.WithError(err).Error("Incident purge token unavailable")
+		h.sendErrorResponse(w, http.StatusServiceUnavailable, "incident purge is not configured")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("confirm")), []byte(token)) != 1 {
+		h.sendErrorResponse(w, http.StatusForbidden, "confirm query parameter must be set to the purge token")
+		return
+	}
+
+	deleted, err := h.incidentStore.Purge(storage.PurgeConfirmToken)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to purge incidents")
+		h.sendErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.log.WithField("deleted", deleted).Warn("All incidents purged via API")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(PurgeIncidentsResponse{Status: "success", Deleted: deleted}); err != nil {
+		h.log.WithError(err).Error("Failed to encode purge response")
+	}
+}
+
+// purgeToken reads the purge secret. A missing file or an empty secret is an error so that
+// purge can never be confirmed with an empty parameter.
+func (h *RemediationHandler) purgeToken() (string, error) {
+	if h.purgeTokenFile == "" {
+		return "", fmt.Errorf("no purge token file configured")
+	}
+	data, err := os.ReadFile(h.purgeTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read purge token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("purge token file %s is empty", h.purgeTokenFile)
+	}
+	return token, nil
+}
+
+// parseSince accepts a positive lookback duration relative to now or an RFC3339 timestamp
+func parseSince(value string, now time.Time) (time.Time, error) {
+	return parseTimeParam("since", value, now)
+}
+
+// parseTimeParam parses the query parameter name as a positive lookback duration relative to
+// now or an RFC3339 timestamp
+func parseTimeParam(name, value string, now time.Time) (time.Time, error) {
+	if lookback, err := time.ParseDuration(value); err == nil {
+		if lookback <= 0 {
+			return time.Time{}, fmt.Errorf("%s duration must be positive: %s", name, value)
+		}
+		return now.Add(-lookback), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a duration (e.g. 24h) or RFC3339 timestamp: %s", name, value)
+	}
+	return t, nil
+}
+
+// parseResolvedWindow sets filter's resolution window from the resolved_after and
+// resolved_before query parameters
+func parseResolvedWindow(query url.Values, filter *storage.ListFilter, now time.Time) error {
+	var err error
+	if value := query.Get("resolved_after"); value != "" {
+		if filter.ResolvedAfter, err = parseTimeParam("resolved_after", value, now); err != nil {
+			return err
+		}
+	}
+	if value := query.Get("resolved_before"); value != "" {
+		if filter.ResolvedBefore, err = parseTimeParam("resolved_before", value, now); err != nil {
+			return err
+		}
+	}
+	if !filter.ResolvedAfter.IsZero() && !filter.ResolvedBefore.IsZero() && !filter.ResolvedAfter.Before(filter.ResolvedBefore) {
+		return fmt.Errorf("resolved_after must be before resolved_before")
+	}
+	return nil
+}
+
+// parseLabelSelector parses the labels query parameter, comma-separated key=value pairs that
+// must all match, e.g. "team=payments,sla=gold". Empty means no label filter.
+func parseLabelSelector(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, labelValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid labels parameter %q: expected comma-separated key=value pairs", value)
+		}
+		labels[key] = labelValue
+	}
+	if err := models.ValidateLabels(labels); err != nil {
+		return nil, fmt.Errorf("invalid labels parameter: %w", err)
+	}
+	return labels, nil
+}
+
 // sendErrorResponse sends a JSON error response
 func (h *RemediationHandler) sendErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
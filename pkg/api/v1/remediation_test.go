@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
+)
+
+func TestRemediationHandler_IncidentStats(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	for _, inc := range []*models.Incident{
+		{Title: "Crash", Description: "Pod crash loop", Target: "payments", IssueType: "crash", Severity: models.IncidentSeverityCritical},
+		{Title: "OOM", Description: "Container OOM killed", Target: "payments", IssueType: "oom", Severity: models.IncidentSeverityHigh},
+		{Title: "Crash", Description: "Pod crash loop", Target: "checkout", IssueType: "crash", Severity: models.IncidentSeverityCritical},
+	} {
+		_, err := store.Create(inc)
+		require.NoError(t, err)
+	}
+	handler := NewRemediationHandlerWithStore(nil, store, log)
+
+	t.Run("filters and groups", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/incidents/stats?severity=critical&status=active&since=24h", http.NoBody)
+		w := httptest.NewRecorder()
+
+		handler.IncidentStats(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var stats storage.IncidentStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&stats))
+		assert.Equal(t, 2, stats.Total)
+		assert.Equal(t, map[string]int{"payments": 1, "checkout": 1}, stats.ByNamespace)
+		assert.Equal(t, map[string]int{"critical": 2}, stats.BySeverity)
+	})
+
+	t.Run("resolved window", func(t *testing.T) {
+		incidents := store.List(storage.ListFilter{Namespace: "checkout"})
+		require.Len(t, incidents, 1)
+		incidents[0].Resolve()
+
+		req := httptest.NewRequest("GET", "/api/v1/incidents/stats?resolved_after=1h", http.NoBody)
+		w := httptest.NewRecorder()
+
+		handler.IncidentStats(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var stats storage.IncidentStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&stats))
+		assert.Equal(t, 1, stats.Total)
+		assert.Equal(t, 1, stats.ResolutionTime.Count)
+
+		for _, query := range []string{"resolved_after=soon", "resolved_after=1h&resolved_before=2h"} {
+			w := httptest.NewRecorder()
+			handler.IncidentStats(w, httptest.NewRequest("GET", "/api/v1/incidents/stats?"+query, http.NoBody))
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		labeled := &models.Incident{Title: "Latency", Description: "p99 latency high", Target: "payments", IssueType: "latency",
+			Severity: models.IncidentSeverityMedium, Labels: map[string]string{"team": "payments", "sla": "gold"}}
+		_, err := store.Create(labeled)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("GET", "/api/v1/incidents/stats?labels=team=payments,sla=gold", http.NoBody)
+		w := httptest.NewRecorder()
+
+		handler.IncidentStats(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var stats storage.IncidentStats
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&stats))
+		assert.Equal(t, 1, stats.Total)
+
+		for _, query := range []string{"labels=team", "labels=team=payments,=gold", "labels=team=not%20valid"} {
+			w := httptest.NewRecorder()
+			handler.IncidentStats(w, httptest.NewRequest("GET", "/api/v1/incidents/stats?"+query, http.NoBody))
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/incidents/stats?since=yesterday", http.NoBody)
+		w := httptest.NewRecorder()
+
+		handler.IncidentStats(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRemediationHandler_PurgeIncidents(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := storage.NewIncidentStore()
+	for _, target := range []string{"payments", "checkout"} {
+		_, err := store.Create(&models.Incident{Title: "Crash", Description: "Pod crash loop", Target: target, Severity: models.IncidentSeverityHigh})
+		require.NoError(t, err)
+	}
+	handler := NewRemediationHandlerWithStore(nil, store, log)
+
+	purge := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.PurgeIncidents(w, httptest.NewRequest("DELETE", "/api/v1/incidents"+query, http.NoBody))
+		return w
+	}
+
+	// Without a token file nothing, not even an empty confirm, can purge
+	assert.Equal(t, http.StatusServiceUnavailable, purge("?confirm=").Code)
+	tokenFile := filepath.Join(t.TempDir(), "purge-token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("\n"), 0o600))
+	handler.SetPurgeTokenFile(tokenFile)
+	assert.Equal(t, http.StatusServiceUnavailable, purge("?confirm=").Code, "an empty secret is refused")
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s3cret-token\n"), 0o600))
+	for _, query := range []string{"", "?confirm=yes", "?confirm=" + storage.PurgeConfirmToken} {
+		assert.Equal(t, http.StatusForbidden, purge(query).Code, query)
+		assert.Equal(t, 2, store.Count())
+	}
+
+	w := purge("?confirm=s3cret-token")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp PurgeIncidentsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, 2, resp.Deleted)
+	assert.Equal(t, 0, store.Count())
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	got, err := parseSince("24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), got)
+
+	got, err = parseSince("2026-01-01T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = parseSince("-1h", now)
+	assert.Error(t, err)
+	_, err = parseSince("yesterday", now)
+	assert.Error(t, err)
+}
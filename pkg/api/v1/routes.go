@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+// DefaultAPIPrefix is the path handlers mount their routes under when RegisterRoutes is used
+const DefaultAPIPrefix = "/api/v1"
+
+// normalizeAPIPrefix returns prefix with a leading and no trailing slash, or DefaultAPIPrefix
+// when it is empty
+func normalizeAPIPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return DefaultAPIPrefix
+	}
+	return "/" + prefix
+}
+
+// routeMount is a router and the prefix a handler's routes were registered under on it
+type routeMount struct {
+	router *mux.Router
+	prefix string
+}
+
+// routeRegistry remembers where a handler's routes are registered so that registering them
+// again on the same router and prefix does not add duplicate routes. The zero value is ready
+// to use.
+type routeRegistry struct {
+	mu      sync.Mutex
+	mounted map[routeMount]bool
+}
+
+// claim reports whether routes are not yet registered on router under prefix, and marks them
+// registered
+func (r *routeRegistry) claim(router *mux.Router, prefix string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mount := routeMount{router: router, prefix: prefix}
+	if r.mounted[mount] {
+		return false
+	}
+	if r.mounted == nil {
+		r.mounted = make(map[routeMount]bool)
+	}
+	r.mounted[mount] = true
+	return true
+}
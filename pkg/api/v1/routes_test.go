@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/storage"
+)
+
+// countRoutes returns how many routes router has
+func countRoutes(t *testing.T, router *mux.Router) int {
+	t.Helper()
+	count := 0
+	require.NoError(t, router.Walk(func(*mux.Route, *mux.Router, []*mux.Route) error {
+		count++
+		return nil
+	}))
+	return count
+}
+
+func routeMatches(router *mux.Router, method, path string) bool {
+	return router.Match(httptest.NewRequest(method, path, http.NoBody), &mux.RouteMatch{})
+}
+
+func TestNormalizeAPIPrefix(t *testing.T) {
+	for prefix, want := range map[string]string{
+		"":                DefaultAPIPrefix,
+		"/":               DefaultAPIPrefix,
+		"/api/v1":         "/api/v1",
+		"engine/api/v1/":  "/engine/api/v1",
+		"/engine/api/v1/": "/engine/api/v1",
+	} {
+		assert.Equal(t, want, normalizeAPIPrefix(prefix), prefix)
+	}
+}
+
+func TestPredictionHandler_RegisterRoutesWithPrefix(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewPredictionHandler(nil, nil, log)
+
+	router := mux.NewRouter()
+	handler.RegisterRoutesWithPrefix(router, "/engine/api/v1/")
+	assert.True(t, routeMatches(router, "POST", "/engine/api/v1/predict"))
+	assert.True(t, routeMatches(router, "GET", "/engine/api/v1/config"))
+	assert.False(t, routeMatches(router, "POST", "/api/v1/predict"))
+
+	routes := countRoutes(t, router)
+	handler.RegisterRoutesWithPrefix(router, "/engine/api/v1")
+	assert.Equal(t, routes, countRoutes(t, router), "registering twice must not duplicate routes")
+
+	handler.RegisterRoutes(router)
+	assert.True(t, routeMatches(router, "POST", "/api/v1/predict"), "another prefix is a separate mount")
+	assert.Equal(t, 2*routes, countRoutes(t, router))
+
+	other := mux.NewRouter()
+	handler.RegisterRoutes(other)
+	assert.Equal(t, routes, countRoutes(t, other), "another router is registered independently")
+}
+
+func TestRecommendationsHandler_RegisterRoutes(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	handler := NewRecommendationsHandler(nil, storage.NewIncidentStore(), nil, log)
+
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router)
+	routes := countRoutes(t, router)
+	handler.RegisterRoutes(router)
+	assert.Equal(t, routes, countRoutes(t, router), "registering twice must not duplicate routes")
+	assert.True(t, routeMatches(router, "POST", "/api/v1/recommendations"))
+	assert.True(t, routeMatches(router, "DELETE", "/api/v1/recommendations/rec-1/acknowledge"))
+
+	prefixed := mux.NewRouter()
+	handler.RegisterRoutesWithPrefix(prefixed, "/engine/api/v1")
+	assert.True(t, routeMatches(prefixed, "POST", "/engine/api/v1/recommendations/rec-1/ticket"))
+	assert.False(t, routeMatches(prefixed, "POST", "/api/v1/recommendations"))
+}
@@ -0,0 +1,29 @@
+package v1
+
+import "github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+
+// Request and response bodies of the prediction and recommendation APIs. They are defined in
+// the types package, which API clients can import without the server's dependencies.
+type (
+	PredictResponse    = types.PredictResponse
+	PredictionValues   = types.PredictionValues
+	MetricValue        = types.MetricValue
+	CurrentMetrics     = types.CurrentMetrics
+	ModelInfo          = types.ModelInfo
+	TargetTimeInfo     = types.TargetTimeInfo
+	CapacityWhatIf     = types.CapacityWhatIf
+	CapacityPrediction = types.CapacityPrediction
+	TimeToThreshold    = types.TimeToThreshold
+	ThresholdCrossing  = types.ThresholdCrossing
+	RawModelResponse   = types.RawModelResponse
+
+	PredictErrorResponse = types.ErrorResponse
+
+	GetRecommendationsRequest  = types.GetRecommendationsRequest
+	GetRecommendationsResponse = types.GetRecommendationsResponse
+	Recommendation             = types.Recommendation
+	EvidenceData               = types.EvidenceData
+	HistoricalEvidence         = types.HistoricalEvidence
+	PredictionEvidence         = types.PredictionEvidence
+	PatternEvidence            = types.PatternEvidence
+)
@@ -0,0 +1,190 @@
+// Package client is the Go client for the coordination engine's prediction and recommendation
+// APIs. It uses the server's request and response types from pkg/api/types, which depends only
+// on the standard library, so callers always build the bodies the server decodes; scope
+// inference and defaults stay on the server.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+)
+
+// Request and response types of the APIs, shared with the server
+type (
+	PredictRequest             = types.PredictRequest
+	PredictResponse            = types.PredictResponse
+	GetRecommendationsRequest  = types.GetRecommendationsRequest
+	GetRecommendationsResponse = types.GetRecommendationsResponse
+	ErrorResponse              = types.ErrorResponse
+)
+
+// DefaultTimeout bounds each call when Config.Timeout is not set
+const DefaultTimeout = 30 * time.Second
+
+// maxErrorBodyBytes bounds how much of an error response is read
+const maxErrorBodyBytes = 64 << 10
+
+// Config configures a Client
+type Config struct {
+	// BaseURL is the engine's address, e.g. http://coordination-engine:8080
+	BaseURL string
+
+	// Timeout bounds each call (0 = DefaultTimeout). Ignored when HTTPClient is set.
+	Timeout time.Duration
+
+	// HTTPClient sends the requests (nil = a client with Timeout)
+	HTTPClient *http.Client
+}
+
+// Client calls the coordination engine's APIs. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New creates a client for the engine at cfg.BaseURL
+func New(cfg Config) (*Client, error) {
+	base, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute http(s) URL", cfg.BaseURL)
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		httpClient = &http.Client{Timeout: timeout}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(base.String(), "/"),
+		httpClient: httpClient,
+	}, nil
+}
+
+// Predict calls POST /api/v1/predict. Errors reported by the engine are *APIError values that
+// match the sentinel for their code, e.g. errors.Is(err, ErrModelNotFound).
+func (c *Client) Predict(ctx context.Context, req PredictRequest) (PredictResponse, error) {
+	var resp PredictResponse
+	body, err := predictRequestBody(req)
+	if err != nil {
+		return resp, err
+	}
+	err = c.post(ctx, "/api/v1/predict", body, &resp)
+	return resp, err
+}
+
+// predictRequestBody encodes req for the engine. The engine rejects target_timestamp combined
+// with hour or day_of_week even when they are zero, so those are left out when it is set.
+func predictRequestBody(req PredictRequest) (any, error) {
+	if req.TargetTimestamp == "" {
+		return req, nil
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	delete(body, "hour")
+	delete(body, "day_of_week")
+	return body, nil
+}
+
+// GetRecommendations calls POST /api/v1/recommendations. Errors are reported as for Predict.
+func (c *Client) GetRecommendations(ctx context.Context, req GetRecommendationsRequest) (GetRecommendationsResponse, error) {
+	var resp GetRecommendationsResponse
+	err := c.post(ctx, "/api/v1/recommendations", req, &resp)
+	return resp, err
+}
+
+// post sends body as JSON to path and decodes a successful response into out
+func (c *Client) post(ctx context.Context, path string, body, out any) (err error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close response from %s: %w", path, closeErr)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// newAPIError builds the error for a non-2xx response. Bodies that are not the engine's error
+// shape, e.g. from a proxy, keep the status with an empty code.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	apiErr.Message = http.StatusText(resp.StatusCode)
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	if err != nil {
+		return apiErr
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Code != "" {
+		apiErr.Code = errResp.Code
+		apiErr.Message = errResp.Error
+		apiErr.Details = errResp.Details
+		return apiErr
+	}
+	apiErr.Details = strings.TrimSpace(string(body))
+	return apiErr
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, given either as delay seconds or
+// as an HTTP date, and 0 when it is missing, malformed or not in the future
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+	v1 "github.com/KubeHeal/openshift-coordination-engine/pkg/api/v1"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(Config{BaseURL: server.URL + "/"})
+	require.NoError(t, err)
+	return c
+}
+
+func TestNew(t *testing.T) {
+	for _, baseURL := range []string{"", "coordination-engine:8080", "ftp://engine", "http://"} {
+		_, err := New(Config{BaseURL: baseURL})
+		assert.Error(t, err, baseURL)
+	}
+
+	c, err := New(Config{BaseURL: "http://engine:8080/"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://engine:8080", c.baseURL)
+	assert.Equal(t, DefaultTimeout, c.httpClient.Timeout)
+}
+
+func TestClient_Predict(t *testing.T) {
+	var got types.PredictRequest
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/predict", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(types.PredictResponse{
+			Status:      "success",
+			Scope:       "deployment",
+			Predictions: types.PredictionValues{CPUPercent: 42, MemoryPercent: 58},
+		}))
+	}))
+
+	resp, err := c.Predict(context.Background(), PredictRequest{Hour: 15, DayOfWeek: 3, Namespace: "payments", Deployment: "api"})
+	require.NoError(t, err)
+	assert.Equal(t, "deployment", resp.Scope)
+	assert.InDelta(t, 42.0, resp.Predictions.CPUPercent, 0.001)
+	assert.Equal(t, 15, got.Hour)
+	assert.Equal(t, "api", got.Deployment)
+}
+
+func TestClient_GetRecommendations(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/recommendations", r.URL.Path)
+		assert.NoError(t, json.NewEncoder(w).Encode(types.GetRecommendationsResponse{
+			Status:               "success",
+			Recommendations:      []types.Recommendation{{ID: "rec-1"}},
+			TotalRecommendations: 1,
+		}))
+	}))
+
+	resp, err := c.GetRecommendations(context.Background(), GetRecommendationsRequest{Timeframe: "1h"})
+	require.NoError(t, err)
+	require.Len(t, resp.Recommendations, 1)
+	assert.Equal(t, "rec-1", resp.Recommendations[0].ID)
+}
+
+// TestClient_ServerErrors runs the engine's handlers so the mapping follows the real error bodies
+func TestClient_ServerErrors(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/predict", v1.NewPredictionHandler(nil, nil, log).HandlePredict)
+	mux.HandleFunc("/api/v1/recommendations", v1.NewRecommendationsHandler(nil, nil, nil, log).GetRecommendations)
+	c := newTestClient(t, mux)
+
+	_, err := c.Predict(context.Background(), PredictRequest{Hour: 25})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, types.ErrCodeInvalidRequest, apiErr.Code)
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+
+	_, err = c.Predict(context.Background(), PredictRequest{Hour: 15, Namespace: "payments"})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.ErrorIs(t, err, ErrKServeUnavailable)
+
+	// A target timestamp alone passes validation, so the request reaches the KServe check
+	target := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	_, err = c.Predict(context.Background(), PredictRequest{TargetTimestamp: target, Namespace: "payments"})
+	assert.ErrorIs(t, err, ErrKServeUnavailable)
+
+	_, err = c.GetRecommendations(context.Background(), GetRecommendationsRequest{Timeframe: "2h"})
+	assert.ErrorIs(t, err, ErrInvalidTimeframe)
+	assert.False(t, errors.Is(err, ErrInvalidRequest))
+}
+
+func TestClient_NonEngineErrors(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/predict":
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(types.ErrorResponse{
+				Status: "error",
+				Error:  "all prediction slots are busy",
+				Code:   types.ErrCodePredictionCapacityExceeded,
+			})
+		default:
+			http.Error(w, "upstream connect error", http.StatusBadGateway)
+		}
+	}))
+
+	_, err := c.Predict(context.Background(), PredictRequest{Hour: 15})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.ErrorIs(t, err, ErrPredictionCapacityExceeded)
+	assert.Equal(t, 2*time.Second, apiErr.RetryAfter)
+
+	_, err = c.GetRecommendations(context.Background(), GetRecommendationsRequest{})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Empty(t, apiErr.Code)
+	assert.Equal(t, "upstream connect error", apiErr.Details)
+	assert.NoError(t, errors.Unwrap(err), "unknown codes match no sentinel")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2", now))
+	assert.Equal(t, 2*time.Second, parseRetryAfter(" 2 ", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	for _, value := range []string{"", "0", "-5", "1.5", "2s", "1m", "soon", now.Add(-time.Minute).Format(http.TimeFormat)} {
+		assert.Zero(t, parseRetryAfter(value, now), value)
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+)
+
+// Sentinel errors matched by an *APIError with the corresponding code
+var (
+	ErrInvalidRequest             = errors.New("invalid request")
+	ErrPrometheusUnavailable      = errors.New("prometheus unavailable")
+	ErrKServeUnavailable          = errors.New("kserve unavailable")
+	ErrModelNotFound              = errors.New("model not found")
+	ErrPredictionFailed           = errors.New("prediction failed")
+	ErrFeaturesUnavailable        = errors.New("feature engineering unavailable")
+	ErrPredictionCapacityExceeded = errors.New("prediction capacity exceeded")
+	ErrInvalidTimeframe           = errors.New("invalid timeframe")
+	ErrInvalidConfidence          = errors.New("invalid confidence")
+	ErrMLUnavailable              = errors.New("ml unavailable")
+	ErrInternal                   = errors.New("internal error")
+)
+
+// codeErrors maps the engine's error codes to their sentinel errors
+var codeErrors = map[string]error{
+	types.ErrCodeInvalidRequest:             ErrInvalidRequest,
+	types.ErrCodePrometheusUnavailable:      ErrPrometheusUnavailable,
+	types.ErrCodeKServeUnavailable:          ErrKServeUnavailable,
+	types.ErrCodeModelNotFound:              ErrModelNotFound,
+	types.ErrCodePredictionFailed:           ErrPredictionFailed,
+	types.ErrCodeFeaturesUnavailable:        ErrFeaturesUnavailable,
+	types.ErrCodePredictionCapacityExceeded: ErrPredictionCapacityExceeded,
+	types.ErrCodeInvalidTimeframe:           ErrInvalidTimeframe,
+	types.ErrCodeInvalidConfidence:          ErrInvalidConfidence,
+	types.ErrCodeMLUnavailable:              ErrMLUnavailable,
+	types.ErrCodeInternalError:              ErrInternal,
+}
+
+// APIError is an error response from the engine
+type APIError struct {
+	StatusCode int
+	Code       string // Structured error code, e.g. MODEL_NOT_FOUND; empty when the body had none
+	Message    string
+	Details    string
+
+	// RetryAfter is the server's Retry-After delay, set on PREDICTION_CAPACITY_EXCEEDED
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("coordination engine returned %d", e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Details != "" {
+		msg += " (" + e.Details + ")"
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error for the code, so errors.Is matches it; nil for unknown codes
+func (e *APIError) Unwrap() error {
+	return codeErrors[e.Code]
+}
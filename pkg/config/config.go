@@ -3,10 +3,17 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	v1 "github.com/KubeHeal/openshift-coordination-engine/pkg/api/v1"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/features"
+	"github.com/KubeHeal/openshift-coordination-engine/pkg/models"
 )
 
 // Config holds all application configuration
@@ -27,16 +34,42 @@ type Config struct {
 	// Prometheus configuration for metrics querying
 	PrometheusURL string `json:"prometheus_url,omitempty"` // URL for Prometheus API queries
 
+	// Prometheus failover: further query endpoints tried in order when one is unreachable, e.g.
+	// the other Thanos Query replicas, and how long a failed endpoint is skipped
+	PrometheusFallbackURLs     []string      `json:"prometheus_fallback_urls,omitempty"`
+	PrometheusEndpointCooldown time.Duration `json:"prometheus_endpoint_cooldown"`
+
+	// Prometheus TLS and authentication (secured Thanos/Prometheus endpoints)
+	PrometheusCAFile             string `json:"prometheus_ca_file,omitempty"`           // PEM root CA bundle for server verification
+	PrometheusInsecureSkipVerify bool   `json:"prometheus_insecure_skip_verify"`        // Skip verification when no CA file is set
+	PrometheusBearerTokenFile    string `json:"prometheus_bearer_token_file,omitempty"` // Re-read per request to follow SA token rotation
+
+	// How long rolling means are shared between prediction and recommendation requests
+	// (0 = only concurrent requests share a query)
+	MetricsSnapshotTTL time.Duration `json:"metrics_snapshot_ttl"`
+
 	// KServe Integration (ADR-039)
 	KServe KServeConfig `json:"kserve"`
 
 	// HTTP client configuration
 	HTTPTimeout time.Duration `json:"http_timeout"`
 
+	// HTTP server configuration. Zero timeouts mean no timeout, as with net/http;
+	// a zero body limit disables the cap.
+	ServerReadTimeout   time.Duration `json:"server_read_timeout"`
+	ServerWriteTimeout  time.Duration `json:"server_write_timeout"`
+	MaxRequestBodyBytes int64         `json:"max_request_body_bytes"`
+
 	// Feature flags
 	EnableCORS      bool     `json:"enable_cors"`
 	CORSAllowOrigin []string `json:"cors_allow_origin,omitempty"`
 
+	// CORS details; empty method and header lists use the middleware defaults
+	CORSAllowMethods     []string      `json:"cors_allow_methods,omitempty"`
+	CORSAllowHeaders     []string      `json:"cors_allow_headers,omitempty"`
+	CORSAllowCredentials bool          `json:"cors_allow_credentials"`
+	CORSMaxAge           time.Duration `json:"cors_max_age"`
+
 	// Performance tuning
 	KubernetesQPS   float32 `json:"kubernetes_qps"`
 	KubernetesBurst int     `json:"kubernetes_burst"`
@@ -45,8 +78,86 @@ type Config struct {
 	DataDir               string `json:"data_dir,omitempty"`                // Directory for persistent incident storage
 	IncidentRetentionDays int    `json:"incident_retention_days,omitempty"` // Days to retain resolved incidents (0 = no cleanup)
 
+	// Days to retain resolved incidents per severity, overriding IncidentRetentionDays (0 = keep forever)
+	IncidentRetentionDaysBySeverity map[string]int `json:"incident_retention_days_by_severity,omitempty"`
+
+	// Incident severity escalation on recurrence
+	IncidentEscalation IncidentEscalationConfig `json:"incident_escalation"`
+
+	// Automatic resolution of incidents that stop recurring
+	IncidentAutoResolve IncidentAutoResolveConfig `json:"incident_auto_resolve"`
+
+	// Webhook notifications for incident changes
+	IncidentWebhook IncidentWebhookConfig `json:"incident_webhook"`
+
+	// Ticket creation from recommendations in an external ticketing system
+	RecommendationTicket RecommendationTicketConfig `json:"recommendation_ticket"`
+
+	// Background (write-behind) persistence of the incident store
+	IncidentWriteBehind IncidentWriteBehindConfig `json:"incident_write_behind"`
+
+	// Bulk deletion of all incidents via DELETE /api/v1/incidents
+	IncidentPurge IncidentPurgeConfig `json:"incident_purge"`
+
+	// Recency weighting for history-based recommendations
+	RecommendationHistory RecommendationHistoryConfig `json:"recommendation_history"`
+
+	// Defer recommendations while a MachineConfigPool is updating
+	RecommendationMCOGating bool `json:"recommendation_mco_gating"`
+
+	// State transitions kept per MachineConfigPool for instability checks (0 = integrations.DefaultPoolHistorySize)
+	MCOPoolHistorySize int `json:"mco_pool_history_size"`
+
+	// How often every MachineConfigPool's status is polled into the history (0 = only when
+	// pool status is requested)
+	MCOPoolHistoryPollInterval time.Duration `json:"mco_pool_history_poll_interval"`
+
+	// How long an acknowledgement suppresses a recommendation when the request gives no duration (0 = 24h)
+	RecommendationAckDuration time.Duration `json:"recommendation_ack_duration"`
+
+	// Most recommendations returned per response, most important first (0 = unlimited)
+	RecommendationMaxCount int `json:"recommendation_max_count"`
+
+	// Instances per KServe call and KServe calls at once when recommendations predict many
+	// namespaces (0 = v1.DefaultMLBatchSize and v1.DefaultMLConcurrency)
+	RecommendationMLBatchSize   int `json:"recommendation_ml_batch_size"`
+	RecommendationMLConcurrency int `json:"recommendation_ml_concurrency"`
+
+	// Lowest confidence reported for ML recommendations; confidences derived from model scores
+	// or metrics below it are raised to it (0.0-1.0)
+	RecommendationMLConfidenceFloor float64 `json:"recommendation_ml_confidence_floor"`
+
+	// How far past the request time ML recommendations are predicted, per timeframe
+	// ("1h", "6h" and "24h"); each horizon is within its timeframe (empty = halfway into each)
+	RecommendationPredictionHorizons map[string]time.Duration `json:"recommendation_prediction_horizons"`
+
 	// Feature Engineering (Issue #54, ADR-016)
 	FeatureEngineering FeatureEngineeringConfig `json:"feature_engineering"`
+
+	// Prediction response caching and ETags
+	PredictionCache PredictionCacheConfig `json:"prediction_cache"`
+
+	// Per-scope baselines learned from Prometheus, used when it becomes unavailable
+	PredictionBaseline PredictionBaselineConfig `json:"prediction_baseline"`
+
+	// Existence checks for prediction targets
+	PredictionTargetValidation PredictionTargetValidationConfig `json:"prediction_target_validation"`
+
+	// Recording of served predictions for trend analysis
+	PredictionHistory PredictionHistoryConfig `json:"prediction_history"`
+
+	// Bound on concurrent feature-engineered predictions
+	PredictionConcurrency PredictionConcurrencyConfig `json:"prediction_concurrency"`
+
+	// Heuristic predictions served when KServe is unreachable
+	PredictionDegradedMode PredictionDegradedModeConfig `json:"prediction_degraded_mode"`
+
+	// Incidents opened from predictions that cross critical thresholds
+	PredictedIncidents PredictedIncidentsConfig `json:"predicted_incidents"`
+
+	// PredictionMetricAggregation is the statistic of a scope's current CPU and memory that
+	// predictions start from when the request sets none: mean, p95 or max over the last hour
+	PredictionMetricAggregation string `json:"prediction_metric_aggregation"`
 }
 
 // FeatureEngineeringConfig holds configuration for ML feature engineering (Issue #54)
@@ -60,12 +171,283 @@ type FeatureEngineeringConfig struct {
 	// Default: 24 hours (matches model training)
 	LookbackHours int `json:"lookback_hours"`
 
+	// MaxLookbackHours caps LookbackHours; larger values are clamped with a warning
+	// Default: 72 hours
+	MaxLookbackHours int `json:"max_lookback_hours"`
+
 	// ExpectedFeatureCount is the number of features the model expects.
 	// If set (> 0), the system will log a warning if the generated count doesn't match.
 	// This helps detect feature engineering mismatches early.
 	// Default: 0 (validation disabled)
 	// Set to the model's StandardScaler feature count to enable validation.
 	ExpectedFeatureCount int `json:"expected_feature_count"`
+
+	// BaseMetrics selects the base metrics the model was trained on, e.g. cpu_usage,memory_usage,disk_usage
+	// on clusters without node network metrics. A subset changes the feature count, so it
+	// requires ExpectedFeatureCount. Names are checked when the feature builder is created.
+	// Default: empty (all five base metrics)
+	BaseMetrics []string `json:"base_metrics,omitempty"`
+
+	// TimeFeatures selects and orders the time-based features per timestep
+	// (e.g. hour,day_of_week,quarter,week_of_year). Names are checked when the feature builder
+	// is created. Default: empty (the notebook's six time features)
+	TimeFeatures []string `json:"time_features,omitempty"`
+
+	// LogQueries logs each PromQL query the feature builder executes, with its scope, point
+	// count and value, at info level. Very verbose; enable only while debugging.
+	// Default: false
+	LogQueries bool `json:"log_queries"`
+
+	// LogVectorStats logs the min, max, mean and defaulted fraction of every built feature
+	// vector, plus each base metric's spread, at info level (one entry per build) so feature
+	// drift can be monitored.
+	// Default: false
+	LogVectorStats bool `json:"log_vector_stats"`
+
+	// ResampleRule is the bucket width metrics are averaged into before lags and rolling
+	// statistics are computed, matching the training pipeline's resample rule. Must be at
+	// least 1m and divide an hour evenly; 0 uses point queries instead.
+	// Default: 1h
+	ResampleRule time.Duration `json:"resample_rule"`
+
+	// ClusterAggregation selects how cluster-scope predictions combine container metrics:
+	// "mean" averages every container, "top_namespaces" averages the ClusterTopNamespaces
+	// busiest namespaces and "weighted" weights each namespace by its own load, so cluster
+	// predictions reflect hot spots rather than a flat mean.
+	// Default: mean
+	ClusterAggregation string `json:"cluster_aggregation"`
+
+	// ClusterTopNamespaces is the number of namespaces the "top_namespaces" strategy averages.
+	// Default: 5
+	ClusterTopNamespaces int `json:"cluster_top_namespaces"`
+
+	// BusinessHoursStart and BusinessHoursEnd bound the is_business_hours time feature:
+	// hours h with start <= h < end on a business day. Match the training pipeline's definition.
+	// Default: 9 and 17
+	BusinessHoursStart int `json:"business_hours_start"`
+	BusinessHoursEnd   int `json:"business_hours_end"`
+
+	// BusinessDays names the days that can have business hours (e.g. sun,mon,tue,wed,thu).
+	// Day names are checked when the feature builder is created.
+	// Default: empty (every day not in WeekendDays)
+	BusinessDays []string `json:"business_days,omitempty"`
+
+	// WeekendDays names the days the is_weekend time feature is 1 on (e.g. friday,saturday).
+	// Default: empty (saturday,sunday)
+	WeekendDays []string `json:"weekend_days,omitempty"`
+
+	// LagPeriods are the lags in hours emitted per metric, in order; diff and pct_change
+	// compare against the first. Match the model's training.
+	// Default: empty (1,2,3,6,12,24)
+	LagPeriods []int `json:"lag_periods,omitempty"`
+
+	// RollingWindows are the rolling statistic windows in hours emitted per metric.
+	// Default: empty (3,6,12,24)
+	RollingWindows []int `json:"rolling_windows,omitempty"`
+
+	// ScalerFile is a JSON file with the training StandardScaler's parameters
+	// ({"mean": [...], "scale": [...]}). When set, feature vectors are standardized before
+	// they are sent, for model servers that do not apply the scaler themselves.
+	// Default: empty (raw feature values)
+	ScalerFile string `json:"scaler_file,omitempty"`
+
+	// FallbackPolicy decides what a prediction does when engineered features cannot be built:
+	// "lenient" predicts from the 5 raw metrics (and defaults for any that are missing),
+	// "strict" fails the request with PREDICTION_FAILED rather than serve a degraded vector.
+	// Default: lenient
+	FallbackPolicy string `json:"fallback_policy"`
+
+	// QueryStep is the resolution of the range queries behind rolling statistics (or, when
+	// resampling, the spacing of the samples in each bucket). Finer steps follow bursty or
+	// short-lived workloads more closely; coarser steps make long cluster windows cheaper.
+	// Must divide every rolling window (or the resample rule) evenly; checked when the
+	// feature builder is created. Requests may override it with query_step.
+	// Default: 0 (5m, or the smaller of 5m and the resample rule)
+	QueryStep time.Duration `json:"query_step"`
+
+	// MaxBuildDuration bounds the wall-clock time of one feature build. Once it passes, no
+	// further Prometheus queries are issued, the remaining features take their defaults and
+	// the prediction's data_quality is "partial".
+	// Default: 0 (unbounded)
+	MaxBuildDuration time.Duration `json:"max_build_duration"`
+}
+
+// IncidentEscalationConfig holds configuration for escalating incident severity when
+// the same Target/IssueType recurs within a window
+type IncidentEscalationConfig struct {
+	// Enabled turns on recurrence tracking and escalation in the incident store
+	Enabled bool `json:"enabled"`
+
+	// RecurrenceWindow is the sliding window for counting recurrences
+	RecurrenceWindow time.Duration `json:"recurrence_window"`
+
+	// Thresholds are occurrence counts within the window that bump severity one level
+	Thresholds []int `json:"thresholds"`
+}
+
+// IncidentAutoResolveConfig holds configuration for resolving active incidents whose
+// Target/IssueType has not been reported for a while
+type IncidentAutoResolveConfig struct {
+	// Enabled turns on the periodic auto-resolve task
+	Enabled bool `json:"enabled"`
+
+	// QuietPeriod is how long an incident must go unreported before it is resolved
+	QuietPeriod time.Duration `json:"quiet_period"`
+
+	// CheckInterval is how often stale incidents are looked for
+	CheckInterval time.Duration `json:"check_interval"`
+}
+
+// IncidentWriteBehindConfig holds configuration for flushing incident changes to DATA_DIR in the
+// background instead of on every change. Changes since the last flush are lost on a crash.
+type IncidentWriteBehindConfig struct {
+	// Enabled switches the incident store to write-behind persistence (requires DATA_DIR)
+	Enabled bool `json:"enabled"`
+
+	// FlushInterval is the base delay between flushes; each is jittered by ±10%
+	FlushInterval time.Duration `json:"flush_interval"`
+
+	// MaxPending flushes early once this many changes are unwritten (0 = interval only)
+	MaxPending int `json:"max_pending"`
+}
+
+// IncidentPurgeConfig holds configuration for the incident purge endpoint
+type IncidentPurgeConfig struct {
+	// Enabled registers DELETE /api/v1/incidents (requires TokenFile)
+	Enabled bool `json:"enabled"`
+
+	// TokenFile holds the secret a purge request must pass as its confirm parameter,
+	// re-read per request
+	TokenFile string `json:"token_file,omitempty"`
+}
+
+// IncidentWebhookConfig holds configuration for posting incident changes to a webhook
+type IncidentWebhookConfig struct {
+	// URL receives a JSON POST per incident event (empty = disabled)
+	URL string `json:"url,omitempty"`
+
+	// Timeout bounds each delivery attempt
+	Timeout time.Duration `json:"timeout"`
+
+	// MaxRetries is how many times a delivery failing with a network error, 429 or 5xx is retried
+	MaxRetries int `json:"max_retries"`
+
+	// Events limits delivery to these event types (empty = all)
+	Events []string `json:"events,omitempty"`
+}
+
+// RecommendationTicketConfig holds configuration for creating tickets from recommendations
+type RecommendationTicketConfig struct {
+	// URL receives a JSON POST per ticket (empty = disabled)
+	URL string `json:"url,omitempty"`
+
+	// Timeout bounds each ticket creation request
+	Timeout time.Duration `json:"timeout"`
+
+	// Format picks the preset payload fields: servicenow, jira or custom
+	Format string `json:"format"`
+
+	// FieldsFile is a JSON object of payload path to text/template, added to the preset's fields
+	FieldsFile string `json:"fields_file,omitempty"`
+
+	// AuthorizationFile holds the Authorization header value sent to the ticketing system
+	AuthorizationFile string `json:"authorization_file,omitempty"`
+
+	// ReferenceField is the response path of the created ticket's reference (empty = common ones)
+	ReferenceField string `json:"reference_field,omitempty"`
+}
+
+// RecommendationHistoryConfig controls how past incidents are weighted when building
+// historical recommendations
+type RecommendationHistoryConfig struct {
+	// HalfLife is the age at which an incident counts half as much as one happening now (0 = no decay)
+	HalfLife time.Duration `json:"half_life"`
+
+	// MaxAge excludes incidents older than this entirely (0 = no cutoff)
+	MaxAge time.Duration `json:"max_age"`
+
+	// ResolvedDiscount is the fraction by which resolved incidents count less than open ones,
+	// from 0 (no discount) to 1 (resolved incidents are ignored)
+	ResolvedDiscount float64 `json:"resolved_discount"`
+}
+
+// PredictionCacheConfig controls server-side caching of /api/v1/predict responses
+type PredictionCacheConfig struct {
+	// TTL is how long an identical request is answered from memory (0 = disabled)
+	TTL time.Duration `json:"ttl"`
+
+	// Bucket is the time granularity folded into ETags; a new bucket forces a fresh prediction.
+	// 0 uses the prediction handler's default.
+	Bucket time.Duration `json:"bucket"`
+}
+
+// PredictionBaselineConfig controls the per-scope exponential moving averages of CPU/memory
+// rolling means that replace the global defaults when Prometheus is unavailable
+type PredictionBaselineConfig struct {
+	// Alpha is the weight of each new observation, in (0, 1]. 0 uses the prediction handler's default.
+	Alpha float64 `json:"alpha"`
+
+	// MaxEntries bounds the number of scopes remembered; the least recently used is evicted.
+	// 0 uses the prediction handler's default.
+	MaxEntries int `json:"max_entries"`
+
+	// PersistInterval is how often baselines are saved to DATA_DIR, in addition to on shutdown.
+	// 0 saves only on shutdown. Ignored without DATA_DIR.
+	PersistInterval time.Duration `json:"persist_interval"`
+}
+
+// PredictionTargetValidationConfig controls checking that a prediction's namespace, deployment
+// and pod exist before predicting, so typos fail instead of returning defaulted metrics
+type PredictionTargetValidationConfig struct {
+	// Enabled turns the check on; off by default because it costs Kubernetes API calls
+	Enabled bool `json:"enabled"`
+
+	// CacheTTL is how long an existence result is reused. 0 uses the checker's default.
+	CacheTTL time.Duration `json:"cache_ttl"`
+}
+
+// PredictionHistoryConfig controls recording served predictions with their request parameters,
+// kept in DATA_DIR when set, so predictions can be compared with actual usage over time
+type PredictionHistoryConfig struct {
+	// Enabled turns recording on; off by default because every prediction adds a record
+	Enabled bool `json:"enabled"`
+
+	// MaxRecords bounds the predictions kept; the oldest are dropped first. 0 uses the store's default.
+	MaxRecords int `json:"max_records"`
+}
+
+// PredictionConcurrencyConfig limits how many predictions build engineered features at once,
+// so bursts cannot open more Prometheus and KServe connections than those backends tolerate
+type PredictionConcurrencyConfig struct {
+	// MaxConcurrent is the number of prediction slots (0 = unlimited)
+	MaxConcurrent int `json:"max_concurrent"`
+
+	// QueueTimeout is how long a request waits for a slot before a 503 with Retry-After
+	// (0 = reject immediately when all slots are busy)
+	QueueTimeout time.Duration `json:"queue_timeout"`
+}
+
+// PredictionDegradedModeConfig controls answering /api/v1/predict with a heuristic prediction,
+// derived from learned baselines and the target hour, when KServe cannot be reached
+type PredictionDegradedModeConfig struct {
+	// Enabled turns degraded mode on; off by default so clients see KServe outages as 503s
+	Enabled bool `json:"enabled"`
+}
+
+// PredictedIncidentsConfig controls opening an incident, labeled source=predicted, when a served
+// prediction exceeds a CPU or memory threshold with enough confidence
+type PredictedIncidentsConfig struct {
+	// Enabled turns predicted incidents on; off by default so predictions have no side effects
+	Enabled bool `json:"enabled"`
+
+	// CPUThreshold and MemoryThreshold are the predicted utilization percentages (0-100) at
+	// or above which an incident is opened
+	CPUThreshold    float64 `json:"cpu_threshold"`
+	MemoryThreshold float64 `json:"memory_threshold"`
+
+	// MinConfidence is the lowest prediction confidence (0-1) that opens an incident; a target
+	// with an active predicted incident opens no other
+	MinConfidence float64 `json:"min_confidence"`
 }
 
 // KServeConfig holds configuration for KServe integration (ADR-039, ADR-040)
@@ -90,6 +472,57 @@ type KServeConfig struct {
 
 	// Timeout for KServe API calls
 	Timeout time.Duration `json:"timeout"`
+
+	// ModelTimeouts overrides Timeout for individual models, e.g. a slow forecast model, on every
+	// call to them: predictions, recommendations, anomaly analysis and detect requests. Like
+	// Timeout, each must not exceed ServerWriteTimeout, or the response could not be written.
+	ModelTimeouts map[string]time.Duration `json:"model_timeouts,omitempty"`
+
+	// Regression configures models that return plain positional regression vectors
+	Regression KServeRegressionConfig `json:"regression"`
+
+	// Forecast names the metric keys read from "forecast" model responses
+	Forecast KServeForecastConfig `json:"forecast"`
+
+	// DebugRawResponse lets /api/v1/predict requests set debug_raw_response to receive the
+	// model's response payload. Keep disabled in production.
+	// Default: false
+	DebugRawResponse bool `json:"debug_raw_response"`
+
+	// ReachabilityTTL is how long a probe of a model's predictor is reused by predictions.
+	// 0 disables probing: predictions only check that the model is registered.
+	// Default: 10s
+	ReachabilityTTL time.Duration `json:"reachability_ttl"`
+}
+
+// KServeForecastConfig lists the keys of a forecast response's predictions map holding the
+// CPU and memory forecasts. The first key present is used, so aliases can be listed.
+type KServeForecastConfig struct {
+	CPUKeys    []string `json:"cpu_keys"`
+	MemoryKeys []string `json:"memory_keys"`
+
+	// Step is the time between consecutive forecast values, used to date threshold crossings.
+	// 0 uses the prediction handler's default.
+	Step time.Duration `json:"step"`
+
+	// ThresholdPercent is the utilization whose first crossing predictions report in
+	// time_to_threshold, unless a request sets its own. 0 uses the prediction handler's default.
+	ThresholdPercent float64 `json:"threshold_percent"`
+}
+
+// KServeRegressionConfig maps positional regression model outputs to CPU/memory percentages
+type KServeRegressionConfig struct {
+	// Models lists model names whose responses are interpreted as regression vectors
+	Models []string `json:"models,omitempty"`
+
+	// CPUIndex is the output position of the CPU prediction
+	CPUIndex int `json:"cpu_index"`
+
+	// MemoryIndex is the output position of the memory prediction
+	MemoryIndex int `json:"memory_index"`
+
+	// Scale converts raw outputs to percentages (1 = already percent, 100 = 0-1 fractions)
+	Scale float64 `json:"scale"`
 }
 
 // KServeServices holds the names of KServe InferenceServices (legacy, for backward compatibility)
@@ -168,26 +601,169 @@ const (
 	DefaultKubernetesBurst = 100
 	DefaultEnableCORS      = false
 
+	// CORSMaxAge default - how long browsers may cache a preflight result
+	DefaultCORSMaxAge = time.Hour
+
+	// HTTP server defaults
+	DefaultServerReadTimeout   = 15 * time.Second
+	DefaultServerWriteTimeout  = 15 * time.Second
+	DefaultMaxRequestBodyBytes = 16 << 10 // 16 KiB; API request bodies are small JSON documents
+
 	// Prometheus defaults - empty means disabled
 	// In OpenShift, typically: https://prometheus-k8s.openshift-monitoring.svc:9091
 	DefaultPrometheusURL = ""
 
+	// A failed Prometheus endpoint is skipped this long before it is tried again
+	DefaultPrometheusEndpointCooldown = 30 * time.Second
+
+	// Prometheus TLS/auth defaults preserve the in-cluster behavior
+	DefaultPrometheusInsecureSkipVerify = true
+	DefaultPrometheusBearerTokenFile    = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	DefaultMetricsSnapshotTTL           = 10 * time.Second
+
 	// KServe defaults (ADR-039)
 	DefaultKServeEnabled       = true
 	DefaultKServeNamespace     = "self-healing-platform"
 	DefaultKServeTimeout       = 10 * time.Second
 	DefaultKServePredictorPort = 8080 // KServe predictors in RawDeployment mode listen on 8080
 
+	// Predictor probes are reused briefly so the hot path rarely pays for them
+	DefaultKServeReachabilityTTL = 10 * time.Second
+
+	// KServe regression output defaults: [cpu_percent, memory_percent]
+	DefaultKServeRegressionCPUIndex    = 0
+	DefaultKServeRegressionMemoryIndex = 1
+	DefaultKServeRegressionScale       = 1.0
+
+	// Forecast response keys of the predictive-analytics model
+	DefaultKServeForecastCPUKey    = "cpu_usage"
+	DefaultKServeForecastMemoryKey = "memory_usage"
+
+	// Forecasts are hourly; time_to_threshold looks for the first step at 90% utilization
+	DefaultKServeForecastStep             = time.Hour
+	DefaultKServeForecastThresholdPercent = 90.0
+
 	// Incident storage defaults (ADR-014)
 	DefaultDataDir               = "" // Empty means in-memory only
 	DefaultIncidentRetentionDays = 90 // 90 days (PCI-DSS, SOC2, HIPAA compliance)
 
+	// Incident escalation defaults - disabled unless explicitly enabled
+	DefaultIncidentEscalationEnabled          = false
+	DefaultIncidentEscalationRecurrenceWindow = 1 * time.Hour
+
+	// Incident auto-resolve defaults - disabled unless explicitly enabled
+	DefaultIncidentAutoResolveEnabled       = false
+	DefaultIncidentAutoResolveQuietPeriod   = 24 * time.Hour
+	DefaultIncidentAutoResolveCheckInterval = 15 * time.Minute
+
+	// Incident webhook defaults - disabled until a URL is set
+	DefaultIncidentWebhookTimeout    = 5 * time.Second
+	DefaultIncidentWebhookMaxRetries = 3
+
+	// Recommendation ticket defaults - disabled until a URL is set
+	DefaultRecommendationTicketTimeout = 10 * time.Second
+	DefaultRecommendationTicketFormat  = "custom"
+
+	// Incident write-behind defaults - synchronous writes unless explicitly enabled
+	DefaultIncidentWriteBehindEnabled       = false
+	DefaultIncidentWriteBehindFlushInterval = 5 * time.Second
+	DefaultIncidentWriteBehindMaxPending    = 1000
+
+	// Recommendation history defaults - owned by the recommendations handler
+	DefaultRecommendationHistoryHalfLife         = v1.DefaultHistoryHalfLife
+	DefaultRecommendationHistoryMaxAge           = v1.DefaultHistoryMaxAge
+	DefaultRecommendationHistoryResolvedDiscount = v1.DefaultHistoryResolvedDiscount
+
+	// MCO gating is off by default; MachineConfigPools only exist on OpenShift
+	DefaultRecommendationMCOGating = false
+
+	// Acknowledged recommendations stay hidden for a day unless the request says otherwise
+	DefaultRecommendationAckDuration = 24 * time.Hour
+
+	// Recommendation responses are cut to the 100 most important entries
+	DefaultRecommendationMaxCount = 100
+
+	// ML predictions for many namespaces share KServe calls of up to 32 instances, 4 at a time
+	DefaultRecommendationMLBatchSize   = v1.DefaultMLBatchSize
+	DefaultRecommendationMLConcurrency = v1.DefaultMLConcurrency
+
+	// ML recommendation confidence is never reported below 0.5, the lowest score-derived value
+	DefaultRecommendationMLConfidenceFloor = v1.DefaultMLConfidenceFloor
+
 	// Feature engineering defaults (Issue #54, ADR-016)
 	DefaultFeatureEngineeringEnabled              = true // Enable by default to fix Issue #54
 	DefaultFeatureEngineeringLookbackHours        = 24   // 24-hour lookback matches model training
+	DefaultFeatureEngineeringMaxLookbackHours     = 72   // Each lookback hour adds 136 features and more Prometheus queries
 	DefaultFeatureEngineeringExpectedFeatureCount = 0    // 0 = disable validation, set to model's expected count to enable
+
+	// Hourly buckets match the training pipeline's resample rule
+	DefaultFeatureEngineeringResampleRule = time.Hour
+
+	// Cluster-scope predictions average every container unless a hot-spot strategy is chosen
+	DefaultFeatureEngineeringClusterAggregation   = features.ClusterAggregationMean
+	DefaultFeatureEngineeringClusterTopNamespaces = features.DefaultClusterTopNamespaces
+
+	// Business hours of the training notebook's is_business_hours feature
+	DefaultFeatureEngineeringBusinessHoursStart = features.DefaultBusinessHoursStart
+	DefaultFeatureEngineeringBusinessHoursEnd   = features.DefaultBusinessHoursEnd
+
+	// Failed feature builds fall back to raw metrics, keeping predictions available
+	DefaultFeatureEngineeringFallbackPolicy = v1.FallbackPolicyLenient
+
+	// Prediction cache defaults - predictions for a target time change slowly
+	DefaultPredictionCacheTTL    = 30 * time.Second
+	DefaultPredictionCacheBucket = 5 * time.Minute
+
+	// Prediction baseline defaults - an observation's weight halves after about three updates
+	DefaultPredictionBaselineAlpha           = v1.DefaultBaselineAlpha
+	DefaultPredictionBaselineMaxEntries      = v1.DefaultBaselineMaxEntries
+	DefaultPredictionBaselinePersistInterval = 5 * time.Minute
+
+	// Prediction target validation defaults
+	DefaultPredictionTargetValidationEnabled  = false
+	DefaultPredictionTargetValidationCacheTTL = 30 * time.Second
+
+	// Prediction history defaults
+	DefaultPredictionHistoryEnabled    = false
+	DefaultPredictionHistoryMaxRecords = 10000
+
+	// Prediction concurrency defaults
+	DefaultPredictionMaxConcurrent = 8
+	DefaultPredictionQueueTimeout  = 5 * time.Second
+
+	// Degraded mode defaults
+	DefaultPredictionDegradedModeEnabled = false
+
+	// Predictions start from the rolling mean unless configured otherwise
+	DefaultPredictionMetricAggregation = integrations.MetricAggregationMean
+
+	// Predicted incident defaults - only near-saturation predictions the model is sure about
+	DefaultPredictedIncidentsEnabled         = false
+	DefaultPredictedIncidentsCPUThreshold    = 95.0
+	DefaultPredictedIncidentsMemoryThreshold = 95.0
+	DefaultPredictedIncidentsMinConfidence   = 0.8
 )
 
+// DefaultIncidentEscalationThresholds escalates on the 3rd and 5th recurrence within the window
+var DefaultIncidentEscalationThresholds = []int{3, 5}
+
+// Valid recommendation ticket formats, matching integrations.TicketFieldPresets
+var validRecommendationTicketFormats = map[string]bool{
+	"servicenow": true,
+	"jira":       true,
+	"custom":     true,
+}
+
+// Valid incident webhook event filters, matching storage.IncidentEventTypes
+var validIncidentWebhookEvents = map[string]bool{
+	"created":        true,
+	"recurred":       true,
+	"escalated":      true,
+	"updated":        true,
+	"status_changed": true,
+	"deleted":        true,
+}
+
 // Valid log levels
 var validLogLevels = map[string]bool{
 	"debug": true,
@@ -200,24 +776,90 @@ var validLogLevels = map[string]bool{
 
 // Load loads configuration from environment variables with defaults
 func Load() (*Config, error) {
+	retentionDaysBySeverity, err := getEnvAsIntMap("INCIDENT_RETENTION_DAYS_BY_SEVERITY", nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	cfg := &Config{
-		Port:            getEnvAsInt("PORT", DefaultPort),
-		MetricsPort:     getEnvAsInt("METRICS_PORT", DefaultMetricsPort),
-		LogLevel:        getEnv("LOG_LEVEL", DefaultLogLevel),
-		Kubeconfig:      getEnv("KUBECONFIG", ""),
-		Namespace:       getEnv("NAMESPACE", DefaultNamespace),
-		MLServiceURL:    getEnv("ML_SERVICE_URL", DefaultMLServiceURL), // Deprecated
-		ArgocdAPIURL:    getEnv("ARGOCD_API_URL", ""),
-		PrometheusURL:   getEnv("PROMETHEUS_URL", DefaultPrometheusURL),
-		HTTPTimeout:     getEnvAsDuration("HTTP_TIMEOUT", DefaultHTTPTimeout),
-		EnableCORS:      getEnvAsBool("ENABLE_CORS", DefaultEnableCORS),
-		CORSAllowOrigin: getEnvAsSlice("CORS_ALLOW_ORIGIN", []string{"*"}),
-		KubernetesQPS:   getEnvAsFloat32("KUBERNETES_QPS", DefaultKubernetesQPS),
-		KubernetesBurst: getEnvAsInt("KUBERNETES_BURST", DefaultKubernetesBurst),
+		Port:                         getEnvAsInt("PORT", DefaultPort),
+		MetricsPort:                  getEnvAsInt("METRICS_PORT", DefaultMetricsPort),
+		LogLevel:                     getEnv("LOG_LEVEL", DefaultLogLevel),
+		Kubeconfig:                   getEnv("KUBECONFIG", ""),
+		Namespace:                    getEnv("NAMESPACE", DefaultNamespace),
+		MLServiceURL:                 getEnv("ML_SERVICE_URL", DefaultMLServiceURL), // Deprecated
+		ArgocdAPIURL:                 getEnv("ARGOCD_API_URL", ""),
+		PrometheusURL:                getEnv("PROMETHEUS_URL", DefaultPrometheusURL),
+		PrometheusFallbackURLs:       getEnvAsSlice("PROMETHEUS_FALLBACK_URLS", nil),
+		PrometheusEndpointCooldown:   getEnvAsDuration("PROMETHEUS_ENDPOINT_COOLDOWN", DefaultPrometheusEndpointCooldown),
+		PrometheusCAFile:             getEnv("PROMETHEUS_CA_FILE", ""),
+		PrometheusInsecureSkipVerify: getEnvAsBool("PROMETHEUS_INSECURE_SKIP_VERIFY", DefaultPrometheusInsecureSkipVerify),
+		PrometheusBearerTokenFile:    getEnv("PROMETHEUS_BEARER_TOKEN_FILE", DefaultPrometheusBearerTokenFile),
+		MetricsSnapshotTTL:           getEnvAsDuration("METRICS_SNAPSHOT_TTL", DefaultMetricsSnapshotTTL),
+		HTTPTimeout:                  getEnvAsDuration("HTTP_TIMEOUT", DefaultHTTPTimeout),
+		ServerReadTimeout:            getEnvAsDuration("SERVER_READ_TIMEOUT", DefaultServerReadTimeout),
+		ServerWriteTimeout:           getEnvAsDuration("SERVER_WRITE_TIMEOUT", DefaultServerWriteTimeout),
+		MaxRequestBodyBytes:          int64(getEnvAsInt("MAX_REQUEST_BODY_BYTES", DefaultMaxRequestBodyBytes)),
+		EnableCORS:                   getEnvAsBool("ENABLE_CORS", DefaultEnableCORS),
+		CORSAllowOrigin:              getEnvAsSlice("CORS_ALLOW_ORIGIN", []string{"*"}),
+		CORSAllowMethods:             getEnvAsSlice("CORS_ALLOW_METHODS", nil),
+		CORSAllowHeaders:             getEnvAsSlice("CORS_ALLOW_HEADERS", nil),
+		CORSAllowCredentials:         getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:                   getEnvAsDuration("CORS_MAX_AGE", DefaultCORSMaxAge),
+		KubernetesQPS:                getEnvAsFloat32("KUBERNETES_QPS", DefaultKubernetesQPS),
+		KubernetesBurst:              getEnvAsInt("KUBERNETES_BURST", DefaultKubernetesBurst),
 
 		// Incident storage configuration (ADR-014)
-		DataDir:               getEnv("DATA_DIR", DefaultDataDir),
-		IncidentRetentionDays: getEnvAsInt("INCIDENT_RETENTION_DAYS", DefaultIncidentRetentionDays),
+		DataDir:                         getEnv("DATA_DIR", DefaultDataDir),
+		IncidentRetentionDays:           getEnvAsInt("INCIDENT_RETENTION_DAYS", DefaultIncidentRetentionDays),
+		IncidentRetentionDaysBySeverity: retentionDaysBySeverity,
+		IncidentEscalation: IncidentEscalationConfig{
+			Enabled:          getEnvAsBool("INCIDENT_ESCALATION_ENABLED", DefaultIncidentEscalationEnabled),
+			RecurrenceWindow: getEnvAsDuration("INCIDENT_RECURRENCE_WINDOW", DefaultIncidentEscalationRecurrenceWindow),
+			Thresholds:       getEnvAsIntSlice("INCIDENT_ESCALATION_THRESHOLDS", DefaultIncidentEscalationThresholds),
+		},
+		IncidentAutoResolve: IncidentAutoResolveConfig{
+			Enabled:       getEnvAsBool("INCIDENT_AUTO_RESOLVE_ENABLED", DefaultIncidentAutoResolveEnabled),
+			QuietPeriod:   getEnvAsDuration("INCIDENT_AUTO_RESOLVE_QUIET_PERIOD", DefaultIncidentAutoResolveQuietPeriod),
+			CheckInterval: getEnvAsDuration("INCIDENT_AUTO_RESOLVE_INTERVAL", DefaultIncidentAutoResolveCheckInterval),
+		},
+		IncidentWebhook: IncidentWebhookConfig{
+			URL:        getEnv("INCIDENT_WEBHOOK_URL", ""),
+			Timeout:    getEnvAsDuration("INCIDENT_WEBHOOK_TIMEOUT", DefaultIncidentWebhookTimeout),
+			MaxRetries: getEnvAsInt("INCIDENT_WEBHOOK_MAX_RETRIES", DefaultIncidentWebhookMaxRetries),
+			Events:     getEnvAsSlice("INCIDENT_WEBHOOK_EVENTS", nil),
+		},
+		RecommendationTicket: RecommendationTicketConfig{
+			URL:               getEnv("RECOMMENDATION_TICKET_URL", ""),
+			Timeout:           getEnvAsDuration("RECOMMENDATION_TICKET_TIMEOUT", DefaultRecommendationTicketTimeout),
+			Format:            getEnv("RECOMMENDATION_TICKET_FORMAT", DefaultRecommendationTicketFormat),
+			FieldsFile:        getEnv("RECOMMENDATION_TICKET_FIELDS_FILE", ""),
+			AuthorizationFile: getEnv("RECOMMENDATION_TICKET_AUTHORIZATION_FILE", ""),
+			ReferenceField:    getEnv("RECOMMENDATION_TICKET_REFERENCE_FIELD", ""),
+		},
+		IncidentWriteBehind: IncidentWriteBehindConfig{
+			Enabled:       getEnvAsBool("INCIDENT_WRITE_BEHIND_ENABLED", DefaultIncidentWriteBehindEnabled),
+			FlushInterval: getEnvAsDuration("INCIDENT_WRITE_BEHIND_INTERVAL", DefaultIncidentWriteBehindFlushInterval),
+			MaxPending:    getEnvAsInt("INCIDENT_WRITE_BEHIND_MAX_PENDING", DefaultIncidentWriteBehindMaxPending),
+		},
+		IncidentPurge: IncidentPurgeConfig{
+			Enabled:   getEnvAsBool("INCIDENT_PURGE_ENABLED", false),
+			TokenFile: getEnv("INCIDENT_PURGE_TOKEN_FILE", ""),
+		},
+		RecommendationHistory: RecommendationHistoryConfig{
+			HalfLife:         getEnvAsDuration("RECOMMENDATION_HISTORY_HALF_LIFE", DefaultRecommendationHistoryHalfLife),
+			MaxAge:           getEnvAsDuration("RECOMMENDATION_HISTORY_MAX_AGE", DefaultRecommendationHistoryMaxAge),
+			ResolvedDiscount: getEnvAsFloat64("RECOMMENDATION_HISTORY_RESOLVED_DISCOUNT", DefaultRecommendationHistoryResolvedDiscount),
+		},
+		RecommendationMCOGating:          getEnvAsBool("RECOMMENDATION_MCO_GATING_ENABLED", DefaultRecommendationMCOGating),
+		MCOPoolHistorySize:               getEnvAsInt("MCO_POOL_HISTORY_SIZE", integrations.DefaultPoolHistorySize),
+		MCOPoolHistoryPollInterval:       getEnvAsDuration("MCO_POOL_HISTORY_POLL_INTERVAL", integrations.DefaultPoolHistoryPollInterval),
+		RecommendationPredictionHorizons: getEnvAsDurationMap("RECOMMENDATION_PREDICTION_HORIZONS", v1.DefaultPredictionHorizons()),
+		RecommendationAckDuration:        getEnvAsDuration("RECOMMENDATION_ACK_DURATION", DefaultRecommendationAckDuration),
+		RecommendationMaxCount:           getEnvAsInt("RECOMMENDATION_MAX_COUNT", DefaultRecommendationMaxCount),
+		RecommendationMLBatchSize:        getEnvAsInt("RECOMMENDATION_ML_BATCH_SIZE", DefaultRecommendationMLBatchSize),
+		RecommendationMLConcurrency:      getEnvAsInt("RECOMMENDATION_ML_CONCURRENCY", DefaultRecommendationMLConcurrency),
+		RecommendationMLConfidenceFloor:  getEnvAsFloat64("RECOMMENDATION_ML_CONFIDENCE_FLOOR", DefaultRecommendationMLConfidenceFloor),
 
 		// KServe configuration (ADR-039, ADR-040)
 		KServe: KServeConfig{
@@ -230,13 +872,85 @@ func Load() (*Config, error) {
 			},
 			DynamicServices: discoverKServeServicesFromEnv(),
 			Timeout:         getEnvAsDuration("KSERVE_TIMEOUT", DefaultKServeTimeout),
+			ModelTimeouts:   getEnvAsDurationMap("KSERVE_MODEL_TIMEOUTS", nil),
+			Regression: KServeRegressionConfig{
+				Models:      getEnvAsSlice("KSERVE_REGRESSION_MODELS", nil),
+				CPUIndex:    getEnvAsInt("KSERVE_REGRESSION_CPU_INDEX", DefaultKServeRegressionCPUIndex),
+				MemoryIndex: getEnvAsInt("KSERVE_REGRESSION_MEMORY_INDEX", DefaultKServeRegressionMemoryIndex),
+				Scale:       getEnvAsFloat64("KSERVE_REGRESSION_SCALE", DefaultKServeRegressionScale),
+			},
+			Forecast: KServeForecastConfig{
+				CPUKeys:          getEnvAsSlice("KSERVE_FORECAST_CPU_KEYS", []string{DefaultKServeForecastCPUKey}),
+				MemoryKeys:       getEnvAsSlice("KSERVE_FORECAST_MEMORY_KEYS", []string{DefaultKServeForecastMemoryKey}),
+				Step:             getEnvAsDuration("KSERVE_FORECAST_STEP", DefaultKServeForecastStep),
+				ThresholdPercent: getEnvAsFloat64("KSERVE_FORECAST_THRESHOLD_PERCENT", DefaultKServeForecastThresholdPercent),
+			},
+			DebugRawResponse: getEnvAsBool("KSERVE_DEBUG_RAW_RESPONSE", false),
+			ReachabilityTTL:  getEnvAsDuration("KSERVE_REACHABILITY_TTL", DefaultKServeReachabilityTTL),
 		},
 
 		// Feature engineering configuration (Issue #54, ADR-016)
 		FeatureEngineering: FeatureEngineeringConfig{
 			Enabled:              getEnvAsBool("ENABLE_FEATURE_ENGINEERING", DefaultFeatureEngineeringEnabled),
 			LookbackHours:        getEnvAsInt("FEATURE_ENGINEERING_LOOKBACK_HOURS", DefaultFeatureEngineeringLookbackHours),
+			MaxLookbackHours:     getEnvAsInt("FEATURE_ENGINEERING_MAX_LOOKBACK_HOURS", DefaultFeatureEngineeringMaxLookbackHours),
 			ExpectedFeatureCount: getEnvAsInt("FEATURE_ENGINEERING_EXPECTED_COUNT", DefaultFeatureEngineeringExpectedFeatureCount),
+			TimeFeatures:         getEnvAsSlice("FEATURE_ENGINEERING_TIME_FEATURES", nil),
+			BaseMetrics:          getEnvAsSlice("FEATURE_ENGINEERING_BASE_METRICS", nil),
+			LogQueries:           getEnvAsBool("FEATURE_ENGINEERING_LOG_QUERIES", false),
+			LogVectorStats:       getEnvAsBool("FEATURE_ENGINEERING_LOG_VECTOR_STATS", false),
+			ResampleRule:         getEnvAsDuration("FEATURE_ENGINEERING_RESAMPLE_RULE", DefaultFeatureEngineeringResampleRule),
+			ClusterAggregation:   getEnv("FEATURE_ENGINEERING_CLUSTER_AGGREGATION", DefaultFeatureEngineeringClusterAggregation),
+			ClusterTopNamespaces: getEnvAsInt("FEATURE_ENGINEERING_CLUSTER_TOP_NAMESPACES", DefaultFeatureEngineeringClusterTopNamespaces),
+			BusinessHoursStart:   getEnvAsInt("FEATURE_ENGINEERING_BUSINESS_HOURS_START", DefaultFeatureEngineeringBusinessHoursStart),
+			BusinessHoursEnd:     getEnvAsInt("FEATURE_ENGINEERING_BUSINESS_HOURS_END", DefaultFeatureEngineeringBusinessHoursEnd),
+			BusinessDays:         getEnvAsSlice("FEATURE_ENGINEERING_BUSINESS_DAYS", nil),
+			WeekendDays:          getEnvAsSlice("FEATURE_ENGINEERING_WEEKEND_DAYS", nil),
+			LagPeriods:           getEnvAsIntSlice("FEATURE_ENGINEERING_LAG_PERIODS", nil),
+			RollingWindows:       getEnvAsIntSlice("FEATURE_ENGINEERING_ROLLING_WINDOWS", nil),
+			ScalerFile:           getEnv("FEATURE_ENGINEERING_SCALER_FILE", ""),
+			FallbackPolicy:       getEnv("FEATURE_ENGINEERING_FALLBACK_POLICY", DefaultFeatureEngineeringFallbackPolicy),
+			QueryStep:            getEnvAsDuration("FEATURE_ENGINEERING_QUERY_STEP", 0),
+			MaxBuildDuration:     getEnvAsDuration("FEATURE_ENGINEERING_MAX_BUILD_DURATION", 0),
+		},
+
+		PredictionCache: PredictionCacheConfig{
+			TTL:    getEnvAsDuration("PREDICTION_CACHE_TTL", DefaultPredictionCacheTTL),
+			Bucket: getEnvAsDuration("PREDICTION_CACHE_BUCKET", DefaultPredictionCacheBucket),
+		},
+
+		PredictionBaseline: PredictionBaselineConfig{
+			Alpha:           getEnvAsFloat64("PREDICTION_BASELINE_ALPHA", DefaultPredictionBaselineAlpha),
+			MaxEntries:      getEnvAsInt("PREDICTION_BASELINE_MAX_ENTRIES", DefaultPredictionBaselineMaxEntries),
+			PersistInterval: getEnvAsDuration("PREDICTION_BASELINE_PERSIST_INTERVAL", DefaultPredictionBaselinePersistInterval),
+		},
+
+		PredictionTargetValidation: PredictionTargetValidationConfig{
+			Enabled:  getEnvAsBool("PREDICTION_TARGET_VALIDATION_ENABLED", DefaultPredictionTargetValidationEnabled),
+			CacheTTL: getEnvAsDuration("PREDICTION_TARGET_VALIDATION_CACHE_TTL", DefaultPredictionTargetValidationCacheTTL),
+		},
+
+		PredictionHistory: PredictionHistoryConfig{
+			Enabled:    getEnvAsBool("PREDICTION_HISTORY_ENABLED", DefaultPredictionHistoryEnabled),
+			MaxRecords: getEnvAsInt("PREDICTION_HISTORY_MAX_RECORDS", DefaultPredictionHistoryMaxRecords),
+		},
+
+		PredictionConcurrency: PredictionConcurrencyConfig{
+			MaxConcurrent: getEnvAsInt("PREDICTION_MAX_CONCURRENT", DefaultPredictionMaxConcurrent),
+			QueueTimeout:  getEnvAsDuration("PREDICTION_QUEUE_TIMEOUT", DefaultPredictionQueueTimeout),
+		},
+
+		PredictionDegradedMode: PredictionDegradedModeConfig{
+			Enabled: getEnvAsBool("PREDICTION_DEGRADED_MODE_ENABLED", DefaultPredictionDegradedModeEnabled),
+		},
+
+		PredictionMetricAggregation: getEnv("PREDICTION_METRIC_AGGREGATION", DefaultPredictionMetricAggregation),
+
+		PredictedIncidents: PredictedIncidentsConfig{
+			Enabled:         getEnvAsBool("PREDICTED_INCIDENTS_ENABLED", DefaultPredictedIncidentsEnabled),
+			CPUThreshold:    getEnvAsFloat64("PREDICTED_INCIDENTS_CPU_THRESHOLD", DefaultPredictedIncidentsCPUThreshold),
+			MemoryThreshold: getEnvAsFloat64("PREDICTED_INCIDENTS_MEMORY_THRESHOLD", DefaultPredictedIncidentsMemoryThreshold),
+			MinConfidence:   getEnvAsFloat64("PREDICTED_INCIDENTS_MIN_CONFIDENCE", DefaultPredictedIncidentsMinConfidence),
 		},
 	}
 
@@ -291,6 +1005,45 @@ func (c *Config) Validate() error {
 		if c.KServe.Timeout > 2*time.Minute {
 			errors = append(errors, fmt.Sprintf("kserve.timeout too long: %s (must be <= 2m)", c.KServe.Timeout))
 		}
+		if c.KServe.ReachabilityTTL < 0 {
+			errors = append(errors, fmt.Sprintf("kserve.reachability_ttl must not be negative: %s", c.KServe.ReachabilityTTL))
+		}
+		for model, timeout := range c.KServe.ModelTimeouts {
+			if timeout < 1*time.Second || timeout > 2*time.Minute {
+				errors = append(errors, fmt.Sprintf("kserve.model_timeouts[%s] out of range: %s (must be between 1s and 2m)", model, timeout))
+			}
+		}
+		// A model call outlasting the server write timeout could never deliver its response
+		if c.ServerWriteTimeout > 0 {
+			if c.KServe.Timeout > c.ServerWriteTimeout {
+				errors = append(errors, fmt.Sprintf("kserve.timeout %s must not exceed server_write_timeout %s", c.KServe.Timeout, c.ServerWriteTimeout))
+			}
+			for model, timeout := range c.KServe.ModelTimeouts {
+				if timeout > c.ServerWriteTimeout {
+					errors = append(errors, fmt.Sprintf("kserve.model_timeouts[%s] %s must not exceed server_write_timeout %s", model, timeout, c.ServerWriteTimeout))
+				}
+			}
+		}
+		if len(c.KServe.Regression.Models) > 0 {
+			if c.KServe.Regression.CPUIndex < 0 || c.KServe.Regression.MemoryIndex < 0 {
+				errors = append(errors, fmt.Sprintf("kserve.regression indexes must be non-negative: cpu=%d, memory=%d",
+					c.KServe.Regression.CPUIndex, c.KServe.Regression.MemoryIndex))
+			}
+			if c.KServe.Regression.Scale <= 0 {
+				errors = append(errors, fmt.Sprintf("kserve.regression.scale must be positive: %v", c.KServe.Regression.Scale))
+			}
+		}
+		if c.KServe.Forecast.Step < 0 {
+			errors = append(errors, fmt.Sprintf("kserve.forecast.step must not be negative: %s", c.KServe.Forecast.Step))
+		}
+		if c.KServe.Forecast.ThresholdPercent < 0 || c.KServe.Forecast.ThresholdPercent > 100 {
+			errors = append(errors, fmt.Sprintf("kserve.forecast.threshold_percent must be between 0 and 100: %v", c.KServe.Forecast.ThresholdPercent))
+		}
+		for _, key := range c.KServe.Forecast.CPUKeys {
+			if slices.Contains(c.KServe.Forecast.MemoryKeys, key) {
+				errors = append(errors, fmt.Sprintf("kserve.forecast key %q is listed for both cpu and memory", key))
+			}
+		}
 	} else if c.MLServiceURL != "" {
 		// Legacy ML_SERVICE_URL validation (deprecated but still supported)
 		if !strings.HasPrefix(c.MLServiceURL, "http://") && !strings.HasPrefix(c.MLServiceURL, "https://") {
@@ -312,6 +1065,17 @@ func (c *Config) Validate() error {
 			errors = append(errors, fmt.Sprintf("prometheus_url must start with http:// or https://: %s", c.PrometheusURL))
 		}
 	}
+	if len(c.PrometheusFallbackURLs) > 0 && c.PrometheusURL == "" {
+		errors = append(errors, "prometheus_fallback_urls requires prometheus_url")
+	}
+	for _, u := range c.PrometheusFallbackURLs {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			errors = append(errors, fmt.Sprintf("prometheus_fallback_urls must start with http:// or https://: %s", u))
+		}
+	}
+	if c.PrometheusEndpointCooldown < 0 {
+		errors = append(errors, fmt.Sprintf("prometheus_endpoint_cooldown must not be negative: %s", c.PrometheusEndpointCooldown))
+	}
 
 	// Validate HTTP timeout
 	if c.HTTPTimeout < 1*time.Second {
@@ -321,6 +1085,29 @@ func (c *Config) Validate() error {
 		errors = append(errors, fmt.Sprintf("http_timeout too long: %s (must be <= 5m)", c.HTTPTimeout))
 	}
 
+	if c.MetricsSnapshotTTL < 0 {
+		errors = append(errors, fmt.Sprintf("metrics_snapshot_ttl cannot be negative: %s", c.MetricsSnapshotTTL))
+	}
+
+	// Validate HTTP server limits
+	if c.ServerReadTimeout < 0 {
+		errors = append(errors, fmt.Sprintf("server_read_timeout cannot be negative: %s", c.ServerReadTimeout))
+	}
+	if c.ServerWriteTimeout < 0 {
+		errors = append(errors, fmt.Sprintf("server_write_timeout cannot be negative: %s", c.ServerWriteTimeout))
+	}
+	// Validate CORS; browsers reject a wildcard origin on credentialed responses
+	if c.EnableCORS && c.CORSAllowCredentials && slices.Contains(c.CORSAllowOrigin, "*") {
+		errors = append(errors, "cors_allow_credentials requires explicit cors_allow_origin entries, not \"*\"")
+	}
+	if c.CORSMaxAge < 0 {
+		errors = append(errors, fmt.Sprintf("cors_max_age must not be negative: %s", c.CORSMaxAge))
+	}
+
+	if c.MaxRequestBodyBytes < 0 {
+		errors = append(errors, fmt.Sprintf("max_request_body_bytes cannot be negative: %d", c.MaxRequestBodyBytes))
+	}
+
 	// Validate Kubernetes client settings
 	if c.KubernetesQPS <= 0 {
 		errors = append(errors, fmt.Sprintf("kubernetes_qps must be positive: %f", c.KubernetesQPS))
@@ -329,6 +1116,222 @@ func (c *Config) Validate() error {
 		errors = append(errors, fmt.Sprintf("kubernetes_burst must be positive: %d", c.KubernetesBurst))
 	}
 
+	// Validate per-severity incident retention
+	for _, severity := range slices.Sorted(maps.Keys(c.IncidentRetentionDaysBySeverity)) {
+		if !models.IsValidSeverity(severity) {
+			errors = append(errors, fmt.Sprintf("incident_retention_days_by_severity has invalid severity %q (must be one of %v)", severity, models.ValidSeverities()))
+		}
+		if days := c.IncidentRetentionDaysBySeverity[severity]; days < 0 {
+			errors = append(errors, fmt.Sprintf("incident_retention_days_by_severity[%s] must not be negative: %d", severity, days))
+		}
+	}
+
+	// Validate incident escalation settings
+	if c.IncidentEscalation.Enabled {
+		if c.IncidentEscalation.RecurrenceWindow <= 0 {
+			errors = append(errors, fmt.Sprintf("incident_escalation.recurrence_window must be positive: %s", c.IncidentEscalation.RecurrenceWindow))
+		}
+		for i, threshold := range c.IncidentEscalation.Thresholds {
+			if threshold < 2 {
+				errors = append(errors, fmt.Sprintf("incident_escalation.thresholds must be >= 2: %d", threshold))
+			} else if i > 0 && threshold <= c.IncidentEscalation.Thresholds[i-1] {
+				errors = append(errors, fmt.Sprintf("incident_escalation.thresholds must be strictly increasing: %v", c.IncidentEscalation.Thresholds))
+			}
+		}
+	}
+
+	// Validate incident auto-resolve settings
+	if c.IncidentAutoResolve.Enabled {
+		if c.IncidentAutoResolve.QuietPeriod <= 0 {
+			errors = append(errors, fmt.Sprintf("incident_auto_resolve.quiet_period must be positive: %s", c.IncidentAutoResolve.QuietPeriod))
+		}
+		if c.IncidentAutoResolve.CheckInterval <= 0 {
+			errors = append(errors, fmt.Sprintf("incident_auto_resolve.check_interval must be positive: %s", c.IncidentAutoResolve.CheckInterval))
+		}
+	}
+
+	// Validate incident webhook settings
+	if c.IncidentWebhook.URL != "" {
+		if !strings.HasPrefix(c.IncidentWebhook.URL, "http://") && !strings.HasPrefix(c.IncidentWebhook.URL, "https://") {
+			errors = append(errors, fmt.Sprintf("incident_webhook.url must be an http(s) URL: %s", c.IncidentWebhook.URL))
+		}
+		if c.IncidentWebhook.Timeout < 0 {
+			errors = append(errors, fmt.Sprintf("incident_webhook.timeout must not be negative: %s", c.IncidentWebhook.Timeout))
+		}
+		if c.IncidentWebhook.MaxRetries < 0 {
+			errors = append(errors, fmt.Sprintf("incident_webhook.max_retries must not be negative: %d", c.IncidentWebhook.MaxRetries))
+		}
+		for _, event := range c.IncidentWebhook.Events {
+			if !validIncidentWebhookEvents[event] {
+				errors = append(errors, fmt.Sprintf("incident_webhook.events contains unknown event: %s", event))
+			}
+		}
+	}
+
+	// Validate recommendation ticket settings
+	if c.RecommendationTicket.URL != "" {
+		if !strings.HasPrefix(c.RecommendationTicket.URL, "http://") && !strings.HasPrefix(c.RecommendationTicket.URL, "https://") {
+			errors = append(errors, fmt.Sprintf("recommendation_ticket.url must be an http(s) URL: %s", c.RecommendationTicket.URL))
+		}
+		if c.RecommendationTicket.Timeout < 0 {
+			errors = append(errors, fmt.Sprintf("recommendation_ticket.timeout must not be negative: %s", c.RecommendationTicket.Timeout))
+		}
+		if !validRecommendationTicketFormats[c.RecommendationTicket.Format] {
+			errors = append(errors, fmt.Sprintf("recommendation_ticket.format must be servicenow, jira or custom: %s", c.RecommendationTicket.Format))
+		}
+		if c.RecommendationTicket.Format == "custom" && c.RecommendationTicket.FieldsFile == "" {
+			errors = append(errors, "recommendation_ticket.fields_file is required with the custom format")
+		}
+	}
+
+	// Validate incident write-behind settings
+	if c.IncidentWriteBehind.Enabled {
+		if c.IncidentWriteBehind.FlushInterval <= 0 {
+			errors = append(errors, fmt.Sprintf("incident_write_behind.flush_interval must be positive: %s", c.IncidentWriteBehind.FlushInterval))
+		}
+		if c.IncidentWriteBehind.MaxPending < 0 {
+			errors = append(errors, fmt.Sprintf("incident_write_behind.max_pending must not be negative: %d", c.IncidentWriteBehind.MaxPending))
+		}
+	}
+
+	// Validate incident purge settings
+	if c.IncidentPurge.Enabled && c.IncidentPurge.TokenFile == "" {
+		errors = append(errors, "incident_purge.token_file is required when incident purge is enabled")
+	}
+
+	// Validate feature engineering lookback (values above the max are clamped at runtime)
+	if c.FeatureEngineering.Enabled {
+		if c.FeatureEngineering.LookbackHours <= 0 {
+			errors = append(errors, fmt.Sprintf("feature_engineering.lookback_hours must be positive: %d", c.FeatureEngineering.LookbackHours))
+		}
+		if c.FeatureEngineering.MaxLookbackHours < 0 {
+			errors = append(errors, fmt.Sprintf("feature_engineering.max_lookback_hours must not be negative: %d", c.FeatureEngineering.MaxLookbackHours))
+		}
+		if rule := c.FeatureEngineering.ResampleRule; rule < 0 || (rule > 0 && (rule < time.Minute || time.Hour%rule != 0)) {
+			errors = append(errors, fmt.Sprintf("feature_engineering.resample_rule must be 0 or at least 1m and divide an hour evenly: %s", rule))
+		}
+		if agg := c.FeatureEngineering.ClusterAggregation; !slices.Contains(features.ClusterAggregationStrategies(), agg) {
+			errors = append(errors, fmt.Sprintf("feature_engineering.cluster_aggregation must be one of %v: %q", features.ClusterAggregationStrategies(), agg))
+		}
+		if c.FeatureEngineering.ClusterTopNamespaces <= 0 {
+			errors = append(errors, fmt.Sprintf("feature_engineering.cluster_top_namespaces must be positive: %d", c.FeatureEngineering.ClusterTopNamespaces))
+		}
+		if start, end := c.FeatureEngineering.BusinessHoursStart, c.FeatureEngineering.BusinessHoursEnd; start < 0 || end > 24 || start >= end {
+			errors = append(errors, fmt.Sprintf("feature_engineering.business_hours must satisfy 0 <= start < end <= 24: %d-%d", start, end))
+		}
+		for _, lag := range c.FeatureEngineering.LagPeriods {
+			if lag <= 0 {
+				errors = append(errors, fmt.Sprintf("feature_engineering.lag_periods must be positive hours: %v", c.FeatureEngineering.LagPeriods))
+				break
+			}
+		}
+		for _, window := range c.FeatureEngineering.RollingWindows {
+			if window <= 0 {
+				errors = append(errors, fmt.Sprintf("feature_engineering.rolling_windows must be positive hours: %v", c.FeatureEngineering.RollingWindows))
+				break
+			}
+		}
+		if policy := c.FeatureEngineering.FallbackPolicy; policy != v1.FallbackPolicyLenient && policy != v1.FallbackPolicyStrict {
+			errors = append(errors, fmt.Sprintf("feature_engineering.fallback_policy must be %s or %s: %q", v1.FallbackPolicyLenient, v1.FallbackPolicyStrict, policy))
+		}
+		if c.FeatureEngineering.QueryStep < 0 {
+			errors = append(errors, fmt.Sprintf("feature_engineering.query_step must not be negative: %s", c.FeatureEngineering.QueryStep))
+		}
+		if c.FeatureEngineering.MaxBuildDuration < 0 {
+			errors = append(errors, fmt.Sprintf("feature_engineering.max_build_duration must not be negative: %s", c.FeatureEngineering.MaxBuildDuration))
+		}
+	}
+
+	// Validate prediction cache
+	if c.PredictionCache.TTL < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_cache.ttl must not be negative: %s", c.PredictionCache.TTL))
+	}
+	if c.PredictionCache.Bucket < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_cache.bucket must not be negative: %s", c.PredictionCache.Bucket))
+	}
+
+	// Validate prediction baselines
+	if c.PredictionBaseline.Alpha < 0 || c.PredictionBaseline.Alpha > 1 {
+		errors = append(errors, fmt.Sprintf("prediction_baseline.alpha must be between 0 and 1: %g", c.PredictionBaseline.Alpha))
+	}
+	if c.PredictionBaseline.MaxEntries < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_baseline.max_entries must not be negative: %d", c.PredictionBaseline.MaxEntries))
+	}
+	if c.PredictionBaseline.PersistInterval < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_baseline.persist_interval must not be negative: %s", c.PredictionBaseline.PersistInterval))
+	}
+
+	// Validate prediction target validation
+	if c.PredictionTargetValidation.CacheTTL < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_target_validation.cache_ttl must not be negative: %s", c.PredictionTargetValidation.CacheTTL))
+	}
+
+	// Validate prediction history
+	if c.PredictionHistory.MaxRecords < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_history.max_records must not be negative: %d", c.PredictionHistory.MaxRecords))
+	}
+
+	// Validate prediction concurrency
+	if c.PredictionConcurrency.MaxConcurrent < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_concurrency.max_concurrent must not be negative: %d", c.PredictionConcurrency.MaxConcurrent))
+	}
+	if c.PredictionConcurrency.QueueTimeout < 0 {
+		errors = append(errors, fmt.Sprintf("prediction_concurrency.queue_timeout must not be negative: %s", c.PredictionConcurrency.QueueTimeout))
+	}
+
+	// Validate prediction metric aggregation (empty = mean)
+	if agg := c.PredictionMetricAggregation; agg != "" && !slices.Contains(integrations.MetricAggregations(), agg) {
+		errors = append(errors, fmt.Sprintf("prediction_metric_aggregation must be one of %v: %q", integrations.MetricAggregations(), agg))
+	}
+
+	// Validate predicted incidents (only when enabled)
+	if c.PredictedIncidents.Enabled {
+		if c.PredictedIncidents.CPUThreshold <= 0 || c.PredictedIncidents.CPUThreshold > 100 {
+			errors = append(errors, fmt.Sprintf("predicted_incidents.cpu_threshold must be in (0, 100]: %g", c.PredictedIncidents.CPUThreshold))
+		}
+		if c.PredictedIncidents.MemoryThreshold <= 0 || c.PredictedIncidents.MemoryThreshold > 100 {
+			errors = append(errors, fmt.Sprintf("predicted_incidents.memory_threshold must be in (0, 100]: %g", c.PredictedIncidents.MemoryThreshold))
+		}
+		if c.PredictedIncidents.MinConfidence < 0 || c.PredictedIncidents.MinConfidence > 1 {
+			errors = append(errors, fmt.Sprintf("predicted_incidents.min_confidence must be between 0 and 1: %g", c.PredictedIncidents.MinConfidence))
+		}
+	}
+
+	// Validate recommendation history weighting
+	if c.RecommendationHistory.HalfLife < 0 {
+		errors = append(errors, fmt.Sprintf("recommendation_history.half_life must not be negative: %s", c.RecommendationHistory.HalfLife))
+	}
+	if c.RecommendationHistory.MaxAge < 0 {
+		errors = append(errors, fmt.Sprintf("recommendation_history.max_age must not be negative: %s", c.RecommendationHistory.MaxAge))
+	}
+	if c.RecommendationHistory.ResolvedDiscount < 0 || c.RecommendationHistory.ResolvedDiscount > 1 {
+		errors = append(errors, fmt.Sprintf("recommendation_history.resolved_discount must be between 0 and 1: %g", c.RecommendationHistory.ResolvedDiscount))
+	}
+	if c.RecommendationAckDuration < 0 || c.RecommendationAckDuration > 30*24*time.Hour {
+		errors = append(errors, fmt.Sprintf("recommendation_ack_duration must be between 0 and 720h: %s", c.RecommendationAckDuration))
+	}
+	if c.RecommendationMaxCount < 0 {
+		errors = append(errors, fmt.Sprintf("recommendation_max_count must not be negative: %d", c.RecommendationMaxCount))
+	}
+	if c.RecommendationMLBatchSize < 0 {
+		errors = append(errors, fmt.Sprintf("recommendation_ml_batch_size must not be negative: %d", c.RecommendationMLBatchSize))
+	}
+	if c.RecommendationMLConcurrency < 0 {
+		errors = append(errors, fmt.Sprintf("recommendation_ml_concurrency must not be negative: %d", c.RecommendationMLConcurrency))
+	}
+	if c.RecommendationMLConfidenceFloor < 0 || c.RecommendationMLConfidenceFloor > 1 {
+		errors = append(errors, fmt.Sprintf("recommendation_ml_confidence_floor must be between 0 and 1: %.2f", c.RecommendationMLConfidenceFloor))
+	}
+	if err := v1.ValidatePredictionHorizons(c.RecommendationPredictionHorizons); err != nil {
+		errors = append(errors, fmt.Sprintf("recommendation_prediction_horizons: %v", err))
+	}
+	if c.MCOPoolHistorySize < 0 {
+		errors = append(errors, fmt.Sprintf("mco_pool_history_size must not be negative: %d", c.MCOPoolHistorySize))
+	}
+	if c.MCOPoolHistoryPollInterval < 0 {
+		errors = append(errors, fmt.Sprintf("mco_pool_history_poll_interval must not be negative: %s", c.MCOPoolHistoryPollInterval))
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errors, "\n  - "))
 	}
@@ -385,6 +1388,19 @@ func getEnvAsFloat32(key string, defaultVal float32) float32 {
 	return float32(value)
 }
 
+// getEnvAsFloat64 gets an environment variable as a float64 or returns a default value
+func getEnvAsFloat64(key string, defaultVal float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultVal
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return defaultVal
+	}
+	return value
+}
+
 // getEnvAsBool gets an environment variable as a boolean or returns a default value
 func getEnvAsBool(key string, defaultVal bool) bool {
 	valueStr := os.Getenv(key)
@@ -430,6 +1446,73 @@ func getEnvAsSlice(key string, defaultVal []string) []string {
 	return result
 }
 
+// getEnvAsIntSlice gets an environment variable as a comma-separated list of integers or returns a default value.
+// Any unparsable entry causes the default to be returned.
+func getEnvAsIntSlice(key string, defaultVal []int) []int {
+	parts := getEnvAsSlice(key, nil)
+	if len(parts) == 0 {
+		return defaultVal
+	}
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return defaultVal
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+// getEnvAsIntMap gets an environment variable as a comma-separated list of key=integer pairs
+// (e.g. "critical=365,low=30") or returns a default value when it is unset. Unlike the other
+// getters it reports an unparsable entry instead of returning the default, since silently
+// dropping one entry would change the meaning of the rest.
+func getEnvAsIntMap(key string, defaultVal map[string]int) (map[string]int, error) {
+	parts := getEnvAsSlice(key, nil)
+	if len(parts) == 0 {
+		return defaultVal, nil
+	}
+	result := make(map[string]int, len(parts))
+	for _, part := range parts {
+		name, valueStr, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s: invalid entry %q (must be key=integer)", key, part)
+		}
+		value, err := strconv.Atoi(strings.TrimSpace(valueStr))
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid entry %q (must be key=integer)", key, part)
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// getEnvAsDurationMap gets an environment variable as a comma-separated list of key=duration
+// pairs (e.g. "predictive-analytics=30s,anomaly-detector=5s") or returns a default value.
+// Any unparsable entry causes the default to be returned.
+func getEnvAsDurationMap(key string, defaultVal map[string]time.Duration) map[string]time.Duration {
+	parts := getEnvAsSlice(key, nil)
+	if len(parts) == 0 {
+		return defaultVal
+	}
+	result := make(map[string]time.Duration, len(parts))
+	for _, part := range parts {
+		name, valueStr, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return defaultVal
+		}
+		value, err := time.ParseDuration(strings.TrimSpace(valueStr))
+		if err != nil {
+			return defaultVal
+		}
+		result[name] = value
+	}
+	return result
+}
+
 // discoverKServeServicesFromEnv discovers KServe services from environment variables.
 // Pattern: KSERVE_<MODEL_NAME>_SERVICE = service-name
 // Example: KSERVE_DISK_FAILURE_PREDICTOR_SERVICE = disk-failure-predictor-predictor
@@ -7,6 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+	v1 "github.com/KubeHeal/openshift-coordination-engine/pkg/api/v1"
 )
 
 func TestLoad_Defaults(t *testing.T) {
@@ -499,15 +502,52 @@ func clearEnv(t *testing.T) {
 	envVars := []string{
 		"PORT", "METRICS_PORT", "LOG_LEVEL", "KUBECONFIG", "NAMESPACE",
 		"ML_SERVICE_URL", "ARGOCD_API_URL", "HTTP_TIMEOUT",
-		"ENABLE_CORS", "CORS_ALLOW_ORIGIN",
+		"SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "MAX_REQUEST_BODY_BYTES",
+		"METRICS_SNAPSHOT_TTL",
+		"ENABLE_CORS", "CORS_ALLOW_ORIGIN", "CORS_ALLOW_METHODS", "CORS_ALLOW_HEADERS", "CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE",
 		"KUBERNETES_QPS", "KUBERNETES_BURST",
 		// KServe environment variables (ADR-039)
 		"ENABLE_KSERVE_INTEGRATION", "KSERVE_NAMESPACE", "KSERVE_PREDICTOR_PORT",
 		"KSERVE_ANOMALY_DETECTOR_SERVICE", "KSERVE_PREDICTIVE_ANALYTICS_SERVICE",
-		"KSERVE_TIMEOUT",
+		"KSERVE_TIMEOUT", "KSERVE_REGRESSION_MODELS", "KSERVE_REGRESSION_CPU_INDEX",
+		"KSERVE_REGRESSION_MEMORY_INDEX", "KSERVE_REGRESSION_SCALE", "KSERVE_MODEL_TIMEOUTS",
+		"KSERVE_FORECAST_CPU_KEYS", "KSERVE_FORECAST_MEMORY_KEYS", "KSERVE_DEBUG_RAW_RESPONSE",
+		"KSERVE_REACHABILITY_TTL", "KSERVE_FORECAST_STEP", "KSERVE_FORECAST_THRESHOLD_PERCENT",
 		// Feature engineering environment variables (Issue #57)
 		"ENABLE_FEATURE_ENGINEERING", "FEATURE_ENGINEERING_LOOKBACK_HOURS",
-		"FEATURE_ENGINEERING_EXPECTED_COUNT",
+		"FEATURE_ENGINEERING_EXPECTED_COUNT", "FEATURE_ENGINEERING_MAX_LOOKBACK_HOURS",
+		"FEATURE_ENGINEERING_TIME_FEATURES", "FEATURE_ENGINEERING_BASE_METRICS", "FEATURE_ENGINEERING_LOG_QUERIES", "FEATURE_ENGINEERING_LOG_VECTOR_STATS",
+		"FEATURE_ENGINEERING_RESAMPLE_RULE",
+		"FEATURE_ENGINEERING_CLUSTER_AGGREGATION", "FEATURE_ENGINEERING_CLUSTER_TOP_NAMESPACES",
+		"FEATURE_ENGINEERING_BUSINESS_HOURS_START", "FEATURE_ENGINEERING_BUSINESS_HOURS_END",
+		"FEATURE_ENGINEERING_BUSINESS_DAYS", "FEATURE_ENGINEERING_WEEKEND_DAYS", "FEATURE_ENGINEERING_SCALER_FILE",
+		"FEATURE_ENGINEERING_LAG_PERIODS", "FEATURE_ENGINEERING_ROLLING_WINDOWS", "FEATURE_ENGINEERING_FALLBACK_POLICY",
+		"FEATURE_ENGINEERING_QUERY_STEP", "FEATURE_ENGINEERING_MAX_BUILD_DURATION",
+		// Prometheus TLS/auth environment variables
+		"PROMETHEUS_CA_FILE", "PROMETHEUS_INSECURE_SKIP_VERIFY", "PROMETHEUS_BEARER_TOKEN_FILE",
+		"PROMETHEUS_FALLBACK_URLS", "PROMETHEUS_ENDPOINT_COOLDOWN",
+		// Incident escalation environment variables
+		"INCIDENT_ESCALATION_ENABLED", "INCIDENT_RECURRENCE_WINDOW", "INCIDENT_ESCALATION_THRESHOLDS",
+		// Incident auto-resolve environment variables
+		"INCIDENT_AUTO_RESOLVE_ENABLED", "INCIDENT_AUTO_RESOLVE_QUIET_PERIOD", "INCIDENT_AUTO_RESOLVE_INTERVAL",
+		"INCIDENT_WEBHOOK_URL", "INCIDENT_WEBHOOK_TIMEOUT", "INCIDENT_WEBHOOK_MAX_RETRIES", "INCIDENT_WEBHOOK_EVENTS",
+		"RECOMMENDATION_TICKET_URL", "RECOMMENDATION_TICKET_TIMEOUT", "RECOMMENDATION_TICKET_FORMAT", "RECOMMENDATION_TICKET_FIELDS_FILE",
+		"RECOMMENDATION_TICKET_AUTHORIZATION_FILE", "RECOMMENDATION_TICKET_REFERENCE_FIELD",
+		"INCIDENT_WRITE_BEHIND_ENABLED", "INCIDENT_WRITE_BEHIND_INTERVAL", "INCIDENT_WRITE_BEHIND_MAX_PENDING",
+		"INCIDENT_PURGE_ENABLED", "INCIDENT_PURGE_TOKEN_FILE",
+		// Recommendation history environment variables
+		"RECOMMENDATION_HISTORY_HALF_LIFE", "RECOMMENDATION_HISTORY_MAX_AGE", "RECOMMENDATION_HISTORY_RESOLVED_DISCOUNT", "RECOMMENDATION_MCO_GATING_ENABLED", "RECOMMENDATION_ACK_DURATION", "RECOMMENDATION_MAX_COUNT",
+		"RECOMMENDATION_ML_BATCH_SIZE", "RECOMMENDATION_ML_CONCURRENCY", "RECOMMENDATION_ML_CONFIDENCE_FLOOR",
+		"MCO_POOL_HISTORY_SIZE", "MCO_POOL_HISTORY_POLL_INTERVAL", "RECOMMENDATION_PREDICTION_HORIZONS", "INCIDENT_RETENTION_DAYS_BY_SEVERITY",
+		// Prediction cache environment variables
+		"PREDICTION_CACHE_TTL", "PREDICTION_CACHE_BUCKET",
+		"PREDICTION_BASELINE_ALPHA", "PREDICTION_BASELINE_MAX_ENTRIES", "PREDICTION_BASELINE_PERSIST_INTERVAL",
+		"PREDICTION_TARGET_VALIDATION_ENABLED", "PREDICTION_TARGET_VALIDATION_CACHE_TTL",
+		"PREDICTION_HISTORY_ENABLED", "PREDICTION_HISTORY_MAX_RECORDS",
+		"PREDICTION_DEGRADED_MODE_ENABLED", "PREDICTION_METRIC_AGGREGATION",
+		"PREDICTED_INCIDENTS_ENABLED", "PREDICTED_INCIDENTS_CPU_THRESHOLD", "PREDICTED_INCIDENTS_MEMORY_THRESHOLD",
+		"PREDICTED_INCIDENTS_MIN_CONFIDENCE",
+		"PREDICTION_MAX_CONCURRENT", "PREDICTION_QUEUE_TIMEOUT",
 	}
 	for _, key := range envVars {
 		os.Unsetenv(key)
@@ -1033,6 +1073,210 @@ func TestFeatureEngineering_Defaults(t *testing.T) {
 	assert.True(t, cfg.FeatureEngineering.Enabled, "Feature engineering should be enabled by default")
 	assert.Equal(t, DefaultFeatureEngineeringLookbackHours, cfg.FeatureEngineering.LookbackHours)
 	assert.Equal(t, DefaultFeatureEngineeringExpectedFeatureCount, cfg.FeatureEngineering.ExpectedFeatureCount)
+	assert.Equal(t, DefaultFeatureEngineeringMaxLookbackHours, cfg.FeatureEngineering.MaxLookbackHours)
+	assert.Equal(t, time.Hour, cfg.FeatureEngineering.ResampleRule)
+	assert.Equal(t, "mean", cfg.FeatureEngineering.ClusterAggregation)
+	assert.Equal(t, "lenient", cfg.FeatureEngineering.FallbackPolicy)
+	assert.Zero(t, cfg.FeatureEngineering.QueryStep)
+	assert.Zero(t, cfg.FeatureEngineering.MaxBuildDuration)
+	assert.Equal(t, DefaultFeatureEngineeringClusterTopNamespaces, cfg.FeatureEngineering.ClusterTopNamespaces)
+	assert.Equal(t, 9, cfg.FeatureEngineering.BusinessHoursStart)
+	assert.Equal(t, 17, cfg.FeatureEngineering.BusinessHoursEnd)
+	assert.Empty(t, cfg.FeatureEngineering.BusinessDays)
+	assert.Empty(t, cfg.FeatureEngineering.WeekendDays)
+}
+
+// TestFeatureEngineering_LookbackValidation verifies invalid lookback settings are rejected at load time
+func TestFeatureEngineering_LookbackValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "zero lookback",
+			env:     map[string]string{"FEATURE_ENGINEERING_LOOKBACK_HOURS": "0"},
+			wantErr: "feature_engineering.lookback_hours must be positive",
+		},
+		{
+			name:    "negative max lookback",
+			env:     map[string]string{"FEATURE_ENGINEERING_MAX_LOOKBACK_HOURS": "-1"},
+			wantErr: "feature_engineering.max_lookback_hours must not be negative",
+		},
+		{
+			name:    "resample rule not dividing an hour",
+			env:     map[string]string{"FEATURE_ENGINEERING_RESAMPLE_RULE": "7m"},
+			wantErr: "feature_engineering.resample_rule must be 0 or at least 1m",
+		},
+		{
+			name:    "unknown cluster aggregation",
+			env:     map[string]string{"FEATURE_ENGINEERING_CLUSTER_AGGREGATION": "max"},
+			wantErr: "feature_engineering.cluster_aggregation must be one of [mean top_namespaces weighted]",
+		},
+		{
+			name:    "unknown fallback policy",
+			env:     map[string]string{"FEATURE_ENGINEERING_FALLBACK_POLICY": "defaults"},
+			wantErr: "feature_engineering.fallback_policy must be lenient or strict",
+		},
+		{
+			name: "strict fallback policy",
+			env:  map[string]string{"FEATURE_ENGINEERING_FALLBACK_POLICY": "strict"},
+		},
+		{
+			name:    "negative query step",
+			env:     map[string]string{"FEATURE_ENGINEERING_QUERY_STEP": "-1m"},
+			wantErr: "feature_engineering.query_step must not be negative",
+		},
+		{
+			name: "custom query step",
+			env:  map[string]string{"FEATURE_ENGINEERING_QUERY_STEP": "1m"},
+		},
+		{
+			name:    "negative max build duration",
+			env:     map[string]string{"FEATURE_ENGINEERING_MAX_BUILD_DURATION": "-1s"},
+			wantErr: "feature_engineering.max_build_duration must not be negative",
+		},
+		{
+			name: "max build duration",
+			env:  map[string]string{"FEATURE_ENGINEERING_MAX_BUILD_DURATION": "2s"},
+		},
+		{
+			name:    "zero cluster top namespaces",
+			env:     map[string]string{"FEATURE_ENGINEERING_CLUSTER_TOP_NAMESPACES": "0"},
+			wantErr: "feature_engineering.cluster_top_namespaces must be positive",
+		},
+		{
+			name: "business hours ending before they start",
+			env: map[string]string{
+				"FEATURE_ENGINEERING_BUSINESS_HOURS_START": "18",
+				"FEATURE_ENGINEERING_BUSINESS_HOURS_END":   "8",
+			},
+			wantErr: "feature_engineering.business_hours must satisfy 0 <= start < end <= 24",
+		},
+		{
+			name: "custom business calendar",
+			env: map[string]string{
+				"FEATURE_ENGINEERING_BUSINESS_HOURS_START": "8",
+				"FEATURE_ENGINEERING_BUSINESS_HOURS_END":   "24",
+				"FEATURE_ENGINEERING_WEEKEND_DAYS":         "friday,saturday",
+			},
+		},
+		{
+			name: "custom lag periods and rolling windows",
+			env: map[string]string{
+				"FEATURE_ENGINEERING_LAG_PERIODS":     "1,24,168",
+				"FEATURE_ENGINEERING_ROLLING_WINDOWS": "6,24",
+			},
+		},
+		{
+			name:    "non-positive lag period",
+			env:     map[string]string{"FEATURE_ENGINEERING_LAG_PERIODS": "1,0"},
+			wantErr: "feature_engineering.lag_periods must be positive hours",
+		},
+		{
+			name: "top namespaces aggregation",
+			env: map[string]string{
+				"FEATURE_ENGINEERING_CLUSTER_AGGREGATION":    "top_namespaces",
+				"FEATURE_ENGINEERING_CLUSTER_TOP_NAMESPACES": "3",
+			},
+		},
+		{
+			name: "resampling disabled",
+			env:  map[string]string{"FEATURE_ENGINEERING_RESAMPLE_RULE": "0"},
+		},
+		{
+			name: "lookback above max is accepted and clamped at runtime",
+			env: map[string]string{
+				"FEATURE_ENGINEERING_LOOKBACK_HOURS":     "168",
+				"FEATURE_ENGINEERING_MAX_LOOKBACK_HOURS": "72",
+			},
+		},
+		{
+			name: "invalid lookback ignored when disabled",
+			env: map[string]string{
+				"ENABLE_FEATURE_ENGINEERING":         "false",
+				"FEATURE_ENGINEERING_LOOKBACK_HOURS": "0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := Load()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestFeatureEngineering_TimeFeaturesFromEnvironment verifies the time feature selection is parsed in order
+func TestFeatureEngineering_TimeFeaturesFromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.FeatureEngineering.TimeFeatures)
+
+	t.Setenv("FEATURE_ENGINEERING_TIME_FEATURES", "hour, day_of_week,quarter,week_of_year")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hour", "day_of_week", "quarter", "week_of_year"}, cfg.FeatureEngineering.TimeFeatures)
+}
+
+// TestFeatureEngineering_BaseMetricsFromEnvironment verifies the base metric selection is parsed
+func TestFeatureEngineering_BaseMetricsFromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.FeatureEngineering.BaseMetrics)
+
+	t.Setenv("FEATURE_ENGINEERING_BASE_METRICS", "cpu_usage, memory_usage")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu_usage", "memory_usage"}, cfg.FeatureEngineering.BaseMetrics)
+}
+
+// TestFeatureEngineering_LogQueriesFromEnvironment verifies PromQL query logging is off unless requested
+func TestFeatureEngineering_LogQueriesFromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.FeatureEngineering.LogQueries)
+
+	t.Setenv("FEATURE_ENGINEERING_LOG_QUERIES", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.FeatureEngineering.LogQueries)
+}
+
+// TestFeatureEngineering_LogVectorStatsFromEnvironment verifies feature vector statistics are off unless requested
+func TestFeatureEngineering_LogVectorStatsFromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.FeatureEngineering.LogVectorStats)
+
+	t.Setenv("FEATURE_ENGINEERING_LOG_VECTOR_STATS", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.FeatureEngineering.LogVectorStats)
 }
 
 // TestFeatureEngineering_EnabledFromEnvironment verifies ENABLE_FEATURE_ENGINEERING=true is read correctly
@@ -1141,3 +1385,979 @@ func TestFeatureEngineering_DefaultConstants(t *testing.T) {
 	assert.Equal(t, 24, DefaultFeatureEngineeringLookbackHours, "Default lookback should be 24 hours")
 	assert.Equal(t, 0, DefaultFeatureEngineeringExpectedFeatureCount, "Default expected count should be 0 (disabled)")
 }
+
+// TestPrometheusAuth_Defaults verifies Prometheus TLS/auth defaults keep in-cluster behavior
+func TestPrometheusAuth_Defaults(t *testing.T) {
+	clearEnv(t)
+
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer os.Unsetenv("KSERVE_ANOMALY_DETECTOR_SERVICE")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Empty(t, cfg.PrometheusCAFile)
+	assert.True(t, cfg.PrometheusInsecureSkipVerify)
+	assert.Equal(t, DefaultPrometheusBearerTokenFile, cfg.PrometheusBearerTokenFile)
+}
+
+// TestPrometheusAuth_FromEnvironment verifies Prometheus TLS/auth settings are read from env
+func TestPrometheusAuth_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+
+	os.Setenv("PROMETHEUS_CA_FILE", "/etc/prometheus/ca.crt")
+	os.Setenv("PROMETHEUS_INSECURE_SKIP_VERIFY", "false")
+	os.Setenv("PROMETHEUS_BEARER_TOKEN_FILE", "/etc/prometheus/token")
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer func() {
+		os.Unsetenv("PROMETHEUS_CA_FILE")
+		os.Unsetenv("PROMETHEUS_INSECURE_SKIP_VERIFY")
+		os.Unsetenv("PROMETHEUS_BEARER_TOKEN_FILE")
+		os.Unsetenv("KSERVE_ANOMALY_DETECTOR_SERVICE")
+	}()
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "/etc/prometheus/ca.crt", cfg.PrometheusCAFile)
+	assert.False(t, cfg.PrometheusInsecureSkipVerify)
+	assert.Equal(t, "/etc/prometheus/token", cfg.PrometheusBearerTokenFile)
+}
+
+// TestPrometheusFailover_FromEnvironment verifies fallback endpoints and the cooldown are read
+// from env and validated
+func TestPrometheusFailover_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.PrometheusFallbackURLs)
+	assert.Equal(t, DefaultPrometheusEndpointCooldown, cfg.PrometheusEndpointCooldown)
+
+	os.Setenv("PROMETHEUS_URL", "https://thanos-querier-0:9091")
+	os.Setenv("PROMETHEUS_FALLBACK_URLS", "https://thanos-querier-1:9091, https://thanos-querier-2:9091")
+	os.Setenv("PROMETHEUS_ENDPOINT_COOLDOWN", "1m")
+	defer os.Unsetenv("PROMETHEUS_URL")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://thanos-querier-1:9091", "https://thanos-querier-2:9091"}, cfg.PrometheusFallbackURLs)
+	assert.Equal(t, time.Minute, cfg.PrometheusEndpointCooldown)
+
+	os.Setenv("PROMETHEUS_FALLBACK_URLS", "thanos-querier-1:9091")
+	_, err = Load()
+	assert.Error(t, err)
+
+	os.Setenv("PROMETHEUS_FALLBACK_URLS", "https://thanos-querier-1:9091")
+	os.Unsetenv("PROMETHEUS_URL")
+	_, err = Load()
+	assert.Error(t, err, "fallbacks without a primary URL")
+
+	os.Setenv("PROMETHEUS_URL", "https://thanos-querier-0:9091")
+	os.Setenv("PROMETHEUS_ENDPOINT_COOLDOWN", "-1s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestIncidentEscalation_FromEnvironment verifies escalation settings are read from env
+func TestIncidentEscalation_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+
+	os.Setenv("INCIDENT_ESCALATION_ENABLED", "true")
+	os.Setenv("INCIDENT_RECURRENCE_WINDOW", "30m")
+	os.Setenv("INCIDENT_ESCALATION_THRESHOLDS", "2, 4, 8")
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.True(t, cfg.IncidentEscalation.Enabled)
+	assert.Equal(t, 30*time.Minute, cfg.IncidentEscalation.RecurrenceWindow)
+	assert.Equal(t, []int{2, 4, 8}, cfg.IncidentEscalation.Thresholds)
+}
+
+// TestIncidentEscalation_Validation verifies invalid escalation settings are rejected when enabled
+func TestIncidentEscalation_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		escalation IncidentEscalationConfig
+		wantError  bool
+	}{
+		{
+			name:       "disabled with invalid values",
+			escalation: IncidentEscalationConfig{Enabled: false, Thresholds: []int{0}},
+			wantError:  false,
+		},
+		{
+			name:       "valid",
+			escalation: IncidentEscalationConfig{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{3, 5}},
+			wantError:  false,
+		},
+		{
+			name:       "zero window",
+			escalation: IncidentEscalationConfig{Enabled: true, Thresholds: []int{3}},
+			wantError:  true,
+		},
+		{
+			name:       "threshold below two",
+			escalation: IncidentEscalationConfig{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{1}},
+			wantError:  true,
+		},
+		{
+			name:       "not increasing",
+			escalation: IncidentEscalationConfig{Enabled: true, RecurrenceWindow: time.Hour, Thresholds: []int{5, 3}},
+			wantError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Port:               8080,
+				MetricsPort:        9090,
+				LogLevel:           "info",
+				Namespace:          "test",
+				HTTPTimeout:        30 * time.Second,
+				KubernetesQPS:      50.0,
+				KubernetesBurst:    100,
+				IncidentEscalation: tt.escalation,
+			}
+			err := cfg.Validate()
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestIncidentAutoResolve_FromEnvironment verifies auto-resolve defaults to off and reads overrides
+func TestIncidentAutoResolve_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.IncidentAutoResolve.Enabled)
+	assert.Equal(t, DefaultIncidentAutoResolveQuietPeriod, cfg.IncidentAutoResolve.QuietPeriod)
+	assert.Equal(t, DefaultIncidentAutoResolveCheckInterval, cfg.IncidentAutoResolve.CheckInterval)
+
+	os.Setenv("INCIDENT_AUTO_RESOLVE_ENABLED", "true")
+	os.Setenv("INCIDENT_AUTO_RESOLVE_QUIET_PERIOD", "6h")
+	os.Setenv("INCIDENT_AUTO_RESOLVE_INTERVAL", "5m")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.IncidentAutoResolve.Enabled)
+	assert.Equal(t, 6*time.Hour, cfg.IncidentAutoResolve.QuietPeriod)
+	assert.Equal(t, 5*time.Minute, cfg.IncidentAutoResolve.CheckInterval)
+
+	os.Setenv("INCIDENT_AUTO_RESOLVE_QUIET_PERIOD", "0s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestIncidentWebhook_FromEnvironment verifies the webhook is off by default and validates overrides
+func TestIncidentWebhook_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.IncidentWebhook.URL)
+	assert.Equal(t, DefaultIncidentWebhookTimeout, cfg.IncidentWebhook.Timeout)
+	assert.Equal(t, DefaultIncidentWebhookMaxRetries, cfg.IncidentWebhook.MaxRetries)
+	assert.Empty(t, cfg.IncidentWebhook.Events)
+
+	os.Setenv("INCIDENT_WEBHOOK_URL", "https://hooks.example.com/incidents")
+	os.Setenv("INCIDENT_WEBHOOK_TIMEOUT", "2s")
+	os.Setenv("INCIDENT_WEBHOOK_MAX_RETRIES", "0")
+	os.Setenv("INCIDENT_WEBHOOK_EVENTS", "created,status_changed")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/incidents", cfg.IncidentWebhook.URL)
+	assert.Equal(t, 2*time.Second, cfg.IncidentWebhook.Timeout)
+	assert.Equal(t, 0, cfg.IncidentWebhook.MaxRetries)
+	assert.Equal(t, []string{"created", "status_changed"}, cfg.IncidentWebhook.Events)
+
+	os.Setenv("INCIDENT_WEBHOOK_EVENTS", "created,exploded")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown event: exploded")
+
+	os.Setenv("INCIDENT_WEBHOOK_EVENTS", "")
+	os.Setenv("INCIDENT_WEBHOOK_URL", "ftp://hooks.example.com")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestRecommendationTicket_FromEnvironment verifies ticket export is off by default and validates overrides
+func TestRecommendationTicket_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.RecommendationTicket.URL)
+	assert.Equal(t, DefaultRecommendationTicketTimeout, cfg.RecommendationTicket.Timeout)
+	assert.Equal(t, DefaultRecommendationTicketFormat, cfg.RecommendationTicket.Format)
+
+	t.Setenv("RECOMMENDATION_TICKET_URL", "https://example.atlassian.net/rest/api/2/issue")
+	t.Setenv("RECOMMENDATION_TICKET_FORMAT", "jira")
+	t.Setenv("RECOMMENDATION_TICKET_FIELDS_FILE", "/etc/tickets/fields.json")
+	t.Setenv("RECOMMENDATION_TICKET_REFERENCE_FIELD", "key")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "jira", cfg.RecommendationTicket.Format)
+	assert.Equal(t, "/etc/tickets/fields.json", cfg.RecommendationTicket.FieldsFile)
+	assert.Equal(t, "key", cfg.RecommendationTicket.ReferenceField)
+
+	t.Setenv("RECOMMENDATION_TICKET_FORMAT", "custom")
+	t.Setenv("RECOMMENDATION_TICKET_FIELDS_FILE", "")
+	_, err = Load()
+	assert.ErrorContains(t, err, "fields_file is required with the custom format")
+
+	t.Setenv("RECOMMENDATION_TICKET_FORMAT", "remedy")
+	_, err = Load()
+	assert.ErrorContains(t, err, "recommendation_ticket.format must be servicenow, jira or custom")
+}
+
+// TestIncidentWriteBehind_FromEnvironment verifies write-behind is off by default and validates overrides
+func TestIncidentWriteBehind_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.IncidentWriteBehind.Enabled)
+	assert.Equal(t, DefaultIncidentWriteBehindFlushInterval, cfg.IncidentWriteBehind.FlushInterval)
+	assert.Equal(t, DefaultIncidentWriteBehindMaxPending, cfg.IncidentWriteBehind.MaxPending)
+
+	os.Setenv("INCIDENT_WRITE_BEHIND_ENABLED", "true")
+	os.Setenv("INCIDENT_WRITE_BEHIND_INTERVAL", "30s")
+	os.Setenv("INCIDENT_WRITE_BEHIND_MAX_PENDING", "0")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.IncidentWriteBehind.Enabled)
+	assert.Equal(t, 30*time.Second, cfg.IncidentWriteBehind.FlushInterval)
+	assert.Equal(t, 0, cfg.IncidentWriteBehind.MaxPending)
+
+	os.Setenv("INCIDENT_WRITE_BEHIND_INTERVAL", "0s")
+	_, err = Load()
+	assert.Error(t, err)
+
+	os.Setenv("INCIDENT_WRITE_BEHIND_INTERVAL", "30s")
+	os.Setenv("INCIDENT_WRITE_BEHIND_MAX_PENDING", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestIncidentPurge_FromEnvironment verifies the purge endpoint is off by default and needs a token file
+func TestIncidentPurge_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.IncidentPurge.Enabled)
+
+	os.Setenv("INCIDENT_PURGE_ENABLED", "true")
+	_, err = Load()
+	assert.ErrorContains(t, err, "incident_purge.token_file")
+
+	os.Setenv("INCIDENT_PURGE_TOKEN_FILE", "/etc/coordination-engine/purge-token")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.IncidentPurge.Enabled)
+	assert.Equal(t, "/etc/coordination-engine/purge-token", cfg.IncidentPurge.TokenFile)
+}
+
+// TestRecommendationHistory_FromEnvironment verifies history weighting defaults and overrides
+func TestRecommendationHistory_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRecommendationHistoryHalfLife, cfg.RecommendationHistory.HalfLife)
+	assert.Equal(t, DefaultRecommendationHistoryMaxAge, cfg.RecommendationHistory.MaxAge)
+	assert.Equal(t, DefaultRecommendationHistoryResolvedDiscount, cfg.RecommendationHistory.ResolvedDiscount)
+
+	os.Setenv("RECOMMENDATION_HISTORY_HALF_LIFE", "48h")
+	os.Setenv("RECOMMENDATION_HISTORY_MAX_AGE", "720h")
+	os.Setenv("RECOMMENDATION_HISTORY_RESOLVED_DISCOUNT", "1")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, cfg.RecommendationHistory.HalfLife)
+	assert.Equal(t, 720*time.Hour, cfg.RecommendationHistory.MaxAge)
+	assert.Equal(t, 1.0, cfg.RecommendationHistory.ResolvedDiscount)
+
+	os.Setenv("RECOMMENDATION_HISTORY_RESOLVED_DISCOUNT", "1.5")
+	_, err = Load()
+	assert.Error(t, err)
+	os.Setenv("RECOMMENDATION_HISTORY_RESOLVED_DISCOUNT", "0.5")
+
+	os.Setenv("RECOMMENDATION_HISTORY_MAX_AGE", "-1h")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestRecommendationMCOGating_FromEnvironment verifies MCO gating is opt-in
+func TestRecommendationMCOGating_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.RecommendationMCOGating)
+
+	os.Setenv("RECOMMENDATION_MCO_GATING_ENABLED", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.RecommendationMCOGating)
+}
+
+// TestRecommendationAckDuration_FromEnvironment verifies the default acknowledgement duration and its bounds
+func TestRecommendationAckDuration_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRecommendationAckDuration, cfg.RecommendationAckDuration)
+
+	os.Setenv("RECOMMENDATION_ACK_DURATION", "4h")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 4*time.Hour, cfg.RecommendationAckDuration)
+
+	os.Setenv("RECOMMENDATION_ACK_DURATION", "800h")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestRecommendationMaxCount_FromEnvironment verifies the recommendation cap default and validation
+func TestRecommendationMaxCount_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRecommendationMaxCount, cfg.RecommendationMaxCount)
+
+	os.Setenv("RECOMMENDATION_MAX_COUNT", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.RecommendationMaxCount)
+
+	os.Setenv("RECOMMENDATION_MAX_COUNT", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestRecommendationMLBatching_FromEnvironment verifies ML batching defaults, overrides and validation
+func TestRecommendationMLBatching_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRecommendationMLBatchSize, cfg.RecommendationMLBatchSize)
+	assert.Equal(t, DefaultRecommendationMLConcurrency, cfg.RecommendationMLConcurrency)
+
+	t.Setenv("RECOMMENDATION_ML_BATCH_SIZE", "1")
+	t.Setenv("RECOMMENDATION_ML_CONCURRENCY", "8")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 1, cfg.RecommendationMLBatchSize)
+	assert.Equal(t, 8, cfg.RecommendationMLConcurrency)
+
+	t.Setenv("RECOMMENDATION_ML_BATCH_SIZE", "-1")
+	_, err = Load()
+	assert.ErrorContains(t, err, "recommendation_ml_batch_size must not be negative")
+}
+
+// TestRecommendationMLConfidenceFloor_FromEnvironment verifies the ML confidence floor default, override and validation
+func TestRecommendationMLConfidenceFloor_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRecommendationMLConfidenceFloor, cfg.RecommendationMLConfidenceFloor)
+
+	t.Setenv("RECOMMENDATION_ML_CONFIDENCE_FLOOR", "0.75")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 0.75, cfg.RecommendationMLConfidenceFloor)
+
+	t.Setenv("RECOMMENDATION_ML_CONFIDENCE_FLOOR", "1.2")
+	_, err = Load()
+	assert.ErrorContains(t, err, "recommendation_ml_confidence_floor must be between 0 and 1")
+}
+
+// TestRecommendationPredictionHorizons_FromEnvironment verifies the timeframe-to-horizon mapping default, override and validation
+func TestRecommendationPredictionHorizons_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, v1.DefaultPredictionHorizons(), cfg.RecommendationPredictionHorizons)
+
+	t.Setenv("RECOMMENDATION_PREDICTION_HORIZONS", "1h=1h,6h=6h,24h=24h")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"1h": time.Hour, "6h": 6 * time.Hour, "24h": 24 * time.Hour}, cfg.RecommendationPredictionHorizons)
+
+	t.Setenv("RECOMMENDATION_PREDICTION_HORIZONS", "1h=1h,6h=6h")
+	_, err = Load()
+	assert.ErrorContains(t, err, `recommendation_prediction_horizons: no prediction horizon for timeframe "24h"`)
+
+	t.Setenv("RECOMMENDATION_PREDICTION_HORIZONS", "1h=90m,6h=6h,24h=24h")
+	_, err = Load()
+	assert.ErrorContains(t, err, `recommendation_prediction_horizons: prediction horizon 1h30m0s for timeframe "1h" must be within (0, 1h]`)
+
+	t.Setenv("RECOMMENDATION_PREDICTION_HORIZONS", "1h=1h,6h=6h,24h=24h,48h=1h")
+	_, err = Load()
+	assert.ErrorContains(t, err, `recommendation_prediction_horizons: unsupported timeframe "48h"`)
+}
+
+// TestIncidentRetentionDaysBySeverity_FromEnvironment verifies per-severity retention parsing and validation
+func TestIncidentRetentionDaysBySeverity_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.IncidentRetentionDaysBySeverity)
+
+	t.Setenv("INCIDENT_RETENTION_DAYS_BY_SEVERITY", "critical=365, low=30")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"critical": 365, "low": 30}, cfg.IncidentRetentionDaysBySeverity)
+
+	t.Setenv("INCIDENT_RETENTION_DAYS_BY_SEVERITY", "severe=365,low=-1")
+	_, err = Load()
+	assert.ErrorContains(t, err, `incident_retention_days_by_severity has invalid severity "severe"`)
+	assert.ErrorContains(t, err, "incident_retention_days_by_severity[low] must not be negative: -1")
+
+	for _, value := range []string{"critical=365,low=thirty", "critical=365,30"} {
+		t.Setenv("INCIDENT_RETENTION_DAYS_BY_SEVERITY", value)
+		_, err = Load()
+		assert.ErrorContains(t, err, "INCIDENT_RETENTION_DAYS_BY_SEVERITY: invalid entry", value)
+	}
+}
+
+// TestMCOPoolHistorySize_FromEnvironment verifies the MachineConfigPool history size default, override and validation
+func TestMCOPoolHistorySize_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, integrations.DefaultPoolHistorySize, cfg.MCOPoolHistorySize)
+	assert.Equal(t, integrations.DefaultPoolHistoryPollInterval, cfg.MCOPoolHistoryPollInterval)
+
+	t.Setenv("MCO_POOL_HISTORY_SIZE", "10")
+	t.Setenv("MCO_POOL_HISTORY_POLL_INTERVAL", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 10, cfg.MCOPoolHistorySize)
+	assert.Zero(t, cfg.MCOPoolHistoryPollInterval, "0 disables polling")
+
+	t.Setenv("MCO_POOL_HISTORY_SIZE", "-1")
+	t.Setenv("MCO_POOL_HISTORY_POLL_INTERVAL", "-1s")
+	_, err = Load()
+	assert.ErrorContains(t, err, "mco_pool_history_size must not be negative")
+	assert.ErrorContains(t, err, "mco_pool_history_poll_interval must not be negative")
+}
+
+// TestPredictionCache_FromEnvironment verifies prediction cache defaults, overrides and validation
+func TestPredictionCache_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPredictionCacheTTL, cfg.PredictionCache.TTL)
+	assert.Equal(t, DefaultPredictionCacheBucket, cfg.PredictionCache.Bucket)
+
+	os.Setenv("PREDICTION_CACHE_TTL", "0s")
+	os.Setenv("PREDICTION_CACHE_BUCKET", "15m")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.PredictionCache.TTL, "0 disables the cache")
+	assert.Equal(t, 15*time.Minute, cfg.PredictionCache.Bucket)
+
+	os.Setenv("PREDICTION_CACHE_TTL", "-5s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestPredictionTargetValidation_FromEnvironment verifies target validation is off by default,
+// can be enabled and rejects a negative cache TTL
+func TestPredictionTargetValidation_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.PredictionTargetValidation.Enabled)
+	assert.Equal(t, DefaultPredictionTargetValidationCacheTTL, cfg.PredictionTargetValidation.CacheTTL)
+
+	os.Setenv("PREDICTION_TARGET_VALIDATION_ENABLED", "true")
+	os.Setenv("PREDICTION_TARGET_VALIDATION_CACHE_TTL", "2m")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.PredictionTargetValidation.Enabled)
+	assert.Equal(t, 2*time.Minute, cfg.PredictionTargetValidation.CacheTTL)
+
+	os.Setenv("PREDICTION_TARGET_VALIDATION_CACHE_TTL", "-1s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestPredictionHistory_FromEnvironment verifies prediction history is off by default, can be
+// enabled and rejects a negative record limit
+func TestPredictionHistory_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.PredictionHistory.Enabled)
+	assert.Equal(t, DefaultPredictionHistoryMaxRecords, cfg.PredictionHistory.MaxRecords)
+
+	os.Setenv("PREDICTION_HISTORY_ENABLED", "true")
+	os.Setenv("PREDICTION_HISTORY_MAX_RECORDS", "500")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.PredictionHistory.Enabled)
+	assert.Equal(t, 500, cfg.PredictionHistory.MaxRecords)
+
+	os.Setenv("PREDICTION_HISTORY_MAX_RECORDS", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+func TestPredictionDegradedMode_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.PredictionDegradedMode.Enabled)
+
+	os.Setenv("PREDICTION_DEGRADED_MODE_ENABLED", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.PredictionDegradedMode.Enabled)
+}
+
+// TestPredictionMetricAggregation_FromEnvironment verifies the aggregation defaults to mean and
+// rejects unknown statistics
+func TestPredictionMetricAggregation_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPredictionMetricAggregation, cfg.PredictionMetricAggregation)
+
+	os.Setenv("PREDICTION_METRIC_AGGREGATION", "p95")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "p95", cfg.PredictionMetricAggregation)
+
+	os.Setenv("PREDICTION_METRIC_AGGREGATION", "median")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestPredictedIncidents_FromEnvironment verifies predicted incidents are off by default and
+// their thresholds are validated when enabled
+func TestPredictedIncidents_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, PredictedIncidentsConfig{
+		CPUThreshold:    DefaultPredictedIncidentsCPUThreshold,
+		MemoryThreshold: DefaultPredictedIncidentsMemoryThreshold,
+		MinConfidence:   DefaultPredictedIncidentsMinConfidence,
+	}, cfg.PredictedIncidents)
+
+	os.Setenv("PREDICTED_INCIDENTS_ENABLED", "true")
+	os.Setenv("PREDICTED_INCIDENTS_CPU_THRESHOLD", "90")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.PredictedIncidents.Enabled)
+	assert.InDelta(t, 90, cfg.PredictedIncidents.CPUThreshold, 0.001)
+
+	for key, value := range map[string]string{
+		"PREDICTED_INCIDENTS_CPU_THRESHOLD":    "150",
+		"PREDICTED_INCIDENTS_MEMORY_THRESHOLD": "0",
+		"PREDICTED_INCIDENTS_MIN_CONFIDENCE":   "1.5",
+	} {
+		clearEnv(t)
+		os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+		os.Setenv("PREDICTED_INCIDENTS_ENABLED", "true")
+		os.Setenv(key, value)
+		_, err = Load()
+		assert.Error(t, err, key)
+	}
+}
+
+// TestCORS_FromEnvironment verifies CORS detail settings and that credentials cannot be combined
+// with a wildcard origin
+func TestCORS_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.CORSAllowMethods)
+	assert.Empty(t, cfg.CORSAllowHeaders)
+	assert.False(t, cfg.CORSAllowCredentials)
+	assert.Equal(t, DefaultCORSMaxAge, cfg.CORSMaxAge)
+
+	os.Setenv("ENABLE_CORS", "true")
+	os.Setenv("CORS_ALLOW_ORIGIN", "https://dashboard.example.com")
+	os.Setenv("CORS_ALLOW_METHODS", "GET,POST,OPTIONS")
+	os.Setenv("CORS_ALLOW_HEADERS", "Content-Type,If-None-Match")
+	os.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	os.Setenv("CORS_MAX_AGE", "10m")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET", "POST", "OPTIONS"}, cfg.CORSAllowMethods)
+	assert.Equal(t, []string{"Content-Type", "If-None-Match"}, cfg.CORSAllowHeaders)
+	assert.True(t, cfg.CORSAllowCredentials)
+	assert.Equal(t, 10*time.Minute, cfg.CORSMaxAge)
+
+	os.Setenv("CORS_ALLOW_ORIGIN", "*")
+	_, err = Load()
+	assert.Error(t, err, "credentials with a wildcard origin")
+
+	os.Setenv("ENABLE_CORS", "false")
+	_, err = Load()
+	assert.NoError(t, err, "ignored while CORS is disabled")
+}
+
+// TestPredictionConcurrency_FromEnvironment verifies the concurrency defaults, overrides and
+// that negative values are rejected
+func TestPredictionConcurrency_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPredictionMaxConcurrent, cfg.PredictionConcurrency.MaxConcurrent)
+	assert.Equal(t, DefaultPredictionQueueTimeout, cfg.PredictionConcurrency.QueueTimeout)
+
+	os.Setenv("PREDICTION_MAX_CONCURRENT", "0")
+	os.Setenv("PREDICTION_QUEUE_TIMEOUT", "0s")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.PredictionConcurrency.MaxConcurrent)
+	assert.Equal(t, time.Duration(0), cfg.PredictionConcurrency.QueueTimeout)
+
+	os.Setenv("PREDICTION_MAX_CONCURRENT", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+
+	os.Setenv("PREDICTION_MAX_CONCURRENT", "4")
+	os.Setenv("PREDICTION_QUEUE_TIMEOUT", "-1s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestPredictionBaseline_FromEnvironment verifies learned baseline defaults, overrides and validation
+func TestPredictionBaseline_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPredictionBaselineAlpha, cfg.PredictionBaseline.Alpha)
+	assert.Equal(t, DefaultPredictionBaselineMaxEntries, cfg.PredictionBaseline.MaxEntries)
+	assert.Equal(t, DefaultPredictionBaselinePersistInterval, cfg.PredictionBaseline.PersistInterval)
+
+	os.Setenv("PREDICTION_BASELINE_ALPHA", "0.5")
+	os.Setenv("PREDICTION_BASELINE_MAX_ENTRIES", "50")
+	os.Setenv("PREDICTION_BASELINE_PERSIST_INTERVAL", "0s")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, cfg.PredictionBaseline.Alpha)
+	assert.Equal(t, 50, cfg.PredictionBaseline.MaxEntries)
+	assert.Zero(t, cfg.PredictionBaseline.PersistInterval)
+
+	os.Setenv("PREDICTION_BASELINE_PERSIST_INTERVAL", "-1m")
+	_, err = Load()
+	assert.Error(t, err)
+	os.Setenv("PREDICTION_BASELINE_PERSIST_INTERVAL", "")
+
+	os.Setenv("PREDICTION_BASELINE_ALPHA", "1.5")
+	_, err = Load()
+	assert.Error(t, err)
+
+	os.Setenv("PREDICTION_BASELINE_ALPHA", "0.5")
+	os.Setenv("PREDICTION_BASELINE_MAX_ENTRIES", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestServerLimits_FromEnvironment verifies HTTP server timeout and body limit settings
+func TestServerLimits_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultServerReadTimeout, cfg.ServerReadTimeout)
+	assert.Equal(t, DefaultServerWriteTimeout, cfg.ServerWriteTimeout)
+	assert.Equal(t, int64(DefaultMaxRequestBodyBytes), cfg.MaxRequestBodyBytes)
+
+	os.Setenv("SERVER_READ_TIMEOUT", "5s")
+	os.Setenv("SERVER_WRITE_TIMEOUT", "45s")
+	os.Setenv("MAX_REQUEST_BODY_BYTES", "4096")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.ServerReadTimeout)
+	assert.Equal(t, 45*time.Second, cfg.ServerWriteTimeout)
+	assert.Equal(t, int64(4096), cfg.MaxRequestBodyBytes)
+
+	os.Setenv("MAX_REQUEST_BODY_BYTES", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+
+	os.Setenv("MAX_REQUEST_BODY_BYTES", "4096")
+	os.Setenv("SERVER_WRITE_TIMEOUT", "-1s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestMetricsSnapshotTTL_FromEnvironment verifies the shared metrics snapshot TTL setting
+func TestMetricsSnapshotTTL_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMetricsSnapshotTTL, cfg.MetricsSnapshotTTL)
+
+	os.Setenv("METRICS_SNAPSHOT_TTL", "0s")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.MetricsSnapshotTTL, "0 keeps single-flight only")
+
+	os.Setenv("METRICS_SNAPSHOT_TTL", "-1s")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+// TestGetEnvAsIntSlice tests integer list parsing
+func TestGetEnvAsIntSlice(t *testing.T) {
+	defer os.Unsetenv("TEST_INT_SLICE")
+
+	os.Setenv("TEST_INT_SLICE", "1,2,3")
+	assert.Equal(t, []int{1, 2, 3}, getEnvAsIntSlice("TEST_INT_SLICE", []int{9}))
+
+	os.Setenv("TEST_INT_SLICE", "1,x")
+	assert.Equal(t, []int{9}, getEnvAsIntSlice("TEST_INT_SLICE", []int{9}))
+
+	os.Unsetenv("TEST_INT_SLICE")
+	assert.Equal(t, []int{9}, getEnvAsIntSlice("TEST_INT_SLICE", []int{9}))
+}
+
+// TestKServeModelTimeouts_FromEnvironment verifies per-model timeout overrides are parsed and validated
+func TestKServeModelTimeouts_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	os.Setenv("KSERVE_MODEL_TIMEOUTS", "predictive-analytics=45s, anomaly-detector=3s")
+	defer clearEnv(t)
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.model_timeouts[predictive-analytics] 45s must not exceed server_write_timeout 15s")
+
+	os.Setenv("SERVER_WRITE_TIMEOUT", "1m")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"predictive-analytics": 45 * time.Second,
+		"anomaly-detector":     3 * time.Second,
+	}, cfg.KServe.ModelTimeouts)
+	assert.Empty(t, cfg.KServe.DynamicServices, "timeouts must not be discovered as services")
+
+	os.Setenv("KSERVE_MODEL_TIMEOUTS", "predictive-analytics=5m")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.model_timeouts[predictive-analytics]")
+
+	os.Setenv("KSERVE_MODEL_TIMEOUTS", "predictive-analytics")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.KServe.ModelTimeouts, "unparsable entries fall back to no overrides")
+}
+
+// TestKServeRegression_FromEnvironment verifies regression output mapping is read from env
+func TestKServeRegression_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	os.Setenv("KSERVE_REGRESSION_MODELS", "resource-regressor, capacity-regressor")
+	os.Setenv("KSERVE_REGRESSION_CPU_INDEX", "1")
+	os.Setenv("KSERVE_REGRESSION_MEMORY_INDEX", "0")
+	os.Setenv("KSERVE_REGRESSION_SCALE", "100")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"resource-regressor", "capacity-regressor"}, cfg.KServe.Regression.Models)
+	assert.Equal(t, 1, cfg.KServe.Regression.CPUIndex)
+	assert.Equal(t, 0, cfg.KServe.Regression.MemoryIndex)
+	assert.Equal(t, 100.0, cfg.KServe.Regression.Scale)
+	assert.Empty(t, cfg.KServe.DynamicServices, "regression settings must not be discovered as services")
+}
+
+// TestKServeForecast_FromEnvironment verifies forecast response keys are read from env
+func TestKServeForecast_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu_usage"}, cfg.KServe.Forecast.CPUKeys)
+	assert.Equal(t, []string{"memory_usage"}, cfg.KServe.Forecast.MemoryKeys)
+
+	os.Setenv("KSERVE_FORECAST_CPU_KEYS", "cpu_usage, cpu")
+	os.Setenv("KSERVE_FORECAST_MEMORY_KEYS", "memory")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu_usage", "cpu"}, cfg.KServe.Forecast.CPUKeys)
+	assert.Equal(t, []string{"memory"}, cfg.KServe.Forecast.MemoryKeys)
+
+	os.Setenv("KSERVE_FORECAST_MEMORY_KEYS", "cpu")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.forecast")
+}
+
+func TestKServeForecastThreshold_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultKServeForecastStep, cfg.KServe.Forecast.Step)
+	assert.Equal(t, DefaultKServeForecastThresholdPercent, cfg.KServe.Forecast.ThresholdPercent)
+
+	os.Setenv("KSERVE_FORECAST_STEP", "30m")
+	os.Setenv("KSERVE_FORECAST_THRESHOLD_PERCENT", "80")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, cfg.KServe.Forecast.Step)
+	assert.Equal(t, 80.0, cfg.KServe.Forecast.ThresholdPercent)
+
+	os.Setenv("KSERVE_FORECAST_THRESHOLD_PERCENT", "120")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.forecast.threshold_percent")
+
+	os.Setenv("KSERVE_FORECAST_THRESHOLD_PERCENT", "80")
+	os.Setenv("KSERVE_FORECAST_STEP", "-1h")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.forecast.step")
+}
+
+func TestKServeDebugRawResponse_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.KServe.DebugRawResponse)
+	assert.Empty(t, cfg.KServe.DynamicServices)
+
+	os.Setenv("KSERVE_DEBUG_RAW_RESPONSE", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.KServe.DebugRawResponse)
+	assert.Empty(t, cfg.KServe.DynamicServices, "the toggle is not a KServe service")
+}
+
+func TestKServeReachabilityTTL_FromEnvironment(t *testing.T) {
+	clearEnv(t)
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	defer clearEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultKServeReachabilityTTL, cfg.KServe.ReachabilityTTL)
+
+	os.Setenv("KSERVE_REACHABILITY_TTL", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.KServe.ReachabilityTTL)
+	assert.Empty(t, cfg.KServe.DynamicServices)
+
+	os.Setenv("KSERVE_REACHABILITY_TTL", "-1s")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.reachability_ttl")
+}
+
+// TestKServeRegression_Validation verifies invalid regression mappings are rejected
+func TestKServeRegression_Validation(t *testing.T) {
+	clearEnv(t)
+
+	os.Setenv("KSERVE_ANOMALY_DETECTOR_SERVICE", "anomaly-detector-predictor")
+	os.Setenv("KSERVE_REGRESSION_MODELS", "resource-regressor")
+	os.Setenv("KSERVE_REGRESSION_SCALE", "0")
+	defer clearEnv(t)
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kserve.regression.scale")
+}
@@ -0,0 +1,140 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RangeQuery is one range query of a batch
+type RangeQuery struct {
+	Query      string
+	Start, End time.Time
+	Step       time.Duration
+}
+
+// BatchMetricDataProvider is a MetricDataProvider that can run many range queries at once.
+// When the builder's provider implements it, the queries a build needs together (the
+// resampled series of every metric, or the current value, lags and rolling windows of one
+// metric at one timestep) are submitted as one batch instead of one after another.
+type BatchMetricDataProvider interface {
+	MetricDataProvider
+
+	// QueryRangeBatch runs queries and returns their points in query order. When some queries
+	// fail the error is a *BatchQueryError; the points of the others are still returned.
+	QueryRangeBatch(ctx context.Context, queries []RangeQuery) ([][]DataPoint, error)
+}
+
+// BatchQueryError reports the failed queries of a batch
+type BatchQueryError struct {
+	// Errs holds each query's error in query order, nil for queries that succeeded
+	Errs []error
+}
+
+// newBatchQueryError returns a *BatchQueryError for errs, or nil when every query succeeded
+func newBatchQueryError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return &BatchQueryError{Errs: errs}
+		}
+	}
+	return nil
+}
+
+// Error implements error, reporting the failure count and the first failure
+func (e *BatchQueryError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e.Errs {
+		if err != nil {
+			failed++
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return fmt.Sprintf("%d of %d range queries failed: %v", failed, len(e.Errs), first)
+}
+
+// Unwrap returns the failures, so errors.Is and errors.As see each of them
+func (e *BatchQueryError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// rangeRequest is a range query of one metric issued while building features
+type rangeRequest struct {
+	query      metricQuery
+	start, end time.Time
+	step       time.Duration
+
+	// logFields describe the query in query logs (only set when LogQueries is on)
+	logFields logrus.Fields
+}
+
+// queryRangeBatch runs requests, as one batch when the provider supports it, and returns each
+// request's finite points or error. Every request is logged and counted like a single query.
+func (b *PredictiveFeatureBuilder) queryRangeBatch(ctx context.Context, requests []rangeRequest) ([][]DataPoint, []error) {
+	points, errs := b.executeRangeBatch(ctx, requests)
+	for i, request := range requests {
+		if b.config.LogQueries {
+			last := 0.0
+			if len(points[i]) > 0 {
+				last = points[i][len(points[i])-1].Value
+			}
+			b.logQuery(ctx, request.query, request.logFields, len(points[i]), last, errs[i])
+		}
+		if errs[i] != nil {
+			points[i] = nil
+			b.recordQueryResult(request.query.metric, true)
+			continue
+		}
+		points[i] = b.finitePoints(ctx, request.query, points[i])
+		b.recordQueryResult(request.query.metric, len(points[i]) == 0)
+	}
+	return points, errs
+}
+
+// executeRangeBatch sends requests to the provider, batched when it implements
+// BatchMetricDataProvider and there is more than one
+func (b *PredictiveFeatureBuilder) executeRangeBatch(ctx context.Context, requests []rangeRequest) ([][]DataPoint, []error) {
+	errs := make([]error, len(requests))
+	batcher, ok := b.provider.(BatchMetricDataProvider)
+	if !ok || len(requests) < 2 {
+		points := make([][]DataPoint, len(requests))
+		for i, request := range requests {
+			points[i], errs[i] = b.provider.QueryRange(ctx, request.query.promql, request.start, request.end, request.step)
+		}
+		return points, errs
+	}
+
+	queries := make([]RangeQuery, len(requests))
+	for i, request := range requests {
+		queries[i] = RangeQuery{Query: request.query.promql, Start: request.start, End: request.end, Step: request.step}
+	}
+	points, err := batcher.QueryRangeBatch(ctx, queries)
+	var batchErr *BatchQueryError
+	switch {
+	case len(points) == len(requests) && err == nil:
+	case len(points) == len(requests) && errors.As(err, &batchErr) && len(batchErr.Errs) == len(requests):
+		copy(errs, batchErr.Errs)
+	default:
+		// The batch as a whole failed or returned a malformed result, so every query failed
+		if err == nil {
+			err = fmt.Errorf("batch returned %d results for %d queries", len(points), len(requests))
+		}
+		points = make([][]DataPoint, len(requests))
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+	return points, errs
+}
@@ -0,0 +1,196 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
+)
+
+// batchingProvider records the batches the builder submits and fails queries matching failing
+type batchingProvider struct {
+	MockMetricDataProvider
+	failing string
+
+	mu      sync.Mutex
+	batches []int
+}
+
+func (p *batchingProvider) QueryRangeBatch(ctx context.Context, queries []RangeQuery) ([][]DataPoint, error) {
+	p.mu.Lock()
+	p.batches = append(p.batches, len(queries))
+	p.mu.Unlock()
+
+	results := make([][]DataPoint, len(queries))
+	errs := make([]error, len(queries))
+	for i, query := range queries {
+		if p.failing != "" && strings.Contains(query.Query, p.failing) {
+			errs[i] = fmt.Errorf("query failed")
+			continue
+		}
+		results[i], errs[i] = p.QueryRange(ctx, query.Query, query.Start, query.End, query.Step)
+	}
+	return results, newBatchQueryError(errs)
+}
+
+func TestBuildFeatures_BatchesRangeQueries(t *testing.T) {
+	constant := func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+		return []DataPoint{{Timestamp: start, Value: 0.3}, {Timestamp: end, Value: 0.3}}, nil
+	}
+
+	t.Run("resampled series in one batch", func(t *testing.T) {
+		provider := &batchingProvider{
+			MockMetricDataProvider: MockMetricDataProvider{IsAvailableResult: true, QueryRangeFunc: constant},
+			failing:                "node_filesystem",
+		}
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, ResampleRule: time.Hour}, logrus.New())
+		require.NoError(t, err)
+
+		vector, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+		require.NoError(t, err)
+		assert.Equal(t, []int{len(predictiveBaseMetrics)}, provider.batches)
+		assert.Equal(t, []string{"disk_usage"}, vector.DefaultedMetrics, "only the failed query's metric is defaulted")
+		assert.InDelta(t, 0.3, vector.MetricsData["cpu_usage"], 0.001)
+		assert.Positive(t, builder.QueryStats()["disk_usage"].Failed)
+	})
+
+	t.Run("query set of each metric and timestep in one batch", func(t *testing.T) {
+		var pointQueries atomic.Int32
+		provider := &batchingProvider{
+			MockMetricDataProvider: MockMetricDataProvider{
+				IsAvailableResult: true,
+				QueryRangeFunc:    constant,
+				QueryAtFunc: func(context.Context, string, time.Time) (float64, error) {
+					pointQueries.Add(1)
+					return 0.3, nil
+				},
+			},
+		}
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, logrus.New())
+		require.NoError(t, err)
+
+		_, err = builder.BuildFeatures(context.Background(), "payments", "", "")
+		require.NoError(t, err)
+		// Raw metric values are queried on their own; buildMetricFeatures batches everything
+		require.Len(t, provider.batches, 2*len(predictiveBaseMetrics))
+		for _, size := range provider.batches {
+			assert.Equal(t, 1+len(DefaultLagPeriods)+len(DefaultRollingWindows), size)
+		}
+		assert.Equal(t, int32(2*len(predictiveBaseMetrics)), pointQueries.Load())
+	})
+
+	t.Run("failed point query retried on its own", func(t *testing.T) {
+		var pointQueries atomic.Int32
+		provider := &batchingProvider{
+			MockMetricDataProvider: MockMetricDataProvider{
+				IsAvailableResult: true,
+				QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+					if start.Equal(end) {
+						return nil, nil // No sample at that time
+					}
+					return constant(ctx, query, start, end, step)
+				},
+				QueryAtFunc: func(context.Context, string, time.Time) (float64, error) {
+					pointQueries.Add(1)
+					return 0.7, nil
+				},
+			},
+		}
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+		require.NoError(t, err)
+
+		features, current, err := builder.buildMetricFeatures(context.Background(), builder.newMetricQuery("cpu_usage", "payments", "", ""), time.Now())
+		require.NoError(t, err)
+		assert.InDelta(t, 0.7, current, 0.001)
+		assert.InDelta(t, 0.7, features[1], 0.001, "lag from the fallback query")
+		assert.Equal(t, int32(1+len(DefaultLagPeriods)), pointQueries.Load())
+	})
+
+	t.Run("failed batch fails every query", func(t *testing.T) {
+		provider := &failingBatchProvider{MockMetricDataProvider: MockMetricDataProvider{IsAvailableResult: true}}
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, logrus.New())
+		require.NoError(t, err)
+
+		points, errs := builder.queryRangeBatch(context.Background(), []rangeRequest{{query: metricQuery{metric: "cpu_usage"}}, {query: metricQuery{metric: "memory_usage"}}})
+		assert.Equal(t, [][]DataPoint{nil, nil}, points)
+		for _, err := range errs {
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+	})
+}
+
+// failingBatchProvider fails whole batches
+type failingBatchProvider struct {
+	MockMetricDataProvider
+}
+
+func (*failingBatchProvider) QueryRangeBatch(context.Context, []RangeQuery) ([][]DataPoint, error) {
+	return nil, context.DeadlineExceeded
+}
+
+func TestPrometheusAdapter_QueryRangeBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		query := r.FormValue("query")
+		if strings.HasPrefix(query, "bad") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1700000000,"%s"]]}]}}`,
+			strings.TrimPrefix(query, "q"))
+	}))
+	defer server.Close()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	adapter := NewPrometheusAdapter(integrations.NewPrometheusClient(server.URL, 5*time.Second, log))
+
+	end := time.Unix(1700000000, 0)
+	queries := make([]RangeQuery, 10)
+	for i := range queries {
+		queries[i] = RangeQuery{Query: fmt.Sprintf("q%d", i), Start: end.Add(-time.Hour), End: end, Step: time.Minute}
+	}
+	queries[3].Query = "bad query"
+
+	results, err := adapter.QueryRangeBatch(context.Background(), queries)
+	var batchErr *BatchQueryError
+	require.True(t, errors.As(err, &batchErr), "%v", err)
+	require.Len(t, batchErr.Errs, len(queries))
+	require.Len(t, results, len(queries))
+	for i, points := range results {
+		if i == 3 {
+			assert.Error(t, batchErr.Errs[i])
+			assert.Empty(t, points)
+			continue
+		}
+		assert.NoError(t, batchErr.Errs[i])
+		require.Len(t, points, 1)
+		assert.InDelta(t, float64(i), points[0].Value, 0.001, "results keep query order")
+	}
+	assert.Contains(t, err.Error(), "1 of 10 range queries failed")
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(QueryBatchConcurrency))
+	assert.Greater(t, maxInFlight.Load(), int32(1), "queries run concurrently")
+}
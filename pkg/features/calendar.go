@@ -0,0 +1,95 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default business calendar of the training notebook: 09:00-17:00, Saturday and Sunday off
+const (
+	DefaultBusinessHoursStart = 9
+	DefaultBusinessHoursEnd   = 17
+)
+
+// DefaultWeekendDays are the weekend days used when none are configured
+var DefaultWeekendDays = []string{"saturday", "sunday"}
+
+// timeCalendar decides is_weekend and is_business_hours
+type timeCalendar struct {
+	businessStart int // First business hour, 0-23
+	businessEnd   int // Hour business ends, exclusive, 1-24
+	businessDays  [7]bool
+	weekendDays   [7]bool
+}
+
+// newCalendar builds the calendar for c. Empty WeekendDays means Saturday and Sunday, empty
+// BusinessDays means every day that is not a weekend day, and zero business hours mean 9-17.
+func newCalendar(c PredictiveFeatureConfig) (timeCalendar, error) {
+	cal := timeCalendar{businessStart: c.BusinessHoursStart, businessEnd: c.BusinessHoursEnd}
+	if cal.businessStart == 0 && cal.businessEnd == 0 {
+		cal.businessStart, cal.businessEnd = DefaultBusinessHoursStart, DefaultBusinessHoursEnd
+	}
+	if cal.businessStart < 0 || cal.businessEnd > 24 || cal.businessStart >= cal.businessEnd {
+		return timeCalendar{}, fmt.Errorf("business hours must satisfy 0 <= start < end <= 24: %d-%d", cal.businessStart, cal.businessEnd)
+	}
+
+	weekendDays := c.WeekendDays
+	if len(weekendDays) == 0 {
+		weekendDays = DefaultWeekendDays
+	}
+	weekend, err := parseWeekdaySet(weekendDays)
+	if err != nil {
+		return timeCalendar{}, fmt.Errorf("invalid weekend days: %w", err)
+	}
+	cal.weekendDays = weekend
+
+	if len(c.BusinessDays) == 0 {
+		for day := range cal.businessDays {
+			cal.businessDays[day] = !weekend[day]
+		}
+		return cal, nil
+	}
+	business, err := parseWeekdaySet(c.BusinessDays)
+	if err != nil {
+		return timeCalendar{}, fmt.Errorf("invalid business days: %w", err)
+	}
+	cal.businessDays = business
+	return cal, nil
+}
+
+func (c timeCalendar) isWeekend(t time.Time) bool {
+	return c.weekendDays[t.Weekday()]
+}
+
+func (c timeCalendar) isBusinessHours(t time.Time) bool {
+	return c.businessDays[t.Weekday()] && t.Hour() >= c.businessStart && t.Hour() < c.businessEnd
+}
+
+// parseWeekdaySet parses English day names or their three-letter abbreviations, in any case
+func parseWeekdaySet(names []string) ([7]bool, error) {
+	var days [7]bool
+	for _, name := range names {
+		day, err := ParseWeekday(name)
+		if err != nil {
+			return days, err
+		}
+		if days[day] {
+			return days, fmt.Errorf("duplicate day %q", name)
+		}
+		days[day] = true
+	}
+	return days, nil
+}
+
+// ParseWeekday parses an English day name ("friday") or its abbreviation ("fri"), in any case
+func ParseWeekday(name string) (time.Weekday, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if normalized == full || normalized == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", name)
+}
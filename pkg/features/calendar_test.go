@@ -0,0 +1,90 @@
+package features
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calendarFeatures returns is_weekend and is_business_hours at t for a builder using config
+func calendarFeatures(t *testing.T, config PredictiveFeatureConfig, at time.Time) (weekend, business float64) {
+	t.Helper()
+	config.TimeFeatures = []string{"is_weekend", "is_business_hours"}
+	builder, err := NewPredictiveFeatureBuilder(&MockMetricDataProvider{IsAvailableResult: true}, config, logrus.New())
+	require.NoError(t, err)
+
+	features := builder.buildTimeFeatures(at)
+	return features[0], features[1]
+}
+
+func TestTimeCalendar_Configured(t *testing.T) {
+	friday := time.Date(2026, 2, 6, 10, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, 2, 8, 10, 0, 0, 0, time.UTC)
+
+	config := DefaultPredictiveConfig()
+	config.WeekendDays = []string{"Friday", "sat"}
+	config.BusinessHoursStart = 8
+	config.BusinessHoursEnd = 20
+
+	weekend, business := calendarFeatures(t, config, friday)
+	assert.Equal(t, 1.0, weekend)
+	assert.Equal(t, 0.0, business, "business days default to the days outside the weekend")
+
+	weekend, business = calendarFeatures(t, config, sunday)
+	assert.Equal(t, 0.0, weekend)
+	assert.Equal(t, 1.0, business)
+
+	_, business = calendarFeatures(t, config, sunday.Add(10*time.Hour))
+	assert.Equal(t, 0.0, business, "20:00 is past the end hour")
+
+	// Explicit business days are independent of the weekend
+	config.BusinessDays = []string{"mon", "tue", "wed", "thu", "fri"}
+	weekend, business = calendarFeatures(t, config, friday)
+	assert.Equal(t, 1.0, weekend)
+	assert.Equal(t, 1.0, business)
+}
+
+func TestTimeCalendar_Default(t *testing.T) {
+	monday := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	for hour, want := range map[int]float64{8: 0, 9: 1, 16: 1, 17: 0} {
+		_, business := calendarFeatures(t, DefaultPredictiveConfig(), monday.Add(time.Duration(hour)*time.Hour))
+		assert.Equal(t, want, business, "hour %d", hour)
+	}
+}
+
+func TestPredictiveFeatureConfig_CalendarValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*PredictiveFeatureConfig)
+	}{
+		{name: "end before start", modify: func(c *PredictiveFeatureConfig) { c.BusinessHoursStart, c.BusinessHoursEnd = 17, 9 }},
+		{name: "end past midnight", modify: func(c *PredictiveFeatureConfig) { c.BusinessHoursEnd = 25 }},
+		{name: "unknown weekend day", modify: func(c *PredictiveFeatureConfig) { c.WeekendDays = []string{"caturday"} }},
+		{name: "duplicate business day", modify: func(c *PredictiveFeatureConfig) { c.BusinessDays = []string{"mon", "Monday"} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultPredictiveConfig()
+			tt.modify(&config)
+			assert.Error(t, config.Validate())
+		})
+	}
+
+	config := DefaultPredictiveConfig()
+	config.BusinessHoursStart, config.BusinessHoursEnd = 0, 24
+	assert.NoError(t, config.Validate(), "round-the-clock business hours")
+}
+
+func TestParseWeekday(t *testing.T) {
+	for name, want := range map[string]time.Weekday{"sunday": time.Sunday, "SAT": time.Saturday, " Fri ": time.Friday} {
+		day, err := ParseWeekday(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, day, name)
+	}
+
+	_, err := ParseWeekday("fr")
+	assert.Error(t, err)
+}
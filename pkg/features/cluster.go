@@ -0,0 +1,77 @@
+package features
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Cluster aggregation strategies for cluster-scope builds, i.e. builds without a namespace,
+// deployment or pod filter
+const (
+	// ClusterAggregationMean averages every container in the cluster, so a few saturated
+	// namespaces can disappear into a large idle fleet
+	ClusterAggregationMean = "mean"
+
+	// ClusterAggregationTopNamespaces averages the per-namespace means of the
+	// ClusterTopNamespaces busiest namespaces, so the prediction follows the hot spots
+	ClusterAggregationTopNamespaces = "top_namespaces"
+
+	// ClusterAggregationWeighted averages the per-namespace means weighted by themselves,
+	// so busier namespaces count more without dropping the rest
+	ClusterAggregationWeighted = "weighted"
+)
+
+// DefaultClusterTopNamespaces is the number of namespaces ClusterAggregationTopNamespaces averages
+const DefaultClusterTopNamespaces = 5
+
+// ClusterAggregationStrategies returns the accepted ClusterAggregation values
+func ClusterAggregationStrategies() []string {
+	return []string{ClusterAggregationMean, ClusterAggregationTopNamespaces, ClusterAggregationWeighted}
+}
+
+// validateClusterAggregation checks the strategy name and the namespace count
+func validateClusterAggregation(strategy string, topNamespaces int) error {
+	if strategy != "" && !slices.Contains(ClusterAggregationStrategies(), strategy) {
+		return fmt.Errorf("unknown cluster aggregation %q (supported: %v)", strategy, ClusterAggregationStrategies())
+	}
+	if topNamespaces < 0 {
+		return fmt.Errorf("cluster top namespaces must not be negative: %d", topNamespaces)
+	}
+	return nil
+}
+
+// clusterAggregation returns the configured strategy, or ClusterAggregationMean when none is set
+func (c PredictiveFeatureConfig) clusterAggregation() string {
+	if c.ClusterAggregation == "" {
+		return ClusterAggregationMean
+	}
+	return c.ClusterAggregation
+}
+
+// clusterTopNamespaces returns the effective namespace count for ClusterAggregationTopNamespaces
+func (c PredictiveFeatureConfig) clusterTopNamespaces() int {
+	if c.ClusterTopNamespaces > 0 {
+		return c.ClusterTopNamespaces
+	}
+	return DefaultClusterTopNamespaces
+}
+
+// aggregate wraps a namespaced container series in the aggregation for its scope. Scoped
+// queries, and cluster queries with the mean strategy, average all matching series.
+func (b *PredictiveFeatureBuilder) aggregate(series string, cluster bool) string {
+	if !cluster {
+		return fmt.Sprintf("avg(%s)", series)
+	}
+
+	byNamespace := fmt.Sprintf("avg by (namespace) (%s)", series)
+	switch b.config.clusterAggregation() {
+	case ClusterAggregationTopNamespaces:
+		return fmt.Sprintf("avg(topk(%d, %s))", b.config.clusterTopNamespaces(), byNamespace)
+	case ClusterAggregationWeighted:
+		// Σ m² / Σ m over the namespace means m; NaN when every namespace is idle, which the
+		// builder treats as missing data
+		return fmt.Sprintf("(sum((%s) ^ 2) / sum(%s))", byNamespace, byNamespace)
+	default:
+		return fmt.Sprintf("avg(%s)", series)
+	}
+}
@@ -0,0 +1,102 @@
+package features
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Query types of a QueryPlanEntry; they match the query_type field of LogQueries entries
+const (
+	QueryTypeInstantAt     = "instant_at"
+	QueryTypeRange         = "range"
+	QueryTypeResampleRange = "resample_range"
+
+	// QueryTypePointRange is a value at one time fetched as a single-point range query, so
+	// it can join a BatchMetricDataProvider batch
+	QueryTypePointRange = "point_range"
+)
+
+// QueryPlanEntry is one Prometheus query a feature build would execute. Instant queries set
+// At; range queries set Start, End and Step.
+type QueryPlanEntry struct {
+	Metric string     `json:"metric"`
+	Type   string     `json:"type"`
+	PromQL string     `json:"promql"`
+	At     *time.Time `json:"at,omitempty"`
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+	Step   string     `json:"step,omitempty"`
+}
+
+// PlanQueries returns the queries BuildFeatures would execute for the scope right now, in
+// execution order, without executing them. Repeated queries are listed each time they would
+// run. The fallback instant queries issued when a query at a past time returns no data are
+// not included, since they depend on the data.
+func (b *PredictiveFeatureBuilder) PlanQueries(ctx context.Context, namespace, deployment, pod string) ([]QueryPlanEntry, error) {
+	if err := ValidateScopeIdentifiers(namespace, deployment, pod); err != nil {
+		return nil, err
+	}
+
+	now := b.now()
+	metrics := b.config.baseMetrics()
+	queries := make([]metricQuery, len(metrics))
+	for i, metric := range metrics {
+		queries[i] = b.newMetricQuery(metric, namespace, deployment, pod)
+	}
+
+	var plan []QueryPlanEntry
+	step := b.queryStep(ctx)
+	if b.config.ResampleRule > 0 {
+		// One range query per metric covers the whole window
+		start, _ := b.resampleRange(ctx, now)
+		for _, query := range queries {
+			plan = append(plan, rangePlanEntry(query, QueryTypeResampleRange, start, now, step))
+		}
+	} else {
+		// A batching provider fetches buildMetricFeatures' values at one time as range queries
+		pointEntry := instantPlanEntry
+		if _, batched := b.provider.(BatchMetricDataProvider); batched {
+			pointEntry = func(query metricQuery, at time.Time) QueryPlanEntry {
+				return rangePlanEntry(query, QueryTypePointRange, at, at, step)
+			}
+		}
+		for hourOffset := 0; hourOffset < b.config.LookbackHours; hourOffset++ {
+			timestamp := now.Add(-time.Duration(hourOffset) * time.Hour)
+
+			// Raw metric values, then buildMetricFeatures' queries for each metric
+			for _, query := range queries {
+				plan = append(plan, instantPlanEntry(query, timestamp))
+			}
+			for _, query := range queries {
+				plan = append(plan, pointEntry(query, timestamp))
+				for _, lag := range b.config.lagPeriods() {
+					plan = append(plan, pointEntry(query, timestamp.Add(-time.Duration(lag)*time.Hour)))
+				}
+				for _, window := range b.config.rollingWindows() {
+					plan = append(plan, rangePlanEntry(query, QueryTypeRange, timestamp.Add(-time.Duration(window)*time.Hour), timestamp, step))
+				}
+			}
+		}
+	}
+
+	b.log.WithContext(ctx).WithFields(logrus.Fields{
+		"namespace":     namespace,
+		"deployment":    deployment,
+		"pod":           pod,
+		"query_count":   len(plan),
+		"resample_rule": b.resampleRuleName(),
+		"query_step":    step.String(),
+	}).Debug("Planned feature build queries")
+
+	return plan, nil
+}
+
+func instantPlanEntry(query metricQuery, at time.Time) QueryPlanEntry {
+	return QueryPlanEntry{Metric: query.metric, Type: QueryTypeInstantAt, PromQL: query.promql, At: &at}
+}
+
+func rangePlanEntry(query metricQuery, queryType string, start, end time.Time, step time.Duration) QueryPlanEntry {
+	return QueryPlanEntry{Metric: query.metric, Type: queryType, PromQL: query.promql, Start: &start, End: &end, Step: step.String()}
+}
@@ -0,0 +1,140 @@
+package features
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingProvider answers every query and records it as a QueryPlanEntry
+func recordingProvider() (*MockMetricDataProvider, func() []QueryPlanEntry) {
+	var mu sync.Mutex
+	var executed []QueryPlanEntry
+	record := func(entry QueryPlanEntry) {
+		mu.Lock()
+		defer mu.Unlock()
+		entry.Metric = "" // The provider only sees PromQL
+		executed = append(executed, entry)
+	}
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryAtFunc: func(_ context.Context, query string, at time.Time) (float64, error) {
+			record(QueryPlanEntry{Type: QueryTypeInstantAt, PromQL: query, At: &at})
+			return 0.5, nil
+		},
+		QueryRangeFunc: func(_ context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			record(QueryPlanEntry{PromQL: query, Start: &start, End: &end, Step: step.String()})
+			return []DataPoint{{Timestamp: end, Value: 0.5}}, nil
+		},
+	}
+	return provider, func() []QueryPlanEntry {
+		mu.Lock()
+		defer mu.Unlock()
+		return executed
+	}
+}
+
+// TestPlanQueries verifies the plan lists exactly the queries a build executes
+func TestPlanQueries(t *testing.T) {
+	now := time.Date(2026, 3, 16, 14, 30, 0, 0, time.UTC)
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	tests := []struct {
+		name      string
+		config    PredictiveFeatureConfig
+		wantCount int
+	}{
+		{
+			name:      "point queries",
+			config:    PredictiveFeatureConfig{LookbackHours: 2, Enabled: true},
+			wantCount: 2 * len(predictiveBaseMetrics) * (1 + 1 + len(DefaultLagPeriods) + len(DefaultRollingWindows)),
+		},
+		{
+			name:      "custom periods",
+			config:    PredictiveFeatureConfig{LookbackHours: 1, Enabled: true, LagPeriods: []int{1, 24}, RollingWindows: []int{6}},
+			wantCount: len(predictiveBaseMetrics) * (1 + 1 + 2 + 1),
+		},
+		{
+			name:      "resampled",
+			config:    PredictiveFeatureConfig{LookbackHours: 24, Enabled: true, ResampleRule: time.Hour},
+			wantCount: len(predictiveBaseMetrics),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, executed := recordingProvider()
+			builder, err := NewPredictiveFeatureBuilder(provider, tt.config, log)
+			require.NoError(t, err)
+			builder.SetClock(func() time.Time { return now })
+
+			plan, err := builder.PlanQueries(context.Background(), "payments", "api", "")
+			require.NoError(t, err)
+			require.Len(t, plan, tt.wantCount)
+			assert.Empty(t, executed(), "planning executes no queries")
+
+			_, err = builder.BuildFeatures(context.Background(), "payments", "api", "")
+			require.NoError(t, err)
+
+			require.Len(t, executed(), len(plan))
+			for i, entry := range executed() {
+				planned := plan[i]
+				assert.NotEmpty(t, planned.Metric)
+				assert.Contains(t, planned.PromQL, `namespace="payments"`)
+				planned.Metric = ""
+				if entry.Type == "" {
+					entry.Type = planned.Type // The provider cannot tell range query kinds apart
+				}
+				assert.Equal(t, planned, entry, "query %d", i)
+			}
+		})
+	}
+}
+
+// TestPlanQueries_Batched verifies a batching provider's plan lists point values as the
+// single-point range queries the build executes
+func TestPlanQueries_Batched(t *testing.T) {
+	now := time.Date(2026, 3, 16, 14, 30, 0, 0, time.UTC)
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	mock, executed := recordingProvider()
+	provider := &batchingProvider{MockMetricDataProvider: *mock}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, log)
+	require.NoError(t, err)
+	builder.SetClock(func() time.Time { return now })
+
+	plan, err := builder.PlanQueries(context.Background(), "payments", "api", "")
+	require.NoError(t, err)
+	_, err = builder.BuildFeatures(context.Background(), "payments", "api", "")
+	require.NoError(t, err)
+
+	require.Len(t, executed(), len(plan))
+	pointRanges := 0
+	for i, entry := range executed() {
+		planned := plan[i]
+		if planned.Type == QueryTypePointRange {
+			pointRanges++
+			assert.Equal(t, *planned.Start, *planned.End)
+		}
+		planned.Metric = ""
+		if entry.Type == "" {
+			entry.Type = planned.Type
+		}
+		assert.Equal(t, planned, entry, "query %d", i)
+	}
+	assert.Equal(t, len(predictiveBaseMetrics)*(1+len(DefaultLagPeriods)), pointRanges)
+}
+
+func TestPlanQueries_InvalidScope(t *testing.T) {
+	builder, err := NewPredictiveFeatureBuilder(nil, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	_, err = builder.PlanQueries(context.Background(), "Bad_Namespace", "", "")
+	assert.ErrorIs(t, err, ErrInvalidScopeIdentifier)
+}
@@ -5,11 +5,18 @@ package features
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
 )
 
 // PredictiveFeatureConfig holds configuration for predictive feature engineering
@@ -25,17 +32,232 @@ type PredictiveFeatureConfig struct {
 	// This helps detect feature engineering mismatches early.
 	// Set this to match the model's StandardScaler expectation.
 	ExpectedFeatureCount int
+
+	// MaxLookbackHours caps LookbackHours (0 = DefaultMaxLookbackHours). Every lookback hour adds
+	// 136 features and a round of Prometheus range queries, so larger values are clamped with a warning.
+	MaxLookbackHours int
+
+	// BaseMetrics selects the base metrics the model was trained on (see
+	// GetPredictiveBaseMetrics), e.g. without network_in and network_out on clusters lacking node
+	// network metrics. Columns keep the notebook's metric order whatever the listing order. Empty
+	// uses all five. A subset changes the feature count, so ExpectedFeatureCount must be set and
+	// match it; the builder is not created otherwise.
+	BaseMetrics []string
+
+	// TimeFeatures selects and orders the time-based features emitted per timestep, by name
+	// (see SupportedTimeFeatureNames). Empty means the training notebook's default six.
+	TimeFeatures []string
+
+	// LogQueries logs every executed PromQL query with its scope, point count and resulting
+	// value at info level under the "promql" field. A single build runs over a thousand
+	// queries, so only enable it while diagnosing unexpected feature values.
+	LogQueries bool
+
+	// LogVectorStats computes FeatureVector.Stats for every build and logs them at info level,
+	// one entry per build, for drift monitoring
+	LogVectorStats bool
+
+	// ResampleRule resamples each metric to buckets of this width before computing values,
+	// lags and rolling statistics, mirroring the training pipeline's resample(rule).mean()
+	// followed by shift/rolling. It must be at least 1m and divide an hour evenly. Zero keeps
+	// the older point queries, where a lag is the value exactly that long before.
+	ResampleRule time.Duration
+
+	// ClusterAggregation selects how cluster-scope builds (no namespace, deployment or pod)
+	// combine container metrics: ClusterAggregationMean (default when empty),
+	// ClusterAggregationTopNamespaces or ClusterAggregationWeighted. Disk usage comes from node
+	// metrics without a namespace and is always the mean.
+	ClusterAggregation string
+
+	// ClusterTopNamespaces is the namespace count for ClusterAggregationTopNamespaces
+	// (0 = DefaultClusterTopNamespaces)
+	ClusterTopNamespaces int
+
+	// BusinessHoursStart and BusinessHoursEnd bound is_business_hours: hours h with
+	// start <= h < end on a business day. Both zero means DefaultBusinessHoursStart-DefaultBusinessHoursEnd.
+	BusinessHoursStart int
+	BusinessHoursEnd   int
+
+	// BusinessDays names the days is_business_hours can be 1 on, e.g. "sunday" or "sun"
+	// (empty = every day not in WeekendDays)
+	BusinessDays []string
+
+	// WeekendDays names the days is_weekend is 1 on (empty = DefaultWeekendDays). Set it to the
+	// training data's definition, e.g. friday,saturday for some regions.
+	WeekendDays []string
+
+	// LagPeriods are the lags, in hours, emitted per metric as lag_<N>h, in order (empty =
+	// DefaultLagPeriods). diff and pct_change compare against the first one.
+	LagPeriods []int
+
+	// RollingWindows are the windows, in hours, of the rolling mean, std, max and min emitted
+	// per metric (empty = DefaultRollingWindows)
+	RollingWindows []int
+
+	// ScalerFile is a JSON file with the training pipeline's StandardScaler parameters
+	// (see FeatureScaler). When set, built vectors are standardized before they are returned,
+	// for model servers that do not re-apply the scaler. Empty sends raw values.
+	ScalerFile string
+
+	// QueryStep is the resolution of the range queries behind the rolling statistics (0 =
+	// DefaultQueryStep), or with ResampleRule the spacing of the samples averaged into each
+	// bucket (0 = the smaller of 5m and the rule). Finer steps capture short bursts in the
+	// rolling max, min and std at the cost of larger queries. It must divide every rolling
+	// window (or the resample rule) evenly. Requests may override it with WithQueryStep.
+	QueryStep time.Duration
+
+	// MaxBuildDuration bounds the wall-clock time of one build (0 = unbounded). Once it has
+	// passed, no further queries are issued: features not yet queried take their defaults and
+	// the vector is returned with Partial set, so slow Prometheus answers cannot hold a
+	// prediction for longer than this whatever deadline the caller's context carries.
+	MaxBuildDuration time.Duration
 }
 
+// errBuildDeadline is the cause of a build's query context when MaxBuildDuration passed
+var errBuildDeadline = errors.New("feature build exceeded its maximum duration")
+
+// DefaultMaxLookbackHours is the default upper bound for LookbackHours (9792 features)
+const DefaultMaxLookbackHours = 72
+
 // DefaultPredictiveConfig returns default configuration for predictive feature engineering
 func DefaultPredictiveConfig() PredictiveFeatureConfig {
 	return PredictiveFeatureConfig{
 		LookbackHours: 24,
 		Enabled:       true,
+		ResampleRule:  DefaultResampleRule,
 	}
 }
 
-// MetricDataProvider is an interface for querying historical metric data
+// Validate checks the lookback settings. LookbackHours above the maximum is not an error;
+// NewPredictiveFeatureBuilder clamps it.
+func (c PredictiveFeatureConfig) Validate() error {
+	if c.LookbackHours <= 0 {
+		return fmt.Errorf("lookback hours must be positive: %d", c.LookbackHours)
+	}
+	if c.MaxLookbackHours < 0 {
+		return fmt.Errorf("max lookback hours must not be negative: %d", c.MaxLookbackHours)
+	}
+	if c.MaxBuildDuration < 0 {
+		return fmt.Errorf("max build duration must not be negative: %s", c.MaxBuildDuration)
+	}
+	if err := validateResampleRule(c.ResampleRule); err != nil {
+		return err
+	}
+	if err := validateClusterAggregation(c.ClusterAggregation, c.ClusterTopNamespaces); err != nil {
+		return err
+	}
+	if _, err := newCalendar(c); err != nil {
+		return err
+	}
+	if err := validatePeriods("lag period", c.LagPeriods); err != nil {
+		return err
+	}
+	if err := validatePeriods("rolling window", c.RollingWindows); err != nil {
+		return err
+	}
+	if c.QueryStep < 0 {
+		return fmt.Errorf("query step must not be negative: %s", c.QueryStep)
+	}
+	if err := c.validateQueryStep(c.QueryStep); err != nil {
+		return err
+	}
+	if err := ValidateBaseMetrics(c.BaseMetrics); err != nil {
+		return err
+	}
+	if len(c.baseMetrics()) < len(predictiveBaseMetrics) && c.ExpectedFeatureCount <= 0 {
+		return fmt.Errorf("base metrics %s are a subset, so the expected feature count must be set to the model's", strings.Join(inColumnOrder(c.BaseMetrics), ","))
+	}
+	return ValidateTimeFeatureNames(c.TimeFeatures)
+}
+
+// validatePeriods checks that every period is a positive number of hours and appears once
+func validatePeriods(kind string, hours []int) error {
+	seen := make(map[int]bool, len(hours))
+	for _, h := range hours {
+		if h <= 0 {
+			return fmt.Errorf("%s must be a positive number of hours: %d", kind, h)
+		}
+		if seen[h] {
+			return fmt.Errorf("duplicate %s %dh", kind, h)
+		}
+		seen[h] = true
+	}
+	return nil
+}
+
+// ValidateBaseMetrics checks that every name is a base metric and appears once. An empty list
+// is valid and selects all base metrics.
+func ValidateBaseMetrics(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if !slices.Contains(predictiveBaseMetrics, name) {
+			return fmt.Errorf("unsupported base metric %q (supported: %s)", name, strings.Join(predictiveBaseMetrics, ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate base metric %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// baseMetrics returns the selected base metrics, or all of them when none are selected. The
+// builder stores the selection in column order.
+func (c PredictiveFeatureConfig) baseMetrics() []string {
+	if len(c.BaseMetrics) == 0 {
+		return predictiveBaseMetrics
+	}
+	return c.BaseMetrics
+}
+
+// inColumnOrder returns the base metrics names in the notebook's column order
+func inColumnOrder(names []string) []string {
+	return slices.DeleteFunc(slices.Clone(predictiveBaseMetrics), func(metric string) bool {
+		return !slices.Contains(names, metric)
+	})
+}
+
+// lagPeriods returns the configured lag periods, or the defaults when none are set
+func (c PredictiveFeatureConfig) lagPeriods() []int {
+	if len(c.LagPeriods) == 0 {
+		return DefaultLagPeriods
+	}
+	return c.LagPeriods
+}
+
+// rollingWindows returns the configured rolling windows, or the defaults when none are set
+func (c PredictiveFeatureConfig) rollingWindows() []int {
+	if len(c.RollingWindows) == 0 {
+		return DefaultRollingWindows
+	}
+	return c.RollingWindows
+}
+
+// featuresPerMetric is the engineered feature count per metric: the value, one per lag, four
+// statistics per rolling window, diff and pct_change
+func (c PredictiveFeatureConfig) featuresPerMetric() int {
+	return 1 + len(c.lagPeriods()) + 4*len(c.rollingWindows()) + 2
+}
+
+// timeFeatureNames returns the configured time features, or the defaults when none are set
+func (c PredictiveFeatureConfig) timeFeatureNames() []string {
+	if len(c.TimeFeatures) == 0 {
+		return timeFeatureNames
+	}
+	return c.TimeFeatures
+}
+
+// maxLookbackHours returns the effective lookback cap
+func (c PredictiveFeatureConfig) maxLookbackHours() int {
+	if c.MaxLookbackHours > 0 {
+		return c.MaxLookbackHours
+	}
+	return DefaultMaxLookbackHours
+}
+
+// MetricDataProvider is an interface for querying historical metric data.
+// Implementations must be safe for concurrent use; the prediction handler shares one
+// provider across all in-flight requests.
 type MetricDataProvider interface {
 	// QueryRange queries a PromQL expression over a time range and returns data points
 	QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error)
@@ -43,6 +265,9 @@ type MetricDataProvider interface {
 	// Query performs an instant query
 	Query(ctx context.Context, query string) (float64, error)
 
+	// QueryAt performs an instant query evaluated at the given time
+	QueryAt(ctx context.Context, query string, at time.Time) (float64, error)
+
 	// IsAvailable returns true if the provider is configured and available
 	IsAvailable() bool
 }
@@ -59,37 +284,117 @@ type DataPoint struct {
 // Feature breakdown (using Python formula):
 // - Per timestep: 5 metrics + 6 time features + (25 features × 5 metrics) = 136 columns
 // - Total: 24 lookback hours × 136 columns = 3264 features
+//
+// Thread safety: the builder's configuration is fixed once it is shared (SetClock must be
+// called before), its query counters are atomic, and BuildFeatures keeps all working data in
+// locals, so one builder may be shared by concurrent requests as long as the
+// MetricDataProvider is itself safe for concurrent use.
 type PredictiveFeatureBuilder struct {
 	provider MetricDataProvider
 	config   PredictiveFeatureConfig
 	log      *logrus.Logger
+
+	// calendar decides is_weekend and is_business_hours
+	calendar timeCalendar
+
+	// now anchors builds that do not name a time; replaced by SetClock in tests
+	now func() time.Time
+
+	// nonFinite counts NaN and ±Inf values discarded from query results
+	nonFinite atomic.Int64
+
+	// queryCounters counts succeeded and failed queries per base metric
+	queryCounters map[string]*queryCounters
+
+	// scaler standardizes built vectors; nil sends raw values
+	scaler *FeatureScaler
 }
 
-// NewPredictiveFeatureBuilder creates a new feature builder
-func NewPredictiveFeatureBuilder(provider MetricDataProvider, config PredictiveFeatureConfig, log *logrus.Logger) *PredictiveFeatureBuilder {
+// NewPredictiveFeatureBuilder creates a new feature builder.
+// It returns an error for a non-positive LookbackHours and clamps values above the maximum,
+// so misconfiguration surfaces at startup instead of on the first prediction.
+func NewPredictiveFeatureBuilder(provider MetricDataProvider, config PredictiveFeatureConfig, log *logrus.Logger) (*PredictiveFeatureBuilder, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid predictive feature config: %w", err)
+	}
+
+	if maxHours := config.maxLookbackHours(); config.LookbackHours > maxHours {
+		log.WithFields(logrus.Fields{
+			"lookback_hours":     config.LookbackHours,
+			"max_lookback_hours": maxHours,
+		}).Warn("Feature engineering lookback exceeds the maximum, clamping")
+		config.LookbackHours = maxHours
+	}
+
+	// Copy so later changes to the caller's slices cannot alter the vector layout; the base
+	// metrics are stored in column order
+	config.BaseMetrics = inColumnOrder(config.baseMetrics())
+	config.TimeFeatures = append([]string(nil), config.TimeFeatures...)
+	config.LagPeriods = append([]int(nil), config.LagPeriods...)
+	config.RollingWindows = append([]int(nil), config.RollingWindows...)
+
+	calendar, err := newCalendar(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid predictive feature config: %w", err)
+	}
+
 	builder := &PredictiveFeatureBuilder{
-		provider: provider,
-		config:   config,
-		log:      log,
+		provider:      provider,
+		config:        config,
+		log:           log,
+		calendar:      calendar,
+		now:           time.Now,
+		queryCounters: newQueryCounters(config.BaseMetrics),
 	}
 
-	// Validate expected feature count if specified
+	// Validate expected feature count if specified (after clamping, so it reflects what is actually built)
 	if config.ExpectedFeatureCount > 0 {
 		actualCount := builder.calculateTotalFeatures()
+		if actualCount != config.ExpectedFeatureCount && len(config.BaseMetrics) < len(predictiveBaseMetrics) {
+			return nil, fmt.Errorf("invalid predictive feature config: base metrics %s produce %d features, the model expects %d",
+				strings.Join(config.BaseMetrics, ","), actualCount, config.ExpectedFeatureCount)
+		}
 		if actualCount != config.ExpectedFeatureCount {
 			log.WithFields(logrus.Fields{
 				"expected_features":   config.ExpectedFeatureCount,
 				"actual_features":     actualCount,
-				"base_metrics":        len(predictiveBaseMetrics),
-				"features_per_metric": FeaturesPerMetric,
+				"base_metrics":        len(config.BaseMetrics),
+				"features_per_metric": config.featuresPerMetric(),
 				"lookback_hours":      config.LookbackHours,
-				"time_features":       TimeFeatureCount,
+				"time_features":       len(config.timeFeatureNames()),
 			}).Warn("Feature count mismatch detected! The model may reject predictions. " +
 				"Update the Go feature engineering to match the model's training or set ExpectedFeatureCount=0 to disable this warning.")
 		}
 	}
 
-	return builder
+	if config.ScalerFile != "" {
+		scaler, err := LoadFeatureScaler(config.ScalerFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid predictive feature config: %w", err)
+		}
+		if scaler.FeatureCount() != builder.calculateTotalFeatures() {
+			return nil, fmt.Errorf("invalid predictive feature config: scaler expects %d features, builder produces %d",
+				scaler.FeatureCount(), builder.calculateTotalFeatures())
+		}
+		builder.scaler = scaler
+	}
+
+	return builder, nil
+}
+
+// SetClock replaces the time source used by BuildFeatures, GetDefaultFeatures and
+// CompareToReference, so tests can build reproducible vectors. Must be called before the
+// builder is shared; nil restores time.Now.
+func (b *PredictiveFeatureBuilder) SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	b.now = now
+}
+
+// FeatureCount returns the number of features BuildFeatures produces
+func (b *PredictiveFeatureBuilder) FeatureCount() int {
+	return b.calculateTotalFeatures()
 }
 
 // Base metrics used for predictive analytics
@@ -102,13 +407,13 @@ var predictiveBaseMetrics = []string{
 	"network_out",
 }
 
-// Lag periods in hours - matches training notebook
-var lagPeriods = []int{1, 2, 3, 6, 12, 24}
+// DefaultLagPeriods are the lag periods in hours of the training notebook
+var DefaultLagPeriods = []int{1, 2, 3, 6, 12, 24}
 
-// Rolling window sizes in hours - matches training notebook
-var rollingWindows = []int{3, 6, 12, 24}
+// DefaultRollingWindows are the rolling window sizes in hours of the training notebook
+var DefaultRollingWindows = []int{3, 6, 12, 24}
 
-// Feature names per metric (25 features each)
+// Feature names per metric with the default periods (25 features each)
 var predictiveFeatureNames = []string{
 	"value",            // Current value
 	"lag_1h",           // 1-hour lag
@@ -137,20 +442,61 @@ var predictiveFeatureNames = []string{
 	"pct_change",       // (value - lag_1h) / lag_1h
 }
 
-// Time-based feature names - MUST match Python notebook exactly
+// Default time-based feature names - MUST match Python notebook exactly
 var timeFeatureNames = []string{
 	"hour",              // 0-23
 	"day_of_week",       // 0-6 (Monday=0)
 	"day_of_month",      // 1-31
 	"month",             // 1-12
 	"is_weekend",        // 0 or 1
-	"is_business_hours", // 0 or 1 (9-17 weekdays)
+	"is_business_hours", // 0 or 1 (9-17 weekdays by default, see PredictiveFeatureConfig.BusinessHoursStart)
+}
+
+// supportedTimeFeatures computes each selectable time feature from a timestamp and the
+// builder's calendar. The values match the pandas definitions used in training.
+var supportedTimeFeatures = map[string]func(cal timeCalendar, t time.Time) float64{
+	"hour":              func(_ timeCalendar, t time.Time) float64 { return float64(t.Hour()) },
+	"day_of_week":       func(_ timeCalendar, t time.Time) float64 { return float64((int(t.Weekday()) + 6) % 7) }, // Monday=0
+	"day_of_month":      func(_ timeCalendar, t time.Time) float64 { return float64(t.Day()) },
+	"month":             func(_ timeCalendar, t time.Time) float64 { return float64(t.Month()) },
+	"is_weekend":        func(cal timeCalendar, t time.Time) float64 { return boolFeature(cal.isWeekend(t)) },
+	"is_business_hours": func(cal timeCalendar, t time.Time) float64 { return boolFeature(cal.isBusinessHours(t)) },
+	"quarter":           func(_ timeCalendar, t time.Time) float64 { return float64((int(t.Month())-1)/3 + 1) }, // 1-4
+	"week_of_year": func(_ timeCalendar, t time.Time) float64 { // ISO 8601 week, 1-53; early-January days may belong to the previous year's last week
+		_, week := t.ISOWeek()
+		return float64(week)
+	},
+}
+
+// ValidateTimeFeatureNames checks that every name is a supported time feature and appears once.
+// An empty list is valid and selects the defaults.
+func ValidateTimeFeatureNames(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := supportedTimeFeatures[name]; !ok {
+			return fmt.Errorf("unsupported time feature %q (supported: %s)", name, strings.Join(SupportedTimeFeatureNames(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate time feature %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
 }
 
-// FeaturesPerMetric is the number of features generated per metric
+func boolFeature(b bool) float64 {
+	if b {
+		return 1.0
+	}
+	return 0.0
+}
+
+// FeaturesPerMetric is the number of features generated per metric with the default lag
+// periods and rolling windows; builders configured with others report theirs via GetFeatureInfo
 const FeaturesPerMetric = 25
 
-// TimeFeatureCount is the number of time-based features
+// TimeFeatureCount is the number of time-based features in the default set; builders
+// configured with PredictiveFeatureConfig.TimeFeatures report theirs via GetFeatureInfo
 const TimeFeatureCount = 6
 
 // FeatureVector contains the engineered features for prediction
@@ -164,30 +510,113 @@ type FeatureVector struct {
 	// MetricsData contains the raw current metric values (for debugging/logging)
 	MetricsData map[string]float64
 
+	// DefaultedMetrics lists base metrics whose current value could not be queried and was
+	// replaced by the default in MetricsData
+	DefaultedMetrics []string
+
+	// DefaultedFeatures counts the metric features holding defaults because their query failed
+	// or, in a partial build, was never issued
+	DefaultedFeatures int
+
+	// Partial is true when the build hit MaxBuildDuration and stopped querying; the features it
+	// had not reached hold defaults and are included in DefaultedFeatures
+	Partial bool
+
+	// Scaled is true when Features were standardized with the builder's scaler; MetricsData
+	// always holds raw values
+	Scaled bool
+
+	// Stats summarizes the unscaled metric columns; nil unless LogVectorStats is enabled
+	Stats *FeatureVectorStats
+
 	// Timestamp when the features were generated
 	Timestamp time.Time
 }
 
+// TimeFeatureWindow holds the time-based columns for every timestep of one lookback window.
+// They depend only on the window's end time, not on the scope, so a caller building vectors
+// for several scopes can compute them once with BuildTimeFeatureWindow and share them.
+type TimeFeatureWindow struct {
+	// End is the most recent timestep of the window
+	End time.Time
+
+	// Steps holds the builder's time feature values per timestep, newest first
+	Steps [][]float64
+
+	// historical windows end in the past, so missing data must not be filled from an
+	// instant query, which would return the current value instead
+	historical bool
+}
+
 // FeatureInfo contains metadata about the feature engineering
 type FeatureInfo struct {
 	TotalFeatures     int      `json:"total_features"`
 	BaseMetrics       []string `json:"base_metrics"`
 	FeaturesPerMetric int      `json:"features_per_metric"`
+	LagPeriods        []int    `json:"lag_periods"`     // Hours
+	RollingWindows    []int    `json:"rolling_windows"` // Hours
 	LookbackHours     int      `json:"lookback_hours"`
 	TimeFeatures      int      `json:"time_features"`
+	TimeFeatureNames  []string `json:"time_feature_names"`
+	ResampleRule      string   `json:"resample_rule,omitempty"`
+
+	// QueryStep is the configured resolution of the range queries; requests may override it
+	QueryStep string `json:"query_step"`
+
+	// ClusterAggregation is how cluster-scope builds combine container metrics
+	ClusterAggregation string `json:"cluster_aggregation"`
+
+	// Scaled reports whether vectors are standardized with the configured StandardScaler
+	Scaled bool `json:"scaled"`
+
+	// Breakdown explains how TotalFeatures is reached, so a mismatch with the model is diagnosable
+	Breakdown FeatureCountBreakdown `json:"breakdown"`
+
+	// QueryStats counts each base metric's succeeded and failed queries since startup
+	QueryStats map[string]MetricQueryStats `json:"query_stats"`
+}
+
+// FeatureCountBreakdown splits the feature count into its parts. Every timestep of the lookback
+// window carries the raw metric values, their engineered features and the time features:
+// Total = LookbackHours × ColumnsPerTimestep + StaticFeatures.
+type FeatureCountBreakdown struct {
+	LookbackHours            int `json:"lookback_hours"`
+	RawMetricFeatures        int `json:"raw_metric_features"`        // Per timestep: one per base metric
+	EngineeredMetricFeatures int `json:"engineered_metric_features"` // Per timestep: base metrics × features per metric
+	TimeFeatures             int `json:"time_features"`              // Per timestep
+	ColumnsPerTimestep       int `json:"columns_per_timestep"`
+	StaticFeatures           int `json:"static_features"` // Appended once outside the window; the model currently has none
+	Total                    int `json:"total"`
 }
 
 // GetFeatureInfo returns metadata about the feature engineering configuration
 func (b *PredictiveFeatureBuilder) GetFeatureInfo() FeatureInfo {
 	return FeatureInfo{
-		TotalFeatures:     b.calculateTotalFeatures(),
-		BaseMetrics:       predictiveBaseMetrics,
-		FeaturesPerMetric: FeaturesPerMetric,
-		LookbackHours:     b.config.LookbackHours,
-		TimeFeatures:      TimeFeatureCount,
+		TotalFeatures:      b.calculateTotalFeatures(),
+		BaseMetrics:        slices.Clone(b.config.baseMetrics()),
+		FeaturesPerMetric:  b.config.featuresPerMetric(),
+		LagPeriods:         slices.Clone(b.config.lagPeriods()),
+		RollingWindows:     slices.Clone(b.config.rollingWindows()),
+		LookbackHours:      b.config.LookbackHours,
+		TimeFeatures:       len(b.config.timeFeatureNames()),
+		TimeFeatureNames:   b.TimeFeatureNames(),
+		ResampleRule:       b.resampleRuleName(),
+		QueryStep:          b.queryStep(context.Background()).String(),
+		ClusterAggregation: b.config.clusterAggregation(),
+		Scaled:             b.scaler != nil,
+		Breakdown:          b.featureCountBreakdown(),
+		QueryStats:         b.QueryStats(),
 	}
 }
 
+// resampleRuleName returns the resample rule for reporting, or "" when resampling is off
+func (b *PredictiveFeatureBuilder) resampleRuleName() string {
+	if b.config.ResampleRule == 0 {
+		return ""
+	}
+	return b.config.ResampleRule.String()
+}
+
 // BuildFeatures builds the complete feature vector for the predictive-analytics model.
 // The feature vector is structured to match the training notebook's feature engineering.
 //
@@ -204,17 +633,93 @@ func (b *PredictiveFeatureBuilder) GetFeatureInfo() FeatureInfo {
 //   - deployment: Optional deployment filter for scoped predictions
 //   - pod: Optional pod filter for scoped predictions
 //
+// Log entries are emitted with ctx attached so request-scoped fields (e.g. request_id) are included.
 // Returns the feature vector or an error if feature generation fails.
 func (b *PredictiveFeatureBuilder) BuildFeatures(ctx context.Context, namespace, deployment, pod string) (*FeatureVector, error) {
+	return b.buildFeaturesAsOf(ctx, b.now(), false, namespace, deployment, pod)
+}
+
+// BuildFeaturesAsOf builds the feature vector as it would have been built at asOf: the lookback
+// window and the time features end at asOf, and only data up to that time is read. Used to
+// replay past predictions; missing data falls back to defaults rather than to current values.
+func (b *PredictiveFeatureBuilder) BuildFeaturesAsOf(ctx context.Context, asOf time.Time, namespace, deployment, pod string) (*FeatureVector, error) {
+	return b.buildFeaturesAsOf(ctx, asOf, true, namespace, deployment, pod)
+}
+
+// buildFeaturesAsOf anchors the window at asOf. Only a live build, anchored at the builder's
+// clock, may fill gaps from an instant query, which always answers for the present.
+func (b *PredictiveFeatureBuilder) buildFeaturesAsOf(ctx context.Context, asOf time.Time, historical bool, namespace, deployment, pod string) (*FeatureVector, error) {
+	window := b.BuildTimeFeatureWindow(asOf)
+	window.historical = historical
+	return b.BuildFeaturesWithTime(ctx, window, namespace, deployment, pod)
+}
+
+// BuildHistoricalTimeFeatureWindow is BuildTargetTimeFeatureWindow for a window ending at a past
+// asOf time. Features built with it read only data up to asOf, as BuildFeaturesAsOf does.
+func (b *PredictiveFeatureBuilder) BuildHistoricalTimeFeatureWindow(asOf, target time.Time) *TimeFeatureWindow {
+	window := b.BuildTargetTimeFeatureWindow(asOf, target)
+	window.historical = true
+	return window
+}
+
+// BuildTimeFeatureWindow computes the time-based columns for the lookback window ending at now
+func (b *PredictiveFeatureBuilder) BuildTimeFeatureWindow(now time.Time) *TimeFeatureWindow {
+	return b.BuildTargetTimeFeatureWindow(now, now)
+}
+
+// BuildTargetTimeFeatureWindow computes the time-based columns for the lookback window ending
+// at target, while metrics are still read for the window ending at now. This lets the model
+// see the calendar position of a future target time with the most recent observed metrics.
+func (b *PredictiveFeatureBuilder) BuildTargetTimeFeatureWindow(now, target time.Time) *TimeFeatureWindow {
+	steps := make([][]float64, b.config.LookbackHours)
+	for hourOffset := range steps {
+		steps[hourOffset] = b.buildTimeFeatures(target.Add(-time.Duration(hourOffset) * time.Hour))
+	}
+	return &TimeFeatureWindow{End: now, Steps: steps}
+}
+
+// BuildFeaturesWithTime is BuildFeatures with precomputed time features, which also fix the
+// window's end time. The time features must come from this builder's BuildTimeFeatureWindow.
+func (b *PredictiveFeatureBuilder) BuildFeaturesWithTime(ctx context.Context, window *TimeFeatureWindow, namespace, deployment, pod string) (*FeatureVector, error) {
+	vector, err := b.buildRawFeatures(ctx, window, namespace, deployment, pod)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.scale(vector); err != nil {
+		return nil, err
+	}
+	return vector, nil
+}
+
+// scale standardizes vector's features with the builder's scaler, if one is configured
+func (b *PredictiveFeatureBuilder) scale(vector *FeatureVector) error {
+	if b.scaler == nil {
+		return nil
+	}
+	if err := b.scaler.Transform(vector.Features); err != nil {
+		return fmt.Errorf("failed to scale features: %w", err)
+	}
+	vector.Scaled = true
+	return nil
+}
+
+// buildRawFeatures builds the unscaled feature vector for BuildFeaturesWithTime
+func (b *PredictiveFeatureBuilder) buildRawFeatures(ctx context.Context, window *TimeFeatureWindow, namespace, deployment, pod string) (*FeatureVector, error) {
 	if b.provider == nil || !b.provider.IsAvailable() {
 		return nil, fmt.Errorf("metric data provider not available")
 	}
+	if window == nil || len(window.Steps) != b.config.LookbackHours {
+		return nil, fmt.Errorf("time features do not cover the %d hour lookback window", b.config.LookbackHours)
+	}
+	if err := ValidateScopeIdentifiers(namespace, deployment, pod); err != nil {
+		return nil, err
+	}
 
-	now := time.Now()
+	now := window.End
 	lookbackDuration := time.Duration(b.config.LookbackHours) * time.Hour
 	startTime := now.Add(-lookbackDuration)
 
-	b.log.WithFields(logrus.Fields{
+	b.log.WithContext(ctx).WithFields(logrus.Fields{
 		"lookback_hours": b.config.LookbackHours,
 		"start_time":     startTime.Format(time.RFC3339),
 		"end_time":       now.Format(time.RFC3339),
@@ -223,25 +728,66 @@ func (b *PredictiveFeatureBuilder) BuildFeatures(ctx context.Context, namespace,
 		"pod":            pod,
 	}).Debug("Building predictive features")
 
+	metrics := b.config.baseMetrics()
+	queries := make([]metricQuery, len(metrics))
+	for i, metric := range metrics {
+		queries[i] = b.newMetricQuery(metric, namespace, deployment, pod)
+		queries[i].noInstantFallback = window.historical
+	}
+
+	// Queries run under the build's own deadline; once it passes, remaining features are
+	// defaulted without querying
+	queryCtx := ctx
+	if b.config.MaxBuildDuration > 0 {
+		var cancel context.CancelFunc
+		queryCtx, cancel = context.WithTimeoutCause(ctx, b.config.MaxBuildDuration, errBuildDeadline)
+		defer cancel()
+	}
+	expired := func() bool { return errors.Is(context.Cause(queryCtx), errBuildDeadline) }
+
+	// With resampling, each metric is fetched once for the whole window; a failed fetch
+	// defaults that metric's features at every timestep
+	var series []*resampledSeries
+	var seriesErrs []error
+	if b.config.ResampleRule > 0 {
+		series, seriesErrs = b.queryResampledSeries(queryCtx, queries, now)
+	}
+
 	// Collect features for all metrics and time steps
 	allFeatures := make([]float64, 0, b.calculateTotalFeatures())
 	metricsData := make(map[string]float64)
+	var defaultedMetrics []string
+	defaultedColumns := 0
 
 	// For each hour in the lookback window
 	for hourOffset := 0; hourOffset < b.config.LookbackHours; hourOffset++ {
 		timestamp := now.Add(-time.Duration(hourOffset) * time.Hour)
 
 		// 1. Add raw metric values (5 features) - matches Python "metrics" term
-		rawMetricValues := make([]float64, len(predictiveBaseMetrics))
-		for i, metric := range predictiveBaseMetrics {
-			baseQuery := b.getMetricQuery(metric, namespace, deployment, pod)
-			value, err := b.queryAtTime(ctx, baseQuery, timestamp)
+		rawMetricValues := make([]float64, len(metrics))
+		for i, metric := range metrics {
+			var value float64
+			var err error
+			switch {
+			case series == nil && expired():
+				err = errBuildDeadline
+			case series == nil:
+				value, err = b.queryAtTime(queryCtx, queries[i], timestamp)
+			case seriesErrs[i] != nil:
+				err = seriesErrs[i]
+			default:
+				value, err = series[i].valueAt(timestamp)
+			}
 			if err != nil {
-				b.log.WithError(err).WithFields(logrus.Fields{
+				b.log.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
 					"metric":      metric,
 					"hour_offset": hourOffset,
 				}).Debug("Failed to query raw metric value, using default")
 				value = 0.5
+				defaultedColumns++
+				if hourOffset == 0 {
+					defaultedMetrics = append(defaultedMetrics, metric)
+				}
 			}
 			rawMetricValues[i] = value
 			// Store current value for the most recent time step
@@ -252,71 +798,168 @@ func (b *PredictiveFeatureBuilder) BuildFeatures(ctx context.Context, namespace,
 		allFeatures = append(allFeatures, rawMetricValues...)
 
 		// 2. Add time-based features (6 features)
-		timeFeatures := b.buildTimeFeatures(timestamp)
-		allFeatures = append(allFeatures, timeFeatures...)
+		allFeatures = append(allFeatures, window.Steps[hourOffset]...)
 
 		// 3. Add engineered metric features (25 × 5 = 125 features)
-		for _, metric := range predictiveBaseMetrics {
-			metricFeatures, _, err := b.buildMetricFeatures(ctx, metric, timestamp, namespace, deployment, pod)
+		for i, metric := range metrics {
+			var metricFeatures []float64
+			var err error
+			switch {
+			case series == nil && expired():
+				err = errBuildDeadline
+			case series == nil:
+				metricFeatures, _, err = b.buildMetricFeatures(queryCtx, queries[i], timestamp)
+			case seriesErrs[i] != nil:
+				err = seriesErrs[i]
+			default:
+				metricFeatures, err = resampledMetricFeatures(series[i], timestamp, b.config)
+			}
 			if err != nil {
-				b.log.WithError(err).WithFields(logrus.Fields{
+				b.log.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
 					"metric":      metric,
 					"hour_offset": hourOffset,
 				}).Debug("Failed to build metric features, using defaults")
 				metricFeatures = b.getDefaultMetricFeatures()
+				defaultedColumns += len(metricFeatures)
 			}
 			allFeatures = append(allFeatures, metricFeatures...)
 		}
 	}
 
-	b.log.WithFields(logrus.Fields{
+	b.log.WithContext(ctx).WithFields(logrus.Fields{
 		"feature_count":  len(allFeatures),
-		"metrics_count":  len(predictiveBaseMetrics),
+		"metrics_count":  len(metrics),
 		"lookback_hours": b.config.LookbackHours,
 	}).Debug("Predictive features built successfully")
 
-	return &FeatureVector{
-		Features:     allFeatures,
-		FeatureCount: len(allFeatures),
-		MetricsData:  metricsData,
-		Timestamp:    now,
-	}, nil
+	vector := &FeatureVector{
+		Features:          allFeatures,
+		FeatureCount:      len(allFeatures),
+		MetricsData:       metricsData,
+		DefaultedMetrics:  defaultedMetrics,
+		DefaultedFeatures: defaultedColumns,
+		Partial:           expired(),
+		Timestamp:         now,
+	}
+	if vector.Partial {
+		b.log.WithContext(ctx).WithFields(logrus.Fields{
+			"max_build_duration": b.config.MaxBuildDuration.String(),
+			"defaulted_features": defaultedColumns,
+			"feature_count":      len(allFeatures),
+			"namespace":          namespace,
+			"deployment":         deployment,
+			"pod":                pod,
+		}).Warn("Feature build exceeded its maximum duration, returning partial features")
+	}
+	if b.config.LogVectorStats {
+		vector.Stats = b.vectorStats(allFeatures, defaultedColumns)
+		b.logVectorStats(ctx, vector.Stats, namespace, deployment, pod)
+	}
+	return vector, nil
+}
+
+// WithTimeFeatures returns a copy of features, a vector built by this builder, with the time
+// columns of every timestep replaced by window's. Metric columns do not depend on the time
+// features, so one metric build can be re-timed for several target times. With a scaler the
+// replacement columns are standardized like the rest of the vector.
+func (b *PredictiveFeatureBuilder) WithTimeFeatures(features []float64, window *TimeFeatureWindow) ([]float64, error) {
+	if len(features) != b.calculateTotalFeatures() {
+		return nil, fmt.Errorf("feature vector has %d features, expected %d", len(features), b.calculateTotalFeatures())
+	}
+	if window == nil || len(window.Steps) != b.config.LookbackHours {
+		return nil, fmt.Errorf("time features do not cover the %d hour lookback window", b.config.LookbackHours)
+	}
+
+	timeFeatures := len(b.config.timeFeatureNames())
+	columns := len(features) / b.config.LookbackHours
+	retimed := slices.Clone(features)
+	for step, values := range window.Steps {
+		if len(values) != timeFeatures {
+			return nil, fmt.Errorf("timestep %d has %d time features, expected %d", step, len(values), timeFeatures)
+		}
+		offset := step*columns + len(b.config.baseMetrics())
+		copy(retimed[offset:offset+timeFeatures], values)
+		if b.scaler != nil {
+			b.scaler.transformAt(retimed[offset:offset+timeFeatures], offset)
+		}
+	}
+	return retimed, nil
 }
 
 // calculateTotalFeatures calculates the expected total number of features
 // Uses Python formula: lookback × (metrics + time_features + features_per_metric × metrics)
 // = 24 × (5 + 6 + 25×5) = 24 × 136 = 3264
 func (b *PredictiveFeatureBuilder) calculateTotalFeatures() int {
-	columnsPerTimestep := len(predictiveBaseMetrics) + TimeFeatureCount +
-		(FeaturesPerMetric * len(predictiveBaseMetrics))
-	return b.config.LookbackHours * columnsPerTimestep
+	return b.featureCountBreakdown().Total
+}
+
+// featureCountBreakdown computes the parts of the feature count for the builder's configuration
+func (b *PredictiveFeatureBuilder) featureCountBreakdown() FeatureCountBreakdown {
+	breakdown := FeatureCountBreakdown{
+		LookbackHours:            b.config.LookbackHours,
+		RawMetricFeatures:        len(b.config.baseMetrics()),
+		EngineeredMetricFeatures: b.config.featuresPerMetric() * len(b.config.baseMetrics()),
+		TimeFeatures:             len(b.config.timeFeatureNames()),
+	}
+	breakdown.ColumnsPerTimestep = breakdown.RawMetricFeatures + breakdown.EngineeredMetricFeatures + breakdown.TimeFeatures
+	breakdown.Total = breakdown.LookbackHours*breakdown.ColumnsPerTimestep + breakdown.StaticFeatures
+	return breakdown
 }
 
-// buildMetricFeatures builds the 25 features for a single metric at a specific time
+// buildMetricFeatures builds the engineered features for a single metric at a specific time.
+// With a BatchMetricDataProvider the metric's whole query set at timestamp (current value, lags
+// and rolling windows) is submitted as one batch.
 func (b *PredictiveFeatureBuilder) buildMetricFeatures(
 	ctx context.Context,
-	metric string,
+	baseQuery metricQuery,
 	timestamp time.Time,
-	namespace, deployment, pod string,
 ) ([]float64, float64, error) {
-	baseQuery := b.getMetricQuery(metric, namespace, deployment, pod)
+	lagPeriods := b.config.lagPeriods()
+	pointTimes := make([]time.Time, 1+len(lagPeriods))
+	pointTimes[0] = timestamp
+	for i, lag := range lagPeriods {
+		pointTimes[1+i] = timestamp.Add(-time.Duration(lag) * time.Hour)
+	}
+	windows := b.config.rollingWindows()
+	windowRequests := make([]rangeRequest, len(windows))
+	for i, window := range windows {
+		windowRequests[i] = b.statsRangeRequest(ctx, baseQuery, timestamp.Add(-time.Duration(window)*time.Hour), timestamp)
+	}
+
+	var batchPoints [][]DataPoint
+	var batchErrs []error
+	_, batched := b.provider.(BatchMetricDataProvider)
+	if batched {
+		requests := make([]rangeRequest, 0, len(pointTimes)+len(windowRequests))
+		for _, at := range pointTimes {
+			requests = append(requests, b.pointRangeRequest(ctx, baseQuery, at))
+		}
+		batchPoints, batchErrs = b.queryRangeBatch(ctx, append(requests, windowRequests...))
+	}
+	pointValue := func(i int) (float64, error) {
+		if batched && batchErrs[i] == nil && len(batchPoints[i]) > 0 {
+			return batchPoints[i][len(batchPoints[i])-1].Value, nil
+		}
+		// Without a batch, or when the batched query found nothing, query on its own with the
+		// instant query fallback
+		return b.queryAtTime(ctx, baseQuery, pointTimes[i])
+	}
 
 	// Query current value
-	currentValue, err := b.queryAtTime(ctx, baseQuery, timestamp)
+	currentValue, err := pointValue(0)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query current value for %s: %w", metric, err)
+		return nil, 0, fmt.Errorf("failed to query current value for %s: %w", baseQuery.metric, err)
 	}
 
-	features := make([]float64, 0, FeaturesPerMetric)
+	features := make([]float64, 0, b.config.featuresPerMetric())
 
 	// 1. Current value
 	features = append(features, currentValue)
 
-	// 2. Lag features (6 features)
+	// 2. Lag features (6 features by default)
 	lagValues := make([]float64, len(lagPeriods))
-	for i, lag := range lagPeriods {
-		lagTime := timestamp.Add(-time.Duration(lag) * time.Hour)
-		lagValue, err := b.queryAtTime(ctx, baseQuery, lagTime)
+	for i := range lagPeriods {
+		lagValue, err := pointValue(1 + i)
 		if err != nil {
 			lagValue = currentValue // Default to current value on error
 		}
@@ -324,14 +967,17 @@ func (b *PredictiveFeatureBuilder) buildMetricFeatures(
 		features = append(features, lagValue)
 	}
 
-	// 3-6. Rolling statistics (16 features: 4 windows × 4 stats)
-	for _, window := range rollingWindows {
-		windowDuration := time.Duration(window) * time.Hour
-		windowStart := timestamp.Add(-windowDuration)
-
-		// Query range for this window
-		dataPoints, err := b.queryRangeForStats(ctx, baseQuery, windowStart, timestamp)
-		if err != nil || len(dataPoints) == 0 {
+	// 3-6. Rolling statistics (4 stats per window, 16 features by default), with the
+	// windows' range queries submitted as one batch unless they already were
+	var windowPoints [][]DataPoint
+	var windowErrs []error
+	if batched {
+		windowPoints, windowErrs = batchPoints[len(pointTimes):], batchErrs[len(pointTimes):]
+	} else {
+		windowPoints, windowErrs = b.queryRangeBatch(ctx, windowRequests)
+	}
+	for i, dataPoints := range windowPoints {
+		if windowErrs[i] != nil || len(dataPoints) == 0 {
 			// Default values when data is unavailable
 			features = append(features, currentValue, 0.1, currentValue, currentValue)
 			continue
@@ -342,50 +988,38 @@ func (b *PredictiveFeatureBuilder) buildMetricFeatures(
 		features = append(features, mean, std, maxVal, minVal)
 	}
 
-	// 7. Diff feature (value - lag_1h)
-	lag1h := lagValues[0] // First lag is 1 hour
-	diff := currentValue - lag1h
-	features = append(features, diff)
+	// 7-8. Diff and percent change against the first lag (lag_1h by default)
+	diff, pctChange := changeFeatures(currentValue, lagValues[0])
+	features = append(features, diff, pctChange)
+
+	return features, currentValue, nil
+}
 
-	// 8. Percent change feature
-	pctChange := 0.0
+// changeFeatures returns the diff and clamped percent change from lag1h to currentValue
+func changeFeatures(currentValue, lag1h float64) (diff, pctChange float64) {
+	diff = currentValue - lag1h
 	if lag1h != 0 {
 		pctChange = (currentValue - lag1h) / lag1h
 	}
 	// Clamp extreme values
 	pctChange = math.Max(-10.0, math.Min(10.0, pctChange))
-	features = append(features, pctChange)
-
-	return features, currentValue, nil
+	return diff, pctChange
 }
 
-// buildTimeFeatures builds time-based features for a given timestamp
-// Returns 6 features in order matching Python notebook: hour, day_of_week, day_of_month, month, is_weekend, is_business_hours
+// buildTimeFeatures builds the configured time-based features for a given timestamp.
+// The default set, in notebook order, is: hour, day_of_week, day_of_month, month, is_weekend, is_business_hours
 func (b *PredictiveFeatureBuilder) buildTimeFeatures(t time.Time) []float64 {
-	hour := float64(t.Hour())
-	dayOfWeek := float64((int(t.Weekday()) + 6) % 7) // Convert Sunday=0 to Monday=0
-	dayOfMonth := float64(t.Day())
-	month := float64(t.Month())
-	isWeekend := 0.0
-	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
-		isWeekend = 1.0
-	}
-	isBusinessHours := 0.0
-	if hour >= 9 && hour < 17 && isWeekend == 0 {
-		isBusinessHours = 1.0
-	}
-
-	return []float64{
-		hour,            // 0-23
-		dayOfWeek,       // 0-6 (Monday=0)
-		dayOfMonth,      // 1-31
-		month,           // 1-12
-		isWeekend,       // 0 or 1
-		isBusinessHours, // 0 or 1 (9-17 weekdays)
+	names := b.config.timeFeatureNames()
+	features := make([]float64, len(names))
+	for i, name := range names {
+		features[i] = supportedTimeFeatures[name](b.calendar, t)
 	}
+	return features
 }
 
-// getMetricQuery returns the Prometheus query for a metric with optional scope filters
+// getMetricQuery returns the Prometheus query for a metric with optional scope filters.
+// Callers validate the filters with ValidateScopeIdentifiers first; values are still quoted
+// and regex-escaped so a bad name cannot change the query's structure.
 func (b *PredictiveFeatureBuilder) getMetricQuery(metric, namespace, deployment, pod string) string {
 	// Build label selectors
 	var selectors []string
@@ -396,7 +1030,7 @@ func (b *PredictiveFeatureBuilder) getMetricQuery(metric, namespace, deployment,
 		selectors = append(selectors, fmt.Sprintf("pod=%q", pod))
 	}
 	if deployment != "" {
-		selectors = append(selectors, fmt.Sprintf(`pod=~"%s-.*"`, deployment))
+		selectors = append(selectors, integrations.DeploymentPodSelector(deployment))
 	}
 
 	selectorStr := ""
@@ -404,28 +1038,31 @@ func (b *PredictiveFeatureBuilder) getMetricQuery(metric, namespace, deployment,
 		selectorStr = "," + joinSelectors(selectors)
 	}
 
+	// Container metrics of unscoped queries are combined with the cluster aggregation strategy
+	cluster := len(selectors) == 0
+
 	// Define queries for each metric type
 	queries := map[string]string{
-		"cpu_usage": fmt.Sprintf(
-			`avg(rate(container_cpu_usage_seconds_total{container!="",pod!=""%s}[5m]))`,
+		"cpu_usage": b.aggregate(fmt.Sprintf(
+			`rate(container_cpu_usage_seconds_total{container!="",pod!=""%s}[5m])`,
 			selectorStr,
-		),
-		"memory_usage": fmt.Sprintf(
-			`avg(container_memory_working_set_bytes{container!="",pod!=""%s}) / avg(kube_node_status_allocatable{resource="memory"})`,
+		), cluster),
+		"memory_usage": b.aggregate(fmt.Sprintf(
+			`container_memory_working_set_bytes{container!="",pod!=""%s}`,
 			selectorStr,
-		),
+		), cluster) + ` / avg(kube_node_status_allocatable{resource="memory"})`,
 		"disk_usage": fmt.Sprintf(
 			`1 - avg(node_filesystem_avail_bytes{mountpoint="/"%s}) / avg(node_filesystem_size_bytes{mountpoint="/"%s})`,
 			selectorStr, selectorStr,
 		),
-		"network_in": fmt.Sprintf(
-			`avg(rate(container_network_receive_bytes_total{interface!="lo"%s}[5m]))`,
+		"network_in": b.aggregate(fmt.Sprintf(
+			`rate(container_network_receive_bytes_total{interface!="lo"%s}[5m])`,
 			selectorStr,
-		),
-		"network_out": fmt.Sprintf(
-			`avg(rate(container_network_transmit_bytes_total{interface!="lo"%s}[5m]))`,
+		), cluster),
+		"network_out": b.aggregate(fmt.Sprintf(
+			`rate(container_network_transmit_bytes_total{interface!="lo"%s}[5m])`,
 			selectorStr,
-		),
+		), cluster),
 	}
 
 	query, ok := queries[metric]
@@ -435,61 +1072,163 @@ func (b *PredictiveFeatureBuilder) getMetricQuery(metric, namespace, deployment,
 	return query
 }
 
-// queryAtTime queries the metric value at a specific timestamp
-func (b *PredictiveFeatureBuilder) queryAtTime(ctx context.Context, query string, timestamp time.Time) (float64, error) {
-	// For historical queries, use query_range with a small window and take the last value
-	start := timestamp.Add(-1 * time.Minute)
-	end := timestamp
+// metricQuery is a metric's PromQL query together with the scope it was built for
+type metricQuery struct {
+	metric     string
+	namespace  string
+	deployment string
+	pod        string
+	promql     string
+
+	// noInstantFallback disables queryAtTime's fallback to the current value when the
+	// query at the requested time fails
+	noInstantFallback bool
+}
 
-	dataPoints, err := b.provider.QueryRange(ctx, query, start, end, time.Minute)
+func (b *PredictiveFeatureBuilder) newMetricQuery(metric, namespace, deployment, pod string) metricQuery {
+	return metricQuery{
+		metric:     metric,
+		namespace:  namespace,
+		deployment: deployment,
+		pod:        pod,
+		promql:     b.getMetricQuery(metric, namespace, deployment, pod),
+	}
+}
+
+// logQuery records an executed query when LogQueries is enabled
+func (b *PredictiveFeatureBuilder) logQuery(ctx context.Context, query metricQuery, fields logrus.Fields, points int, value float64, err error) {
+	if !b.config.LogQueries {
+		return
+	}
+
+	entry := b.log.WithContext(ctx).WithFields(fields).WithFields(logrus.Fields{
+		"promql":     query.promql,
+		"metric":     query.metric,
+		"namespace":  query.namespace,
+		"deployment": query.deployment,
+		"pod":        query.pod,
+		"points":     points,
+	})
 	if err != nil {
-		// Fall back to instant query if range query fails
-		value, queryErr := b.provider.Query(ctx, query)
-		if queryErr != nil {
-			return 0, fmt.Errorf("failed to query metric at time %s: %w", timestamp.Format(time.RFC3339), queryErr)
-		}
-		return value, nil
+		entry.WithError(err).Info("PromQL query failed")
+		return
 	}
+	entry.WithField("value", value).Info("PromQL query executed")
+}
+
+// errNonFinite reports a NaN or ±Inf query result, e.g. from a ratio whose denominator was
+// momentarily zero. It is handled like missing data, so the metric falls back to its defaults.
+var errNonFinite = errors.New("query returned NaN or Inf")
+
+// NonFiniteValues returns how many NaN and ±Inf values the builder has discarded
+func (b *PredictiveFeatureBuilder) NonFiniteValues() int64 {
+	return b.nonFinite.Load()
+}
+
+// checkFinite turns a NaN or ±Inf value into errNonFinite, whether the provider returned it
+// or rejected it with integrations.ErrNonFiniteSample
+func (b *PredictiveFeatureBuilder) checkFinite(ctx context.Context, query metricQuery, value float64, err error) (float64, error) {
+	if (err != nil && !errors.Is(err, integrations.ErrNonFiniteSample)) || (err == nil && isFinite(value)) {
+		return value, err
+	}
+	b.recordNonFinite(ctx, query, 1)
+	return 0, errNonFinite
+}
 
-	if len(dataPoints) == 0 {
-		value, queryErr := b.provider.Query(ctx, query)
-		if queryErr != nil {
-			return 0, fmt.Errorf("no data and instant query failed: %w", queryErr)
+// finitePoints returns points without NaN and ±Inf values; points itself is not modified
+func (b *PredictiveFeatureBuilder) finitePoints(ctx context.Context, query metricQuery, points []DataPoint) []DataPoint {
+	finite := make([]DataPoint, 0, len(points))
+	for _, p := range points {
+		if isFinite(p.Value) {
+			finite = append(finite, p)
 		}
+	}
+	if dropped := len(points) - len(finite); dropped > 0 {
+		b.recordNonFinite(ctx, query, dropped)
+	}
+	return finite
+}
+
+// recordNonFinite counts discarded values and logs the running total
+func (b *PredictiveFeatureBuilder) recordNonFinite(ctx context.Context, query metricQuery, count int) {
+	total := b.nonFinite.Add(int64(count))
+	b.log.WithContext(ctx).WithFields(logrus.Fields{
+		"metric":           query.metric,
+		"discarded":        count,
+		"non_finite_total": total,
+	}).Warn("Discarded NaN/Inf values from metric query")
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// queryAtTime queries the metric value at a specific timestamp
+func (b *PredictiveFeatureBuilder) queryAtTime(ctx context.Context, query metricQuery, timestamp time.Time) (float64, error) {
+	value, err := b.provider.QueryAt(ctx, query.promql, timestamp)
+	b.logQuery(ctx, query, logrus.Fields{"query_type": "instant_at", "at": timestamp.Format(time.RFC3339)}, 1, value, err)
+	value, err = b.checkFinite(ctx, query, value, err)
+	b.recordQueryResult(query.metric, err != nil)
+	if err == nil {
 		return value, nil
 	}
+	if query.noInstantFallback {
+		return 0, fmt.Errorf("failed to query metric at time %s: %w", timestamp.Format(time.RFC3339), err)
+	}
 
-	// Return the last data point
-	return dataPoints[len(dataPoints)-1].Value, nil
+	// Fall back to the current value if there is no data at the requested time
+	value, queryErr := b.provider.Query(ctx, query.promql)
+	b.logQuery(ctx, query, logrus.Fields{"query_type": "instant", "at": timestamp.Format(time.RFC3339)}, 1, value, queryErr)
+	value, queryErr = b.checkFinite(ctx, query, value, queryErr)
+	b.recordQueryResult(query.metric, queryErr != nil)
+	if queryErr != nil {
+		return 0, fmt.Errorf("failed to query metric at time %s: %w", timestamp.Format(time.RFC3339), queryErr)
+	}
+	return value, nil
 }
 
-// queryRangeForStats queries a range of data points for statistical calculations
-func (b *PredictiveFeatureBuilder) queryRangeForStats(
-	ctx context.Context,
-	query string,
-	start, end time.Time,
-) ([]DataPoint, error) {
-	// Use 5-minute steps for efficiency
-	step := 5 * time.Minute
-	dataPoints, err := b.provider.QueryRange(ctx, query, start, end, step)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query range for stats: %w", err)
+// pointRangeRequest is a single-point range query of the metric's value at a time, which
+// Prometheus evaluates like an instant query at that time so it can join a batch
+func (b *PredictiveFeatureBuilder) pointRangeRequest(ctx context.Context, query metricQuery, at time.Time) rangeRequest {
+	request := rangeRequest{query: query, start: at, end: at, step: b.queryStep(ctx)}
+	if b.config.LogQueries {
+		request.logFields = logrus.Fields{
+			"query_type": QueryTypePointRange,
+			"start":      at.Format(time.RFC3339),
+			"end":        at.Format(time.RFC3339),
+			"step":       request.step.String(),
+		}
 	}
-	return dataPoints, nil
+	return request
+}
+
+// statsRangeRequest is the range query of a rolling window's data points for statistical calculations
+func (b *PredictiveFeatureBuilder) statsRangeRequest(ctx context.Context, query metricQuery, start, end time.Time) rangeRequest {
+	request := rangeRequest{query: query, start: start, end: end, step: b.queryStep(ctx)}
+	if b.config.LogQueries {
+		request.logFields = logrus.Fields{
+			"query_type": "range",
+			"start":      start.Format(time.RFC3339),
+			"end":        end.Format(time.RFC3339),
+			"step":       request.step.String(),
+		}
+	}
+	return request
 }
 
 // getDefaultMetricFeatures returns default features for a single metric when data is unavailable
 func (b *PredictiveFeatureBuilder) getDefaultMetricFeatures() []float64 {
-	features := make([]float64, FeaturesPerMetric)
+	count := b.config.featuresPerMetric()
+	features := make([]float64, count)
 
-	// Default current value and lags (7 features)
-	for i := 0; i < 7; i++ {
+	// Default current value and lags (7 features by default)
+	idx := 1 + len(b.config.lagPeriods())
+	for i := range idx {
 		features[i] = 0.5
 	}
 
-	// Default rolling statistics (16 features: 4 windows × 4 stats)
-	idx := 7
-	for range rollingWindows {
+	// Default rolling statistics (4 stats per window)
+	for range b.config.rollingWindows() {
 		features[idx] = 0.5   // mean
 		features[idx+1] = 0.1 // std
 		features[idx+2] = 0.6 // max
@@ -498,8 +1237,8 @@ func (b *PredictiveFeatureBuilder) getDefaultMetricFeatures() []float64 {
 	}
 
 	// Default diff and pct_change
-	features[FeaturesPerMetric-2] = 0.0 // diff
-	features[FeaturesPerMetric-1] = 0.0 // pct_change
+	features[count-2] = 0.0 // diff
+	features[count-1] = 0.0 // pct_change
 
 	return features
 }
@@ -509,13 +1248,14 @@ func (b *PredictiveFeatureBuilder) getDefaultMetricFeatures() []float64 {
 func (b *PredictiveFeatureBuilder) GetDefaultFeatures() *FeatureVector {
 	totalFeatures := b.calculateTotalFeatures()
 	features := make([]float64, totalFeatures)
+	now := b.now()
 
 	idx := 0
 	for hourOffset := 0; hourOffset < b.config.LookbackHours; hourOffset++ {
-		timestamp := time.Now().Add(-time.Duration(hourOffset) * time.Hour)
+		timestamp := now.Add(-time.Duration(hourOffset) * time.Hour)
 
 		// 1. Raw metric values (5 features)
-		for range predictiveBaseMetrics {
+		for range b.config.baseMetrics() {
 			features[idx] = 0.5 // Default raw metric value
 			idx++
 		}
@@ -526,30 +1266,33 @@ func (b *PredictiveFeatureBuilder) GetDefaultFeatures() *FeatureVector {
 		idx += len(timeFeatures)
 
 		// 3. Engineered metric features (25 × 5 = 125 features)
-		for range predictiveBaseMetrics {
+		for range b.config.baseMetrics() {
 			defaultMetricFeatures := b.getDefaultMetricFeatures()
 			copy(features[idx:], defaultMetricFeatures)
 			idx += len(defaultMetricFeatures)
 		}
 	}
 
-	return &FeatureVector{
+	vector := &FeatureVector{
 		Features:     features,
 		FeatureCount: len(features),
 		MetricsData:  b.getDefaultMetricsData(),
-		Timestamp:    time.Now(),
+		Timestamp:    now,
 	}
+	if b.scaler != nil {
+		b.scaler.transformAt(vector.Features, 0) // Length is checked at construction
+		vector.Scaled = true
+	}
+	return vector
 }
 
-// getDefaultMetricsData returns default raw metric values
+// getDefaultMetricsData returns default raw metric values of the selected base metrics
 func (b *PredictiveFeatureBuilder) getDefaultMetricsData() map[string]float64 {
-	return map[string]float64{
-		"cpu_usage":    0.5,
-		"memory_usage": 0.5,
-		"disk_usage":   0.5,
-		"network_in":   0.5,
-		"network_out":  0.5,
+	data := make(map[string]float64, len(b.config.baseMetrics()))
+	for _, metric := range b.config.baseMetrics() {
+		data[metric] = 0.5
 	}
+	return data
 }
 
 // Helper functions
@@ -608,16 +1351,52 @@ func GetPredictiveBaseMetrics() []string {
 	return result
 }
 
-// GetPredictiveFeatureNames returns the list of feature names per metric
+// GetPredictiveFeatureNames returns the list of feature names per metric with the default periods
 func GetPredictiveFeatureNames() []string {
 	result := make([]string, len(predictiveFeatureNames))
 	copy(result, predictiveFeatureNames)
 	return result
 }
 
-// GetTimeFeatureNames returns the list of time-based feature names
+// metricFeatureNames returns the engineered feature names per metric for the builder's periods
+func (b *PredictiveFeatureBuilder) metricFeatureNames() []string {
+	if len(b.config.LagPeriods) == 0 && len(b.config.RollingWindows) == 0 {
+		return predictiveFeatureNames
+	}
+	names := make([]string, 0, b.config.featuresPerMetric())
+	names = append(names, "value")
+	for _, lag := range b.config.lagPeriods() {
+		names = append(names, fmt.Sprintf("lag_%dh", lag))
+	}
+	for _, stat := range []string{"mean", "std", "max", "min"} {
+		for _, window := range b.config.rollingWindows() {
+			names = append(names, fmt.Sprintf("rolling_%s_%dh", stat, window))
+		}
+	}
+	return append(names, "diff", "pct_change")
+}
+
+// GetTimeFeatureNames returns the default list of time-based feature names
 func GetTimeFeatureNames() []string {
 	result := make([]string, len(timeFeatureNames))
 	copy(result, timeFeatureNames)
 	return result
 }
+
+// SupportedTimeFeatureNames returns every name accepted in PredictiveFeatureConfig.TimeFeatures, sorted
+func SupportedTimeFeatureNames() []string {
+	result := make([]string, 0, len(supportedTimeFeatures))
+	for name := range supportedTimeFeatures {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// TimeFeatureNames returns the time-based features this builder emits, in vector order
+func (b *PredictiveFeatureBuilder) TimeFeatureNames() []string {
+	names := b.config.timeFeatureNames()
+	result := make([]string, len(names))
+	copy(result, names)
+	return result
+}
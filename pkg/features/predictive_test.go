@@ -2,13 +2,21 @@
 package features
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
 )
 
 // MockMetricDataProvider implements MetricDataProvider for testing
@@ -19,6 +27,9 @@ type MockMetricDataProvider struct {
 	// QueryFunc allows customizing Query behavior in tests
 	QueryFunc func(ctx context.Context, query string) (float64, error)
 
+	// QueryAtFunc allows customizing QueryAt behavior in tests
+	QueryAtFunc func(ctx context.Context, query string, at time.Time) (float64, error)
+
 	// IsAvailableResult controls the return value of IsAvailable
 	IsAvailableResult bool
 }
@@ -47,6 +58,21 @@ func (m *MockMetricDataProvider) Query(ctx context.Context, query string) (float
 	return 0.65, nil
 }
 
+func (m *MockMetricDataProvider) QueryAt(ctx context.Context, query string, at time.Time) (float64, error) {
+	if m.QueryAtFunc != nil {
+		return m.QueryAtFunc(ctx, query, at)
+	}
+	// Default: the last point of the minute ending at the requested time
+	points, err := m.QueryRange(ctx, query, at.Add(-time.Minute), at, time.Minute)
+	if err != nil {
+		return 0, err
+	}
+	if len(points) == 0 {
+		return 0, fmt.Errorf("no data at %s", at.Format(time.RFC3339))
+	}
+	return points[len(points)-1].Value, nil
+}
+
 func (m *MockMetricDataProvider) IsAvailable() bool {
 	return m.IsAvailableResult
 }
@@ -58,11 +84,51 @@ func TestNewPredictiveFeatureBuilder(t *testing.T) {
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
 
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	assert.NotNil(t, builder)
 }
 
+func TestNewPredictiveFeatureBuilder_LookbackGuard(t *testing.T) {
+	log := logrus.New()
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+
+	t.Run("rejects non-positive lookback", func(t *testing.T) {
+		for _, hours := range []int{0, -1} {
+			builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: hours, Enabled: true}, log)
+			assert.Error(t, err)
+			assert.Nil(t, builder)
+		}
+	})
+
+	t.Run("rejects negative max lookback", func(t *testing.T) {
+		_, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 24, MaxLookbackHours: -1}, log)
+		assert.Error(t, err)
+	})
+
+	t.Run("clamps to default max", func(t *testing.T) {
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 168, Enabled: true}, log)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultMaxLookbackHours, builder.GetFeatureInfo().LookbackHours)
+		assert.Equal(t, 9792, builder.FeatureCount())
+	})
+
+	t.Run("clamps to custom max", func(t *testing.T) {
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 48, MaxLookbackHours: 12}, log)
+		require.NoError(t, err)
+		assert.Equal(t, 12, builder.GetFeatureInfo().LookbackHours)
+		assert.Equal(t, 12*136, builder.FeatureCount())
+	})
+
+	t.Run("leaves lookback within max unchanged", func(t *testing.T) {
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 48}, log)
+		require.NoError(t, err)
+		assert.Equal(t, 48, builder.GetFeatureInfo().LookbackHours)
+		assert.Equal(t, 6528, builder.FeatureCount())
+	})
+}
+
 func TestDefaultPredictiveConfig(t *testing.T) {
 	config := DefaultPredictiveConfig()
 
@@ -74,7 +140,8 @@ func TestGetFeatureInfo(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	info := builder.GetFeatureInfo()
 
@@ -85,13 +152,65 @@ func TestGetFeatureInfo(t *testing.T) {
 	assert.Equal(t, 6, info.TimeFeatures) // Verify TimeFeatureCount is 6
 	// Total features should be exactly 3264 (matching Python model)
 	assert.Equal(t, 3264, info.TotalFeatures)
+	assert.Equal(t, ClusterAggregationMean, info.ClusterAggregation)
+
+	assert.Equal(t, FeatureCountBreakdown{
+		LookbackHours:            24,
+		RawMetricFeatures:        5,
+		EngineeredMetricFeatures: 125,
+		TimeFeatures:             6,
+		ColumnsPerTimestep:       136,
+		Total:                    3264,
+	}, info.Breakdown)
+}
+
+// TestGetFeatureInfo_CustomPeriods verifies the layout follows configured lags and rolling windows
+func TestGetFeatureInfo_CustomPeriods(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+	config := DefaultPredictiveConfig()
+	config.LookbackHours = 2
+	config.LagPeriods = []int{1, 24, 168}
+	config.RollingWindows = []int{6, 24}
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	info := builder.GetFeatureInfo()
+	perMetric := 1 + 3 + 4*2 + 2
+	assert.Equal(t, perMetric, info.FeaturesPerMetric)
+	assert.Equal(t, []int{1, 24, 168}, info.LagPeriods)
+	assert.Equal(t, []int{6, 24}, info.RollingWindows)
+	assert.Equal(t, 2*(5+6+5*perMetric), info.TotalFeatures)
+
+	vector, err := builder.BuildFeatures(context.Background(), "", "", "")
+	require.NoError(t, err)
+	assert.Len(t, vector.Features, info.TotalFeatures)
+	assert.Len(t, builder.GetDefaultFeatures().Features, info.TotalFeatures)
+	assert.Equal(t, "t-0h:cpu_usage.lag_168h", builder.FeatureName(5+6+3))
+	assert.Equal(t, "t-0h:memory_usage.value", builder.FeatureName(5+6+perMetric))
+
+	// Point-query builds produce the same layout
+	config.ResampleRule = 0
+	pointBuilder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+	vector, err = pointBuilder.BuildFeatures(context.Background(), "", "", "")
+	require.NoError(t, err)
+	assert.Len(t, vector.Features, info.TotalFeatures)
+
+	for _, periods := range [][]int{{0}, {-1}, {6, 6}} {
+		config.LagPeriods = periods
+		_, err := NewPredictiveFeatureBuilder(provider, config, log)
+		assert.Error(t, err, "lag periods %v", periods)
+	}
 }
 
 func TestBuildTimeFeatures(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	// Test a specific timestamp: Wednesday, 2:30 PM
 	testTime := time.Date(2026, 1, 28, 14, 30, 0, 0, time.UTC)
@@ -113,7 +232,8 @@ func TestBuildTimeFeaturesWeekend(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	// Test a Saturday at 10 AM
 	testTime := time.Date(2026, 2, 7, 10, 0, 0, 0, time.UTC)
@@ -129,11 +249,53 @@ func TestBuildTimeFeaturesWeekend(t *testing.T) {
 	assert.Equal(t, 0.0, features[5])  // is_business_hours (weekend, so not business hours)
 }
 
+func TestBuildTimeFeatures_Configured(t *testing.T) {
+	log := logrus.New()
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+	config := DefaultPredictiveConfig()
+	config.LookbackHours = 2
+	config.TimeFeatures = []string{"week_of_year", "quarter", "hour"}
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	// Friday, January 1st 2027 belongs to ISO week 53 of 2026
+	features := builder.buildTimeFeatures(time.Date(2027, 1, 1, 8, 0, 0, 0, time.UTC))
+	assert.Equal(t, []float64{53, 1, 8}, features)
+
+	features = builder.buildTimeFeatures(time.Date(2026, 11, 16, 8, 0, 0, 0, time.UTC))
+	assert.Equal(t, []float64{47, 4, 8}, features)
+
+	columns := len(predictiveBaseMetrics) + 3 + FeaturesPerMetric*len(predictiveBaseMetrics)
+	info := builder.GetFeatureInfo()
+	assert.Equal(t, 3, info.TimeFeatures)
+	assert.Equal(t, []string{"week_of_year", "quarter", "hour"}, info.TimeFeatureNames)
+	assert.Equal(t, 2*columns, builder.FeatureCount())
+	assert.Equal(t, "t-0h:quarter", builder.FeatureName(len(predictiveBaseMetrics)+1))
+	assert.Len(t, builder.GetDefaultFeatures().Features, 2*columns)
+}
+
+func TestPredictiveFeatureConfig_TimeFeatureValidation(t *testing.T) {
+	log := logrus.New()
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+
+	for _, names := range [][]string{{"hour", "minute"}, {"hour", "hour"}} {
+		config := DefaultPredictiveConfig()
+		config.TimeFeatures = names
+		_, err := NewPredictiveFeatureBuilder(provider, config, log)
+		assert.Error(t, err, "%v", names)
+	}
+
+	assert.NoError(t, ValidateTimeFeatureNames(nil))
+	assert.NoError(t, ValidateTimeFeatureNames(SupportedTimeFeatureNames()))
+	assert.Len(t, SupportedTimeFeatureNames(), 8)
+}
+
 func TestGetDefaultFeatures(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	featureVector := builder.GetDefaultFeatures()
 
@@ -148,7 +310,8 @@ func TestGetDefaultMetricFeatures(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	features := builder.getDefaultMetricFeatures()
 
@@ -163,9 +326,10 @@ func TestBuildFeaturesProviderUnavailable(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: false}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
-	_, err := builder.BuildFeatures(context.Background(), "", "", "")
+	_, err = builder.BuildFeatures(context.Background(), "", "", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not available")
@@ -174,9 +338,10 @@ func TestBuildFeaturesProviderUnavailable(t *testing.T) {
 func TestBuildFeaturesNilProvider(t *testing.T) {
 	log := logrus.New()
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(nil, config, log)
+	builder, err := NewPredictiveFeatureBuilder(nil, config, log)
+	require.NoError(t, err)
 
-	_, err := builder.BuildFeatures(context.Background(), "", "", "")
+	_, err = builder.BuildFeatures(context.Background(), "", "", "")
 
 	assert.Error(t, err)
 }
@@ -209,7 +374,8 @@ func TestBuildFeaturesSuccess(t *testing.T) {
 		LookbackHours: 2, // Use shorter lookback for faster tests
 		Enabled:       true,
 	}
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	featureVector, err := builder.BuildFeatures(context.Background(), "test-namespace", "", "")
 
@@ -226,6 +392,362 @@ func TestBuildFeaturesSuccess(t *testing.T) {
 	}
 }
 
+// TestBuildFeatures_DefaultedMetrics verifies failed current-value queries are reported
+func TestBuildFeatures_DefaultedMetrics(t *testing.T) {
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			if strings.Contains(query, "node_filesystem") {
+				return nil, nil // falls back to the instant query, which fails too
+			}
+			return []DataPoint{{Timestamp: start, Value: 0.3}, {Timestamp: end, Value: 0.3}}, nil
+		},
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0, fmt.Errorf("no data")
+		},
+	}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	featureVector, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"disk_usage"}, featureVector.DefaultedMetrics)
+	assert.Equal(t, 0.5, featureVector.MetricsData["disk_usage"])
+	assert.Equal(t, 0.3, featureVector.MetricsData["cpu_usage"])
+}
+
+// TestBuildFeatures_Clock verifies an injected clock anchors live builds, default vectors and
+// reference comparisons, so repeated builds are identical
+func TestBuildFeatures_Clock(t *testing.T) {
+	now := time.Date(2026, 3, 16, 14, 30, 0, 0, time.UTC) // Monday
+	var latestEnd time.Time
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			if end.After(latestEnd) {
+				latestEnd = end
+			}
+			return []DataPoint{{Timestamp: start, Value: 0.4}, {Timestamp: end, Value: 0.4}}, nil
+		},
+	}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+	builder.SetClock(func() time.Time { return now })
+
+	first, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	second, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, first.Features, second.Features)
+	assert.Equal(t, now, first.Timestamp)
+	assert.Equal(t, now, latestEnd)
+	assert.Equal(t, []float64{14, 0, 16, 3, 0, 1}, first.Features[5:5+len(GetTimeFeatureNames())])
+
+	asOf, err := builder.BuildFeaturesAsOf(context.Background(), now, "payments", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, first.Features, asOf.Features, "BuildFeatures is BuildFeaturesAsOf at the clock's time")
+
+	defaults := builder.GetDefaultFeatures()
+	assert.Equal(t, now, defaults.Timestamp)
+	assert.Equal(t, []float64{14, 0, 16, 3, 0, 1}, defaults.Features[5:5+len(GetTimeFeatureNames())])
+
+	mismatches, err := builder.CompareToReference(context.Background(), first.Features)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	builder.SetClock(nil)
+	assert.Less(t, time.Since(builder.now()).Abs(), time.Minute, "nil restores the wall clock")
+}
+
+// TestBuildFeaturesAsOf verifies historical builds query ranges ending at asOf and never
+// fill gaps with the current value from an instant query
+func TestBuildFeaturesAsOf(t *testing.T) {
+	asOf := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC) // Saturday
+	var latestEnd time.Time
+	var instantQueries int
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			if end.After(latestEnd) {
+				latestEnd = end
+			}
+			if strings.Contains(query, "node_filesystem") {
+				return nil, nil
+			}
+			return []DataPoint{{Timestamp: start, Value: 0.3}, {Timestamp: end, Value: 0.3}}, nil
+		},
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			instantQueries++
+			return 0.99, nil
+		},
+	}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	featureVector, err := builder.BuildFeaturesAsOf(context.Background(), asOf, "payments", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, asOf, featureVector.Timestamp)
+	assert.Equal(t, asOf, latestEnd, "no query reads past asOf")
+	assert.Zero(t, instantQueries)
+	assert.Equal(t, []string{"disk_usage"}, featureVector.DefaultedMetrics)
+	assert.Equal(t, 0.5, featureVector.MetricsData["disk_usage"])
+
+	// Newest timestep's time features follow the 5 raw metric values
+	timeFeatures := featureVector.Features[5 : 5+len(GetTimeFeatureNames())]
+	assert.Equal(t, []float64{9, 5, 14, 3, 1, 0}, timeFeatures)
+
+	_, err = builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	assert.Positive(t, instantQueries, "live builds still fall back to instant queries")
+}
+
+// TestQueryAtTime verifies point-in-time values come from an instant query evaluated at the
+// timestamp, falling back to the current value only for live builds
+func TestQueryAtTime(t *testing.T) {
+	at := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	var queriedAt []time.Time
+	var rangeQueries int
+	failAt := false
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryAtFunc: func(ctx context.Context, query string, ts time.Time) (float64, error) {
+			queriedAt = append(queriedAt, ts)
+			if failAt {
+				return 0, fmt.Errorf("no data")
+			}
+			return 0.42, nil
+		},
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			rangeQueries++
+			return nil, nil
+		},
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0.99, nil
+		},
+	}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+	query := builder.newMetricQuery("cpu_usage", "payments", "", "")
+
+	value, err := builder.queryAtTime(context.Background(), query, at)
+	require.NoError(t, err)
+	assert.Equal(t, 0.42, value)
+	assert.Equal(t, []time.Time{at}, queriedAt)
+	assert.Zero(t, rangeQueries, "no range query approximates the instant value")
+
+	failAt = true
+	value, err = builder.queryAtTime(context.Background(), query, at)
+	require.NoError(t, err)
+	assert.Equal(t, 0.99, value, "live builds fall back to the current value")
+
+	query.noInstantFallback = true
+	_, err = builder.queryAtTime(context.Background(), query, at)
+	assert.Error(t, err, "historical builds never read the current value")
+}
+
+// TestBuildFeatures_LogQueries verifies executed PromQL queries are only logged when enabled
+func TestBuildFeatures_LogQueries(t *testing.T) {
+	var logBuf bytes.Buffer
+	log := logrus.New()
+	log.SetLevel(logrus.InfoLevel)
+	log.SetOutput(&logBuf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			if strings.Contains(query, "node_filesystem") {
+				return nil, nil // disk_usage has no data and falls back to an instant query
+			}
+			return []DataPoint{{Timestamp: start, Value: 0.2}, {Timestamp: end, Value: 0.3}}, nil
+		},
+	}
+
+	config := PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	_, err = builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	assert.NotContains(t, logBuf.String(), `"promql"`, "queries are not logged by default")
+
+	config.LogQueries = true
+	builder, err = NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	logBuf.Reset()
+	_, err = builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+
+	var diskInstant, cpuRange bool
+	for _, line := range strings.Split(strings.TrimSpace(logBuf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if _, ok := entry["promql"]; !ok {
+			continue
+		}
+		assert.Equal(t, "payments", entry["namespace"])
+		assert.Contains(t, entry["promql"], `namespace="payments"`)
+		switch {
+		case entry["metric"] == "disk_usage" && entry["query_type"] == "instant":
+			diskInstant = true
+			assert.Equal(t, 0.65, entry["value"])
+		case entry["metric"] == "cpu_usage" && entry["query_type"] == "range":
+			cpuRange = true
+			assert.Equal(t, float64(2), entry["points"])
+			assert.Equal(t, 0.3, entry["value"])
+		}
+	}
+	assert.True(t, diskInstant, "instant fallback for disk_usage should be logged")
+	assert.True(t, cpuRange, "range query for cpu_usage should be logged")
+}
+
+func TestBuildFeaturesWithTime(t *testing.T) {
+	log := logrus.New()
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0.65, nil
+		},
+	}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, log)
+	require.NoError(t, err)
+
+	end := time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC) // Monday
+	window := builder.BuildTimeFeatureWindow(end)
+	require.Len(t, window.Steps, 2)
+	assert.Equal(t, builder.buildTimeFeatures(end.Add(-time.Hour)), window.Steps[1])
+
+	columns := len(predictiveBaseMetrics) + TimeFeatureCount + FeaturesPerMetric*len(predictiveBaseMetrics)
+	for _, namespace := range []string{"team-a", "team-b"} {
+		vector, err := builder.BuildFeaturesWithTime(context.Background(), window, namespace, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, end, vector.Timestamp)
+		require.Equal(t, 2*columns, vector.FeatureCount)
+
+		// Time columns of each timestep come straight from the shared window
+		for step := range window.Steps {
+			offset := step*columns + len(predictiveBaseMetrics)
+			assert.Equal(t, window.Steps[step], vector.Features[offset:offset+TimeFeatureCount])
+		}
+	}
+
+	other, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 3, Enabled: true}, log)
+	require.NoError(t, err)
+	_, err = other.BuildFeaturesWithTime(context.Background(), window, "team-a", "", "")
+	assert.Error(t, err, "window from a builder with a different lookback")
+}
+
+func TestBuildTargetTimeFeatureWindow(t *testing.T) {
+	builder, err := NewPredictiveFeatureBuilder(&MockMetricDataProvider{IsAvailableResult: true},
+		PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC)     // Monday
+	target := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC) // Sunday
+	window := builder.BuildTargetTimeFeatureWindow(now, target)
+
+	assert.Equal(t, now, window.End, "metrics are still read up to now")
+	assert.Equal(t, builder.buildTimeFeatures(target), window.Steps[0])
+	assert.Equal(t, builder.buildTimeFeatures(target.Add(-time.Hour)), window.Steps[1])
+	assert.Equal(t, float64(10), window.Steps[0][0], "hour")
+	assert.Equal(t, float64(6), window.Steps[0][1], "day_of_week")
+}
+
+func TestWithTimeFeatures(t *testing.T) {
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0.65, nil
+		},
+	}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC) // Monday
+	vector, err := builder.BuildFeaturesWithTime(context.Background(), builder.BuildTimeFeatureWindow(now), "team-a", "", "")
+	require.NoError(t, err)
+
+	target := time.Date(2026, 1, 10, 3, 0, 0, 0, time.UTC) // Saturday
+	window := builder.BuildTargetTimeFeatureWindow(now, target)
+	retimed, err := builder.WithTimeFeatures(vector.Features, window)
+	require.NoError(t, err)
+
+	// The same as building with the target window, without querying metrics again
+	expected, err := builder.BuildFeaturesWithTime(context.Background(), window, "team-a", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, expected.Features, retimed)
+	assert.NotEqual(t, vector.Features, retimed, "input must not be modified")
+
+	_, err = builder.WithTimeFeatures(vector.Features[1:], window)
+	assert.Error(t, err)
+	_, err = builder.WithTimeFeatures(vector.Features, &TimeFeatureWindow{Steps: window.Steps[:1]})
+	assert.Error(t, err)
+}
+
+func TestBuildFeatures_DiscardsNonFiniteValues(t *testing.T) {
+	for _, rule := range []time.Duration{0, time.Hour} {
+		t.Run(fmt.Sprintf("resample_%s", rule), func(t *testing.T) {
+			provider := &MockMetricDataProvider{
+				IsAvailableResult: true,
+				QueryAtFunc: func(ctx context.Context, query string, at time.Time) (float64, error) {
+					if strings.Contains(query, "memory") {
+						return math.NaN(), nil
+					}
+					return 0.4, nil
+				},
+				QueryFunc: func(ctx context.Context, query string) (float64, error) {
+					return math.Inf(1), nil
+				},
+				QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+					var points []DataPoint
+					for ts := start; !ts.After(end); ts = ts.Add(step) {
+						value := 0.4
+						if strings.Contains(query, "memory") {
+							value = math.NaN()
+						} else if ts.Minute() == 30 {
+							value = math.Inf(-1)
+						}
+						points = append(points, DataPoint{Timestamp: ts, Value: value})
+					}
+					return points, nil
+				},
+			}
+			builder, err := NewPredictiveFeatureBuilder(provider,
+				PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, ResampleRule: rule}, logrus.New())
+			require.NoError(t, err)
+
+			now := time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC)
+			vector, err := builder.BuildFeaturesWithTime(context.Background(), builder.BuildTimeFeatureWindow(now), "payments", "", "")
+			require.NoError(t, err)
+
+			for i, value := range vector.Features {
+				require.False(t, math.IsNaN(value) || math.IsInf(value, 0), "feature %d (%s) is %v", i, builder.FeatureName(i), value)
+			}
+			assert.Equal(t, 0.5, vector.MetricsData["memory_usage"], "memory falls back to its default")
+			assert.Contains(t, vector.DefaultedMetrics, "memory_usage")
+			assert.Equal(t, 0.4, vector.MetricsData["cpu_usage"])
+			assert.Positive(t, builder.NonFiniteValues())
+		})
+	}
+}
+
+// TestCheckFinite_RejectedSample verifies samples the client rejected as non-finite are counted
+// like NaN values returned as-is
+func TestCheckFinite_RejectedSample(t *testing.T) {
+	builder, err := NewPredictiveFeatureBuilder(&MockMetricDataProvider{IsAvailableResult: true}, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+	query := metricQuery{metric: "memory_usage"}
+
+	_, err = builder.checkFinite(context.Background(), query, 0, fmt.Errorf("query: %w", integrations.ErrNonFiniteSample))
+	assert.ErrorIs(t, err, errNonFinite)
+	assert.Equal(t, int64(1), builder.NonFiniteValues())
+
+	_, err = builder.checkFinite(context.Background(), query, 0, errors.New("timeout"))
+	assert.NotErrorIs(t, err, errNonFinite)
+	assert.Equal(t, int64(1), builder.NonFiniteValues(), "other failures are not counted")
+}
+
 func TestCalculateStats(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -285,7 +807,8 @@ func TestCalculateTotalFeatures(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	total := builder.calculateTotalFeatures()
 
@@ -369,7 +892,8 @@ func TestGetMetricQuery(t *testing.T) {
 	log := logrus.New()
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
 
 	tests := []struct {
 		name       string
@@ -404,6 +928,13 @@ func TestGetMetricQuery(t *testing.T) {
 			pod:       "my-pod-abc123",
 			contains:  `pod="my-pod-abc123"`,
 		},
+		{
+			name:       "deployment name is regex-escaped",
+			metric:     "cpu_usage",
+			namespace:  "test-ns",
+			deployment: "api.v2",
+			contains:   `pod=~"api\\.v2-.*"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -414,12 +945,139 @@ func TestGetMetricQuery(t *testing.T) {
 	}
 }
 
+func TestGetMetricQuery_ClusterAggregation(t *testing.T) {
+	log := logrus.New()
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+
+	tests := []struct {
+		name          string
+		aggregation   string
+		topNamespaces int
+		wantCPU       string
+	}{
+		{
+			name:        "default mean",
+			aggregation: "",
+			wantCPU:     `avg(rate(container_cpu_usage_seconds_total{container!="",pod!=""}[5m]))`,
+		},
+		{
+			name:          "top namespaces",
+			aggregation:   ClusterAggregationTopNamespaces,
+			topNamespaces: 3,
+			wantCPU:       `avg(topk(3, avg by (namespace) (rate(container_cpu_usage_seconds_total{container!="",pod!=""}[5m]))))`,
+		},
+		{
+			name:        "top namespaces default count",
+			aggregation: ClusterAggregationTopNamespaces,
+			wantCPU:     `avg(topk(5, avg by (namespace) (rate(container_cpu_usage_seconds_total{container!="",pod!=""}[5m]))))`,
+		},
+		{
+			name:        "weighted",
+			aggregation: ClusterAggregationWeighted,
+			wantCPU: `(sum((avg by (namespace) (rate(container_cpu_usage_seconds_total{container!="",pod!=""}[5m]))) ^ 2)` +
+				` / sum(avg by (namespace) (rate(container_cpu_usage_seconds_total{container!="",pod!=""}[5m]))))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultPredictiveConfig()
+			config.ClusterAggregation = tt.aggregation
+			config.ClusterTopNamespaces = tt.topNamespaces
+			builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCPU, builder.getMetricQuery("cpu_usage", "", "", ""))
+
+			// Scoped queries and node-level disk usage always use the plain mean
+			assert.Equal(t, `avg(rate(container_cpu_usage_seconds_total{container!="",pod!="",namespace="payments"}[5m]))`,
+				builder.getMetricQuery("cpu_usage", "payments", "", ""))
+			assert.NotContains(t, builder.getMetricQuery("disk_usage", "", "", ""), "namespace")
+			assert.True(t, strings.HasSuffix(builder.getMetricQuery("memory_usage", "", "", ""),
+				` / avg(kube_node_status_allocatable{resource="memory"})`))
+		})
+	}
+}
+
+func TestPredictiveFeatureConfig_ClusterAggregationValidation(t *testing.T) {
+	config := DefaultPredictiveConfig()
+	config.ClusterAggregation = "max"
+	assert.ErrorContains(t, config.Validate(), "unknown cluster aggregation")
+
+	config.ClusterAggregation = ClusterAggregationWeighted
+	config.ClusterTopNamespaces = -1
+	assert.ErrorContains(t, config.Validate(), "must not be negative")
+
+	config.ClusterTopNamespaces = 0
+	assert.NoError(t, config.Validate())
+}
+
+func TestPredictiveFeatureConfig_BaseMetrics(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		metrics []string
+		want    string
+	}{
+		{name: "unknown", metrics: []string{"gpu_usage"}, want: "unsupported base metric"},
+		{name: "duplicate", metrics: []string{"cpu_usage", "cpu_usage"}, want: "duplicate base metric"},
+		{name: "subset without expected count", metrics: []string{"cpu_usage", "memory_usage"}, want: "expected feature count must be set"},
+	} {
+		config := PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, BaseMetrics: tt.metrics}
+		assert.ErrorContains(t, config.Validate(), tt.want, tt.name)
+	}
+
+	all := PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, BaseMetrics: GetPredictiveBaseMetrics()}
+	assert.NoError(t, all.Validate(), "all metrics need no expected count")
+
+	// 2 × (3 raw + 6 time + 25 × 3 engineered)
+	subset := PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, ExpectedFeatureCount: 167,
+		BaseMetrics: []string{"disk_usage", "cpu_usage", "memory_usage"}}
+	_, err := NewPredictiveFeatureBuilder(nil, subset, logrus.New())
+	assert.ErrorContains(t, err, "produce 168 features, the model expects 167")
+
+	var queried []string
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryAtFunc: func(_ context.Context, query string, _ time.Time) (float64, error) {
+			queried = append(queried, query)
+			return 0.4, nil
+		},
+	}
+	subset.ExpectedFeatureCount = 168
+	builder, err := NewPredictiveFeatureBuilder(provider, subset, logrus.New())
+	require.NoError(t, err)
+
+	info := builder.GetFeatureInfo()
+	assert.Equal(t, []string{"cpu_usage", "memory_usage", "disk_usage"}, info.BaseMetrics, "columns keep the notebook's order")
+	assert.Equal(t, 3, info.Breakdown.RawMetricFeatures)
+	assert.Equal(t, "t-0h:disk_usage", builder.FeatureName(2))
+	assert.Equal(t, "t-0h:hour", builder.FeatureName(3))
+	assert.Equal(t, "t-1h:cpu_usage", builder.FeatureName(84))
+	assert.Len(t, builder.GetDefaultFeatures().Features, 168)
+	assert.Len(t, builder.GetDefaultFeatures().MetricsData, 3)
+
+	vector, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	assert.Len(t, vector.Features, 168)
+	assert.Equal(t, map[string]float64{"cpu_usage": 0.4, "memory_usage": 0.4, "disk_usage": 0.4}, vector.MetricsData)
+	for _, query := range queried {
+		assert.NotContains(t, query, "network", "disabled metrics are not queried")
+	}
+
+	plan, err := builder.PlanQueries(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	for _, entry := range plan {
+		assert.NotContains(t, []string{"network_in", "network_out"}, entry.Metric)
+	}
+}
+
 func BenchmarkBuildTimeFeatures(b *testing.B) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(b, err)
 
 	testTime := time.Now()
 
@@ -434,10 +1092,81 @@ func BenchmarkGetDefaultFeatures(b *testing.B) {
 	log.SetLevel(logrus.ErrorLevel)
 	provider := &MockMetricDataProvider{IsAvailableResult: true}
 	config := DefaultPredictiveConfig()
-	builder := NewPredictiveFeatureBuilder(provider, config, log)
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(b, err)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		builder.GetDefaultFeatures()
 	}
 }
+
+func TestBuildFeatures_MaxBuildDuration(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	// Every query takes 5ms unless its context ends first
+	slow := func(ctx context.Context) error {
+		select {
+		case <-time.After(5 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryAtFunc: func(ctx context.Context, query string, at time.Time) (float64, error) {
+			return 0.3, slow(ctx)
+		},
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0.3, slow(ctx)
+		},
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			return []DataPoint{{Timestamp: start, Value: 0.3}, {Timestamp: end, Value: 0.3}}, slow(ctx)
+		},
+	}
+
+	t.Run("stops querying and defaults the rest", func(t *testing.T) {
+		builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 4, Enabled: true, MaxBuildDuration: 30 * time.Millisecond}, log)
+		require.NoError(t, err)
+
+		started := time.Now()
+		vector, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+		require.NoError(t, err)
+		assert.Less(t, time.Since(started), 200*time.Millisecond, "bounded by the build duration, not the query count")
+		assert.True(t, vector.Partial)
+		assert.Positive(t, vector.DefaultedFeatures)
+		assert.Less(t, vector.DefaultedFeatures, vector.FeatureCount)
+		assert.Equal(t, builder.FeatureCount(), vector.FeatureCount, "the vector keeps its shape")
+		assert.InDelta(t, 0.3, vector.MetricsData["cpu_usage"], 1e-9, "features built in time keep their values")
+	})
+
+	t.Run("complete within the duration", func(t *testing.T) {
+		// Cover the whole range; the default mock data spans under an hour, which leaves a
+		// resampled bucket empty late in the hour
+		covering := &MockMetricDataProvider{
+			IsAvailableResult: true,
+			QueryRangeFunc: func(_ context.Context, _ string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+				var points []DataPoint
+				for ts := start; !ts.After(end); ts = ts.Add(step) {
+					points = append(points, DataPoint{Timestamp: ts, Value: 0.3})
+				}
+				return points, nil
+			},
+		}
+		builder, err := NewPredictiveFeatureBuilder(covering,
+			PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, ResampleRule: time.Hour, MaxBuildDuration: time.Minute}, log)
+		require.NoError(t, err)
+		builder.SetClock(func() time.Time { return time.Date(2026, 3, 16, 14, 57, 0, 0, time.UTC) })
+		vector, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+		require.NoError(t, err)
+		assert.False(t, vector.Partial)
+		assert.Zero(t, vector.DefaultedFeatures)
+	})
+
+	t.Run("negative duration", func(t *testing.T) {
+		_, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, MaxBuildDuration: -time.Second}, log)
+		assert.ErrorContains(t, err, "max build duration must not be negative")
+	})
+}
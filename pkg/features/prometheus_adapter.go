@@ -4,18 +4,24 @@ package features
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/KubeHeal/openshift-coordination-engine/internal/integrations"
 )
 
+// QueryBatchConcurrency bounds the range queries of one QueryRangeBatch in flight at once. It
+// stays within the client's idle connection pool, so batches reuse kept-alive connections.
+const QueryBatchConcurrency = 4
+
 // PrometheusAdapter adapts the PrometheusClient to the MetricDataProvider interface.
 // This allows the PredictiveFeatureBuilder to use Prometheus as its data source.
 type PrometheusAdapter struct {
 	client *integrations.PrometheusClient
 }
 
-// NewPrometheusAdapter creates a new adapter wrapping a PrometheusClient
+// NewPrometheusAdapter creates a new adapter wrapping a PrometheusClient.
+// TLS and bearer-token auth are configured on the client via integrations.NewPrometheusClientWithOptions.
 func NewPrometheusAdapter(client *integrations.PrometheusClient) *PrometheusAdapter {
 	return &PrometheusAdapter{client: client}
 }
@@ -44,6 +50,28 @@ func (a *PrometheusAdapter) QueryRange(ctx context.Context, query string, start,
 	return dataPoints, nil
 }
 
+// QueryRangeBatch implements BatchMetricDataProvider.QueryRangeBatch. Prometheus has no
+// multi-query API, so the queries run concurrently over the client's shared connection pool,
+// at most QueryBatchConcurrency at a time.
+func (a *PrometheusAdapter) QueryRangeBatch(ctx context.Context, queries []RangeQuery) ([][]DataPoint, error) {
+	results := make([][]DataPoint, len(queries))
+	errs := make([]error, len(queries))
+	sem := make(chan struct{}, QueryBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query RangeQuery) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = a.QueryRange(ctx, query.Query, query.Start, query.End, query.Step)
+		}(i, query)
+	}
+	wg.Wait()
+	return results, newBatchQueryError(errs)
+}
+
 // Query implements MetricDataProvider.Query by delegating to PrometheusClient
 func (a *PrometheusAdapter) Query(ctx context.Context, query string) (float64, error) {
 	if a.client == nil {
@@ -56,6 +84,18 @@ func (a *PrometheusAdapter) Query(ctx context.Context, query string) (float64, e
 	return value, nil
 }
 
+// QueryAt implements MetricDataProvider.QueryAt using an instant query with an evaluation time
+func (a *PrometheusAdapter) QueryAt(ctx context.Context, query string, at time.Time) (float64, error) {
+	if a.client == nil {
+		return 0, nil
+	}
+	value, err := a.client.QueryAtTime(ctx, query, at)
+	if err != nil {
+		return 0, fmt.Errorf("prometheus instant query at %s failed: %w", at.Format(time.RFC3339), err)
+	}
+	return value, nil
+}
+
 // IsAvailable implements MetricDataProvider.IsAvailable
 func (a *PrometheusAdapter) IsAvailable() bool {
 	return a.client != nil && a.client.IsAvailable()
@@ -0,0 +1,73 @@
+package features
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Results of a feature build query as counted by FeatureQueriesTotal
+const (
+	QueryResultSuccess = "success"
+	QueryResultFailure = "failure"
+)
+
+// FeatureQueriesTotal counts the Prometheus queries feature builds execute, by base metric and
+// result. A query fails when it errors or returns no usable (finite) data, which is what
+// defaults the metric's features.
+var FeatureQueriesTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "coordination_engine_feature_queries_total",
+		Help: "Total Prometheus queries executed while building features, by base metric and result",
+	},
+	[]string{"metric", "result"},
+)
+
+// MetricQueryStats counts one base metric's feature build queries since the builder was created
+type MetricQueryStats struct {
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+}
+
+// queryCounters holds the running query counts of one base metric
+type queryCounters struct {
+	succeeded atomic.Int64
+	failed    atomic.Int64
+}
+
+// newQueryCounters returns counters for every base metric queried, so lookups need no locking
+func newQueryCounters(metrics []string) map[string]*queryCounters {
+	counters := make(map[string]*queryCounters, len(metrics))
+	for _, metric := range metrics {
+		counters[metric] = &queryCounters{}
+	}
+	return counters
+}
+
+// recordQueryResult counts an executed query of metric as failed or succeeded
+func (b *PredictiveFeatureBuilder) recordQueryResult(metric string, failed bool) {
+	counters, ok := b.queryCounters[metric]
+	if !ok {
+		return
+	}
+	result := QueryResultSuccess
+	if failed {
+		counters.failed.Add(1)
+		result = QueryResultFailure
+	} else {
+		counters.succeeded.Add(1)
+	}
+	FeatureQueriesTotal.WithLabelValues(metric, result).Inc()
+}
+
+// QueryStats returns the succeeded and failed query counts per base metric since the builder
+// was created. A metric with mostly failed queries is being defaulted, e.g. because its series
+// has different labels on this cluster.
+func (b *PredictiveFeatureBuilder) QueryStats() map[string]MetricQueryStats {
+	stats := make(map[string]MetricQueryStats, len(b.queryCounters))
+	for metric, counters := range b.queryCounters {
+		stats[metric] = MetricQueryStats{Succeeded: counters.succeeded.Load(), Failed: counters.failed.Load()}
+	}
+	return stats
+}
@@ -0,0 +1,50 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFeatures_QueryStats(t *testing.T) {
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			if strings.Contains(query, "node_filesystem") {
+				return nil, nil // No series matches, e.g. different labels on this cluster
+			}
+			return []DataPoint{{Timestamp: start, Value: 0.3}, {Timestamp: end, Value: 0.3}}, nil
+		},
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0, fmt.Errorf("no data")
+		},
+	}
+
+	for _, config := range []PredictiveFeatureConfig{
+		{LookbackHours: 2, Enabled: true},
+		{LookbackHours: 2, Enabled: true, ResampleRule: time.Hour},
+	} {
+		builder, err := NewPredictiveFeatureBuilder(provider, config, logrus.New())
+		require.NoError(t, err)
+		for _, metric := range predictiveBaseMetrics {
+			assert.Equal(t, MetricQueryStats{}, builder.QueryStats()[metric], "no queries yet")
+		}
+
+		_, err = builder.BuildFeatures(context.Background(), "payments", "", "")
+		require.NoError(t, err)
+
+		stats := builder.QueryStats()
+		disk, cpu := stats["disk_usage"], stats["cpu_usage"]
+		assert.Zero(t, disk.Succeeded, "resample rule %s", config.ResampleRule)
+		assert.Positive(t, disk.Failed)
+		assert.Positive(t, cpu.Succeeded)
+		assert.Zero(t, cpu.Failed)
+		assert.Equal(t, stats, builder.GetFeatureInfo().QueryStats)
+	}
+}
@@ -0,0 +1,88 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultQueryStep is the resolution of the range queries behind point-mode rolling statistics
+// when PredictiveFeatureConfig.QueryStep is not set; 5-minute steps keep the queries cheap
+const DefaultQueryStep = 5 * time.Minute
+
+// minQueryStep is the finest accepted query step
+const minQueryStep = time.Second
+
+// maxQueryPoints is the most samples one range query may return per series; Prometheus rejects
+// range queries above 11,000 points
+const maxQueryPoints = 11000
+
+// queryStepKey carries a per-request query step override
+type queryStepKey struct{}
+
+// WithQueryStep returns a context whose feature builds and query plans use step instead of the
+// configured QueryStep. The caller must validate step with ValidateQueryStep; zero keeps the
+// configured step.
+func WithQueryStep(ctx context.Context, step time.Duration) context.Context {
+	if step <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStepKey{}, step)
+}
+
+// ValidateQueryStep checks step for use with this builder's windows, e.g. as a per-request
+// override. Zero (the configured step) is always valid.
+func (b *PredictiveFeatureBuilder) ValidateQueryStep(step time.Duration) error {
+	return b.config.validateQueryStep(step)
+}
+
+// validateQueryStep checks that every rolling window (and, when resampling, every bucket) holds
+// a whole number of steps, so each window's statistics are computed over the same sample
+// count, and that no range query exceeds Prometheus' point limit
+func (c PredictiveFeatureConfig) validateQueryStep(step time.Duration) error {
+	if step == 0 {
+		return nil
+	}
+	if step < minQueryStep {
+		return fmt.Errorf("query step must be at least %s: %s", minQueryStep, step)
+	}
+
+	if c.ResampleRule > 0 {
+		if c.ResampleRule%step != 0 {
+			return fmt.Errorf("query step %s must divide the resample rule %s evenly", step, c.ResampleRule)
+		}
+		hours := min(c.LookbackHours, c.maxLookbackHours()) - 1 + c.featureHistoryHours()
+		if points := time.Duration(hours) * time.Hour / step; points > maxQueryPoints {
+			return fmt.Errorf("query step %s is too fine: a %dh resample range query would return %d points (max %d)",
+				step, hours, points, maxQueryPoints)
+		}
+		return nil
+	}
+
+	for _, window := range c.rollingWindows() {
+		span := time.Duration(window) * time.Hour
+		if span%step != 0 {
+			return fmt.Errorf("query step %s must divide the %dh rolling window evenly", step, window)
+		}
+		if points := span / step; points > maxQueryPoints {
+			return fmt.Errorf("query step %s is too fine: a %dh rolling window query would return %d points (max %d)",
+				step, window, points, maxQueryPoints)
+		}
+	}
+	return nil
+}
+
+// queryStep returns the range query step of a build under ctx: the request's override, else
+// the configured step, else the default for the query mode
+func (b *PredictiveFeatureBuilder) queryStep(ctx context.Context) time.Duration {
+	if step, ok := ctx.Value(queryStepKey{}).(time.Duration); ok {
+		return step
+	}
+	if b.config.QueryStep > 0 {
+		return b.config.QueryStep
+	}
+	if b.config.ResampleRule > 0 {
+		return min(maxResampleStep, b.config.ResampleRule)
+	}
+	return DefaultQueryStep
+}
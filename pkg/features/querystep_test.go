@@ -0,0 +1,128 @@
+package features
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredictiveFeatureConfig_QueryStepValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  PredictiveFeatureConfig
+		wantErr string
+	}{
+		{name: "default", config: PredictiveFeatureConfig{LookbackHours: 24}},
+		{name: "divides every window", config: PredictiveFeatureConfig{LookbackHours: 24, QueryStep: 30 * time.Second}},
+		{name: "negative", config: PredictiveFeatureConfig{LookbackHours: 24, QueryStep: -time.Minute}, wantErr: "must not be negative"},
+		{name: "below a second", config: PredictiveFeatureConfig{LookbackHours: 24, QueryStep: time.Millisecond}, wantErr: "at least 1s"},
+		{
+			name:    "does not divide a window",
+			config:  PredictiveFeatureConfig{LookbackHours: 24, RollingWindows: []int{3, 5}, QueryStep: 36 * time.Minute},
+			wantErr: "must divide the 5h rolling window evenly",
+		},
+		{name: "too many points", config: PredictiveFeatureConfig{LookbackHours: 24, QueryStep: 5 * time.Second}, wantErr: "too fine"},
+		{name: "divides the resample rule", config: PredictiveFeatureConfig{LookbackHours: 24, ResampleRule: time.Hour, QueryStep: 15 * time.Minute}},
+		{
+			name:    "does not divide the resample rule",
+			config:  PredictiveFeatureConfig{LookbackHours: 24, ResampleRule: 30 * time.Minute, QueryStep: 7 * time.Minute},
+			wantErr: "must divide the resample rule",
+		},
+		{
+			name:    "resample range too many points",
+			config:  PredictiveFeatureConfig{LookbackHours: 24, ResampleRule: time.Hour, QueryStep: 10 * time.Second},
+			wantErr: "too fine",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestBuildFeatures_QueryStep verifies the configured step reaches the range queries and that
+// a request's override replaces it
+func TestBuildFeatures_QueryStep(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	var mu sync.Mutex
+	steps := make(map[time.Duration]int)
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryAtFunc: func(_ context.Context, _ string, _ time.Time) (float64, error) {
+			return 0.5, nil
+		},
+		QueryRangeFunc: func(_ context.Context, _ string, _, end time.Time, step time.Duration) ([]DataPoint, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			steps[step]++
+			return []DataPoint{{Timestamp: end, Value: 0.5}}, nil
+		},
+	}
+	stepsSeen := func() map[time.Duration]int {
+		mu.Lock()
+		defer mu.Unlock()
+		seen := steps
+		steps = make(map[time.Duration]int)
+		return seen
+	}
+
+	tests := []struct {
+		name     string
+		config   PredictiveFeatureConfig
+		override time.Duration
+		want     time.Duration
+	}{
+		{name: "point default", config: PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, want: DefaultQueryStep},
+		{name: "point configured", config: PredictiveFeatureConfig{LookbackHours: 1, Enabled: true, QueryStep: time.Minute}, want: time.Minute},
+		{
+			name:     "point override",
+			config:   PredictiveFeatureConfig{LookbackHours: 1, Enabled: true, QueryStep: time.Minute},
+			override: 15 * time.Minute,
+			want:     15 * time.Minute,
+		},
+		{name: "resample default", config: PredictiveFeatureConfig{LookbackHours: 1, Enabled: true, ResampleRule: 2 * time.Minute}, want: 2 * time.Minute},
+		{
+			name:     "resample override",
+			config:   PredictiveFeatureConfig{LookbackHours: 1, Enabled: true, ResampleRule: time.Hour},
+			override: 30 * time.Second,
+			want:     30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder, err := NewPredictiveFeatureBuilder(provider, tt.config, log)
+			require.NoError(t, err)
+			require.NoError(t, builder.ValidateQueryStep(tt.override))
+
+			ctx := WithQueryStep(context.Background(), tt.override)
+			_, err = builder.BuildFeatures(ctx, "payments", "", "")
+			require.NoError(t, err)
+
+			seen := stepsSeen()
+			require.Len(t, seen, 1, "every range query uses one step: %v", seen)
+			assert.Contains(t, seen, tt.want)
+
+			plan, err := builder.PlanQueries(ctx, "payments", "", "")
+			require.NoError(t, err)
+			for _, entry := range plan {
+				if entry.Step != "" {
+					assert.Equal(t, tt.want.String(), entry.Step)
+				}
+			}
+		})
+	}
+}
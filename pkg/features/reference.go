@@ -0,0 +1,99 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ReferenceTolerance is the allowed difference between a built feature and its reference
+// value, applied relative to the reference magnitude once that exceeds 1
+const ReferenceTolerance = 1e-6
+
+// ErrReferenceLengthMismatch is returned when a reference vector does not have the builder's
+// feature count, which usually means a different lookback window or feature layout
+var ErrReferenceLengthMismatch = errors.New("reference vector length mismatch")
+
+// FeatureMismatch describes one feature whose value differs from the training reference
+type FeatureMismatch struct {
+	Index    int     `json:"index"`
+	Name     string  `json:"name"`
+	Expected float64 `json:"expected"`
+	Actual   float64 `json:"actual"`
+}
+
+// CompareToReference builds the current cluster-wide feature vector and diffs it
+// element-by-element against a vector recorded from the training pipeline.
+// Time features reflect the current time, so a reference captured at another time will
+// report hour/day mismatches; use CompareToReferenceWithTime to pin the window. The vector is
+// compared before scaling, since references are captured ahead of the StandardScaler.
+func (b *PredictiveFeatureBuilder) CompareToReference(ctx context.Context, referenceVector []float64) ([]FeatureMismatch, error) {
+	return b.CompareToReferenceWithTime(ctx, b.BuildTimeFeatureWindow(b.now()), "", "", "", referenceVector)
+}
+
+// CompareToReferenceWithTime is CompareToReference for a scope and a fixed time window
+func (b *PredictiveFeatureBuilder) CompareToReferenceWithTime(ctx context.Context, window *TimeFeatureWindow, namespace, deployment, pod string, referenceVector []float64) ([]FeatureMismatch, error) {
+	if len(referenceVector) != b.calculateTotalFeatures() {
+		return nil, fmt.Errorf("%w: got %d values, builder produces %d", ErrReferenceLengthMismatch, len(referenceVector), b.calculateTotalFeatures())
+	}
+
+	// References are recorded before the training pipeline's scaler, so compare raw values
+	vector, err := b.buildRawFeatures(ctx, window, namespace, deployment, pod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build features: %w", err)
+	}
+
+	return b.diffFeatures(vector.Features, referenceVector), nil
+}
+
+// diffFeatures returns the positions where actual and reference disagree beyond ReferenceTolerance
+func (b *PredictiveFeatureBuilder) diffFeatures(actual, reference []float64) []FeatureMismatch {
+	mismatches := make([]FeatureMismatch, 0)
+	for i := range reference {
+		if featureValuesMatch(actual[i], reference[i]) {
+			continue
+		}
+		mismatches = append(mismatches, FeatureMismatch{
+			Index:    i,
+			Name:     b.FeatureName(i),
+			Expected: reference[i],
+			Actual:   actual[i],
+		})
+	}
+	return mismatches
+}
+
+func featureValuesMatch(actual, expected float64) bool {
+	return math.Abs(actual-expected) <= ReferenceTolerance*math.Max(1, math.Abs(expected))
+}
+
+// FeatureName returns a readable name for a position in the flattened feature vector, e.g.
+// "t-3h:cpu_usage" (raw value), "t-3h:hour" (time feature) or
+// "t-3h:cpu_usage.rolling_mean_6h" (engineered feature). The hour offset counts back from
+// the window end. Returns "" for out-of-range indexes.
+func (b *PredictiveFeatureBuilder) FeatureName(index int) string {
+	if index < 0 || index >= b.calculateTotalFeatures() {
+		return ""
+	}
+
+	columnsPerTimestep := b.calculateTotalFeatures() / b.config.LookbackHours
+	hourOffset := index / columnsPerTimestep
+	column := index % columnsPerTimestep
+	metrics := b.config.baseMetrics()
+
+	if column < len(metrics) {
+		return fmt.Sprintf("t-%dh:%s", hourOffset, metrics[column])
+	}
+	column -= len(metrics)
+
+	timeNames := b.config.timeFeatureNames()
+	if column < len(timeNames) {
+		return fmt.Sprintf("t-%dh:%s", hourOffset, timeNames[column])
+	}
+	column -= len(timeNames)
+
+	perMetric := b.config.featuresPerMetric()
+	return fmt.Sprintf("t-%dh:%s.%s", hourOffset,
+		metrics[column/perMetric], b.metricFeatureNames()[column%perMetric])
+}
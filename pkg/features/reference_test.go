@@ -0,0 +1,81 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReferenceTestBuilder(t *testing.T) *PredictiveFeatureBuilder {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	builder, err := NewPredictiveFeatureBuilder(&MockMetricDataProvider{IsAvailableResult: true},
+		PredictiveFeatureConfig{Enabled: true, LookbackHours: 2}, log)
+	require.NoError(t, err)
+	return builder
+}
+
+func TestPredictiveFeatureBuilder_CompareToReference(t *testing.T) {
+	ctx := context.Background()
+	builder := newReferenceTestBuilder(t)
+	window := builder.BuildTimeFeatureWindow(time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC))
+
+	vector, err := builder.BuildFeaturesWithTime(ctx, window, "", "", "")
+	require.NoError(t, err)
+
+	t.Run("identical vector has no mismatches", func(t *testing.T) {
+		mismatches, err := builder.CompareToReferenceWithTime(ctx, window, "", "", "", vector.Features)
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("reports mismatched features by index and name", func(t *testing.T) {
+		reference := append([]float64(nil), vector.Features...)
+		reference[5] += 1   // t-0h:hour
+		reference[137] += 1 // t-1h:memory_usage
+
+		mismatches, err := builder.CompareToReferenceWithTime(ctx, window, "", "", "", reference)
+		require.NoError(t, err)
+		require.Len(t, mismatches, 2)
+
+		assert.Equal(t, 5, mismatches[0].Index)
+		assert.Equal(t, "t-0h:hour", mismatches[0].Name)
+		assert.Equal(t, reference[5], mismatches[0].Expected)
+		assert.Equal(t, vector.Features[5], mismatches[0].Actual)
+		assert.Equal(t, "t-1h:memory_usage", mismatches[1].Name)
+	})
+
+	t.Run("rejects reference of the wrong length", func(t *testing.T) {
+		_, err := builder.CompareToReference(ctx, vector.Features[:10])
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrReferenceLengthMismatch))
+	})
+
+	t.Run("provider unavailable", func(t *testing.T) {
+		builder.provider = &MockMetricDataProvider{IsAvailableResult: false}
+		_, err := builder.CompareToReference(ctx, vector.Features)
+		assert.Error(t, err)
+	})
+}
+
+func TestPredictiveFeatureBuilder_FeatureName(t *testing.T) {
+	builder := newReferenceTestBuilder(t)
+
+	assert.Equal(t, "t-0h:cpu_usage", builder.FeatureName(0))
+	assert.Equal(t, "t-0h:network_out", builder.FeatureName(4))
+	assert.Equal(t, "t-0h:is_business_hours", builder.FeatureName(10))
+	assert.Equal(t, "t-0h:cpu_usage.value", builder.FeatureName(11))
+	assert.Equal(t, "t-0h:cpu_usage.pct_change", builder.FeatureName(35))
+	assert.Equal(t, "t-0h:memory_usage.value", builder.FeatureName(36))
+	assert.Equal(t, "t-1h:network_out.pct_change", builder.FeatureName(builder.FeatureCount()-1))
+	assert.Empty(t, builder.FeatureName(-1))
+	assert.Empty(t, builder.FeatureName(builder.FeatureCount()))
+}
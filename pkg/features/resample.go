@@ -0,0 +1,216 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultResampleRule is the bucket width the training pipeline resamples metrics to
+const DefaultResampleRule = time.Hour
+
+// maxResampleStep is the widest default spacing of the samples averaged into each bucket
+const maxResampleStep = 5 * time.Minute
+
+// validateResampleRule checks that whole buckets fit the hour-based lags and rolling windows
+func validateResampleRule(rule time.Duration) error {
+	if rule < 0 {
+		return fmt.Errorf("resample rule must not be negative: %s", rule)
+	}
+	if rule > 0 && (rule < time.Minute || time.Hour%rule != 0) {
+		return fmt.Errorf("resample rule must be at least 1m and divide an hour evenly: %s", rule)
+	}
+	return nil
+}
+
+// resampledSeries is one metric's range data averaged into fixed-width buckets, like pandas'
+// resample(rule).mean(): bucket i covers [start+i*rule, start+(i+1)*rule) and is NaN when no
+// sample fell into it.
+type resampledSeries struct {
+	start  time.Time
+	rule   time.Duration
+	values []float64
+}
+
+// resample averages points into rule-wide buckets from the one containing start through the
+// one containing end. Buckets are aligned to multiples of rule, i.e. to the hour for hourly rules.
+func resample(points []DataPoint, start, end time.Time, rule time.Duration) *resampledSeries {
+	start = start.Truncate(rule)
+	n := int(end.Truncate(rule).Sub(start)/rule) + 1
+
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	for _, p := range points {
+		if p.Timestamp.Before(start) || p.Timestamp.After(end) || math.IsNaN(p.Value) {
+			continue
+		}
+		i := int(p.Timestamp.Sub(start) / rule)
+		sums[i] += p.Value
+		counts[i]++
+	}
+
+	values := make([]float64, n)
+	for i := range values {
+		if counts[i] == 0 {
+			values[i] = math.NaN()
+			continue
+		}
+		values[i] = sums[i] / float64(counts[i])
+	}
+	return &resampledSeries{start: start, rule: rule, values: values}
+}
+
+// index returns the bucket containing t, or -1 outside the series
+func (s *resampledSeries) index(t time.Time) int {
+	if t.Before(s.start) {
+		return -1
+	}
+	i := int(t.Sub(s.start) / s.rule)
+	if i >= len(s.values) {
+		return -1
+	}
+	return i
+}
+
+// shifted returns the bucket the given number of buckets before the one containing t, like
+// pandas' shift(buckets). It is NaN when that bucket is empty or outside the series.
+func (s *resampledSeries) shifted(t time.Time, buckets int) float64 {
+	i := s.index(t)
+	if i < 0 || i-buckets < 0 {
+		return math.NaN()
+	}
+	return s.values[i-buckets]
+}
+
+// valueAt returns the bucket containing t, or an error when it is empty
+func (s *resampledSeries) valueAt(t time.Time) (float64, error) {
+	value := s.shifted(t, 0)
+	if math.IsNaN(value) {
+		return 0, fmt.Errorf("no data in the %s bucket containing %s", s.rule, t.Format(time.RFC3339))
+	}
+	return value, nil
+}
+
+// rolling returns the non-empty values of the window of buckets ending with the one containing t
+func (s *resampledSeries) rolling(t time.Time, buckets int) []float64 {
+	end := s.index(t)
+	if end < 0 {
+		return nil
+	}
+	values := make([]float64, 0, buckets)
+	for i := max(end-buckets+1, 0); i <= end; i++ {
+		if !math.IsNaN(s.values[i]) {
+			values = append(values, s.values[i])
+		}
+	}
+	return values
+}
+
+// featureHistoryHours is how far before a timestep its lags and rolling windows reach
+func (c PredictiveFeatureConfig) featureHistoryHours() int {
+	hours := 0
+	for _, lag := range c.lagPeriods() {
+		hours = max(hours, lag)
+	}
+	for _, window := range c.rollingWindows() {
+		hours = max(hours, window)
+	}
+	return hours
+}
+
+// resampleRange returns the start and step of the range query fetching a metric's resampled
+// series for a lookback window ending at end
+func (b *PredictiveFeatureBuilder) resampleRange(ctx context.Context, end time.Time) (start time.Time, step time.Duration) {
+	rule := b.config.ResampleRule
+	history := time.Duration(b.config.LookbackHours-1+b.config.featureHistoryHours()) * time.Hour
+	return end.Add(-history).Truncate(rule), b.queryStep(ctx)
+}
+
+// queryResampledSeries fetches each query's metric with one range query covering every bucket
+// the lookback window's timesteps need, up to end, and resamples it to the configured rule. The
+// range queries are submitted together as one batch.
+func (b *PredictiveFeatureBuilder) queryResampledSeries(ctx context.Context, queries []metricQuery, end time.Time) ([]*resampledSeries, []error) {
+	start, step := b.resampleRange(ctx, end)
+	requests := make([]rangeRequest, len(queries))
+	for i, query := range queries {
+		requests[i] = rangeRequest{query: query, start: start, end: end, step: step}
+		if b.config.LogQueries {
+			requests[i].logFields = logrus.Fields{
+				"query_type": "resample_range",
+				"start":      start.Format(time.RFC3339),
+				"end":        end.Format(time.RFC3339),
+				"rule":       b.config.ResampleRule.String(),
+			}
+		}
+	}
+
+	points, errs := b.queryRangeBatch(ctx, requests)
+	series := make([]*resampledSeries, len(queries))
+	for i, query := range queries {
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("failed to query %s for resampling: %w", query.metric, errs[i])
+			continue
+		}
+		series[i] = resample(points[i], start, end, b.config.ResampleRule)
+	}
+	return series, errs
+}
+
+// resampledMetricFeatures builds the engineered features for a metric at timestamp from its
+// resampled series. As in training, lags are whole buckets back and rolling statistics cover
+// whole buckets ending with the current one; the standard deviation is the sample (ddof=1) one.
+func resampledMetricFeatures(series *resampledSeries, timestamp time.Time, config PredictiveFeatureConfig) ([]float64, error) {
+	currentValue, err := series.valueAt(timestamp)
+	if err != nil {
+		return nil, err
+	}
+	bucketsPerHour := int(time.Hour / series.rule)
+	lagPeriods := config.lagPeriods()
+
+	features := make([]float64, 0, config.featuresPerMetric())
+	features = append(features, currentValue)
+
+	lagValues := make([]float64, len(lagPeriods))
+	for i, lag := range lagPeriods {
+		lagValue := series.shifted(timestamp, lag*bucketsPerHour)
+		if math.IsNaN(lagValue) {
+			lagValue = currentValue
+		}
+		lagValues[i] = lagValue
+		features = append(features, lagValue)
+	}
+
+	for _, window := range config.rollingWindows() {
+		mean, std, maxVal, minVal := bucketStats(series.rolling(timestamp, window*bucketsPerHour))
+		features = append(features, mean, std, maxVal, minVal)
+	}
+
+	diff, pctChange := changeFeatures(currentValue, lagValues[0])
+	return append(features, diff, pctChange), nil
+}
+
+// bucketStats returns the mean, sample standard deviation, max and min of non-empty values,
+// matching pandas' rolling mean/std/max/min. The deviation of a single value is 0; values
+// must not be empty.
+func bucketStats(values []float64) (mean, std, maxVal, minVal float64) {
+	maxVal, minVal = values[0], values[0]
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+		maxVal = max(maxVal, v)
+		minVal = min(minVal, v)
+	}
+	mean = sum / float64(len(values))
+
+	if len(values) > 1 {
+		sumSquares := 0.0
+		for _, v := range values {
+			sumSquares += (v - mean) * (v - mean)
+		}
+		std = math.Sqrt(sumSquares / float64(len(values)-1))
+	}
+	return mean, std, maxVal, minVal
+}
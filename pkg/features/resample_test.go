@@ -0,0 +1,119 @@
+package features
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResample(t *testing.T) {
+	start := time.Date(2026, 3, 16, 10, 20, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 16, 13, 5, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time { return time.Date(2026, 3, 16, hour, minute, 0, 0, time.UTC) }
+
+	series := resample([]DataPoint{
+		{Timestamp: at(10, 20), Value: 0.2},
+		{Timestamp: at(10, 55), Value: 0.4},
+		{Timestamp: at(11, 0), Value: 0.6}, // buckets are closed on the left
+		{Timestamp: at(13, 0), Value: 0.8},
+		{Timestamp: at(13, 10), Value: 0.9}, // after end
+		{Timestamp: at(9, 59), Value: 0.9},  // before the first bucket
+		{Timestamp: at(13, 1), Value: math.NaN()},
+	}, start, end, time.Hour)
+
+	assert.Equal(t, at(10, 0), series.start, "buckets are aligned to the hour")
+	require.Len(t, series.values, 4)
+	assert.InDelta(t, 0.3, series.values[0], 1e-9)
+	assert.InDelta(t, 0.6, series.values[1], 1e-9)
+	assert.True(t, math.IsNaN(series.values[2]), "empty bucket")
+	assert.InDelta(t, 0.8, series.values[3], 1e-9)
+
+	value, err := series.valueAt(at(11, 30))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.6, value, 1e-9)
+	_, err = series.valueAt(at(12, 30))
+	assert.Error(t, err)
+	assert.True(t, math.IsNaN(series.shifted(at(13, 0), 4)), "shift past the first bucket")
+	assert.Equal(t, []float64{0.6, 0.8}, series.rolling(at(13, 0), 3), "rolling skips empty buckets")
+}
+
+// TestResampledMetricFeatures verifies lags and rolling statistics are taken over whole
+// buckets, as pandas' shift and rolling do on the resampled frame
+func TestResampledMetricFeatures(t *testing.T) {
+	start := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	series := &resampledSeries{start: start, rule: 30 * time.Minute, values: make([]float64, 60)}
+	for i := range series.values {
+		series.values[i] = float64(i)
+	}
+	timestamp := start.Add(29*time.Hour + 40*time.Minute) // bucket 59
+
+	features, err := resampledMetricFeatures(series, timestamp, DefaultPredictiveConfig())
+	require.NoError(t, err)
+	require.Len(t, features, FeaturesPerMetric)
+
+	assert.Equal(t, 59.0, features[0], "value")
+	assert.Equal(t, []float64{57, 55, 53, 47, 35, 11}, features[1:7], "lags are two 30m buckets per hour")
+	// Each window contributes mean, std, max and min; the 3h window covers buckets 54..59
+	assert.Equal(t, 56.5, features[7], "3h mean")
+	assert.InDelta(t, math.Sqrt(3.5), features[8], 1e-9, "3h std uses ddof=1")
+	assert.Equal(t, 59.0, features[9], "3h max")
+	assert.Equal(t, 54.0, features[10], "3h min")
+	assert.Equal(t, 12.0, features[22], "24h min: 48 buckets ending at 59")
+	assert.Equal(t, 2.0, features[23], "diff")
+	assert.InDelta(t, 2.0/57, features[24], 1e-9, "pct_change")
+
+	series.values[59] = math.NaN()
+	_, err = resampledMetricFeatures(series, timestamp, DefaultPredictiveConfig())
+	assert.Error(t, err)
+}
+
+// TestBuildFeatures_Resampled verifies each metric is fetched once for the whole window and
+// every timestep is computed from its resampled series
+func TestBuildFeatures_Resampled(t *testing.T) {
+	asOf := time.Date(2026, 3, 16, 14, 30, 0, 0, time.UTC)
+	var queries []time.Time
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			queries = append(queries, start, end)
+			if strings.Contains(query, "node_filesystem") {
+				return nil, nil
+			}
+			var points []DataPoint
+			for ts := start; !ts.After(end); ts = ts.Add(step) {
+				points = append(points, DataPoint{Timestamp: ts, Value: float64(ts.Hour()) / 100})
+			}
+			return points, nil
+		},
+	}
+	config := PredictiveFeatureConfig{LookbackHours: 2, Enabled: true, ResampleRule: time.Hour}
+	builder, err := NewPredictiveFeatureBuilder(provider, config, logrus.New())
+	require.NoError(t, err)
+	assert.Equal(t, "1h0m0s", builder.GetFeatureInfo().ResampleRule)
+
+	featureVector, err := builder.BuildFeaturesAsOf(context.Background(), asOf, "payments", "", "")
+	require.NoError(t, err)
+	require.Len(t, queries, 2*len(predictiveBaseMetrics), "one range query per metric")
+	assert.Equal(t, time.Date(2026, 3, 15, 13, 0, 0, 0, time.UTC), queries[0], "24h of history before the oldest timestep")
+	assert.Equal(t, asOf, queries[1])
+
+	assert.Equal(t, 0.14, featureVector.MetricsData["cpu_usage"])
+	assert.Equal(t, []string{"disk_usage"}, featureVector.DefaultedMetrics)
+
+	columns := len(predictiveBaseMetrics) + TimeFeatureCount + FeaturesPerMetric*len(predictiveBaseMetrics)
+	require.Len(t, featureVector.Features, 2*columns)
+	cpuFeatures := featureVector.Features[columns+len(predictiveBaseMetrics)+TimeFeatureCount:][:FeaturesPerMetric]
+	assert.InDelta(t, 0.13, cpuFeatures[0], 1e-9, "second timestep is the 13:00 bucket")
+	assert.InDelta(t, 0.12, cpuFeatures[1], 1e-9, "lag_1h is the 12:00 bucket")
+
+	assert.Error(t, PredictiveFeatureConfig{LookbackHours: 1, ResampleRule: 7 * time.Minute}.Validate())
+	assert.Error(t, PredictiveFeatureConfig{LookbackHours: 1, ResampleRule: 30 * time.Second}.Validate())
+	assert.Error(t, PredictiveFeatureConfig{LookbackHours: 1, ResampleRule: -time.Hour}.Validate())
+	assert.NoError(t, PredictiveFeatureConfig{LookbackHours: 1, ResampleRule: 15 * time.Minute}.Validate())
+}
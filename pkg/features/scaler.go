@@ -0,0 +1,75 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FeatureScaler standardizes a feature vector the way the training pipeline's StandardScaler
+// does: each feature becomes (x - mean) / scale. The parameters are the fitted scaler's
+// mean_ and scale_ arrays, exported from the training notebook, e.g.
+//
+//	json.dump({"mean": scaler.mean_.tolist(), "scale": scaler.scale_.tolist()}, f)
+type FeatureScaler struct {
+	Mean  []float64 `json:"mean"`
+	Scale []float64 `json:"scale"`
+}
+
+// LoadFeatureScaler reads and validates scaler parameters from a JSON file
+func LoadFeatureScaler(path string) (*FeatureScaler, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature scaler file: %w", err)
+	}
+
+	var scaler FeatureScaler
+	if err := json.Unmarshal(data, &scaler); err != nil {
+		return nil, fmt.Errorf("failed to parse feature scaler file %s: %w", path, err)
+	}
+	if err := scaler.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid feature scaler file %s: %w", path, err)
+	}
+	return &scaler, nil
+}
+
+// Validate checks that mean and scale describe the same features and are usable.
+// StandardScaler stores a scale of 1 for constant features, so a zero scale is rejected.
+func (s *FeatureScaler) Validate() error {
+	if len(s.Mean) == 0 {
+		return fmt.Errorf("scaler has no features")
+	}
+	if len(s.Mean) != len(s.Scale) {
+		return fmt.Errorf("scaler has %d means but %d scales", len(s.Mean), len(s.Scale))
+	}
+	for i := range s.Mean {
+		if !isFinite(s.Mean[i]) {
+			return fmt.Errorf("scaler mean %d is not finite", i)
+		}
+		if !isFinite(s.Scale[i]) || s.Scale[i] == 0 {
+			return fmt.Errorf("scaler scale %d must be finite and non-zero: %v", i, s.Scale[i])
+		}
+	}
+	return nil
+}
+
+// FeatureCount returns the number of features the scaler was fitted on
+func (s *FeatureScaler) FeatureCount() int {
+	return len(s.Mean)
+}
+
+// Transform standardizes features in place. The vector must have the scaler's feature count.
+func (s *FeatureScaler) Transform(features []float64) error {
+	if len(features) != len(s.Mean) {
+		return fmt.Errorf("feature vector has %d features, scaler expects %d", len(features), len(s.Mean))
+	}
+	s.transformAt(features, 0)
+	return nil
+}
+
+// transformAt standardizes values in place as the features starting at offset
+func (s *FeatureScaler) transformAt(values []float64, offset int) {
+	for i := range values {
+		values[i] = (values[i] - s.Mean[offset+i]) / s.Scale[offset+i]
+	}
+}
@@ -0,0 +1,123 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScalerFile writes scaler parameters for count features, with mean i and scale 2
+func writeScalerFile(t *testing.T, count int) string {
+	t.Helper()
+	scaler := FeatureScaler{Mean: make([]float64, count), Scale: make([]float64, count)}
+	for i := range count {
+		scaler.Mean[i] = float64(i)
+		scaler.Scale[i] = 2
+	}
+	data, err := json.Marshal(scaler)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "scaler.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestFeatureScaler_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scaler  FeatureScaler
+		wantErr bool
+	}{
+		{name: "valid", scaler: FeatureScaler{Mean: []float64{1, 2}, Scale: []float64{0.5, 1}}},
+		{name: "empty", scaler: FeatureScaler{}, wantErr: true},
+		{name: "length mismatch", scaler: FeatureScaler{Mean: []float64{1, 2}, Scale: []float64{1}}, wantErr: true},
+		{name: "zero scale", scaler: FeatureScaler{Mean: []float64{1}, Scale: []float64{0}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.scaler.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeatureScaler_Transform(t *testing.T) {
+	scaler := FeatureScaler{Mean: []float64{1, 10}, Scale: []float64{2, 5}}
+	values := []float64{3, 0}
+	require.NoError(t, scaler.Transform(values))
+	assert.Equal(t, []float64{1, -2}, values)
+
+	assert.Error(t, scaler.Transform([]float64{1}))
+}
+
+func TestPredictiveFeatureBuilder_Scaler(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+	config := PredictiveFeatureConfig{Enabled: true, LookbackHours: 2}
+
+	raw, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	config.ScalerFile = writeScalerFile(t, raw.FeatureCount())
+	scaled, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+	assert.True(t, scaled.GetFeatureInfo().Scaled)
+	assert.False(t, raw.GetFeatureInfo().Scaled)
+
+	ctx := context.Background()
+	window := raw.BuildTimeFeatureWindow(time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC))
+	rawVector, err := raw.BuildFeaturesWithTime(ctx, window, "", "", "")
+	require.NoError(t, err)
+	scaledVector, err := scaled.BuildFeaturesWithTime(ctx, window, "", "", "")
+	require.NoError(t, err)
+
+	assert.True(t, scaledVector.Scaled)
+	assert.Equal(t, rawVector.MetricsData, scaledVector.MetricsData, "metrics data stays raw")
+	for i := range rawVector.Features {
+		assert.InDelta(t, (rawVector.Features[i]-float64(i))/2, scaledVector.Features[i], 1e-9, "feature %d", i)
+	}
+
+	t.Run("re-timed columns are scaled", func(t *testing.T) {
+		target := raw.BuildTimeFeatureWindow(time.Date(2026, 1, 10, 22, 0, 0, 0, time.UTC))
+		rawRetimed, err := raw.WithTimeFeatures(rawVector.Features, target)
+		require.NoError(t, err)
+		scaledRetimed, err := scaled.WithTimeFeatures(scaledVector.Features, target)
+		require.NoError(t, err)
+		for i := range rawRetimed {
+			assert.InDelta(t, (rawRetimed[i]-float64(i))/2, scaledRetimed[i], 1e-9, "feature %d", i)
+		}
+	})
+
+	t.Run("reference comparison uses raw values", func(t *testing.T) {
+		mismatches, err := scaled.CompareToReferenceWithTime(ctx, window, "", "", "", rawVector.Features)
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("default features are scaled", func(t *testing.T) {
+		assert.True(t, scaled.GetDefaultFeatures().Scaled)
+	})
+
+	t.Run("scaler for another feature count is rejected", func(t *testing.T) {
+		config.ScalerFile = writeScalerFile(t, raw.FeatureCount()-1)
+		_, err := NewPredictiveFeatureBuilder(provider, config, log)
+		assert.ErrorContains(t, err, "scaler expects")
+	})
+
+	t.Run("missing scaler file is rejected", func(t *testing.T) {
+		config.ScalerFile = filepath.Join(t.TempDir(), "missing.json")
+		_, err := NewPredictiveFeatureBuilder(provider, config, log)
+		assert.Error(t, err)
+	})
+}
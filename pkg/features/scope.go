@@ -0,0 +1,35 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ErrInvalidScopeIdentifier is returned when a namespace, deployment or pod name is not a
+// valid Kubernetes object name and therefore cannot be placed into a PromQL selector
+var ErrInvalidScopeIdentifier = errors.New("invalid scope identifier")
+
+// ValidateScopeIdentifiers checks scope filters against Kubernetes naming rules: namespaces
+// must be DNS-1123 labels, deployments and pods DNS-1123 subdomains. Empty values mean
+// "no filter" and are accepted.
+func ValidateScopeIdentifiers(namespace, deployment, pod string) error {
+	if namespace != "" {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("%w: namespace %q: %s", ErrInvalidScopeIdentifier, namespace, strings.Join(errs, "; "))
+		}
+	}
+	if deployment != "" {
+		if errs := validation.IsDNS1123Subdomain(deployment); len(errs) > 0 {
+			return fmt.Errorf("%w: deployment %q: %s", ErrInvalidScopeIdentifier, deployment, strings.Join(errs, "; "))
+		}
+	}
+	if pod != "" {
+		if errs := validation.IsDNS1123Subdomain(pod); len(errs) > 0 {
+			return fmt.Errorf("%w: pod %q: %s", ErrInvalidScopeIdentifier, pod, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateScopeIdentifiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespace  string
+		deployment string
+		pod        string
+		wantErr    bool
+	}{
+		{name: "no filters"},
+		{name: "valid names", namespace: "team-a", deployment: "api.v2", pod: "api-7d9f8b-xk2lp"},
+		{name: "quote in namespace", namespace: `prod",namespace=~".*`, wantErr: true},
+		{name: "regex in deployment", deployment: "api|billing", wantErr: true},
+		{name: "uppercase pod", pod: "My-Pod", wantErr: true},
+		{name: "namespace with dot", namespace: "team.a", wantErr: true},
+		{name: "namespace too long", namespace: "a234567890123456789012345678901234567890123456789012345678901234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScopeIdentifiers(tt.namespace, tt.deployment, tt.pod)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidScopeIdentifier))
+		})
+	}
+}
+
+func TestBuildFeatures_RejectsInvalidScope(t *testing.T) {
+	provider := &MockMetricDataProvider{IsAvailableResult: true}
+	builder, err := NewPredictiveFeatureBuilder(provider, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	_, err = builder.BuildFeatures(context.Background(), `prod"}`, "", "")
+	assert.True(t, errors.Is(err, ErrInvalidScopeIdentifier))
+}
@@ -0,0 +1,91 @@
+package features
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FeatureVectorStats summarizes the metric columns of a built vector, so shifts in the live
+// feature distribution (e.g. a metric changing scale after an exporter upgrade) can be watched
+// over time. Time features depend only on the clock and are left out.
+type FeatureVectorStats struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+
+	// DefaultedFraction is the share of metric columns filled entirely with defaults because
+	// their queries failed. Lags that fell back to the current value are not counted.
+	DefaultedFraction float64 `json:"defaulted_fraction"`
+
+	// Metrics holds the spread of each base metric's raw value over the lookback window
+	Metrics map[string]MetricValueStats `json:"metrics"`
+}
+
+// MetricValueStats is the min, max and mean of one base metric's raw values
+type MetricValueStats struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+}
+
+// vectorStats computes the statistics of raw, an unscaled vector laid out by this builder, in
+// which defaulted metric columns were filled with defaults
+func (b *PredictiveFeatureBuilder) vectorStats(raw []float64, defaulted int) *FeatureVectorStats {
+	breakdown := b.featureCountBreakdown()
+	metricColumns := breakdown.RawMetricFeatures + breakdown.EngineeredMetricFeatures
+	if len(raw) == 0 || metricColumns == 0 {
+		return &FeatureVectorStats{Metrics: map[string]MetricValueStats{}}
+	}
+
+	metrics := b.config.baseMetrics()
+	stats := &FeatureVectorStats{Min: raw[0], Max: raw[0], Metrics: make(map[string]MetricValueStats, len(metrics))}
+	perMetric := make([]MetricValueStats, len(metrics))
+	sum := 0.0
+	count := 0
+	for step := range breakdown.LookbackHours {
+		columns := raw[step*breakdown.ColumnsPerTimestep : (step+1)*breakdown.ColumnsPerTimestep]
+		for i, value := range columns {
+			if i >= breakdown.RawMetricFeatures && i < breakdown.RawMetricFeatures+breakdown.TimeFeatures {
+				continue
+			}
+			stats.Min = min(stats.Min, value)
+			stats.Max = max(stats.Max, value)
+			sum += value
+			count++
+		}
+		for i, value := range columns[:breakdown.RawMetricFeatures] {
+			if step == 0 {
+				perMetric[i] = MetricValueStats{Min: value, Max: value}
+			}
+			perMetric[i].Min = min(perMetric[i].Min, value)
+			perMetric[i].Max = max(perMetric[i].Max, value)
+			perMetric[i].Mean += value / float64(breakdown.LookbackHours)
+		}
+	}
+	stats.Mean = sum / float64(count)
+	stats.DefaultedFraction = float64(defaulted) / float64(count)
+	for i, metric := range metrics {
+		stats.Metrics[metric] = perMetric[i]
+	}
+	return stats
+}
+
+// logVectorStats logs stats at info level for the scope's build
+func (b *PredictiveFeatureBuilder) logVectorStats(ctx context.Context, stats *FeatureVectorStats, namespace, deployment, pod string) {
+	fields := logrus.Fields{
+		"namespace":          namespace,
+		"deployment":         deployment,
+		"pod":                pod,
+		"feature_min":        stats.Min,
+		"feature_max":        stats.Max,
+		"feature_mean":       stats.Mean,
+		"defaulted_fraction": stats.DefaultedFraction,
+	}
+	for metric, metricStats := range stats.Metrics {
+		fields[metric+"_min"] = metricStats.Min
+		fields[metric+"_max"] = metricStats.Max
+		fields[metric+"_mean"] = metricStats.Mean
+	}
+	b.log.WithContext(ctx).WithFields(fields).Info("Feature vector statistics")
+}
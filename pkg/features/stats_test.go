@@ -0,0 +1,91 @@
+package features
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFeatures_LogVectorStats(t *testing.T) {
+	var logBuf bytes.Buffer
+	log := logrus.New()
+	log.SetLevel(logrus.InfoLevel)
+	log.SetOutput(&logBuf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+
+	provider := &MockMetricDataProvider{
+		IsAvailableResult: true,
+		QueryRangeFunc: func(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]DataPoint, error) {
+			if strings.Contains(query, "node_filesystem") {
+				return nil, nil // disk_usage has no data at all and is defaulted
+			}
+			return []DataPoint{{Timestamp: start, Value: 0.3}, {Timestamp: end, Value: 0.3}}, nil
+		},
+		QueryFunc: func(ctx context.Context, query string) (float64, error) {
+			return 0, fmt.Errorf("no data")
+		},
+	}
+
+	config := PredictiveFeatureConfig{LookbackHours: 2, Enabled: true}
+	builder, err := NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	vector, err := builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, vector.Stats, "stats are not computed by default")
+	assert.NotContains(t, logBuf.String(), "Feature vector statistics")
+
+	config.LogVectorStats = true
+	builder, err = NewPredictiveFeatureBuilder(provider, config, log)
+	require.NoError(t, err)
+
+	logBuf.Reset()
+	vector, err = builder.BuildFeatures(context.Background(), "payments", "", "")
+	require.NoError(t, err)
+	require.NotNil(t, vector.Stats)
+
+	// disk_usage's raw value and its 25 engineered features are defaulted at both timesteps,
+	// out of 5 + 125 metric columns per timestep
+	stats := vector.Stats
+	assert.InDelta(t, 26.0/130.0, stats.DefaultedFraction, 1e-9)
+	assert.Equal(t, MetricValueStats{Min: 0.3, Max: 0.3, Mean: 0.3}, stats.Metrics["cpu_usage"])
+	assert.Equal(t, MetricValueStats{Min: 0.5, Max: 0.5, Mean: 0.5}, stats.Metrics["disk_usage"])
+	assert.Equal(t, 0.0, stats.Min, "diff and pct_change are zero")
+	assert.Equal(t, 0.6, stats.Max, "default rolling max")
+	assert.Less(t, stats.Max, 1.0, "time features such as hour are left out")
+
+	var entries int
+	for _, line := range strings.Split(strings.TrimSpace(logBuf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["msg"] != "Feature vector statistics" {
+			continue
+		}
+		entries++
+		assert.Equal(t, "payments", entry["namespace"])
+		assert.InDelta(t, stats.Mean, entry["feature_mean"], 1e-9)
+		assert.InDelta(t, stats.DefaultedFraction, entry["defaulted_fraction"], 1e-9)
+		assert.Equal(t, 0.3, entry["cpu_usage_mean"])
+	}
+	assert.Equal(t, 1, entries, "one entry per build")
+}
+
+func TestVectorStats_DefaultFeatures(t *testing.T) {
+	builder, err := NewPredictiveFeatureBuilder(nil, PredictiveFeatureConfig{LookbackHours: 1, Enabled: true}, logrus.New())
+	require.NoError(t, err)
+
+	features := builder.GetDefaultFeatures().Features
+	stats := builder.vectorStats(features, 0)
+	assert.Zero(t, stats.DefaultedFraction)
+	for _, metric := range predictiveBaseMetrics {
+		assert.Equal(t, MetricValueStats{Min: 0.5, Max: 0.5, Mean: 0.5}, stats.Metrics[metric])
+	}
+}
@@ -19,15 +19,37 @@ import (
 
 // ProxyClient is a client for proxying requests to KServe InferenceServices.
 // It supports dynamic model discovery from environment variables.
+// ProxyClient is safe for concurrent use; the model registry is guarded by modelsMutex.
 type ProxyClient struct {
 	namespace     string
 	predictorPort int
 	models        map[string]*ModelInfo
 	httpClient    *http.Client
+	timeout       time.Duration
 	log           *logrus.Logger
 	modelsMutex   sync.RWMutex
+
+	// regressionModels are models whose responses are positional regression vectors
+	regressionModels map[string]bool
+}
+
+// ModelClient is the inference API used by the prediction and recommendation handlers.
+// ProxyClient implements it against KServe InferenceServices; tests and alternate inference
+// backends can provide their own implementation. Implementations should honor
+// WithRequestTimeout overrides on the context where they support timeouts.
+type ModelClient interface {
+	// GetModel returns the registered model with the given name
+	GetModel(name string) (*ModelInfo, bool)
+
+	// Predict calls an anomaly-detection style model
+	Predict(ctx context.Context, modelName string, instances [][]float64) (*DetectResponse, error)
+
+	// PredictFlexible calls a model and parses whichever response format it returns
+	PredictFlexible(ctx context.Context, modelName string, instances [][]float64) (*ModelResponse, error)
 }
 
+var _ ModelClient = (*ProxyClient)(nil)
+
 // ModelInfo contains information about a registered KServe model
 type ModelInfo struct {
 	// Name is the user-friendly model name (e.g., "anomaly-detector")
@@ -59,11 +81,43 @@ type ProxyConfig struct {
 
 	// Timeout for HTTP requests to KServe services
 	Timeout time.Duration
+
+	// RegressionModels lists models that return a plain regression vector
+	// (e.g. {"predictions": [[cpu_pct, mem_pct]]}). Their responses are parsed
+	// as "regression" instead of being auto-detected as forecasts.
+	RegressionModels []string
 }
 
 // DefaultPredictorPort is the default port for KServe predictors in RawDeployment mode
 const DefaultPredictorPort = 8080
 
+// MaxRequestTimeout caps per-request timeout overrides set with WithRequestTimeout
+const MaxRequestTimeout = 2 * time.Minute
+
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a context whose KServe calls use timeout instead of the client's
+// configured Timeout, e.g. for a model that is slower than the rest. The timeout is capped at
+// MaxRequestTimeout; a non-positive timeout leaves ctx unchanged.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestTimeoutKey{}, min(timeout, MaxRequestTimeout))
+}
+
+// requestContext bounds a KServe call by the context's timeout override, or the client timeout
+func (c *ProxyClient) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.timeout
+	if override, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // DetectRequest represents a request to call a KServe model for predictions
 type DetectRequest struct {
 	// Model is the name of the model to call (e.g., "anomaly-detector")
@@ -79,6 +133,10 @@ type DetectResponse struct {
 	// Predictions contains the model predictions (for anomaly-detector: []int)
 	Predictions []int `json:"predictions"`
 
+	// Scores optionally carries the model's decision_function value per instance.
+	// IsolationForest-style scores are negative for anomalies; the magnitude reflects certainty.
+	Scores []float64 `json:"scores,omitempty"`
+
 	// ModelName is the name of the model that made the prediction
 	ModelName string `json:"model_name"`
 
@@ -100,7 +158,8 @@ type ForecastResult struct {
 
 // ForecastResponse represents the response from the predictive-analytics KServe model
 type ForecastResponse struct {
-	// Predictions contains forecasts per metric (cpu_usage, memory_usage)
+	// Predictions contains forecasts per metric (cpu_usage, memory_usage, and optionally
+	// disk_usage, network_in, network_out)
 	Predictions map[string]ForecastResult `json:"predictions"`
 
 	// ModelName is the name of the model that made the prediction
@@ -116,9 +175,21 @@ type ForecastResponse struct {
 	LookbackWindow int `json:"lookback_window,omitempty"`
 }
 
-// ModelResponse is a flexible response type that can hold either DetectResponse or ForecastResponse
+// RegressionResponse represents positional outputs from a regression model
+type RegressionResponse struct {
+	// Outputs contains the regression outputs for the first instance, in model output order
+	Outputs []float64 `json:"outputs"`
+
+	// ModelName is the name of the model that made the prediction
+	ModelName string `json:"model_name"`
+
+	// ModelVersion is the version of the model
+	ModelVersion string `json:"model_version,omitempty"`
+}
+
+// ModelResponse is a flexible response type that can hold a DetectResponse, ForecastResponse or RegressionResponse
 type ModelResponse struct {
-	// Type indicates the response type: "anomaly" or "forecast"
+	// Type indicates the response type: "anomaly", "forecast" or "regression"
 	Type string
 
 	// AnomalyResponse holds anomaly detection results (for anomaly-detector model)
@@ -126,6 +197,9 @@ type ModelResponse struct {
 
 	// ForecastResponse holds forecast results (for predictive-analytics model)
 	ForecastResponse *ForecastResponse
+
+	// RegressionResponse holds positional regression outputs (for models listed in ProxyConfig.RegressionModels)
+	RegressionResponse *RegressionResponse
 }
 
 // ModelHealthResponse represents the health status of a KServe model
@@ -170,15 +244,22 @@ func NewProxyClient(cfg ProxyConfig, log *logrus.Logger) (*ProxyClient, error) {
 		DisableKeepAlives:   false,
 	}
 
+	regressionModels := make(map[string]bool, len(cfg.RegressionModels))
+	for _, name := range cfg.RegressionModels {
+		regressionModels[name] = true
+	}
+
 	client := &ProxyClient{
 		namespace:     cfg.Namespace,
 		predictorPort: predictorPort,
 		models:        make(map[string]*ModelInfo),
+		// Timeouts are applied per request by requestContext so they can be overridden per model
 		httpClient: &http.Client{
 			Transport: transport,
-			Timeout:   timeout,
 		},
-		log: log,
+		timeout:          timeout,
+		log:              log,
+		regressionModels: regressionModels,
 	}
 
 	// Load models from environment variables
@@ -325,6 +406,9 @@ func (c *ProxyClient) Predict(ctx context.Context, modelName string, instances [
 	// Use the KServeModelName which is read from KSERVE_*_MODEL env var or defaults to logical model name
 	endpoint := fmt.Sprintf("%s/v1/models/%s:predict", model.URL, model.KServeModelName)
 
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	// Create HTTP request
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -340,7 +424,7 @@ func (c *ProxyClient) Predict(ctx context.Context, modelName string, instances [
 	duration := time.Since(startTime)
 
 	if err != nil {
-		c.log.WithFields(logrus.Fields{
+		c.log.WithContext(ctx).WithFields(logrus.Fields{
 			"model":    modelName,
 			"endpoint": endpoint,
 			"duration": duration.Milliseconds(),
@@ -349,12 +433,12 @@ func (c *ProxyClient) Predict(ctx context.Context, modelName string, instances [
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
-			c.log.WithError(closeErr).Warn("Failed to close response body")
+			c.log.WithContext(ctx).WithError(closeErr).Warn("Failed to close response body")
 		}
 	}()
 
 	// Log request
-	c.log.WithFields(logrus.Fields{
+	c.log.WithContext(ctx).WithFields(logrus.Fields{
 		"model":    modelName,
 		"endpoint": endpoint,
 		"status":   resp.StatusCode,
@@ -390,9 +474,10 @@ func (c *ProxyClient) Predict(ctx context.Context, modelName string, instances [
 
 	// Decode response - KServe v1 response format
 	var kserveResp struct {
-		Predictions  []int  `json:"predictions"`
-		ModelName    string `json:"model_name,omitempty"`
-		ModelVersion string `json:"model_version,omitempty"`
+		Predictions  []int     `json:"predictions"`
+		Scores       []float64 `json:"scores,omitempty"`
+		ModelName    string    `json:"model_name,omitempty"`
+		ModelVersion string    `json:"model_version,omitempty"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&kserveResp); err != nil {
@@ -401,6 +486,7 @@ func (c *ProxyClient) Predict(ctx context.Context, modelName string, instances [
 
 	return &DetectResponse{
 		Predictions:  kserveResp.Predictions,
+		Scores:       kserveResp.Scores,
 		ModelName:    modelName,
 		ModelVersion: kserveResp.ModelVersion,
 	}, nil
@@ -429,6 +515,9 @@ func (c *ProxyClient) PredictFlexible(ctx context.Context, modelName string, ins
 	// Use the KServeModelName which is read from KSERVE_*_MODEL env var or defaults to logical model name
 	endpoint := fmt.Sprintf("%s/v1/models/%s:predict", model.URL, model.KServeModelName)
 
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	// Create HTTP request
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
@@ -444,7 +533,7 @@ func (c *ProxyClient) PredictFlexible(ctx context.Context, modelName string, ins
 	duration := time.Since(startTime)
 
 	if err != nil {
-		c.log.WithFields(logrus.Fields{
+		c.log.WithContext(ctx).WithFields(logrus.Fields{
 			"model":    modelName,
 			"endpoint": endpoint,
 			"duration": duration.Milliseconds(),
@@ -453,12 +542,12 @@ func (c *ProxyClient) PredictFlexible(ctx context.Context, modelName string, ins
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
-			c.log.WithError(closeErr).Warn("Failed to close response body")
+			c.log.WithContext(ctx).WithError(closeErr).Warn("Failed to close response body")
 		}
 	}()
 
 	// Log request
-	c.log.WithFields(logrus.Fields{
+	c.log.WithContext(ctx).WithFields(logrus.Fields{
 		"model":    modelName,
 		"endpoint": endpoint,
 		"status":   resp.StatusCode,
@@ -504,6 +593,10 @@ func (c *ProxyClient) PredictFlexible(ctx context.Context, modelName string, ins
 
 // parseModelResponse parses the response body based on the model type
 func (c *ProxyClient) parseModelResponse(modelName string, body []byte) (*ModelResponse, error) {
+	if c.regressionModels[modelName] {
+		return c.parseRegressionResponse(modelName, body)
+	}
+
 	switch modelName {
 	case "predictive-analytics":
 		return c.parseForecastResponse(modelName, body)
@@ -665,9 +758,10 @@ func (c *ProxyClient) parseForecastResponse(modelName string, body []byte) (*Mod
 // parseAnomalyResponse parses an anomaly-detector model response
 func (c *ProxyClient) parseAnomalyResponse(modelName string, body []byte) (*ModelResponse, error) {
 	var anomalyResp struct {
-		Predictions  []int  `json:"predictions"`
-		ModelName    string `json:"model_name,omitempty"`
-		ModelVersion string `json:"model_version,omitempty"`
+		Predictions  []int     `json:"predictions"`
+		Scores       []float64 `json:"scores,omitempty"`
+		ModelName    string    `json:"model_name,omitempty"`
+		ModelVersion string    `json:"model_version,omitempty"`
 	}
 
 	if err := json.Unmarshal(body, &anomalyResp); err != nil {
@@ -678,12 +772,57 @@ func (c *ProxyClient) parseAnomalyResponse(modelName string, body []byte) (*Mode
 		Type: "anomaly",
 		AnomalyResponse: &DetectResponse{
 			Predictions:  anomalyResp.Predictions,
+			Scores:       anomalyResp.Scores,
 			ModelName:    modelName,
 			ModelVersion: anomalyResp.ModelVersion,
 		},
 	}, nil
 }
 
+// parseRegressionResponse parses a regression model response.
+// Accepts both a single output vector and one vector per instance; only the first instance is used:
+//
+//	{"predictions": [cpu_value, memory_value]}
+//	{"predictions": [[cpu_value, memory_value], ...]}
+func (c *ProxyClient) parseRegressionResponse(modelName string, body []byte) (*ModelResponse, error) {
+	var rawResp struct {
+		Predictions  json.RawMessage `json:"predictions"`
+		ModelVersion string          `json:"model_version,omitempty"`
+	}
+	if err := json.Unmarshal(body, &rawResp); err != nil {
+		return nil, fmt.Errorf("failed to decode regression response from model %s: %w", modelName, err)
+	}
+
+	var outputs []float64
+	var rows [][]float64
+	switch {
+	case json.Unmarshal(rawResp.Predictions, &rows) == nil && len(rows) > 0:
+		outputs = rows[0]
+	case json.Unmarshal(rawResp.Predictions, &outputs) == nil:
+	default:
+		return nil, fmt.Errorf("unsupported regression predictions format from model %s", modelName)
+	}
+
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("empty regression predictions from model %s", modelName)
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"model":       modelName,
+		"format":      "regression",
+		"num_outputs": len(outputs),
+	}).Debug("Parsed regression response")
+
+	return &ModelResponse{
+		Type: "regression",
+		RegressionResponse: &RegressionResponse{
+			Outputs:      outputs,
+			ModelName:    modelName,
+			ModelVersion: rawResp.ModelVersion,
+		},
+	}, nil
+}
+
 // isLikelyAnomalyArray checks if an array of values looks like anomaly detection output.
 // Anomaly detectors typically return integer values in the range [-1, 0, 1]:
 // -1 = outlier/anomaly, 1 = inlier/normal, 0 = sometimes used as normal
@@ -804,6 +943,9 @@ func (c *ProxyClient) CheckModelHealth(ctx context.Context, modelName string) (*
 	// Use the KServeModelName which is read from KSERVE_*_MODEL env var or defaults to logical model name
 	endpoint := fmt.Sprintf("%s/v1/models/%s", model.URL, model.KServeModelName)
 
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", endpoint, http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create health check request: %w", err)
@@ -870,6 +1012,18 @@ func (c *ProxyClient) Close() {
 	c.httpClient.CloseIdleConnections()
 }
 
+// RegisterModel registers or replaces a model that was not discovered from the environment.
+// Safe to call concurrently with predictions.
+func (c *ProxyClient) RegisterModel(model *ModelInfo) {
+	c.modelsMutex.Lock()
+	defer c.modelsMutex.Unlock()
+
+	if model.KServeModelName == "" {
+		model.KServeModelName = model.Name
+	}
+	c.models[model.Name] = model
+}
+
 // RefreshModels reloads models from environment variables
 func (c *ProxyClient) RefreshModels() {
 	c.modelsMutex.Lock()
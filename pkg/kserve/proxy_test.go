@@ -263,6 +263,27 @@ func TestProxyClient_GetModel(t *testing.T) {
 	assert.False(t, exists)
 }
 
+func TestProxyClient_RegisterModel(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client, err := NewProxyClient(ProxyConfig{Namespace: "test-ns"}, log)
+	require.NoError(t, err)
+
+	client.RegisterModel(&ModelInfo{Name: "custom-model", URL: "http://custom:8080"})
+
+	model, exists := client.GetModel("custom-model")
+	require.True(t, exists)
+	assert.Equal(t, "custom-model", model.KServeModelName, "KServe model name should default to Name")
+	assert.Equal(t, "http://custom:8080", model.URL)
+
+	// Re-registering replaces the entry
+	client.RegisterModel(&ModelInfo{Name: "custom-model", KServeModelName: "custom-v2", URL: "http://custom:9090"})
+	model, _ = client.GetModel("custom-model")
+	assert.Equal(t, "custom-v2", model.KServeModelName)
+	assert.Equal(t, "http://custom:9090", model.URL)
+}
+
 func TestProxyClient_GetAllModels(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -758,6 +779,7 @@ func TestProxyClient_PredictFlexible_AnomalyResponse(t *testing.T) {
 		assert.Equal(t, "/v1/models/anomaly-detector:predict", r.URL.Path)
 		resp := map[string]interface{}{
 			"predictions":   []int{-1, 1, -1},
+			"scores":        []float64{-0.21, 0.08, -0.05},
 			"model_name":    "anomaly-detector",
 			"model_version": "v1",
 		}
@@ -798,6 +820,7 @@ func TestProxyClient_PredictFlexible_AnomalyResponse(t *testing.T) {
 	assert.Nil(t, result.ForecastResponse)
 
 	assert.Equal(t, []int{-1, 1, -1}, result.AnomalyResponse.Predictions)
+	assert.Equal(t, []float64{-0.21, 0.08, -0.05}, result.AnomalyResponse.Scores)
 	assert.Equal(t, "anomaly-detector", result.AnomalyResponse.ModelName)
 }
 
@@ -1356,3 +1379,120 @@ func TestProxyClient_AutoDetect_FlatFloatArray(t *testing.T) {
 	forecast := result.ForecastResponse.Predictions["forecast"]
 	assert.Equal(t, []float64{0.75, 0.82, 0.79}, forecast.Forecast)
 }
+
+func TestProxyClient_PredictFlexible_RegressionResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		predictions interface{}
+		expected    []float64
+	}{
+		{
+			name:        "array of arrays uses first instance",
+			predictions: [][]float64{{72.5, 64.0}, {10, 20}},
+			expected:    []float64{72.5, 64.0},
+		},
+		{
+			name:        "flat vector",
+			predictions: []float64{1, 0},
+			expected:    []float64{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"predictions":   tt.predictions,
+					"model_version": "3",
+				})
+			}))
+			defer server.Close()
+
+			log := logrus.New()
+			log.SetLevel(logrus.ErrorLevel)
+
+			client, err := NewProxyClient(ProxyConfig{
+				Namespace:        "test-ns",
+				RegressionModels: []string{"resource-regressor"},
+			}, log)
+			require.NoError(t, err)
+
+			client.models["resource-regressor"] = &ModelInfo{
+				Name:            "resource-regressor",
+				KServeModelName: "resource-regressor",
+				URL:             server.URL,
+			}
+
+			result, err := client.PredictFlexible(context.Background(), "resource-regressor", [][]float64{{1.0, 2.0}})
+
+			require.NoError(t, err)
+			assert.Equal(t, "regression", result.Type)
+			require.NotNil(t, result.RegressionResponse)
+			assert.Nil(t, result.ForecastResponse)
+			assert.Nil(t, result.AnomalyResponse)
+			assert.Equal(t, tt.expected, result.RegressionResponse.Outputs)
+			assert.Equal(t, "3", result.RegressionResponse.ModelVersion)
+		})
+	}
+}
+
+func TestProxyClient_PredictFlexible_RegressionResponse_Empty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"predictions": []}`))
+	}))
+	defer server.Close()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client, err := NewProxyClient(ProxyConfig{
+		Namespace:        "test-ns",
+		RegressionModels: []string{"resource-regressor"},
+	}, log)
+	require.NoError(t, err)
+
+	client.models["resource-regressor"] = &ModelInfo{
+		Name:            "resource-regressor",
+		KServeModelName: "resource-regressor",
+		URL:             server.URL,
+	}
+
+	_, err = client.PredictFlexible(context.Background(), "resource-regressor", [][]float64{{1.0}})
+	assert.Error(t, err)
+}
+
+// TestProxyClient_WithRequestTimeout verifies a context override replaces the client timeout
+func TestProxyClient_WithRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"predictions": [1]}`))
+	}))
+	defer server.Close()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	client, err := NewProxyClient(ProxyConfig{Namespace: "test-ns", Timeout: 20 * time.Millisecond}, log)
+	require.NoError(t, err)
+	client.models["slow-model"] = &ModelInfo{Name: "slow-model", KServeModelName: "slow-model", URL: server.URL}
+
+	_, err = client.PredictFlexible(context.Background(), "slow-model", [][]float64{{1.0}})
+	require.Error(t, err, "client timeout applies without an override")
+
+	_, err = client.PredictFlexible(WithRequestTimeout(context.Background(), 5*time.Second), "slow-model", [][]float64{{1.0}})
+	require.NoError(t, err)
+
+	_, err = client.PredictFlexible(WithRequestTimeout(context.Background(), 0), "slow-model", [][]float64{{1.0}})
+	require.Error(t, err, "a zero override keeps the client timeout")
+}
+
+func TestWithRequestTimeout_Clamped(t *testing.T) {
+	ctx := WithRequestTimeout(context.Background(), time.Hour)
+	assert.Equal(t, MaxRequestTimeout, ctx.Value(requestTimeoutKey{}))
+
+	ctx = WithRequestTimeout(context.Background(), -time.Second)
+	assert.Nil(t, ctx.Value(requestTimeoutKey{}))
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// bodyLimitKey holds the request body as it was before MaxBodySize capped it
+type bodyLimitKey struct{}
+
+type uncappedBody struct {
+	body  io.ReadCloser
+	limit int64
+}
+
+// MaxBodySize creates a middleware that caps request bodies at limit bytes using
+// http.MaxBytesReader. Reading past the limit fails with *http.MaxBytesError, which handlers
+// detect with IsBodyTooLarge. A non-positive limit disables the cap.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil && r.Body != http.NoBody {
+				r = r.WithContext(context.WithValue(r.Context(), bodyLimitKey{}, uncappedBody{body: r.Body, limit: limit}))
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RaiseBodyLimit creates a middleware for single routes that accept larger documents than the
+// MaxBodySize limit. It re-caps the body at limit when that exceeds the MaxBodySize limit, and
+// leaves requests MaxBodySize did not cap untouched. It must run before the body is read.
+func RaiseBodyLimit(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if original, ok := r.Context().Value(bodyLimitKey{}).(uncappedBody); ok && limit > original.limit {
+				r.Body = http.MaxBytesReader(w, original.body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IsBodyTooLarge reports whether err was caused by reading past the MaxBodySize limit
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	var readErr error
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("body within limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader("0123456789"))
+		MaxBodySize(10)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.NoError(t, readErr)
+	})
+
+	t.Run("body over limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader("0123456789A"))
+		MaxBodySize(10)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Error(t, readErr)
+		assert.True(t, IsBodyTooLarge(readErr))
+	})
+
+	t.Run("non-positive limit disables the cap", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(strings.Repeat("x", 1<<16)))
+		MaxBodySize(0)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.NoError(t, readErr)
+	})
+
+	t.Run("other errors are not body-size errors", func(t *testing.T) {
+		assert.False(t, IsBodyTooLarge(io.ErrUnexpectedEOF))
+	})
+}
+
+func TestRaiseBodyLimit(t *testing.T) {
+	var readErr error
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("raises the MaxBodySize limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(strings.Repeat("x", 20)))
+		MaxBodySize(10)(RaiseBodyLimit(20)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+		assert.NoError(t, readErr)
+
+		req = httptest.NewRequest("POST", "/test", strings.NewReader(strings.Repeat("x", 21)))
+		MaxBodySize(10)(RaiseBodyLimit(20)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+		assert.True(t, IsBodyTooLarge(readErr))
+	})
+
+	t.Run("never lowers the MaxBodySize limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(strings.Repeat("x", 20)))
+		MaxBodySize(30)(RaiseBodyLimit(10)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+		assert.NoError(t, readErr)
+	})
+
+	t.Run("uncapped requests stay uncapped", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/test", strings.NewReader(strings.Repeat("x", 1<<16)))
+		MaxBodySize(0)(RaiseBodyLimit(10)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+		assert.NoError(t, readErr)
+	})
+}
@@ -3,17 +3,19 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 )
 
 // CORSConfig holds CORS configuration
 type CORSConfig struct {
-	AllowedOrigins   []string
+	AllowedOrigins   []string // "*" allows any origin unless AllowCredentials is set
 	AllowedMethods   []string
 	AllowedHeaders   []string
 	ExposedHeaders   []string
 	AllowCredentials bool
-	MaxAge           int
+	MaxAge           int // Seconds browsers may cache a preflight result (0 = not sent)
 }
 
 // DefaultCORSConfig returns a permissive CORS configuration for development
@@ -21,60 +23,73 @@ func DefaultCORSConfig() *CORSConfig {
 	return &CORSConfig{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "If-None-Match", "Idempotency-Key"},
+		ExposedHeaders:   []string{"X-Request-ID", "ETag", "Retry-After"},
 		AllowCredentials: false,
 		MaxAge:           3600,
 	}
 }
 
-// CORS creates a middleware that handles CORS
+// CORS creates a middleware that handles CORS. Preflight requests (OPTIONS with
+// Access-Control-Request-Method) from an allowed origin are answered with 204 without reaching
+// next, so it must wrap the router rather than be added with Router.Use: mux answers OPTIONS
+// for POST-only routes with 405 before route middleware runs. Requests from other origins get
+// no CORS headers, which leaves browsers to enforce the same-origin policy.
 func CORS(config *CORSConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
-
-			// Check if origin is allowed
-			allowOrigin := ""
-			for _, allowed := range config.AllowedOrigins {
-				if allowed == "*" || allowed == origin {
-					allowOrigin = allowed
-					break
-				}
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
 			}
 
-			// Set CORS headers if origin is allowed
-			if allowOrigin != "" {
-				w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+			// The response depends on the request's origin unless every origin gets "*"
+			allowOrigin := config.allowedOrigin(origin)
+			if allowOrigin != "*" {
+				w.Header().Add("Vary", "Origin")
+			}
+			if allowOrigin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
 
-				if config.AllowCredentials {
-					w.Header().Set("Access-Control-Allow-Credentials", "true")
-				}
+			w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+			if config.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
 
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
 				if len(config.AllowedMethods) > 0 {
 					w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
 				}
-
 				if len(config.AllowedHeaders) > 0 {
 					w.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
 				}
-
-				if len(config.ExposedHeaders) > 0 {
-					w.Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
-				}
-
 				if config.MaxAge > 0 {
-					w.Header().Set("Access-Control-Max-Age", string(rune(config.MaxAge)))
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(config.MaxAge))
 				}
-			}
-
-			// Handle preflight requests
-			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
 
+			if len(config.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
+			}
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// allowedOrigin returns the Access-Control-Allow-Origin value for origin, or "" when it is not
+// allowed. "*" is ignored with credentials: browsers reject it, and echoing any origin would let
+// every site make credentialed requests.
+func (c *CORSConfig) allowedOrigin(origin string) string {
+	if slices.Contains(c.AllowedOrigins, origin) {
+		return origin
+	}
+	if !c.AllowCredentials && slices.Contains(c.AllowedOrigins, "*") {
+		return "*"
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSTestRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/predict", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}).Methods("POST")
+	return router
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	config := &CORSConfig{
+		AllowedOrigins: []string{"https://dashboard.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         600,
+	}
+	handler := CORS(config)(newCORSTestRouter())
+
+	t.Run("allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/predict", nil)
+		req.Header.Set("Origin", "https://dashboard.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+		assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	})
+
+	t.Run("other origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/predict", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code, "falls through to the router")
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	config := &CORSConfig{
+		AllowedOrigins: []string{"*"},
+		ExposedHeaders: []string{"ETag"},
+	}
+	handler := CORS(config)(newCORSTestRouter())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/predict", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "ETag", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"), "preflight-only header")
+	assert.Empty(t, w.Header().Get("Vary"))
+
+	// Same-origin and non-browser requests carry no Origin and get no CORS headers
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/predict", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	config := &CORSConfig{
+		AllowedOrigins:   []string{"*", "https://dashboard.example.com"},
+		AllowCredentials: true,
+	}
+	handler := CORS(config)(newCORSTestRouter())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/predict", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	// The wildcard does not extend credentialed access to unlisted origins
+	req.Header.Set("Origin", "https://other.example.com")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
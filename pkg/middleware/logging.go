@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -20,6 +21,61 @@ type contextKey string
 // RequestIDKey is the context key for storing request IDs
 const RequestIDKey contextKey = "request_id"
 
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or "" if none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+// EnsureRequestID returns a context carrying the request's ID, reusing the ID from the
+// context or X-Request-ID header when present and generating one otherwise.
+// The ID is echoed back in the X-Request-ID response header.
+func EnsureRequestID(w http.ResponseWriter, r *http.Request) (context.Context, string) {
+	ctx := r.Context()
+	requestID := RequestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = r.Header.Get(RequestIDHeader)
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	w.Header().Set(RequestIDHeader, requestID)
+	return WithRequestID(ctx, requestID), requestID
+}
+
+// RequestIDHook is a logrus hook that adds the request_id field to entries
+// logged with WithContext(ctx) when ctx carries a request ID
+type RequestIDHook struct{}
+
+// NewRequestIDHook creates a hook that correlates log entries by request ID
+func NewRequestIDHook() *RequestIDHook {
+	return &RequestIDHook{}
+}
+
+// Levels returns the log levels the hook fires for (all levels)
+func (h *RequestIDHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds the request ID from the entry's context to the entry's fields
+func (h *RequestIDHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if requestID := RequestIDFromContext(entry.Context); requestID != "" {
+		entry.Data["request_id"] = requestID
+	}
+	return nil
+}
+
 // responseWriter wraps http.ResponseWriter to capture status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -41,20 +97,22 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, nil
 }
 
+// Unwrap exposes the wrapped writer to http.ResponseController, so handlers can flush
+// streamed responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // RequestLogger creates a middleware that logs HTTP requests
 func RequestLogger(log *logrus.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
-			// Generate or extract request ID
-			requestID := r.Header.Get(RequestIDHeader)
-			if requestID == "" {
-				requestID = uuid.New().String()
-			}
-
-			// Add request ID to response headers
-			w.Header().Set(RequestIDHeader, requestID)
+			// Generate or extract request ID, echo it in the response headers and
+			// make it available to handlers through the request context
+			ctx, requestID := EnsureRequestID(w, r)
+			r = r.WithContext(ctx)
 
 			// Wrap response writer to capture status code
 			rw := &responseWriter{
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -30,6 +32,21 @@ func TestRequestLogger(t *testing.T) {
 	assert.NotEmpty(t, rr.Header().Get(RequestIDHeader))
 }
 
+func TestRequestLogger_Flush(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}\n"))
+		assert.NoError(t, http.NewResponseController(w).Flush())
+	})
+
+	rr := httptest.NewRecorder()
+	RequestLogger(log)(handler).ServeHTTP(rr, httptest.NewRequest("GET", "/test", http.NoBody))
+
+	assert.True(t, rr.Flushed, "streaming handlers can flush through the logging wrapper")
+}
+
 func TestRequestLogger_WithRequestID(t *testing.T) {
 	log := logrus.New()
 	log.SetLevel(logrus.ErrorLevel)
@@ -83,3 +100,62 @@ func TestRequestLogger_StatusCode(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestLogger_PropagatesRequestIDInContext(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	var ctxRequestID string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxRequestID = RequestIDFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/test", http.NoBody)
+	req.Header.Set(RequestIDHeader, "ctx-request-id")
+	rr := httptest.NewRecorder()
+
+	RequestLogger(log)(handler).ServeHTTP(rr, req)
+
+	assert.Equal(t, "ctx-request-id", ctxRequestID)
+}
+
+func TestEnsureRequestID(t *testing.T) {
+	t.Run("generates ID when missing", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		rr := httptest.NewRecorder()
+
+		ctx, requestID := EnsureRequestID(rr, req)
+
+		assert.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, RequestIDFromContext(ctx))
+		assert.Equal(t, requestID, rr.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("prefers ID already in context", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", http.NoBody)
+		req.Header.Set(RequestIDHeader, "header-id")
+		req = req.WithContext(WithRequestID(req.Context(), "context-id"))
+		rr := httptest.NewRecorder()
+
+		_, requestID := EnsureRequestID(rr, req)
+
+		assert.Equal(t, "context-id", requestID)
+		assert.Equal(t, "context-id", rr.Header().Get(RequestIDHeader))
+	})
+}
+
+func TestRequestIDHook(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetOutput(&buf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.AddHook(NewRequestIDHook())
+
+	log.WithContext(WithRequestID(context.Background(), "hook-id")).Info("with context")
+	assert.Contains(t, buf.String(), `"request_id":"hook-id"`)
+
+	buf.Reset()
+	log.WithContext(context.Background()).Info("without request ID")
+	log.Info("without context")
+	assert.NotContains(t, buf.String(), "request_id")
+}
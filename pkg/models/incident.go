@@ -2,7 +2,12 @@ package models
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // IncidentStatus represents the current state of an incident
@@ -40,8 +45,46 @@ type Incident struct {
 	UpdatedAt         time.Time         `json:"updated_at"`
 	ResolvedAt        *time.Time        `json:"resolved_at,omitempty"`
 	WorkflowID        string            `json:"workflow_id,omitempty"`
+
+	// RemediationOutcome is the final status of the most recent linked remediation workflow
+	RemediationOutcome WorkflowStatus `json:"remediation_outcome,omitempty"`
+
+	// RemediationAttempts and RemediationFailures count finished workflows linked to this incident
+	RemediationAttempts int `json:"remediation_attempts,omitempty"`
+	RemediationFailures int `json:"remediation_failures,omitempty"`
+
+	// IssueType identifies the kind of problem (e.g. pod_crash_loop) for recurrence tracking
+	IssueType string `json:"issue_type,omitempty"`
+
+	// OccurrenceCount is the total number of times this incident has been reported
+	OccurrenceCount int `json:"occurrence_count,omitempty"`
+
+	// RecentOccurrences holds sighting timestamps inside the current recurrence window
+	RecentOccurrences []time.Time `json:"recent_occurrences,omitempty"`
+
+	// EscalatedAtThreshold is the escalation threshold last applied in the current burst of
+	// recurrences; lower or equal thresholds do not escalate again until the burst ends
+	EscalatedAtThreshold int `json:"escalated_at_threshold,omitempty"`
+
+	// LastSeen is when the incident was last reported, either created or recurring. It is
+	// omitted while unset; omitempty would not drop a zero time.Time
+	LastSeen time.Time `json:"last_seen,omitzero"`
+
+	// StatusHistory records status and severity transitions in chronological order
+	StatusHistory []IncidentHistoryEntry `json:"status_history,omitempty"`
+}
+
+// IncidentHistoryEntry records a status or severity transition of an incident
+type IncidentHistoryEntry struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Status    IncidentStatus   `json:"status"`
+	Severity  IncidentSeverity `json:"severity"`
+	Reason    string           `json:"reason"`
 }
 
+// MaxIncidentLabels bounds the number of labels one incident can carry
+const MaxIncidentLabels = 32
+
 // ValidSeverities returns all valid severity values
 func ValidSeverities() []IncidentSeverity {
 	return []IncidentSeverity{
@@ -62,7 +105,42 @@ func IsValidSeverity(severity string) bool {
 	return false
 }
 
-// Validate checks if the incident is valid
+// ValidStatuses returns all valid status values
+func ValidStatuses() []IncidentStatus {
+	return []IncidentStatus{
+		IncidentStatusActive,
+		IncidentStatusResolved,
+		IncidentStatusCancelled,
+	}
+}
+
+// IsValidStatus checks if a status string is valid
+func IsValidStatus(status string) bool {
+	for _, s := range ValidStatuses() {
+		if string(s) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// NextSeverity returns the severity one level above the given one.
+// Critical (and unknown values) are returned unchanged.
+func NextSeverity(severity IncidentSeverity) IncidentSeverity {
+	switch severity {
+	case IncidentSeverityLow:
+		return IncidentSeverityMedium
+	case IncidentSeverityMedium:
+		return IncidentSeverityHigh
+	case IncidentSeverityHigh:
+		return IncidentSeverityCritical
+	default:
+		return severity
+	}
+}
+
+// Validate checks if the incident is valid. Labels are checked separately with ValidateLabels
+// on create and update, so incidents stored before label validation still load.
 func (i *Incident) Validate() error {
 	if i.Title == "" {
 		return fmt.Errorf("title is required")
@@ -88,6 +166,28 @@ func (i *Incident) Validate() error {
 	if len(i.Target) > 100 {
 		return fmt.Errorf("target must not exceed 100 characters")
 	}
+	// Status may be empty on create, where it defaults to active
+	if i.Status != "" && !IsValidStatus(string(i.Status)) {
+		return fmt.Errorf("status must be one of: active, resolved, cancelled")
+	}
+	return nil
+}
+
+// ValidateLabels checks labels against Kubernetes label syntax: keys are qualified names with
+// an optional DNS subdomain prefix ("team", "example.com/sla"), values are at most 63
+// alphanumerics, '-', '_' or '.' that start and end alphanumeric, or empty
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxIncidentLabels {
+		return fmt.Errorf("labels must not exceed %d entries", MaxIncidentLabels)
+	}
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("label key %q is invalid: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(labels[key]); len(errs) > 0 {
+			return fmt.Errorf("label %q value %q is invalid: %s", key, labels[key], strings.Join(errs, "; "))
+		}
+	}
 	return nil
 }
 
@@ -108,14 +208,51 @@ func (i *Incident) IsActive() bool {
 
 // Resolve marks the incident as resolved
 func (i *Incident) Resolve() {
+	i.ResolveWithReason("resolved")
+}
+
+// ResolveWithReason marks the incident as resolved, recording reason in its history
+func (i *Incident) ResolveWithReason(reason string) {
 	now := time.Now()
 	i.Status = IncidentStatusResolved
 	i.ResolvedAt = &now
 	i.UpdatedAt = now
+	i.RecordHistory(reason)
+}
+
+// LastSeenAt returns when the incident was last reported. Incidents stored before LastSeen
+// was tracked fall back to their latest recorded occurrence, then to CreatedAt.
+func (i *Incident) LastSeenAt() time.Time {
+	if !i.LastSeen.IsZero() {
+		return i.LastSeen
+	}
+	last := i.CreatedAt
+	for _, ts := range i.RecentOccurrences {
+		if ts.After(last) {
+			last = ts
+		}
+	}
+	return last
+}
+
+// AutoResolved returns true if the incident was closed by a successful remediation workflow
+func (i *Incident) AutoResolved() bool {
+	return i.Status == IncidentStatusResolved && i.RemediationOutcome == WorkflowStatusCompleted
 }
 
 // Cancel marks the incident as cancelled
 func (i *Incident) Cancel() {
 	i.Status = IncidentStatusCancelled
 	i.UpdatedAt = time.Now()
+	i.RecordHistory("cancelled")
+}
+
+// RecordHistory appends the incident's current status and severity to its history
+func (i *Incident) RecordHistory(reason string) {
+	i.StatusHistory = append(i.StatusHistory, IncidentHistoryEntry{
+		Timestamp: time.Now(),
+		Status:    i.Status,
+		Severity:  i.Severity,
+		Reason:    reason,
+	})
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// PredictionRecord is one served resource prediction, kept for trend and drift analysis
+type PredictionRecord struct {
+	ID         string    `json:"id"`
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// Request parameters, after scope inference and defaults
+	Scope           string `json:"scope"`
+	Target          string `json:"target"`
+	Namespace       string `json:"namespace,omitempty"`
+	Deployment      string `json:"deployment,omitempty"`
+	Pod             string `json:"pod,omitempty"`
+	Model           string `json:"model"`
+	Hour            int    `json:"hour"`
+	DayOfWeek       int    `json:"day_of_week"`
+	TargetTimestamp string `json:"target_timestamp,omitempty"`
+
+	// Result
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+	Confidence    float64 `json:"confidence"`
+	ModelVersion  string  `json:"model_version,omitempty"`
+}
+
+// Validate checks the fields every stored prediction must have
+func (r *PredictionRecord) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if r.RecordedAt.IsZero() {
+		return fmt.Errorf("recorded_at is required")
+	}
+	if r.Scope == "" {
+		return fmt.Errorf("scope is required")
+	}
+	if r.Model == "" {
+		return fmt.Errorf("model is required")
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package models
+
+import "github.com/KubeHeal/openshift-coordination-engine/pkg/api/types"
+
+// RecommendationAck suppresses a recommendation until ExpiresAt. It is part of the
+// recommendations API, so it is defined with the other wire types.
+type RecommendationAck = types.RecommendationAck